@@ -4,9 +4,7 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
 	"log"
 	"os"
 	"strings"
@@ -38,24 +36,17 @@ func main() {
 		}
 	}()
 
-	// Prepare to receive page lifecycle events from the browser.
-	ch, err := devtools.SubscribeEvent(ctx, "Page.lifecycleEvent")
-	if err != nil {
-		log.Fatalf("event subscription error: %v", err)
-	}
-	defer close(ch)
-
 	// Interact with the browsing session.
-	if err := navigate(ctx, ch); err != nil {
+	if err := navigate(ctx); err != nil {
 		log.Fatalf("navigation error: %v", err)
 	}
-	if err := search(ctx, ch); err != nil {
+	if err := search(ctx); err != nil {
 		log.Fatalf("failed to type search query: %v", err)
 	}
-	if err := imageResults(ctx, ch); err != nil {
+	if err := imageResults(ctx); err != nil {
 		log.Fatalf("failed to switch to image results: %v", err)
 	}
-	if err := scrollDown(ctx, ch); err != nil {
+	if err := scrollDown(ctx); err != nil {
 		log.Fatalf("failed to scroll down: %v", err)
 	}
 	if err := lastResult(ctx); err != nil {
@@ -64,38 +55,21 @@ func main() {
 }
 
 // Navigate to Google Search.
-func navigate(ctx context.Context, ch <-chan *devtools.Message) error {
+func navigate(ctx context.Context) error {
 	nav := page.NewNavigate("https://google.com/webhp?hl=en&pws=0")
 	if _, err := nav.Do(ctx); err != nil {
 		return err
 	}
-	return waitUntilStable(ch)
+	return waitUntilStable(ctx)
 }
 
 // Wait until there are no page lifecycle events for 2 seconds.
-func waitUntilStable(ch <-chan *devtools.Message) error {
-	d := 2 * time.Second
-	t := time.NewTimer(d)
-	for {
-		select {
-		case msg := <-ch:
-			e := &page.LifecycleEvent{}
-			if err := json.Unmarshal(msg.Params, e); err != nil {
-				return fmt.Errorf("JSON event parsing error: %v", err)
-			}
-			if !t.Stop() {
-				<-t.C
-			}
-			t.Reset(d)
-
-		case <-t.C:
-			return nil
-		}
-	}
+func waitUntilStable(ctx context.Context) error {
+	return page.WaitForStable(ctx, page.QuietPeriod(2*time.Second))
 }
 
 // Type the search query "kittens", and press the Enter key.
-func search(ctx context.Context, ch <-chan *devtools.Message) error {
+func search(ctx context.Context) error {
 	down := input.NewDispatchKeyEvent("keyDown")
 	up := input.NewDispatchKeyEvent("keyUp")
 	for _, key := range "kittens\r" {
@@ -111,11 +85,11 @@ func search(ctx context.Context, ch <-chan *devtools.Message) error {
 		}
 		time.Sleep(50 * time.Millisecond)
 	}
-	return waitUntilStable(ch)
+	return waitUntilStable(ctx)
 }
 
 // Click the "Images" tab to show image search results.
-func imageResults(ctx context.Context, ch <-chan *devtools.Message) error {
+func imageResults(ctx context.Context) error {
 	doc, err := dom.NewGetDocument().Do(ctx)
 	if err != nil {
 		return err
@@ -151,11 +125,11 @@ func imageResults(ctx context.Context, ch <-chan *devtools.Message) error {
 	if !clicked {
 		return errors.New("image search results tab not found")
 	}
-	return waitUntilStable(ch)
+	return waitUntilStable(ctx)
 }
 
 // Scroll down to the bottom of the page.
-func scrollDown(ctx context.Context, ch <-chan *devtools.Message) error {
+func scrollDown(ctx context.Context) error {
 	doc, err := dom.NewGetDocument().Do(ctx)
 	if err != nil {
 		return err
@@ -191,7 +165,7 @@ func scrollDown(ctx context.Context, ch <-chan *devtools.Message) error {
 			bottomIsVisible++
 		}
 	}
-	return waitUntilStable(ch)
+	return waitUntilStable(ctx)
 }
 
 // Print the title and URL of the last result.
@@ -31,17 +31,21 @@ func main() {
 	}()
 
 	// Prepare to receive a couple of events from the browser.
-	fnEventChan, err := devtools.SubscribeEvent(ctx, "Page.frameNavigated")
+	// The subscriptions own their channels, and close them when they end.
+	fnEvents, err := devtools.Subscribe(ctx, "Page.frameNavigated")
 	if err != nil {
 		log.Fatalf("event subscription error: %v", err)
 	}
-	defer close(fnEventChan)
+	defer fnEvents.Unsubscribe()
 
-	leEventChan, err := devtools.SubscribeEvent(ctx, "Page.lifecycleEvent")
+	// Lifecycle events are only a stability signal, so it's OK to drop
+	// some of them, rather than delay other messages from the browser.
+	leEvents, err := devtools.Subscribe(ctx, "Page.lifecycleEvent",
+		devtools.Overflow(devtools.OverflowDropOldest))
 	if err != nil {
 		log.Fatalf("event subscription error: %v", err)
 	}
-	defer close(leEventChan)
+	defer leEvents.Unsubscribe()
 
 	// Navigate to Amazon's homepage.
 	n := page.NewNavigate("https://amazon.com/")
@@ -49,7 +53,6 @@ func main() {
 	if err != nil {
 		log.Fatalf("navigation command error: %v", err)
 	}
-	defer close(navCmdChan)
 
 	// Wait for multiple conditions to determine that page loading as really done
 	// (per https://tour.golang.org/concurrency/5, the order of the conditions
@@ -69,7 +72,7 @@ func main() {
 			gotNavResponse = true
 
 		// Condition 2: we received a `Page.frameNavigated` event.
-		case msg := <-fnEventChan:
+		case msg := <-fnEvents.C:
 			fn := &page.FrameNavigated{}
 			if err := json.Unmarshal(msg.Params, fn); err != nil {
 				log.Fatalf("JSON event parsing error: %v", err)
@@ -78,7 +81,7 @@ func main() {
 			gotNavEvent = true
 
 		// Condition 3: no page lifecycle events for 3 seconds.
-		case msg := <-leEventChan:
+		case msg := <-leEvents.C:
 			e := &page.LifecycleEvent{}
 			if err := json.Unmarshal(msg.Params, e); err != nil {
 				log.Fatalf("JSON event parsing error: %v", err)
@@ -32,11 +32,11 @@ func main() {
 
 	// Prepare to receive navigation events from the browser (before calling the
 	// navigation command, so we won't lose any events due to a race condition).
-	ch, err := devtools.SubscribeEvent(ctx, "Page.frameNavigated")
+	sub, err := devtools.Subscribe(ctx, "Page.frameNavigated")
 	if err != nil {
 		log.Fatalf("event subscription error: %v", err)
 	}
-	defer close(ch)
+	defer sub.Unsubscribe()
 
 	// Navigate to Amazon's homepage.
 	n := page.NewNavigate("https://amazon.com/")
@@ -45,7 +45,7 @@ func main() {
 	}
 
 	// Wait until the page is really loaded (though not necessarily stable).
-	msg := <-ch
+	msg := <-sub.C
 	e := &page.FrameNavigated{}
 	if err := json.Unmarshal(msg.Params, e); err != nil {
 		log.Fatalf("JSON event parsing error: %v", err)
@@ -33,9 +33,22 @@ func generateCommands(d Domain) string {
 		generateType(b, t, d.Domain, "method", c.Redirect)
 
 		// Constructor function.
+		var required, optional []Property
+		for _, p := range c.Parameters {
+			if p.Optional {
+				optional = append(optional, p)
+			} else {
+				required = append(required, p)
+			}
+		}
 		fmt.Fprintf(b, "\n// New%s constructs a new %s struct instance, with\n", cmd, cmd)
 		fmt.Fprintln(b, "// all (but only) the required parameters. Optional parameters")
-		fmt.Fprintln(b, "// may be added using the builder-like methods below.")
+		if len(optional) == 0 {
+			fmt.Fprintln(b, "// may be added using the builder-like methods below.")
+		} else {
+			fmt.Fprintln(b, "// may be added using the builder-like methods below, or with")
+			fmt.Fprintf(b, "// functional options (see `%sOption`).\n", cmd)
+		}
 		fmt.Fprintf(b, "//\n// %s/%s/#method-%s\n", cdpURL, d.Domain, c.Name)
 		if c.Deprecated || c.Experimental || c.Redirect != nil {
 			fmt.Fprintln(b, "//")
@@ -52,24 +65,26 @@ func generateCommands(d Domain) string {
 		fmt.Fprintf(b, "func New%s(", cmd)
 
 		// Required parameters (in the constructor).
-		var required, optional []Property
-		for _, p := range c.Parameters {
-			if p.Optional {
-				optional = append(optional, p)
-			} else {
-				required = append(required, p)
-			}
-		}
 		for i, p := range required {
 			generateRequiredParameter(b, p, d.Domain)
 			if i+1 < len(required) {
 				fmt.Fprint(b, ", ")
 			}
 		}
+		if len(optional) > 0 {
+			if len(required) > 0 {
+				fmt.Fprint(b, ", ")
+			}
+			fmt.Fprintf(b, "opts ...%sOption", cmd)
+		}
 
 		// Constructor method body.
 		fmt.Fprintf(b, ") *%s {\n", cmd)
-		fmt.Fprintf(b, "\treturn &%s{", cmd)
+		if len(optional) == 0 {
+			fmt.Fprintf(b, "\treturn &%s{", cmd)
+		} else {
+			fmt.Fprintf(b, "\tc := &%s{", cmd)
+		}
 		if len(required) > 0 {
 			fmt.Fprint(b, "\n")
 			for _, p := range required {
@@ -85,11 +100,27 @@ func generateCommands(d Domain) string {
 			}
 			fmt.Fprint(b, "\t")
 		}
-		fmt.Fprintln(b, "}\n}")
+		if len(optional) == 0 {
+			fmt.Fprintln(b, "}\n}")
+		} else {
+			fmt.Fprintln(b, "}")
+			fmt.Fprintln(b, "\tfor _, opt := range opts {")
+			fmt.Fprintln(b, "\t\topt(c)")
+			fmt.Fprintln(b, "\t}")
+			fmt.Fprintln(b, "\treturn c")
+			fmt.Fprintln(b, "}")
 
-		// Optional parameters (as builder-like methods).
+			// Functional options type.
+			fmt.Fprintf(b, "\n// %sOption is a functional option for the optional\n", cmd)
+			fmt.Fprintf(b, "// parameters of the %s CDP command, see the\n", cmd)
+			fmt.Fprintf(b, "// New%s constructor. Options are applied in order.\n", cmd)
+			fmt.Fprintf(b, "type %sOption = func(*%s)\n", cmd, cmd)
+		}
+
+		// Optional parameters (as builder-like methods and functional options).
 		for _, p := range optional {
 			generateOptionalParameter(b, d.Domain, cmd, p)
+			generateOption(b, d.Domain, cmd, p)
 		}
 
 		// Return types.
@@ -108,6 +139,11 @@ func generateCommands(d Domain) string {
 		}
 
 		// Do() method to tie all of the above together.
+		cacheable := isCacheable(c.Name, c.Parameters, d.Domain)
+		marshal := "json.Marshal(t)"
+		if cacheable {
+			marshal = "t.marshal()"
+		}
 		fmt.Fprintf(b, "\n// Do sends the %s CDP command to a browser,\n", cmd)
 		fmt.Fprintln(b, "// and returns the browser's response.")
 
@@ -122,7 +158,7 @@ func generateCommands(d Domain) string {
 			fmt.Fprint(b, "\tm, err := devtools.SendAndWait(ctx, ")
 			fmt.Fprintf(b, "\"%s.%s\", nil)\n", d.Domain, c.Name)
 		} else {
-			fmt.Fprintln(b, "\tb, err := json.Marshal(t)")
+			fmt.Fprintf(b, "\tb, err := %s\n", marshal)
 			fmt.Fprintln(b, "\tif err != nil {")
 			if len(c.Returns) == 0 {
 				fmt.Fprintln(b, "\t\treturn err")
@@ -159,7 +195,7 @@ func generateCommands(d Domain) string {
 				fmt.Fprint(b, "\treturn devtools.Send(ctx, ")
 				fmt.Fprintf(b, "\"%s.%s\", nil)\n", d.Domain, c.Name)
 			} else {
-				fmt.Fprintln(b, "\tb, err := json.Marshal(t)")
+				fmt.Fprintf(b, "\tb, err := %s\n", marshal)
 				fmt.Fprintln(b, "\tif err != nil {")
 				fmt.Fprintln(b, "\t\treturn nil, err")
 				fmt.Fprintln(b, "\t}")
@@ -203,10 +239,75 @@ func generateCommands(d Domain) string {
 			fmt.Fprintln(b, "\treturn result, nil")
 		}
 		fmt.Fprintln(b, "}")
+
+		if cacheable {
+			generateMarshal(b, cmd, c.Parameters, d.Domain)
+		}
 	}
 	return b.String()
 }
 
+// Generate a method which caches the JSON encoding of a CDP command's
+// parameters, to reduce allocations when the same command is sent
+// repeatedly in hot loops (e.g. mouse moves, screencast frame acks).
+func generateMarshal(b *strings.Builder, cmd string, params []Property, domain string) {
+	cache := strings.ToLower(cmd[:1]) + cmd[1:] + "Cache"
+	fmt.Fprintf(b, "\n// Cached JSON encoding of the %s CDP command's parameters.\n", cmd)
+	fmt.Fprintf(b, "type %s struct {\n", cache)
+	fmt.Fprintf(b, "\tparams %s\n", cmd)
+	fmt.Fprintln(b, "\tb      []byte")
+	fmt.Fprintln(b, "}")
+
+	// Pointer parameters are compared by the values they point to, and the
+	// cache keeps its own copies of these values, so changes to the values
+	// behind the caller's pointers aren't missed.
+	var pointers []string
+	fmt.Fprintln(b, "\n// Report whether the cached parameters are equal to the given ones.")
+	fmt.Fprintf(b, "func (c *%s) equal(t *%s) bool {\n", cache, cmd)
+	fmt.Fprint(b, "\treturn ")
+	for i, p := range params {
+		name := adjust(p.Name)
+		if i > 0 {
+			fmt.Fprint(b, " &&\n\t\t")
+		}
+		if isPointerProperty(p, domain) {
+			pointers = append(pointers, name)
+			fmt.Fprintf(b, "(c.params.%s == nil) == (t.%s == nil) &&\n", name, name)
+			fmt.Fprintf(b, "\t\t(c.params.%s == nil || *c.params.%s == *t.%s)", name, name, name)
+		} else {
+			fmt.Fprintf(b, "c.params.%s == t.%s", name, name)
+		}
+	}
+	fmt.Fprintln(b, "\n}")
+
+	fmt.Fprintln(b, "\n// Return the JSON encoding of the parameters, and cache it for")
+	fmt.Fprintln(b, "// subsequent calls, as long as the parameters don't change (whether")
+	fmt.Fprintln(b, "// they're changed by the builder-like methods above, or directly).")
+	fmt.Fprintf(b, "func (t *%s) marshal() ([]byte, error) {\n", cmd)
+	fmt.Fprintf(b, "\tif c, ok := t.cache.Load().(*%s); ok && c.equal(t) {\n", cache)
+	fmt.Fprintln(b, "\t\treturn c.b, nil")
+	fmt.Fprintln(b, "\t}")
+	fmt.Fprintf(b, "\tc := &%s{params: %s{\n", cache, cmd)
+	for _, p := range params {
+		fmt.Fprintf(b, "\t\t%s: t.%s,\n", adjust(p.Name), adjust(p.Name))
+	}
+	fmt.Fprintln(b, "\t}}")
+	for _, name := range pointers {
+		fmt.Fprintf(b, "\tif t.%s != nil {\n", name)
+		fmt.Fprintf(b, "\t\tv := *t.%s\n", name)
+		fmt.Fprintf(b, "\t\tc.params.%s = &v\n", name)
+		fmt.Fprintln(b, "\t}")
+	}
+	fmt.Fprintln(b, "\tb, err := json.Marshal(&c.params)")
+	fmt.Fprintln(b, "\tif err != nil {")
+	fmt.Fprintln(b, "\t\treturn nil, err")
+	fmt.Fprintln(b, "\t}")
+	fmt.Fprintln(b, "\tc.b = b")
+	fmt.Fprintln(b, "\tt.cache.Store(c)")
+	fmt.Fprintln(b, "\treturn b, nil")
+	fmt.Fprintln(b, "}")
+}
+
 func generateRequiredParameter(b *strings.Builder, p Property, domain string) {
 	// Name.
 	if p.Name == "range" || p.Name == "type" {
@@ -275,19 +376,46 @@ func generateOptionalParameter(b *strings.Builder, domain, cmd string, p Propert
 
 	// Method body.
 	fmt.Fprintf(b, "\tt.%s = ", adjust(p.Name))
+	t := ""
 	if p.Type != nil {
-		fmt.Fprintln(b, "v") // By value - built-in JSON types.
+		t = transformType(*p.Type, p.Items) // Built-in JSON types.
+	} else {
+		t = strings.ReplaceAll(adjust(*p.Ref), strings.ToLower(domain)+".", "")
+		if a, ok := aliases[t]; ok {
+			t = a // De-alias built-in data types (https://crbug.com/1193242).
+		}
+	}
+	if isPointer(p, t) {
+		fmt.Fprintln(b, "&v") // By reference - CDP types, and meaningful zero values.
+	} else {
+		fmt.Fprintln(b, "v") // By value - (aliased) built-in JSON types.
+	}
+	fmt.Fprintln(b, "\treturn t")
+	fmt.Fprintln(b, "}")
+}
+
+// Generate a functional option for an optional parameter, which wraps its
+// builder-like method (see the generateOptionalParameter function). The
+// command name is a prefix, because parameter names in different commands
+// of the same domain are not unique (e.g. "format" in Page.captureScreenshot
+// and Page.startScreencast).
+func generateOption(b *strings.Builder, domain, cmd string, p Property) {
+	name := adjust(p.Name)
+	fmt.Fprintf(b, "\n// %sWith%s is a functional option which sets the value of the\n", cmd, name)
+	fmt.Fprintf(b, "// optional parameter `%s` in the %s CDP command, see Set%s.\n", p.Name, cmd, name)
+	fmt.Fprintf(b, "func %sWith%s(v ", cmd, name)
+	if p.Type != nil {
+		fmt.Fprint(b, transformType(*p.Type, p.Items))
 	} else {
 		r := strings.ReplaceAll(adjust(*p.Ref), strings.ToLower(domain)+".", "")
 		if a, ok := aliases[r]; ok {
 			r = a // De-alias built-in data types (https://crbug.com/1193242).
 		}
-		if r == "int64" || r == "float64" || r == "string" {
-			fmt.Fprintln(b, "v") // By value - aliased built-in JSON types.
-		} else {
-			fmt.Fprintln(b, "&v") // By reference - CDP types.
-		}
+		fmt.Fprint(b, discardRepetitivePrefix(r, domain))
 	}
-	fmt.Fprintln(b, "\treturn t")
+	fmt.Fprintf(b, ") %sOption {\n", cmd)
+	fmt.Fprintf(b, "\treturn func(c *%s) {\n", cmd)
+	fmt.Fprintf(b, "\t\tc.Set%s(v)\n", name)
+	fmt.Fprintln(b, "\t}")
 	fmt.Fprintln(b, "}")
 }
@@ -0,0 +1,211 @@
+package cdpgen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateOptionalParameter(t *testing.T) {
+	aliases["network.TimeSinceEpoch"] = "devtools.TimeSinceEpoch"
+	t.Cleanup(func() { delete(aliases, "network.TimeSinceEpoch") })
+
+	tests := []struct {
+		name   string
+		domain string
+		cmd    string
+		p      Property
+		want   string
+	}{
+		{
+			name:   "zero_default",
+			domain: "Page",
+			cmd:    "CaptureScreenshot",
+			p: Property{Name: "captureBeyondViewport", Optional: true, Experimental: true, Type: str("boolean"),
+				Description: str("Capture the screenshot beyond the viewport. Defaults to false.")},
+			want: `
+// SetCaptureBeyondViewport adds or modifies the value of the optional
+// parameter ` + "`captureBeyondViewport`" + ` in the CaptureScreenshot CDP command.
+//
+// Capture the screenshot beyond the viewport. Defaults to false.
+//
+// This CDP parameter is experimental.
+func (t *CaptureScreenshot) SetCaptureBeyondViewport(v bool) *CaptureScreenshot {
+	t.CaptureBeyondViewport = v
+	return t
+}
+`,
+		},
+		{
+			name:   "non_zero_default",
+			domain: "Page",
+			cmd:    "CaptureScreenshot",
+			p: Property{Name: "fromSurface", Optional: true, Type: str("boolean"),
+				Description: str("Capture the screenshot from the surface, rather than the view.\nDefaults to true.")},
+			want: `
+// SetFromSurface adds or modifies the value of the optional
+// parameter ` + "`fromSurface`" + ` in the CaptureScreenshot CDP command.
+//
+// Capture the screenshot from the surface, rather than the view.
+// Defaults to true.
+func (t *CaptureScreenshot) SetFromSurface(v bool) *CaptureScreenshot {
+	t.FromSurface = &v
+	return t
+}
+`,
+		},
+		{
+			name:   "cdp_type",
+			domain: "Input",
+			cmd:    "DispatchMouseEvent",
+			p:      Property{Name: "button", Optional: true, Ref: str("MouseButton"), Description: str(`Mouse button (default: "none").`)},
+			want: `
+// SetButton adds or modifies the value of the optional
+// parameter ` + "`button`" + ` in the DispatchMouseEvent CDP command.
+//
+// Mouse button (default: "none").
+func (t *DispatchMouseEvent) SetButton(v MouseButton) *DispatchMouseEvent {
+	t.Button = &v
+	return t
+}
+`,
+		},
+		{
+			name:   "other_domain_cdp_type",
+			domain: "Overlay",
+			cmd:    "HighlightRect",
+			p:      Property{Name: "color", Optional: true, Ref: str("DOM.RGBA")},
+			want: `
+// SetColor adds or modifies the value of the optional
+// parameter ` + "`color`" + ` in the HighlightRect CDP command.
+func (t *HighlightRect) SetColor(v dom.RGBA) *HighlightRect {
+	t.Color = &v
+	return t
+}
+`,
+		},
+		{
+			name:   "aliased_type",
+			domain: "Input",
+			cmd:    "DispatchMouseEvent",
+			p:      Property{Name: "timestamp", Optional: true, Deprecated: true, Ref: str("Network.TimeSinceEpoch")},
+			want: `
+// SetTimestamp adds or modifies the value of the optional
+// parameter ` + "`timestamp`" + ` in the DispatchMouseEvent CDP command.
+//
+// This CDP parameter is deprecated.
+func (t *DispatchMouseEvent) SetTimestamp(v devtools.TimeSinceEpoch) *DispatchMouseEvent {
+	t.Timestamp = v
+	return t
+}
+`,
+		},
+		{
+			name:   "array",
+			domain: "Network",
+			cmd:    "GetCookies",
+			p:      Property{Name: "urls", Optional: true, Type: str("array"), Items: map[string]string{"type": "string"}},
+			want: `
+// SetURLs adds or modifies the value of the optional
+// parameter ` + "`urls`" + ` in the GetCookies CDP command.
+func (t *GetCookies) SetURLs(v []string) *GetCookies {
+	t.URLs = v
+	return t
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(strings.Builder)
+			generateOptionalParameter(b, tt.domain, tt.cmd, tt.p)
+			if diff := cmp.Diff(tt.want, b.String()); diff != "" {
+				t.Errorf("generateOptionalParameter() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateOption(t *testing.T) {
+	aliases["network.TimeSinceEpoch"] = "devtools.TimeSinceEpoch"
+	t.Cleanup(func() { delete(aliases, "network.TimeSinceEpoch") })
+
+	tests := []struct {
+		name   string
+		domain string
+		cmd    string
+		p      Property
+		want   string
+	}{
+		{
+			name:   "builtin_type",
+			domain: "Page",
+			cmd:    "CaptureScreenshot",
+			p:      Property{Name: "fromSurface", Optional: true, Type: str("boolean")},
+			want: `
+// CaptureScreenshotWithFromSurface is a functional option which sets the value of the
+// optional parameter ` + "`fromSurface`" + ` in the CaptureScreenshot CDP command, see SetFromSurface.
+func CaptureScreenshotWithFromSurface(v bool) CaptureScreenshotOption {
+	return func(c *CaptureScreenshot) {
+		c.SetFromSurface(v)
+	}
+}
+`,
+		},
+		{
+			name:   "same_domain_cdp_type",
+			domain: "Input",
+			cmd:    "DispatchMouseEvent",
+			p:      Property{Name: "button", Optional: true, Ref: str("Input.MouseButton")},
+			want: `
+// DispatchMouseEventWithButton is a functional option which sets the value of the
+// optional parameter ` + "`button`" + ` in the DispatchMouseEvent CDP command, see SetButton.
+func DispatchMouseEventWithButton(v MouseButton) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetButton(v)
+	}
+}
+`,
+		},
+		{
+			name:   "other_domain_cdp_type",
+			domain: "Overlay",
+			cmd:    "HighlightRect",
+			p:      Property{Name: "color", Optional: true, Ref: str("DOM.RGBA")},
+			want: `
+// HighlightRectWithColor is a functional option which sets the value of the
+// optional parameter ` + "`color`" + ` in the HighlightRect CDP command, see SetColor.
+func HighlightRectWithColor(v dom.RGBA) HighlightRectOption {
+	return func(c *HighlightRect) {
+		c.SetColor(v)
+	}
+}
+`,
+		},
+		{
+			name:   "aliased_type",
+			domain: "Input",
+			cmd:    "DispatchMouseEvent",
+			p:      Property{Name: "timestamp", Optional: true, Ref: str("Network.TimeSinceEpoch")},
+			want: `
+// DispatchMouseEventWithTimestamp is a functional option which sets the value of the
+// optional parameter ` + "`timestamp`" + ` in the DispatchMouseEvent CDP command, see SetTimestamp.
+func DispatchMouseEventWithTimestamp(v devtools.TimeSinceEpoch) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetTimestamp(v)
+	}
+}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := new(strings.Builder)
+			generateOption(b, tt.domain, tt.cmd, tt.p)
+			if diff := cmp.Diff(tt.want, b.String()); diff != "" {
+				t.Errorf("generateOption() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,21 +2,49 @@ package cdpgen
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
 // For de-aliasing of built-in data types.
 var aliases = make(map[string]string)
 
+// CDP timestamp types, which are defined in the devtools package (with
+// conversions to Go times and durations), and aliased in domain packages.
+var timeTypes = map[string]string{
+	"MonotonicTime":  "devtools.MonotonicTime",
+	"TimeSinceEpoch": "devtools.TimeSinceEpoch",
+}
+
+// CDP object types without properties, whose values have a known type, by
+// their fully-qualified ID. They're generated as Go maps instead of empty
+// structs, so their content isn't discarded when parsing JSON.
+var mapTypes = map[string]string{
+	"Network.Headers": "map[string]string",
+}
+
+// CDP types which are Go scalars (i.e. not arrays and not objects), by
+// their fully-qualified ID, for checking whether command parameters can be
+// cached and compared with "==".
+var scalarTypes = make(map[string]bool)
+
 func generateTypes(d Domain) string {
 	b := new(strings.Builder)
 	fmt.Fprintf(b, "package %s\n", strings.ToLower(d.Domain))
 
 	for _, t := range d.Types {
+		if t.Type == "string" || t.Type == "integer" || t.Type == "number" || t.Type == "boolean" {
+			scalarTypes[d.Domain+"."+t.ID] = true
+		}
 		if t.Type != "array" && t.Type != "object" && len(t.Enum) == 0 {
-			aliases[adjust(t.ID)] = transformType(t.Type, nil)
+			goType := transformType(t.Type, nil)
+			if tt, ok := timeTypes[t.ID]; ok {
+				goType = tt
+			}
+			aliases[adjust(t.ID)] = goType
 			key := strings.ToLower(d.Domain) + "." + adjust(t.ID)
-			aliases[key] = transformType(t.Type, nil)
+			aliases[key] = goType
 		}
 		description := discardRepetitivePrefix(adjust(t.ID), d.Domain)
 		description += " data type."
@@ -62,6 +90,10 @@ func generateType(b *strings.Builder, t Type, domain, usage string, redirect *st
 	id := discardRepetitivePrefix(adjust(t.ID), domain)
 	switch t.Type {
 	case "object":
+		if mt, ok := mapTypes[domain+"."+t.ID]; ok && usage == "type" {
+			fmt.Fprintf(b, "type %s %s\n", id, mt)
+			break
+		}
 		fmt.Fprintf(b, "type %s struct", id)
 		if len(t.Properties) == 0 {
 			fmt.Fprint(b, "{")
@@ -75,6 +107,10 @@ func generateType(b *strings.Builder, t Type, domain, usage string, redirect *st
 			}
 			generateProperty(b, p, pUsage, domain)
 		}
+		if usage == "method" && isCacheable(t.ID, t.Properties, domain) {
+			fmt.Fprintln(b, "\n\t// Cached JSON encoding of the parameters, see the `marshal` method.")
+			fmt.Fprintln(b, "\tcache atomic.Value")
+		}
 		fmt.Fprintln(b, "}")
 	case "string":
 		fmt.Fprintf(b, "type %s string\n", id)
@@ -92,7 +128,11 @@ func generateType(b *strings.Builder, t Type, domain, usage string, redirect *st
 			fmt.Fprintln(b, "}")
 		}
 	default:
-		fmt.Fprintf(b, "type %s %s\n", id, transformType(t.Type, t.Items))
+		if tt, ok := timeTypes[t.ID]; ok && usage == "type" {
+			fmt.Fprintf(b, "type %s = %s\n", id, tt)
+		} else {
+			fmt.Fprintf(b, "type %s %s\n", id, transformType(t.Type, t.Items))
+		}
 	}
 }
 
@@ -122,13 +162,16 @@ func generateProperty(b *strings.Builder, p Property, usage, domain string) {
 				t = "[]" + a // De-alias built-in data types (https://crbug.com/1193242).
 			}
 		}
+		if isPointer(p, t) {
+			fmt.Fprint(b, "*")
+		}
 		fmt.Fprint(b, discardRepetitivePrefix(t, domain))
 	} else {
 		r := strings.ReplaceAll(adjust(*p.Ref), strings.ToLower(domain)+".", "")
 		if a, ok := aliases[r]; ok {
 			r = a // De-alias built-in data types (https://crbug.com/1193242).
 		}
-		if p.Optional && r != "int64" && r != "float64" && r != "string" {
+		if isPointer(p, r) {
 			fmt.Fprint(b, "*")
 		}
 		fmt.Fprint(b, discardRepetitivePrefix(r, domain))
@@ -141,3 +184,93 @@ func generateProperty(b *strings.Builder, p Property, usage, domain string) {
 	}
 	fmt.Fprint(b, "\"`\n")
 }
+
+// CDP commands which are sent repeatedly in hot loops, with the same
+// parameters or a few changes, so caching their JSON encoding pays off.
+var hotCommands = map[string]bool{
+	"Input.dispatchMouseEvent":         true,
+	"Input.emulateTouchFromMouseEvent": true,
+	"Page.screencastFrameAck":          true,
+}
+
+// Report whether the JSON encoding of a CDP command with the given parameters
+// should be cached: only for hot commands (see `hotCommands`), and only if all
+// the parameters are scalars, or pointers to scalars, so they (or the values
+// they point to) are comparable with "==" (see the generated `marshal` methods).
+func isCacheable(name string, params []Property, domain string) bool {
+	if !hotCommands[domain+"."+name] {
+		return false
+	}
+	for _, p := range params {
+		if p.Type != nil {
+			switch *p.Type {
+			case "string", "integer", "number", "boolean":
+				continue
+			}
+			return false
+		}
+		ref := *p.Ref
+		if !strings.Contains(ref, ".") {
+			ref = domain + "." + ref
+		}
+		if !scalarTypes[ref] {
+			return false
+		}
+	}
+	return len(params) > 0
+}
+
+// Report whether the Go type of a property is a pointer, see `isPointer`.
+func isPointerProperty(p Property, domain string) bool {
+	if p.Type != nil {
+		return isPointer(p, transformType(*p.Type, p.Items))
+	}
+	r := strings.ReplaceAll(adjust(*p.Ref), strings.ToLower(domain)+".", "")
+	if a, ok := aliases[r]; ok {
+		r = a
+	}
+	return isPointer(p, r)
+}
+
+// Default values in CDP descriptions, e.g. "Defaults to true", "(default: 1)".
+var defaultValue = regexp.MustCompile(`(?i)\bdefaults?(?: value)?(?: is| to|:)?\s+['"]?(?:(true|false|one)\b|(-?\d+(?:\.\d+)?))|\b(true) by default\b`)
+
+// Report whether the Go type of a property should be a pointer: optional CDP
+// types, and optional booleans and numbers whose zero value is meaningful,
+// i.e. their default value isn't zero. Otherwise, "omitempty" would drop
+// explicit zero values (e.g. `SetFromSurface(false)`), and the browser would
+// use the default value instead.
+func isPointer(p Property, goType string) bool {
+	if !p.Optional {
+		return false
+	}
+	switch goType {
+	case "bool", "int64", "float64", timeTypes["MonotonicTime"], timeTypes["TimeSinceEpoch"]:
+		return hasNonZeroDefault(p)
+	case "string":
+		return false
+	default:
+		return p.Type == nil // CDP types, but not arrays.
+	}
+}
+
+// Report whether the description of a property
+// mentions a default value which isn't zero.
+func hasNonZeroDefault(p Property) bool {
+	if p.Description == nil {
+		return false
+	}
+	desc := strings.ReplaceAll(*p.Description, "\n", " ")
+	for _, m := range defaultValue.FindAllStringSubmatch(desc, -1) {
+		switch v := strings.ToLower(m[1] + m[2] + m[3]); v {
+		case "true", "one":
+			return true
+		case "false":
+		default:
+			if f, err := strconv.ParseFloat(v, 64); err == nil && f != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
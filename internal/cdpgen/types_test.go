@@ -0,0 +1,194 @@
+package cdpgen
+
+import "testing"
+
+func str(s string) *string {
+	return &s
+}
+
+func TestIsPointer(t *testing.T) {
+	tests := []struct {
+		name   string
+		p      Property
+		goType string
+		want   bool
+	}{
+		{
+			name:   "required_bool",
+			p:      Property{Type: str("boolean"), Description: str("Defaults to true.")},
+			goType: "bool",
+		},
+		{
+			name:   "optional_bool_without_default",
+			p:      Property{Optional: true, Type: str("boolean")},
+			goType: "bool",
+		},
+		{
+			name:   "optional_bool_default_true",
+			p:      Property{Optional: true, Type: str("boolean"), Description: str("Whether to capture. Defaults to true.")},
+			goType: "bool",
+			want:   true,
+		},
+		{
+			name:   "optional_bool_true_by_default",
+			p:      Property{Optional: true, Type: str("boolean"), Description: str("Capture the screenshot\nfrom the surface, true by default.")},
+			goType: "bool",
+			want:   true,
+		},
+		{
+			name:   "optional_bool_default_false",
+			p:      Property{Optional: true, Type: str("boolean"), Description: str("Defaults to false.")},
+			goType: "bool",
+		},
+		{
+			name:   "optional_int_default_one",
+			p:      Property{Optional: true, Type: str("integer"), Description: str("Number of clicks (default: 1).")},
+			goType: "int64",
+			want:   true,
+		},
+		{
+			name:   "optional_int_default_one_in_words",
+			p:      Property{Optional: true, Type: str("integer"), Description: str("Defaults to one.")},
+			goType: "int64",
+			want:   true,
+		},
+		{
+			name:   "optional_int_default_zero",
+			p:      Property{Optional: true, Type: str("integer"), Description: str("The default value is 0.")},
+			goType: "int64",
+		},
+		{
+			name:   "optional_number_default_fraction",
+			p:      Property{Optional: true, Type: str("number"), Description: str("Scale factor, defaults to 0.5.")},
+			goType: "float64",
+			want:   true,
+		},
+		{
+			name:   "optional_number_default_negative",
+			p:      Property{Optional: true, Type: str("number"), Description: str("Default: -1")},
+			goType: "float64",
+			want:   true,
+		},
+		{
+			name:   "optional_time_default",
+			p:      Property{Optional: true, Ref: str("TimeSinceEpoch"), Description: str("Defaults to 1.")},
+			goType: "devtools.TimeSinceEpoch",
+			want:   true,
+		},
+		{
+			name:   "optional_time_without_default",
+			p:      Property{Optional: true, Ref: str("MonotonicTime")},
+			goType: "devtools.MonotonicTime",
+		},
+		{
+			name:   "optional_string_with_default",
+			p:      Property{Optional: true, Type: str("string"), Description: str("Defaults to 'png'.")},
+			goType: "string",
+		},
+		{
+			name:   "optional_cdp_type",
+			p:      Property{Optional: true, Ref: str("Viewport")},
+			goType: "Viewport",
+			want:   true,
+		},
+		{
+			name:   "required_cdp_type",
+			p:      Property{Ref: str("Viewport")},
+			goType: "Viewport",
+		},
+		{
+			name:   "optional_array",
+			p:      Property{Optional: true, Type: str("array"), Items: map[string]string{"$ref": "Cookie"}},
+			goType: "[]Cookie",
+		},
+		{
+			name:   "optional_object",
+			p:      Property{Optional: true, Type: str("object")},
+			goType: "json.RawMessage",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPointer(tt.p, tt.goType); got != tt.want {
+				t.Errorf("isPointer(%q) = %v, want %v", tt.goType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsPointerProperty(t *testing.T) {
+	// Aliases of scalar CDP types, see `generateTypes`.
+	for k, v := range map[string]string{
+		"FrameID":                "string",
+		"page.FrameID":           "string",
+		"network.TimeSinceEpoch": "devtools.TimeSinceEpoch",
+	} {
+		k := k
+		aliases[k] = v
+		t.Cleanup(func() { delete(aliases, k) })
+	}
+
+	tests := []struct {
+		name   string
+		p      Property
+		domain string
+		want   bool
+	}{
+		{
+			name:   "builtin_type",
+			p:      Property{Optional: true, Type: str("boolean"), Description: str("Defaults to true.")},
+			domain: "Page",
+			want:   true,
+		},
+		{
+			name:   "builtin_array",
+			p:      Property{Optional: true, Type: str("array"), Items: map[string]string{"type": "string"}},
+			domain: "Page",
+		},
+		{
+			name:   "same_domain_cdp_type",
+			p:      Property{Optional: true, Ref: str("Viewport")},
+			domain: "Page",
+			want:   true,
+		},
+		{
+			name:   "other_domain_cdp_type",
+			p:      Property{Optional: true, Ref: str("DOM.RGBA")},
+			domain: "Overlay",
+			want:   true,
+		},
+		{
+			name:   "same_domain_alias",
+			p:      Property{Optional: true, Ref: str("FrameId")},
+			domain: "Page",
+		},
+		{
+			name:   "qualified_same_domain_alias",
+			p:      Property{Optional: true, Ref: str("Page.FrameId")},
+			domain: "Page",
+		},
+		{
+			name:   "other_domain_time_alias",
+			p:      Property{Optional: true, Ref: str("Network.TimeSinceEpoch")},
+			domain: "Input",
+		},
+		{
+			name:   "other_domain_time_alias_with_default",
+			p:      Property{Optional: true, Ref: str("Network.TimeSinceEpoch"), Description: str("Defaults to 1.")},
+			domain: "Input",
+			want:   true,
+		},
+		{
+			name:   "required_cdp_type",
+			p:      Property{Ref: str("Viewport")},
+			domain: "Page",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPointerProperty(tt.p, tt.domain); got != tt.want {
+				t.Errorf("isPointerProperty() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
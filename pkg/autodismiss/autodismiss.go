@@ -0,0 +1,317 @@
+// Package autodismiss dismisses cookie consent banners automatically, after
+// each page load, using a ruleset of well-known consent management platforms
+// (see `autodismiss.DefaultRules`). This is an optional heuristic, which keeps
+// banners from covering the elements that automation scripts interact with:
+//
+//	d, err := autodismiss.Enable(ctx, autodismiss.HostPolicy("example.com", autodismiss.Accept))
+//	...
+//	defer d.Disable()
+//
+// Banners are rejected by default, which is the privacy-preserving choice.
+package autodismiss
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/dom"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// Policy determines which button is clicked in cookie consent banners.
+type Policy int
+
+// Cookie consent banner policies.
+const (
+	// Reject all non-essential cookies, and leave banners
+	// which don't have a reject button as they are.
+	Reject Policy = iota
+	// Accept all cookies.
+	Accept
+	// RejectOrAccept rejects all non-essential cookies, or
+	// accepts them if the banner doesn't have a reject button.
+	RejectOrAccept
+	// Ignore leaves banners as they are.
+	Ignore
+)
+
+func (p Policy) String() string {
+	switch p {
+	case Reject:
+		return "reject"
+	case Accept:
+		return "accept"
+	case RejectOrAccept:
+		return "reject-or-accept"
+	case Ignore:
+		return "ignore"
+	default:
+		return fmt.Sprintf("Policy(%d)", int(p))
+	}
+}
+
+// DefaultTimeout is the default duration for detecting banners after each
+// page load, because many of them are rendered asynchronously.
+const DefaultTimeout = 5 * time.Second
+
+// Interval between consecutive attempts to detect a banner.
+const pollInterval = 250 * time.Millisecond
+
+// Dismissal reports a detected cookie consent banner.
+type Dismissal struct {
+	// URL of the page.
+	URL string
+	// Rule is the name of the matching `autodismiss.Rule`.
+	Rule string
+	// Button which was clicked: "reject" or "accept",
+	// or empty if the banner was left as it is.
+	Button string
+	// Err is the error of the click, if it failed.
+	Err error
+}
+
+// Dismisser dismisses cookie consent banners in the background,
+// see `autodismiss.Enable`.
+type Dismisser struct {
+	ctx     context.Context
+	events  *devtools.Subscription
+	stop    chan struct{}
+	wg      sync.WaitGroup
+	rules   []Rule
+	policy  Policy
+	hosts   map[string]Policy
+	timeout time.Duration
+	// Releases the Page domain, see `devtools.EnableDomain`.
+	release func() error
+
+	mu         sync.Mutex
+	until      time.Time // Detect banners until this time.
+	generation int       // Incremented after each page load.
+	dismissals []Dismissal
+}
+
+// Option is used for customization in the `autodismiss.Enable` function.
+//
+// See https://commandcenter.blogspot.com/2014/01/self-referential-functions-and-design.html.
+type Option = func(*Dismisser)
+
+// Rules replaces the default ruleset (`autodismiss.DefaultRules`).
+// The first rule whose banner is rendered in a page applies to it.
+func Rules(rules ...Rule) Option {
+	return func(d *Dismisser) {
+		d.rules = rules
+	}
+}
+
+// DefaultPolicy sets the policy for all the hosts
+// which don't have a specific one (default: `autodismiss.Reject`).
+func DefaultPolicy(p Policy) Option {
+	return func(d *Dismisser) {
+		d.policy = p
+	}
+}
+
+// HostPolicy sets the policy for a specific host, and its subdomains, e.g.
+// "example.com" applies to "www.example.com" too. The most specific host
+// wins: "example.com" doesn't override the policy of "shop.example.com".
+func HostPolicy(host string, p Policy) Option {
+	return func(d *Dismisser) {
+		d.hosts[strings.ToLower(host)] = p
+	}
+}
+
+// Timeout sets the duration for detecting banners after each page
+// load (default: `autodismiss.DefaultTimeout`).
+func Timeout(t time.Duration) Option {
+	return func(d *Dismisser) {
+		d.timeout = t
+	}
+}
+
+// Enable starts dismissing cookie consent banners in the main frame of the
+// page associated with the given context, in the current document and after
+// each page load, until the `Disable` method of the returned dismisser is
+// called. Banners are dismissed with trusted mouse clicks (see the
+// `ElementHandle.Click` method). This function enables the Page domain.
+func Enable(ctx context.Context, opts ...Option) (*Dismisser, error) {
+	d := &Dismisser{
+		ctx:     ctx,
+		stop:    make(chan struct{}),
+		rules:   DefaultRules,
+		policy:  Reject,
+		hosts:   make(map[string]Policy),
+		timeout: DefaultTimeout,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	sub, err := devtools.Subscribe(ctx, "Page.loadEventFired")
+	if err != nil {
+		return nil, err
+	}
+	d.events = sub
+	d.wg.Add(2)
+	go d.track(devtools.EventsOfSession(ctx))
+	go d.poll()
+
+	release, err := devtools.EnableDomain(ctx, "Page")
+	if err != nil {
+		d.Disable()
+		return nil, err
+	}
+	d.release = release
+	d.arm()
+	return d, nil
+}
+
+// Receive page load events until the subscription is closed. Banners are
+// detected and clicked in a separate goroutine (see the `poll` method).
+func (d *Dismisser) track(accept devtools.EventFilter) {
+	defer d.wg.Done()
+	for m := range d.events.C {
+		if accept(m) {
+			d.arm()
+		}
+	}
+}
+
+// Start detecting banners in a new document.
+func (d *Dismisser) arm() {
+	d.mu.Lock()
+	d.until = time.Now().Add(d.timeout)
+	d.generation++
+	d.mu.Unlock()
+}
+
+// Detect and dismiss banners periodically, until the dismisser is disabled.
+func (d *Dismisser) poll() {
+	defer d.wg.Done()
+	t := time.NewTicker(pollInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-t.C:
+		}
+		d.mu.Lock()
+		active, gen := time.Now().Before(d.until), d.generation
+		d.mu.Unlock()
+		if !active || !d.dismiss() {
+			continue
+		}
+		// The banner was handled, so stop until the next page load.
+		d.mu.Lock()
+		if d.generation == gen {
+			d.until = time.Time{}
+		}
+		d.mu.Unlock()
+	}
+}
+
+// Find the first rule whose banner is rendered,
+// and which of its buttons are rendered too.
+const detectBanner = `function(rules) {
+	const rendered = (selector) => {
+		try {
+			const e = selector && document.querySelector(selector);
+			return !!e && e.getClientRects().length > 0 && getComputedStyle(e).visibility !== "hidden";
+		} catch (err) {
+			return false; // Invalid selector.
+		}
+	};
+	const rule = rules.findIndex((r) => rendered(r.Banner));
+	const r = rules[rule] || {};
+	return {url: location.href, host: location.hostname, rule, reject: rendered(r.Reject), accept: rendered(r.Accept)};
+}`
+
+type detection struct {
+	URL    string `json:"url"`
+	Host   string `json:"host"`
+	Rule   int    `json:"rule"`
+	Reject bool   `json:"reject"`
+	Accept bool   `json:"accept"`
+}
+
+// Detect a banner in the current document, and click the button which
+// the policy of the page's host prefers. It reports whether a banner
+// was detected, regardless of whether it was dismissed.
+func (d *Dismisser) dismiss() bool {
+	rules, err := json.Marshal(d.rules)
+	if err != nil {
+		return false
+	}
+	det := &detection{}
+	if err := runtime.Eval(d.ctx, fmt.Sprintf("(%s)(%s)", detectBanner, rules), det); err != nil {
+		return false // E.g. the document is being replaced.
+	}
+	if det.Rule < 0 || det.Rule >= len(d.rules) {
+		return false
+	}
+
+	r := d.rules[det.Rule]
+	dis := Dismissal{URL: det.URL, Rule: r.Name}
+	var selector string
+	switch p := d.policyFor(det.Host); {
+	case (p == Reject || p == RejectOrAccept) && det.Reject:
+		dis.Button, selector = "reject", r.Reject
+	case (p == Accept || p == RejectOrAccept) && det.Accept:
+		dis.Button, selector = "accept", r.Accept
+	}
+	if selector != "" {
+		dis.Err = click(d.ctx, selector)
+	}
+
+	d.mu.Lock()
+	d.dismissals = append(d.dismissals, dis)
+	d.mu.Unlock()
+	return true
+}
+
+// Return the policy of the most specific host which matches the given one.
+func (d *Dismisser) policyFor(host string) Policy {
+	host = strings.ToLower(host)
+	for {
+		if p, ok := d.hosts[host]; ok {
+			return p
+		}
+		i := strings.Index(host, ".")
+		if i < 0 {
+			return d.policy
+		}
+		host = host[i+1:]
+	}
+}
+
+func click(ctx context.Context, selector string) error {
+	h, err := dom.Query(ctx, selector, dom.NoRequery())
+	if err != nil {
+		return err
+	}
+	return h.Click(ctx)
+}
+
+// Dismissals returns the banners which were detected so far.
+func (d *Dismisser) Dismissals() []Dismissal {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]Dismissal(nil), d.dismissals...)
+}
+
+// Disable stops dismissing banners, and returns
+// the banners which were detected (see `Dismissals`).
+func (d *Dismisser) Disable() []Dismissal {
+	d.events.Unsubscribe()
+	close(d.stop)
+	d.wg.Wait()
+	if d.release != nil {
+		d.release()
+	}
+	return d.Dismissals()
+}
@@ -0,0 +1,40 @@
+package autodismiss
+
+import "testing"
+
+func TestPolicyFor(t *testing.T) {
+	d := &Dismisser{policy: Reject, hosts: make(map[string]Policy)}
+	HostPolicy("Example.com", Accept)(d)
+	HostPolicy("shop.example.com", Ignore)(d)
+
+	tests := []struct {
+		host string
+		want Policy
+	}{
+		{"example.com", Accept},
+		{"www.example.com", Accept},
+		{"shop.example.com", Ignore},
+		{"cart.shop.example.com", Ignore},
+		{"example.org", Reject},
+		{"notexample.com", Reject},
+		{"localhost", Reject},
+	}
+	for _, test := range tests {
+		if got := d.policyFor(test.host); got != test.want {
+			t.Errorf("policyFor(%q) = %v, want %v", test.host, got, test.want)
+		}
+	}
+}
+
+func TestDefaultRules(t *testing.T) {
+	names := map[string]bool{}
+	for _, r := range DefaultRules {
+		if r.Name == "" || r.Banner == "" || r.Accept == "" {
+			t.Errorf("incomplete rule: %+v", r)
+		}
+		if names[r.Name] {
+			t.Errorf("duplicate rule name %q", r.Name)
+		}
+		names[r.Name] = true
+	}
+}
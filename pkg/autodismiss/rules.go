@@ -0,0 +1,88 @@
+package autodismiss
+
+// Rule describes the cookie consent banner of a specific consent management
+// platform (CMP). All the selectors are CSS selectors, in the main frame.
+type Rule struct {
+	// Name of the consent management platform, for reporting.
+	Name string
+	// Banner matches the banner's root element. The rule applies
+	// only while this element is rendered (i.e. it has a layout box).
+	Banner string
+	// Reject matches the button which rejects all non-essential cookies,
+	// if the banner has one.
+	Reject string
+	// Accept matches the button which accepts all cookies.
+	Accept string
+}
+
+// DefaultRules is the built-in ruleset, for the most common consent
+// management platforms. Banners which are rendered in iframes (e.g. by
+// Sourcepoint) or in shadow DOMs (e.g. by Usercentrics) aren't supported.
+var DefaultRules = []Rule{
+	{
+		Name:   "OneTrust",
+		Banner: "#onetrust-banner-sdk",
+		Reject: "#onetrust-reject-all-handler",
+		Accept: "#onetrust-accept-btn-handler",
+	},
+	{
+		Name:   "Cookiebot",
+		Banner: "#CybotCookiebotDialog",
+		Reject: "#CybotCookiebotDialogBodyButtonDecline",
+		Accept: "#CybotCookiebotDialogBodyLevelButtonLevelOptinAllowAll",
+	},
+	{
+		Name:   "Quantcast Choice",
+		Banner: ".qc-cmp2-container",
+		Reject: `.qc-cmp2-summary-buttons button[mode="secondary"]`,
+		Accept: `.qc-cmp2-summary-buttons button[mode="primary"]`,
+	},
+	{
+		Name:   "Didomi",
+		Banner: "#didomi-notice",
+		Reject: "#didomi-notice-disagree-button",
+		Accept: "#didomi-notice-agree-button",
+	},
+	{
+		Name:   "TrustArc",
+		Banner: "#truste-consent-track",
+		Reject: "#truste-consent-required",
+		Accept: "#truste-consent-button",
+	},
+	{
+		Name:   "Google Funding Choices",
+		Banner: ".fc-consent-root",
+		Reject: ".fc-cta-do-not-consent",
+		Accept: ".fc-cta-consent",
+	},
+	{
+		Name:   "Osano",
+		Banner: ".osano-cm-dialog",
+		Reject: ".osano-cm-denyAll",
+		Accept: ".osano-cm-accept-all",
+	},
+	{
+		Name:   "Complianz",
+		Banner: ".cmplz-cookiebanner",
+		Reject: ".cmplz-deny",
+		Accept: ".cmplz-accept",
+	},
+	{
+		Name:   "CookieYes",
+		Banner: ".cky-consent-container",
+		Reject: ".cky-btn-reject",
+		Accept: ".cky-btn-accept",
+	},
+	{
+		Name:   "iubenda",
+		Banner: "#iubenda-cs-banner",
+		Reject: ".iubenda-cs-reject-btn",
+		Accept: ".iubenda-cs-accept-btn",
+	},
+	{
+		Name:   "Klaro",
+		Banner: ".klaro .cookie-notice",
+		Reject: ".klaro .cn-decline",
+		Accept: ".klaro .cm-btn-success",
+	},
+}
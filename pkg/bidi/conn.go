@@ -0,0 +1,188 @@
+// Package bidi is a minimal client of the WebDriver BiDi protocol
+// (https://w3c.github.io/webdriver-bidi/), for the subset of operations that
+// it shares with the Chrome DevTools Protocol (CDP): navigation, screenshots
+// and script evaluation. It allows gradual migration of automation code, and
+// driving browsers which support only WebDriver BiDi (e.g. Firefox), while
+// keeping the CDP bindings in the devtools package as the primary path.
+//
+// The `bidi.Driver` interface abstracts these operations, with
+// implementations for both protocols.
+package bidi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/websocket"
+)
+
+var errClosed = errors.New("WebDriver BiDi connection closed")
+
+// Error details passed within a WebDriver BiDi error response message
+// (https://w3c.github.io/webdriver-bidi/#protocol-definition).
+type Error struct {
+	Code    string `json:"error"`
+	Message string `json:"message"`
+}
+
+// Error satisfies the Go error interface (https://golang.org/pkg/builtin/#error).
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return e.Code
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Message is a generic WebDriver BiDi message sent to or received from a browser.
+type Message struct {
+	Type   string          `json:"type,omitempty"`
+	ID     int64           `json:"id,omitempty"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	*Error
+}
+
+// Conn is a WebDriver BiDi connection to a browser. Multiple
+// goroutines may send commands through it simultaneously.
+type Conn struct {
+	ws   *websocket.Conn
+	done chan struct{}
+
+	mu      sync.Mutex // Serializes writes, and guards the fields below.
+	msgID   int64
+	pending map[int64]chan *Message
+	err     error
+}
+
+// Dial opens a WebDriver BiDi connection to the given WebSocket URL. If its
+// path is "/session" (e.g. "ws://localhost:9222/session" for Firefox with the
+// flag "--remote-debugging-port=9222"), it also starts a new BiDi session.
+// Other URLs are assumed to belong to existing sessions, e.g. the
+// "webSocketUrl" capability of a WebDriver classic session.
+func Dial(ctx context.Context, wsURL string) (*Conn, error) {
+	u, err := url.Parse(wsURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "ws" {
+		return nil, fmt.Errorf("unsupported WebSocket URL scheme: %q", u.Scheme)
+	}
+	ws, err := websocket.Handshake(ctx, u.Host, u.RequestURI())
+	if err != nil {
+		return nil, err
+	}
+	c := &Conn{ws: ws, done: make(chan struct{}), pending: make(map[int64]chan *Message)}
+	go c.receive()
+	if u.Path == "/session" {
+		// https://w3c.github.io/webdriver-bidi/#command-session-new
+		params := map[string]interface{}{"capabilities": struct{}{}}
+		if err := c.Send(ctx, "session.new", params, nil); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("failed to start WebDriver BiDi session: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// Receive incoming messages, and relay responses to their callers.
+func (c *Conn) receive() {
+	defer close(c.done)
+	for {
+		b, err := c.ws.Read()
+		if err != nil {
+			c.mu.Lock()
+			c.err = err
+			for id, ch := range c.pending {
+				ch <- &Message{ID: id, Error: &Error{Code: "connection closed", Message: err.Error()}}
+				delete(c.pending, id)
+			}
+			c.mu.Unlock()
+			return
+		}
+		m := &Message{}
+		if err := json.Unmarshal(b, m); err != nil {
+			log.Printf("WebDriver BiDi JSON error: %v", err)
+			continue
+		}
+		if m.Type == "event" {
+			continue // Events are not supported yet.
+		}
+		c.mu.Lock()
+		ch, ok := c.pending[m.ID]
+		delete(c.pending, m.ID)
+		c.mu.Unlock()
+		if ok {
+			ch <- m
+		}
+	}
+}
+
+// Send sends a WebDriver BiDi command with the given parameters (which may be
+// nil), waits for the response, and parses its result into the given struct
+// (unless it's nil). Error responses are returned as `*bidi.Error` errors.
+func (c *Conn) Send(ctx context.Context, method string, params, result interface{}) error {
+	if params == nil {
+		params = struct{}{}
+	}
+	p, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	ch := make(chan *Message, 1)
+
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return errClosed
+	}
+	c.msgID++
+	id := c.msgID
+	b, err := json.Marshal(&Message{ID: id, Method: method, Params: p})
+	if err == nil {
+		c.pending[id] = ch
+		err = c.ws.WriteText(b)
+	}
+	c.mu.Unlock()
+	if err != nil {
+		c.forget(id)
+		return err
+	}
+
+	select {
+	case m := <-ch:
+		return parseResponse(m, result)
+	case <-ctx.Done():
+		c.forget(id)
+		return ctx.Err()
+	}
+}
+
+func (c *Conn) forget(id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, id)
+}
+
+// Parse the result of a WebDriver BiDi response message.
+func parseResponse(m *Message, result interface{}) error {
+	if m.Error != nil {
+		return m.Error
+	}
+	if result == nil || len(m.Result) == 0 {
+		return nil
+	}
+	return json.Unmarshal(m.Result, result)
+}
+
+// Close closes the connection, and waits for its receiver to end.
+func (c *Conn) Close() error {
+	err := c.ws.Close(1000, []byte{})
+	<-c.done
+	return err
+}
@@ -0,0 +1,182 @@
+package bidi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// Driver is the subset of browser automation operations which is supported
+// by both CDP and WebDriver BiDi. Code which uses only this interface can run
+// against either protocol.
+type Driver interface {
+	// Navigate navigates the page to the given URL,
+	// and waits until it's fully loaded.
+	Navigate(ctx context.Context, url string) error
+	// Screenshot captures a PNG screenshot of the page's viewport.
+	Screenshot(ctx context.Context) ([]byte, error)
+	// Eval evaluates a JavaScript expression in the page, waits for the
+	// result if it's a promise, and parses its JSON value into v (unless
+	// v is nil).
+	Eval(ctx context.Context, expression string, v interface{}) error
+}
+
+// CDP returns a `bidi.Driver` which uses the CDP session that is associated
+// with the context of each call (see the `devtools.NewContext` function).
+func CDP() Driver {
+	return cdpDriver{}
+}
+
+type cdpDriver struct{}
+
+func (cdpDriver) Navigate(ctx context.Context, url string) error {
+	r, err := page.NewNavigate(url).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if r.ErrorText != "" {
+		return fmt.Errorf("navigation to %s failed: %s", url, r.ErrorText)
+	}
+	return page.WaitForLoad(ctx)
+}
+
+func (cdpDriver) Screenshot(ctx context.Context) ([]byte, error) {
+	r, err := page.NewCaptureScreenshot().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(r.Data)
+}
+
+func (cdpDriver) Eval(ctx context.Context, expression string, v interface{}) error {
+	return runtime.Eval(ctx, expression, v)
+}
+
+// Tab is a top-level WebDriver BiDi browsing context, which
+// satisfies the `bidi.Driver` interface.
+type Tab struct {
+	conn *Conn
+	// ID of the browsing context.
+	ID string
+}
+
+// OpenTab creates a new tab in the browser, and returns its `bidi.Driver`.
+func (c *Conn) OpenTab(ctx context.Context) (*Tab, error) {
+	// https://w3c.github.io/webdriver-bidi/#command-browsingContext-create
+	params := map[string]string{"type": "tab"}
+	r := &struct {
+		Context string `json:"context"`
+	}{}
+	if err := c.Send(ctx, "browsingContext.create", params, r); err != nil {
+		return nil, err
+	}
+	return &Tab{conn: c, ID: r.Context}, nil
+}
+
+// FirstTab returns the `bidi.Driver` of the first existing tab in the browser.
+func (c *Conn) FirstTab(ctx context.Context) (*Tab, error) {
+	// https://w3c.github.io/webdriver-bidi/#command-browsingContext-getTree
+	params := map[string]int{"maxDepth": 0}
+	r := &struct {
+		Contexts []struct {
+			Context string `json:"context"`
+		} `json:"contexts"`
+	}{}
+	if err := c.Send(ctx, "browsingContext.getTree", params, r); err != nil {
+		return nil, err
+	}
+	if len(r.Contexts) == 0 {
+		return nil, errors.New("no browsing contexts in the browser")
+	}
+	return &Tab{conn: c, ID: r.Contexts[0].Context}, nil
+}
+
+// Close closes the tab.
+func (t *Tab) Close(ctx context.Context) error {
+	// https://w3c.github.io/webdriver-bidi/#command-browsingContext-close
+	params := map[string]string{"context": t.ID}
+	return t.conn.Send(ctx, "browsingContext.close", params, nil)
+}
+
+// Navigate satisfies the `bidi.Driver` interface.
+func (t *Tab) Navigate(ctx context.Context, url string) error {
+	// https://w3c.github.io/webdriver-bidi/#command-browsingContext-navigate
+	params := map[string]string{"context": t.ID, "url": url, "wait": "complete"}
+	return t.conn.Send(ctx, "browsingContext.navigate", params, nil)
+}
+
+// Screenshot satisfies the `bidi.Driver` interface.
+func (t *Tab) Screenshot(ctx context.Context) ([]byte, error) {
+	// https://w3c.github.io/webdriver-bidi/#command-browsingContext-captureScreenshot
+	params := map[string]string{"context": t.ID}
+	r := &struct {
+		Data string `json:"data"`
+	}{}
+	if err := t.conn.Send(ctx, "browsingContext.captureScreenshot", params, r); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(r.Data)
+}
+
+// Eval satisfies the `bidi.Driver` interface. JavaScript
+// exceptions are returned as `*bidi.ExceptionError` errors.
+func (t *Tab) Eval(ctx context.Context, expression string, v interface{}) error {
+	// https://w3c.github.io/webdriver-bidi/#command-script-evaluate
+	params := map[string]interface{}{
+		"expression":   wrapExpression(expression),
+		"target":       map[string]string{"context": t.ID},
+		"awaitPromise": true,
+	}
+	r := &evaluateResult{}
+	if err := t.conn.Send(ctx, "script.evaluate", params, r); err != nil {
+		return err
+	}
+	return r.parse(v)
+}
+
+// ExceptionError is a JavaScript exception which was thrown by an expression
+// that was evaluated with the `Tab.Eval` method.
+type ExceptionError struct {
+	Text string `json:"text"`
+}
+
+// Error satisfies the Go error interface (https://golang.org/pkg/builtin/#error).
+func (e *ExceptionError) Error() string {
+	return "JavaScript exception: " + e.Text
+}
+
+// Serialize the expression's value in the browser, instead of parsing
+// WebDriver BiDi's remote value representation, for parity with
+// the `runtime.Eval` function.
+func wrapExpression(expression string) string {
+	return fmt.Sprintf("(async () => JSON.stringify(await (%s)))()", expression)
+}
+
+// https://w3c.github.io/webdriver-bidi/#type-script-EvaluateResult
+type evaluateResult struct {
+	Type   string `json:"type"`
+	Result struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	} `json:"result"`
+	ExceptionDetails *ExceptionError `json:"exceptionDetails"`
+}
+
+func (r *evaluateResult) parse(v interface{}) error {
+	if r.Type == "exception" {
+		if r.ExceptionDetails == nil {
+			return &ExceptionError{}
+		}
+		return r.ExceptionDetails
+	}
+	// Values which aren't JSON-serializable (e.g. undefined) are left as-is.
+	if v == nil || r.Result.Type != "string" {
+		return nil
+	}
+	return json.Unmarshal([]byte(r.Result.Value), v)
+}
@@ -0,0 +1,77 @@
+package bidi
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestEvaluateResult(t *testing.T) {
+	tests := []struct {
+		name, resp string
+		want       interface{}
+		err        string
+	}{
+		{
+			name: "object",
+			resp: `{"type":"success","realm":"r1","result":{"type":"string","value":"{\"a\":[1,2]}"}}`,
+			want: map[string]interface{}{"a": []interface{}{1.0, 2.0}},
+		},
+		{
+			name: "undefined",
+			resp: `{"type":"success","realm":"r1","result":{"type":"undefined"}}`,
+		},
+		{
+			name: "exception",
+			resp: `{"type":"exception","realm":"r1","exceptionDetails":{"text":"ReferenceError: x is not defined"}}`,
+			err:  "JavaScript exception: ReferenceError: x is not defined",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &evaluateResult{}
+			if err := json.Unmarshal([]byte(test.resp), r); err != nil {
+				t.Fatal(err)
+			}
+			var got interface{}
+			err := r.parse(&got)
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Fatalf("parse() error = %v, want %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parse() = %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseResponse(t *testing.T) {
+	m := &Message{}
+	b := `{"type":"error","id":3,"error":"no such frame","message":"Browsing context not found"}`
+	if err := json.Unmarshal([]byte(b), m); err != nil {
+		t.Fatal(err)
+	}
+	var e *Error
+	if err := parseResponse(m, nil); !errors.As(err, &e) || e.Code != "no such frame" {
+		t.Errorf("parseResponse() error = %v, want *Error with code %q", err, "no such frame")
+	}
+
+	m = &Message{}
+	b = `{"type":"success","id":4,"result":{"context":"abc"}}`
+	if err := json.Unmarshal([]byte(b), m); err != nil {
+		t.Fatal(err)
+	}
+	r := &struct {
+		Context string `json:"context"`
+	}{}
+	if err := parseResponse(m, r); err != nil || r.Context != "abc" {
+		t.Errorf("parseResponse() = %q, %v; want %q, nil", r.Context, err, "abc")
+	}
+}
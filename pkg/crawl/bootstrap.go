@@ -0,0 +1,195 @@
+package crawl
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+)
+
+// Limits of a single sitemap file, and of sitemap indexes, based on
+// https://www.sitemaps.org/protocol.html. Larger files are truncated.
+const (
+	maxSitemapSize  = 50 << 20 // 50 MiB, uncompressed.
+	maxSitemapURLs  = 50000
+	maxSitemapFiles = 50 // Per sitemap index, to bound the requests.
+)
+
+// SitemapURL is an entry in a sitemap file, see the `crawl.FetchSitemap`
+// function. Only the location is required, the other fields are optional.
+type SitemapURL struct {
+	Loc        string  `xml:"loc"`
+	LastMod    string  `xml:"lastmod"`
+	ChangeFreq string  `xml:"changefreq"`
+	Priority   float64 `xml:"priority"`
+}
+
+// The root element of a sitemap file (`<urlset>`) or a sitemap index
+// (`<sitemapindex>`), which lists the locations of other sitemap files.
+type sitemapXML struct {
+	XMLName  xml.Name
+	URLs     []SitemapURL `xml:"url"`
+	Sitemaps []SitemapURL `xml:"sitemap"`
+}
+
+// FetchRobots fetches and parses the "robots.txt" file of the given URL's
+// origin through the network stack of the browser associated with the given
+// context, i.e. with the page's cookies and proxy settings, unlike the HTTP
+// client of `crawl.Governor`. Origins without a "robots.txt" file (HTTP
+// status 4xx) allow everything.
+func FetchRobots(ctx context.Context, rawURL string) (*Robots, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	robotsURL := u.Scheme + "://" + u.Host + "/robots.txt"
+	r, err := load(ctx, robotsURL)
+	if err != nil {
+		return nil, err
+	}
+	var body []byte
+	if r.Body != nil {
+		body = r.Body.Bytes
+	}
+	return robotsResponse(robotsURL, r.StatusCode, bytes.NewReader(body))
+}
+
+// FetchSitemap fetches and parses the sitemap file in the given URL through
+// the network stack of the browser associated with the given context, and
+// returns its entries, e.g. to bootstrap a crawl. The locations of sitemap
+// files are listed in "robots.txt" files (see `Robots.Sitemaps`), or
+// conventionally "/sitemap.xml".
+//
+// It supports XML sitemaps, sitemap indexes (whose sitemap files are fetched
+// and merged, skipping those which fail), plain text sitemaps with one URL
+// per line, and gzip-compressed files of all of these.
+func FetchSitemap(ctx context.Context, sitemapURL string) ([]SitemapURL, error) {
+	b, err := loadSitemap(ctx, sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+	urls, sitemaps, err := parseSitemap(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sitemap %s: %v", sitemapURL, err)
+	}
+	if len(sitemaps) > maxSitemapFiles {
+		sitemaps = sitemaps[:maxSitemapFiles]
+	}
+	// Sitemap indexes may not be nested, so they're followed only one level deep.
+	for _, s := range sitemaps {
+		b, err := loadSitemap(ctx, s.Loc)
+		if err != nil {
+			log.Printf("Skipping sitemap %s: %v", s.Loc, err)
+			continue
+		}
+		more, _, err := parseSitemap(b)
+		if err != nil {
+			log.Printf("Skipping sitemap %s: %v", s.Loc, err)
+			continue
+		}
+		urls = append(urls, more...)
+	}
+	return urls, nil
+}
+
+// FetchFavicon fetches the conventional "/favicon.ico" file of the given
+// URL's origin through the network stack of the browser associated with
+// the given context. Icons which are declared with `<link rel="icon">`
+// elements in pages can be fetched with the `network.LoadResource` function.
+func FetchFavicon(ctx context.Context, rawURL string) (*network.Body, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	iconURL := u.Scheme + "://" + u.Host + "/favicon.ico"
+	r, err := load(ctx, iconURL)
+	if err != nil {
+		return nil, err
+	}
+	if r.StatusCode >= 400 || r.Body == nil {
+		return nil, fmt.Errorf("failed to fetch %s: HTTP status %d", iconURL, r.StatusCode)
+	}
+	return r.Body, nil
+}
+
+// Load the given URL in the context of the main frame of the page
+// associated with the given context.
+func load(ctx context.Context, rawURL string) (*network.LoadedResource, error) {
+	tree, err := page.NewGetFrameTree().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return network.LoadResource(ctx, tree.FrameTree.Frame.ID, rawURL)
+}
+
+// Load a sitemap file, and decompress it if needed.
+func loadSitemap(ctx context.Context, sitemapURL string) ([]byte, error) {
+	r, err := load(ctx, sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+	if r.StatusCode >= 400 || r.Body == nil {
+		return nil, fmt.Errorf("failed to fetch %s: HTTP status %d", sitemapURL, r.StatusCode)
+	}
+	b := r.Body.Bytes
+	// Servers don't always set "Content-Encoding: gzip" for ".xml.gz" files,
+	// in which case the browser doesn't decompress them.
+	if !bytes.HasPrefix(b, []byte("\x1f\x8b")) {
+		return b, nil
+	}
+	z, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %v", sitemapURL, err)
+	}
+	defer z.Close()
+	if b, err = ioutil.ReadAll(io.LimitReader(z, maxSitemapSize)); err != nil {
+		return nil, fmt.Errorf("failed to decompress %s: %v", sitemapURL, err)
+	}
+	return b, nil
+}
+
+// Parse an XML sitemap file or sitemap index, or a plain text sitemap.
+func parseSitemap(b []byte) (urls, sitemaps []SitemapURL, err error) {
+	if len(b) > maxSitemapSize {
+		b = b[:maxSitemapSize]
+	}
+	if trimmed := bytes.TrimSpace(bytes.TrimPrefix(b, []byte("\xef\xbb\xbf"))); !bytes.HasPrefix(trimmed, []byte("<")) {
+		scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+		for scanner.Scan() && len(urls) < maxSitemapURLs {
+			if line := strings.TrimSpace(scanner.Text()); strings.HasPrefix(line, "http") {
+				urls = append(urls, SitemapURL{Loc: line})
+			}
+		}
+		return urls, nil, scanner.Err()
+	}
+
+	s := &sitemapXML{}
+	if err := xml.Unmarshal(b, s); err != nil {
+		return nil, nil, err
+	}
+	switch s.XMLName.Local {
+	case "urlset", "sitemapindex":
+	default:
+		return nil, nil, fmt.Errorf("unexpected root element <%s>", s.XMLName.Local)
+	}
+	if len(s.URLs) > maxSitemapURLs {
+		s.URLs = s.URLs[:maxSitemapURLs]
+	}
+	for i := range s.URLs {
+		s.URLs[i].Loc = strings.TrimSpace(s.URLs[i].Loc)
+	}
+	for i := range s.Sitemaps {
+		s.Sitemaps[i].Loc = strings.TrimSpace(s.Sitemaps[i].Loc)
+	}
+	return s.URLs, s.Sitemaps, nil
+}
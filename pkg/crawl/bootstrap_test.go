@@ -0,0 +1,137 @@
+package crawl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeResource struct {
+	status int
+	body   []byte
+}
+
+// Serve the given resources with `Network.loadNetworkResource`,
+// and their bodies as single-chunk streams.
+func fakeResources(resources map[string]fakeResource) context.Context {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	b.Respond("Page.getFrameTree", map[string]interface{}{
+		"frameTree": map[string]interface{}{"frame": map[string]string{"id": "main"}},
+	})
+	b.Handle("Network.loadNetworkResource", func(params json.RawMessage) (interface{}, error) {
+		p := &struct{ URL string }{}
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, err
+		}
+		r, ok := resources[p.URL]
+		if !ok {
+			return map[string]interface{}{"resource": map[string]interface{}{
+				"success": false, "netError": -105, "netErrorName": "net::ERR_NAME_NOT_RESOLVED",
+			}}, nil
+		}
+		return map[string]interface{}{"resource": map[string]interface{}{
+			"success": r.status < 400, "httpStatusCode": r.status, "stream": p.URL,
+		}}, nil
+	})
+	b.Handle("IO.read", func(params json.RawMessage) (interface{}, error) {
+		p := &struct{ Handle string }{}
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, err
+		}
+		data := base64.StdEncoding.EncodeToString(resources[p.Handle].body)
+		return map[string]interface{}{"base64Encoded": true, "data": data, "eof": true}, nil
+	})
+	return ctx
+}
+
+func gzipped(s string) []byte {
+	var buf bytes.Buffer
+	z := gzip.NewWriter(&buf)
+	z.Write([]byte(s))
+	z.Close()
+	return buf.Bytes()
+}
+
+func TestFetchRobots(t *testing.T) {
+	ctx := fakeResources(map[string]fakeResource{
+		"https://a.com/robots.txt": {200, []byte("User-agent: *\nDisallow: /private\nSitemap: https://a.com/s.xml\n")},
+		"https://b.com/robots.txt": {404, nil},
+		"https://c.com/robots.txt": {503, nil},
+	})
+	r, err := FetchRobots(ctx, "https://a.com/page?x=1")
+	if err != nil {
+		t.Fatalf("FetchRobots(a.com); got error: %v", err)
+	}
+	if r.Allowed(DefaultUserAgent, "/private") {
+		t.Error("FetchRobots(a.com).Allowed(/private) = true, want false")
+	}
+	if diff := cmp.Diff([]string{"https://a.com/s.xml"}, r.Sitemaps); diff != "" {
+		t.Errorf("FetchRobots(a.com).Sitemaps mismatch (-want +got):\n%s", diff)
+	}
+	if r, err := FetchRobots(ctx, "https://b.com/"); err != nil || !r.Allowed(DefaultUserAgent, "/private") {
+		t.Errorf("FetchRobots(b.com) = %v, %v; want allow all", r, err)
+	}
+	if _, err := FetchRobots(ctx, "https://c.com/"); err == nil {
+		t.Error("FetchRobots(c.com); got nil error")
+	}
+	if _, err := FetchRobots(ctx, "https://d.com/"); err == nil {
+		t.Error("FetchRobots(d.com); got nil error")
+	}
+}
+
+func TestFetchSitemap(t *testing.T) {
+	ctx := fakeResources(map[string]fakeResource{
+		"https://a.com/index.xml": {200, []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <sitemap><loc>https://a.com/1.xml.gz</loc></sitemap>
+  <sitemap><loc>https://a.com/missing.xml</loc></sitemap>
+  <sitemap><loc> https://a.com/2.txt </loc></sitemap>
+</sitemapindex>`)},
+		"https://a.com/1.xml.gz": {200, gzipped(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url><loc>https://a.com/x</loc><lastmod>2021-01-02</lastmod><priority>0.8</priority></url>
+  <url><loc>https://a.com/y</loc><changefreq>daily</changefreq><priority/></url>
+</urlset>`)},
+		"https://a.com/missing.xml": {404, []byte("Not found")},
+		"https://a.com/2.txt":       {200, []byte("\xef\xbb\xbfhttps://a.com/z\n\n  https://a.com/w  \n")},
+		"https://a.com/bad.xml":     {200, []byte("<html><body>Oops</body></html>")},
+	})
+	got, err := FetchSitemap(ctx, "https://a.com/index.xml")
+	if err != nil {
+		t.Fatalf("FetchSitemap(); got error: %v", err)
+	}
+	want := []SitemapURL{
+		{Loc: "https://a.com/x", LastMod: "2021-01-02", Priority: 0.8},
+		{Loc: "https://a.com/y", ChangeFreq: "daily"},
+		{Loc: "https://a.com/z"},
+		{Loc: "https://a.com/w"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FetchSitemap() mismatch (-want +got):\n%s", diff)
+	}
+	if _, err := FetchSitemap(ctx, "https://a.com/bad.xml"); err == nil {
+		t.Error("FetchSitemap(bad.xml); got nil error")
+	}
+	if _, err := FetchSitemap(ctx, "https://a.com/missing.xml"); err == nil {
+		t.Error("FetchSitemap(missing.xml); got nil error")
+	}
+}
+
+func TestFetchFavicon(t *testing.T) {
+	icon := []byte("\x00\x00\x01\x00\x01\x00")
+	ctx := fakeResources(map[string]fakeResource{
+		"https://a.com/favicon.ico": {200, icon},
+	})
+	b, err := FetchFavicon(ctx, "https://a.com/some/page")
+	if err != nil {
+		t.Fatalf("FetchFavicon(); got error: %v", err)
+	}
+	if !bytes.Equal(b.Bytes, icon) || b.Charset != "" {
+		t.Errorf("FetchFavicon() = %q (charset %q), want %q", b.Bytes, b.Charset, icon)
+	}
+}
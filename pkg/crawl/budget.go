@@ -0,0 +1,292 @@
+package crawl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// ErrorKind is a kind of page error which is counted by an `crawl.ErrorBudget`.
+type ErrorKind int
+
+// Kinds of page errors.
+const (
+	// Exception is an uncaught JavaScript exception.
+	Exception ErrorKind = iota
+	// FailedRequest is a network request which failed (e.g. DNS or
+	// connection errors, or blocked requests), but wasn't canceled.
+	// HTTP error statuses aren't failures.
+	FailedRequest
+	// ConsoleError is a `console.error` call.
+	ConsoleError
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case Exception:
+		return "JavaScript exceptions"
+	case FailedRequest:
+		return "failed requests"
+	case ConsoleError:
+		return "console errors"
+	default:
+		return fmt.Sprintf("ErrorKind(%d)", int(k))
+	}
+}
+
+// BudgetExceededError describes an exceeded `crawl.ErrorBudget`.
+type BudgetExceededError struct {
+	Kind  ErrorKind
+	Count int
+	Limit int
+	// Window is the sliding time window of the count,
+	// or 0 if errors are counted since the budget was started.
+	Window time.Duration
+	// Last is a description of the last error.
+	Last string
+}
+
+// Error satisfies the Go error interface (https://golang.org/pkg/builtin/#error).
+func (e *BudgetExceededError) Error() string {
+	msg := fmt.Sprintf("error budget exceeded: %d %s", e.Count, e.Kind)
+	if e.Window > 0 {
+		msg += fmt.Sprintf(" in %v", e.Window)
+	}
+	return fmt.Sprintf("%s (limit: %d), last: %s", msg, e.Limit, e.Last)
+}
+
+// ErrorBudget counts page errors in the background, and reacts when their
+// number exceeds a limit, see the `crawl.WatchErrorBudget` function.
+type ErrorBudget struct {
+	ctx        context.Context
+	names      []string
+	subs       []*devtools.Subscription
+	done       chan struct{}
+	limits     map[ErrorKind]int
+	window     time.Duration
+	onExceeded []func(context.Context, *BudgetExceededError)
+	// Release the Network and Runtime domains, see `devtools.EnableDomain`.
+	releases []func() error
+
+	mu       sync.Mutex
+	times    map[ErrorKind][]time.Time // Errors in the current window.
+	exceeded *BudgetExceededError      // The first one.
+}
+
+// BudgetOption is used for customization in the `crawl.WatchErrorBudget`
+// function.
+//
+// See https://commandcenter.blogspot.com/2014/01/self-referential-functions-and-design.html.
+type BudgetOption = func(*ErrorBudget)
+
+// MaxErrors sets the maximum number of errors of the given kind
+// which are tolerated within the budget's window. Kinds without
+// a maximum are counted, but they're never exceeded.
+func MaxErrors(k ErrorKind, n int) BudgetOption {
+	return func(b *ErrorBudget) {
+		b.limits[k] = n
+	}
+}
+
+// BudgetWindow sets the sliding time window in which errors are counted,
+// e.g. 10 errors per minute (default: 0, i.e. since the budget was started).
+func BudgetWindow(d time.Duration) BudgetOption {
+	return func(b *ErrorBudget) {
+		b.window = d
+	}
+}
+
+// OnExceeded adds a callback which is called when a maximum is exceeded,
+// e.g. to reload the page (see `crawl.ReloadPage`), rotate a proxy, or
+// abort the job by canceling its context. Callbacks are called in separate
+// goroutines, with the budget's context, so they may send CDP commands.
+// The count of the exceeded kind restarts from zero afterwards.
+func OnExceeded(f func(context.Context, *BudgetExceededError)) BudgetOption {
+	return func(b *ErrorBudget) {
+		b.onExceeded = append(b.onExceeded, f)
+	}
+}
+
+// ReloadPage is an `crawl.OnExceeded` callback which reloads the page,
+// ignoring the browser's cache.
+func ReloadPage(ctx context.Context, _ *BudgetExceededError) {
+	page.NewReload().SetIgnoreCache(true).Do(ctx)
+}
+
+// WatchErrorBudget starts counting JavaScript exceptions, failed network
+// requests and console errors in the page associated with the given context,
+// until the `Stop` method of the returned budget is called, to detect when
+// a long-running session degrades, e.g. due to blocking or rate limiting.
+// This function enables the Network and Runtime domains until the budget is
+// stopped (see `devtools.EnableDomain`).
+//
+//	b, err := crawl.WatchErrorBudget(ctx,
+//		crawl.MaxErrors(crawl.FailedRequest, 20), crawl.BudgetWindow(time.Minute),
+//		crawl.OnExceeded(crawl.ReloadPage))
+func WatchErrorBudget(ctx context.Context, opts ...BudgetOption) (*ErrorBudget, error) {
+	b := &ErrorBudget{
+		ctx:    ctx,
+		names:  []string{"Runtime.exceptionThrown", "Runtime.consoleAPICalled", "Network.loadingFailed"},
+		done:   make(chan struct{}),
+		limits: make(map[ErrorKind]int),
+		times:  make(map[ErrorKind][]time.Time),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	for _, name := range b.names {
+		sub, err := devtools.Subscribe(ctx, name)
+		if err != nil {
+			b.unsubscribe()
+			return nil, err
+		}
+		b.subs = append(b.subs, sub)
+	}
+	go b.collect(devtools.EventsOfSession(ctx))
+
+	for _, domain := range []string{"Runtime", "Network"} {
+		release, err := devtools.EnableDomain(ctx, domain)
+		if err != nil {
+			b.Stop()
+			return nil, err
+		}
+		b.releases = append(b.releases, release)
+	}
+	return b, nil
+}
+
+func (b *ErrorBudget) unsubscribe() {
+	for _, sub := range b.subs {
+		sub.Unsubscribe()
+	}
+}
+
+// Receive events until all the subscriptions are closed.
+func (b *ErrorBudget) collect(accept devtools.EventFilter) {
+	defer close(b.done)
+	exceptions, console, failures := b.subs[0].C, b.subs[1].C, b.subs[2].C
+	for exceptions != nil || console != nil || failures != nil {
+		select {
+		case m, ok := <-exceptions:
+			if !ok {
+				exceptions = nil
+				continue
+			}
+			e := &runtime.ExceptionThrown{}
+			if !accept(m) || json.Unmarshal(m.Params, e) != nil {
+				continue
+			}
+			b.record(Exception, time.Now(), e.ExceptionDetails.Error())
+		case m, ok := <-console:
+			if !ok {
+				console = nil
+				continue
+			}
+			e := &runtime.ConsoleAPICalled{}
+			if !accept(m) || json.Unmarshal(m.Params, e) != nil || e.Type != "error" {
+				continue
+			}
+			b.record(ConsoleError, time.Now(), consoleText(e.Args))
+		case m, ok := <-failures:
+			if !ok {
+				failures = nil
+				continue
+			}
+			e := &network.LoadingFailed{}
+			if !accept(m) || json.Unmarshal(m.Params, e) != nil || e.Canceled {
+				continue
+			}
+			b.record(FailedRequest, time.Now(), fmt.Sprintf("%s request %s", e.Type, e.ErrorText))
+		}
+	}
+}
+
+// Count an error, and call the callbacks if it exceeds the budget.
+func (b *ErrorBudget) record(k ErrorKind, t time.Time, desc string) {
+	b.mu.Lock()
+	times := append(b.prune(k, t), t)
+	b.times[k] = times
+	limit, ok := b.limits[k]
+	if !ok || len(times) <= limit {
+		b.mu.Unlock()
+		return
+	}
+	e := &BudgetExceededError{Kind: k, Count: len(times), Limit: limit, Window: b.window, Last: desc}
+	if b.exceeded == nil {
+		b.exceeded = e
+	}
+	b.times[k] = nil
+	b.mu.Unlock()
+
+	for _, f := range b.onExceeded {
+		go f(b.ctx, e)
+	}
+}
+
+// Return the times of errors of the given kind which are still within the
+// window at the given time. The caller must hold the budget's lock.
+func (b *ErrorBudget) prune(k ErrorKind, now time.Time) []time.Time {
+	times := b.times[k]
+	if b.window <= 0 {
+		return times
+	}
+	i := 0
+	for i < len(times) && now.Sub(times[i]) >= b.window {
+		i++
+	}
+	return times[i:]
+}
+
+// Count returns the number of errors of the given kind in the current
+// window, since the last time that its maximum was exceeded.
+func (b *ErrorBudget) Count(k ErrorKind) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.prune(k, time.Now()))
+}
+
+// Err returns the first `*crawl.BudgetExceededError`,
+// or nil if the budget wasn't exceeded yet.
+func (b *ErrorBudget) Err() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.exceeded == nil {
+		return nil
+	}
+	return b.exceeded
+}
+
+// Stop stops counting errors, and releases the Network and Runtime
+// domains, which are disabled only if nothing else requires them.
+func (b *ErrorBudget) Stop() {
+	b.unsubscribe()
+	for _, release := range b.releases {
+		release()
+	}
+	<-b.done
+}
+
+// Return a short description of the arguments of a console call.
+func consoleText(args []runtime.RemoteObject) string {
+	var parts []string
+	for _, a := range args {
+		var s string
+		if a.Type == "string" && json.Unmarshal(a.Value, &s) == nil {
+			parts = append(parts, s)
+		} else if a.Description != "" {
+			parts = append(parts, a.Description)
+		} else {
+			parts = append(parts, string(a.Value))
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,44 @@
+package crawl
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestErrorBudget(t *testing.T) {
+	exceeded := make(chan *BudgetExceededError, 1)
+	b := &ErrorBudget{limits: make(map[ErrorKind]int), times: make(map[ErrorKind][]time.Time)}
+	MaxErrors(FailedRequest, 2)(b)
+	BudgetWindow(time.Minute)(b)
+	OnExceeded(func(_ context.Context, e *BudgetExceededError) { exceeded <- e })(b)
+
+	t0 := time.Now()
+	b.record(FailedRequest, t0, "Script request net::ERR_FAILED")
+	b.record(FailedRequest, t0.Add(10*time.Second), "Script request net::ERR_FAILED")
+	// The first error is outside the window of the third one.
+	b.record(FailedRequest, t0.Add(65*time.Second), "Script request net::ERR_FAILED")
+	for i := 0; i < 10; i++ {
+		b.record(ConsoleError, t0, "oops") // Unlimited.
+	}
+	if err := b.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil", err)
+	}
+
+	b.record(FailedRequest, t0.Add(66*time.Second), "Image request net::ERR_NAME_NOT_RESOLVED")
+	select {
+	case e := <-exceeded:
+		want := "error budget exceeded: 3 failed requests in 1m0s (limit: 2), last: Image request net::ERR_NAME_NOT_RESOLVED"
+		if e.Error() != want {
+			t.Errorf("BudgetExceededError = %q, want %q", e.Error(), want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnExceeded() callback wasn't called")
+	}
+	if b.Err() == nil {
+		t.Error("Err() = nil, want BudgetExceededError")
+	}
+	if n := len(b.times[FailedRequest]); n != 0 {
+		t.Errorf("failed requests count after exceeding = %d, want 0", n)
+	}
+}
@@ -0,0 +1,275 @@
+// Package crawl provides building blocks for polite and robust web crawlers
+// on top of the Chrome DevTools Protocol (CDP) bindings in the devtools
+// package: "robots.txt" parsing and caching, per-host concurrency and delay
+// limits which are applied before each page navigation, error budgets for
+// long-running sessions, and fetching of "robots.txt", sitemap and favicon
+// files through the browser's network stack.
+package crawl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+)
+
+// DefaultUserAgent is the product token used to select "robots.txt" rules,
+// and to fetch "robots.txt" files, unless the `crawl.UserAgent` option
+// overrides it.
+const DefaultUserAgent = "chrome-vision"
+
+// ErrDisallowed is returned by `Governor.Navigate` when the target URL is
+// disallowed by the "robots.txt" file of its origin.
+var ErrDisallowed = errors.New("disallowed by robots.txt")
+
+// Governor applies "robots.txt" rules and per-host rate limits to page
+// navigations. Multiple goroutines, with different browser contexts,
+// may share the same governor simultaneously.
+type Governor struct {
+	userAgent   string
+	client      *http.Client
+	concurrency int
+	delay       time.Duration
+	robotsTTL   time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+type hostState struct {
+	slots chan struct{} // Semaphore for concurrent navigations.
+
+	mu       sync.Mutex
+	next     time.Time // Earliest time for the next navigation.
+	robots   *Robots
+	fetched  time.Time
+	fetching chan struct{} // Closed when the current fetch of "robots.txt" ends.
+}
+
+// Option is used for customization in the `crawl.NewGovernor` function.
+//
+// See https://commandcenter.blogspot.com/2014/01/self-referential-functions-and-design.html.
+type Option = func(*Governor)
+
+// NewGovernor constructs a new crawl governor. By default, it allows only one
+// navigation at a time per host, with a delay of 1 second between them (or
+// longer, if the host's "robots.txt" file specifies a longer "Crawl-delay"),
+// and caches "robots.txt" files for 24 hours.
+func NewGovernor(opts ...Option) *Governor {
+	g := &Governor{
+		userAgent:   DefaultUserAgent,
+		client:      http.DefaultClient,
+		concurrency: 1,
+		delay:       time.Second,
+		robotsTTL:   24 * time.Hour,
+		hosts:       make(map[string]*hostState),
+	}
+	for _, o := range opts {
+		o(g)
+	}
+	return g
+}
+
+// UserAgent allows the caller of the `crawl.NewGovernor` function to
+// customize the product token which is used to select "robots.txt" rules.
+func UserAgent(ua string) Option {
+	return func(g *Governor) {
+		g.userAgent = ua
+	}
+}
+
+// HTTPClient allows the caller of the `crawl.NewGovernor` function to
+// customize the HTTP client which is used to fetch "robots.txt" files.
+func HTTPClient(c *http.Client) Option {
+	return func(g *Governor) {
+		g.client = c
+	}
+}
+
+// MaxConcurrency allows the caller of the `crawl.NewGovernor` function to
+// customize the maximum number of simultaneous navigations per host.
+func MaxConcurrency(n int) Option {
+	return func(g *Governor) {
+		if n > 0 {
+			g.concurrency = n
+		}
+	}
+}
+
+// MinDelay allows the caller of the `crawl.NewGovernor` function to customize
+// the minimum delay between the starts of consecutive navigations per host.
+// The "Crawl-delay" in a host's "robots.txt" file takes precedence if longer.
+func MinDelay(d time.Duration) Option {
+	return func(g *Governor) {
+		g.delay = d
+	}
+}
+
+// RobotsTTL allows the caller of the `crawl.NewGovernor` function to
+// customize how long fetched "robots.txt" files are cached.
+func RobotsTTL(d time.Duration) Option {
+	return func(g *Governor) {
+		g.robotsTTL = d
+	}
+}
+
+// Navigate navigates the browser tab which is associated with the given
+// context to the given URL, if it's allowed by the "robots.txt" file of
+// the URL's origin, and as soon as the per-host rate limits allow it.
+//
+// It returns `crawl.ErrDisallowed` (wrapped) if the URL is disallowed, or
+// the context's error if it's done before the navigation could start.
+func (g *Governor) Navigate(ctx context.Context, rawURL string) (*page.NavigateResult, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := g.Allowed(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%w: %s", ErrDisallowed, rawURL)
+	}
+
+	h := g.host(u)
+	select {
+	case h.slots <- struct{}{}:
+		defer func() { <-h.slots }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if err := g.wait(ctx, h); err != nil {
+		return nil, err
+	}
+	return page.NewNavigate(rawURL).Do(ctx)
+}
+
+// Allowed reports whether the given URL may be crawled according to the
+// "robots.txt" file of its origin, which is fetched and cached if needed.
+// Origins without a "robots.txt" file (HTTP status 4xx) allow everything.
+func (g *Governor) Allowed(ctx context.Context, u *url.URL) (bool, error) {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return true, nil
+	}
+	r, err := g.robots(ctx, u)
+	if err != nil {
+		return false, err
+	}
+	return r.Allowed(g.userAgent, u.RequestURI()), nil
+}
+
+// Return the cached "robots.txt" file of the given URL's origin, or fetch it
+// if needed. The host's mutex isn't held during the fetch, so it doesn't
+// block the rate limiting of other navigations, and concurrent callers
+// wait for the same fetch instead of repeating it.
+func (g *Governor) robots(ctx context.Context, u *url.URL) (*Robots, error) {
+	h := g.host(u)
+	for {
+		h.mu.Lock()
+		if h.robots != nil && time.Since(h.fetched) <= g.robotsTTL {
+			r := h.robots
+			h.mu.Unlock()
+			return r, nil
+		}
+		fetching := h.fetching
+		if fetching == nil {
+			break // Still locked.
+		}
+		h.mu.Unlock()
+		select {
+		case <-fetching:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	fetching := make(chan struct{})
+	h.fetching = fetching
+	h.mu.Unlock()
+
+	r, err := g.fetchRobots(ctx, u)
+	h.mu.Lock()
+	h.fetching = nil
+	// Failures aren't cached, the next call will try again.
+	if err == nil {
+		h.robots, h.fetched = r, time.Now()
+	}
+	h.mu.Unlock()
+	close(fetching)
+	return r, err
+}
+
+// Return the state of the given URL's host, and initialize it if needed.
+func (g *Governor) host(u *url.URL) *hostState {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	key := u.Scheme + "://" + u.Host
+	h, ok := g.hosts[key]
+	if !ok {
+		h = &hostState{slots: make(chan struct{}, g.concurrency)}
+		g.hosts[key] = h
+	}
+	return h
+}
+
+// Wait until the delay since the previous navigation to the same host
+// has passed, and reserve the next time slot.
+func (g *Governor) wait(ctx context.Context, h *hostState) error {
+	h.mu.Lock()
+	delay := g.delay
+	if d := h.robots.CrawlDelay(g.userAgent); d > delay {
+		delay = d
+	}
+	start := time.Now()
+	if h.next.After(start) {
+		start = h.next
+	}
+	h.next = start.Add(delay)
+	h.mu.Unlock()
+
+	timer := time.NewTimer(time.Until(start))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Fetch and parse the "robots.txt" file of the given URL's origin.
+func (g *Governor) fetchRobots(ctx context.Context, u *url.URL) (*Robots, error) {
+	robotsURL := u.Scheme + "://" + u.Host + "/robots.txt"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", g.userAgent)
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %v", robotsURL, err)
+	}
+	defer resp.Body.Close()
+	return robotsResponse(robotsURL, resp.StatusCode, resp.Body)
+}
+
+// Parse the response to a "robots.txt" request, according to its
+// HTTP status code, as specified in
+// https://www.rfc-editor.org/rfc/rfc9309.html#name-access-results.
+func robotsResponse(robotsURL string, status int, body io.Reader) (*Robots, error) {
+	switch {
+	case status >= 500:
+		return nil, fmt.Errorf("failed to fetch %s: HTTP status %d", robotsURL, status)
+	case status >= 400:
+		log.Printf("No %s (HTTP status %d)", robotsURL, status)
+		return &Robots{}, nil
+	}
+	return ParseRobots(body), nil
+}
@@ -0,0 +1,60 @@
+package crawl_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/crawl"
+)
+
+func TestGovernorAllowed(t *testing.T) {
+	// Set up.
+	var fetches int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		w.Write([]byte("User-agent: *\nDisallow: /private\n"))
+	}))
+	defer srv.Close()
+	g := crawl.NewGovernor(crawl.HTTPClient(srv.Client()))
+	private, _ := url.Parse(srv.URL + "/private")
+	public, _ := url.Parse(srv.URL + "/public")
+
+	// Test: concurrent callers wait for the same fetch.
+	var wg sync.WaitGroup
+	for _, u := range []*url.URL{private, public, private} {
+		wg.Add(1)
+		go func(u *url.URL) {
+			defer wg.Done()
+			allowed, err := g.Allowed(context.Background(), u)
+			if err != nil {
+				t.Errorf("Allowed(%s); got error: %v", u, err)
+			}
+			if want := u == public; allowed != want {
+				t.Errorf("Allowed(%s) = %v, want %v", u, allowed, want)
+			}
+		}(u)
+	}
+	// Test: a waiting caller's context may end before the fetch.
+	for atomic.LoadInt32(&fetches) == 0 {
+		runtime.Gosched()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := g.Allowed(ctx, public); err != context.Canceled {
+		t.Errorf("Allowed() with canceled context; got error %v, want %v", err, context.Canceled)
+	}
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&fetches); n != 1 {
+		t.Errorf("robots.txt fetched %d times, want 1", n)
+	}
+}
@@ -0,0 +1,204 @@
+package crawl
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Robots contains the parsed rules of a "robots.txt" file, based on
+// https://www.rfc-editor.org/rfc/rfc9309.html (Robots Exclusion Protocol)
+// and the widely supported "Crawl-delay" extension.
+type Robots struct {
+	groups []robotsGroup
+	// Sitemaps lists the URLs of all the "Sitemap" lines in the file.
+	Sitemaps []string
+}
+
+type robotsGroup struct {
+	userAgents []string
+	rules      []robotsRule
+	crawlDelay time.Duration
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+}
+
+// ParseRobots parses the content of a "robots.txt" file. Unrecognized
+// or malformed lines are ignored, as required by the specification.
+func ParseRobots(r io.Reader) *Robots {
+	robots := &Robots{}
+	var g *robotsGroup
+	inUserAgents := false // Consecutive "User-agent" lines form one group.
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		kv := strings.SplitN(line, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(kv[0]))
+		value := strings.TrimSpace(kv[1])
+
+		switch key {
+		case "user-agent":
+			if !inUserAgents {
+				robots.groups = append(robots.groups, robotsGroup{})
+				g = &robots.groups[len(robots.groups)-1]
+			}
+			g.userAgents = append(g.userAgents, productToken(value))
+			inUserAgents = true
+		case "allow", "disallow":
+			inUserAgents = false
+			if g == nil {
+				continue // Rules without a preceding "User-agent" line.
+			}
+			if value == "" {
+				continue // An empty "Disallow" rule means "allow all".
+			}
+			g.rules = append(g.rules, robotsRule{allow: key == "allow", pattern: value})
+		case "crawl-delay":
+			inUserAgents = false
+			if g == nil {
+				continue
+			}
+			if secs, err := strconv.ParseFloat(value, 64); err == nil && secs > 0 {
+				g.crawlDelay = time.Duration(secs * float64(time.Second))
+			}
+		case "sitemap":
+			robots.Sitemaps = append(robots.Sitemaps, value)
+		}
+	}
+	return robots
+}
+
+// Return the product token of the given user agent in lowercase, without
+// its version, e.g. "foobot" for "FooBot/1.0", because the specification
+// requires case-insensitive matching of product tokens.
+func productToken(userAgent string) string {
+	if i := strings.IndexByte(userAgent, '/'); i >= 0 {
+		userAgent = userAgent[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(userAgent))
+}
+
+// Return the group which applies to the given user agent: the groups with the
+// same product token, or else the "*" groups, combined into one group as
+// required by the specification, or nil if none match.
+func (r *Robots) group(userAgent string) *robotsGroup {
+	token := productToken(userAgent)
+	var match, wildcard *robotsGroup
+	for i := range r.groups {
+		g := &r.groups[i]
+		if g.has(token) {
+			match = combine(match, g)
+		} else if g.has("*") {
+			wildcard = combine(wildcard, g)
+		}
+	}
+	if match != nil {
+		return match
+	}
+	return wildcard
+}
+
+func (g *robotsGroup) has(userAgent string) bool {
+	for _, ua := range g.userAgents {
+		if ua == userAgent {
+			return true
+		}
+	}
+	return false
+}
+
+// Combine the rules of two groups, and the longer crawl delay of them.
+func combine(a, b *robotsGroup) *robotsGroup {
+	if a == nil {
+		return b
+	}
+	c := &robotsGroup{rules: append(append([]robotsRule(nil), a.rules...), b.rules...), crawlDelay: a.crawlDelay}
+	if b.crawlDelay > c.crawlDelay {
+		c.crawlDelay = b.crawlDelay
+	}
+	return c
+}
+
+// Allowed reports whether the given user agent may crawl the given path
+// (which may include a query string). The most specific (longest) matching
+// rule wins, and "Allow" wins ties, as required by the specification.
+func (r *Robots) Allowed(userAgent, path string) bool {
+	if r == nil {
+		return true
+	}
+	if path == "" {
+		path = "/"
+	}
+	if path == "/robots.txt" {
+		return true
+	}
+	g := r.group(userAgent)
+	if g == nil {
+		return true
+	}
+	allowed, longest := true, -1
+	for _, rule := range g.rules {
+		if !matchPattern(rule.pattern, path) {
+			continue
+		}
+		n := len(rule.pattern)
+		if n > longest || (n == longest && rule.allow) {
+			allowed, longest = rule.allow, n
+		}
+	}
+	return allowed
+}
+
+// CrawlDelay returns the minimum delay between consecutive requests which
+// the given user agent is asked to respect, or 0 if it isn't specified.
+func (r *Robots) CrawlDelay(userAgent string) time.Duration {
+	if r == nil {
+		return 0
+	}
+	if g := r.group(userAgent); g != nil {
+		return g.crawlDelay
+	}
+	return 0
+}
+
+// Match a path against a rule pattern, which may contain the special
+// characters "*" (any sequence of characters) and "$" (end of the path).
+func matchPattern(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+	parts := strings.Split(pattern, "*")
+
+	// The first part must be a prefix of the path.
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	path = path[len(parts[0]):]
+	if len(parts) == 1 {
+		return !anchored || path == ""
+	}
+	// Each subsequent part must appear in order.
+	for i, p := range parts[1:] {
+		last := i == len(parts)-2
+		if last && anchored {
+			return strings.HasSuffix(path, p)
+		}
+		j := strings.Index(path, p)
+		if j < 0 {
+			return false
+		}
+		path = path[j+len(p):]
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package crawl_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/crawl"
+)
+
+const robotsTxt = `# Comment.
+User-agent: FooBot
+User-agent: BarBot
+Disallow: /private
+Allow: /private/public$
+Crawl-delay: 2.5
+
+User-agent: *
+Disallow: /*.pdf$
+Disallow: /tmp/
+Allow: /tmp/ok
+
+Sitemap: https://example.com/sitemap.xml
+`
+
+func TestRobotsAllowed(t *testing.T) {
+	r := crawl.ParseRobots(strings.NewReader(robotsTxt))
+	tests := []struct {
+		ua, path string
+		want     bool
+	}{
+		{"FooBot/1.0", "/", true},
+		{"foobot", "/private", false},
+		{"SuperFooBot/1.0", "/private", true},
+		{"Foo", "/private", true},
+		{"FooBot/1.0", "/private", false},
+		{"FooBot/1.0", "/private/x", false},
+		{"BarBot", "/private/public", true},
+		{"BarBot", "/private/public/x", false},
+		{"BarBot", "/tmp/x", true},
+		{"chrome-vision", "/tmp/x", false},
+		{"chrome-vision", "/tmp/ok", true},
+		{"chrome-vision", "/a/b.pdf", false},
+		{"chrome-vision", "/a/b.pdf?x=1", true},
+		{"chrome-vision", "/robots.txt", true},
+	}
+	for _, tt := range tests {
+		if got := r.Allowed(tt.ua, tt.path); got != tt.want {
+			t.Errorf("Allowed(%q, %q) = %v, want %v", tt.ua, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRobotsCrawlDelay(t *testing.T) {
+	r := crawl.ParseRobots(strings.NewReader(robotsTxt))
+	if got, want := r.CrawlDelay("barbot"), 2500*time.Millisecond; got != want {
+		t.Errorf(`CrawlDelay("barbot") = %v, want %v`, got, want)
+	}
+	if got := r.CrawlDelay("chrome-vision"); got != 0 {
+		t.Errorf(`CrawlDelay("chrome-vision") = %v, want 0`, got)
+	}
+	if len(r.Sitemaps) != 1 {
+		t.Errorf("len(Sitemaps) = %d, want 1", len(r.Sitemaps))
+	}
+}
+
+func TestRobotsCombinedGroups(t *testing.T) {
+	r := crawl.ParseRobots(strings.NewReader(`User-agent: FooBot/2.0
+Disallow: /a
+Crawl-delay: 1
+
+User-agent: *
+Disallow: /c
+
+User-agent: foobot
+Disallow: /b
+Crawl-delay: 3
+`))
+	for _, path := range []string{"/a", "/b"} {
+		if r.Allowed("FooBot", path) {
+			t.Errorf(`Allowed("FooBot", %q) = true, want false`, path)
+		}
+	}
+	if !r.Allowed("FooBot", "/c") {
+		t.Error(`Allowed("FooBot", "/c") = false, want true`)
+	}
+	if got, want := r.CrawlDelay("FooBot"), 3*time.Second; got != want {
+		t.Errorf(`CrawlDelay("FooBot") = %v, want %v`, got, want)
+	}
+}
@@ -114,20 +114,30 @@ type GetPartialAXTree struct {
 	// JavaScript object id of the node wrapper to get the partial accessibility tree for.
 	ObjectID *runtime.RemoteObjectID `json:"objectId,omitempty"`
 	// Whether to fetch this nodes ancestors, siblings and children. Defaults to true.
-	FetchRelatives bool `json:"fetchRelatives,omitempty"`
+	FetchRelatives *bool `json:"fetchRelatives,omitempty"`
 }
 
 // NewGetPartialAXTree constructs a new GetPartialAXTree struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetPartialAXTreeOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-getPartialAXTree
 //
 // This CDP method is experimental.
-func NewGetPartialAXTree() *GetPartialAXTree {
-	return &GetPartialAXTree{}
+func NewGetPartialAXTree(opts ...GetPartialAXTreeOption) *GetPartialAXTree {
+	c := &GetPartialAXTree{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetPartialAXTreeOption is a functional option for the optional
+// parameters of the GetPartialAXTree CDP command, see the
+// NewGetPartialAXTree constructor. Options are applied in order.
+type GetPartialAXTreeOption = func(*GetPartialAXTree)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the GetPartialAXTree CDP command.
 //
@@ -137,6 +147,14 @@ func (t *GetPartialAXTree) SetNodeID(v int64) *GetPartialAXTree {
 	return t
 }
 
+// GetPartialAXTreeWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the GetPartialAXTree CDP command, see SetNodeID.
+func GetPartialAXTreeWithNodeID(v int64) GetPartialAXTreeOption {
+	return func(c *GetPartialAXTree) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the GetPartialAXTree CDP command.
 //
@@ -146,6 +164,14 @@ func (t *GetPartialAXTree) SetBackendNodeID(v int64) *GetPartialAXTree {
 	return t
 }
 
+// GetPartialAXTreeWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the GetPartialAXTree CDP command, see SetBackendNodeID.
+func GetPartialAXTreeWithBackendNodeID(v int64) GetPartialAXTreeOption {
+	return func(c *GetPartialAXTree) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the GetPartialAXTree CDP command.
 //
@@ -155,15 +181,31 @@ func (t *GetPartialAXTree) SetObjectID(v runtime.RemoteObjectID) *GetPartialAXTr
 	return t
 }
 
+// GetPartialAXTreeWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the GetPartialAXTree CDP command, see SetObjectID.
+func GetPartialAXTreeWithObjectID(v runtime.RemoteObjectID) GetPartialAXTreeOption {
+	return func(c *GetPartialAXTree) {
+		c.SetObjectID(v)
+	}
+}
+
 // SetFetchRelatives adds or modifies the value of the optional
 // parameter `fetchRelatives` in the GetPartialAXTree CDP command.
 //
 // Whether to fetch this nodes ancestors, siblings and children. Defaults to true.
 func (t *GetPartialAXTree) SetFetchRelatives(v bool) *GetPartialAXTree {
-	t.FetchRelatives = v
+	t.FetchRelatives = &v
 	return t
 }
 
+// GetPartialAXTreeWithFetchRelatives is a functional option which sets the value of the
+// optional parameter `fetchRelatives` in the GetPartialAXTree CDP command, see SetFetchRelatives.
+func GetPartialAXTreeWithFetchRelatives(v bool) GetPartialAXTreeOption {
+	return func(c *GetPartialAXTree) {
+		c.SetFetchRelatives(v)
+	}
+}
+
 // GetPartialAXTreeResult contains the browser's response
 // to calling the GetPartialAXTree CDP command with Do().
 type GetPartialAXTreeResult struct {
@@ -234,15 +276,25 @@ type GetFullAXTree struct {
 
 // NewGetFullAXTree constructs a new GetFullAXTree struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetFullAXTreeOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-getFullAXTree
 //
 // This CDP method is experimental.
-func NewGetFullAXTree() *GetFullAXTree {
-	return &GetFullAXTree{}
+func NewGetFullAXTree(opts ...GetFullAXTreeOption) *GetFullAXTree {
+	c := &GetFullAXTree{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetFullAXTreeOption is a functional option for the optional
+// parameters of the GetFullAXTree CDP command, see the
+// NewGetFullAXTree constructor. Options are applied in order.
+type GetFullAXTreeOption = func(*GetFullAXTree)
+
 // SetDepth adds or modifies the value of the optional
 // parameter `depth` in the GetFullAXTree CDP command.
 //
@@ -253,6 +305,14 @@ func (t *GetFullAXTree) SetDepth(v int64) *GetFullAXTree {
 	return t
 }
 
+// GetFullAXTreeWithDepth is a functional option which sets the value of the
+// optional parameter `depth` in the GetFullAXTree CDP command, see SetDepth.
+func GetFullAXTreeWithDepth(v int64) GetFullAXTreeOption {
+	return func(c *GetFullAXTree) {
+		c.SetDepth(v)
+	}
+}
+
 // SetMaxDepth adds or modifies the value of the optional
 // parameter `max_depth` in the GetFullAXTree CDP command.
 //
@@ -264,6 +324,14 @@ func (t *GetFullAXTree) SetMaxDepth(v int64) *GetFullAXTree {
 	return t
 }
 
+// GetFullAXTreeWithMaxDepth is a functional option which sets the value of the
+// optional parameter `max_depth` in the GetFullAXTree CDP command, see SetMaxDepth.
+func GetFullAXTreeWithMaxDepth(v int64) GetFullAXTreeOption {
+	return func(c *GetFullAXTree) {
+		c.SetMaxDepth(v)
+	}
+}
+
 // SetFrameID adds or modifies the value of the optional
 // parameter `frameId` in the GetFullAXTree CDP command.
 //
@@ -274,6 +342,14 @@ func (t *GetFullAXTree) SetFrameID(v string) *GetFullAXTree {
 	return t
 }
 
+// GetFullAXTreeWithFrameID is a functional option which sets the value of the
+// optional parameter `frameId` in the GetFullAXTree CDP command, see SetFrameID.
+func GetFullAXTreeWithFrameID(v string) GetFullAXTreeOption {
+	return func(c *GetFullAXTree) {
+		c.SetFrameID(v)
+	}
+}
+
 // GetFullAXTreeResult contains the browser's response
 // to calling the GetFullAXTree CDP command with Do().
 type GetFullAXTreeResult struct {
@@ -336,15 +412,25 @@ type GetRootAXNode struct {
 
 // NewGetRootAXNode constructs a new GetRootAXNode struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetRootAXNodeOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-getRootAXNode
 //
 // This CDP method is experimental.
-func NewGetRootAXNode() *GetRootAXNode {
-	return &GetRootAXNode{}
+func NewGetRootAXNode(opts ...GetRootAXNodeOption) *GetRootAXNode {
+	c := &GetRootAXNode{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetRootAXNodeOption is a functional option for the optional
+// parameters of the GetRootAXNode CDP command, see the
+// NewGetRootAXNode constructor. Options are applied in order.
+type GetRootAXNodeOption = func(*GetRootAXNode)
+
 // SetFrameID adds or modifies the value of the optional
 // parameter `frameId` in the GetRootAXNode CDP command.
 //
@@ -355,6 +441,14 @@ func (t *GetRootAXNode) SetFrameID(v string) *GetRootAXNode {
 	return t
 }
 
+// GetRootAXNodeWithFrameID is a functional option which sets the value of the
+// optional parameter `frameId` in the GetRootAXNode CDP command, see SetFrameID.
+func GetRootAXNodeWithFrameID(v string) GetRootAXNodeOption {
+	return func(c *GetRootAXNode) {
+		c.SetFrameID(v)
+	}
+}
+
 // GetRootAXNodeResult contains the browser's response
 // to calling the GetRootAXNode CDP command with Do().
 type GetRootAXNodeResult struct {
@@ -420,15 +514,25 @@ type GetAXNodeAndAncestors struct {
 
 // NewGetAXNodeAndAncestors constructs a new GetAXNodeAndAncestors struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetAXNodeAndAncestorsOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-getAXNodeAndAncestors
 //
 // This CDP method is experimental.
-func NewGetAXNodeAndAncestors() *GetAXNodeAndAncestors {
-	return &GetAXNodeAndAncestors{}
+func NewGetAXNodeAndAncestors(opts ...GetAXNodeAndAncestorsOption) *GetAXNodeAndAncestors {
+	c := &GetAXNodeAndAncestors{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetAXNodeAndAncestorsOption is a functional option for the optional
+// parameters of the GetAXNodeAndAncestors CDP command, see the
+// NewGetAXNodeAndAncestors constructor. Options are applied in order.
+type GetAXNodeAndAncestorsOption = func(*GetAXNodeAndAncestors)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the GetAXNodeAndAncestors CDP command.
 //
@@ -438,6 +542,14 @@ func (t *GetAXNodeAndAncestors) SetNodeID(v int64) *GetAXNodeAndAncestors {
 	return t
 }
 
+// GetAXNodeAndAncestorsWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the GetAXNodeAndAncestors CDP command, see SetNodeID.
+func GetAXNodeAndAncestorsWithNodeID(v int64) GetAXNodeAndAncestorsOption {
+	return func(c *GetAXNodeAndAncestors) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the GetAXNodeAndAncestors CDP command.
 //
@@ -447,6 +559,14 @@ func (t *GetAXNodeAndAncestors) SetBackendNodeID(v int64) *GetAXNodeAndAncestors
 	return t
 }
 
+// GetAXNodeAndAncestorsWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the GetAXNodeAndAncestors CDP command, see SetBackendNodeID.
+func GetAXNodeAndAncestorsWithBackendNodeID(v int64) GetAXNodeAndAncestorsOption {
+	return func(c *GetAXNodeAndAncestors) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the GetAXNodeAndAncestors CDP command.
 //
@@ -456,6 +576,14 @@ func (t *GetAXNodeAndAncestors) SetObjectID(v runtime.RemoteObjectID) *GetAXNode
 	return t
 }
 
+// GetAXNodeAndAncestorsWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the GetAXNodeAndAncestors CDP command, see SetObjectID.
+func GetAXNodeAndAncestorsWithObjectID(v runtime.RemoteObjectID) GetAXNodeAndAncestorsOption {
+	return func(c *GetAXNodeAndAncestors) {
+		c.SetObjectID(v)
+	}
+}
+
 // GetAXNodeAndAncestorsResult contains the browser's response
 // to calling the GetAXNodeAndAncestors CDP command with Do().
 type GetAXNodeAndAncestorsResult struct {
@@ -519,17 +647,27 @@ type GetChildAXNodes struct {
 
 // NewGetChildAXNodes constructs a new GetChildAXNodes struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetChildAXNodesOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-getChildAXNodes
 //
 // This CDP method is experimental.
-func NewGetChildAXNodes(id string) *GetChildAXNodes {
-	return &GetChildAXNodes{
+func NewGetChildAXNodes(id string, opts ...GetChildAXNodesOption) *GetChildAXNodes {
+	c := &GetChildAXNodes{
 		ID: id,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetChildAXNodesOption is a functional option for the optional
+// parameters of the GetChildAXNodes CDP command, see the
+// NewGetChildAXNodes constructor. Options are applied in order.
+type GetChildAXNodesOption = func(*GetChildAXNodes)
+
 // SetFrameID adds or modifies the value of the optional
 // parameter `frameId` in the GetChildAXNodes CDP command.
 //
@@ -540,6 +678,14 @@ func (t *GetChildAXNodes) SetFrameID(v string) *GetChildAXNodes {
 	return t
 }
 
+// GetChildAXNodesWithFrameID is a functional option which sets the value of the
+// optional parameter `frameId` in the GetChildAXNodes CDP command, see SetFrameID.
+func GetChildAXNodesWithFrameID(v string) GetChildAXNodesOption {
+	return func(c *GetChildAXNodes) {
+		c.SetFrameID(v)
+	}
+}
+
 // GetChildAXNodesResult contains the browser's response
 // to calling the GetChildAXNodes CDP command with Do().
 type GetChildAXNodesResult struct {
@@ -612,15 +758,25 @@ type QueryAXTree struct {
 
 // NewQueryAXTree constructs a new QueryAXTree struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `QueryAXTreeOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Accessibility/#method-queryAXTree
 //
 // This CDP method is experimental.
-func NewQueryAXTree() *QueryAXTree {
-	return &QueryAXTree{}
+func NewQueryAXTree(opts ...QueryAXTreeOption) *QueryAXTree {
+	c := &QueryAXTree{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// QueryAXTreeOption is a functional option for the optional
+// parameters of the QueryAXTree CDP command, see the
+// NewQueryAXTree constructor. Options are applied in order.
+type QueryAXTreeOption = func(*QueryAXTree)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the QueryAXTree CDP command.
 //
@@ -630,6 +786,14 @@ func (t *QueryAXTree) SetNodeID(v int64) *QueryAXTree {
 	return t
 }
 
+// QueryAXTreeWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the QueryAXTree CDP command, see SetNodeID.
+func QueryAXTreeWithNodeID(v int64) QueryAXTreeOption {
+	return func(c *QueryAXTree) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the QueryAXTree CDP command.
 //
@@ -639,6 +803,14 @@ func (t *QueryAXTree) SetBackendNodeID(v int64) *QueryAXTree {
 	return t
 }
 
+// QueryAXTreeWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the QueryAXTree CDP command, see SetBackendNodeID.
+func QueryAXTreeWithBackendNodeID(v int64) QueryAXTreeOption {
+	return func(c *QueryAXTree) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the QueryAXTree CDP command.
 //
@@ -648,6 +820,14 @@ func (t *QueryAXTree) SetObjectID(v runtime.RemoteObjectID) *QueryAXTree {
 	return t
 }
 
+// QueryAXTreeWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the QueryAXTree CDP command, see SetObjectID.
+func QueryAXTreeWithObjectID(v runtime.RemoteObjectID) QueryAXTreeOption {
+	return func(c *QueryAXTree) {
+		c.SetObjectID(v)
+	}
+}
+
 // SetAccessibleName adds or modifies the value of the optional
 // parameter `accessibleName` in the QueryAXTree CDP command.
 //
@@ -657,6 +837,14 @@ func (t *QueryAXTree) SetAccessibleName(v string) *QueryAXTree {
 	return t
 }
 
+// QueryAXTreeWithAccessibleName is a functional option which sets the value of the
+// optional parameter `accessibleName` in the QueryAXTree CDP command, see SetAccessibleName.
+func QueryAXTreeWithAccessibleName(v string) QueryAXTreeOption {
+	return func(c *QueryAXTree) {
+		c.SetAccessibleName(v)
+	}
+}
+
 // SetRole adds or modifies the value of the optional
 // parameter `role` in the QueryAXTree CDP command.
 //
@@ -666,6 +854,14 @@ func (t *QueryAXTree) SetRole(v string) *QueryAXTree {
 	return t
 }
 
+// QueryAXTreeWithRole is a functional option which sets the value of the
+// optional parameter `role` in the QueryAXTree CDP command, see SetRole.
+func QueryAXTreeWithRole(v string) QueryAXTreeOption {
+	return func(c *QueryAXTree) {
+		c.SetRole(v)
+	}
+}
+
 // QueryAXTreeResult contains the browser's response
 // to calling the QueryAXTree CDP command with Do().
 type QueryAXTreeResult struct {
@@ -0,0 +1,398 @@
+package accessibility
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"strings"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/css"
+	"github.com/daabr/chrome-vision/pkg/devtools/dom"
+	"github.com/daabr/chrome-vision/pkg/devtools/input"
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// DefaultMaxFocusStops is the default maximum number of Tab keypresses
+// in the `accessibility.AuditKeyboard` function.
+const DefaultMaxFocusStops = 500
+
+// Elements which users expect to reach with the keyboard, unless they're
+// hidden, disabled, inert, or removed from the tab order with a negative
+// "tabindex" attribute.
+const interactiveSelector = `a[href], area[href], button, input:not([type=hidden]), select, textarea, ` +
+	`summary, iframe, audio[controls], video[controls], [contenteditable]:not([contenteditable=false]), [tabindex]`
+
+// Describe an element, and check whether it should be reachable.
+const describeElement = `function() {
+	const attr = n => (this.getAttribute(n) || '').trim();
+	const text = attr('aria-label') || (this.innerText || '').trim() ||
+		(typeof this.value === 'string' ? this.value : '') ||
+		attr('title') || attr('alt') || attr('placeholder');
+	const style = getComputedStyle(this);
+	const visible = this.getClientRects().length > 0 && style.visibility !== 'hidden';
+	return {
+		tag: this.localName,
+		label: text.replace(/\s+/g, ' ').slice(0, 100),
+		reachable: visible && !this.disabled && this.tabIndex >= 0 && !this.closest('[inert]'),
+	};
+}`
+
+// The focused element, including inside shadow trees, or null if the
+// focus isn't in an element of the page.
+const activeElement = `(() => {
+	let e = document.activeElement;
+	while (e && e.shadowRoot && e.shadowRoot.activeElement) {
+		e = e.shadowRoot.activeElement;
+	}
+	return e === document.body || e === document.documentElement ? null : e;
+})()`
+
+const blurActiveElement = `document.activeElement && document.activeElement.blur()`
+
+// CSS properties which typically indicate keyboard focus visually.
+var focusIndicatorProperties = map[string]bool{
+	"outline-style": true, "outline-width": true, "outline-color": true, "outline-offset": true,
+	"box-shadow": true, "background-color": true, "color": true, "text-decoration-line": true,
+	"border-top-color": true, "border-right-color": true, "border-bottom-color": true, "border-left-color": true,
+	"border-top-width": true, "border-right-width": true, "border-bottom-width": true, "border-left-width": true,
+}
+
+// KeyboardElement is an interactive element in an
+// `accessibility.AuditKeyboard` report.
+type KeyboardElement struct {
+	// Unique CSS selector of the element, see `dom.SelectorFor`.
+	Selector      string
+	BackendNodeID int64
+	// Tag name, e.g. "a" or "button".
+	Tag string
+	// Short text which identifies the element: its ARIA label, text,
+	// value, title, alternative text or placeholder.
+	Label string
+}
+
+// FocusStop is an element which received keyboard focus in an
+// `accessibility.AuditKeyboard` report.
+type FocusStop struct {
+	KeyboardElement
+	// CSS properties whose computed values differ when the element is
+	// focused, e.g. "outline-style" or "box-shadow". If there aren't any,
+	// sighted keyboard users can't see where the focus is.
+	IndicatorProperties []string
+	// PNG image of the focused element and its surroundings, only with the
+	// `accessibility.FocusScreenshots` option.
+	Screenshot []byte
+}
+
+// HasFocusIndicator reports whether focusing the element changes its style.
+func (s *FocusStop) HasFocusIndicator() bool {
+	return len(s.IndicatorProperties) > 0
+}
+
+// KeyboardAudit is the report of the `accessibility.AuditKeyboard` function.
+type KeyboardAudit struct {
+	// Elements in the order which the Tab key focuses them, starting
+	// from the top of the page, without repetitions.
+	FocusOrder []FocusStop
+	// Visible and enabled interactive elements (e.g. links, buttons and
+	// form fields) which the Tab key didn't focus, in document order.
+	Unreachable []KeyboardElement
+	// Whether the focus got stuck in a subset of the focusable elements,
+	// which the user can't leave with the Tab key (a keyboard trap).
+	Trapped bool
+}
+
+// MissingFocusIndicators returns the focus stops
+// which don't have a visible focus indicator.
+func (a *KeyboardAudit) MissingFocusIndicators() []FocusStop {
+	var stops []FocusStop
+	for _, s := range a.FocusOrder {
+		if !s.HasFocusIndicator() {
+			stops = append(stops, s)
+		}
+	}
+	return stops
+}
+
+type keyboardAuditConfig struct {
+	maxStops    int
+	screenshots bool
+}
+
+// KeyboardAuditOption is used for customization in
+// the `accessibility.AuditKeyboard` function.
+type KeyboardAuditOption = func(*keyboardAuditConfig)
+
+// MaxFocusStops allows the caller of the `accessibility.AuditKeyboard`
+// function to customize the maximum number of Tab keypresses (default:
+// `accessibility.DefaultMaxFocusStops`).
+func MaxFocusStops(n int) KeyboardAuditOption {
+	return func(c *keyboardAuditConfig) {
+		c.maxStops = n
+	}
+}
+
+// FocusScreenshots allows the caller of the `accessibility.AuditKeyboard`
+// function to capture a screenshot of each focused element, e.g. for
+// reviewing focus indicators which computed styles can't judge, such as
+// insufficient contrast.
+func FocusScreenshots() KeyboardAuditOption {
+	return func(c *keyboardAuditConfig) {
+		c.screenshots = true
+	}
+}
+
+// AuditKeyboard audits the keyboard accessibility of the page which is
+// associated with the given context: it moves the focus through the page
+// with synthesized Tab keypresses (see `input.Shortcut`), starting from the
+// top, and records the focus order, whether each focused element has a
+// visible focus indicator (based on its computed style with and without the
+// focus, see `css.GetComputedStyleForNode`), and which interactive elements
+// the focus didn't reach. It stops when the focus leaves the page, returns
+// to an element which it already reached, or doesn't move.
+//
+// This function changes the page's focus, and triggers its keyboard event
+// handlers. It blurs the focused element when it's done. It enables the DOM
+// and CSS domains (see `devtools.EnableDomain`) while it's running.
+func AuditKeyboard(ctx context.Context, opts ...KeyboardAuditOption) (*KeyboardAudit, error) {
+	cfg := &keyboardAuditConfig{maxStops: DefaultMaxFocusStops}
+	for _, o := range opts {
+		o(cfg)
+	}
+
+	// The CSS domain can't be enabled without the DOM domain.
+	releaseDOM, err := devtools.EnableDomain(ctx, "DOM")
+	if err != nil {
+		return nil, err
+	}
+	defer releaseDOM()
+	releaseCSS, err := devtools.EnableDomain(ctx, "CSS")
+	if err != nil {
+		return nil, err
+	}
+	defer releaseCSS()
+
+	candidates, err := interactiveElements(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := evaluate(ctx, blurActiveElement); err != nil {
+		return nil, err
+	}
+	defer evaluate(ctx, blurActiveElement)
+
+	a := &KeyboardAudit{}
+	reached := make(map[int64]bool)
+	var nodeIDs []int64
+	var focused [][]css.ComputedStyleProperty
+	for i := 0; i < cfg.maxStops; i++ {
+		if err := input.Shortcut(ctx, "Tab"); err != nil {
+			return nil, err
+		}
+		h, err := focusedElement(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if h == nil {
+			break // The focus left the page.
+		}
+		e, _, err := describe(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		if reached[e.BackendNodeID] {
+			// A cycle is normal only if it includes all the focus stops.
+			a.Trapped = len(a.FocusOrder) == 0 || e.BackendNodeID != a.FocusOrder[0].BackendNodeID
+			break
+		}
+		reached[e.BackendNodeID] = true
+
+		nodeID, err := h.NodeID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		style, err := css.NewGetComputedStyleForNode(nodeID).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		focused = append(focused, style.ComputedStyle)
+		stop := FocusStop{KeyboardElement: *e}
+		if cfg.screenshots {
+			if stop.Screenshot, err = screenshotNode(ctx, nodeID); err != nil {
+				return nil, err
+			}
+		}
+		a.FocusOrder = append(a.FocusOrder, stop)
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+
+	// Compare the style of each focus stop without the focus.
+	if err := evaluate(ctx, blurActiveElement); err != nil {
+		return nil, err
+	}
+	for i := range a.FocusOrder {
+		s := &a.FocusOrder[i]
+		style, err := css.NewGetComputedStyleForNode(nodeIDs[i]).Do(ctx)
+		if dom.IsStaleNodeError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		s.IndicatorProperties = focusIndicator(focused[i], style.ComputedStyle)
+	}
+
+	for _, e := range candidates {
+		if !reached[e.BackendNodeID] {
+			a.Unreachable = append(a.Unreachable, e)
+		}
+	}
+	return a, nil
+}
+
+// Return the visible and enabled interactive elements in the page.
+func interactiveElements(ctx context.Context) ([]KeyboardElement, error) {
+	handles, err := dom.QueryAll(ctx, interactiveSelector)
+	if err != nil {
+		return nil, err
+	}
+	var elements []KeyboardElement
+	for _, h := range handles {
+		e, reachable, err := describe(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+		if reachable {
+			elements = append(elements, *e)
+		}
+	}
+	return elements, nil
+}
+
+type elementDescription struct {
+	Tag       string `json:"tag"`
+	Label     string `json:"label"`
+	Reachable bool   `json:"reachable"`
+}
+
+// Return a handle to the focused element, or nil if there's none.
+func focusedElement(ctx context.Context) (*dom.ElementHandle, error) {
+	r, err := runtime.NewEvaluate(activeElement).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.ExceptionDetails != nil {
+		return nil, r.ExceptionDetails
+	}
+	if r.Result.ObjectID == "" {
+		return nil, nil
+	}
+	return dom.HandleFromObjectID(runtime.RemoteObjectID(r.Result.ObjectID)), nil
+}
+
+// Describe the given element, and report whether it should be reachable.
+func describe(ctx context.Context, h *dom.ElementHandle) (*KeyboardElement, bool, error) {
+	var d elementDescription
+	if err := callFunction(ctx, h, describeElement, &d); err != nil {
+		return nil, false, err
+	}
+	backendNodeID, err := h.BackendNodeID(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	nodeID, err := h.NodeID(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	selector, err := dom.SelectorFor(ctx, nodeID)
+	if err != nil {
+		return nil, false, err
+	}
+	e := &KeyboardElement{Selector: selector, BackendNodeID: backendNodeID, Tag: d.Tag, Label: d.Label}
+	return e, d.Reachable, nil
+}
+
+// Return the names of the focus indicator properties whose values differ
+// between the given computed styles. Changes of the outline's color, width
+// and offset are ignored if the focused element has no outline.
+func focusIndicator(focused, unfocused []css.ComputedStyleProperty) []string {
+	values := make(map[string]string)
+	for _, p := range unfocused {
+		values[p.Name] = p.Value
+	}
+	noOutline := false
+	for _, p := range focused {
+		if (p.Name == "outline-style" && p.Value == "none") || (p.Name == "outline-width" && p.Value == "0px") {
+			noOutline = true
+		}
+	}
+	var changed []string
+	for _, p := range focused {
+		if !focusIndicatorProperties[p.Name] || values[p.Name] == p.Value {
+			continue
+		}
+		if noOutline && strings.HasPrefix(p.Name, "outline-") {
+			continue
+		}
+		changed = append(changed, p.Name)
+	}
+	return changed
+}
+
+// Capture a screenshot of the given element, with a margin
+// around it, so its focus indicator (e.g. an outline) is included.
+func screenshotNode(ctx context.Context, nodeID int64) ([]byte, error) {
+	const margin = 8
+	box, err := dom.NewGetBoxModel().SetNodeID(nodeID).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := box.Model.Border
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for i := 0; i+1 < len(q); i += 2 {
+		minX, maxX = math.Min(minX, q[i]), math.Max(maxX, q[i])
+		minY, maxY = math.Min(minY, q[i+1]), math.Max(maxY, q[i+1])
+	}
+	clip := page.Viewport{
+		X:      math.Max(0, minX-margin),
+		Y:      math.Max(0, minY-margin),
+		Width:  maxX - minX + 2*margin,
+		Height: maxY - minY + 2*margin,
+		Scale:  1,
+	}
+	r, err := page.NewCaptureScreenshot().SetClip(clip).SetCaptureBeyondViewport(true).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(r.Data)
+}
+
+// Call a JavaScript function on the given element,
+// and parse its JSON value into v.
+func callFunction(ctx context.Context, h *dom.ElementHandle, f string, v interface{}) error {
+	objectID, err := h.ObjectID(ctx)
+	if err != nil {
+		return err
+	}
+	r, err := runtime.NewCallFunctionOn(f).SetObjectID(string(objectID)).SetReturnByValue(true).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if r.ExceptionDetails != nil {
+		return r.ExceptionDetails
+	}
+	return json.Unmarshal(r.Result.Value, v)
+}
+
+func evaluate(ctx context.Context, expr string) error {
+	r, err := runtime.NewEvaluate(expr).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if r.ExceptionDetails != nil {
+		return r.ExceptionDetails
+	}
+	return nil
+}
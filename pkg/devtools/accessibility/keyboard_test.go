@@ -0,0 +1,79 @@
+package accessibility
+
+import (
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/css"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFocusIndicator(t *testing.T) {
+	unfocused := []css.ComputedStyleProperty{
+		{Name: "outline-style", Value: "none"},
+		{Name: "outline-width", Value: "0px"},
+		{Name: "outline-color", Value: "rgb(0, 0, 0)"},
+		{Name: "box-shadow", Value: "none"},
+		{Name: "cursor", Value: "auto"},
+	}
+	tests := []struct {
+		name    string
+		focused []css.ComputedStyleProperty
+		want    []string
+	}{
+		{
+			name:    "unchanged",
+			focused: unfocused,
+		},
+		{
+			name: "outline",
+			focused: []css.ComputedStyleProperty{
+				{Name: "outline-style", Value: "auto"},
+				{Name: "outline-width", Value: "1px"},
+				{Name: "outline-color", Value: "rgb(16, 16, 16)"},
+				{Name: "box-shadow", Value: "none"},
+				{Name: "cursor", Value: "auto"},
+			},
+			want: []string{"outline-style", "outline-width", "outline-color"},
+		},
+		{
+			name: "outline_color_without_outline",
+			focused: []css.ComputedStyleProperty{
+				{Name: "outline-style", Value: "none"},
+				{Name: "outline-width", Value: "0px"},
+				{Name: "outline-color", Value: "rgb(255, 0, 0)"},
+				{Name: "box-shadow", Value: "none"},
+				{Name: "cursor", Value: "auto"},
+			},
+		},
+		{
+			name: "box_shadow_and_irrelevant_property",
+			focused: []css.ComputedStyleProperty{
+				{Name: "outline-style", Value: "none"},
+				{Name: "outline-width", Value: "0px"},
+				{Name: "outline-color", Value: "rgb(0, 0, 0)"},
+				{Name: "box-shadow", Value: "rgb(0, 0, 255) 0px 0px 0px 2px"},
+				{Name: "cursor", Value: "pointer"},
+			},
+			want: []string{"box-shadow"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := focusIndicator(tt.focused, unfocused)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("focusIndicator() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMissingFocusIndicators(t *testing.T) {
+	a := &KeyboardAudit{FocusOrder: []FocusStop{
+		{KeyboardElement: KeyboardElement{Selector: "#a"}, IndicatorProperties: []string{"outline-style"}},
+		{KeyboardElement: KeyboardElement{Selector: "#b"}},
+	}}
+	got := a.MissingFocusIndicators()
+	if len(got) != 1 || got[0].Selector != "#b" {
+		t.Errorf("MissingFocusIndicators() = %v, want only #b", got)
+	}
+}
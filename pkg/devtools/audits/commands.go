@@ -21,32 +21,50 @@ type GetEncodedResponse struct {
 	// The encoding to use.
 	Encoding string `json:"encoding"`
 	// The quality of the encoding (0-1). (defaults to 1)
-	Quality float64 `json:"quality,omitempty"`
+	Quality *float64 `json:"quality,omitempty"`
 	// Whether to only return the size information (defaults to false).
 	SizeOnly bool `json:"sizeOnly,omitempty"`
 }
 
 // NewGetEncodedResponse constructs a new GetEncodedResponse struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetEncodedResponseOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Audits/#method-getEncodedResponse
-func NewGetEncodedResponse(requestID string, encoding string) *GetEncodedResponse {
-	return &GetEncodedResponse{
+func NewGetEncodedResponse(requestID string, encoding string, opts ...GetEncodedResponseOption) *GetEncodedResponse {
+	c := &GetEncodedResponse{
 		RequestID: requestID,
 		Encoding:  encoding,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetEncodedResponseOption is a functional option for the optional
+// parameters of the GetEncodedResponse CDP command, see the
+// NewGetEncodedResponse constructor. Options are applied in order.
+type GetEncodedResponseOption = func(*GetEncodedResponse)
+
 // SetQuality adds or modifies the value of the optional
 // parameter `quality` in the GetEncodedResponse CDP command.
 //
 // The quality of the encoding (0-1). (defaults to 1)
 func (t *GetEncodedResponse) SetQuality(v float64) *GetEncodedResponse {
-	t.Quality = v
+	t.Quality = &v
 	return t
 }
 
+// GetEncodedResponseWithQuality is a functional option which sets the value of the
+// optional parameter `quality` in the GetEncodedResponse CDP command, see SetQuality.
+func GetEncodedResponseWithQuality(v float64) GetEncodedResponseOption {
+	return func(c *GetEncodedResponse) {
+		c.SetQuality(v)
+	}
+}
+
 // SetSizeOnly adds or modifies the value of the optional
 // parameter `sizeOnly` in the GetEncodedResponse CDP command.
 //
@@ -56,6 +74,14 @@ func (t *GetEncodedResponse) SetSizeOnly(v bool) *GetEncodedResponse {
 	return t
 }
 
+// GetEncodedResponseWithSizeOnly is a functional option which sets the value of the
+// optional parameter `sizeOnly` in the GetEncodedResponse CDP command, see SetSizeOnly.
+func GetEncodedResponseWithSizeOnly(v bool) GetEncodedResponseOption {
+	return func(c *GetEncodedResponse) {
+		c.SetSizeOnly(v)
+	}
+}
+
 // GetEncodedResponseResult contains the browser's response
 // to calling the GetEncodedResponse CDP command with Do().
 type GetEncodedResponseResult struct {
@@ -209,13 +235,23 @@ type CheckContrast struct {
 
 // NewCheckContrast constructs a new CheckContrast struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `CheckContrastOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Audits/#method-checkContrast
-func NewCheckContrast() *CheckContrast {
-	return &CheckContrast{}
+func NewCheckContrast(opts ...CheckContrastOption) *CheckContrast {
+	c := &CheckContrast{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// CheckContrastOption is a functional option for the optional
+// parameters of the CheckContrast CDP command, see the
+// NewCheckContrast constructor. Options are applied in order.
+type CheckContrastOption = func(*CheckContrast)
+
 // SetReportAAA adds or modifies the value of the optional
 // parameter `reportAAA` in the CheckContrast CDP command.
 //
@@ -225,6 +261,14 @@ func (t *CheckContrast) SetReportAAA(v bool) *CheckContrast {
 	return t
 }
 
+// CheckContrastWithReportAAA is a functional option which sets the value of the
+// optional parameter `reportAAA` in the CheckContrast CDP command, see SetReportAAA.
+func CheckContrastWithReportAAA(v bool) CheckContrastOption {
+	return func(c *CheckContrast) {
+		c.SetReportAAA(v)
+	}
+}
+
 // Do sends the CheckContrast CDP command to a browser,
 // and returns the browser's response.
 func (t *CheckContrast) Do(ctx context.Context) error {
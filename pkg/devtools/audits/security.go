@@ -0,0 +1,166 @@
+package audits
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/log"
+)
+
+// SecurityReport aggregates mixed content issues, Content Security Policy
+// (CSP) violations, and security-related log entries which were reported
+// by a page, see the `audits.StartSecurityReport` function.
+type SecurityReport struct {
+	MixedContent  []MixedContentIssueDetails
+	CSPViolations []ContentSecurityPolicyIssueDetails
+	// Log entries whose source is "security", e.g. the console messages
+	// which accompany the issues above, and other security warnings.
+	LogEntries []log.Entry
+}
+
+// Err returns an error which describes all the insecure requests (i.e.
+// mixed content which was loaded over HTTP) and enforced CSP violations in
+// the report, or nil if there aren't any, so a release can be gated on it.
+// Mixed content which was blocked or automatically upgraded to HTTPS, and
+// violations of report-only policies, are ignored.
+func (r *SecurityReport) Err() error {
+	var msgs []string
+	for _, mc := range r.MixedContent {
+		if mc.ResolutionStatus != MixedContentResolutionStatusMixedContentWarning {
+			continue
+		}
+		msgs = append(msgs, fmt.Sprintf("insecure request to %s from %s (%s)",
+			mc.InsecureURL, mc.MainResourceURL, mc.ResolutionStatus))
+	}
+	for _, csp := range r.CSPViolations {
+		if csp.IsReportOnly {
+			continue
+		}
+		msg := fmt.Sprintf("CSP violation of %q", csp.ViolatedDirective)
+		if csp.BlockedURL != "" {
+			msg += fmt.Sprintf(" by %s", csp.BlockedURL)
+		}
+		if l := csp.SourceCodeLocation; l != nil {
+			msg += fmt.Sprintf(" at %s:%d:%d", l.URL, l.LineNumber+1, l.ColumnNumber+1)
+		}
+		msgs = append(msgs, msg)
+	}
+	if len(msgs) > 0 {
+		return fmt.Errorf("security issues detected: %s", strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+// Add an inspector issue to the report, if it's relevant.
+func (r *SecurityReport) addIssue(i *InspectorIssue) {
+	if d := i.Details.MixedContentIssueDetails; d != nil {
+		r.MixedContent = append(r.MixedContent, *d)
+	}
+	if d := i.Details.ContentSecurityPolicyIssueDetails; d != nil {
+		r.CSPViolations = append(r.CSPViolations, *d)
+	}
+}
+
+// SecurityReporter collects a `audits.SecurityReport` in the background.
+type SecurityReporter struct {
+	ctx          context.Context
+	issues, logs *devtools.Subscription
+	done         chan struct{}
+	// Release the Audits and Log domains, see `devtools.EnableDomain`.
+	releases []func() error
+
+	mu     sync.Mutex
+	report SecurityReport
+}
+
+// StartSecurityReport starts collecting mixed content issues, CSP violations
+// and security-related log entries in the page associated with the given
+// context, until the `Stop` method of the returned reporter is called. This
+// function enables the Audits and Log domains until the reporter is stopped
+// (see `devtools.EnableDomain`). Enabling them also reports the issues and
+// log entries that the page has generated so far, unless something else
+// already enabled them.
+func StartSecurityReport(ctx context.Context) (*SecurityReporter, error) {
+	issues, err := devtools.Subscribe(ctx, "Audits.issueAdded")
+	if err != nil {
+		return nil, err
+	}
+	logs, err := devtools.Subscribe(ctx, "Log.entryAdded")
+	if err != nil {
+		issues.Unsubscribe()
+		return nil, err
+	}
+	r := &SecurityReporter{ctx: ctx, issues: issues, logs: logs, done: make(chan struct{})}
+	go r.collect(devtools.EventsOfSession(ctx))
+
+	for _, domain := range []string{"Audits", "Log"} {
+		release, err := devtools.EnableDomain(ctx, domain)
+		if err != nil {
+			r.Stop()
+			return nil, err
+		}
+		r.releases = append(r.releases, release)
+	}
+	return r, nil
+}
+
+// Receive events until both subscriptions are closed.
+func (r *SecurityReporter) collect(accept devtools.EventFilter) {
+	defer close(r.done)
+	issues, logs := r.issues.C, r.logs.C
+	for issues != nil || logs != nil {
+		select {
+		case m, ok := <-issues:
+			if !ok {
+				issues = nil
+				continue
+			}
+			e := &IssueAdded{}
+			if !accept(m) || json.Unmarshal(m.Params, e) != nil {
+				continue
+			}
+			r.mu.Lock()
+			r.report.addIssue(&e.Issue)
+			r.mu.Unlock()
+		case m, ok := <-logs:
+			if !ok {
+				logs = nil
+				continue
+			}
+			e := &log.EntryAdded{}
+			if !accept(m) || json.Unmarshal(m.Params, e) != nil || e.Entry.Source != "security" {
+				continue
+			}
+			r.mu.Lock()
+			r.report.LogEntries = append(r.report.LogEntries, e.Entry)
+			r.mu.Unlock()
+		}
+	}
+}
+
+// Report returns a snapshot of the report collected so far.
+func (r *SecurityReporter) Report() *SecurityReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &SecurityReport{
+		MixedContent:  append([]MixedContentIssueDetails(nil), r.report.MixedContent...),
+		CSPViolations: append([]ContentSecurityPolicyIssueDetails(nil), r.report.CSPViolations...),
+		LogEntries:    append([]log.Entry(nil), r.report.LogEntries...),
+	}
+}
+
+// Stop stops collecting, releases the Audits and Log domains (which are
+// disabled only if nothing else requires them), and returns the final report.
+func (r *SecurityReporter) Stop() *SecurityReport {
+	r.issues.Unsubscribe()
+	r.logs.Unsubscribe()
+	for _, release := range r.releases {
+		release()
+	}
+	<-r.done
+	return r.Report()
+}
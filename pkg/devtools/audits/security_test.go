@@ -0,0 +1,55 @@
+package audits
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecurityReportErr(t *testing.T) {
+	// Set up.
+	r := &SecurityReport{}
+	r.addIssue(&InspectorIssue{Details: InspectorIssueDetails{
+		MixedContentIssueDetails: &MixedContentIssueDetails{
+			ResolutionStatus: MixedContentResolutionStatusMixedContentBlocked,
+			InsecureURL:      "http://example.com/blocked.js",
+		},
+	}})
+	r.addIssue(&InspectorIssue{Details: InspectorIssueDetails{
+		ContentSecurityPolicyIssueDetails: &ContentSecurityPolicyIssueDetails{
+			ViolatedDirective: "img-src",
+			IsReportOnly:      true,
+		},
+	}})
+	// Test.
+	if err := r.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+
+	// Set up.
+	r.addIssue(&InspectorIssue{Details: InspectorIssueDetails{
+		MixedContentIssueDetails: &MixedContentIssueDetails{
+			ResolutionStatus: MixedContentResolutionStatusMixedContentWarning,
+			InsecureURL:      "http://example.com/image.png",
+			MainResourceURL:  "https://example.com/",
+		},
+	}})
+	r.addIssue(&InspectorIssue{Details: InspectorIssueDetails{
+		ContentSecurityPolicyIssueDetails: &ContentSecurityPolicyIssueDetails{
+			ViolatedDirective:  "script-src",
+			SourceCodeLocation: &SourceCodeLocation{URL: "https://example.com/app.js", LineNumber: 9, ColumnNumber: 4},
+		},
+	}})
+	// Test.
+	err := r.Err()
+	if err == nil {
+		t.Fatal("Err() = nil, want an error")
+	}
+	for _, want := range []string{"http://example.com/image.png", `"script-src"`, "app.js:10:5"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Err() = %q, want it to contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "blocked.js") {
+		t.Errorf("Err() = %q, want it to ignore blocked mixed content", err)
+	}
+}
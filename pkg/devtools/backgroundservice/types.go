@@ -1,5 +1,7 @@
 package backgroundservice
 
+import "github.com/daabr/chrome-vision/pkg/devtools"
+
 // ServiceName data type. The Background Service that will be associated with the commands/events.
 // Every Background Service operates independently, but they share the same
 // API.
@@ -35,7 +37,7 @@ type EventMetadata struct {
 // https://chromedevtools.github.io/devtools-protocol/tot/BackgroundService/#type-BackgroundServiceEvent
 type Event struct {
 	// Timestamp of the event (in seconds).
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.TimeSinceEpoch `json:"timestamp"`
 	// The origin this event belongs to.
 	Origin string `json:"origin"`
 	// The Service Worker ID that initiated the event.
@@ -10,12 +10,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/daabr/chrome-vision/pkg/websocket"
@@ -25,14 +27,16 @@ import (
 // report its WebSocket address via STDERR, in order to perform a handshake.
 const WebSocketTimeout = 1 * time.Minute
 
-type windowsStderrWriter struct {
+// stderrWriter is used when the browser is driven over a WebSocket instead
+// of pipes: on Windows, and with Firefox (see the `devtools.Firefox` option).
+type stderrWriter struct {
 	session *Session
-	stderr  *os.File
+	stderr  io.Writer
 }
 
 // Write passes lines from the browser's STDERR to our log file, but also
 // extracts and remembers the browser's WebSocket address for initialization.
-func (w *windowsStderrWriter) Write(b []byte) (n int, err error) {
+func (w *stderrWriter) Write(b []byte) (n int, err error) {
 	if w.session.wsAddress.Read() == "" {
 		re := regexp.MustCompile(`ws://(.+:\d+)(/devtools/browser/[\w-]{36})`)
 		m := re.FindSubmatch(b)
@@ -51,7 +55,11 @@ func start(ctx context.Context, s *Session) error {
 	// https://github.com/SeleniumHQ/selenium/wiki/ChromeDriver#requirements
 	p := s.browserPath
 	if p == nil {
-		for _, e := range executables {
+		candidates := executables[:]
+		if s.firefox {
+			candidates = firefoxExecutables[:]
+		}
+		for _, e := range candidates {
 			if path, err := exec.LookPath(e); err == nil {
 				p = &path
 				break
@@ -62,14 +70,44 @@ func start(ctx context.Context, s *Session) error {
 		}
 	}
 	log.Printf("Browser executable path: %s", *p)
+	if isFirefox(*p) {
+		s.firefox = true
+	}
+	// Firefox doesn't support pipes, so use a WebSocket on all operating systems.
+	useWebSocket := runtime.GOOS == "windows" || s.firefox || s.resumable
 
 	// Initialize the command-line.
-	if len(s.browserFlags) == 0 {
-		s.browserFlags = DefaultBrowserFlags()
+	var args []string
+	if s.firefox {
+		if len(s.browserFlags) == 0 {
+			s.browserFlags = DefaultFirefoxFlags()
+		}
+		args = adjustFirefoxFlags(s)
+	} else {
+		if len(s.browserFlags) == 0 {
+			s.browserFlags = DefaultBrowserFlags()
+		}
+		warnings, err := ValidateFlags(s.browserFlags)
+		for _, w := range warnings {
+			log.Printf("Browser flags warning: %s", w)
+		}
+		if err == nil && s.strictFlags && len(warnings) > 0 {
+			err = fmt.Errorf("browser flags warnings: %s", strings.Join(warnings, "; "))
+		}
+		if err != nil {
+			os.RemoveAll(s.OutputDir)
+			return err
+		}
+		args = adjustFlags(s)
 	}
-	args := append(adjustFlags(s), "about:blank")
+	args = append(args, "about:blank")
 	log.Printf("Browser command-line args: %q", args)
 	cmd := exec.CommandContext(ctx, *p, args...)
+	// Start the browser in its own process group, so it can be
+	// terminated along with its child processes when it hangs.
+	setProcessGroup(cmd)
+	cmd.Env = processEnv(s, os.Environ())
+	cmd.Dir = s.browserDir
 
 	// Ensure that the user data directory exists, whether it's the
 	// default (in the output directory) or a custom one specified by the caller.
@@ -80,6 +118,18 @@ func start(ctx context.Context, s *Session) error {
 			os.RemoveAll(s.OutputDir)
 		}
 	}()
+	if s.profileSeed != "" {
+		if err := seedProfile(s.profileSeed, s.UserDataDir); err != nil {
+			return fmt.Errorf("failed to seed user data directory: %v", err)
+		}
+	}
+	if s.firefox {
+		if err := writeFirefoxPrefs(s.UserDataDir); err != nil {
+			return fmt.Errorf("failed to initialize Firefox preferences: %v", err)
+		}
+	} else if err := writePrefs(s); err != nil {
+		return fmt.Errorf("failed to write browser preferences: %v", err)
+	}
 
 	// Redirect the browser process's output to files.
 	stdout, err := os.Create(filepath.Join(s.OutputDir, "stdout.txt"))
@@ -90,19 +140,19 @@ func start(ctx context.Context, s *Session) error {
 	if err != nil {
 		return fmt.Errorf("failed to initialize browser process's STDERR file: %v", err)
 	}
-	cmd.Stdout = stdout
-	if runtime.GOOS != "windows" {
-		cmd.Stderr = stderr
-	} else {
-		// On Windows, we also need to read STDERR during runtime,
+	var flushStdout, flushStderr func()
+	cmd.Stdout, flushStdout = outputWriter(s, "stdout", stdout)
+	cmd.Stderr, flushStderr = outputWriter(s, "stderr", stderr)
+	if useWebSocket {
+		// With a WebSocket, we also need to read STDERR during runtime,
 		// in order to know how to communicate with the browser.
 		s.wsAddress, s.wsPath = newSafeString(), newSafeString()
-		cmd.Stderr = &windowsStderrWriter{session: s, stderr: stderr}
+		cmd.Stderr = &stderrWriter{session: s, stderr: cmd.Stderr}
 	}
 
 	// On POSIX-compliant operating systems, prepare input and output pipes to
 	// communicate with the browser process. On Windows, we use a WebSocket.
-	if runtime.GOOS != "windows" {
+	if !useWebSocket {
 		inputReader, inputWriter, err := os.Pipe()
 		if err != nil {
 			return fmt.Errorf("failed to initialize browser input pipe: %v", err)
@@ -120,14 +170,20 @@ func start(ctx context.Context, s *Session) error {
 		return fmt.Errorf("failed to start browser process: %v", err)
 	}
 	log.Printf("Browser process started: PID %d", cmd.Process.Pid)
+	s.process = cmd.Process
+	if err := writeProcessInfo(s, cmd.Process.Pid); err != nil {
+		log.Printf("Failed to write browser process info: %v", err)
+	}
+	s.metrics.browserStarted()
 	s.browserDone = make(chan struct{})
 
-	if runtime.GOOS != "windows" {
+	if !useWebSocket {
 		// On POSIX-compliant operating systems, start using the
 		// previously-prepared pipes to communicate with the browser.
 		go receiveFromPipe(s)
 	} else {
-		// On Windows, initialize a WebSocket to communicate with the browser.
+		// On Windows (or with Firefox), initialize a WebSocket
+		// to communicate with the browser.
 		ticker := time.NewTicker(10 * time.Millisecond)
 		timer := time.NewTimer(WebSocketTimeout)
 		defer ticker.Stop()
@@ -146,6 +202,7 @@ func start(ctx context.Context, s *Session) error {
 			s.cancel()
 			return err
 		}
+		conn.SetReadLimit(int64(s.maxMessageSize))
 		s.webSocket = conn
 		go receiveFromWebSocket(s)
 	}
@@ -160,15 +217,17 @@ func start(ctx context.Context, s *Session) error {
 		} else {
 			log.Print("Browser process has ended without an error")
 		}
+		flushStdout()
+		flushStderr()
 		s.cancel()
+		s.metrics.browserExited()
 
 		close(s.msgQ)
-		if runtime.GOOS != "windows" {
+		if !useWebSocket {
 			s.browserInputWriter.Close()
 			s.browserOutputReader.Close()
 		}
-		s.msgLog.Writer().(*os.File).Sync()
-		s.msgLog.Writer().(*os.File).Close()
+		closeMsgLog(s.msgLog)
 		// TODO: unsubscribe (close channels) for all existing subscribers.
 		close(s.browserDone)
 	}(s, cmd)
@@ -183,6 +242,10 @@ func start(ctx context.Context, s *Session) error {
 // call the `devtools.Close` function instead. Either way, any resources
 // associated with the CDP session will be released when the process ends.
 //
+// If the context's session reuses an existing browser (i.e. its parent
+// context already carried a session), this cancels only the tab's context,
+// without closing the tab or impacting the browser and its other tabs.
+//
 // Remember that this does not impact ancestor or parent contexts specified
 // in the `devtools.NewContext` function call, only contexts returned by it.
 // Context cancelation propagates only from ancestors to descendants.
@@ -210,7 +273,13 @@ func Wait(ctx context.Context) {
 // Either way, any resources associated with the CDP session will be
 // released when the process ends.
 func Close(ctx context.Context) {
-	if _, ok := FromContext(ctx); ok {
+	if s, ok := FromContext(ctx); ok {
+		if s.remote != nil {
+			// Close only the tabs which were opened in the remote browser.
+			s.cancel()
+			Wait(ctx)
+			return
+		}
 		// https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-close
 		// (we don't use the browser sub-package to avoid circular dependencies).
 		SendAndWait(ctx, "Browser.close", nil)
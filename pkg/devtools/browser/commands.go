@@ -29,18 +29,28 @@ type SetPermission struct {
 
 // NewSetPermission constructs a new SetPermission struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetPermissionOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-setPermission
 //
 // This CDP method is experimental.
-func NewSetPermission(permission PermissionDescriptor, setting PermissionSetting) *SetPermission {
-	return &SetPermission{
+func NewSetPermission(permission PermissionDescriptor, setting PermissionSetting, opts ...SetPermissionOption) *SetPermission {
+	c := &SetPermission{
 		Permission: permission,
 		Setting:    setting,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetPermissionOption is a functional option for the optional
+// parameters of the SetPermission CDP command, see the
+// NewSetPermission constructor. Options are applied in order.
+type SetPermissionOption = func(*SetPermission)
+
 // SetOrigin adds or modifies the value of the optional
 // parameter `origin` in the SetPermission CDP command.
 //
@@ -50,6 +60,14 @@ func (t *SetPermission) SetOrigin(v string) *SetPermission {
 	return t
 }
 
+// SetPermissionWithOrigin is a functional option which sets the value of the
+// optional parameter `origin` in the SetPermission CDP command, see SetOrigin.
+func SetPermissionWithOrigin(v string) SetPermissionOption {
+	return func(c *SetPermission) {
+		c.SetOrigin(v)
+	}
+}
+
 // SetBrowserContextID adds or modifies the value of the optional
 // parameter `browserContextId` in the SetPermission CDP command.
 //
@@ -59,6 +77,14 @@ func (t *SetPermission) SetBrowserContextID(v string) *SetPermission {
 	return t
 }
 
+// SetPermissionWithBrowserContextID is a functional option which sets the value of the
+// optional parameter `browserContextId` in the SetPermission CDP command, see SetBrowserContextID.
+func SetPermissionWithBrowserContextID(v string) SetPermissionOption {
+	return func(c *SetPermission) {
+		c.SetBrowserContextID(v)
+	}
+}
+
 // Do sends the SetPermission CDP command to a browser,
 // and returns the browser's response.
 func (t *SetPermission) Do(ctx context.Context) error {
@@ -112,17 +138,27 @@ type GrantPermissions struct {
 
 // NewGrantPermissions constructs a new GrantPermissions struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GrantPermissionsOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-grantPermissions
 //
 // This CDP method is experimental.
-func NewGrantPermissions(permissions []PermissionType) *GrantPermissions {
-	return &GrantPermissions{
+func NewGrantPermissions(permissions []PermissionType, opts ...GrantPermissionsOption) *GrantPermissions {
+	c := &GrantPermissions{
 		Permissions: permissions,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GrantPermissionsOption is a functional option for the optional
+// parameters of the GrantPermissions CDP command, see the
+// NewGrantPermissions constructor. Options are applied in order.
+type GrantPermissionsOption = func(*GrantPermissions)
+
 // SetOrigin adds or modifies the value of the optional
 // parameter `origin` in the GrantPermissions CDP command.
 //
@@ -132,6 +168,14 @@ func (t *GrantPermissions) SetOrigin(v string) *GrantPermissions {
 	return t
 }
 
+// GrantPermissionsWithOrigin is a functional option which sets the value of the
+// optional parameter `origin` in the GrantPermissions CDP command, see SetOrigin.
+func GrantPermissionsWithOrigin(v string) GrantPermissionsOption {
+	return func(c *GrantPermissions) {
+		c.SetOrigin(v)
+	}
+}
+
 // SetBrowserContextID adds or modifies the value of the optional
 // parameter `browserContextId` in the GrantPermissions CDP command.
 //
@@ -141,6 +185,14 @@ func (t *GrantPermissions) SetBrowserContextID(v string) *GrantPermissions {
 	return t
 }
 
+// GrantPermissionsWithBrowserContextID is a functional option which sets the value of the
+// optional parameter `browserContextId` in the GrantPermissions CDP command, see SetBrowserContextID.
+func GrantPermissionsWithBrowserContextID(v string) GrantPermissionsOption {
+	return func(c *GrantPermissions) {
+		c.SetBrowserContextID(v)
+	}
+}
+
 // Do sends the GrantPermissions CDP command to a browser,
 // and returns the browser's response.
 func (t *GrantPermissions) Do(ctx context.Context) error {
@@ -191,15 +243,25 @@ type ResetPermissions struct {
 
 // NewResetPermissions constructs a new ResetPermissions struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ResetPermissionsOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-resetPermissions
 //
 // This CDP method is experimental.
-func NewResetPermissions() *ResetPermissions {
-	return &ResetPermissions{}
+func NewResetPermissions(opts ...ResetPermissionsOption) *ResetPermissions {
+	c := &ResetPermissions{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ResetPermissionsOption is a functional option for the optional
+// parameters of the ResetPermissions CDP command, see the
+// NewResetPermissions constructor. Options are applied in order.
+type ResetPermissionsOption = func(*ResetPermissions)
+
 // SetBrowserContextID adds or modifies the value of the optional
 // parameter `browserContextId` in the ResetPermissions CDP command.
 //
@@ -209,6 +271,14 @@ func (t *ResetPermissions) SetBrowserContextID(v string) *ResetPermissions {
 	return t
 }
 
+// ResetPermissionsWithBrowserContextID is a functional option which sets the value of the
+// optional parameter `browserContextId` in the ResetPermissions CDP command, see SetBrowserContextID.
+func ResetPermissionsWithBrowserContextID(v string) ResetPermissionsOption {
+	return func(c *ResetPermissions) {
+		c.SetBrowserContextID(v)
+	}
+}
+
 // Do sends the ResetPermissions CDP command to a browser,
 // and returns the browser's response.
 func (t *ResetPermissions) Do(ctx context.Context) error {
@@ -268,17 +338,27 @@ type SetDownloadBehavior struct {
 
 // NewSetDownloadBehavior constructs a new SetDownloadBehavior struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetDownloadBehaviorOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-setDownloadBehavior
 //
 // This CDP method is experimental.
-func NewSetDownloadBehavior(behavior string) *SetDownloadBehavior {
-	return &SetDownloadBehavior{
+func NewSetDownloadBehavior(behavior string, opts ...SetDownloadBehaviorOption) *SetDownloadBehavior {
+	c := &SetDownloadBehavior{
 		Behavior: behavior,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetDownloadBehaviorOption is a functional option for the optional
+// parameters of the SetDownloadBehavior CDP command, see the
+// NewSetDownloadBehavior constructor. Options are applied in order.
+type SetDownloadBehaviorOption = func(*SetDownloadBehavior)
+
 // SetBrowserContextID adds or modifies the value of the optional
 // parameter `browserContextId` in the SetDownloadBehavior CDP command.
 //
@@ -288,6 +368,14 @@ func (t *SetDownloadBehavior) SetBrowserContextID(v string) *SetDownloadBehavior
 	return t
 }
 
+// SetDownloadBehaviorWithBrowserContextID is a functional option which sets the value of the
+// optional parameter `browserContextId` in the SetDownloadBehavior CDP command, see SetBrowserContextID.
+func SetDownloadBehaviorWithBrowserContextID(v string) SetDownloadBehaviorOption {
+	return func(c *SetDownloadBehavior) {
+		c.SetBrowserContextID(v)
+	}
+}
+
 // SetDownloadPath adds or modifies the value of the optional
 // parameter `downloadPath` in the SetDownloadBehavior CDP command.
 //
@@ -298,6 +386,14 @@ func (t *SetDownloadBehavior) SetDownloadPath(v string) *SetDownloadBehavior {
 	return t
 }
 
+// SetDownloadBehaviorWithDownloadPath is a functional option which sets the value of the
+// optional parameter `downloadPath` in the SetDownloadBehavior CDP command, see SetDownloadPath.
+func SetDownloadBehaviorWithDownloadPath(v string) SetDownloadBehaviorOption {
+	return func(c *SetDownloadBehavior) {
+		c.SetDownloadPath(v)
+	}
+}
+
 // SetEventsEnabled adds or modifies the value of the optional
 // parameter `eventsEnabled` in the SetDownloadBehavior CDP command.
 //
@@ -307,6 +403,14 @@ func (t *SetDownloadBehavior) SetEventsEnabled(v bool) *SetDownloadBehavior {
 	return t
 }
 
+// SetDownloadBehaviorWithEventsEnabled is a functional option which sets the value of the
+// optional parameter `eventsEnabled` in the SetDownloadBehavior CDP command, see SetEventsEnabled.
+func SetDownloadBehaviorWithEventsEnabled(v bool) SetDownloadBehaviorOption {
+	return func(c *SetDownloadBehavior) {
+		c.SetEventsEnabled(v)
+	}
+}
+
 // Do sends the SetDownloadBehavior CDP command to a browser,
 // and returns the browser's response.
 func (t *SetDownloadBehavior) Do(ctx context.Context) error {
@@ -359,17 +463,27 @@ type CancelDownload struct {
 
 // NewCancelDownload constructs a new CancelDownload struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `CancelDownloadOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-cancelDownload
 //
 // This CDP method is experimental.
-func NewCancelDownload(guid string) *CancelDownload {
-	return &CancelDownload{
+func NewCancelDownload(guid string, opts ...CancelDownloadOption) *CancelDownload {
+	c := &CancelDownload{
 		GUID: guid,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// CancelDownloadOption is a functional option for the optional
+// parameters of the CancelDownload CDP command, see the
+// NewCancelDownload constructor. Options are applied in order.
+type CancelDownloadOption = func(*CancelDownload)
+
 // SetBrowserContextID adds or modifies the value of the optional
 // parameter `browserContextId` in the CancelDownload CDP command.
 //
@@ -379,6 +493,14 @@ func (t *CancelDownload) SetBrowserContextID(v string) *CancelDownload {
 	return t
 }
 
+// CancelDownloadWithBrowserContextID is a functional option which sets the value of the
+// optional parameter `browserContextId` in the CancelDownload CDP command, see SetBrowserContextID.
+func CancelDownloadWithBrowserContextID(v string) CancelDownloadOption {
+	return func(c *CancelDownload) {
+		c.SetBrowserContextID(v)
+	}
+}
+
 // Do sends the CancelDownload CDP command to a browser,
 // and returns the browser's response.
 func (t *CancelDownload) Do(ctx context.Context) error {
@@ -696,15 +818,25 @@ type GetHistograms struct {
 
 // NewGetHistograms constructs a new GetHistograms struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetHistogramsOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-getHistograms
 //
 // This CDP method is experimental.
-func NewGetHistograms() *GetHistograms {
-	return &GetHistograms{}
+func NewGetHistograms(opts ...GetHistogramsOption) *GetHistograms {
+	c := &GetHistograms{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetHistogramsOption is a functional option for the optional
+// parameters of the GetHistograms CDP command, see the
+// NewGetHistograms constructor. Options are applied in order.
+type GetHistogramsOption = func(*GetHistograms)
+
 // SetQuery adds or modifies the value of the optional
 // parameter `query` in the GetHistograms CDP command.
 //
@@ -716,6 +848,14 @@ func (t *GetHistograms) SetQuery(v string) *GetHistograms {
 	return t
 }
 
+// GetHistogramsWithQuery is a functional option which sets the value of the
+// optional parameter `query` in the GetHistograms CDP command, see SetQuery.
+func GetHistogramsWithQuery(v string) GetHistogramsOption {
+	return func(c *GetHistograms) {
+		c.SetQuery(v)
+	}
+}
+
 // SetDelta adds or modifies the value of the optional
 // parameter `delta` in the GetHistograms CDP command.
 //
@@ -725,6 +865,14 @@ func (t *GetHistograms) SetDelta(v bool) *GetHistograms {
 	return t
 }
 
+// GetHistogramsWithDelta is a functional option which sets the value of the
+// optional parameter `delta` in the GetHistograms CDP command, see SetDelta.
+func GetHistogramsWithDelta(v bool) GetHistogramsOption {
+	return func(c *GetHistograms) {
+		c.SetDelta(v)
+	}
+}
+
 // GetHistogramsResult contains the browser's response
 // to calling the GetHistograms CDP command with Do().
 type GetHistogramsResult struct {
@@ -788,17 +936,27 @@ type GetHistogram struct {
 
 // NewGetHistogram constructs a new GetHistogram struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetHistogramOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-getHistogram
 //
 // This CDP method is experimental.
-func NewGetHistogram(name string) *GetHistogram {
-	return &GetHistogram{
+func NewGetHistogram(name string, opts ...GetHistogramOption) *GetHistogram {
+	c := &GetHistogram{
 		Name: name,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetHistogramOption is a functional option for the optional
+// parameters of the GetHistogram CDP command, see the
+// NewGetHistogram constructor. Options are applied in order.
+type GetHistogramOption = func(*GetHistogram)
+
 // SetDelta adds or modifies the value of the optional
 // parameter `delta` in the GetHistogram CDP command.
 //
@@ -808,6 +966,14 @@ func (t *GetHistogram) SetDelta(v bool) *GetHistogram {
 	return t
 }
 
+// GetHistogramWithDelta is a functional option which sets the value of the
+// optional parameter `delta` in the GetHistogram CDP command, see SetDelta.
+func GetHistogramWithDelta(v bool) GetHistogramOption {
+	return func(c *GetHistogram) {
+		c.SetDelta(v)
+	}
+}
+
 // GetHistogramResult contains the browser's response
 // to calling the GetHistogram CDP command with Do().
 type GetHistogramResult struct {
@@ -942,15 +1108,25 @@ type GetWindowForTarget struct {
 
 // NewGetWindowForTarget constructs a new GetWindowForTarget struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetWindowForTargetOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-getWindowForTarget
 //
 // This CDP method is experimental.
-func NewGetWindowForTarget() *GetWindowForTarget {
-	return &GetWindowForTarget{}
+func NewGetWindowForTarget(opts ...GetWindowForTargetOption) *GetWindowForTarget {
+	c := &GetWindowForTarget{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetWindowForTargetOption is a functional option for the optional
+// parameters of the GetWindowForTarget CDP command, see the
+// NewGetWindowForTarget constructor. Options are applied in order.
+type GetWindowForTargetOption = func(*GetWindowForTarget)
+
 // SetTargetID adds or modifies the value of the optional
 // parameter `targetId` in the GetWindowForTarget CDP command.
 //
@@ -960,6 +1136,14 @@ func (t *GetWindowForTarget) SetTargetID(v string) *GetWindowForTarget {
 	return t
 }
 
+// GetWindowForTargetWithTargetID is a functional option which sets the value of the
+// optional parameter `targetId` in the GetWindowForTarget CDP command, see SetTargetID.
+func GetWindowForTargetWithTargetID(v string) GetWindowForTargetOption {
+	return func(c *GetWindowForTarget) {
+		c.SetTargetID(v)
+	}
+}
+
 // GetWindowForTargetResult contains the browser's response
 // to calling the GetWindowForTarget CDP command with Do().
 type GetWindowForTargetResult struct {
@@ -1090,15 +1274,25 @@ type SetDockTile struct {
 
 // NewSetDockTile constructs a new SetDockTile struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetDockTileOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-setDockTile
 //
 // This CDP method is experimental.
-func NewSetDockTile() *SetDockTile {
-	return &SetDockTile{}
+func NewSetDockTile(opts ...SetDockTileOption) *SetDockTile {
+	c := &SetDockTile{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetDockTileOption is a functional option for the optional
+// parameters of the SetDockTile CDP command, see the
+// NewSetDockTile constructor. Options are applied in order.
+type SetDockTileOption = func(*SetDockTile)
+
 // SetBadgeLabel adds or modifies the value of the optional
 // parameter `badgeLabel` in the SetDockTile CDP command.
 func (t *SetDockTile) SetBadgeLabel(v string) *SetDockTile {
@@ -1106,6 +1300,14 @@ func (t *SetDockTile) SetBadgeLabel(v string) *SetDockTile {
 	return t
 }
 
+// SetDockTileWithBadgeLabel is a functional option which sets the value of the
+// optional parameter `badgeLabel` in the SetDockTile CDP command, see SetBadgeLabel.
+func SetDockTileWithBadgeLabel(v string) SetDockTileOption {
+	return func(c *SetDockTile) {
+		c.SetBadgeLabel(v)
+	}
+}
+
 // SetImage adds or modifies the value of the optional
 // parameter `image` in the SetDockTile CDP command.
 //
@@ -1115,6 +1317,14 @@ func (t *SetDockTile) SetImage(v string) *SetDockTile {
 	return t
 }
 
+// SetDockTileWithImage is a functional option which sets the value of the
+// optional parameter `image` in the SetDockTile CDP command, see SetImage.
+func SetDockTileWithImage(v string) SetDockTileOption {
+	return func(c *SetDockTile) {
+		c.SetImage(v)
+	}
+}
+
 // Do sends the SetDockTile CDP command to a browser,
 // and returns the browser's response.
 func (t *SetDockTile) Do(ctx context.Context) error {
@@ -0,0 +1,262 @@
+package browser
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/target"
+)
+
+// Download describes a file which was downloaded by the browser,
+// see the `browser.AllowDownloads` function.
+type Download struct {
+	// Global unique identifier of the download.
+	GUID string
+	// URL of the downloaded resource.
+	URL string
+	// File name which was suggested by the server or the page. The
+	// file is saved under its GUID instead, to avoid collisions.
+	SuggestedFilename string
+	// Path of the downloaded file.
+	Path string
+	// Size of the downloaded file, in bytes.
+	Size int64
+	// Hex-encoded SHA-256 checksum of the downloaded file.
+	SHA256 string
+
+	err error // Why the download failed, if it did.
+}
+
+// Downloads tracks the files which the browser downloads in the background,
+// see the `browser.AllowDownloads` function. Multiple goroutines may use it
+// simultaneously.
+type Downloads struct {
+	dir         string
+	began, prog *devtools.Subscription
+	done        chan struct{}
+
+	mu      sync.Mutex
+	pending map[string]*Download // By GUID, until they end.
+	ended   []*Download          // Not yet returned by `WaitForDownload`.
+	updated chan struct{}        // Closed and replaced whenever a download ends.
+}
+
+// AllowDownloads lets the browser associated with the given context save
+// downloaded files in the given directory (which must exist), and tracks
+// them until the `Stop` method of the returned downloads tracker is called.
+// Files are named after their GUIDs (not their suggested file names), and
+// received by the tracker's `WaitForDownload` method in the order in which
+// they complete, including downloads which completed before it was called.
+// For files behind HTTP authentication, see the `fetch.Authenticate` function.
+func AllowDownloads(ctx context.Context, dir string) (*Downloads, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	d := &Downloads{
+		dir:     dir,
+		done:    make(chan struct{}),
+		pending: make(map[string]*Download),
+		updated: make(chan struct{}),
+	}
+	if d.began, err = devtools.Subscribe(ctx, "Browser.downloadWillBegin"); err != nil {
+		return nil, err
+	}
+	if d.prog, err = devtools.Subscribe(ctx, "Browser.downloadProgress"); err != nil {
+		d.began.Unsubscribe()
+		return nil, err
+	}
+	go d.track()
+
+	// Downloads are configured per browser context, not per tab.
+	info, err := target.NewGetTargetInfo().Do(ctx)
+	if err != nil {
+		d.Stop()
+		return nil, err
+	}
+	s := NewSetDownloadBehavior("allowAndName").SetDownloadPath(dir).SetEventsEnabled(true)
+	if id := info.TargetInfo.BrowserContextID; id != "" {
+		s.SetBrowserContextID(id)
+	}
+	if err := s.Do(ctx); err != nil {
+		d.Stop()
+		return nil, err
+	}
+	return d, nil
+}
+
+// Receive download events until both subscriptions end.
+func (d *Downloads) track() {
+	defer close(d.done)
+	began, prog := d.began.C, d.prog.C
+	for began != nil || prog != nil {
+		select {
+		case m, ok := <-began:
+			if !ok {
+				began = nil
+				continue
+			}
+			d.begin(m)
+		case m, ok := <-prog:
+			if !ok {
+				prog = nil
+				continue
+			}
+			// Downloads begin before they progress, but the subscriptions
+			// are separate, so handle the beginnings which are buffered.
+		pending:
+			for began != nil {
+				select {
+				case b, ok := <-began:
+					if !ok {
+						began = nil
+						continue
+					}
+					d.begin(b)
+				default:
+					break pending
+				}
+			}
+			e := &DownloadProgress{}
+			if json.Unmarshal(m.Params, e) != nil || e.State == "inProgress" {
+				continue
+			}
+			d.end(e)
+		}
+	}
+}
+
+// Record the beginning of a download.
+func (d *Downloads) begin(m *devtools.Message) {
+	e := &DownloadWillBegin{}
+	if json.Unmarshal(m.Params, e) != nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pending[e.GUID] = &Download{
+		GUID:              e.GUID,
+		URL:               e.URL,
+		SuggestedFilename: e.SuggestedFilename,
+		Path:              filepath.Join(d.dir, e.GUID),
+	}
+}
+
+// Record the end of a download, and wake up waiting callers.
+func (d *Downloads) end(e *DownloadProgress) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	dl, ok := d.pending[e.GUID]
+	if !ok {
+		return // Started before tracking.
+	}
+	delete(d.pending, e.GUID)
+	if e.State != "completed" {
+		dl.err = fmt.Errorf("download of %s ended with state %q", dl.URL, e.State)
+	}
+	d.ended = append(d.ended, dl)
+	close(d.updated)
+	d.updated = make(chan struct{})
+}
+
+// DownloadOption is used for customization in the `Downloads.WaitForDownload` method.
+type DownloadOption = func(*downloadCheck)
+
+type downloadCheck struct {
+	size   int64
+	sha256 string
+}
+
+// ExpectSize allows the caller of the `Downloads.WaitForDownload` method
+// to validate the size of the downloaded file, in bytes.
+func ExpectSize(n int64) DownloadOption {
+	return func(c *downloadCheck) {
+		c.size = n
+	}
+}
+
+// ExpectSHA256 allows the caller of the `Downloads.WaitForDownload` method
+// to validate the hex-encoded SHA-256 checksum of the downloaded file.
+func ExpectSHA256(checksum string) DownloadOption {
+	return func(c *downloadCheck) {
+		c.sha256 = strings.ToLower(checksum)
+	}
+}
+
+// ErrDownloadMismatch is wrapped by the errors of the `Downloads.WaitForDownload`
+// method when a downloaded file doesn't match the expected size or checksum.
+var ErrDownloadMismatch = errors.New("downloaded file mismatch")
+
+// WaitForDownload waits until the next download ends (or returns
+// immediately if one already ended since the last call), and returns its
+// details, including the size and SHA-256 checksum of the file on disk. It
+// returns an error if the download was canceled or interrupted, or if it
+// doesn't match the expected size or checksum (see `browser.ExpectSize` and
+// `browser.ExpectSHA256`), or early with the context's error if the context
+// is done first.
+func (d *Downloads) WaitForDownload(ctx context.Context, opts ...DownloadOption) (*Download, error) {
+	c := &downloadCheck{size: -1}
+	for _, opt := range opts {
+		opt(c)
+	}
+	for {
+		d.mu.Lock()
+		if len(d.ended) > 0 {
+			dl := d.ended[0]
+			d.ended = d.ended[1:]
+			d.mu.Unlock()
+			if dl.err != nil {
+				return nil, dl.err
+			}
+			return dl, verifyDownload(dl, c)
+		}
+		updated := d.updated
+		d.mu.Unlock()
+
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Measure and checksum the downloaded file, and compare them
+// with the expected values, if there are any.
+func verifyDownload(dl *Download, c *downloadCheck) error {
+	f, err := os.Open(dl.Path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if dl.Size, err = io.Copy(h, f); err != nil {
+		return err
+	}
+	dl.SHA256 = hex.EncodeToString(h.Sum(nil))
+	if c.size >= 0 && dl.Size != c.size {
+		return fmt.Errorf("%w: %s is %d bytes, want %d", ErrDownloadMismatch, dl.URL, dl.Size, c.size)
+	}
+	if c.sha256 != "" && dl.SHA256 != c.sha256 {
+		return fmt.Errorf("%w: %s has SHA-256 %s, want %s", ErrDownloadMismatch, dl.URL, dl.SHA256, c.sha256)
+	}
+	return nil
+}
+
+// Stop stops tracking downloads. It doesn't change the browser's
+// download behavior, or affect downloads which are in progress.
+func (d *Downloads) Stop() {
+	d.began.Unsubscribe()
+	d.prog.Unsubscribe()
+	<-d.done
+}
@@ -0,0 +1,74 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+func TestWaitForDownload(t *testing.T) {
+	dir := t.TempDir()
+	var behavior string
+	ctx := devtools.NewFakeContext(context.Background(), func(_ context.Context, method string, params json.RawMessage) (*devtools.Message, error) {
+		result := `{}`
+		switch method {
+		case "Target.getTargetInfo":
+			result = `{"targetInfo":{"targetId":"FAKE_TARGET_ID","type":"page","browserContextId":"A"}}`
+		case "Browser.setDownloadBehavior":
+			behavior = string(params)
+		}
+		return &devtools.Message{Result: json.RawMessage(result)}, nil
+	})
+	d, err := AllowDownloads(ctx, dir)
+	if err != nil {
+		t.Fatalf("AllowDownloads(); got error: %v", err)
+	}
+	defer d.Stop()
+	want := `{"behavior":"allowAndName","browserContextId":"A","downloadPath":"` + dir + `","eventsEnabled":true}`
+	if behavior != want {
+		t.Errorf("AllowDownloads() params = %s, want %s", behavior, want)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "1"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	events := []string{
+		`{"method":"Browser.downloadWillBegin","params":{"guid":"1","url":"https://example.com/a"}}`,
+		`{"method":"Browser.downloadWillBegin","params":{"guid":"2","url":"https://example.com/b"}}`,
+		`{"method":"Browser.downloadProgress","params":{"guid":"1","totalBytes":5,"receivedBytes":2,"state":"inProgress"}}`,
+		`{"method":"Browser.downloadProgress","params":{"guid":"1","totalBytes":5,"receivedBytes":5,"state":"completed"}}`,
+		`{"method":"Browser.downloadProgress","params":{"guid":"2","totalBytes":5,"receivedBytes":0,"state":"canceled"}}`,
+	}
+	for _, e := range events {
+		m := &devtools.Message{}
+		if err := json.Unmarshal([]byte(e), m); err != nil {
+			t.Fatal(err)
+		}
+		if err := devtools.DispatchEvent(ctx, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sum := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	dl, err := d.WaitForDownload(ctx, ExpectSize(5), ExpectSHA256(sum))
+	if err != nil {
+		t.Fatalf("WaitForDownload(); got error: %v", err)
+	}
+	if dl.URL != "https://example.com/a" || dl.Size != 5 || dl.SHA256 != sum {
+		t.Errorf("WaitForDownload() = %+v", dl)
+	}
+	if _, err := d.WaitForDownload(ctx); err == nil {
+		t.Error("WaitForDownload() of a canceled download; got nil error")
+	}
+
+	// Mismatches are reported with the download's details.
+	d.ended = append(d.ended, dl)
+	if _, err := d.WaitForDownload(ctx, ExpectSize(4)); !errors.Is(err, ErrDownloadMismatch) {
+		t.Errorf("WaitForDownload(ExpectSize(4)) = %v, want %v", err, ErrDownloadMismatch)
+	}
+}
@@ -0,0 +1,178 @@
+package browser
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// HistogramOption is used for customization in the `browser.Histograms` function.
+type HistogramOption = func(*histogramQuery)
+
+type histogramQuery struct {
+	substring string
+	names     []string
+	prefixes  []string
+	delta     bool
+}
+
+// HistogramsContaining allows the caller of the `browser.Histograms`
+// function to get only histograms whose names contain the given substring
+// (e.g. "Cache"). The browser filters them, so this is efficient.
+func HistogramsContaining(substring string) HistogramOption {
+	return func(q *histogramQuery) {
+		q.substring = substring
+	}
+}
+
+// HistogramsNamed allows the caller of the `browser.Histograms` function to
+// get only the histograms with the given exact names (e.g.
+// "Blink.DecodedImage.EffectiveDimensionsLocation"). Histograms which
+// weren't recorded yet are returned empty, instead of being omitted.
+func HistogramsNamed(names ...string) HistogramOption {
+	return func(q *histogramQuery) {
+		q.names = append(q.names, names...)
+	}
+}
+
+// HistogramsWithPrefix allows the caller of the `browser.Histograms`
+// function to get only histograms whose names start with one of the given
+// prefixes (e.g. "Net.HttpCache."). Prefixes are applied after the filter
+// of the `browser.HistogramsContaining` option, if it's specified.
+func HistogramsWithPrefix(prefixes ...string) HistogramOption {
+	return func(q *histogramQuery) {
+		q.prefixes = append(q.prefixes, prefixes...)
+	}
+}
+
+// HistogramsDelta allows the caller of the `browser.Histograms` function to
+// get only the samples which were recorded since the previous call with this
+// option, instead of all the samples since the browser started. The browser
+// tracks this state globally, for all CDP clients and sessions, so concurrent
+// users should compare snapshots with the `Histogram.Sub` method instead.
+func HistogramsDelta() HistogramOption {
+	return func(q *histogramQuery) {
+		q.delta = true
+	}
+}
+
+// Histograms returns Chrome's internal UMA histograms (see
+// chrome://histograms), e.g. to validate cache hit rates or image decode
+// times during automated performance runs, sorted by name. Options filter
+// them by names (see `browser.HistogramsContaining`,
+// `browser.HistogramsNamed` and `browser.HistogramsWithPrefix`), or enable
+// delta mode (see `browser.HistogramsDelta`).
+func Histograms(ctx context.Context, opts ...HistogramOption) ([]Histogram, error) {
+	q := &histogramQuery{}
+	for _, opt := range opts {
+		opt(q)
+	}
+
+	var hs []Histogram
+	if len(q.names) > 0 {
+		// A single "Browser.getHistograms" command with a substring query
+		// can't select multiple exact names, so send one command per name.
+		for _, name := range q.names {
+			r, err := NewGetHistogram(name).SetDelta(q.delta).Do(ctx)
+			if err != nil {
+				if isMissingHistogram(err) {
+					hs = append(hs, Histogram{Name: name})
+					continue
+				}
+				return nil, err
+			}
+			hs = append(hs, r.Histogram)
+		}
+	} else {
+		c := NewGetHistograms().SetDelta(q.delta)
+		if q.substring != "" {
+			c.SetQuery(q.substring)
+		}
+		r, err := c.Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		hs = r.Histograms
+	}
+
+	filtered := hs[:0]
+	for _, h := range hs {
+		if matchesHistogram(q, h.Name) {
+			filtered = append(filtered, h)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].Name < filtered[j].Name })
+	return filtered, nil
+}
+
+// Report whether the given histogram name matches the query's filters,
+// other than exact names, which are selected by the browser.
+func matchesHistogram(q *histogramQuery, name string) bool {
+	if q.substring != "" && !strings.Contains(name, q.substring) {
+		return false
+	}
+	if len(q.prefixes) == 0 {
+		return true
+	}
+	for _, p := range q.prefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// The browser responds with an error to requests for
+// histograms which weren't recorded yet.
+func isMissingHistogram(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "cannot find histogram")
+}
+
+// Mean returns the average value of the histogram's samples,
+// or 0 if it doesn't have any.
+func (h *Histogram) Mean() float64 {
+	if h.Count == 0 {
+		return 0
+	}
+	return float64(h.Sum) / float64(h.Count)
+}
+
+// Percentile returns an estimate of the given percentile (between 0 and 100)
+// of the histogram's samples: the exclusive upper bound of the bucket which
+// contains it, or 0 if it doesn't have any samples.
+func (h *Histogram) Percentile(p float64) int64 {
+	total := int64(0)
+	for _, b := range h.Buckets {
+		total += b.Count
+	}
+	if total == 0 {
+		return 0
+	}
+	buckets := append([]Bucket(nil), h.Buckets...)
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Low < buckets[j].Low })
+	target := p / 100 * float64(total)
+	seen := int64(0)
+	for _, b := range buckets {
+		if seen += b.Count; float64(seen) >= target {
+			return b.High
+		}
+	}
+	return buckets[len(buckets)-1].High
+}
+
+// Sub returns the samples which were recorded in this histogram since the
+// given earlier snapshot of it, e.g. to measure a single test step without
+// the browser's global delta state (see `browser.HistogramsDelta`).
+func (h *Histogram) Sub(earlier *Histogram) *Histogram {
+	d := &Histogram{Name: h.Name, Sum: h.Sum - earlier.Sum, Count: h.Count - earlier.Count}
+	prev := make(map[int64]int64, len(earlier.Buckets))
+	for _, b := range earlier.Buckets {
+		prev[b.Low] = b.Count
+	}
+	for _, b := range h.Buckets {
+		if n := b.Count - prev[b.Low]; n > 0 {
+			d.Buckets = append(d.Buckets, Bucket{Low: b.Low, High: b.High, Count: n})
+		}
+	}
+	return d
+}
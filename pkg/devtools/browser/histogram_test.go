@@ -0,0 +1,98 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHistograms(t *testing.T) {
+	var params []string
+	ctx := devtools.NewFakeContext(context.Background(), func(_ context.Context, method string, p json.RawMessage) (*devtools.Message, error) {
+		params = append(params, method+" "+string(p))
+		switch method {
+		case "Browser.getHistograms":
+			return &devtools.Message{Result: json.RawMessage(`{"histograms":[
+				{"name":"Net.HttpCache.Hit","sum":3,"count":3,"buckets":[{"low":1,"high":2,"count":3}]},
+				{"name":"Blink.Cache.Size","sum":10,"count":1,"buckets":[{"low":8,"high":16,"count":1}]},
+				{"name":"Net.DiskCache.Size","sum":5,"count":1,"buckets":[{"low":4,"high":8,"count":1}]}
+			]}`)}, nil
+		case "Browser.getHistogram":
+			if string(p) == `{"name":"Missing"}` {
+				return &devtools.Message{Error: &devtools.Error{Code: -32000, Message: "Cannot find histogram: Missing"}}, nil
+			}
+			return &devtools.Message{Result: json.RawMessage(`{"histogram":{"name":"Found","sum":1,"count":1}}`)}, nil
+		}
+		return &devtools.Message{Result: json.RawMessage(`{}`)}, nil
+	})
+
+	tests := []struct {
+		name       string
+		opts       []HistogramOption
+		want       []string
+		wantParams string
+	}{
+		{
+			name:       "all",
+			want:       []string{"Blink.Cache.Size", "Net.DiskCache.Size", "Net.HttpCache.Hit"},
+			wantParams: `Browser.getHistograms {}`,
+		},
+		{
+			name:       "containing_with_prefix_delta",
+			opts:       []HistogramOption{HistogramsContaining("Cache"), HistogramsWithPrefix("Net."), HistogramsDelta()},
+			want:       []string{"Net.DiskCache.Size", "Net.HttpCache.Hit"},
+			wantParams: `Browser.getHistograms {"query":"Cache","delta":true}`,
+		},
+		{
+			name:       "named",
+			opts:       []HistogramOption{HistogramsNamed("Missing")},
+			want:       []string{"Missing"},
+			wantParams: `Browser.getHistogram {"name":"Missing"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params = nil
+			hs, err := Histograms(ctx, tt.opts...)
+			if err != nil {
+				t.Fatalf("Histograms(); got error: %v", err)
+			}
+			var got []string
+			for _, h := range hs {
+				got = append(got, h.Name)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Histograms() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]string{tt.wantParams}, params); diff != "" {
+				t.Errorf("Histograms() commands mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHistogramStats(t *testing.T) {
+	earlier := &Histogram{Name: "A", Sum: 3, Count: 2, Buckets: []Bucket{{0, 2, 1}, {2, 4, 1}}}
+	later := &Histogram{Name: "A", Sum: 33, Count: 6, Buckets: []Bucket{{0, 2, 1}, {2, 4, 3}, {16, 32, 2}}}
+
+	d := later.Sub(earlier)
+	want := &Histogram{Name: "A", Sum: 30, Count: 4, Buckets: []Bucket{{2, 4, 2}, {16, 32, 2}}}
+	if diff := cmp.Diff(want, d); diff != "" {
+		t.Errorf("Sub() mismatch (-want +got):\n%s", diff)
+	}
+	if got := d.Mean(); got != 7.5 {
+		t.Errorf("Mean() = %v, want 7.5", got)
+	}
+	if got := d.Percentile(50); got != 4 {
+		t.Errorf("Percentile(50) = %d, want 4", got)
+	}
+	if got := d.Percentile(95); got != 32 {
+		t.Errorf("Percentile(95) = %d, want 32", got)
+	}
+	if got := (&Histogram{}).Percentile(50); got != 0 {
+		t.Errorf("Percentile(50) of an empty histogram = %d, want 0", got)
+	}
+}
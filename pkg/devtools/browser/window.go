@@ -0,0 +1,85 @@
+package browser
+
+import (
+	"context"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// Window returns the ID and current bounds of the browser window
+// which contains the tab associated with the given context.
+func Window(ctx context.Context) (int64, *Bounds, error) {
+	w := NewGetWindowForTarget()
+	if s, ok := devtools.FromContext(ctx); ok {
+		if id := s.TargetID.Read(); id != "" {
+			w.SetTargetID(id)
+		}
+	}
+	r, err := w.Do(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+	return r.WindowID, &r.Bounds, nil
+}
+
+// Maximize maximizes the browser window which
+// contains the tab associated with the given context.
+func Maximize(ctx context.Context) error {
+	return setWindowState(ctx, WindowStateMaximized)
+}
+
+// Minimize minimizes the browser window which
+// contains the tab associated with the given context.
+func Minimize(ctx context.Context) error {
+	return setWindowState(ctx, WindowStateMinimized)
+}
+
+// Fullscreen switches the browser window which contains
+// the tab associated with the given context to fullscreen mode.
+func Fullscreen(ctx context.Context) error {
+	return setWindowState(ctx, WindowStateFullscreen)
+}
+
+// Restore restores the browser window which contains the tab associated
+// with the given context to its normal state, i.e. not minimized,
+// maximized or in fullscreen mode.
+func Restore(ctx context.Context) error {
+	return setWindowState(ctx, WindowStateNormal)
+}
+
+// SetSize resizes the browser window which contains the tab associated
+// with the given context, restoring it to its normal state if needed.
+func SetSize(ctx context.Context, width, height int64) error {
+	return setWindowBounds(ctx, Bounds{Width: width, Height: height})
+}
+
+// MoveTo moves the browser window which contains the tab associated with
+// the given context to the given screen coordinates, restoring it to its
+// normal state if needed.
+func MoveTo(ctx context.Context, left, top int64) error {
+	return setWindowBounds(ctx, Bounds{Left: left, Top: top})
+}
+
+func setWindowState(ctx context.Context, s WindowState) error {
+	id, _, err := Window(ctx)
+	if err != nil {
+		return err
+	}
+	return NewSetWindowBounds(id, Bounds{WindowState: &s}).Do(ctx)
+}
+
+// The window's position and size can't be changed unless it's in the normal
+// state, and they can't be combined with a state change in the same command.
+func setWindowBounds(ctx context.Context, b Bounds) error {
+	id, current, err := Window(ctx)
+	if err != nil {
+		return err
+	}
+	if current.WindowState != nil && *current.WindowState != WindowStateNormal {
+		normal := WindowStateNormal
+		if err := NewSetWindowBounds(id, Bounds{WindowState: &normal}).Do(ctx); err != nil {
+			return err
+		}
+	}
+	return NewSetWindowBounds(id, b).Do(ctx)
+}
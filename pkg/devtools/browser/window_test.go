@@ -0,0 +1,145 @@
+package browser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWindow(t *testing.T) {
+	const getWindow = `Browser.getWindowForTarget {"targetId":"` + devtools.FakeTargetID + `"}`
+	tests := []struct {
+		name    string
+		state   WindowState // Of the window before the change.
+		change  func(context.Context) error
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "maximize",
+			state:  WindowStateNormal,
+			change: Maximize,
+			want:   []string{getWindow, `Browser.setWindowBounds {"windowId":7,"bounds":{"windowState":"maximized"}}`},
+		},
+		{
+			name:   "minimize",
+			state:  WindowStateNormal,
+			change: Minimize,
+			want:   []string{getWindow, `Browser.setWindowBounds {"windowId":7,"bounds":{"windowState":"minimized"}}`},
+		},
+		{
+			name:   "fullscreen",
+			state:  WindowStateMaximized,
+			change: Fullscreen,
+			want:   []string{getWindow, `Browser.setWindowBounds {"windowId":7,"bounds":{"windowState":"fullscreen"}}`},
+		},
+		{
+			name:   "restore",
+			state:  WindowStateMinimized,
+			change: Restore,
+			want:   []string{getWindow, `Browser.setWindowBounds {"windowId":7,"bounds":{"windowState":"normal"}}`},
+		},
+		{
+			name:   "set_size_normal",
+			state:  WindowStateNormal,
+			change: func(ctx context.Context) error { return SetSize(ctx, 800, 600) },
+			want:   []string{getWindow, `Browser.setWindowBounds {"windowId":7,"bounds":{"width":800,"height":600}}`},
+		},
+		{
+			name:   "set_size_maximized",
+			state:  WindowStateMaximized,
+			change: func(ctx context.Context) error { return SetSize(ctx, 800, 600) },
+			want: []string{
+				getWindow,
+				`Browser.setWindowBounds {"windowId":7,"bounds":{"windowState":"normal"}}`,
+				`Browser.setWindowBounds {"windowId":7,"bounds":{"width":800,"height":600}}`,
+			},
+		},
+		{
+			name:   "move_to_fullscreen",
+			state:  WindowStateFullscreen,
+			change: func(ctx context.Context) error { return MoveTo(ctx, 10, 20) },
+			want: []string{
+				getWindow,
+				`Browser.setWindowBounds {"windowId":7,"bounds":{"windowState":"normal"}}`,
+				`Browser.setWindowBounds {"windowId":7,"bounds":{"left":10,"top":20}}`,
+			},
+		},
+		{
+			name:   "move_to_minimized",
+			state:  WindowStateMinimized,
+			change: func(ctx context.Context) error { return MoveTo(ctx, 10, 20) },
+			want: []string{
+				getWindow,
+				`Browser.setWindowBounds {"windowId":7,"bounds":{"windowState":"normal"}}`,
+				`Browser.setWindowBounds {"windowId":7,"bounds":{"left":10,"top":20}}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+			state := tt.state
+			b.Respond("Browser.getWindowForTarget", &GetWindowForTargetResult{
+				WindowID: 7,
+				Bounds:   Bounds{Left: 1, Top: 2, Width: 1024, Height: 768, WindowState: &state},
+			})
+
+			if err := tt.change(ctx); err != nil {
+				t.Fatalf("window change; got error: %v", err)
+			}
+			var got []string
+			for _, c := range b.Calls() {
+				got = append(got, c.Method+" "+string(c.Params))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("window commands mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWindowError(t *testing.T) {
+	tests := []struct {
+		name   string
+		failOn string
+		want   []string
+	}{
+		{
+			name:   "get_window",
+			failOn: "Browser.getWindowForTarget",
+			want:   []string{"Browser.getWindowForTarget"},
+		},
+		{
+			name:   "restore_state",
+			failOn: "Browser.setWindowBounds",
+			want:   []string{"Browser.getWindowForTarget", "Browser.setWindowBounds"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+			state := WindowStateMaximized
+			b.Respond("Browser.getWindowForTarget", &GetWindowForTargetResult{WindowID: 7, Bounds: Bounds{WindowState: &state}})
+			b.Fail(tt.failOn, "failed")
+
+			if err := SetSize(ctx, 800, 600); err == nil {
+				t.Error("SetSize() = nil error, want an error")
+			}
+			var got []string
+			for _, c := range b.Calls() {
+				got = append(got, c.Method)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("SetSize() commands mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -6,3 +6,8 @@ var executables = [...]string{
 
 	"/Applications/Chromium.app/Contents/MacOS/Chromium",
 }
+
+var firefoxExecutables = [...]string{
+	"/Applications/Firefox.app/Contents/MacOS/firefox",
+	"/Applications/Firefox Nightly.app/Contents/MacOS/firefox",
+}
@@ -12,3 +12,9 @@ var executables = [...]string{
 
 	"/usr/bin/google-chrome",
 }
+
+var firefoxExecutables = [...]string{
+	"firefox",
+	"firefox-nightly",
+	"/usr/bin/firefox",
+}
@@ -25,4 +25,11 @@ var (
 
 		"chrome.exe",
 	}
+
+	firefoxExecutables = [...]string{
+		filepath.Join(`C:\Program Files`, "Mozilla Firefox", "firefox.exe"),
+		filepath.Join(`C:\Program Files (x86)`, "Mozilla Firefox", "firefox.exe"),
+
+		"firefox.exe",
+	}
 )
@@ -0,0 +1,30 @@
+package devtools
+
+import "sync"
+
+// Pool of buffers for receiving CDP messages, to reduce GC pressure when
+// processing thousands of messages per second (e.g. Network events). Parsed
+// messages don't refer to these buffers, because `json.Unmarshal` copies raw
+// JSON values (i.e. `Message.Params` and `Message.Result`).
+var bufferPool = sync.Pool{New: func() interface{} {
+	b := make([]byte, 0, 4096)
+	return &b
+}}
+
+// Buffers which grew larger than this size (e.g. for screenshots) are not
+// returned to the pool, so they don't hold onto memory.
+const maxPooledBuffer = 1 << 20
+
+func getBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+// Return a buffer to the pool, with the given (possibly
+// reallocated) slice, unless it's too large.
+func putBuffer(bp *[]byte, b []byte) {
+	if cap(b) > maxPooledBuffer {
+		return
+	}
+	*bp = b[:0]
+	bufferPool.Put(bp)
+}
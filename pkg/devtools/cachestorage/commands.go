@@ -286,15 +286,25 @@ type RequestEntries struct {
 
 // NewRequestEntries constructs a new RequestEntries struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `RequestEntriesOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/CacheStorage/#method-requestEntries
-func NewRequestEntries(cacheID string) *RequestEntries {
-	return &RequestEntries{
+func NewRequestEntries(cacheID string, opts ...RequestEntriesOption) *RequestEntries {
+	c := &RequestEntries{
 		CacheID: cacheID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// RequestEntriesOption is a functional option for the optional
+// parameters of the RequestEntries CDP command, see the
+// NewRequestEntries constructor. Options are applied in order.
+type RequestEntriesOption = func(*RequestEntries)
+
 // SetSkipCount adds or modifies the value of the optional
 // parameter `skipCount` in the RequestEntries CDP command.
 //
@@ -304,6 +314,14 @@ func (t *RequestEntries) SetSkipCount(v int64) *RequestEntries {
 	return t
 }
 
+// RequestEntriesWithSkipCount is a functional option which sets the value of the
+// optional parameter `skipCount` in the RequestEntries CDP command, see SetSkipCount.
+func RequestEntriesWithSkipCount(v int64) RequestEntriesOption {
+	return func(c *RequestEntries) {
+		c.SetSkipCount(v)
+	}
+}
+
 // SetPageSize adds or modifies the value of the optional
 // parameter `pageSize` in the RequestEntries CDP command.
 //
@@ -313,6 +331,14 @@ func (t *RequestEntries) SetPageSize(v int64) *RequestEntries {
 	return t
 }
 
+// RequestEntriesWithPageSize is a functional option which sets the value of the
+// optional parameter `pageSize` in the RequestEntries CDP command, see SetPageSize.
+func RequestEntriesWithPageSize(v int64) RequestEntriesOption {
+	return func(c *RequestEntries) {
+		c.SetPageSize(v)
+	}
+}
+
 // SetPathFilter adds or modifies the value of the optional
 // parameter `pathFilter` in the RequestEntries CDP command.
 //
@@ -322,6 +348,14 @@ func (t *RequestEntries) SetPathFilter(v string) *RequestEntries {
 	return t
 }
 
+// RequestEntriesWithPathFilter is a functional option which sets the value of the
+// optional parameter `pathFilter` in the RequestEntries CDP command, see SetPathFilter.
+func RequestEntriesWithPathFilter(v string) RequestEntriesOption {
+	return func(c *RequestEntries) {
+		c.SetPathFilter(v)
+	}
+}
+
 // RequestEntriesResult contains the browser's response
 // to calling the RequestEntries CDP command with Do().
 type RequestEntriesResult struct {
@@ -24,13 +24,23 @@ type Enable struct {
 
 // NewEnable constructs a new Enable struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `EnableOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Cast/#method-enable
-func NewEnable() *Enable {
-	return &Enable{}
+func NewEnable(opts ...EnableOption) *Enable {
+	c := &Enable{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// EnableOption is a functional option for the optional
+// parameters of the Enable CDP command, see the
+// NewEnable constructor. Options are applied in order.
+type EnableOption = func(*Enable)
+
 // SetPresentationURL adds or modifies the value of the optional
 // parameter `presentationUrl` in the Enable CDP command.
 func (t *Enable) SetPresentationURL(v string) *Enable {
@@ -38,6 +48,14 @@ func (t *Enable) SetPresentationURL(v string) *Enable {
 	return t
 }
 
+// EnableWithPresentationURL is a functional option which sets the value of the
+// optional parameter `presentationUrl` in the Enable CDP command, see SetPresentationURL.
+func EnableWithPresentationURL(v string) EnableOption {
+	return func(c *Enable) {
+		c.SetPresentationURL(v)
+	}
+}
+
 // Do sends the Enable CDP command to a browser,
 // and returns the browser's response.
 func (t *Enable) Do(ctx context.Context) error {
@@ -0,0 +1,128 @@
+package devtools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// processInfoName is the name of the file in which a session's output
+// directory records the details of its browser process, for the
+// `devtools.CleanupStale` function.
+const processInfoName = "process.json"
+
+// Details of a locally-started browser process, and the Go process which
+// started it. The user data directory is part of the browser's command
+// line, so it identifies the browser even if its process ID is reused.
+type processInfo struct {
+	PID         int    `json:"pid"`
+	OwnerPID    int    `json:"ownerPid"`
+	UserDataDir string `json:"userDataDir"`
+}
+
+func writeProcessInfo(s *Session, pid int) error {
+	b, err := json.Marshal(&processInfo{PID: pid, OwnerPID: os.Getpid(), UserDataDir: s.UserDataDir})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.OutputDir, processInfoName), b, 0644)
+}
+
+// CleanupResult lists what the `devtools.CleanupStale` function cleaned up.
+type CleanupResult struct {
+	// RemovedDirs are the output directories that were deleted.
+	RemovedDirs []string
+	// KilledPIDs are the IDs of orphaned browser processes that were killed.
+	KilledPIDs []int
+}
+
+// CleanupStale scans the given root directory of session output directories
+// (or the default one, if the path is empty - see `devtools.OutputRootEnv`)
+// and cleans up after sessions of Go processes which ended without closing
+// their browser (e.g. crashed or killed CI jobs):
+//
+// • Orphaned browsers, whose Go process has ended, are killed along with
+// their child processes, if their command line still matches the session
+// (i.e. their process ID wasn't reused by an unrelated process).
+//
+// • Output directories of sessions whose browser has ended are deleted,
+// including their user data directory (if it's inside the output directory).
+//
+// Sessions which were started before the Go process was upgraded to support
+// this, and sessions which saved a descriptor to be resumed (see the
+// `SessionDescriptor.Save` method), are left alone - their browsers
+// are never killed, and their directories are never deleted. Errors in
+// specific directories don't stop the scan, the first one is returned.
+func CleanupStale(root string) (*CleanupResult, error) {
+	if root == "" {
+		root = outputRoot()
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	result := &CleanupResult{}
+	var firstErr error
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), outputDirPrefix) {
+			continue
+		}
+		dir := filepath.Join(root, e.Name())
+		if err := cleanupDir(dir, result); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to clean up %s: %v", dir, err)
+		}
+	}
+	return result, firstErr
+}
+
+func cleanupDir(dir string, result *CleanupResult) error {
+	if _, err := os.Stat(filepath.Join(dir, DescriptorName)); err == nil {
+		return nil // Resumable.
+	}
+	b, err := os.ReadFile(filepath.Join(dir, processInfoName))
+	if os.IsNotExist(err) {
+		return nil // Unknown, or still starting.
+	}
+	if err != nil {
+		return err
+	}
+	info := &processInfo{}
+	if err := json.Unmarshal(b, info); err != nil {
+		return err
+	}
+	if info.OwnerPID > 0 && processAlive(info.OwnerPID) {
+		return nil // Still in use, even if the browser has ended.
+	}
+
+	if processAlive(info.PID) {
+		cmdLine, err := processCommandLine(info.PID)
+		if err != nil {
+			return nil // Can't tell whether it's still the browser.
+		}
+		if isBrowserOf(info, cmdLine) {
+			p, err := os.FindProcess(info.PID)
+			if err != nil {
+				return err
+			}
+			if err := signalProcessGroup(p, syscall.SIGKILL); err != nil {
+				return err
+			}
+			result.KilledPIDs = append(result.KilledPIDs, info.PID)
+		}
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	result.RemovedDirs = append(result.RemovedDirs, dir)
+	return nil
+}
+
+// Report whether the given command line of the process with the recorded
+// ID shows that it's still the browser that was started by the session.
+func isBrowserOf(info *processInfo, cmdLine string) bool {
+	return info.UserDataDir != "" && strings.Contains(cmdLine, info.UserDataDir)
+}
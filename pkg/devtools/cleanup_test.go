@@ -0,0 +1,83 @@
+package devtools
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCleanupStale(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("command lines of other processes aren't supported")
+	}
+	root := t.TempDir()
+	dir := func(name string, files map[string]interface{}) string {
+		path := filepath.Join(root, name)
+		if err := os.Mkdir(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		for f, v := range files {
+			b, _ := json.Marshal(v)
+			if err := os.WriteFile(filepath.Join(path, f), b, 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return path
+	}
+
+	// A process which has already ended.
+	ended := exec.Command("true")
+	if err := ended.Run(); err != nil {
+		t.Fatalf("exec.Command(true).Run(); got error: %v", err)
+	}
+	deadPID := ended.ProcessState.Pid()
+
+	// An orphaned "browser", whose command line contains its user data directory.
+	userDataDir := filepath.Join(root, outputDirPrefix+"orphan", "user_data")
+	orphan := exec.Command("sh", "-c", "sleep 60", userDataDir)
+	setProcessGroup(orphan)
+	if err := orphan.Start(); err != nil {
+		t.Fatalf("orphan.Start(); got error: %v", err)
+	}
+	defer orphan.Process.Kill()
+	go orphan.Wait()
+
+	ended1 := dir(outputDirPrefix+"ended", map[string]interface{}{
+		processInfoName: &processInfo{PID: deadPID, OwnerPID: deadPID, UserDataDir: "x"},
+	})
+	orphaned := dir(outputDirPrefix+"orphan", map[string]interface{}{
+		processInfoName: &processInfo{PID: orphan.Process.Pid, OwnerPID: deadPID, UserDataDir: userDataDir},
+	})
+	dir(outputDirPrefix+"in_use", map[string]interface{}{
+		processInfoName: &processInfo{PID: orphan.Process.Pid, OwnerPID: os.Getpid(), UserDataDir: userDataDir},
+	})
+	dir(outputDirPrefix+"owner_alive", map[string]interface{}{
+		processInfoName: &processInfo{PID: deadPID, OwnerPID: os.Getpid(), UserDataDir: "x"},
+	})
+	dir(outputDirPrefix+"resumable", map[string]interface{}{
+		processInfoName: &processInfo{PID: deadPID, OwnerPID: deadPID, UserDataDir: "x"},
+		DescriptorName:  &SessionDescriptor{},
+	})
+	dir(outputDirPrefix+"unknown", nil)
+	dir("other", map[string]interface{}{
+		processInfoName: &processInfo{PID: deadPID, OwnerPID: deadPID, UserDataDir: "x"},
+	})
+
+	got, err := CleanupStale(root)
+	if err != nil {
+		t.Fatalf("CleanupStale(); got error: %v", err)
+	}
+	want := &CleanupResult{RemovedDirs: []string{ended1, orphaned}, KilledPIDs: []int{orphan.Process.Pid}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("CleanupStale() mismatch (-want +got):\n%s", diff)
+	}
+	entries, _ := os.ReadDir(root)
+	if len(entries) != 5 {
+		t.Errorf("CleanupStale() left %d directories, want 5", len(entries))
+	}
+}
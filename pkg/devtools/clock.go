@@ -0,0 +1,115 @@
+package devtools
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Clock maps monotonic timestamps of a browser (see `devtools.MonotonicTime`)
+// to wall-clock times, based on a sync point: a monotonic timestamp and the
+// wall-clock time at the same moment. This allows merging timelines of CDP
+// events (e.g. network requests and page lifecycle events) with external
+// logs. See the `devtools.WallClock` function. Multiple goroutines may use
+// it simultaneously.
+type Clock struct {
+	mu          sync.Mutex
+	mono        MonotonicTime
+	wall        time.Time
+	uncertainty time.Duration
+	synced      bool
+}
+
+// NewClock returns a clock with the given sync point.
+func NewClock(mono MonotonicTime, wall time.Time) *Clock {
+	return &Clock{mono: mono, wall: wall, synced: true}
+}
+
+// Sync replaces the clock's sync point with the given one, which the browser
+// reported, e.g. the `Timestamp` and `WallTime` fields of a
+// `network.RequestWillBeSent` event. This is more accurate than the initial
+// calibration of the `devtools.WallClock` function, and it compensates
+// for adjustments of the system's wall clock since then.
+func (c *Clock) Sync(mono MonotonicTime, wall TimeSinceEpoch) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mono, c.wall, c.uncertainty, c.synced = mono, wall.Time(), 0, true
+}
+
+// Time converts the given monotonic timestamp to a wall-clock time, in UTC.
+func (c *Clock) Time(t MonotonicTime) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.wall.Add(t.Duration() - c.mono.Duration())
+}
+
+// Monotonic converts the given wall-clock time to a monotonic timestamp
+// of the browser, e.g. to find CDP events near an external log entry.
+func (c *Clock) Monotonic(t time.Time) MonotonicTime {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.mono + MonotonicTime(t.Sub(c.wall).Seconds())
+}
+
+// Uncertainty returns the maximum error of the clock's sync point: half
+// the round-trip time of the calibration command, or 0 after the `Sync`
+// method was called with a sync point which the browser reported.
+func (c *Clock) Uncertainty() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.uncertainty
+}
+
+// Partial copy of `performance.GetMetricsResult`
+// (we don't use the performance sub-package to avoid circular dependencies).
+type metricsResult struct {
+	Metrics []struct {
+		Name  string  `json:"name"`
+		Value float64 `json:"value"`
+	} `json:"metrics"`
+}
+
+// WallClock returns the clock of the browser which is associated with the
+// given context (see `devtools.Clock`), which all of its tabs share. On the
+// first call, it calibrates the clock with the CDP command
+// `Performance.getMetrics`, whose "Timestamp" metric is the browser's
+// monotonic time, and the wall-clock time in the middle of the command's
+// round trip.
+func WallClock(ctx context.Context) (*Clock, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return nil, errNotInitialized
+	}
+	c := s.clock
+	c.mu.Lock()
+	synced := c.synced
+	c.mu.Unlock()
+	if synced {
+		return c, nil
+	}
+
+	release, err := EnableDomain(ctx, "Performance")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	start := time.Now()
+	r := &metricsResult{}
+	if err := sendAndParse(ctx, "Performance.getMetrics", nil, r); err != nil {
+		return nil, err
+	}
+	rtt := time.Since(start)
+	for _, m := range r.Metrics {
+		if m.Name != "Timestamp" {
+			continue
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if !c.synced { // Unless another goroutine was faster.
+			c.mono, c.wall, c.uncertainty, c.synced = MonotonicTime(m.Value), start.Add(rtt/2).UTC(), rtt/2, true
+		}
+		return c, nil
+	}
+	return nil, errors.New("the browser didn't report a monotonic timestamp")
+}
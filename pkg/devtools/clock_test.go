@@ -0,0 +1,48 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestWallClock(t *testing.T) {
+	var methods []string
+	ctx := NewFakeContext(context.Background(), func(_ context.Context, method string, _ json.RawMessage) (*Message, error) {
+		methods = append(methods, method)
+		if method == "Performance.getMetrics" {
+			return &Message{Result: json.RawMessage(`{"metrics":[{"name":"Nodes","value":5},{"name":"Timestamp","value":1000.5}]}`)}, nil
+		}
+		return &Message{Result: json.RawMessage(`{}`)}, nil
+	})
+
+	before := time.Now()
+	c, err := WallClock(ctx)
+	if err != nil {
+		t.Fatalf("WallClock(); got error: %v", err)
+	}
+	after := time.Now()
+	if got := c.Time(1001.5); got.Before(before.Add(time.Second)) || got.After(after.Add(time.Second)) {
+		t.Errorf("Time() = %v, want between %v and %v", got, before.Add(time.Second), after.Add(time.Second))
+	}
+	if c.Uncertainty() > after.Sub(before) {
+		t.Errorf("Uncertainty() = %v, want at most %v", c.Uncertainty(), after.Sub(before))
+	}
+
+	// Calibrated only once, and then synced by the caller.
+	if c2, _ := WallClock(ctx); c2 != c || len(methods) != 3 {
+		t.Errorf("WallClock() called again: %v", methods)
+	}
+	wall := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	c.Sync(2000, NewTimeSinceEpoch(wall))
+	if got, want := c.Time(2000.25), wall.Add(250*time.Millisecond); !got.Equal(want) {
+		t.Errorf("Time() = %v, want %v", got, want)
+	}
+	if got := c.Monotonic(wall.Add(-time.Second)); got != 1999 {
+		t.Errorf("Monotonic() = %v, want 1999", got)
+	}
+	if c.Uncertainty() != 0 {
+		t.Errorf("Uncertainty() after Sync() = %v, want 0", c.Uncertainty())
+	}
+}
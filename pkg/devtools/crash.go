@@ -0,0 +1,274 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Crash describes a crash of the renderer process of a browser tab,
+// reported by the CDP events `Inspector.targetCrashed` and/or
+// `Target.targetCrashed` (the latter contains more details).
+type Crash struct {
+	TargetID, SessionID string
+	// Termination status, e.g. "crashed", "killed" or "oom" (out of memory).
+	// Empty if the crash was reported only by `Inspector.targetCrashed`.
+	Status string
+	// Termination error code, if reported.
+	ErrorCode int64
+	// OOM indicates that the renderer process ran out of memory, according
+	// to its termination status or error code (see `isOOM`).
+	OOM  bool
+	Time time.Time
+	// Reloads is the number of automatic reloads of the tab so far,
+	// including the one triggered by this crash, if any (see the
+	// `devtools.ReloadOnCrash` session option).
+	Reloads int
+	// GaveUp indicates that this crash exceeded the maximum
+	// number of automatic reloads, so the tab was not reloaded.
+	GaveUp bool
+	// Minidump is the path of the crash's minidump file, if it was
+	// collected (see the `devtools.CollectCrashDumps` session option).
+	Minidump string
+}
+
+// Copy of `target.Crashed`, for parsing crash details.
+type targetCrashed struct {
+	TargetID  string `json:"targetId"`
+	Status    string `json:"status"`
+	ErrorCode int64  `json:"errorCode"`
+}
+
+// Crashes are reported at most once within this time window, because
+// a single crash may be reported by more than one type of CDP event.
+const crashDedupWindow = time.Second
+
+// Crashes which are reported by `Inspector.targetCrashed` are reported to
+// subscribers after this delay, unless `Target.targetCrashed` reports them
+// first, with more details (e.g. whether the renderer ran out of memory).
+const crashDetailsDelay = 200 * time.Millisecond
+
+// Renderer exit codes on Windows which indicate that it ran out of memory:
+// Chromium's own "out of memory" exception code (`base::win::kOomExceptionCode`),
+// and the system's `STATUS_NO_MEMORY`. On other operating systems, the
+// termination status is "oom" in such cases.
+var oomErrorCodes = map[int64]bool{
+	0xE0000008: true,
+	0xC0000017: true,
+}
+
+// Report whether a crash's termination status or
+// error code indicate that it ran out of memory.
+func isOOM(e *targetCrashed) bool {
+	return e.Status == "oom" || oomErrorCodes[e.ErrorCode]
+}
+
+type crashWatcher struct {
+	ctx     context.Context // The session's context.
+	session *Session
+
+	startMu sync.Mutex
+	started bool
+
+	mu          sync.Mutex
+	subscribers []chan *Crash
+	reloads     int
+	last        time.Time
+}
+
+// ReloadOnCrash allows the caller of the `devtools.NewContext` function to
+// reload the tab automatically, up to n times, when its renderer process
+// crashes, so long-running sessions can recover on their own. Crashes are
+// reported either way to receivers of the `devtools.Crashes` function.
+//
+// Unlike most other session options, this one is inherited by new tabs
+// (i.e. descendant contexts), unless they override it.
+func ReloadOnCrash(n int) SessionOption {
+	return func(s *Session) {
+		s.reloadOnCrash = n
+	}
+}
+
+// Crashes returns a channel to receive crash reports for the tab associated
+// with the given context. The channel is closed when the context is done.
+// Reports are dropped if the channel's buffer is full. With the
+// `devtools.CollectCrashDumps` session option, reports are sent after
+// their minidumps are collected, which may take a few seconds.
+func Crashes(ctx context.Context) (<-chan *Crash, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return nil, errNotInitialized
+	}
+	w := s.crashes
+	if err := w.start(); err != nil {
+		return nil, err
+	}
+
+	ch := make(chan *Crash, 16)
+	w.mu.Lock()
+	w.subscribers = append(w.subscribers, ch)
+	w.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		for i, c := range w.subscribers {
+			if c == ch {
+				w.subscribers = append(w.subscribers[:i:i], w.subscribers[i+1:]...)
+				close(ch)
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// Start watching for crashes of the tab, if this wasn't done already.
+// If starting fails, the next call tries again.
+func (w *crashWatcher) start() error {
+	w.startMu.Lock()
+	defer w.startMu.Unlock()
+	if w.started {
+		return nil
+	}
+	if err := w.subscribe(); err != nil {
+		return err
+	}
+	w.started = true
+	return nil
+}
+
+func (w *crashWatcher) subscribe() error {
+	inspector, err := Subscribe(w.ctx, "Inspector.targetCrashed")
+	if err != nil {
+		return err
+	}
+	target, err := Subscribe(w.ctx, "Target.targetCrashed")
+	if err != nil {
+		inspector.Unsubscribe()
+		return err
+	}
+
+	// The Inspector domain remains enabled as long as the session.
+	if _, err := EnableDomain(w.ctx, "Inspector"); err != nil {
+		inspector.Unsubscribe()
+		target.Unsubscribe()
+		return err
+	}
+	// Target discovery is browser-wide, so it's enabled in the browser's
+	// session rather than the tab's, and it remains enabled too.
+	// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-setDiscoverTargets
+	// (we don't use the target sub-package to avoid circular dependencies).
+	params := json.RawMessage(`{"discover":true}`)
+	if err := sendToBrowser(w.ctx, w.session, "Target.setDiscoverTargets", params, nil); err != nil {
+		inspector.Unsubscribe()
+		target.Unsubscribe()
+		return fmt.Errorf(`"Target.setDiscoverTargets" command error: %v`, err)
+	}
+
+	go w.watch(inspector, target)
+	return nil
+}
+
+// Receive crash events until the session's context is done.
+func (w *crashWatcher) watch(inspector, target *Subscription) {
+	defer func() {
+		inspector.Unsubscribe()
+		target.Unsubscribe()
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		for _, ch := range w.subscribers {
+			close(ch)
+		}
+		w.subscribers = nil
+	}()
+
+	var pending <-chan time.Time // See `crashDetailsDelay`.
+	for {
+		select {
+		case m, ok := <-inspector.C:
+			if !ok {
+				return // The context is done.
+			}
+			if m.TargetID == w.session.TargetID.Read() && pending == nil {
+				pending = time.After(crashDetailsDelay)
+			}
+		case m, ok := <-target.C:
+			if !ok {
+				return
+			}
+			e := &targetCrashed{}
+			if err := json.Unmarshal(m.Params, e); err != nil {
+				log.Printf("JSON error: %v", err)
+				continue
+			}
+			if e.TargetID == w.session.TargetID.Read() {
+				pending = nil
+				w.report(&Crash{Status: e.Status, ErrorCode: e.ErrorCode, OOM: isOOM(e)})
+			}
+		case <-pending:
+			pending = nil
+			w.report(&Crash{})
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+// Report a crash to all the subscribers, and reload the tab if needed.
+func (w *crashWatcher) report(c *Crash) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := time.Now()
+	if now.Sub(w.last) < crashDedupWindow {
+		return
+	}
+	w.last = now
+
+	c.TargetID = w.session.TargetID.Read()
+	c.SessionID = w.session.SessionID.Read()
+	c.Time = now
+	if w.reloads < w.session.reloadOnCrash {
+		w.reloads++
+		go func(n int) {
+			log.Printf("Reloading crashed tab %s (attempt %d)", c.TargetID, n)
+			// https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-reload
+			if err := sendAndParse(w.ctx, "Page.reload", nil, nil); err != nil {
+				log.Printf("Failed to reload crashed tab %s: %v", c.TargetID, err)
+			}
+		}(w.reloads)
+	} else if w.session.reloadOnCrash > 0 {
+		c.GaveUp = true
+	}
+	c.Reloads = w.reloads
+	log.Printf("Tab crashed: %+v", *c)
+	w.session.metrics.crashed()
+
+	if cd := w.session.crashDumps; cd != nil {
+		go func() {
+			cd.collect(c)
+			w.mu.Lock()
+			w.deliver(c)
+			w.mu.Unlock()
+			cd.runHooks(c)
+		}()
+		return
+	}
+	w.deliver(c)
+}
+
+// Send a crash report to all the subscribers, without blocking.
+// The caller must hold the watcher's lock.
+func (w *crashWatcher) deliver(c *Crash) {
+	for _, ch := range w.subscribers {
+		select {
+		case ch <- c:
+		default:
+			log.Printf("Dropped crash report for tab %s: channel is full", c.TargetID)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package devtools_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// Emit both types of crash events of the fake browser's tab.
+func emitCrash(t *testing.T, b *devtoolstest.Browser, status string, code int64) {
+	t.Helper()
+	if err := b.Emit("Inspector.targetCrashed", struct{}{}); err != nil {
+		t.Fatalf("Emit(); got error: %v", err)
+	}
+	e := map[string]interface{}{"targetId": devtools.FakeTargetID, "status": status, "errorCode": code}
+	if err := b.EmitBrowser("Target.targetCrashed", e); err != nil {
+		t.Fatalf("EmitBrowser(); got error: %v", err)
+	}
+}
+
+func receiveCrash(t *testing.T, ch <-chan *devtools.Crash) *devtools.Crash {
+	t.Helper()
+	select {
+	case c := <-ch:
+		return c
+	case <-time.After(5 * time.Second):
+		t.Fatal("crash report wasn't delivered")
+		return nil
+	}
+}
+
+func TestCrashes(t *testing.T) {
+	ignore := cmpopts.IgnoreFields(devtools.Crash{}, "Time")
+	tests := []struct {
+		name   string
+		status string
+		code   int64
+		want   *devtools.Crash
+	}{
+		{
+			name:   "crashed",
+			status: "crashed",
+			code:   11,
+			want:   &devtools.Crash{Status: "crashed", ErrorCode: 11},
+		},
+		{
+			name:   "oom_status",
+			status: "oom",
+			want:   &devtools.Crash{Status: "oom", OOM: true},
+		},
+		{
+			name:   "oom_error_code",
+			status: "crashed",
+			code:   0xE0000008,
+			want:   &devtools.Crash{Status: "crashed", ErrorCode: 0xE0000008, OOM: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+
+			ch, err := devtools.Crashes(ctx)
+			if err != nil {
+				t.Fatalf("Crashes(); got error: %v", err)
+			}
+			if got := b.Calls("Inspector.enable"); len(got) != 1 {
+				t.Errorf("Crashes() sent %d Inspector.enable commands, want 1", len(got))
+			}
+			if got := b.Calls("Target.setDiscoverTargets"); len(got) != 1 || string(got[0].Params) != `{"discover":true}` {
+				t.Errorf("Crashes() sent Target.setDiscoverTargets commands %v, want 1", got)
+			}
+
+			// Crashes of other targets are ignored.
+			if err := b.EmitBrowser("Target.targetCrashed", map[string]string{"targetId": "other"}); err != nil {
+				t.Fatalf("EmitBrowser(); got error: %v", err)
+			}
+			// Crashes of the tab are reported with their details.
+			if err := b.EmitBrowser("Target.targetCrashed", map[string]interface{}{
+				"targetId": devtools.FakeTargetID, "status": tt.status, "errorCode": tt.code}); err != nil {
+				t.Fatalf("EmitBrowser(); got error: %v", err)
+			}
+			want := *tt.want
+			want.TargetID, want.SessionID = devtools.FakeTargetID, devtools.FakeSessionID
+			if diff := cmp.Diff(&want, receiveCrash(t, ch), ignore); diff != "" {
+				t.Errorf("Crashes() report mismatch (-want +got):\n%s", diff)
+			}
+
+			// Tabs aren't reloaded without the ReloadOnCrash option.
+			if got := b.Calls("Page.reload"); len(got) != 0 {
+				t.Errorf("crash sent %d Page.reload commands, want 0", len(got))
+			}
+			cancel()
+			if _, ok := <-ch; ok {
+				t.Error("Crashes() channel isn't closed when the context is done")
+			}
+		})
+	}
+}
+
+func TestCrashesWithoutDetails(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, b := devtoolstest.NewContext(ctx)
+	ch, err := devtools.Crashes(ctx)
+	if err != nil {
+		t.Fatalf("Crashes(); got error: %v", err)
+	}
+
+	// Crashes of other tabs are ignored.
+	if err := devtools.DispatchEvent(ctx, &devtools.Message{SessionID: "other",
+		Method: "Inspector.targetCrashed", Params: json.RawMessage(`{}`)}); err != nil {
+		t.Fatalf("DispatchEvent(); got error: %v", err)
+	}
+	if err := b.Emit("Inspector.targetCrashed", struct{}{}); err != nil {
+		t.Fatalf("Emit(); got error: %v", err)
+	}
+	c := receiveCrash(t, ch)
+	if c.TargetID != devtools.FakeTargetID || c.Status != "" || c.OOM {
+		t.Errorf("Crashes() report = %+v, want only the target ID", c)
+	}
+	select {
+	case c := <-ch:
+		t.Errorf("unexpected crash report: %+v", c)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func TestCrashesError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, b := devtoolstest.NewContext(ctx)
+	b.Fail("Target.setDiscoverTargets", "failed")
+
+	if _, err := devtools.Crashes(ctx); err == nil {
+		t.Fatal("Crashes() = nil error, want an error")
+	}
+	// The failure isn't cached: the next call tries again.
+	b.Respond("Target.setDiscoverTargets", nil)
+	ch, err := devtools.Crashes(ctx)
+	if err != nil {
+		t.Fatalf("Crashes(); got error: %v", err)
+	}
+	emitCrash(t, b, "killed", 9)
+	if c := receiveCrash(t, ch); c.Status != "killed" {
+		t.Errorf("Crash.Status = %q, want %q", c.Status, "killed")
+	}
+	select {
+	case c := <-ch:
+		t.Errorf("crash reported twice, or by a leaked watcher: %+v", c)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestReloadOnCrash(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, b := devtoolstest.NewContext(ctx, devtools.ReloadOnCrash(1))
+	reloads := make(chan struct{}, 2)
+	b.Handle("Page.reload", func(json.RawMessage) (interface{}, error) {
+		reloads <- struct{}{}
+		return nil, nil
+	})
+	ch, err := devtools.Crashes(ctx)
+	if err != nil {
+		t.Fatalf("Crashes(); got error: %v", err)
+	}
+
+	// A crash which is reported by both types of events, and then
+	// again by one of them, is reported and reloaded only once.
+	emitCrash(t, b, "crashed", 11)
+	if err := b.Emit("Inspector.targetCrashed", struct{}{}); err != nil {
+		t.Fatalf("Emit(); got error: %v", err)
+	}
+	c := receiveCrash(t, ch)
+	if c.Reloads != 1 || c.GaveUp {
+		t.Errorf("first crash: Reloads = %d, GaveUp = %v; want 1, false", c.Reloads, c.GaveUp)
+	}
+	select {
+	case <-reloads:
+	case <-time.After(5 * time.Second):
+		t.Fatal("crashed tab wasn't reloaded")
+	}
+	select {
+	case c := <-ch:
+		t.Errorf("duplicate crash report: %+v", c)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Another crash, after the deduplication window,
+	// exceeds the maximum number of reloads.
+	time.Sleep(time.Second)
+	emitCrash(t, b, "crashed", 11)
+	c = receiveCrash(t, ch)
+	if c.Reloads != 1 || !c.GaveUp {
+		t.Errorf("second crash: Reloads = %d, GaveUp = %v; want 1, true", c.Reloads, c.GaveUp)
+	}
+	select {
+	case <-reloads:
+		t.Error("crashed tab was reloaded after giving up")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
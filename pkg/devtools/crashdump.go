@@ -0,0 +1,119 @@
+package devtools
+
+import (
+	"io/fs"
+	"log"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CrashHook processes a crash after its minidump was collected (see the
+// `devtools.CollectCrashDumps` session option), e.g. to symbolize it with
+// tools such as minidump_stackwalk, or to upload it to a crash aggregation
+// service. Hooks are called in a separate goroutine, in order, and their
+// errors are logged.
+type CrashHook func(c *Crash) error
+
+// Maximum amount of time to wait for a minidump file after a crash, because
+// the crash handler writes it asynchronously, and polling interval.
+const (
+	crashDumpTimeout  = 10 * time.Second
+	crashDumpInterval = 100 * time.Millisecond
+)
+
+// Minidump files which were written before a crash by more than this
+// are attributed to earlier crashes, or to other browsers.
+const crashDumpSlack = time.Minute
+
+// Configuration and state of minidump collection, shared by all the tabs
+// of a browser, so a minidump isn't attributed to more than one crash.
+type crashDumps struct {
+	dir   string
+	hooks []CrashHook
+
+	mu   *sync.Mutex
+	seen map[string]bool
+}
+
+// CollectCrashDumps allows the caller of the `devtools.NewContext` function to
+// collect minidump files of renderer crashes: the browser's crash handler
+// (crashpad) writes them in the "crashpad" subdirectory of the session's
+// output directory, and the `Minidump` field of crash reports (see the
+// `devtools.Crashes` function) contains the path of each crash's minidump,
+// if it's written within a few seconds. The given hooks (if any) are called
+// with each crash report after that, e.g. to upload it.
+//
+// This is ignored with remote browsers (see the `devtools.RemoteBrowser`
+// option), and with Firefox. Like the `devtools.ReloadOnCrash` option, it's
+// inherited by new tabs, unless they override it with their own hooks.
+func CollectCrashDumps(hooks ...CrashHook) SessionOption {
+	return func(s *Session) {
+		cd := &crashDumps{hooks: hooks, mu: &sync.Mutex{}, seen: make(map[string]bool)}
+		if s.crashDumps != nil {
+			// A new tab in an existing browser, with its own hooks.
+			cd.dir, cd.mu, cd.seen = s.crashDumps.dir, s.crashDumps.mu, s.crashDumps.seen
+		}
+		s.crashDumps = cd
+	}
+}
+
+// Adjust the browser flags before starting the browser, if the caller
+// specified the `devtools.CollectCrashDumps` session option.
+func adjustCrashDumpFlags(s *Session) {
+	s.crashDumps.dir = filepath.Join(s.OutputDir, "crashpad")
+	s.browserFlags["enable-crash-reporter"] = true
+	s.browserFlags["crash-dumps-dir"] = s.crashDumps.dir
+}
+
+// Wait for the minidump of the given crash, and attribute it to the
+// crash, unless it was already attributed to another one.
+func (cd *crashDumps) collect(c *Crash) {
+	if cd.dir == "" {
+		return
+	}
+	deadline := time.Now().Add(crashDumpTimeout)
+	for {
+		if path := cd.claim(c.Time.Add(-crashDumpSlack)); path != "" {
+			c.Minidump = path
+			return
+		}
+		if time.Now().After(deadline) {
+			log.Printf("No minidump found in %s for the crash of tab %s", cd.dir, c.TargetID)
+			return
+		}
+		time.Sleep(crashDumpInterval)
+	}
+}
+
+// Find the newest unclaimed minidump file which was modified after the
+// given time, in any of the crash handler's subdirectories (e.g.
+// "pending" or "completed"), and mark it as claimed.
+func (cd *crashDumps) claim(after time.Time) string {
+	cd.mu.Lock()
+	defer cd.mu.Unlock()
+	newest, newestTime := "", after
+	filepath.WalkDir(cd.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".dmp") || cd.seen[path] {
+			return nil
+		}
+		if info, err := d.Info(); err == nil && info.ModTime().After(newestTime) {
+			newest, newestTime = path, info.ModTime()
+		}
+		return nil
+	})
+	if newest != "" {
+		cd.seen[newest] = true
+	}
+	return newest
+}
+
+// Call the hooks with the given crash, in order.
+func (cd *crashDumps) runHooks(c *Crash) {
+	for _, h := range cd.hooks {
+		if err := h(c); err != nil {
+			log.Printf("Crash hook error for tab %s: %v", c.TargetID, err)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCollectCrashDumps(t *testing.T) {
+	dir := t.TempDir()
+	s := &Session{OutputDir: dir, browserFlags: map[string]interface{}{}}
+	hooked := make(chan *Crash, 1)
+	CollectCrashDumps(func(c *Crash) error {
+		hooked <- c
+		return nil
+	})(s)
+	adjustCrashDumpFlags(s)
+	crashpad := filepath.Join(dir, "crashpad")
+	if s.browserFlags["crash-dumps-dir"] != crashpad || s.browserFlags["enable-crash-reporter"] != true {
+		t.Errorf("adjustCrashDumpFlags() = %v", s.browserFlags)
+	}
+
+	// An old minidump, from an earlier crash, and the crash's minidump.
+	pending := filepath.Join(crashpad, "pending")
+	if err := os.MkdirAll(pending, 0755); err != nil {
+		t.Fatal(err)
+	}
+	old, dump := filepath.Join(pending, "old.dmp"), filepath.Join(pending, "new.dmp")
+	for _, path := range []string{old, dump} {
+		if err := os.WriteFile(path, []byte("MDMP"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	longAgo := time.Now().Add(-time.Hour)
+	os.Chtimes(old, longAgo, longAgo)
+
+	ctx := NewFakeContext(context.Background(), func(context.Context, string, json.RawMessage) (*Message, error) {
+		return &Message{}, nil
+	})
+	session, _ := FromContext(ctx)
+	session.crashDumps = s.crashDumps
+	w := &crashWatcher{ctx: ctx, session: session}
+	ch := make(chan *Crash, 1)
+	w.subscribers = append(w.subscribers, ch)
+	w.report(&Crash{Status: "crashed"})
+
+	select {
+	case c := <-ch:
+		if c.Minidump != dump {
+			t.Errorf("Crash.Minidump = %q, want %q", c.Minidump, dump)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("crash report wasn't delivered")
+	}
+	if c := <-hooked; c.Minidump != dump {
+		t.Errorf("hook received Crash.Minidump = %q, want %q", c.Minidump, dump)
+	}
+	if path := s.crashDumps.claim(time.Now().Add(-crashDumpSlack)); path != "" {
+		t.Errorf("claim() = %q, want no unclaimed minidump", path)
+	}
+}
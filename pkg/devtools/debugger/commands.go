@@ -23,15 +23,25 @@ type ContinueToLocation struct {
 
 // NewContinueToLocation constructs a new ContinueToLocation struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ContinueToLocationOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#method-continueToLocation
-func NewContinueToLocation(location Location) *ContinueToLocation {
-	return &ContinueToLocation{
+func NewContinueToLocation(location Location, opts ...ContinueToLocationOption) *ContinueToLocation {
+	c := &ContinueToLocation{
 		Location: location,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ContinueToLocationOption is a functional option for the optional
+// parameters of the ContinueToLocation CDP command, see the
+// NewContinueToLocation constructor. Options are applied in order.
+type ContinueToLocationOption = func(*ContinueToLocation)
+
 // SetTargetCallFrames adds or modifies the value of the optional
 // parameter `targetCallFrames` in the ContinueToLocation CDP command.
 func (t *ContinueToLocation) SetTargetCallFrames(v string) *ContinueToLocation {
@@ -39,6 +49,14 @@ func (t *ContinueToLocation) SetTargetCallFrames(v string) *ContinueToLocation {
 	return t
 }
 
+// ContinueToLocationWithTargetCallFrames is a functional option which sets the value of the
+// optional parameter `targetCallFrames` in the ContinueToLocation CDP command, see SetTargetCallFrames.
+func ContinueToLocationWithTargetCallFrames(v string) ContinueToLocationOption {
+	return func(c *ContinueToLocation) {
+		c.SetTargetCallFrames(v)
+	}
+}
+
 // Do sends the ContinueToLocation CDP command to a browser,
 // and returns the browser's response.
 func (t *ContinueToLocation) Do(ctx context.Context) error {
@@ -135,13 +153,23 @@ type Enable struct {
 
 // NewEnable constructs a new Enable struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `EnableOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#method-enable
-func NewEnable() *Enable {
-	return &Enable{}
+func NewEnable(opts ...EnableOption) *Enable {
+	c := &Enable{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// EnableOption is a functional option for the optional
+// parameters of the Enable CDP command, see the
+// NewEnable constructor. Options are applied in order.
+type EnableOption = func(*Enable)
+
 // SetMaxScriptsCacheSize adds or modifies the value of the optional
 // parameter `maxScriptsCacheSize` in the Enable CDP command.
 //
@@ -154,6 +182,14 @@ func (t *Enable) SetMaxScriptsCacheSize(v float64) *Enable {
 	return t
 }
 
+// EnableWithMaxScriptsCacheSize is a functional option which sets the value of the
+// optional parameter `maxScriptsCacheSize` in the Enable CDP command, see SetMaxScriptsCacheSize.
+func EnableWithMaxScriptsCacheSize(v float64) EnableOption {
+	return func(c *Enable) {
+		c.SetMaxScriptsCacheSize(v)
+	}
+}
+
 // EnableResult contains the browser's response
 // to calling the Enable CDP command with Do().
 type EnableResult struct {
@@ -238,16 +274,26 @@ type EvaluateOnCallFrame struct {
 
 // NewEvaluateOnCallFrame constructs a new EvaluateOnCallFrame struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `EvaluateOnCallFrameOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#method-evaluateOnCallFrame
-func NewEvaluateOnCallFrame(callFrameID string, expression string) *EvaluateOnCallFrame {
-	return &EvaluateOnCallFrame{
+func NewEvaluateOnCallFrame(callFrameID string, expression string, opts ...EvaluateOnCallFrameOption) *EvaluateOnCallFrame {
+	c := &EvaluateOnCallFrame{
 		CallFrameID: callFrameID,
 		Expression:  expression,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// EvaluateOnCallFrameOption is a functional option for the optional
+// parameters of the EvaluateOnCallFrame CDP command, see the
+// NewEvaluateOnCallFrame constructor. Options are applied in order.
+type EvaluateOnCallFrameOption = func(*EvaluateOnCallFrame)
+
 // SetObjectGroup adds or modifies the value of the optional
 // parameter `objectGroup` in the EvaluateOnCallFrame CDP command.
 //
@@ -258,6 +304,14 @@ func (t *EvaluateOnCallFrame) SetObjectGroup(v string) *EvaluateOnCallFrame {
 	return t
 }
 
+// EvaluateOnCallFrameWithObjectGroup is a functional option which sets the value of the
+// optional parameter `objectGroup` in the EvaluateOnCallFrame CDP command, see SetObjectGroup.
+func EvaluateOnCallFrameWithObjectGroup(v string) EvaluateOnCallFrameOption {
+	return func(c *EvaluateOnCallFrame) {
+		c.SetObjectGroup(v)
+	}
+}
+
 // SetIncludeCommandLineAPI adds or modifies the value of the optional
 // parameter `includeCommandLineAPI` in the EvaluateOnCallFrame CDP command.
 //
@@ -268,6 +322,14 @@ func (t *EvaluateOnCallFrame) SetIncludeCommandLineAPI(v bool) *EvaluateOnCallFr
 	return t
 }
 
+// EvaluateOnCallFrameWithIncludeCommandLineAPI is a functional option which sets the value of the
+// optional parameter `includeCommandLineAPI` in the EvaluateOnCallFrame CDP command, see SetIncludeCommandLineAPI.
+func EvaluateOnCallFrameWithIncludeCommandLineAPI(v bool) EvaluateOnCallFrameOption {
+	return func(c *EvaluateOnCallFrame) {
+		c.SetIncludeCommandLineAPI(v)
+	}
+}
+
 // SetSilent adds or modifies the value of the optional
 // parameter `silent` in the EvaluateOnCallFrame CDP command.
 //
@@ -278,6 +340,14 @@ func (t *EvaluateOnCallFrame) SetSilent(v bool) *EvaluateOnCallFrame {
 	return t
 }
 
+// EvaluateOnCallFrameWithSilent is a functional option which sets the value of the
+// optional parameter `silent` in the EvaluateOnCallFrame CDP command, see SetSilent.
+func EvaluateOnCallFrameWithSilent(v bool) EvaluateOnCallFrameOption {
+	return func(c *EvaluateOnCallFrame) {
+		c.SetSilent(v)
+	}
+}
+
 // SetReturnByValue adds or modifies the value of the optional
 // parameter `returnByValue` in the EvaluateOnCallFrame CDP command.
 //
@@ -287,6 +357,14 @@ func (t *EvaluateOnCallFrame) SetReturnByValue(v bool) *EvaluateOnCallFrame {
 	return t
 }
 
+// EvaluateOnCallFrameWithReturnByValue is a functional option which sets the value of the
+// optional parameter `returnByValue` in the EvaluateOnCallFrame CDP command, see SetReturnByValue.
+func EvaluateOnCallFrameWithReturnByValue(v bool) EvaluateOnCallFrameOption {
+	return func(c *EvaluateOnCallFrame) {
+		c.SetReturnByValue(v)
+	}
+}
+
 // SetGeneratePreview adds or modifies the value of the optional
 // parameter `generatePreview` in the EvaluateOnCallFrame CDP command.
 //
@@ -298,6 +376,14 @@ func (t *EvaluateOnCallFrame) SetGeneratePreview(v bool) *EvaluateOnCallFrame {
 	return t
 }
 
+// EvaluateOnCallFrameWithGeneratePreview is a functional option which sets the value of the
+// optional parameter `generatePreview` in the EvaluateOnCallFrame CDP command, see SetGeneratePreview.
+func EvaluateOnCallFrameWithGeneratePreview(v bool) EvaluateOnCallFrameOption {
+	return func(c *EvaluateOnCallFrame) {
+		c.SetGeneratePreview(v)
+	}
+}
+
 // SetThrowOnSideEffect adds or modifies the value of the optional
 // parameter `throwOnSideEffect` in the EvaluateOnCallFrame CDP command.
 //
@@ -307,6 +393,14 @@ func (t *EvaluateOnCallFrame) SetThrowOnSideEffect(v bool) *EvaluateOnCallFrame
 	return t
 }
 
+// EvaluateOnCallFrameWithThrowOnSideEffect is a functional option which sets the value of the
+// optional parameter `throwOnSideEffect` in the EvaluateOnCallFrame CDP command, see SetThrowOnSideEffect.
+func EvaluateOnCallFrameWithThrowOnSideEffect(v bool) EvaluateOnCallFrameOption {
+	return func(c *EvaluateOnCallFrame) {
+		c.SetThrowOnSideEffect(v)
+	}
+}
+
 // SetTimeout adds or modifies the value of the optional
 // parameter `timeout` in the EvaluateOnCallFrame CDP command.
 //
@@ -318,6 +412,14 @@ func (t *EvaluateOnCallFrame) SetTimeout(v float64) *EvaluateOnCallFrame {
 	return t
 }
 
+// EvaluateOnCallFrameWithTimeout is a functional option which sets the value of the
+// optional parameter `timeout` in the EvaluateOnCallFrame CDP command, see SetTimeout.
+func EvaluateOnCallFrameWithTimeout(v float64) EvaluateOnCallFrameOption {
+	return func(c *EvaluateOnCallFrame) {
+		c.SetTimeout(v)
+	}
+}
+
 // EvaluateOnCallFrameResult contains the browser's response
 // to calling the EvaluateOnCallFrame CDP command with Do().
 type EvaluateOnCallFrameResult struct {
@@ -385,15 +487,25 @@ type GetPossibleBreakpoints struct {
 
 // NewGetPossibleBreakpoints constructs a new GetPossibleBreakpoints struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetPossibleBreakpointsOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#method-getPossibleBreakpoints
-func NewGetPossibleBreakpoints(start Location) *GetPossibleBreakpoints {
-	return &GetPossibleBreakpoints{
+func NewGetPossibleBreakpoints(start Location, opts ...GetPossibleBreakpointsOption) *GetPossibleBreakpoints {
+	c := &GetPossibleBreakpoints{
 		Start: start,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetPossibleBreakpointsOption is a functional option for the optional
+// parameters of the GetPossibleBreakpoints CDP command, see the
+// NewGetPossibleBreakpoints constructor. Options are applied in order.
+type GetPossibleBreakpointsOption = func(*GetPossibleBreakpoints)
+
 // SetEnd adds or modifies the value of the optional
 // parameter `end` in the GetPossibleBreakpoints CDP command.
 //
@@ -404,6 +516,14 @@ func (t *GetPossibleBreakpoints) SetEnd(v Location) *GetPossibleBreakpoints {
 	return t
 }
 
+// GetPossibleBreakpointsWithEnd is a functional option which sets the value of the
+// optional parameter `end` in the GetPossibleBreakpoints CDP command, see SetEnd.
+func GetPossibleBreakpointsWithEnd(v Location) GetPossibleBreakpointsOption {
+	return func(c *GetPossibleBreakpoints) {
+		c.SetEnd(v)
+	}
+}
+
 // SetRestrictToFunction adds or modifies the value of the optional
 // parameter `restrictToFunction` in the GetPossibleBreakpoints CDP command.
 //
@@ -413,6 +533,14 @@ func (t *GetPossibleBreakpoints) SetRestrictToFunction(v bool) *GetPossibleBreak
 	return t
 }
 
+// GetPossibleBreakpointsWithRestrictToFunction is a functional option which sets the value of the
+// optional parameter `restrictToFunction` in the GetPossibleBreakpoints CDP command, see SetRestrictToFunction.
+func GetPossibleBreakpointsWithRestrictToFunction(v bool) GetPossibleBreakpointsOption {
+	return func(c *GetPossibleBreakpoints) {
+		c.SetRestrictToFunction(v)
+	}
+}
+
 // GetPossibleBreakpointsResult contains the browser's response
 // to calling the GetPossibleBreakpoints CDP command with Do().
 type GetPossibleBreakpointsResult struct {
@@ -915,13 +1043,23 @@ type Resume struct {
 
 // NewResume constructs a new Resume struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ResumeOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#method-resume
-func NewResume() *Resume {
-	return &Resume{}
+func NewResume(opts ...ResumeOption) *Resume {
+	c := &Resume{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ResumeOption is a functional option for the optional
+// parameters of the Resume CDP command, see the
+// NewResume constructor. Options are applied in order.
+type ResumeOption = func(*Resume)
+
 // SetTerminateOnResume adds or modifies the value of the optional
 // parameter `terminateOnResume` in the Resume CDP command.
 //
@@ -935,6 +1073,14 @@ func (t *Resume) SetTerminateOnResume(v bool) *Resume {
 	return t
 }
 
+// ResumeWithTerminateOnResume is a functional option which sets the value of the
+// optional parameter `terminateOnResume` in the Resume CDP command, see SetTerminateOnResume.
+func ResumeWithTerminateOnResume(v bool) ResumeOption {
+	return func(c *Resume) {
+		c.SetTerminateOnResume(v)
+	}
+}
+
 // Do sends the Resume CDP command to a browser,
 // and returns the browser's response.
 func (t *Resume) Do(ctx context.Context) error {
@@ -989,16 +1135,26 @@ type SearchInContent struct {
 
 // NewSearchInContent constructs a new SearchInContent struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SearchInContentOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#method-searchInContent
-func NewSearchInContent(scriptID string, query string) *SearchInContent {
-	return &SearchInContent{
+func NewSearchInContent(scriptID string, query string, opts ...SearchInContentOption) *SearchInContent {
+	c := &SearchInContent{
 		ScriptID: scriptID,
 		Query:    query,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SearchInContentOption is a functional option for the optional
+// parameters of the SearchInContent CDP command, see the
+// NewSearchInContent constructor. Options are applied in order.
+type SearchInContentOption = func(*SearchInContent)
+
 // SetCaseSensitive adds or modifies the value of the optional
 // parameter `caseSensitive` in the SearchInContent CDP command.
 //
@@ -1008,6 +1164,14 @@ func (t *SearchInContent) SetCaseSensitive(v bool) *SearchInContent {
 	return t
 }
 
+// SearchInContentWithCaseSensitive is a functional option which sets the value of the
+// optional parameter `caseSensitive` in the SearchInContent CDP command, see SetCaseSensitive.
+func SearchInContentWithCaseSensitive(v bool) SearchInContentOption {
+	return func(c *SearchInContent) {
+		c.SetCaseSensitive(v)
+	}
+}
+
 // SetIsRegex adds or modifies the value of the optional
 // parameter `isRegex` in the SearchInContent CDP command.
 //
@@ -1017,6 +1181,14 @@ func (t *SearchInContent) SetIsRegex(v bool) *SearchInContent {
 	return t
 }
 
+// SearchInContentWithIsRegex is a functional option which sets the value of the
+// optional parameter `isRegex` in the SearchInContent CDP command, see SetIsRegex.
+func SearchInContentWithIsRegex(v bool) SearchInContentOption {
+	return func(c *SearchInContent) {
+		c.SetIsRegex(v)
+	}
+}
+
 // SearchInContentResult contains the browser's response
 // to calling the SearchInContent CDP command with Do().
 type SearchInContentResult struct {
@@ -1266,15 +1438,25 @@ type SetBreakpoint struct {
 
 // NewSetBreakpoint constructs a new SetBreakpoint struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetBreakpointOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#method-setBreakpoint
-func NewSetBreakpoint(location Location) *SetBreakpoint {
-	return &SetBreakpoint{
+func NewSetBreakpoint(location Location, opts ...SetBreakpointOption) *SetBreakpoint {
+	c := &SetBreakpoint{
 		Location: location,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetBreakpointOption is a functional option for the optional
+// parameters of the SetBreakpoint CDP command, see the
+// NewSetBreakpoint constructor. Options are applied in order.
+type SetBreakpointOption = func(*SetBreakpoint)
+
 // SetCondition adds or modifies the value of the optional
 // parameter `condition` in the SetBreakpoint CDP command.
 //
@@ -1285,6 +1467,14 @@ func (t *SetBreakpoint) SetCondition(v string) *SetBreakpoint {
 	return t
 }
 
+// SetBreakpointWithCondition is a functional option which sets the value of the
+// optional parameter `condition` in the SetBreakpoint CDP command, see SetCondition.
+func SetBreakpointWithCondition(v string) SetBreakpointOption {
+	return func(c *SetBreakpoint) {
+		c.SetCondition(v)
+	}
+}
+
 // SetBreakpointResult contains the browser's response
 // to calling the SetBreakpoint CDP command with Do().
 type SetBreakpointResult struct {
@@ -1429,15 +1619,25 @@ type SetBreakpointByURL struct {
 
 // NewSetBreakpointByURL constructs a new SetBreakpointByURL struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetBreakpointByURLOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#method-setBreakpointByUrl
-func NewSetBreakpointByURL(lineNumber int64) *SetBreakpointByURL {
-	return &SetBreakpointByURL{
+func NewSetBreakpointByURL(lineNumber int64, opts ...SetBreakpointByURLOption) *SetBreakpointByURL {
+	c := &SetBreakpointByURL{
 		LineNumber: lineNumber,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetBreakpointByURLOption is a functional option for the optional
+// parameters of the SetBreakpointByURL CDP command, see the
+// NewSetBreakpointByURL constructor. Options are applied in order.
+type SetBreakpointByURLOption = func(*SetBreakpointByURL)
+
 // SetURL adds or modifies the value of the optional
 // parameter `url` in the SetBreakpointByURL CDP command.
 //
@@ -1447,6 +1647,14 @@ func (t *SetBreakpointByURL) SetURL(v string) *SetBreakpointByURL {
 	return t
 }
 
+// SetBreakpointByURLWithURL is a functional option which sets the value of the
+// optional parameter `url` in the SetBreakpointByURL CDP command, see SetURL.
+func SetBreakpointByURLWithURL(v string) SetBreakpointByURLOption {
+	return func(c *SetBreakpointByURL) {
+		c.SetURL(v)
+	}
+}
+
 // SetURLRegex adds or modifies the value of the optional
 // parameter `urlRegex` in the SetBreakpointByURL CDP command.
 //
@@ -1457,6 +1665,14 @@ func (t *SetBreakpointByURL) SetURLRegex(v string) *SetBreakpointByURL {
 	return t
 }
 
+// SetBreakpointByURLWithURLRegex is a functional option which sets the value of the
+// optional parameter `urlRegex` in the SetBreakpointByURL CDP command, see SetURLRegex.
+func SetBreakpointByURLWithURLRegex(v string) SetBreakpointByURLOption {
+	return func(c *SetBreakpointByURL) {
+		c.SetURLRegex(v)
+	}
+}
+
 // SetScriptHash adds or modifies the value of the optional
 // parameter `scriptHash` in the SetBreakpointByURL CDP command.
 //
@@ -1466,6 +1682,14 @@ func (t *SetBreakpointByURL) SetScriptHash(v string) *SetBreakpointByURL {
 	return t
 }
 
+// SetBreakpointByURLWithScriptHash is a functional option which sets the value of the
+// optional parameter `scriptHash` in the SetBreakpointByURL CDP command, see SetScriptHash.
+func SetBreakpointByURLWithScriptHash(v string) SetBreakpointByURLOption {
+	return func(c *SetBreakpointByURL) {
+		c.SetScriptHash(v)
+	}
+}
+
 // SetColumnNumber adds or modifies the value of the optional
 // parameter `columnNumber` in the SetBreakpointByURL CDP command.
 //
@@ -1475,6 +1699,14 @@ func (t *SetBreakpointByURL) SetColumnNumber(v int64) *SetBreakpointByURL {
 	return t
 }
 
+// SetBreakpointByURLWithColumnNumber is a functional option which sets the value of the
+// optional parameter `columnNumber` in the SetBreakpointByURL CDP command, see SetColumnNumber.
+func SetBreakpointByURLWithColumnNumber(v int64) SetBreakpointByURLOption {
+	return func(c *SetBreakpointByURL) {
+		c.SetColumnNumber(v)
+	}
+}
+
 // SetCondition adds or modifies the value of the optional
 // parameter `condition` in the SetBreakpointByURL CDP command.
 //
@@ -1485,6 +1717,14 @@ func (t *SetBreakpointByURL) SetCondition(v string) *SetBreakpointByURL {
 	return t
 }
 
+// SetBreakpointByURLWithCondition is a functional option which sets the value of the
+// optional parameter `condition` in the SetBreakpointByURL CDP command, see SetCondition.
+func SetBreakpointByURLWithCondition(v string) SetBreakpointByURLOption {
+	return func(c *SetBreakpointByURL) {
+		c.SetCondition(v)
+	}
+}
+
 // SetBreakpointByURLResult contains the browser's response
 // to calling the SetBreakpointByURL CDP command with Do().
 type SetBreakpointByURLResult struct {
@@ -1553,17 +1793,27 @@ type SetBreakpointOnFunctionCall struct {
 
 // NewSetBreakpointOnFunctionCall constructs a new SetBreakpointOnFunctionCall struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetBreakpointOnFunctionCallOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#method-setBreakpointOnFunctionCall
 //
 // This CDP method is experimental.
-func NewSetBreakpointOnFunctionCall(objectID string) *SetBreakpointOnFunctionCall {
-	return &SetBreakpointOnFunctionCall{
+func NewSetBreakpointOnFunctionCall(objectID string, opts ...SetBreakpointOnFunctionCallOption) *SetBreakpointOnFunctionCall {
+	c := &SetBreakpointOnFunctionCall{
 		ObjectID: objectID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetBreakpointOnFunctionCallOption is a functional option for the optional
+// parameters of the SetBreakpointOnFunctionCall CDP command, see the
+// NewSetBreakpointOnFunctionCall constructor. Options are applied in order.
+type SetBreakpointOnFunctionCallOption = func(*SetBreakpointOnFunctionCall)
+
 // SetCondition adds or modifies the value of the optional
 // parameter `condition` in the SetBreakpointOnFunctionCall CDP command.
 //
@@ -1574,6 +1824,14 @@ func (t *SetBreakpointOnFunctionCall) SetCondition(v string) *SetBreakpointOnFun
 	return t
 }
 
+// SetBreakpointOnFunctionCallWithCondition is a functional option which sets the value of the
+// optional parameter `condition` in the SetBreakpointOnFunctionCall CDP command, see SetCondition.
+func SetBreakpointOnFunctionCallWithCondition(v string) SetBreakpointOnFunctionCallOption {
+	return func(c *SetBreakpointOnFunctionCall) {
+		c.SetCondition(v)
+	}
+}
+
 // SetBreakpointOnFunctionCallResult contains the browser's response
 // to calling the SetBreakpointOnFunctionCall CDP command with Do().
 type SetBreakpointOnFunctionCallResult struct {
@@ -1814,16 +2072,26 @@ type SetScriptSource struct {
 
 // NewSetScriptSource constructs a new SetScriptSource struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetScriptSourceOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#method-setScriptSource
-func NewSetScriptSource(scriptID string, scriptSource string) *SetScriptSource {
-	return &SetScriptSource{
+func NewSetScriptSource(scriptID string, scriptSource string, opts ...SetScriptSourceOption) *SetScriptSource {
+	c := &SetScriptSource{
 		ScriptID:     scriptID,
 		ScriptSource: scriptSource,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetScriptSourceOption is a functional option for the optional
+// parameters of the SetScriptSource CDP command, see the
+// NewSetScriptSource constructor. Options are applied in order.
+type SetScriptSourceOption = func(*SetScriptSource)
+
 // SetDryRun adds or modifies the value of the optional
 // parameter `dryRun` in the SetScriptSource CDP command.
 //
@@ -1834,6 +2102,14 @@ func (t *SetScriptSource) SetDryRun(v bool) *SetScriptSource {
 	return t
 }
 
+// SetScriptSourceWithDryRun is a functional option which sets the value of the
+// optional parameter `dryRun` in the SetScriptSource CDP command, see SetDryRun.
+func SetScriptSourceWithDryRun(v bool) SetScriptSourceOption {
+	return func(c *SetScriptSource) {
+		c.SetDryRun(v)
+	}
+}
+
 // SetScriptSourceResult contains the browser's response
 // to calling the SetScriptSource CDP command with Do().
 type SetScriptSourceResult struct {
@@ -2035,13 +2311,23 @@ type StepInto struct {
 
 // NewStepInto constructs a new StepInto struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `StepIntoOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#method-stepInto
-func NewStepInto() *StepInto {
-	return &StepInto{}
+func NewStepInto(opts ...StepIntoOption) *StepInto {
+	c := &StepInto{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// StepIntoOption is a functional option for the optional
+// parameters of the StepInto CDP command, see the
+// NewStepInto constructor. Options are applied in order.
+type StepIntoOption = func(*StepInto)
+
 // SetBreakOnAsyncCall adds or modifies the value of the optional
 // parameter `breakOnAsyncCall` in the StepInto CDP command.
 //
@@ -2054,6 +2340,14 @@ func (t *StepInto) SetBreakOnAsyncCall(v bool) *StepInto {
 	return t
 }
 
+// StepIntoWithBreakOnAsyncCall is a functional option which sets the value of the
+// optional parameter `breakOnAsyncCall` in the StepInto CDP command, see SetBreakOnAsyncCall.
+func StepIntoWithBreakOnAsyncCall(v bool) StepIntoOption {
+	return func(c *StepInto) {
+		c.SetBreakOnAsyncCall(v)
+	}
+}
+
 // SetSkipList adds or modifies the value of the optional
 // parameter `skipList` in the StepInto CDP command.
 //
@@ -2065,6 +2359,14 @@ func (t *StepInto) SetSkipList(v []LocationRange) *StepInto {
 	return t
 }
 
+// StepIntoWithSkipList is a functional option which sets the value of the
+// optional parameter `skipList` in the StepInto CDP command, see SetSkipList.
+func StepIntoWithSkipList(v []LocationRange) StepIntoOption {
+	return func(c *StepInto) {
+		c.SetSkipList(v)
+	}
+}
+
 // Do sends the StepInto CDP command to a browser,
 // and returns the browser's response.
 func (t *StepInto) Do(ctx context.Context) error {
@@ -2159,13 +2461,23 @@ type StepOver struct {
 
 // NewStepOver constructs a new StepOver struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `StepOverOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Debugger/#method-stepOver
-func NewStepOver() *StepOver {
-	return &StepOver{}
+func NewStepOver(opts ...StepOverOption) *StepOver {
+	c := &StepOver{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// StepOverOption is a functional option for the optional
+// parameters of the StepOver CDP command, see the
+// NewStepOver constructor. Options are applied in order.
+type StepOverOption = func(*StepOver)
+
 // SetSkipList adds or modifies the value of the optional
 // parameter `skipList` in the StepOver CDP command.
 //
@@ -2177,6 +2489,14 @@ func (t *StepOver) SetSkipList(v []LocationRange) *StepOver {
 	return t
 }
 
+// StepOverWithSkipList is a functional option which sets the value of the
+// optional parameter `skipList` in the StepOver CDP command, see SetSkipList.
+func StepOverWithSkipList(v []LocationRange) StepOverOption {
+	return func(c *StepOver) {
+		c.SetSkipList(v)
+	}
+}
+
 // Do sends the StepOver CDP command to a browser,
 // and returns the browser's response.
 func (t *StepOver) Do(ctx context.Context) error {
@@ -0,0 +1,186 @@
+package debugger
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// PausedHandler is called by the `debugger.OnPaused` function whenever
+// JavaScript execution is paused. Execution remains paused until the
+// handler calls the `Resume` method or one of the `Step...` methods of
+// the given event, which it may do before or after returning.
+type PausedHandler = func(ctx context.Context, e *Paused)
+
+// OnPaused enables the Debugger domain in the page associated with the
+// given context, and calls the given handler with the details of every
+// pause in JavaScript execution (e.g. due to a breakpoint, a "debugger"
+// statement, or a step), one at a time, in a separate goroutine. It
+// returns a function to stop handling pauses.
+//
+// The handler may call other CDP commands, e.g. to inspect the paused
+// call frames (see `CallFrame.Eval` and `CallFrame.Variables`).
+func OnPaused(ctx context.Context, handler PausedHandler) (func(), error) {
+	sub, err := devtools.Subscribe(ctx, "Debugger.paused")
+	if err != nil {
+		return nil, err
+	}
+
+	// Queue events without blocking, so that a busy handler
+	// doesn't fill the subscription's buffer.
+	var mu sync.Mutex
+	var queue []*Paused
+	ready := make(chan struct{}, 1)
+	done := make(chan struct{})
+	accept := devtools.EventsOfSession(ctx)
+	go func() {
+		defer close(done)
+		for m := range sub.C {
+			e := &Paused{}
+			if !accept(m) || json.Unmarshal(m.Params, e) != nil {
+				continue
+			}
+			mu.Lock()
+			queue = append(queue, e)
+			mu.Unlock()
+			select {
+			case ready <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	go func() {
+		for {
+			select {
+			case <-ready:
+			case <-done:
+				return
+			}
+			for {
+				mu.Lock()
+				if len(queue) == 0 {
+					mu.Unlock()
+					break
+				}
+				e := queue[0]
+				queue = queue[1:]
+				mu.Unlock()
+				handler(ctx, e)
+			}
+		}
+	}()
+
+	release, err := devtools.EnableDomain(ctx, "Debugger")
+	if err != nil {
+		sub.Unsubscribe()
+		return nil, err
+	}
+	stop := func() {
+		sub.Unsubscribe()
+		release()
+	}
+	return stop, nil
+}
+
+// BreakAtURL sets a breakpoint at the given 0-based line (and column,
+// if it's positive) in all the current and future scripts whose URL is
+// the given one, and returns the breakpoint's ID, for `NewRemoveBreakpoint`.
+// If the condition isn't empty, the breakpoint pauses only when
+// this JavaScript expression evaluates to true.
+func BreakAtURL(ctx context.Context, url string, line, column int64, condition string) (string, error) {
+	b := NewSetBreakpointByURL(line).SetURL(url)
+	if column > 0 {
+		b.SetColumnNumber(column)
+	}
+	if condition != "" {
+		b.SetCondition(condition)
+	}
+	r, err := b.Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	return r.BreakpointID, nil
+}
+
+// Resume resumes JavaScript execution after this pause.
+func (e *Paused) Resume(ctx context.Context) error {
+	return NewResume().Do(ctx)
+}
+
+// StepOver resumes JavaScript execution after this pause,
+// until the next statement.
+func (e *Paused) StepOver(ctx context.Context) error {
+	return NewStepOver().Do(ctx)
+}
+
+// StepInto resumes JavaScript execution after this pause, until the next
+// statement, stepping into the function call in the current one, if any.
+func (e *Paused) StepInto(ctx context.Context) error {
+	return NewStepInto().Do(ctx)
+}
+
+// StepOut resumes JavaScript execution after this pause,
+// until the current function returns to its caller.
+func (e *Paused) StepOut(ctx context.Context) error {
+	return NewStepOut().Do(ctx)
+}
+
+// Eval evaluates the given JavaScript expression in the scope of this paused
+// call frame, and parses its JSON value into v (unless v is nil). JavaScript
+// exceptions are returned as `*runtime.ExceptionDetails` errors.
+func (f *CallFrame) Eval(ctx context.Context, expression string, v interface{}) error {
+	r, err := NewEvaluateOnCallFrame(f.CallFrameID, expression).SetReturnByValue(true).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if r.ExceptionDetails != nil {
+		return r.ExceptionDetails
+	}
+	if v == nil || r.Result.Type == "undefined" || len(r.Result.Value) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r.Result.Value, v)
+}
+
+// Variables returns the variables in this paused call frame's scopes of
+// the given types (e.g. "local", "closure", "global"), or in all of its
+// scopes if no type is specified. Inner scopes shadow outer ones. Primitive
+// values are included in the returned remote objects, and objects can be
+// inspected further with their object IDs (see `runtime.NewGetProperties`).
+func (f *CallFrame) Variables(ctx context.Context, scopeTypes ...string) (map[string]runtime.RemoteObject, error) {
+	vars := make(map[string]runtime.RemoteObject)
+	for _, s := range f.ScopeChain {
+		if len(scopeTypes) > 0 && !contains(scopeTypes, s.Type) {
+			continue
+		}
+		if s.Object.ObjectID == "" {
+			continue
+		}
+		r, err := runtime.NewGetProperties(s.Object.ObjectID).SetOwnProperties(true).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if r.ExceptionDetails != nil {
+			return nil, r.ExceptionDetails
+		}
+		for _, p := range r.Result {
+			if _, ok := vars[p.Name]; ok || p.Value == nil {
+				continue
+			}
+			vars[p.Name] = *p.Value
+		}
+	}
+	return vars, nil
+}
+
+func contains(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,242 @@
+package debugger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOnPaused(t *testing.T) {
+	// Set up.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, b := devtoolstest.NewContext(ctx)
+
+	reasons := make(chan string)
+	stop, err := OnPaused(ctx, func(ctx context.Context, e *Paused) {
+		reasons <- e.Reason
+		// Handlers may send commands.
+		if err := e.Resume(ctx); err != nil {
+			t.Errorf("Paused.Resume(); got error: %v", err)
+		}
+	})
+	if err != nil {
+		t.Fatalf("OnPaused(); got error: %v", err)
+	}
+	if got := b.Calls("Debugger.enable"); len(got) != 1 {
+		t.Errorf("OnPaused() sent %d Debugger.enable commands, want 1", len(got))
+	}
+
+	// Test: pauses in other sessions are ignored, and
+	// the others are handled one at a time, in order.
+	if err := devtools.DispatchEvent(ctx, &devtools.Message{SessionID: "other",
+		Method: "Debugger.paused", Params: json.RawMessage(`{"reason":"other"}`)}); err != nil {
+		t.Fatalf("DispatchEvent(); got error: %v", err)
+	}
+	want := []string{"debugCommand", "step", "exception"}
+	for _, r := range want {
+		if err := b.Emit("Debugger.paused", &Paused{Reason: r}); err != nil {
+			t.Fatalf("Emit(); got error: %v", err)
+		}
+	}
+	var got []string
+	for range want {
+		select {
+		case r := <-reasons:
+			got = append(got, r)
+		case <-time.After(5 * time.Second):
+			t.Fatal("handler not called")
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("handled pauses mismatch (-want +got):\n%s", diff)
+	}
+
+	stop()
+	if got := b.Calls("Debugger.resume"); len(got) != len(want) {
+		t.Errorf("handler sent %d Debugger.resume commands, want %d", len(got), len(want))
+	}
+	if got := b.Calls("Debugger.disable"); len(got) != 1 {
+		t.Errorf("stop() sent %d Debugger.disable commands, want 1", len(got))
+	}
+}
+
+func TestOnPausedError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, b := devtoolstest.NewContext(ctx)
+	b.Fail("Debugger.enable", "failed")
+
+	if _, err := OnPaused(ctx, func(context.Context, *Paused) {}); err == nil {
+		t.Error("OnPaused() = nil error, want an error")
+	}
+}
+
+func TestBreakAtURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		column    int64
+		condition string
+		want      string
+	}{
+		{
+			name: "line",
+			want: `{"lineNumber":10,"url":"https://example.com/app.js"}`,
+		},
+		{
+			name:   "column",
+			column: 4,
+			want:   `{"lineNumber":10,"url":"https://example.com/app.js","columnNumber":4}`,
+		},
+		{
+			name:      "condition",
+			condition: "x === 1",
+			want:      `{"lineNumber":10,"url":"https://example.com/app.js","condition":"x === 1"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+			b.Respond("Debugger.setBreakpointByUrl", &SetBreakpointByURLResult{BreakpointID: "1:10:0"})
+
+			id, err := BreakAtURL(ctx, "https://example.com/app.js", 10, tt.column, tt.condition)
+			if err != nil {
+				t.Fatalf("BreakAtURL(); got error: %v", err)
+			}
+			if id != "1:10:0" {
+				t.Errorf("BreakAtURL() = %q, want %q", id, "1:10:0")
+			}
+			calls := b.Calls("Debugger.setBreakpointByUrl")
+			if len(calls) != 1 {
+				t.Fatalf("BreakAtURL() sent %d commands, want 1", len(calls))
+			}
+			if got := string(calls[0].Params); got != tt.want {
+				t.Errorf("BreakAtURL() params = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallFrameEval(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  *EvaluateOnCallFrameResult
+		want    interface{}
+		wantErr bool
+	}{
+		{
+			name:   "value",
+			result: &EvaluateOnCallFrameResult{Result: runtime.RemoteObject{Type: "number", Value: json.RawMessage(`42`)}},
+			want:   float64(42),
+		},
+		{
+			name:   "undefined",
+			result: &EvaluateOnCallFrameResult{Result: runtime.RemoteObject{Type: "undefined"}},
+		},
+		{
+			name:    "exception",
+			result:  &EvaluateOnCallFrameResult{ExceptionDetails: &runtime.ExceptionDetails{Text: "Uncaught"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+			b.Respond("Debugger.evaluateOnCallFrame", tt.result)
+
+			f := &CallFrame{CallFrameID: "frame"}
+			var got interface{}
+			err := f.Eval(ctx, "x", &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CallFrame.Eval() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var details *runtime.ExceptionDetails
+			if tt.wantErr && !errors.As(err, &details) {
+				t.Errorf("CallFrame.Eval() error = %v, want *runtime.ExceptionDetails", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CallFrame.Eval() value mismatch (-want +got):\n%s", diff)
+			}
+			calls := b.Calls("Debugger.evaluateOnCallFrame")
+			if want := `{"callFrameId":"frame","expression":"x","returnByValue":true}`; len(calls) != 1 || string(calls[0].Params) != want {
+				t.Errorf("CallFrame.Eval() sent %v, want params %s", calls, want)
+			}
+		})
+	}
+}
+
+func TestCallFrameVariables(t *testing.T) {
+	// Set up.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, b := devtoolstest.NewContext(ctx)
+	props := map[string][]runtime.PropertyDescriptor{
+		"local": {
+			{Name: "x", Value: &runtime.RemoteObject{Type: "number", Value: json.RawMessage(`1`)}},
+			{Name: "getter"}, // Without a value.
+		},
+		"closure": {
+			{Name: "x", Value: &runtime.RemoteObject{Type: "number", Value: json.RawMessage(`2`)}},
+			{Name: "y", Value: &runtime.RemoteObject{Type: "string", Value: json.RawMessage(`"y"`)}},
+		},
+	}
+	b.Handle("Runtime.getProperties", func(params json.RawMessage) (interface{}, error) {
+		p := &runtime.GetProperties{}
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, err
+		}
+		return &runtime.GetPropertiesResult{Result: props[p.ObjectID]}, nil
+	})
+	f := &CallFrame{ScopeChain: []Scope{
+		{Type: "local", Object: runtime.RemoteObject{ObjectID: "local"}},
+		{Type: "closure", Object: runtime.RemoteObject{ObjectID: "closure"}},
+		{Type: "global"}, // Without an object ID.
+	}}
+
+	tests := []struct {
+		name       string
+		scopeTypes []string
+		want       map[string]string
+	}{
+		{
+			name: "all_scopes",
+			want: map[string]string{"x": "1", "y": `"y"`},
+		},
+		{
+			name:       "closure_only",
+			scopeTypes: []string{"closure"},
+			want:       map[string]string{"x": "2", "y": `"y"`},
+		},
+		{
+			name:       "no_matching_scopes",
+			scopeTypes: []string{"block"},
+			want:       map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vars, err := f.Variables(ctx, tt.scopeTypes...)
+			if err != nil {
+				t.Fatalf("CallFrame.Variables(); got error: %v", err)
+			}
+			got := make(map[string]string)
+			for name, v := range vars {
+				got[name] = string(v.Value)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CallFrame.Variables() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
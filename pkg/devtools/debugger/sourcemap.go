@@ -0,0 +1,172 @@
+package debugger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SourceMap is a parsed JavaScript source map (revision 3), which maps
+// positions in generated (e.g. minified or transpiled) code to their
+// original source files. See https://sourcemaps.info/spec.html.
+type SourceMap struct {
+	File    string
+	Sources []string
+	Names   []string
+	// Mapping segments per generated line, sorted by generated column.
+	lines [][]mappingSegment
+}
+
+// OriginalPosition is a position in an original source file, which
+// is the result of a `SourceMap.Lookup`. Lines and columns are 0-based.
+type OriginalPosition struct {
+	Source       string
+	LineNumber   int64
+	ColumnNumber int64
+	// Original name of the identifier at this position, if known.
+	Name string
+}
+
+type mappingSegment struct {
+	genColumn            int64
+	source               int64 // -1 if the segment isn't mapped to a source.
+	origLine, origColumn int64
+	name                 int64 // -1 if the segment has no name.
+}
+
+// ParseSourceMap parses the JSON representation of a source map.
+// Index maps (which consist of sections) are not supported.
+func ParseSourceMap(b []byte) (*SourceMap, error) {
+	raw := &struct {
+		Version    int             `json:"version"`
+		File       string          `json:"file"`
+		SourceRoot string          `json:"sourceRoot"`
+		Sources    []string        `json:"sources"`
+		Names      []string        `json:"names"`
+		Mappings   string          `json:"mappings"`
+		Sections   json.RawMessage `json:"sections"`
+	}{}
+	if err := json.Unmarshal(b, raw); err != nil {
+		return nil, fmt.Errorf("source map JSON error: %v", err)
+	}
+	if raw.Version != 3 {
+		return nil, fmt.Errorf("unsupported source map version: %d", raw.Version)
+	}
+	if len(raw.Sections) > 0 {
+		return nil, errors.New("source map index maps are not supported")
+	}
+
+	m := &SourceMap{File: raw.File, Names: raw.Names}
+	for _, s := range raw.Sources {
+		if raw.SourceRoot != "" && !strings.Contains(s, "://") && !strings.HasPrefix(s, "/") {
+			s = strings.TrimSuffix(raw.SourceRoot, "/") + "/" + s
+		}
+		m.Sources = append(m.Sources, s)
+	}
+	lines, err := parseMappings(raw.Mappings)
+	if err != nil {
+		return nil, err
+	}
+	m.lines = lines
+	return m, nil
+}
+
+// Decode the "mappings" field of a source map.
+func parseMappings(mappings string) ([][]mappingSegment, error) {
+	var lines [][]mappingSegment
+	// All fields except the generated column are relative to the previous
+	// segment in the whole map, not just the current line.
+	var source, origLine, origColumn, name int64
+	for _, line := range strings.Split(mappings, ";") {
+		var segments []mappingSegment
+		genColumn := int64(0)
+		for _, s := range strings.Split(line, ",") {
+			if s == "" {
+				continue
+			}
+			fields, err := decodeVLQ(s)
+			if err != nil {
+				return nil, err
+			}
+			if len(fields) != 1 && len(fields) != 4 && len(fields) != 5 {
+				return nil, fmt.Errorf("invalid source map segment %q: %d fields", s, len(fields))
+			}
+			genColumn += fields[0]
+			seg := mappingSegment{genColumn: genColumn, source: -1, name: -1}
+			if len(fields) >= 4 {
+				source += fields[1]
+				origLine += fields[2]
+				origColumn += fields[3]
+				seg.source, seg.origLine, seg.origColumn = source, origLine, origColumn
+			}
+			if len(fields) == 5 {
+				name += fields[4]
+				seg.name = name
+			}
+			segments = append(segments, seg)
+		}
+		sort.SliceStable(segments, func(i, j int) bool {
+			return segments[i].genColumn < segments[j].genColumn
+		})
+		lines = append(lines, segments)
+	}
+	return lines, nil
+}
+
+const base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// Decode a sequence of Base64 VLQ values.
+func decodeVLQ(s string) ([]int64, error) {
+	var values []int64
+	value, shift := int64(0), uint(0)
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(base64Chars, s[i])
+		if digit < 0 {
+			return nil, fmt.Errorf("invalid Base64 VLQ character %q in %q", s[i], s)
+		}
+		value += int64(digit&31) << shift
+		if digit&32 != 0 {
+			shift += 5
+			continue
+		}
+		// The least significant bit is the sign.
+		if value&1 != 0 {
+			values = append(values, -(value >> 1))
+		} else {
+			values = append(values, value>>1)
+		}
+		value, shift = 0, 0
+	}
+	if shift != 0 {
+		return nil, fmt.Errorf("truncated Base64 VLQ value in %q", s)
+	}
+	return values, nil
+}
+
+// Lookup returns the original position of the given 0-based position in
+// the generated code, i.e. the position of the closest mapping segment
+// which starts at or before it in the same line, if there is one.
+func (m *SourceMap) Lookup(line, column int64) (*OriginalPosition, bool) {
+	if line < 0 || line >= int64(len(m.lines)) {
+		return nil, false
+	}
+	segments := m.lines[line]
+	i := sort.Search(len(segments), func(i int) bool {
+		return segments[i].genColumn > column
+	}) - 1
+	if i < 0 || segments[i].source < 0 || segments[i].source >= int64(len(m.Sources)) {
+		return nil, false
+	}
+	seg := segments[i]
+	p := &OriginalPosition{
+		Source:       m.Sources[seg.source],
+		LineNumber:   seg.origLine,
+		ColumnNumber: seg.origColumn,
+	}
+	if seg.name >= 0 && seg.name < int64(len(m.Names)) {
+		p.Name = m.Names[seg.name]
+	}
+	return p, true
+}
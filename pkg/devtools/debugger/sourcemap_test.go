@@ -0,0 +1,88 @@
+package debugger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeVLQ(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []int64
+	}{
+		{"A", []int64{0}},
+		{"C", []int64{1}},
+		{"D", []int64{-1}},
+		{"gB", []int64{16}},
+		{"AAgBC", []int64{0, 0, 16, 1}},
+	}
+	for _, tt := range tests {
+		got, err := decodeVLQ(tt.s)
+		if err != nil {
+			t.Errorf("decodeVLQ(%q) error: %v", tt.s, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("decodeVLQ(%q) = %v, want %v", tt.s, got, tt.want)
+		}
+	}
+	for _, s := range []string{"g", "A!"} {
+		if _, err := decodeVLQ(s); err == nil {
+			t.Errorf("decodeVLQ(%q) error = nil, want an error", s)
+		}
+	}
+}
+
+func TestSourceMapLookup(t *testing.T) {
+	// Set up.
+	m, err := ParseSourceMap([]byte(`{
+		"version": 3,
+		"file": "app.min.js",
+		"sourceRoot": "src",
+		"sources": ["a.js", "b.js"],
+		"names": ["foo", "bar"],
+		"mappings": "AAAAA,IAAIC;;AACA,KCAFD"
+	}`))
+	if err != nil {
+		t.Fatalf("ParseSourceMap() error: %v", err)
+	}
+	tests := []struct {
+		line, column int64
+		want         *OriginalPosition
+	}{
+		{0, 0, &OriginalPosition{"src/a.js", 0, 0, "foo"}},
+		{0, 3, &OriginalPosition{"src/a.js", 0, 0, "foo"}},
+		{0, 4, &OriginalPosition{"src/a.js", 0, 4, "bar"}},
+		{0, 100, &OriginalPosition{"src/a.js", 0, 4, "bar"}},
+		{1, 0, nil},
+		{2, 2, &OriginalPosition{"src/a.js", 1, 4, ""}},
+		{2, 5, &OriginalPosition{"src/b.js", 1, 2, "foo"}},
+		{3, 0, nil},
+	}
+	// Test.
+	for _, tt := range tests {
+		got, ok := m.Lookup(tt.line, tt.column)
+		if ok != (tt.want != nil) || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Lookup(%d, %d) = %+v, %v, want %+v", tt.line, tt.column, got, ok, tt.want)
+		}
+	}
+}
+
+func TestParseDataURL(t *testing.T) {
+	tests := []struct {
+		u, want string
+	}{
+		{"data:application/json;base64,eyJ2ZXJzaW9uIjozfQ==", `{"version":3}`},
+		{"data:application/json;charset=utf-8,%7B%22version%22%3A3%7D", `{"version":3}`},
+	}
+	for _, tt := range tests {
+		got, err := parseDataURL(tt.u)
+		if err != nil {
+			t.Errorf("parseDataURL(%q) error: %v", tt.u, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("parseDataURL(%q) = %q, want %q", tt.u, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package debugger
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// Symbolicator resolves JavaScript stack traces through the source maps
+// of the scripts in a page, so that exceptions in minified production
+// bundles can be reported with their original file names and positions.
+type Symbolicator struct {
+	ctx     context.Context
+	events  *devtools.Subscription
+	done    chan struct{}
+	client  *http.Client
+	scripts sync.Map // Script ID -> absolute source map URL.
+	// Releases the Debugger domain, see `devtools.EnableDomain`.
+	release func() error
+
+	mu   sync.Mutex
+	maps map[string]*sourceMapEntry // By source map URL.
+}
+
+// A source map which is fetched once, even by concurrent lookups.
+type sourceMapEntry struct {
+	ready chan struct{} // Closed after the map is fetched and parsed.
+	m     *SourceMap    // Nil if the source map is invalid.
+}
+
+// NewSymbolicator starts tracking the scripts which are parsed in the page
+// associated with the given context, along with their source map URLs, until
+// the `Close` method of the returned symbolicator is called. This function
+// enables the Debugger domain, which also reports the scripts that the page
+// has parsed so far. Source maps are fetched lazily, with the given HTTP
+// client (or `http.DefaultClient` if it's nil), and cached.
+func NewSymbolicator(ctx context.Context, client *http.Client) (*Symbolicator, error) {
+	sub, err := devtools.Subscribe(ctx, "Debugger.scriptParsed")
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	s := &Symbolicator{
+		ctx:    ctx,
+		events: sub,
+		done:   make(chan struct{}),
+		client: client,
+		maps:   make(map[string]*sourceMapEntry),
+	}
+	go s.track(devtools.EventsOfSession(ctx))
+
+	release, err := devtools.EnableDomain(ctx, "Debugger")
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	s.release = release
+	return s, nil
+}
+
+// Receive script parsing events until the subscription is closed.
+func (s *Symbolicator) track(accept devtools.EventFilter) {
+	defer close(s.done)
+	for m := range s.events.C {
+		e := &ScriptParsed{}
+		if !accept(m) || json.Unmarshal(m.Params, e) != nil || e.SourceMapURL == "" {
+			continue
+		}
+		u, err := resolveURL(e.URL, e.SourceMapURL)
+		if err != nil {
+			continue
+		}
+		s.scripts.Store(e.ScriptID, u)
+	}
+}
+
+// Close stops tracking scripts.
+func (s *Symbolicator) Close() {
+	s.events.Unsubscribe()
+	if s.release != nil {
+		s.release()
+	}
+	<-s.done
+}
+
+// Symbolicate returns a copy of the given stack trace (including its parent
+// async stack traces), in which all the call frames whose scripts have a
+// valid source map point to their original source files and positions.
+// Other call frames are copied as-is.
+func (s *Symbolicator) Symbolicate(st *runtime.StackTrace) *runtime.StackTrace {
+	if st == nil {
+		return nil
+	}
+	result := *st
+	result.CallFrames = make([]runtime.CallFrame, len(st.CallFrames))
+	for i, f := range st.CallFrames {
+		result.CallFrames[i] = s.symbolicateFrame(f)
+	}
+	result.Parent = s.Symbolicate(st.Parent)
+	return &result
+}
+
+func (s *Symbolicator) symbolicateFrame(f runtime.CallFrame) runtime.CallFrame {
+	u, ok := s.scripts.Load(f.ScriptID)
+	if !ok {
+		return f
+	}
+	m := s.sourceMap(u.(string))
+	if m == nil {
+		return f
+	}
+	p, ok := m.Lookup(f.LineNumber, f.ColumnNumber)
+	if !ok {
+		return f
+	}
+	if src, err := resolveURL(u.(string), p.Source); err == nil {
+		f.URL = src
+	} else {
+		f.URL = p.Source
+	}
+	f.LineNumber, f.ColumnNumber = p.LineNumber, p.ColumnNumber
+	return f
+}
+
+// Return the parsed source map in the given URL, or nil if it's invalid.
+// The source map is fetched without holding the symbolicator's mutex, so
+// slow servers don't block lookups of other source maps, and concurrent
+// lookups of the same source map wait for the first one to fetch it.
+func (s *Symbolicator) sourceMap(u string) *SourceMap {
+	s.mu.Lock()
+	e, ok := s.maps[u]
+	if !ok {
+		e = &sourceMapEntry{ready: make(chan struct{})}
+		s.maps[u] = e
+	}
+	s.mu.Unlock()
+	if ok {
+		<-e.ready
+		return e.m
+	}
+
+	defer close(e.ready)
+	if b, err := s.fetch(u); err == nil {
+		e.m, _ = ParseSourceMap(b)
+	}
+	return e.m
+}
+
+// Fetch the contents of an HTTP(S) or data URL.
+func (s *Symbolicator) fetch(u string) ([]byte, error) {
+	if strings.HasPrefix(u, "data:") {
+		return parseDataURL(u)
+	}
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// Parse the contents of a data URL (https://tools.ietf.org/html/rfc2397),
+// which is commonly used for inline source maps.
+func parseDataURL(u string) ([]byte, error) {
+	i := strings.IndexByte(u, ',')
+	if i < 0 {
+		return nil, fmt.Errorf("invalid data URL: %q", u)
+	}
+	if strings.HasSuffix(u[:i], ";base64") {
+		return base64.StdEncoding.DecodeString(u[i+1:])
+	}
+	s, err := url.PathUnescape(u[i+1:])
+	return []byte(s), err
+}
+
+// Resolve a possibly-relative URL against a base URL.
+func resolveURL(base, ref string) (string, error) {
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	return b.ResolveReference(r).String(), nil
+}
@@ -0,0 +1,73 @@
+package debugger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSymbolicatorSourceMap(t *testing.T) {
+	// Set up.
+	release := make(chan struct{})
+	var mu sync.Mutex
+	hits := make(map[string]int)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		mu.Unlock()
+		if r.URL.Path == "/slow.js.map" {
+			<-release
+		}
+		if r.URL.Path == "/invalid.js.map" {
+			w.Write([]byte(`{"version":2}`))
+			return
+		}
+		w.Write([]byte(`{"version":3,"sources":["app.js"],"names":[],"mappings":"AAAA"}`))
+	}))
+	defer srv.Close()
+	s := &Symbolicator{ctx: context.Background(), client: srv.Client(), maps: make(map[string]*sourceMapEntry)}
+
+	// Test: concurrent lookups of a slow source map fetch it once.
+	results := make(chan *SourceMap, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			results <- s.sourceMap(srv.URL + "/slow.js.map")
+		}()
+	}
+	// Test: other source maps are available in the meantime.
+	fast := make(chan *SourceMap, 1)
+	go func() {
+		fast <- s.sourceMap(srv.URL + "/fast.js.map")
+	}()
+	select {
+	case m := <-fast:
+		if m == nil {
+			t.Errorf("sourceMap(fast) = nil, want a source map")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("sourceMap(fast) blocked by the fetching of another source map")
+	}
+	close(release)
+	for i := 0; i < 2; i++ {
+		if m := <-results; m == nil {
+			t.Errorf("sourceMap(slow) #%d = nil, want a source map", i)
+		}
+	}
+	// Test: invalid source maps are cached too.
+	for i := 0; i < 2; i++ {
+		if m := s.sourceMap(srv.URL + "/invalid.js.map"); m != nil {
+			t.Errorf("sourceMap(invalid) #%d = %v, want nil", i, m)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for path, n := range hits {
+		if n != 1 {
+			t.Errorf("%s fetched %d times, want 1", path, n)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Package devtoolstest provides a fake browser for unit tests of code which
+// uses CDP sessions, e.g. the `Do` methods of commands in the domain
+// sub-packages, and `devtools.SubscribeEvent`, without launching a browser.
+// Its responses to commands are scriptable, and it can emit events.
+//
+//	ctx, b := devtoolstest.NewContext(context.Background())
+//	b.Respond("Page.navigate", map[string]string{"frameId": "main"})
+//	r, err := page.NewNavigate("https://example.com").Do(ctx)
+package devtoolstest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// Handler handles a CDP command in a fake browser, and returns its result,
+// which is marshaled into JSON (nil results are empty objects). Errors of
+// type `*devtools.Error` are sent as CDP error responses, and other errors
+// are returned to the caller as transport errors.
+type Handler = func(params json.RawMessage) (interface{}, error)
+
+// Call is a CDP command which was sent to a fake browser.
+type Call struct {
+	Method string
+	Params json.RawMessage
+}
+
+// Browser is a fake browser, see the `devtoolstest.NewContext` function.
+// Multiple goroutines may use it simultaneously.
+type Browser struct {
+	ctx context.Context
+
+	mu       sync.Mutex
+	handlers map[string]Handler
+	calls    []Call
+	strict   bool
+}
+
+// NewContext returns a copy of the parent context which carries a CDP
+// session with a new fake browser (see `devtools.NewFakeContext`), and the
+// fake browser itself. By default, it responds to all commands with empty
+// results, unless they have specific handlers (see the `Browser.Handle`,
+// `Browser.Respond` and `Browser.Fail` methods), or it's strict (see the
+// `Browser.Strict` method). Session options are applied to the session,
+// e.g. `devtools.CommandMiddleware`.
+func NewContext(parent context.Context, opts ...devtools.SessionOption) (context.Context, *Browser) {
+	b := &Browser{handlers: make(map[string]Handler)}
+	b.ctx = devtools.NewFakeContext(parent, b.handle, opts...)
+	return b.ctx, b
+}
+
+// Handle sets the handler of the given CDP command (e.g. "Page.navigate").
+func (b *Browser) Handle(method string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[method] = h
+}
+
+// Respond sets a fixed result for the given CDP command.
+func (b *Browser) Respond(method string, result interface{}) {
+	b.Handle(method, func(json.RawMessage) (interface{}, error) {
+		return result, nil
+	})
+}
+
+// Fail sets a fixed CDP error response for the given CDP command.
+func (b *Browser) Fail(method, message string) {
+	b.Handle(method, func(json.RawMessage) (interface{}, error) {
+		return nil, &devtools.Error{Code: -32000, Message: message}
+	})
+}
+
+// Strict makes the fake browser fail commands which don't have handlers,
+// instead of responding to them with empty results.
+func (b *Browser) Strict() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.strict = true
+}
+
+// Calls returns all the CDP commands which were sent to the fake browser,
+// or only those with the given method names, in the order they were sent.
+func (b *Browser) Calls(methods ...string) []Call {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(methods) == 0 {
+		return append([]Call(nil), b.calls...)
+	}
+	var calls []Call
+	for _, c := range b.calls {
+		for _, m := range methods {
+			if c.Method == m {
+				calls = append(calls, c)
+				break
+			}
+		}
+	}
+	return calls
+}
+
+// Emit sends an event with the given method name (e.g. "Page.loadEventFired")
+// and parameters (marshaled into JSON) from the fake browser's tab, and
+// returns after all of its subscribers receive it. Events without
+// subscribers are discarded, so tests should emit events after the code
+// under test subscribes to them, e.g. in a handler of a command which
+// it sends afterwards (such as "Page.navigate").
+func (b *Browser) Emit(method string, params interface{}) error {
+	return b.emit(devtools.FakeSessionID, method, params)
+}
+
+// EmitBrowser is like the `Browser.Emit` method, but for events
+// of the browser itself (e.g. "Target.targetCreated").
+func (b *Browser) EmitBrowser(method string, params interface{}) error {
+	return b.emit("", method, params)
+}
+
+func (b *Browser) emit(sessionID, method string, params interface{}) error {
+	p, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return devtools.DispatchEvent(b.ctx, &devtools.Message{SessionID: sessionID, Method: method, Params: p})
+}
+
+func (b *Browser) handle(_ context.Context, method string, params json.RawMessage) (*devtools.Message, error) {
+	b.mu.Lock()
+	b.calls = append(b.calls, Call{Method: method, Params: params})
+	h, ok := b.handlers[method]
+	strict := b.strict
+	b.mu.Unlock()
+
+	if !ok {
+		if strict {
+			return nil, fmt.Errorf("unexpected CDP command in strict fake browser: %s", method)
+		}
+		return &devtools.Message{Result: json.RawMessage("{}")}, nil
+	}
+	result, err := h(params)
+	var cdpErr *devtools.Error
+	if errors.As(err, &cdpErr) {
+		return &devtools.Message{Error: cdpErr}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return &devtools.Message{Result: json.RawMessage("{}")}, nil
+	}
+	r, err := json.Marshal(result)
+	if err != nil {
+		return nil, err
+	}
+	return &devtools.Message{Result: r}, nil
+}
@@ -0,0 +1,59 @@
+package devtoolstest_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+func TestRespondAndFail(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	b.Respond("Page.navigate", map[string]string{"frameId": "main"})
+	r, err := page.NewNavigate("https://example.com").Do(ctx)
+	if err != nil {
+		t.Fatalf("page.NewNavigate().Do(); got error: %v", err)
+	}
+	if r.FrameID != "main" {
+		t.Errorf("page.NewNavigate().Do() frame ID = %q, want %q", r.FrameID, "main")
+	}
+	calls := b.Calls("Page.navigate")
+	if len(calls) != 1 || string(calls[0].Params) != `{"url":"https://example.com"}` {
+		t.Errorf("b.Calls() = %+v", calls)
+	}
+
+	b.Fail("Page.reload", "no page")
+	if err := page.NewReload().Do(ctx); err == nil || err.Error() != "no page (-32000)" {
+		t.Errorf("page.NewReload().Do() error = %v, want %q", err, "no page (-32000)")
+	}
+
+	// Default and strict responses.
+	if err := page.NewEnable().Do(ctx); err != nil {
+		t.Errorf("page.NewEnable().Do(); got error: %v", err)
+	}
+	b.Strict()
+	if err := page.NewDisable().Do(ctx); err == nil {
+		t.Error("strict page.NewDisable().Do(); got nil error")
+	}
+}
+
+func TestEmit(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	// The page is still loading when it's checked, and then it finishes.
+	b.Handle("Runtime.evaluate", func(json.RawMessage) (interface{}, error) {
+		if err := b.Emit("Page.loadEventFired", map[string]float64{"timestamp": 1}); err != nil {
+			return nil, err
+		}
+		return &runtime.EvaluateResult{Result: runtime.RemoteObject{Type: "string", Value: json.RawMessage(`"loading"`)}}, nil
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := page.WaitForLoad(ctx); err != nil {
+		t.Errorf("page.WaitForLoad(); got error: %v", err)
+	}
+}
@@ -0,0 +1,169 @@
+package dom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/input"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// Report whether the argument node is `this` element, or one of its
+// descendants, including descendants in shadow trees.
+const containsNode = `function(n) {
+	for (; n; n = n.parentNode || n.host) {
+		if (n === this) {
+			return true;
+		}
+	}
+	return false;
+}`
+
+// Return a CSS selector path of `this` node (or its parent element, if it's
+// not an element), e.g. "body > div.modal > div#overlay". The path starts
+// at the nearest ancestor with an ID, or at the root element.
+const selectorPath = `function() {
+	const parts = [];
+	let e = this.nodeType === Node.ELEMENT_NODE ? this : this.parentElement;
+	for (; e; e = e.parentElement) {
+		let s = e.localName;
+		if (e.id) {
+			parts.unshift(s + "#" + CSS.escape(e.id));
+			break;
+		}
+		for (const c of e.classList) {
+			s += "." + CSS.escape(c);
+		}
+		const p = e.parentElement;
+		if (p) {
+			const same = Array.from(p.children).filter((c) => c.localName === e.localName);
+			if (same.length > 1) {
+				s += ":nth-of-type(" + (same.indexOf(e) + 1) + ")";
+			}
+		}
+		parts.unshift(s);
+	}
+	return parts.join(" > ");
+}`
+
+// InterceptedError is returned by the `ElementHandle.Click` method when
+// another element would receive the click instead of the target element,
+// e.g. a modal overlay, a cookie banner, or a fixed header.
+type InterceptedError struct {
+	// CSS selector path of the element which would receive the click.
+	Covering string
+	// Backend node ID of the element which would receive the click.
+	CoveringBackendNodeID int64
+	// Click point, in CSS pixels relative to the main frame's viewport.
+	X, Y float64
+}
+
+// Error satisfies the Go error interface (https://golang.org/pkg/builtin/#error).
+func (e *InterceptedError) Error() string {
+	return fmt.Sprintf("element click intercepted: %s would receive the click at (%g, %g)",
+		e.Covering, e.X, e.Y)
+}
+
+// Click scrolls the element into view if needed, and clicks the center of
+// its first content quad with the left mouse button. Before clicking, it
+// checks with a `DOM.getNodeForLocation` command which element would receive
+// the click, and if it's not the target element (or one of its descendants),
+// it fails with a `*dom.InterceptedError` which describes the covering
+// element, instead of clicking it.
+//
+// Stale elements are re-queried like in the `ElementHandle.Do` method.
+func (h *ElementHandle) Click(ctx context.Context) error {
+	return h.Do(ctx, func(ctx context.Context, h *ElementHandle) error {
+		id, err := h.BackendNodeID(ctx)
+		if err != nil {
+			return err
+		}
+		if err := NewScrollIntoViewIfNeeded().SetBackendNodeID(id).Do(ctx); err != nil {
+			return err
+		}
+		q, err := NewGetContentQuads().SetBackendNodeID(id).Do(ctx)
+		if err != nil {
+			return err
+		}
+		if len(q.Quads) == 0 {
+			return errors.New("element is not rendered, or has no size")
+		}
+		x, y := quadCenter(q.Quads[0])
+		if err := h.checkHitTarget(ctx, x, y); err != nil {
+			return err
+		}
+		m := input.NewMouse(x, y)
+		if err := m.MoveTo(ctx, x, y); err != nil {
+			return err
+		}
+		if err := m.Down(ctx, input.MouseButtonLeft); err != nil {
+			return err
+		}
+		return m.Up(ctx, input.MouseButtonLeft)
+	})
+}
+
+// Return an `InterceptedError` if the element wouldn't receive
+// a click at the given point in the viewport.
+func (h *ElementHandle) checkHitTarget(ctx context.Context, x, y float64) error {
+	hit, err := NewGetNodeForLocation(int64(math.Floor(x)), int64(math.Floor(y))).
+		SetIncludeUserAgentShadowDOM(false).Do(ctx)
+	if err != nil {
+		return err
+	}
+	target, err := h.ObjectID(ctx)
+	if err != nil {
+		return err
+	}
+	covering := HandleFromBackendNodeID(hit.BackendNodeID)
+	hitObj, err := covering.ObjectID(ctx)
+	if err != nil {
+		return err
+	}
+	var ok bool
+	if err := callFunction(ctx, target, containsNode, &ok, hitObj); err != nil {
+		return err
+	}
+	if ok {
+		return nil
+	}
+	path := ""
+	if err := callFunction(ctx, hitObj, selectorPath, &path); err != nil {
+		return err
+	}
+	return &InterceptedError{Covering: path, CoveringBackendNodeID: hit.BackendNodeID, X: x, Y: y}
+}
+
+// Call a JavaScript function on the given object, with the
+// given object arguments, and parse its JSON value into v.
+func callFunction(ctx context.Context, obj runtime.RemoteObjectID, f string, v interface{}, args ...runtime.RemoteObjectID) error {
+	var a []runtime.CallArgument
+	for _, arg := range args {
+		a = append(a, runtime.CallArgument{ObjectID: string(arg)})
+	}
+	c := runtime.NewCallFunctionOn(f).SetObjectID(string(obj)).SetReturnByValue(true)
+	if len(a) > 0 {
+		c.SetArguments(a)
+	}
+	r, err := c.Do(ctx)
+	if err != nil {
+		return err
+	}
+	if r.ExceptionDetails != nil {
+		return r.ExceptionDetails
+	}
+	return json.Unmarshal(r.Result.Value, v)
+}
+
+// Return the center of a quad (4 points: x1, y1, ..., x4, y4).
+func quadCenter(q Quad) (float64, float64) {
+	var x, y float64
+	for i := 0; i+1 < len(q) && i < 8; i += 2 {
+		x += q[i]
+		y += q[i+1]
+	}
+	return x / 4, y / 4
+}
@@ -0,0 +1,18 @@
+package dom
+
+import "testing"
+
+func TestQuadCenter(t *testing.T) {
+	x, y := quadCenter(Quad{10, 20, 110, 20, 110, 60, 10, 60})
+	if x != 60 || y != 40 {
+		t.Errorf("quadCenter() = (%g, %g), want (60, 40)", x, y)
+	}
+}
+
+func TestInterceptedError(t *testing.T) {
+	err := &InterceptedError{Covering: "div#consent > button.accept", X: 60, Y: 40.5}
+	want := "element click intercepted: div#consent > button.accept would receive the click at (60, 40.5)"
+	if err.Error() != want {
+		t.Errorf("InterceptedError.Error() = %q, want %q", err.Error(), want)
+	}
+}
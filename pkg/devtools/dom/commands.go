@@ -102,18 +102,28 @@ type CopyTo struct {
 
 // NewCopyTo constructs a new CopyTo struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `CopyToOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-copyTo
 //
 // This CDP method is experimental.
-func NewCopyTo(nodeID int64, targetNodeID int64) *CopyTo {
-	return &CopyTo{
+func NewCopyTo(nodeID int64, targetNodeID int64, opts ...CopyToOption) *CopyTo {
+	c := &CopyTo{
 		NodeID:       nodeID,
 		TargetNodeID: targetNodeID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// CopyToOption is a functional option for the optional
+// parameters of the CopyTo CDP command, see the
+// NewCopyTo constructor. Options are applied in order.
+type CopyToOption = func(*CopyTo)
+
 // SetInsertBeforeNodeID adds or modifies the value of the optional
 // parameter `insertBeforeNodeId` in the CopyTo CDP command.
 //
@@ -124,6 +134,14 @@ func (t *CopyTo) SetInsertBeforeNodeID(v int64) *CopyTo {
 	return t
 }
 
+// CopyToWithInsertBeforeNodeID is a functional option which sets the value of the
+// optional parameter `insertBeforeNodeId` in the CopyTo CDP command, see SetInsertBeforeNodeID.
+func CopyToWithInsertBeforeNodeID(v int64) CopyToOption {
+	return func(c *CopyTo) {
+		c.SetInsertBeforeNodeID(v)
+	}
+}
+
 // CopyToResult contains the browser's response
 // to calling the CopyTo CDP command with Do().
 type CopyToResult struct {
@@ -186,7 +204,7 @@ type DescribeNode struct {
 	ObjectID *runtime.RemoteObjectID `json:"objectId,omitempty"`
 	// The maximum depth at which children should be retrieved, defaults to 1. Use -1 for the
 	// entire subtree or provide an integer larger than 0.
-	Depth int64 `json:"depth,omitempty"`
+	Depth *int64 `json:"depth,omitempty"`
 	// Whether or not iframes and shadow roots should be traversed when returning the subtree
 	// (default is false).
 	Pierce bool `json:"pierce,omitempty"`
@@ -194,13 +212,23 @@ type DescribeNode struct {
 
 // NewDescribeNode constructs a new DescribeNode struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `DescribeNodeOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-describeNode
-func NewDescribeNode() *DescribeNode {
-	return &DescribeNode{}
+func NewDescribeNode(opts ...DescribeNodeOption) *DescribeNode {
+	c := &DescribeNode{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// DescribeNodeOption is a functional option for the optional
+// parameters of the DescribeNode CDP command, see the
+// NewDescribeNode constructor. Options are applied in order.
+type DescribeNodeOption = func(*DescribeNode)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the DescribeNode CDP command.
 //
@@ -210,6 +238,14 @@ func (t *DescribeNode) SetNodeID(v int64) *DescribeNode {
 	return t
 }
 
+// DescribeNodeWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the DescribeNode CDP command, see SetNodeID.
+func DescribeNodeWithNodeID(v int64) DescribeNodeOption {
+	return func(c *DescribeNode) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the DescribeNode CDP command.
 //
@@ -219,6 +255,14 @@ func (t *DescribeNode) SetBackendNodeID(v int64) *DescribeNode {
 	return t
 }
 
+// DescribeNodeWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the DescribeNode CDP command, see SetBackendNodeID.
+func DescribeNodeWithBackendNodeID(v int64) DescribeNodeOption {
+	return func(c *DescribeNode) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the DescribeNode CDP command.
 //
@@ -228,16 +272,32 @@ func (t *DescribeNode) SetObjectID(v runtime.RemoteObjectID) *DescribeNode {
 	return t
 }
 
+// DescribeNodeWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the DescribeNode CDP command, see SetObjectID.
+func DescribeNodeWithObjectID(v runtime.RemoteObjectID) DescribeNodeOption {
+	return func(c *DescribeNode) {
+		c.SetObjectID(v)
+	}
+}
+
 // SetDepth adds or modifies the value of the optional
 // parameter `depth` in the DescribeNode CDP command.
 //
 // The maximum depth at which children should be retrieved, defaults to 1. Use -1 for the
 // entire subtree or provide an integer larger than 0.
 func (t *DescribeNode) SetDepth(v int64) *DescribeNode {
-	t.Depth = v
+	t.Depth = &v
 	return t
 }
 
+// DescribeNodeWithDepth is a functional option which sets the value of the
+// optional parameter `depth` in the DescribeNode CDP command, see SetDepth.
+func DescribeNodeWithDepth(v int64) DescribeNodeOption {
+	return func(c *DescribeNode) {
+		c.SetDepth(v)
+	}
+}
+
 // SetPierce adds or modifies the value of the optional
 // parameter `pierce` in the DescribeNode CDP command.
 //
@@ -248,6 +308,14 @@ func (t *DescribeNode) SetPierce(v bool) *DescribeNode {
 	return t
 }
 
+// DescribeNodeWithPierce is a functional option which sets the value of the
+// optional parameter `pierce` in the DescribeNode CDP command, see SetPierce.
+func DescribeNodeWithPierce(v bool) DescribeNodeOption {
+	return func(c *DescribeNode) {
+		c.SetPierce(v)
+	}
+}
+
 // DescribeNodeResult contains the browser's response
 // to calling the DescribeNode CDP command with Do().
 type DescribeNodeResult struct {
@@ -318,15 +386,25 @@ type ScrollIntoViewIfNeeded struct {
 
 // NewScrollIntoViewIfNeeded constructs a new ScrollIntoViewIfNeeded struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ScrollIntoViewIfNeededOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-scrollIntoViewIfNeeded
 //
 // This CDP method is experimental.
-func NewScrollIntoViewIfNeeded() *ScrollIntoViewIfNeeded {
-	return &ScrollIntoViewIfNeeded{}
+func NewScrollIntoViewIfNeeded(opts ...ScrollIntoViewIfNeededOption) *ScrollIntoViewIfNeeded {
+	c := &ScrollIntoViewIfNeeded{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ScrollIntoViewIfNeededOption is a functional option for the optional
+// parameters of the ScrollIntoViewIfNeeded CDP command, see the
+// NewScrollIntoViewIfNeeded constructor. Options are applied in order.
+type ScrollIntoViewIfNeededOption = func(*ScrollIntoViewIfNeeded)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the ScrollIntoViewIfNeeded CDP command.
 //
@@ -336,6 +414,14 @@ func (t *ScrollIntoViewIfNeeded) SetNodeID(v int64) *ScrollIntoViewIfNeeded {
 	return t
 }
 
+// ScrollIntoViewIfNeededWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the ScrollIntoViewIfNeeded CDP command, see SetNodeID.
+func ScrollIntoViewIfNeededWithNodeID(v int64) ScrollIntoViewIfNeededOption {
+	return func(c *ScrollIntoViewIfNeeded) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the ScrollIntoViewIfNeeded CDP command.
 //
@@ -345,6 +431,14 @@ func (t *ScrollIntoViewIfNeeded) SetBackendNodeID(v int64) *ScrollIntoViewIfNeed
 	return t
 }
 
+// ScrollIntoViewIfNeededWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the ScrollIntoViewIfNeeded CDP command, see SetBackendNodeID.
+func ScrollIntoViewIfNeededWithBackendNodeID(v int64) ScrollIntoViewIfNeededOption {
+	return func(c *ScrollIntoViewIfNeeded) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the ScrollIntoViewIfNeeded CDP command.
 //
@@ -354,6 +448,14 @@ func (t *ScrollIntoViewIfNeeded) SetObjectID(v runtime.RemoteObjectID) *ScrollIn
 	return t
 }
 
+// ScrollIntoViewIfNeededWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the ScrollIntoViewIfNeeded CDP command, see SetObjectID.
+func ScrollIntoViewIfNeededWithObjectID(v runtime.RemoteObjectID) ScrollIntoViewIfNeededOption {
+	return func(c *ScrollIntoViewIfNeeded) {
+		c.SetObjectID(v)
+	}
+}
+
 // SetRect adds or modifies the value of the optional
 // parameter `rect` in the ScrollIntoViewIfNeeded CDP command.
 //
@@ -364,6 +466,14 @@ func (t *ScrollIntoViewIfNeeded) SetRect(v Rect) *ScrollIntoViewIfNeeded {
 	return t
 }
 
+// ScrollIntoViewIfNeededWithRect is a functional option which sets the value of the
+// optional parameter `rect` in the ScrollIntoViewIfNeeded CDP command, see SetRect.
+func ScrollIntoViewIfNeededWithRect(v Rect) ScrollIntoViewIfNeededOption {
+	return func(c *ScrollIntoViewIfNeeded) {
+		c.SetRect(v)
+	}
+}
+
 // Do sends the ScrollIntoViewIfNeeded CDP command to a browser,
 // and returns the browser's response.
 func (t *ScrollIntoViewIfNeeded) Do(ctx context.Context) error {
@@ -566,13 +676,23 @@ type Focus struct {
 
 // NewFocus constructs a new Focus struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `FocusOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-focus
-func NewFocus() *Focus {
-	return &Focus{}
+func NewFocus(opts ...FocusOption) *Focus {
+	c := &Focus{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// FocusOption is a functional option for the optional
+// parameters of the Focus CDP command, see the
+// NewFocus constructor. Options are applied in order.
+type FocusOption = func(*Focus)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the Focus CDP command.
 //
@@ -582,6 +702,14 @@ func (t *Focus) SetNodeID(v int64) *Focus {
 	return t
 }
 
+// FocusWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the Focus CDP command, see SetNodeID.
+func FocusWithNodeID(v int64) FocusOption {
+	return func(c *Focus) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the Focus CDP command.
 //
@@ -591,6 +719,14 @@ func (t *Focus) SetBackendNodeID(v int64) *Focus {
 	return t
 }
 
+// FocusWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the Focus CDP command, see SetBackendNodeID.
+func FocusWithBackendNodeID(v int64) FocusOption {
+	return func(c *Focus) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the Focus CDP command.
 //
@@ -600,6 +736,14 @@ func (t *Focus) SetObjectID(v runtime.RemoteObjectID) *Focus {
 	return t
 }
 
+// FocusWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the Focus CDP command, see SetObjectID.
+func FocusWithObjectID(v runtime.RemoteObjectID) FocusOption {
+	return func(c *Focus) {
+		c.SetObjectID(v)
+	}
+}
+
 // Do sends the Focus CDP command to a browser,
 // and returns the browser's response.
 func (t *Focus) Do(ctx context.Context) error {
@@ -720,13 +864,23 @@ type GetBoxModel struct {
 
 // NewGetBoxModel constructs a new GetBoxModel struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetBoxModelOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-getBoxModel
-func NewGetBoxModel() *GetBoxModel {
-	return &GetBoxModel{}
+func NewGetBoxModel(opts ...GetBoxModelOption) *GetBoxModel {
+	c := &GetBoxModel{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetBoxModelOption is a functional option for the optional
+// parameters of the GetBoxModel CDP command, see the
+// NewGetBoxModel constructor. Options are applied in order.
+type GetBoxModelOption = func(*GetBoxModel)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the GetBoxModel CDP command.
 //
@@ -736,6 +890,14 @@ func (t *GetBoxModel) SetNodeID(v int64) *GetBoxModel {
 	return t
 }
 
+// GetBoxModelWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the GetBoxModel CDP command, see SetNodeID.
+func GetBoxModelWithNodeID(v int64) GetBoxModelOption {
+	return func(c *GetBoxModel) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the GetBoxModel CDP command.
 //
@@ -745,6 +907,14 @@ func (t *GetBoxModel) SetBackendNodeID(v int64) *GetBoxModel {
 	return t
 }
 
+// GetBoxModelWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the GetBoxModel CDP command, see SetBackendNodeID.
+func GetBoxModelWithBackendNodeID(v int64) GetBoxModelOption {
+	return func(c *GetBoxModel) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the GetBoxModel CDP command.
 //
@@ -754,6 +924,14 @@ func (t *GetBoxModel) SetObjectID(v runtime.RemoteObjectID) *GetBoxModel {
 	return t
 }
 
+// GetBoxModelWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the GetBoxModel CDP command, see SetObjectID.
+func GetBoxModelWithObjectID(v runtime.RemoteObjectID) GetBoxModelOption {
+	return func(c *GetBoxModel) {
+		c.SetObjectID(v)
+	}
+}
+
 // GetBoxModelResult contains the browser's response
 // to calling the GetBoxModel CDP command with Do().
 type GetBoxModelResult struct {
@@ -820,15 +998,25 @@ type GetContentQuads struct {
 
 // NewGetContentQuads constructs a new GetContentQuads struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetContentQuadsOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-getContentQuads
 //
 // This CDP method is experimental.
-func NewGetContentQuads() *GetContentQuads {
-	return &GetContentQuads{}
+func NewGetContentQuads(opts ...GetContentQuadsOption) *GetContentQuads {
+	c := &GetContentQuads{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetContentQuadsOption is a functional option for the optional
+// parameters of the GetContentQuads CDP command, see the
+// NewGetContentQuads constructor. Options are applied in order.
+type GetContentQuadsOption = func(*GetContentQuads)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the GetContentQuads CDP command.
 //
@@ -838,6 +1026,14 @@ func (t *GetContentQuads) SetNodeID(v int64) *GetContentQuads {
 	return t
 }
 
+// GetContentQuadsWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the GetContentQuads CDP command, see SetNodeID.
+func GetContentQuadsWithNodeID(v int64) GetContentQuadsOption {
+	return func(c *GetContentQuads) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the GetContentQuads CDP command.
 //
@@ -847,6 +1043,14 @@ func (t *GetContentQuads) SetBackendNodeID(v int64) *GetContentQuads {
 	return t
 }
 
+// GetContentQuadsWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the GetContentQuads CDP command, see SetBackendNodeID.
+func GetContentQuadsWithBackendNodeID(v int64) GetContentQuadsOption {
+	return func(c *GetContentQuads) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the GetContentQuads CDP command.
 //
@@ -856,6 +1060,14 @@ func (t *GetContentQuads) SetObjectID(v runtime.RemoteObjectID) *GetContentQuads
 	return t
 }
 
+// GetContentQuadsWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the GetContentQuads CDP command, see SetObjectID.
+func GetContentQuadsWithObjectID(v runtime.RemoteObjectID) GetContentQuadsOption {
+	return func(c *GetContentQuads) {
+		c.SetObjectID(v)
+	}
+}
+
 // GetContentQuadsResult contains the browser's response
 // to calling the GetContentQuads CDP command with Do().
 type GetContentQuadsResult struct {
@@ -911,7 +1123,7 @@ func (t *GetContentQuads) ParseResponse(m *devtools.Message) (*GetContentQuadsRe
 type GetDocument struct {
 	// The maximum depth at which children should be retrieved, defaults to 1. Use -1 for the
 	// entire subtree or provide an integer larger than 0.
-	Depth int64 `json:"depth,omitempty"`
+	Depth *int64 `json:"depth,omitempty"`
 	// Whether or not iframes and shadow roots should be traversed when returning the subtree
 	// (default is false).
 	Pierce bool `json:"pierce,omitempty"`
@@ -919,23 +1131,41 @@ type GetDocument struct {
 
 // NewGetDocument constructs a new GetDocument struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetDocumentOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-getDocument
-func NewGetDocument() *GetDocument {
-	return &GetDocument{}
+func NewGetDocument(opts ...GetDocumentOption) *GetDocument {
+	c := &GetDocument{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetDocumentOption is a functional option for the optional
+// parameters of the GetDocument CDP command, see the
+// NewGetDocument constructor. Options are applied in order.
+type GetDocumentOption = func(*GetDocument)
+
 // SetDepth adds or modifies the value of the optional
 // parameter `depth` in the GetDocument CDP command.
 //
 // The maximum depth at which children should be retrieved, defaults to 1. Use -1 for the
 // entire subtree or provide an integer larger than 0.
 func (t *GetDocument) SetDepth(v int64) *GetDocument {
-	t.Depth = v
+	t.Depth = &v
 	return t
 }
 
+// GetDocumentWithDepth is a functional option which sets the value of the
+// optional parameter `depth` in the GetDocument CDP command, see SetDepth.
+func GetDocumentWithDepth(v int64) GetDocumentOption {
+	return func(c *GetDocument) {
+		c.SetDepth(v)
+	}
+}
+
 // SetPierce adds or modifies the value of the optional
 // parameter `pierce` in the GetDocument CDP command.
 //
@@ -946,6 +1176,14 @@ func (t *GetDocument) SetPierce(v bool) *GetDocument {
 	return t
 }
 
+// GetDocumentWithPierce is a functional option which sets the value of the
+// optional parameter `pierce` in the GetDocument CDP command, see SetPierce.
+func GetDocumentWithPierce(v bool) GetDocumentOption {
+	return func(c *GetDocument) {
+		c.SetPierce(v)
+	}
+}
+
 // GetDocumentResult contains the browser's response
 // to calling the GetDocument CDP command with Do().
 type GetDocumentResult struct {
@@ -1005,7 +1243,7 @@ func (t *GetDocument) ParseResponse(m *devtools.Message) (*GetDocumentResult, er
 type GetFlattenedDocument struct {
 	// The maximum depth at which children should be retrieved, defaults to 1. Use -1 for the
 	// entire subtree or provide an integer larger than 0.
-	Depth int64 `json:"depth,omitempty"`
+	Depth *int64 `json:"depth,omitempty"`
 	// Whether or not iframes and shadow roots should be traversed when returning the subtree
 	// (default is false).
 	Pierce bool `json:"pierce,omitempty"`
@@ -1013,25 +1251,43 @@ type GetFlattenedDocument struct {
 
 // NewGetFlattenedDocument constructs a new GetFlattenedDocument struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetFlattenedDocumentOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-getFlattenedDocument
 //
 // This CDP method is deprecated.
-func NewGetFlattenedDocument() *GetFlattenedDocument {
-	return &GetFlattenedDocument{}
+func NewGetFlattenedDocument(opts ...GetFlattenedDocumentOption) *GetFlattenedDocument {
+	c := &GetFlattenedDocument{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetFlattenedDocumentOption is a functional option for the optional
+// parameters of the GetFlattenedDocument CDP command, see the
+// NewGetFlattenedDocument constructor. Options are applied in order.
+type GetFlattenedDocumentOption = func(*GetFlattenedDocument)
+
 // SetDepth adds or modifies the value of the optional
 // parameter `depth` in the GetFlattenedDocument CDP command.
 //
 // The maximum depth at which children should be retrieved, defaults to 1. Use -1 for the
 // entire subtree or provide an integer larger than 0.
 func (t *GetFlattenedDocument) SetDepth(v int64) *GetFlattenedDocument {
-	t.Depth = v
+	t.Depth = &v
 	return t
 }
 
+// GetFlattenedDocumentWithDepth is a functional option which sets the value of the
+// optional parameter `depth` in the GetFlattenedDocument CDP command, see SetDepth.
+func GetFlattenedDocumentWithDepth(v int64) GetFlattenedDocumentOption {
+	return func(c *GetFlattenedDocument) {
+		c.SetDepth(v)
+	}
+}
+
 // SetPierce adds or modifies the value of the optional
 // parameter `pierce` in the GetFlattenedDocument CDP command.
 //
@@ -1042,6 +1298,14 @@ func (t *GetFlattenedDocument) SetPierce(v bool) *GetFlattenedDocument {
 	return t
 }
 
+// GetFlattenedDocumentWithPierce is a functional option which sets the value of the
+// optional parameter `pierce` in the GetFlattenedDocument CDP command, see SetPierce.
+func GetFlattenedDocumentWithPierce(v bool) GetFlattenedDocumentOption {
+	return func(c *GetFlattenedDocument) {
+		c.SetPierce(v)
+	}
+}
+
 // GetFlattenedDocumentResult contains the browser's response
 // to calling the GetFlattenedDocument CDP command with Do().
 type GetFlattenedDocumentResult struct {
@@ -1108,18 +1372,28 @@ type GetNodesForSubtreeByStyle struct {
 
 // NewGetNodesForSubtreeByStyle constructs a new GetNodesForSubtreeByStyle struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetNodesForSubtreeByStyleOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-getNodesForSubtreeByStyle
 //
 // This CDP method is experimental.
-func NewGetNodesForSubtreeByStyle(nodeID int64, computedStyles []CSSComputedStyleProperty) *GetNodesForSubtreeByStyle {
-	return &GetNodesForSubtreeByStyle{
+func NewGetNodesForSubtreeByStyle(nodeID int64, computedStyles []CSSComputedStyleProperty, opts ...GetNodesForSubtreeByStyleOption) *GetNodesForSubtreeByStyle {
+	c := &GetNodesForSubtreeByStyle{
 		NodeID:         nodeID,
 		ComputedStyles: computedStyles,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetNodesForSubtreeByStyleOption is a functional option for the optional
+// parameters of the GetNodesForSubtreeByStyle CDP command, see the
+// NewGetNodesForSubtreeByStyle constructor. Options are applied in order.
+type GetNodesForSubtreeByStyleOption = func(*GetNodesForSubtreeByStyle)
+
 // SetPierce adds or modifies the value of the optional
 // parameter `pierce` in the GetNodesForSubtreeByStyle CDP command.
 //
@@ -1130,6 +1404,14 @@ func (t *GetNodesForSubtreeByStyle) SetPierce(v bool) *GetNodesForSubtreeByStyle
 	return t
 }
 
+// GetNodesForSubtreeByStyleWithPierce is a functional option which sets the value of the
+// optional parameter `pierce` in the GetNodesForSubtreeByStyle CDP command, see SetPierce.
+func GetNodesForSubtreeByStyleWithPierce(v bool) GetNodesForSubtreeByStyleOption {
+	return func(c *GetNodesForSubtreeByStyle) {
+		c.SetPierce(v)
+	}
+}
+
 // GetNodesForSubtreeByStyleResult contains the browser's response
 // to calling the GetNodesForSubtreeByStyle CDP command with Do().
 type GetNodesForSubtreeByStyleResult struct {
@@ -1196,16 +1478,26 @@ type GetNodeForLocation struct {
 
 // NewGetNodeForLocation constructs a new GetNodeForLocation struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetNodeForLocationOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-getNodeForLocation
-func NewGetNodeForLocation(x int64, y int64) *GetNodeForLocation {
-	return &GetNodeForLocation{
+func NewGetNodeForLocation(x int64, y int64, opts ...GetNodeForLocationOption) *GetNodeForLocation {
+	c := &GetNodeForLocation{
 		X: x,
 		Y: y,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetNodeForLocationOption is a functional option for the optional
+// parameters of the GetNodeForLocation CDP command, see the
+// NewGetNodeForLocation constructor. Options are applied in order.
+type GetNodeForLocationOption = func(*GetNodeForLocation)
+
 // SetIncludeUserAgentShadowDOM adds or modifies the value of the optional
 // parameter `includeUserAgentShadowDOM` in the GetNodeForLocation CDP command.
 //
@@ -1215,6 +1507,14 @@ func (t *GetNodeForLocation) SetIncludeUserAgentShadowDOM(v bool) *GetNodeForLoc
 	return t
 }
 
+// GetNodeForLocationWithIncludeUserAgentShadowDOM is a functional option which sets the value of the
+// optional parameter `includeUserAgentShadowDOM` in the GetNodeForLocation CDP command, see SetIncludeUserAgentShadowDOM.
+func GetNodeForLocationWithIncludeUserAgentShadowDOM(v bool) GetNodeForLocationOption {
+	return func(c *GetNodeForLocation) {
+		c.SetIncludeUserAgentShadowDOM(v)
+	}
+}
+
 // SetIgnorePointerEventsNone adds or modifies the value of the optional
 // parameter `ignorePointerEventsNone` in the GetNodeForLocation CDP command.
 //
@@ -1224,6 +1524,14 @@ func (t *GetNodeForLocation) SetIgnorePointerEventsNone(v bool) *GetNodeForLocat
 	return t
 }
 
+// GetNodeForLocationWithIgnorePointerEventsNone is a functional option which sets the value of the
+// optional parameter `ignorePointerEventsNone` in the GetNodeForLocation CDP command, see SetIgnorePointerEventsNone.
+func GetNodeForLocationWithIgnorePointerEventsNone(v bool) GetNodeForLocationOption {
+	return func(c *GetNodeForLocation) {
+		c.SetIgnorePointerEventsNone(v)
+	}
+}
+
 // GetNodeForLocationResult contains the browser's response
 // to calling the GetNodeForLocation CDP command with Do().
 type GetNodeForLocationResult struct {
@@ -1291,13 +1599,23 @@ type GetOuterHTML struct {
 
 // NewGetOuterHTML constructs a new GetOuterHTML struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetOuterHTMLOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-getOuterHTML
-func NewGetOuterHTML() *GetOuterHTML {
-	return &GetOuterHTML{}
+func NewGetOuterHTML(opts ...GetOuterHTMLOption) *GetOuterHTML {
+	c := &GetOuterHTML{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetOuterHTMLOption is a functional option for the optional
+// parameters of the GetOuterHTML CDP command, see the
+// NewGetOuterHTML constructor. Options are applied in order.
+type GetOuterHTMLOption = func(*GetOuterHTML)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the GetOuterHTML CDP command.
 //
@@ -1307,6 +1625,14 @@ func (t *GetOuterHTML) SetNodeID(v int64) *GetOuterHTML {
 	return t
 }
 
+// GetOuterHTMLWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the GetOuterHTML CDP command, see SetNodeID.
+func GetOuterHTMLWithNodeID(v int64) GetOuterHTMLOption {
+	return func(c *GetOuterHTML) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the GetOuterHTML CDP command.
 //
@@ -1316,6 +1642,14 @@ func (t *GetOuterHTML) SetBackendNodeID(v int64) *GetOuterHTML {
 	return t
 }
 
+// GetOuterHTMLWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the GetOuterHTML CDP command, see SetBackendNodeID.
+func GetOuterHTMLWithBackendNodeID(v int64) GetOuterHTMLOption {
+	return func(c *GetOuterHTML) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the GetOuterHTML CDP command.
 //
@@ -1325,6 +1659,14 @@ func (t *GetOuterHTML) SetObjectID(v runtime.RemoteObjectID) *GetOuterHTML {
 	return t
 }
 
+// GetOuterHTMLWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the GetOuterHTML CDP command, see SetObjectID.
+func GetOuterHTMLWithObjectID(v runtime.RemoteObjectID) GetOuterHTMLOption {
+	return func(c *GetOuterHTML) {
+		c.SetObjectID(v)
+	}
+}
+
 // GetOuterHTMLResult contains the browser's response
 // to calling the GetOuterHTML CDP command with Do().
 type GetOuterHTMLResult struct {
@@ -1588,16 +1930,26 @@ type MoveTo struct {
 
 // NewMoveTo constructs a new MoveTo struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `MoveToOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-moveTo
-func NewMoveTo(nodeID int64, targetNodeID int64) *MoveTo {
-	return &MoveTo{
+func NewMoveTo(nodeID int64, targetNodeID int64, opts ...MoveToOption) *MoveTo {
+	c := &MoveTo{
 		NodeID:       nodeID,
 		TargetNodeID: targetNodeID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// MoveToOption is a functional option for the optional
+// parameters of the MoveTo CDP command, see the
+// NewMoveTo constructor. Options are applied in order.
+type MoveToOption = func(*MoveTo)
+
 // SetInsertBeforeNodeID adds or modifies the value of the optional
 // parameter `insertBeforeNodeId` in the MoveTo CDP command.
 //
@@ -1608,6 +1960,14 @@ func (t *MoveTo) SetInsertBeforeNodeID(v int64) *MoveTo {
 	return t
 }
 
+// MoveToWithInsertBeforeNodeID is a functional option which sets the value of the
+// optional parameter `insertBeforeNodeId` in the MoveTo CDP command, see SetInsertBeforeNodeID.
+func MoveToWithInsertBeforeNodeID(v int64) MoveToOption {
+	return func(c *MoveTo) {
+		c.SetInsertBeforeNodeID(v)
+	}
+}
+
 // MoveToResult contains the browser's response
 // to calling the MoveTo CDP command with Do().
 type MoveToResult struct {
@@ -1672,17 +2032,27 @@ type PerformSearch struct {
 
 // NewPerformSearch constructs a new PerformSearch struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `PerformSearchOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-performSearch
 //
 // This CDP method is experimental.
-func NewPerformSearch(query string) *PerformSearch {
-	return &PerformSearch{
+func NewPerformSearch(query string, opts ...PerformSearchOption) *PerformSearch {
+	c := &PerformSearch{
 		Query: query,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// PerformSearchOption is a functional option for the optional
+// parameters of the PerformSearch CDP command, see the
+// NewPerformSearch constructor. Options are applied in order.
+type PerformSearchOption = func(*PerformSearch)
+
 // SetIncludeUserAgentShadowDOM adds or modifies the value of the optional
 // parameter `includeUserAgentShadowDOM` in the PerformSearch CDP command.
 //
@@ -1692,6 +2062,14 @@ func (t *PerformSearch) SetIncludeUserAgentShadowDOM(v bool) *PerformSearch {
 	return t
 }
 
+// PerformSearchWithIncludeUserAgentShadowDOM is a functional option which sets the value of the
+// optional parameter `includeUserAgentShadowDOM` in the PerformSearch CDP command, see SetIncludeUserAgentShadowDOM.
+func PerformSearchWithIncludeUserAgentShadowDOM(v bool) PerformSearchOption {
+	return func(c *PerformSearch) {
+		c.SetIncludeUserAgentShadowDOM(v)
+	}
+}
+
 // PerformSearchResult contains the browser's response
 // to calling the PerformSearch CDP command with Do().
 type PerformSearchResult struct {
@@ -2205,7 +2583,7 @@ type RequestChildNodes struct {
 	NodeID int64 `json:"nodeId"`
 	// The maximum depth at which children should be retrieved, defaults to 1. Use -1 for the
 	// entire subtree or provide an integer larger than 0.
-	Depth int64 `json:"depth,omitempty"`
+	Depth *int64 `json:"depth,omitempty"`
 	// Whether or not iframes and shadow roots should be traversed when returning the sub-tree
 	// (default is false).
 	Pierce bool `json:"pierce,omitempty"`
@@ -2213,25 +2591,43 @@ type RequestChildNodes struct {
 
 // NewRequestChildNodes constructs a new RequestChildNodes struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `RequestChildNodesOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-requestChildNodes
-func NewRequestChildNodes(nodeID int64) *RequestChildNodes {
-	return &RequestChildNodes{
+func NewRequestChildNodes(nodeID int64, opts ...RequestChildNodesOption) *RequestChildNodes {
+	c := &RequestChildNodes{
 		NodeID: nodeID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// RequestChildNodesOption is a functional option for the optional
+// parameters of the RequestChildNodes CDP command, see the
+// NewRequestChildNodes constructor. Options are applied in order.
+type RequestChildNodesOption = func(*RequestChildNodes)
+
 // SetDepth adds or modifies the value of the optional
 // parameter `depth` in the RequestChildNodes CDP command.
 //
 // The maximum depth at which children should be retrieved, defaults to 1. Use -1 for the
 // entire subtree or provide an integer larger than 0.
 func (t *RequestChildNodes) SetDepth(v int64) *RequestChildNodes {
-	t.Depth = v
+	t.Depth = &v
 	return t
 }
 
+// RequestChildNodesWithDepth is a functional option which sets the value of the
+// optional parameter `depth` in the RequestChildNodes CDP command, see SetDepth.
+func RequestChildNodesWithDepth(v int64) RequestChildNodesOption {
+	return func(c *RequestChildNodes) {
+		c.SetDepth(v)
+	}
+}
+
 // SetPierce adds or modifies the value of the optional
 // parameter `pierce` in the RequestChildNodes CDP command.
 //
@@ -2242,6 +2638,14 @@ func (t *RequestChildNodes) SetPierce(v bool) *RequestChildNodes {
 	return t
 }
 
+// RequestChildNodesWithPierce is a functional option which sets the value of the
+// optional parameter `pierce` in the RequestChildNodes CDP command, see SetPierce.
+func RequestChildNodesWithPierce(v bool) RequestChildNodesOption {
+	return func(c *RequestChildNodes) {
+		c.SetPierce(v)
+	}
+}
+
 // Do sends the RequestChildNodes CDP command to a browser,
 // and returns the browser's response.
 func (t *RequestChildNodes) Do(ctx context.Context) error {
@@ -2366,13 +2770,23 @@ type ResolveNode struct {
 
 // NewResolveNode constructs a new ResolveNode struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ResolveNodeOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-resolveNode
-func NewResolveNode() *ResolveNode {
-	return &ResolveNode{}
+func NewResolveNode(opts ...ResolveNodeOption) *ResolveNode {
+	c := &ResolveNode{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ResolveNodeOption is a functional option for the optional
+// parameters of the ResolveNode CDP command, see the
+// NewResolveNode constructor. Options are applied in order.
+type ResolveNodeOption = func(*ResolveNode)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the ResolveNode CDP command.
 //
@@ -2382,6 +2796,14 @@ func (t *ResolveNode) SetNodeID(v int64) *ResolveNode {
 	return t
 }
 
+// ResolveNodeWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the ResolveNode CDP command, see SetNodeID.
+func ResolveNodeWithNodeID(v int64) ResolveNodeOption {
+	return func(c *ResolveNode) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the ResolveNode CDP command.
 //
@@ -2391,6 +2813,14 @@ func (t *ResolveNode) SetBackendNodeID(v int64) *ResolveNode {
 	return t
 }
 
+// ResolveNodeWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the ResolveNode CDP command, see SetBackendNodeID.
+func ResolveNodeWithBackendNodeID(v int64) ResolveNodeOption {
+	return func(c *ResolveNode) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectGroup adds or modifies the value of the optional
 // parameter `objectGroup` in the ResolveNode CDP command.
 //
@@ -2400,6 +2830,14 @@ func (t *ResolveNode) SetObjectGroup(v string) *ResolveNode {
 	return t
 }
 
+// ResolveNodeWithObjectGroup is a functional option which sets the value of the
+// optional parameter `objectGroup` in the ResolveNode CDP command, see SetObjectGroup.
+func ResolveNodeWithObjectGroup(v string) ResolveNodeOption {
+	return func(c *ResolveNode) {
+		c.SetObjectGroup(v)
+	}
+}
+
 // SetExecutionContextID adds or modifies the value of the optional
 // parameter `executionContextId` in the ResolveNode CDP command.
 //
@@ -2409,6 +2847,14 @@ func (t *ResolveNode) SetExecutionContextID(v runtime.ExecutionContextID) *Resol
 	return t
 }
 
+// ResolveNodeWithExecutionContextID is a functional option which sets the value of the
+// optional parameter `executionContextId` in the ResolveNode CDP command, see SetExecutionContextID.
+func ResolveNodeWithExecutionContextID(v runtime.ExecutionContextID) ResolveNodeOption {
+	return func(c *ResolveNode) {
+		c.SetExecutionContextID(v)
+	}
+}
+
 // ResolveNodeResult contains the browser's response
 // to calling the ResolveNode CDP command with Do().
 type ResolveNodeResult struct {
@@ -2537,16 +2983,26 @@ type SetAttributesAsText struct {
 
 // NewSetAttributesAsText constructs a new SetAttributesAsText struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetAttributesAsTextOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-setAttributesAsText
-func NewSetAttributesAsText(nodeID int64, text string) *SetAttributesAsText {
-	return &SetAttributesAsText{
+func NewSetAttributesAsText(nodeID int64, text string, opts ...SetAttributesAsTextOption) *SetAttributesAsText {
+	c := &SetAttributesAsText{
 		NodeID: nodeID,
 		Text:   text,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetAttributesAsTextOption is a functional option for the optional
+// parameters of the SetAttributesAsText CDP command, see the
+// NewSetAttributesAsText constructor. Options are applied in order.
+type SetAttributesAsTextOption = func(*SetAttributesAsText)
+
 // SetName adds or modifies the value of the optional
 // parameter `name` in the SetAttributesAsText CDP command.
 //
@@ -2557,6 +3013,14 @@ func (t *SetAttributesAsText) SetName(v string) *SetAttributesAsText {
 	return t
 }
 
+// SetAttributesAsTextWithName is a functional option which sets the value of the
+// optional parameter `name` in the SetAttributesAsText CDP command, see SetName.
+func SetAttributesAsTextWithName(v string) SetAttributesAsTextOption {
+	return func(c *SetAttributesAsText) {
+		c.SetName(v)
+	}
+}
+
 // Do sends the SetAttributesAsText CDP command to a browser,
 // and returns the browser's response.
 func (t *SetAttributesAsText) Do(ctx context.Context) error {
@@ -2611,15 +3075,25 @@ type SetFileInputFiles struct {
 
 // NewSetFileInputFiles constructs a new SetFileInputFiles struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetFileInputFilesOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-setFileInputFiles
-func NewSetFileInputFiles(files []string) *SetFileInputFiles {
-	return &SetFileInputFiles{
+func NewSetFileInputFiles(files []string, opts ...SetFileInputFilesOption) *SetFileInputFiles {
+	c := &SetFileInputFiles{
 		Files: files,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetFileInputFilesOption is a functional option for the optional
+// parameters of the SetFileInputFiles CDP command, see the
+// NewSetFileInputFiles constructor. Options are applied in order.
+type SetFileInputFilesOption = func(*SetFileInputFiles)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the SetFileInputFiles CDP command.
 //
@@ -2629,6 +3103,14 @@ func (t *SetFileInputFiles) SetNodeID(v int64) *SetFileInputFiles {
 	return t
 }
 
+// SetFileInputFilesWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the SetFileInputFiles CDP command, see SetNodeID.
+func SetFileInputFilesWithNodeID(v int64) SetFileInputFilesOption {
+	return func(c *SetFileInputFiles) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the SetFileInputFiles CDP command.
 //
@@ -2638,6 +3120,14 @@ func (t *SetFileInputFiles) SetBackendNodeID(v int64) *SetFileInputFiles {
 	return t
 }
 
+// SetFileInputFilesWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the SetFileInputFiles CDP command, see SetBackendNodeID.
+func SetFileInputFilesWithBackendNodeID(v int64) SetFileInputFilesOption {
+	return func(c *SetFileInputFiles) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the SetFileInputFiles CDP command.
 //
@@ -2647,6 +3137,14 @@ func (t *SetFileInputFiles) SetObjectID(v runtime.RemoteObjectID) *SetFileInputF
 	return t
 }
 
+// SetFileInputFilesWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the SetFileInputFiles CDP command, see SetObjectID.
+func SetFileInputFilesWithObjectID(v runtime.RemoteObjectID) SetFileInputFilesOption {
+	return func(c *SetFileInputFiles) {
+		c.SetObjectID(v)
+	}
+}
+
 // Do sends the SetFileInputFiles CDP command to a browser,
 // and returns the browser's response.
 func (t *SetFileInputFiles) Do(ctx context.Context) error {
@@ -3278,17 +3776,27 @@ type GetContainerForNode struct {
 
 // NewGetContainerForNode constructs a new GetContainerForNode struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetContainerForNodeOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-getContainerForNode
 //
 // This CDP method is experimental.
-func NewGetContainerForNode(nodeID int64) *GetContainerForNode {
-	return &GetContainerForNode{
+func NewGetContainerForNode(nodeID int64, opts ...GetContainerForNodeOption) *GetContainerForNode {
+	c := &GetContainerForNode{
 		NodeID: nodeID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetContainerForNodeOption is a functional option for the optional
+// parameters of the GetContainerForNode CDP command, see the
+// NewGetContainerForNode constructor. Options are applied in order.
+type GetContainerForNodeOption = func(*GetContainerForNode)
+
 // SetContainerName adds or modifies the value of the optional
 // parameter `containerName` in the GetContainerForNode CDP command.
 func (t *GetContainerForNode) SetContainerName(v string) *GetContainerForNode {
@@ -3296,6 +3804,14 @@ func (t *GetContainerForNode) SetContainerName(v string) *GetContainerForNode {
 	return t
 }
 
+// GetContainerForNodeWithContainerName is a functional option which sets the value of the
+// optional parameter `containerName` in the GetContainerForNode CDP command, see SetContainerName.
+func GetContainerForNodeWithContainerName(v string) GetContainerForNodeOption {
+	return func(c *GetContainerForNode) {
+		c.SetContainerName(v)
+	}
+}
+
 // GetContainerForNodeResult contains the browser's response
 // to calling the GetContainerForNode CDP command with Do().
 type GetContainerForNodeResult struct {
@@ -0,0 +1,300 @@
+package dom
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// ElementHandle refers to a DOM element, and manages its identifiers in
+// the 3 ID spaces of the CDP, converting between them lazily as needed:
+//
+// • Node IDs (`nodeId`), which are used by most DOM commands, but are valid
+// only after a `DOM.getDocument` command, until the next one.
+//
+// • Backend node IDs (`backendNodeId`), which are stable for the lifetime
+// of the node, and used in other domains, e.g. Accessibility and LayerTree.
+//
+// Handles identify their element by its backend node ID whenever they know
+// it, and don't cache node IDs which they converted from it, so they don't
+// go stale when another component sends a `DOM.getDocument` command. Handles
+// send this command only if the page's document wasn't requested yet.
+//
+// • Remote object IDs (`objectId`), which are used to access the
+// element in JavaScript, e.g. with `runtime.NewCallFunctionOn`.
+//
+// Handles which were created by `dom.Query` remember their selector, so
+// they can re-resolve their element automatically when it's replaced, e.g.
+// after a navigation or a re-render (see the `ElementHandle.Do` method).
+type ElementHandle struct {
+	selector     string
+	maxRequeries int
+
+	mu            sync.Mutex
+	nodeID        int64
+	backendNodeID int64
+	objectID      runtime.RemoteObjectID
+}
+
+// DefaultMaxRequeries is the default maximum number of times that the
+// `ElementHandle.Do` method re-queries a stale element by its selector.
+const DefaultMaxRequeries = 3
+
+// QueryOption is used for customization in the `dom.Query` function.
+type QueryOption = func(*ElementHandle)
+
+// MaxRequeries allows the caller of the `dom.Query` function to customize
+// how many times the `ElementHandle.Do` method re-queries a stale element
+// by its selector (default: `dom.DefaultMaxRequeries`).
+func MaxRequeries(n int) QueryOption {
+	return func(h *ElementHandle) {
+		h.maxRequeries = n
+	}
+}
+
+// NoRequery allows the caller of the `dom.Query` function to opt out of
+// re-querying stale elements in the `ElementHandle.Do` method, e.g. when
+// a test should fail if the element is replaced.
+func NoRequery() QueryOption {
+	return MaxRequeries(0)
+}
+
+// Query returns a handle to the first element which matches the given
+// selector, in the main frame of the page associated with the given context.
+// Selectors are CSS selectors by default, or use other selector engines
+// according to their prefix, see the `dom.SelectorEngine` interface.
+func Query(ctx context.Context, selector string, opts ...QueryOption) (*ElementHandle, error) {
+	h := &ElementHandle{selector: selector, maxRequeries: DefaultMaxRequeries}
+	for _, o := range opts {
+		o(h)
+	}
+	if err := h.Resolve(ctx); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// QueryAll returns handles to all the elements which match the given
+// selector, in the main frame of the page associated with the given context,
+// in document order (see the `dom.Query` function). The handles refer to
+// the elements by their backend node IDs, so they remain valid as long as
+// the elements exist, but they don't remember the selector, so they aren't
+// re-queried when the elements are replaced.
+func QueryAll(ctx context.Context, selector string) ([]*ElementHandle, error) {
+	root, err := documentNodeID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := QueryNodes(ctx, root, selector)
+	if err != nil {
+		return nil, err
+	}
+	handles := make([]*ElementHandle, len(ids))
+	for i, id := range ids {
+		r, err := NewDescribeNode().SetNodeID(id).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		handles[i] = HandleFromBackendNodeID(r.Node.BackendNodeID)
+	}
+	return handles, nil
+}
+
+// HandleFromNodeID returns a handle to the element with the given node ID.
+func HandleFromNodeID(nodeID int64) *ElementHandle {
+	return &ElementHandle{nodeID: nodeID}
+}
+
+// HandleFromBackendNodeID returns a handle to the
+// element with the given backend node ID.
+func HandleFromBackendNodeID(backendNodeID int64) *ElementHandle {
+	return &ElementHandle{backendNodeID: backendNodeID}
+}
+
+// HandleFromObjectID returns a handle to the element
+// which is represented by the given remote object ID.
+func HandleFromObjectID(objectID runtime.RemoteObjectID) *ElementHandle {
+	return &ElementHandle{objectID: objectID}
+}
+
+// Selector returns the selector of the handle,
+// if it was created by `dom.Query`.
+func (h *ElementHandle) Selector() string {
+	return h.selector
+}
+
+// Resolve (re-)queries the handle's selector, and resets all of the
+// handle's IDs to refer to the element that matches it now. It fails
+// if the handle wasn't created by `dom.Query`.
+func (h *ElementHandle) Resolve(ctx context.Context) error {
+	if h.selector == "" {
+		return fmt.Errorf("element handle without a selector can't be re-resolved")
+	}
+	root, err := documentNodeID(ctx)
+	if err != nil {
+		return err
+	}
+	ids, err := QueryNodes(ctx, root, h.selector)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 || ids[0] == 0 {
+		return fmt.Errorf("no element matches the selector %q", h.selector)
+	}
+	r, err := NewDescribeNode().SetNodeID(ids[0]).Do(ctx)
+	if err != nil {
+		return err
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nodeID, h.backendNodeID, h.objectID = 0, r.Node.BackendNodeID, ""
+	return nil
+}
+
+// NodeID returns the element's node ID, converting it from one of the
+// element's other IDs if needed. Node IDs which are converted from backend
+// node IDs aren't cached, so each call returns a currently valid node ID.
+func (h *ElementHandle) NodeID(ctx context.Context) (int64, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.backendNodeID != 0 {
+		return pushBackendNode(ctx, h.backendNodeID)
+	}
+	if h.nodeID != 0 {
+		return h.nodeID, nil
+	}
+	r, err := NewDescribeNode().SetObjectID(h.objectID).Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	h.backendNodeID = r.Node.BackendNodeID
+	return pushBackendNode(ctx, h.backendNodeID)
+}
+
+// Return the current node ID of the node with the given backend node ID.
+// Node IDs are issued only for nodes in a document which was requested by
+// the CDP client, so this function requests the document if it wasn't
+// requested yet (but not otherwise, to avoid invalidating all the node IDs).
+func pushBackendNode(ctx context.Context, backendNodeID int64) (int64, error) {
+	r, err := NewPushNodesByBackendIdsToFrontend([]int64{backendNodeID}).Do(ctx)
+	if err != nil && strings.Contains(strings.ToLower(err.Error()), "document needs to be requested first") {
+		if _, err = NewGetDocument().Do(ctx); err == nil {
+			r, err = NewPushNodesByBackendIdsToFrontend([]int64{backendNodeID}).Do(ctx)
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(r.NodeIds) == 0 || r.NodeIds[0] == 0 {
+		return 0, fmt.Errorf("no node found for backend node ID %d", backendNodeID)
+	}
+	return r.NodeIds[0], nil
+}
+
+// Return the current node ID of the document in the main frame of the page
+// associated with the given context, without invalidating other node IDs.
+func documentNodeID(ctx context.Context) (int64, error) {
+	r, err := runtime.NewEvaluate("document").Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if r.ExceptionDetails != nil {
+		return 0, r.ExceptionDetails
+	}
+	defer runtime.NewReleaseObject(r.Result.ObjectID).Do(ctx)
+	d, err := NewDescribeNode().SetObjectID(runtime.RemoteObjectID(r.Result.ObjectID)).Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return pushBackendNode(ctx, d.Node.BackendNodeID)
+}
+
+// BackendNodeID returns the element's backend node ID, converting
+// it from one of the element's other IDs if needed.
+func (h *ElementHandle) BackendNodeID(ctx context.Context) (int64, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.backendNodeID != 0 {
+		return h.backendNodeID, nil
+	}
+	d := NewDescribeNode()
+	if h.nodeID != 0 {
+		d.SetNodeID(h.nodeID)
+	} else {
+		d.SetObjectID(h.objectID)
+	}
+	r, err := d.Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	h.backendNodeID = r.Node.BackendNodeID
+	return h.backendNodeID, nil
+}
+
+// ObjectID returns the element's remote object ID, converting
+// it from one of the element's other IDs if needed.
+func (h *ElementHandle) ObjectID(ctx context.Context) (runtime.RemoteObjectID, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.objectID != "" {
+		return h.objectID, nil
+	}
+	r := NewResolveNode()
+	if h.backendNodeID != 0 {
+		r.SetBackendNodeID(h.backendNodeID)
+	} else {
+		r.SetNodeID(h.nodeID)
+	}
+	result, err := r.Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	h.objectID = runtime.RemoteObjectID(result.Object.ObjectID)
+	return h.objectID, nil
+}
+
+// Do calls the given function, which is expected to use the handle's IDs.
+// If it fails because the element no longer exists (e.g. "Could not find
+// node with given id"), and the handle was created by `dom.Query`, Do
+// re-resolves the handle and calls the function again, up to a maximum
+// number of times (see the `dom.MaxRequeries` and `dom.NoRequery` options).
+func (h *ElementHandle) Do(ctx context.Context, f func(context.Context, *ElementHandle) error) error {
+	err := f(ctx, h)
+	for i := 0; i < h.maxRequeries && h.selector != "" && IsStaleNodeError(err); i++ {
+		if err := h.Resolve(ctx); err != nil {
+			return err
+		}
+		err = f(ctx, h)
+	}
+	return err
+}
+
+// Substrings of CDP error messages which indicate that a node ID or an
+// object ID refers to a node that no longer exists, or is no longer valid.
+var staleNodeErrors = []string{
+	"could not find node",
+	"no node with given id",
+	"no node found",
+	"node with given id does not belong to the document",
+	"could not find object with given id",
+	"cannot find context with specified id",
+}
+
+// IsStaleNodeError reports whether the given error indicates that a node ID
+// or an object ID refers to a node that no longer exists, or is no longer
+// valid (e.g. after a navigation, or a `DOM.getDocument` command).
+func IsStaleNodeError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range staleNodeErrors {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package dom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+)
+
+func TestIsStaleNodeError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errors.New("Could not find node with given id (-32000)"), true},
+		{errors.New("No node with given id found (-32000)"), true},
+		{errors.New("Node with given id does not belong to the document (-32000)"), true},
+		{errors.New("Could not find object with given id (-32000)"), true},
+		{errors.New("Invalid parameters (-32602)"), false},
+	}
+	for _, tt := range tests {
+		if got := IsStaleNodeError(tt.err); got != tt.want {
+			t.Errorf("IsStaleNodeError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestElementHandleDoWithoutSelector(t *testing.T) {
+	// Set up.
+	h := HandleFromNodeID(1)
+	stale := errors.New("Could not find node with given id (-32000)")
+	calls := 0
+	// Test.
+	err := h.Do(context.Background(), func(context.Context, *ElementHandle) error {
+		calls++
+		return stale
+	})
+	if err != stale || calls != 1 {
+		t.Errorf("Do() = %v after %d calls, want %v after 1 call", err, calls, stale)
+	}
+}
+
+func TestElementHandleDoNoRequery(t *testing.T) {
+	// Set up.
+	h := &ElementHandle{selector: "#x"}
+	NoRequery()(h)
+	stale := errors.New("Could not find node with given id (-32000)")
+	calls := 0
+	// Test.
+	err := h.Do(context.Background(), func(context.Context, *ElementHandle) error {
+		calls++
+		return stale
+	})
+	if err != stale || calls != 1 {
+		t.Errorf("Do() = %v after %d calls, want %v after 1 call", err, calls, stale)
+	}
+}
+
+func TestElementHandleNodeID(t *testing.T) {
+	// Set up: node IDs are valid only after a "DOM.getDocument"
+	// command, and each one invalidates the previous node IDs.
+	ctx, b := devtoolstest.NewContext(context.Background())
+	documents := 0
+	b.Handle("DOM.getDocument", func(json.RawMessage) (interface{}, error) {
+		documents++
+		return map[string]interface{}{"root": map[string]int64{"nodeId": int64(documents * 100)}}, nil
+	})
+	b.Handle("DOM.pushNodesByBackendIdsToFrontend", func(params json.RawMessage) (interface{}, error) {
+		if documents == 0 {
+			return nil, &devtools.Error{Code: -32000, Message: "Document needs to be requested first"}
+		}
+		p := &PushNodesByBackendIdsToFrontend{}
+		if err := json.Unmarshal(params, p); err != nil {
+			return nil, err
+		}
+		return map[string][]int64{"nodeIds": {int64(documents*100) + p.BackendNodeIds[0]}}, nil
+	})
+	h1, h2 := HandleFromBackendNodeID(1), HandleFromBackendNodeID(2)
+	// Test.
+	for i, tt := range []struct {
+		h    *ElementHandle
+		want int64
+	}{{h1, 101}, {h2, 102}, {h1, 101}} {
+		got, err := tt.h.NodeID(ctx)
+		if err != nil {
+			t.Fatalf("NodeID() #%d; got error: %v", i, err)
+		}
+		if got != tt.want {
+			t.Errorf("NodeID() #%d = %d, want %d", i, got, tt.want)
+		}
+	}
+	if documents != 1 {
+		t.Errorf("sent %d DOM.getDocument commands, want 1", documents)
+	}
+	// Another component requests the document again.
+	NewGetDocument().Do(ctx)
+	if got, err := h1.NodeID(ctx); err != nil || got != 201 {
+		t.Errorf("NodeID() after DOM.getDocument = %d, %v; want 201", got, err)
+	}
+}
+
+func TestQueryAll(t *testing.T) {
+	// Set up.
+	ctx, b := devtoolstest.NewContext(context.Background())
+	b.Respond("Runtime.evaluate", map[string]interface{}{"result": map[string]string{"type": "object", "objectId": "doc"}})
+	b.Handle("DOM.describeNode", func(params json.RawMessage) (interface{}, error) {
+		d := &DescribeNode{}
+		if err := json.Unmarshal(params, d); err != nil {
+			return nil, err
+		}
+		backendNodeID := int64(1) // The document.
+		if d.NodeID != 0 {
+			backendNodeID = d.NodeID * 10
+		}
+		return map[string]interface{}{"node": map[string]int64{"backendNodeId": backendNodeID}}, nil
+	})
+	b.Respond("DOM.pushNodesByBackendIdsToFrontend", map[string][]int64{"nodeIds": {5}})
+	b.Respond("DOM.querySelectorAll", map[string][]int64{"nodeIds": {6, 7}})
+	// Test.
+	hs, err := QueryAll(ctx, "li")
+	if err != nil {
+		t.Fatalf("QueryAll(); got error: %v", err)
+	}
+	if len(hs) != 2 {
+		t.Fatalf("QueryAll() = %d handles, want 2", len(hs))
+	}
+	for i, want := range []int64{60, 70} {
+		if hs[i].backendNodeID != want || hs[i].nodeID != 0 {
+			t.Errorf("QueryAll()[%d] = %+v, want backend node ID %d", i, hs[i], want)
+		}
+	}
+	if n := len(b.Calls("DOM.getDocument")); n != 0 {
+		t.Errorf("QueryAll() sent %d DOM.getDocument commands, want 0", n)
+	}
+}
@@ -0,0 +1,253 @@
+package dom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// Return the cells of all the rows in a table, with their spans.
+const extractTableCells = `((selector) => {
+	const table = document.querySelector(selector);
+	if (!table) {
+		throw new Error("no element matches the selector " + selector);
+	}
+	return Array.from(table.rows || []).map((row) => Array.from(row.cells).map((cell) => ({
+		text: cell.innerText.trim(),
+		colspan: cell.colSpan || 1,
+		rowspan: cell.rowSpan || 1,
+	})));
+})(%s)`
+
+type tableCell struct {
+	Text    string `json:"text"`
+	Colspan int    `json:"colspan"`
+	Rowspan int    `json:"rowspan"`
+}
+
+// ExtractTable returns the text of all the cells in the first HTML table
+// which matches the given CSS selector, in the main frame of the page
+// associated with the given context, as a grid: cells which span
+// multiple columns and/or rows are repeated in each of them, so
+// all the rows have the same number of columns.
+func ExtractTable(ctx context.Context, selector string) ([][]string, error) {
+	s, err := json.Marshal(selector)
+	if err != nil {
+		return nil, err
+	}
+	var rows [][]tableCell
+	if err := runtime.Eval(ctx, fmt.Sprintf(extractTableCells, s), &rows); err != nil {
+		return nil, err
+	}
+	return normalizeTable(rows), nil
+}
+
+// Expand cells which span multiple columns and/or rows into a rectangular grid.
+func normalizeTable(rows [][]tableCell) [][]string {
+	var grid [][]string
+	// Cells which span into the following rows: row -> column -> text.
+	pending := make(map[int]map[int]string)
+	width := 0
+	for r := 0; r < len(rows) || len(pending[r]) > 0; r++ {
+		var row []string
+		c := 0
+		fill := func() {
+			for {
+				text, ok := pending[r][c]
+				if !ok {
+					return
+				}
+				row = append(row, text)
+				c++
+			}
+		}
+		if r < len(rows) {
+			for _, cell := range rows[r] {
+				fill()
+				colspan := cell.Colspan
+				if colspan < 1 {
+					colspan = 1
+				}
+				for i := 0; i < colspan; i++ {
+					row = append(row, cell.Text)
+					for j := 1; j < cell.Rowspan; j++ {
+						if pending[r+j] == nil {
+							pending[r+j] = make(map[int]string)
+						}
+						pending[r+j][c] = cell.Text
+					}
+					c++
+				}
+			}
+		}
+		fill()
+		delete(pending, r)
+		if len(row) > width {
+			width = len(row)
+		}
+		grid = append(grid, row)
+	}
+	// Pad short rows.
+	for i, row := range grid {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		grid[i] = row
+	}
+	return grid
+}
+
+// Return the values of the given fields in all the items which match a selector.
+const extractListItems = `((selector, fields) => {
+	return Array.from(document.querySelectorAll(selector)).map((item) => fields.map((f) => {
+		const e = f.selector ? item.querySelector(f.selector) : item;
+		if (!e) {
+			return null;
+		}
+		if (f.attr) {
+			return e.getAttribute(f.attr);
+		}
+		return e.innerText.trim();
+	}));
+})(%s, %s)`
+
+type listField struct {
+	index    int
+	Selector string `json:"selector"`
+	Attr     string `json:"attr,omitempty"`
+}
+
+// ExtractList extracts all the items which match the given CSS selector, in
+// the main frame of the page associated with the given context, into v, which
+// must be a pointer to a slice of structs. The struct fields to extract
+// are specified with `dom` tags, which contain a CSS selector relative to
+// each item, and optionally the name of an attribute to extract instead of
+// the element's text. An empty selector refers to the item itself. Example:
+//
+//	type Product struct {
+//		ID    string  `dom:",attr=data-id"`
+//		Name  string  `dom:"h2"`
+//		URL   string  `dom:"a,attr=href"`
+//		Price float64 `dom:".price"`
+//	}
+//	var products []Product
+//	err := dom.ExtractList(ctx, "li.product", &products)
+//
+// Supported field types are strings, booleans, integers and floats. Fields
+// whose elements or attributes are missing are left with their zero values.
+func ExtractList(ctx context.Context, selector string, v interface{}) error {
+	t, fields, err := parseListFields(v)
+	if err != nil {
+		return err
+	}
+	s, err := json.Marshal(selector)
+	if err != nil {
+		return err
+	}
+	f, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	var items [][]*string
+	if err := runtime.Eval(ctx, fmt.Sprintf(extractListItems, s, f), &items); err != nil {
+		return err
+	}
+	return fillList(reflect.ValueOf(v).Elem(), t, fields, items)
+}
+
+// Parse the `dom` tags of the struct type which is
+// the element type of the slice that v points to.
+func parseListFields(v interface{}) (reflect.Type, []listField, error) {
+	p := reflect.TypeOf(v)
+	if p == nil || p.Kind() != reflect.Ptr || p.Elem().Kind() != reflect.Slice || p.Elem().Elem().Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("invalid type %v: must be a pointer to a slice of structs", p)
+	}
+	t := p.Elem().Elem()
+	var fields []listField
+	for i := 0; i < t.NumField(); i++ {
+		tag, ok := t.Field(i).Tag.Lookup("dom")
+		if !ok || t.Field(i).PkgPath != "" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		f := listField{index: i, Selector: strings.TrimSpace(parts[0])}
+		for _, opt := range parts[1:] {
+			opt = strings.TrimSpace(opt)
+			if !strings.HasPrefix(opt, "attr=") {
+				return nil, nil, fmt.Errorf("invalid option %q in tag of field %s", opt, t.Field(i).Name)
+			}
+			f.Attr = strings.TrimPrefix(opt, "attr=")
+		}
+		fields = append(fields, f)
+	}
+	return t, fields, nil
+}
+
+// Set the slice to the extracted items.
+func fillList(slice reflect.Value, t reflect.Type, fields []listField, items [][]*string) error {
+	result := reflect.MakeSlice(slice.Type(), 0, len(items))
+	for i, values := range items {
+		item := reflect.New(t).Elem()
+		for j, f := range fields {
+			if j >= len(values) || values[j] == nil {
+				continue
+			}
+			if err := setField(item.Field(f.index), *values[j]); err != nil {
+				return fmt.Errorf("item %d, field %s: %v", i, t.Field(f.index).Name, err)
+			}
+		}
+		result = reflect.Append(result, item)
+	}
+	slice.Set(result)
+	return nil
+}
+
+func setField(f reflect.Value, s string) error {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(cleanNumber(s), 10, 64)
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(cleanNumber(s), 10, 64)
+		if err != nil {
+			return err
+		}
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(cleanNumber(s), 64)
+		if err != nil {
+			return err
+		}
+		f.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported type %v", f.Type())
+	}
+	return nil
+}
+
+// Remove characters which commonly surround or separate numbers
+// in human-readable text, e.g. currency symbols and thousands separators.
+func cleanNumber(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '+' {
+			return r
+		}
+		return -1
+	}, s)
+}
@@ -0,0 +1,74 @@
+package dom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTable(t *testing.T) {
+	// Set up.
+	// +---+-------+
+	// | a |   b   |
+	// +---+---+---+
+	// |   | c | d |
+	// | e +---+---+
+	// |   | f |
+	// +---+---+
+	rows := [][]tableCell{
+		{{Text: "a", Colspan: 1, Rowspan: 1}, {Text: "b", Colspan: 2, Rowspan: 1}},
+		{{Text: "e", Colspan: 1, Rowspan: 2}, {Text: "c", Colspan: 1, Rowspan: 1}, {Text: "d", Colspan: 1, Rowspan: 1}},
+		{{Text: "f", Colspan: 1, Rowspan: 1}},
+	}
+	// Test.
+	want := [][]string{
+		{"a", "b", "b"},
+		{"e", "c", "d"},
+		{"e", "f", ""},
+	}
+	if got := normalizeTable(rows); !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeTable() = %q, want %q", got, want)
+	}
+}
+
+type product struct {
+	ID      string  `dom:",attr=data-id"`
+	Name    string  `dom:"h2"`
+	Price   float64 `dom:".price"`
+	Stock   int     `dom:".stock"`
+	Ignored string
+}
+
+func TestExtractListFields(t *testing.T) {
+	// Set up.
+	var products []product
+	typ, fields, err := parseListFields(&products)
+	if err != nil {
+		t.Fatalf("parseListFields() error: %v", err)
+	}
+	wantFields := []listField{{0, "", "data-id"}, {1, "h2", ""}, {2, ".price", ""}, {3, ".stock", ""}}
+	if !reflect.DeepEqual(fields, wantFields) {
+		t.Errorf("parseListFields() = %+v, want %+v", fields, wantFields)
+	}
+	str := func(s string) *string { return &s }
+	items := [][]*string{
+		{str("1"), str("Widget"), str("$1,234.50"), str("12 left")},
+		{str("2"), str("Gadget"), nil, nil},
+	}
+	// Test.
+	if err := fillList(reflect.ValueOf(&products).Elem(), typ, fields, items); err != nil {
+		t.Fatalf("fillList() error: %v", err)
+	}
+	want := []product{{"1", "Widget", 1234.5, 12, ""}, {"2", "Gadget", 0, 0, ""}}
+	if !reflect.DeepEqual(products, want) {
+		t.Errorf("fillList() = %+v, want %+v", products, want)
+	}
+}
+
+func TestExtractListInvalidType(t *testing.T) {
+	var p product
+	for _, v := range []interface{}{nil, p, &p, &[]string{}} {
+		if _, _, err := parseListFields(v); err == nil {
+			t.Errorf("parseListFields(%T) error = nil, want an error", v)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package dom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// SelectorEngine resolves selectors of a specific syntax into DOM nodes.
+// All the selector-based helpers in this package (e.g. `dom.Query`) choose
+// the engine according to the selector's prefix, e.g. "xpath=//button" or
+// "text=Sign in", see the `dom.RegisterSelectorEngine` function.
+type SelectorEngine interface {
+	// Query returns the node IDs of all the nodes which match the given
+	// selector (without the engine's prefix), within the node with the
+	// given ID (e.g. the document's root node), in document order.
+	Query(ctx context.Context, root int64, selector string) ([]int64, error)
+}
+
+// SelectorEngineFunc is an adapter to allow the use of ordinary
+// functions as selector engines.
+type SelectorEngineFunc func(ctx context.Context, root int64, selector string) ([]int64, error)
+
+// Query satisfies the `dom.SelectorEngine` interface.
+func (f SelectorEngineFunc) Query(ctx context.Context, root int64, selector string) ([]int64, error) {
+	return f(ctx, root, selector)
+}
+
+// Built-in selector engines. CSS is the default engine for selectors
+// without a prefix.
+var (
+	// CSSEngine resolves CSS selectors, with the prefix "css=".
+	CSSEngine SelectorEngine = SelectorEngineFunc(queryCSS)
+	// XPathEngine resolves XPath expressions, with the prefix "xpath=".
+	// Selectors which start with "//" or ".." use this engine too.
+	XPathEngine = JSSelectorEngine(queryXPath)
+	// TextEngine resolves elements by their rendered text, with the prefix
+	// "text=": the smallest elements whose text contains the selector,
+	// case-insensitively and ignoring whitespace differences. Selectors in
+	// double quotes (e.g. `text="Sign in"`) match the entire text exactly.
+	TextEngine = JSSelectorEngine(queryText)
+)
+
+// Find all the nodes which match an XPath expression, within `this` node.
+const queryXPath = `function(selector) {
+	const doc = this.ownerDocument || this;
+	const r = doc.evaluate(selector, this, null, XPathResult.ORDERED_NODE_SNAPSHOT_TYPE, null);
+	const nodes = [];
+	for (let i = 0; i < r.snapshotLength; i++) {
+		nodes.push(r.snapshotItem(i));
+	}
+	return nodes;
+}`
+
+// Find the smallest elements whose text matches the selector, within `this` node.
+const queryText = `function(selector) {
+	const normalize = (s) => s.replace(/\s+/g, " ").trim();
+	let exact = false;
+	let text = selector;
+	if (text.length > 1 && text.startsWith('"') && text.endsWith('"')) {
+		exact = true;
+		text = text.slice(1, -1);
+	}
+	text = normalize(text);
+	const lower = text.toLowerCase();
+	const matches = (e) => {
+		if (["SCRIPT", "STYLE", "NOSCRIPT", "TEMPLATE", "HEAD"].includes(e.tagName)) {
+			return false;
+		}
+		const t = normalize(e.innerText || e.textContent || "");
+		return exact ? t === text : t.toLowerCase().includes(lower);
+	};
+	const all = Array.from(this.querySelectorAll("*"));
+	if (this.nodeType === Node.ELEMENT_NODE) {
+		all.unshift(this);
+	}
+	const matched = all.filter(matches);
+	// Elements are in document order, so descendants follow their ancestors
+	// immediately: keep only elements which aren't followed by a descendant.
+	return matched.filter((e, i) => i + 1 === matched.length || !e.contains(matched[i + 1]));
+}`
+
+// Valid engine names, which may be used as selector prefixes.
+var engineName = regexp.MustCompile(`^[A-Za-z][\w-]*$`)
+
+var (
+	enginesMu sync.RWMutex
+	engines   = map[string]SelectorEngine{
+		"css":   CSSEngine,
+		"xpath": XPathEngine,
+		"text":  TextEngine,
+	}
+)
+
+// RegisterSelectorEngine registers a custom selector engine, for selectors
+// with the prefix "<name>=", e.g. to resolve test IDs with the selector
+// "data-testid=submit":
+//
+//	dom.RegisterSelectorEngine("data-testid", dom.SelectorEngineFunc(
+//		func(ctx context.Context, root int64, selector string) ([]int64, error) {
+//			return dom.CSSEngine.Query(ctx, root, fmt.Sprintf("[data-testid=%q]", selector))
+//		}))
+//
+// Engines are shared by all the sessions in this process. It fails if the
+// name is invalid, or if an engine with the same name is already registered.
+func RegisterSelectorEngine(name string, e SelectorEngine) error {
+	if !engineName.MatchString(name) {
+		return fmt.Errorf("invalid selector engine name %q", name)
+	}
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+	if _, ok := engines[name]; ok {
+		return fmt.Errorf("selector engine %q is already registered", name)
+	}
+	engines[name] = e
+	return nil
+}
+
+// Return the name of the engine of the given selector (which is
+// registered), and the selector without its prefix.
+func parseSelector(selector string) (string, string) {
+	if strings.HasPrefix(selector, "//") || strings.HasPrefix(selector, "..") {
+		return "xpath", selector
+	}
+	if i := strings.Index(selector, "="); i > 0 && engineName.MatchString(selector[:i]) {
+		enginesMu.RLock()
+		_, ok := engines[selector[:i]]
+		enginesMu.RUnlock()
+		if ok {
+			return selector[:i], selector[i+1:]
+		}
+	}
+	return "css", selector
+}
+
+// QueryNodes returns the node IDs of all the nodes which match the given
+// selector, within the node with the given ID, using the selector engine
+// which matches the selector's prefix (CSS by default).
+func QueryNodes(ctx context.Context, root int64, selector string) ([]int64, error) {
+	name, s := parseSelector(selector)
+	enginesMu.RLock()
+	e := engines[name]
+	enginesMu.RUnlock()
+	return e.Query(ctx, root, s)
+}
+
+func queryCSS(ctx context.Context, root int64, selector string) ([]int64, error) {
+	r, err := NewQuerySelectorAll(root, selector).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.NodeIds, nil
+}
+
+// JSSelectorEngine returns a selector engine which is implemented by the
+// given JavaScript function declaration. The function is called with the
+// root node as `this`, and the selector as its only argument, and must
+// return an array of nodes, e.g.:
+//
+//	function(selector) {
+//		return Array.from(this.querySelectorAll(`[aria-label="${selector}"]`));
+//	}
+func JSSelectorEngine(function string) SelectorEngine {
+	return SelectorEngineFunc(func(ctx context.Context, root int64, selector string) ([]int64, error) {
+		return queryJS(ctx, root, function, selector)
+	})
+}
+
+type executionContextKey struct{}
+
+// InExecutionContext returns a copy of the given context, which makes
+// JavaScript-based selector engines (see `dom.JSSelectorEngine`) run in
+// the execution context with the given ID, instead of the main world of
+// the root node's frame. This is useful with isolated worlds (see
+// `page.IsolatedWorld`), so page scripts can't interfere with queries,
+// e.g. by overriding `document.evaluate` or `Array.from`.
+func InExecutionContext(ctx context.Context, id runtime.ExecutionContextID) context.Context {
+	return context.WithValue(ctx, executionContextKey{}, id)
+}
+
+func queryJS(ctx context.Context, root int64, function, selector string) ([]int64, error) {
+	rn := NewResolveNode().SetNodeID(root)
+	if id, ok := ctx.Value(executionContextKey{}).(runtime.ExecutionContextID); ok {
+		rn.SetExecutionContextID(id)
+	}
+	r, err := rn.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer runtime.NewReleaseObject(r.Object.ObjectID).Do(ctx)
+	s, err := json.Marshal(selector)
+	if err != nil {
+		return nil, err
+	}
+	args := []runtime.CallArgument{{Value: s}}
+	c, err := runtime.NewCallFunctionOn(function).SetObjectID(r.Object.ObjectID).SetArguments(args).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c.ExceptionDetails != nil {
+		return nil, c.ExceptionDetails
+	}
+	if c.Result.ObjectID == "" {
+		return nil, fmt.Errorf("selector engine returned %s instead of an array", c.Result.Type)
+	}
+	defer runtime.NewReleaseObject(c.Result.ObjectID).Do(ctx)
+
+	props, err := runtime.NewGetProperties(c.Result.ObjectID).SetOwnProperties(true).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	type item struct {
+		index  int
+		object string
+	}
+	var items []item
+	for _, p := range props.Result {
+		i, err := strconv.Atoi(p.Name)
+		if err != nil || p.Value == nil || p.Value.ObjectID == "" {
+			continue // Not an array element, or not a node.
+		}
+		items = append(items, item{i, p.Value.ObjectID})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].index < items[j].index })
+	ids := make([]int64, 0, len(items))
+	for _, it := range items {
+		n, err := NewRequestNode(runtime.RemoteObjectID(it.object)).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, n.NodeID)
+	}
+	return ids, nil
+}
+
+// Return a unique CSS selector of `this` node (or its parent element, if it's
+// not an element), within its document or shadow root. Stable attributes
+// (IDs which don't look generated, test IDs, names and ARIA labels) are
+// preferred over structural selectors, and classes are ignored because
+// they tend to change with the page's styling.
+const uniqueSelector = `function() {
+	const e = this.nodeType === Node.ELEMENT_NODE ? this : this.parentElement;
+	if (!e) {
+		throw new Error("node is not an element, and has no parent element");
+	}
+	const root = e.getRootNode();
+	const unique = (s) => {
+		try {
+			const all = root.querySelectorAll(s);
+			return all.length === 1 && all[0] === e;
+		} catch (err) {
+			return false;
+		}
+	};
+	const stableID = (id) => id && !/\d{3,}|:/.test(id);
+	const attrs = ["data-testid", "data-test-id", "data-test", "data-qa", "data-cy", "name", "aria-label"];
+	// Return the most specific stable selector of a single element,
+	// or an empty string if it doesn't have one.
+	const stable = (n) => {
+		if (stableID(n.id)) {
+			return "#" + CSS.escape(n.id);
+		}
+		for (const a of attrs) {
+			const v = n.getAttribute(a);
+			if (v) {
+				return n.localName + "[" + a + '="' + CSS.escape(v) + '"]';
+			}
+		}
+		return "";
+	};
+
+	// Build a structural path upwards, until it's unique by itself or
+	// anchored at an element with a stable selector.
+	let path = "";
+	for (let n = e; n; n = n.parentElement) {
+		const s = stable(n);
+		if (s && unique(path ? s + " > " + path : s)) {
+			return path ? s + " > " + path : s;
+		}
+		let step = n.localName;
+		const same = Array.from(n.parentNode.children).filter((c) => c.localName === n.localName);
+		if (same.length > 1) {
+			step += ":nth-of-type(" + (same.indexOf(n) + 1) + ")";
+		}
+		path = path ? step + " > " + path : step;
+		if (unique(path)) {
+			return path;
+		}
+	}
+	return path;
+}`
+
+// SelectorFor returns a unique CSS selector of the node with the given ID (or
+// its parent element, if it's not an element), e.g. for logging elements,
+// generating code, or re-querying stale elements. The selector prefers
+// stable attributes, i.e. IDs which don't look generated, test IDs (e.g.
+// "data-testid"), names and ARIA labels, over the element's position in
+// the document, and ignores classes. It's unique within the element's
+// document, or shadow root if the element is in a shadow tree.
+func SelectorFor(ctx context.Context, nodeID int64) (string, error) {
+	r, err := NewResolveNode().SetNodeID(nodeID).Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer runtime.NewReleaseObject(r.Object.ObjectID).Do(ctx)
+	var s string
+	if err := callFunction(ctx, runtime.RemoteObjectID(r.Object.ObjectID), uniqueSelector, &s); err != nil {
+		return "", err
+	}
+	return s, nil
+}
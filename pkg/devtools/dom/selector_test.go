@@ -0,0 +1,301 @@
+package dom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseSelector(t *testing.T) {
+	testID := SelectorEngineFunc(func(context.Context, int64, string) ([]int64, error) { return nil, nil })
+	if err := RegisterSelectorEngine("test-id", testID); err != nil {
+		t.Fatalf("RegisterSelectorEngine(); got error: %v", err)
+	}
+	if err := RegisterSelectorEngine("test-id", testID); err == nil {
+		t.Error("RegisterSelectorEngine() with duplicate name; got nil error")
+	}
+	if err := RegisterSelectorEngine("a[b]", testID); err == nil {
+		t.Error("RegisterSelectorEngine() with invalid name; got nil error")
+	}
+
+	tests := []struct {
+		selector, engine, want string
+	}{
+		{"div.item > a", "css", "div.item > a"},
+		{`a[href="x=y"]`, "css", `a[href="x=y"]`},
+		{"css=#main", "css", "#main"},
+		{"xpath=//button[1]", "xpath", "//button[1]"},
+		{"//button", "xpath", "//button"},
+		{"text=Sign in", "text", "Sign in"},
+		{"test-id=submit", "test-id", "submit"},
+		{"unknown=x", "css", "unknown=x"},
+	}
+	for _, test := range tests {
+		engine, got := parseSelector(test.selector)
+		if engine != test.engine || got != test.want {
+			t.Errorf("parseSelector(%q) = %q, %q; want %q, %q", test.selector, engine, got, test.engine, test.want)
+		}
+	}
+}
+
+func TestSelectorFor(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  *runtime.CallFunctionOnResult
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "selector",
+			result: &runtime.CallFunctionOnResult{Result: runtime.RemoteObject{Type: "string", Value: json.RawMessage(`"#main > li:nth-of-type(2)"`)}},
+			want:   "#main > li:nth-of-type(2)",
+		},
+		{
+			name:    "exception",
+			result:  &runtime.CallFunctionOnResult{ExceptionDetails: &runtime.ExceptionDetails{Text: "Uncaught"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+			b.Respond("DOM.resolveNode", &ResolveNodeResult{Object: runtime.RemoteObject{ObjectID: "obj"}})
+			b.Respond("Runtime.callFunctionOn", tt.result)
+
+			got, err := SelectorFor(ctx, 5)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SelectorFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("SelectorFor() = %q, want %q", got, tt.want)
+			}
+
+			var methods []string
+			for _, c := range b.Calls() {
+				methods = append(methods, c.Method)
+			}
+			want := []string{"DOM.resolveNode", "Runtime.callFunctionOn", "Runtime.releaseObject"}
+			if diff := cmp.Diff(want, methods); diff != "" {
+				t.Errorf("SelectorFor() commands mismatch (-want +got):\n%s", diff)
+			}
+			c := &runtime.CallFunctionOn{}
+			if err := json.Unmarshal(b.Calls("Runtime.callFunctionOn")[0].Params, c); err != nil {
+				t.Fatal(err)
+			}
+			if c.FunctionDeclaration != uniqueSelector || c.ObjectID != "obj" || !c.ReturnByValue {
+				t.Errorf("SelectorFor() sent Runtime.callFunctionOn %+v", c)
+			}
+		})
+	}
+}
+
+// A minimal DOM implementation for Node.js, which supports only what the
+// `uniqueSelector` function uses, including the selectors it generates.
+const fakeDOM = `
+const Node = {ELEMENT_NODE: 1, TEXT_NODE: 3, DOCUMENT_NODE: 9};
+const CSS = {escape: (s) => s.replace(/[^\w-]/g, (c) => "\\" + c)};
+
+function build(spec, parent, doc) {
+	if (typeof spec === "string") {
+		return {nodeType: Node.TEXT_NODE, parentNode: parent, parentElement: parent};
+	}
+	const e = {
+		nodeType: Node.ELEMENT_NODE,
+		localName: spec.tag,
+		id: (spec.attrs || {}).id || "",
+		attrs: spec.attrs || {},
+		parentNode: parent,
+		parentElement: parent.nodeType === Node.ELEMENT_NODE ? parent : null,
+		getAttribute(a) { return a in this.attrs ? this.attrs[a] : null; },
+		getRootNode() { return doc; },
+	};
+	e.childNodes = (spec.children || []).map((c) => build(c, e, doc));
+	e.children = e.childNodes.filter((c) => c.nodeType === Node.ELEMENT_NODE);
+	if (spec.target) {
+		doc.target = e;
+	}
+	e.childNodes.forEach((c, i) => {
+		if ((spec.children || [])[i] === spec.targetText) {
+			doc.target = c;
+		}
+	});
+	return e;
+}
+
+// Match a single compound selector, e.g. "#id", "a[name=\"x\"]", "li:nth-of-type(2)".
+function matchStep(e, step) {
+	let m;
+	if ((m = /^#(.+)$/.exec(step))) {
+		return e.id === m[1];
+	}
+	if ((m = /^([\w-]+)\[([\w-]+)="(.*)"\]$/.exec(step))) {
+		return e.localName === m[1] && e.getAttribute(m[2]) === m[3];
+	}
+	if ((m = /^([\w-]+)(?::nth-of-type\((\d+)\))?$/.exec(step))) {
+		if (e.localName !== m[1]) {
+			return false;
+		}
+		const same = e.parentNode.children.filter((c) => c.localName === e.localName);
+		return !m[2] || same.indexOf(e) + 1 === Number(m[2]);
+	}
+	throw new Error("unsupported selector: " + step);
+}
+
+function matches(e, steps) {
+	for (let i = steps.length - 1; i >= 0; i--) {
+		if (!e || !matchStep(e, steps[i])) {
+			return false;
+		}
+		e = e.parentElement;
+	}
+	return true;
+}
+
+function newDocument(spec) {
+	const doc = {nodeType: Node.DOCUMENT_NODE, parentElement: null};
+	doc.children = doc.childNodes = [build(spec, doc, doc)];
+	doc.querySelectorAll = (s) => {
+		const steps = s.split(" > ");
+		const all = [];
+		const walk = (e) => {
+			if (matches(e, steps)) {
+				all.push(e);
+			}
+			e.children.forEach(walk);
+		};
+		walk(doc.children[0]);
+		return all;
+	};
+	return doc;
+}
+`
+
+func TestUniqueSelector(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("Node.js isn't installed")
+	}
+	li := func(attrs map[string]string, children ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"tag": "li", "attrs": attrs, "children": children}
+	}
+	target := func(e map[string]interface{}) map[string]interface{} {
+		e["target"] = true
+		return e
+	}
+	tests := []struct {
+		name     string
+		body     []interface{} // Children of the document's body.
+		detached bool          // Use a detached text node instead of the body's target.
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "stable_id",
+			body: []interface{}{target(map[string]interface{}{"tag": "div", "attrs": map[string]string{"id": "main"}})},
+			want: "#main",
+		},
+		{
+			name: "generated_id",
+			body: []interface{}{
+				map[string]interface{}{"tag": "div"},
+				target(map[string]interface{}{"tag": "p", "attrs": map[string]string{"id": "ember1234"}}),
+			},
+			want: "p",
+		},
+		{
+			name: "test_id",
+			body: []interface{}{
+				map[string]interface{}{"tag": "button"},
+				target(map[string]interface{}{"tag": "button", "attrs": map[string]string{"data-testid": "submit", "class": "btn"}}),
+			},
+			want: `button[data-testid="submit"]`,
+		},
+		{
+			name: "position",
+			body: []interface{}{
+				map[string]interface{}{"tag": "ul", "children": []interface{}{li(nil), target(li(nil)), li(nil)}},
+			},
+			want: "li:nth-of-type(2)",
+		},
+		{
+			name: "anchored_at_stable_ancestor",
+			body: []interface{}{
+				map[string]interface{}{"tag": "ul", "attrs": map[string]string{"id": "a"}, "children": []interface{}{li(nil), li(nil)}},
+				map[string]interface{}{"tag": "ul", "attrs": map[string]string{"id": "b"}, "children": []interface{}{li(nil), target(li(nil))}},
+			},
+			want: "#b > li:nth-of-type(2)",
+		},
+		{
+			name: "duplicate_name",
+			body: []interface{}{
+				map[string]interface{}{"tag": "form", "attrs": map[string]string{"id": "f1"}, "children": []interface{}{
+					map[string]interface{}{"tag": "input", "attrs": map[string]string{"name": "q"}},
+				}},
+				map[string]interface{}{"tag": "form", "attrs": map[string]string{"id": "f2"}, "children": []interface{}{
+					target(map[string]interface{}{"tag": "input", "attrs": map[string]string{"name": "q"}}),
+				}},
+			},
+			want: "#f2 > input",
+		},
+		{
+			name: "text_node",
+			body: []interface{}{
+				map[string]interface{}{"tag": "span", "attrs": map[string]string{"id": "x"}, "children": []interface{}{"text"}, "targetText": "text"},
+			},
+			want: "#x",
+		},
+		{
+			name:     "detached_text_node",
+			detached: true,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := map[string]interface{}{"tag": "html", "children": []interface{}{
+				map[string]interface{}{"tag": "body", "children": tt.body},
+			}}
+			spec, err := json.Marshal(doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			script := fakeDOM + `
+const doc = newDocument(` + string(spec) + `);
+const target = ` + fmt.Sprint(tt.detached) + ` ? {nodeType: Node.TEXT_NODE, parentElement: null} : doc.target;
+const s = (` + uniqueSelector + `).call(target);
+const e = target.nodeType === Node.ELEMENT_NODE ? target : target.parentElement;
+const all = doc.querySelectorAll(s);
+if (all.length !== 1 || all[0] !== e) {
+	throw new Error("selector " + s + " matches " + all.length + " elements");
+}
+console.log(JSON.stringify(s));
+`
+			out, err := exec.Command(node, "-e", script).CombinedOutput()
+			if tt.wantErr {
+				if err == nil || !strings.Contains(string(out), "node is not an element") {
+					t.Errorf("uniqueSelector() = %s, %v; want an exception", out, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("uniqueSelector(); got error: %v\n%s", err, out)
+			}
+			var got string
+			if err := json.Unmarshal(out, &got); err != nil {
+				t.Fatalf("uniqueSelector() output %q: %v", out, err)
+			}
+			if got != tt.want {
+				t.Errorf("uniqueSelector() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
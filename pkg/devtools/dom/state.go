@@ -0,0 +1,114 @@
+package dom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// Pseudo-classes which the `CSS.forcePseudoState` CDP command supports.
+var forcibleStates = map[string]bool{
+	"active": true, "focus": true, "focus-visible": true, "focus-within": true,
+	"hover": true, "target": true, "visited": true,
+}
+
+// Same as `css.ForcePseudoState`, which this package can't import,
+// because the css package depends on it.
+type forcePseudoState struct {
+	NodeID              int64    `json:"nodeId"`
+	ForcedPseudoClasses []string `json:"forcedPseudoClasses"`
+}
+
+// ForceState forces the given CSS pseudo-classes (e.g. ":hover", ":focus"
+// or ":active") on the element with the given node ID, with the
+// `css.ForcePseudoState` CDP command, so screenshot tests of interactive
+// styling don't depend on the mouse position or the window's focus. It
+// returns a function to restore the element's real state, which callers
+// should defer. Restoring more than once has no effect.
+//
+// Pseudo-classes are replaced, not added, by each call for the same element,
+// so multiple pseudo-classes should be forced together. This function
+// enables the DOM and CSS domains (see `devtools.EnableDomain`) until the
+// state is restored.
+func ForceState(ctx context.Context, nodeID int64, pseudoClasses ...string) (restore func() error, err error) {
+	params := &forcePseudoState{NodeID: nodeID}
+	for _, c := range pseudoClasses {
+		c = strings.TrimPrefix(strings.ToLower(c), ":")
+		if !forcibleStates[c] {
+			return nil, fmt.Errorf("unsupported pseudo-class: %q", c)
+		}
+		params.ForcedPseudoClasses = append(params.ForcedPseudoClasses, c)
+	}
+
+	// The CSS domain can't be enabled without the DOM domain.
+	releaseDOM, err := devtools.EnableDomain(ctx, "DOM")
+	if err != nil {
+		return nil, err
+	}
+	releaseCSS, err := devtools.EnableDomain(ctx, "CSS")
+	if err != nil {
+		releaseDOM()
+		return nil, err
+	}
+	release := func() error {
+		err := releaseCSS()
+		if err2 := releaseDOM(); err == nil {
+			err = err2
+		}
+		return err
+	}
+	if err := sendForcePseudoState(ctx, params); err != nil {
+		release()
+		return nil, err
+	}
+
+	var once sync.Once
+	var restoreErr error
+	return func() error {
+		once.Do(func() {
+			params.ForcedPseudoClasses = []string{}
+			restoreErr = sendForcePseudoState(ctx, params)
+			if IsStaleNodeError(restoreErr) {
+				restoreErr = nil // The element no longer exists, so there's nothing to restore.
+			}
+			if err := release(); restoreErr == nil {
+				restoreErr = err
+			}
+		})
+		return restoreErr
+	}, nil
+}
+
+// ForceState forces the given CSS pseudo-classes on the element,
+// see the `dom.ForceState` function, and the `ElementHandle.Do` method.
+func (h *ElementHandle) ForceState(ctx context.Context, pseudoClasses ...string) (restore func() error, err error) {
+	err = h.Do(ctx, func(ctx context.Context, h *ElementHandle) error {
+		nodeID, err := h.NodeID(ctx)
+		if err != nil {
+			return err
+		}
+		restore, err = ForceState(ctx, nodeID, pseudoClasses...)
+		return err
+	})
+	return restore, err
+}
+
+func sendForcePseudoState(ctx context.Context, params *forcePseudoState) error {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	m, err := devtools.SendAndWait(ctx, "CSS.forcePseudoState", b)
+	if err != nil {
+		return err
+	}
+	if m.Error != nil {
+		return errors.New(m.Error.Error())
+	}
+	return nil
+}
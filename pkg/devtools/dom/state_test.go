@@ -0,0 +1,43 @@
+package dom
+
+import (
+	"context"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestForceState(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	if _, err := ForceState(ctx, 1, ":hover", ":checked"); err == nil {
+		t.Error("ForceState(:checked); got nil error")
+	}
+
+	restore, err := ForceState(ctx, 1, ":hover", "FOCUS")
+	if err != nil {
+		t.Fatalf("ForceState(); got error: %v", err)
+	}
+	if err := restore(); err != nil {
+		t.Errorf("restore(); got error: %v", err)
+	}
+	if err := restore(); err != nil {
+		t.Errorf("second restore(); got error: %v", err)
+	}
+
+	var got []string
+	for _, c := range b.Calls() {
+		got = append(got, c.Method+" "+string(c.Params))
+	}
+	want := []string{
+		"DOM.enable ",
+		"CSS.enable ",
+		`CSS.forcePseudoState {"nodeId":1,"forcedPseudoClasses":["hover","focus"]}`,
+		`CSS.forcePseudoState {"nodeId":1,"forcedPseudoClasses":[]}`,
+		"CSS.disable ",
+		"DOM.disable ",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ForceState() commands mismatch (-want +got):\n%s", diff)
+	}
+}
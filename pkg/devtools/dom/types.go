@@ -177,7 +177,7 @@ type RGBA struct {
 	// The blue component, in the [0-255] range.
 	B int64 `json:"b"`
 	// The alpha component, in the [0-1] range (default: 1).
-	A float64 `json:"a,omitempty"`
+	A *float64 `json:"a,omitempty"`
 }
 
 // Quad data type. An array of quad vertices, x immediately followed by y for each point, points clock-wise.
@@ -0,0 +1,134 @@
+package devtools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Maximum time to wait for the response to a "disable" command, which is
+// sent even if the context of the component which releases it is done.
+var releaseTimeout = 10 * time.Second
+
+// Reference counts of the CDP domains which are enabled with the
+// `devtools.EnableDomain` function (and other optional event streams, see
+// `devtools.SubscribeEvents`), for all the sessions in a browser connection
+// (CDP domains are enabled per session, not per browser).
+type domainRegistry struct {
+	mu      sync.Mutex
+	domains map[string]*domainState // By "<session ID> <domain or feature>".
+}
+
+type domainState struct {
+	mu    sync.Mutex // Held while enabling or disabling the domain.
+	count int
+}
+
+func newDomainRegistry() *domainRegistry {
+	return &domainRegistry{domains: make(map[string]*domainState)}
+}
+
+func (r *domainRegistry) get(sessionID, name string) *domainState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := sessionID + " " + name
+	d, ok := r.domains[key]
+	if !ok {
+		d = &domainState{}
+		r.domains[key] = d
+	}
+	return d
+}
+
+// EnableDomain enables a CDP domain (e.g. "Page", "Network" or "Runtime")
+// in the session which is associated with the given context, unless it was
+// already enabled by a previous call to this function, and returns a function
+// to release this requirement. The domain is disabled only after all the
+// callers release it, so independent components can require the same domain
+// without breaking each other's event streams. Releasing more than once has
+// no effect.
+//
+// Components which use this function should not send the domain's
+// "enable" and "disable" commands directly (e.g. `page.Enable`), because
+// they're not counted. It supports only domains whose "enable" command
+// doesn't require any parameters.
+func EnableDomain(ctx context.Context, domain string) (release func() error, err error) {
+	return enableDomain(ctx, domain, nil)
+}
+
+// Like `devtools.EnableDomain`, with parameters for the domain's "enable"
+// command, which are used only if the domain isn't enabled yet.
+func enableDomain(ctx context.Context, domain string, params interface{}) (func() error, error) {
+	return acquire(ctx, domain, domain+".enable", params, domain+".disable", nil)
+}
+
+// Send the given "enable" command, unless the feature with the given key is
+// already enabled in the session which is associated with the given context,
+// and return a function to release it, which sends the given "disable"
+// command when the feature's reference count reaches 0.
+func acquire(ctx context.Context, key, enable string, enableParams interface{}, disable string, disableParams interface{}) (func() error, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return nil, errNotInitialized
+	}
+	d := s.domains.get(s.SessionID.Read(), key)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.count == 0 {
+		if err := sendAndParse(ctx, enable, enableParams, nil); err != nil {
+			return nil, fmt.Errorf("%q command error: %v", enable, err)
+		}
+	}
+	d.count++
+
+	// The caller's context may be done by the time of the release
+	// (e.g. when a subscription ends because of it).
+	detached := detachedContext{ctx}
+	var once sync.Once
+	return func() error {
+		var err error
+		once.Do(func() {
+			ctx, cancel := context.WithTimeout(detached, releaseTimeout)
+			defer cancel()
+			err = d.release(ctx, disable, disableParams)
+		})
+		return err
+	}, nil
+}
+
+// A context which carries the values of another one (e.g. its session), but
+// not its cancellation and deadline, like `context.WithoutCancel` in Go 1.21.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// Decrement the reference count, and send the
+// given "disable" command if it reaches 0.
+func (d *domainState) release(ctx context.Context, method string, params interface{}) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.count--
+	if d.count > 0 {
+		return nil
+	}
+	if err := sendAndParse(ctx, method, params, nil); err != nil {
+		return fmt.Errorf("%q command error: %v", method, err)
+	}
+	return nil
+}
+
+// Count the features which are enabled when a session starts, so releasing
+// them elsewhere (e.g. with `devtools.EnableDomain`) never disables them.
+func (r *domainRegistry) retain(sessionID string, keys ...string) {
+	for _, key := range keys {
+		d := r.get(sessionID, key)
+		d.mu.Lock()
+		d.count++
+		d.mu.Unlock()
+	}
+}
@@ -0,0 +1,64 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEnableDomain(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	ctx := NewFakeContext(context.Background(), func(ctx context.Context, method string, _ json.RawMessage) (*Message, error) {
+		if method == "Log.enable" {
+			return nil, errors.New("fake error")
+		}
+		s, _ := FromContext(ctx)
+		mu.Lock()
+		calls = append(calls, s.SessionID.Read()+" "+method)
+		mu.Unlock()
+		return &Message{Result: json.RawMessage(`{}`)}, nil
+	})
+	other, err := WithAttachedSession(ctx, "OTHER_TARGET_ID", "OTHER_SESSION_ID")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enable := func(ctx context.Context, domain string) func() error {
+		t.Helper()
+		release, err := EnableDomain(ctx, domain)
+		if err != nil {
+			t.Fatalf("EnableDomain(%q); got error: %v", domain, err)
+		}
+		return release
+	}
+	release1 := enable(ctx, "Page")
+	release2 := enable(ctx, "Page")
+	release3 := enable(other, "Page")
+	release4 := enable(ctx, "Network")
+	// The first release is counted only once.
+	release1()
+	release1()
+	release3()
+	release4()
+	release2()
+	if _, err := EnableDomain(ctx, "Log"); err == nil {
+		t.Error(`EnableDomain("Log"); got nil error`)
+	}
+
+	want := []string{
+		"FAKE_SESSION_ID Page.enable",
+		"OTHER_SESSION_ID Page.enable",
+		"FAKE_SESSION_ID Network.enable",
+		"OTHER_SESSION_ID Page.disable",
+		"FAKE_SESSION_ID Network.disable",
+		"FAKE_SESSION_ID Page.disable",
+	}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Errorf("EnableDomain() commands mismatch (-want +got):\n%s", diff)
+	}
+}
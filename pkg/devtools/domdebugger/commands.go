@@ -20,7 +20,7 @@ type GetEventListeners struct {
 	ObjectID runtime.RemoteObjectID `json:"objectId"`
 	// The maximum depth at which Node children should be retrieved, defaults to 1. Use -1 for the
 	// entire subtree or provide an integer larger than 0.
-	Depth int64 `json:"depth,omitempty"`
+	Depth *int64 `json:"depth,omitempty"`
 	// Whether or not iframes and shadow roots should be traversed when returning the subtree
 	// (default is false). Reports listeners for all contexts if pierce is enabled.
 	Pierce bool `json:"pierce,omitempty"`
@@ -28,25 +28,43 @@ type GetEventListeners struct {
 
 // NewGetEventListeners constructs a new GetEventListeners struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetEventListenersOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOMDebugger/#method-getEventListeners
-func NewGetEventListeners(objectID runtime.RemoteObjectID) *GetEventListeners {
-	return &GetEventListeners{
+func NewGetEventListeners(objectID runtime.RemoteObjectID, opts ...GetEventListenersOption) *GetEventListeners {
+	c := &GetEventListeners{
 		ObjectID: objectID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetEventListenersOption is a functional option for the optional
+// parameters of the GetEventListeners CDP command, see the
+// NewGetEventListeners constructor. Options are applied in order.
+type GetEventListenersOption = func(*GetEventListeners)
+
 // SetDepth adds or modifies the value of the optional
 // parameter `depth` in the GetEventListeners CDP command.
 //
 // The maximum depth at which Node children should be retrieved, defaults to 1. Use -1 for the
 // entire subtree or provide an integer larger than 0.
 func (t *GetEventListeners) SetDepth(v int64) *GetEventListeners {
-	t.Depth = v
+	t.Depth = &v
 	return t
 }
 
+// GetEventListenersWithDepth is a functional option which sets the value of the
+// optional parameter `depth` in the GetEventListeners CDP command, see SetDepth.
+func GetEventListenersWithDepth(v int64) GetEventListenersOption {
+	return func(c *GetEventListeners) {
+		c.SetDepth(v)
+	}
+}
+
 // SetPierce adds or modifies the value of the optional
 // parameter `pierce` in the GetEventListeners CDP command.
 //
@@ -57,6 +75,14 @@ func (t *GetEventListeners) SetPierce(v bool) *GetEventListeners {
 	return t
 }
 
+// GetEventListenersWithPierce is a functional option which sets the value of the
+// optional parameter `pierce` in the GetEventListeners CDP command, see SetPierce.
+func GetEventListenersWithPierce(v bool) GetEventListenersOption {
+	return func(c *GetEventListeners) {
+		c.SetPierce(v)
+	}
+}
+
 // GetEventListenersResult contains the browser's response
 // to calling the GetEventListeners CDP command with Do().
 type GetEventListenersResult struct {
@@ -180,15 +206,25 @@ type RemoveEventListenerBreakpoint struct {
 
 // NewRemoveEventListenerBreakpoint constructs a new RemoveEventListenerBreakpoint struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `RemoveEventListenerBreakpointOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOMDebugger/#method-removeEventListenerBreakpoint
-func NewRemoveEventListenerBreakpoint(eventName string) *RemoveEventListenerBreakpoint {
-	return &RemoveEventListenerBreakpoint{
+func NewRemoveEventListenerBreakpoint(eventName string, opts ...RemoveEventListenerBreakpointOption) *RemoveEventListenerBreakpoint {
+	c := &RemoveEventListenerBreakpoint{
 		EventName: eventName,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// RemoveEventListenerBreakpointOption is a functional option for the optional
+// parameters of the RemoveEventListenerBreakpoint CDP command, see the
+// NewRemoveEventListenerBreakpoint constructor. Options are applied in order.
+type RemoveEventListenerBreakpointOption = func(*RemoveEventListenerBreakpoint)
+
 // SetTargetName adds or modifies the value of the optional
 // parameter `targetName` in the RemoveEventListenerBreakpoint CDP command.
 //
@@ -200,6 +236,14 @@ func (t *RemoveEventListenerBreakpoint) SetTargetName(v string) *RemoveEventList
 	return t
 }
 
+// RemoveEventListenerBreakpointWithTargetName is a functional option which sets the value of the
+// optional parameter `targetName` in the RemoveEventListenerBreakpoint CDP command, see SetTargetName.
+func RemoveEventListenerBreakpointWithTargetName(v string) RemoveEventListenerBreakpointOption {
+	return func(c *RemoveEventListenerBreakpoint) {
+		c.SetTargetName(v)
+	}
+}
+
 // Do sends the RemoveEventListenerBreakpoint CDP command to a browser,
 // and returns the browser's response.
 func (t *RemoveEventListenerBreakpoint) Do(ctx context.Context) error {
@@ -492,15 +536,25 @@ type SetEventListenerBreakpoint struct {
 
 // NewSetEventListenerBreakpoint constructs a new SetEventListenerBreakpoint struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetEventListenerBreakpointOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOMDebugger/#method-setEventListenerBreakpoint
-func NewSetEventListenerBreakpoint(eventName string) *SetEventListenerBreakpoint {
-	return &SetEventListenerBreakpoint{
+func NewSetEventListenerBreakpoint(eventName string, opts ...SetEventListenerBreakpointOption) *SetEventListenerBreakpoint {
+	c := &SetEventListenerBreakpoint{
 		EventName: eventName,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetEventListenerBreakpointOption is a functional option for the optional
+// parameters of the SetEventListenerBreakpoint CDP command, see the
+// NewSetEventListenerBreakpoint constructor. Options are applied in order.
+type SetEventListenerBreakpointOption = func(*SetEventListenerBreakpoint)
+
 // SetTargetName adds or modifies the value of the optional
 // parameter `targetName` in the SetEventListenerBreakpoint CDP command.
 //
@@ -513,6 +567,14 @@ func (t *SetEventListenerBreakpoint) SetTargetName(v string) *SetEventListenerBr
 	return t
 }
 
+// SetEventListenerBreakpointWithTargetName is a functional option which sets the value of the
+// optional parameter `targetName` in the SetEventListenerBreakpoint CDP command, see SetTargetName.
+func SetEventListenerBreakpointWithTargetName(v string) SetEventListenerBreakpointOption {
+	return func(c *SetEventListenerBreakpoint) {
+		c.SetTargetName(v)
+	}
+}
+
 // Do sends the SetEventListenerBreakpoint CDP command to a browser,
 // and returns the browser's response.
 func (t *SetEventListenerBreakpoint) Do(ctx context.Context) error {
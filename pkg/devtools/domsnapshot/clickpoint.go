@@ -0,0 +1,261 @@
+package domsnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+)
+
+// Computed styles which are captured by the `domsnapshot.ClickPoint`
+// function, in this order.
+var clickStyles = []string{"visibility", "pointer-events"}
+
+// Point is a position in CSS pixels, relative to the main frame's viewport,
+// e.g. for the `input.NewDispatchMouseEvent` function.
+type Point struct {
+	X, Y float64
+}
+
+// CoveredError is returned by the `domsnapshot.ClickPoint` function when an
+// element is rendered in the viewport, but other elements are painted over
+// all of its visible area (e.g. a modal overlay, or a fixed header).
+type CoveredError struct {
+	// Description of the covering element, e.g. `div#consent.overlay`.
+	Covering string
+	// Backend node ID of the covering element.
+	CoveringBackendNodeID int64
+}
+
+// Error satisfies the Go error interface (https://golang.org/pkg/builtin/#error).
+func (e *CoveredError) Error() string {
+	return "element is covered by " + e.Covering
+}
+
+// ClickPoint returns a point in the viewport where a click would be received
+// by the element with the given backend node ID (or one of its descendants),
+// in the main frame of the page associated with the given context. It
+// prefers the center of the element's visible area, and falls back to other
+// points in it when the center is obscured by other elements.
+//
+// This function is based on a single `DOMSnapshot.captureSnapshot` command,
+// which accounts for overlays, fixed headers, stacking order, and the CSS
+// properties "visibility" and "pointer-events". If the element is rendered
+// but obscured, the error is a `*domsnapshot.CoveredError` which describes
+// the covering element. It fails if the element isn't rendered, or is
+// outside of the viewport: consider calling `dom.NewScrollIntoViewIfNeeded`
+// first.
+func ClickPoint(ctx context.Context, backendNodeID int64) (*Point, error) {
+	m, err := page.NewGetLayoutMetrics().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s, err := NewCaptureSnapshot(clickStyles).SetIncludePaintOrder(true).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Documents) == 0 {
+		return nil, errors.New("empty DOM snapshot")
+	}
+	viewport := rect{0, 0, float64(m.CSSLayoutViewport.ClientWidth), float64(m.CSSLayoutViewport.ClientHeight)}
+	return clickPoint(&snapshot{doc: &s.Documents[0], strings: s.Strings}, backendNodeID, viewport)
+}
+
+// Axis-aligned rectangle: X, Y, width, height.
+type rect [4]float64
+
+func (r rect) contains(p Point) bool {
+	return p.X >= r[0] && p.X < r[0]+r[2] && p.Y >= r[1] && p.Y < r[1]+r[3]
+}
+
+func (r rect) intersect(o rect) rect {
+	x1, y1 := max(r[0], o[0]), max(r[1], o[1])
+	x2, y2 := min(r[0]+r[2], o[0]+o[2]), min(r[1]+r[3], o[1]+o[3])
+	if x2 <= x1 || y2 <= y1 {
+		return rect{}
+	}
+	return rect{x1, y1, x2 - x1, y2 - y1}
+}
+
+func (r rect) empty() bool {
+	return r[2] <= 0 || r[3] <= 0
+}
+
+func min(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Accessors for a single document in a DOM snapshot.
+type snapshot struct {
+	doc     *DocumentSnapshot
+	strings []string
+}
+
+func (s *snapshot) str(i int64) string {
+	if i < 0 || int(i) >= len(s.strings) {
+		return ""
+	}
+	return s.strings[i]
+}
+
+// Return the index of the node with the given backend node ID, or -1.
+func (s *snapshot) nodeIndex(backendNodeID int64) int {
+	for i, id := range s.doc.Nodes.BackendNodeID {
+		if id == backendNodeID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Return the bounds of the given layout object, relative to the viewport.
+func (s *snapshot) bounds(layout int) rect {
+	b := s.doc.Layout.Bounds[layout]
+	if len(b) < 4 {
+		return rect{}
+	}
+	return rect{b[0] - s.doc.ScrollOffsetX, b[1] - s.doc.ScrollOffsetY, b[2], b[3]}
+}
+
+// Return the value of one of the `clickStyles` of the given layout object.
+func (s *snapshot) style(layout int, name string) string {
+	styles := s.doc.Layout.Styles[layout]
+	for i, n := range clickStyles {
+		if n == name && i < len(styles) {
+			return s.str(int64(styles[i]))
+		}
+	}
+	return ""
+}
+
+func (s *snapshot) paintOrder(layout int) int64 {
+	if layout >= len(s.doc.Layout.PaintOrders) {
+		return 0
+	}
+	return s.doc.Layout.PaintOrders[layout]
+}
+
+// Report whether the node with index n is the node with index
+// ancestor, or one of its descendants.
+func (s *snapshot) isWithin(n, ancestor int) bool {
+	for n >= 0 {
+		if n == ancestor {
+			return true
+		}
+		n = int(s.doc.Nodes.ParentIndex[n])
+	}
+	return false
+}
+
+// Return the index of the node which would receive a click at the given
+// point, i.e. the topmost layout object which contains it and doesn't
+// ignore pointer events, or -1.
+func (s *snapshot) hitTest(p Point) int {
+	hit, order := -1, int64(-1)
+	for l, n := range s.doc.Layout.NodeIndex {
+		if !s.bounds(l).contains(p) {
+			continue
+		}
+		if s.style(l, "visibility") == "hidden" || s.style(l, "pointer-events") == "none" {
+			continue
+		}
+		// Later layout objects with the same paint order are painted on top.
+		if o := s.paintOrder(l); o >= order {
+			hit, order = int(n), o
+		}
+	}
+	return hit
+}
+
+// Return a short description of an element, e.g. `div#consent.overlay`.
+// Text nodes are described by their parent element.
+func (s *snapshot) describe(n int) string {
+	if s.doc.Nodes.NodeType[n] == 3 && s.doc.Nodes.ParentIndex[n] >= 0 {
+		n = int(s.doc.Nodes.ParentIndex[n])
+	}
+	d := strings.ToLower(s.str(s.doc.Nodes.NodeName[n]))
+	if n < len(s.doc.Nodes.Attributes) {
+		attrs := s.doc.Nodes.Attributes[n]
+		for i := 0; i+1 < len(attrs); i += 2 {
+			v := s.str(int64(attrs[i+1]))
+			switch s.str(int64(attrs[i])) {
+			case "id":
+				d += "#" + v
+			case "class":
+				for _, c := range strings.Fields(v) {
+					d += "." + c
+				}
+			}
+		}
+	}
+	return d
+}
+
+// Candidate points in the given area, in order of preference:
+// the center, and then a 3x3 grid of points around it.
+func candidates(r rect) []Point {
+	ps := []Point{{r[0] + r[2]/2, r[1] + r[3]/2}}
+	for _, fy := range []float64{0.25, 0.5, 0.75} {
+		for _, fx := range []float64{0.25, 0.5, 0.75} {
+			if fx != 0.5 || fy != 0.5 {
+				ps = append(ps, Point{r[0] + r[2]*fx, r[1] + r[3]*fy})
+			}
+		}
+	}
+	return ps
+}
+
+func clickPoint(s *snapshot, backendNodeID int64, viewport rect) (*Point, error) {
+	target := s.nodeIndex(backendNodeID)
+	if target < 0 {
+		return nil, fmt.Errorf("backend node ID %d not found in the main frame", backendNodeID)
+	}
+	area, rendered := rect{}, false
+	for l, n := range s.doc.Layout.NodeIndex {
+		if int(n) != target {
+			continue
+		}
+		rendered = true
+		if s.style(l, "visibility") == "hidden" {
+			return nil, fmt.Errorf("element %s is hidden", s.describe(target))
+		}
+		area = s.bounds(l).intersect(viewport)
+		break
+	}
+	if !rendered {
+		return nil, fmt.Errorf("element %s is not rendered", s.describe(target))
+	}
+	if area.empty() {
+		return nil, fmt.Errorf("element %s is outside of the viewport, or has no size", s.describe(target))
+	}
+
+	covering := -1
+	for _, p := range candidates(area) {
+		hit := s.hitTest(p)
+		if hit >= 0 && s.isWithin(hit, target) {
+			return &p, nil
+		}
+		if covering < 0 {
+			covering = hit
+		}
+	}
+	if covering < 0 {
+		return nil, fmt.Errorf("element %s doesn't receive pointer events", s.describe(target))
+	}
+	return nil, &CoveredError{
+		Covering:              s.describe(covering),
+		CoveringBackendNodeID: s.doc.Nodes.BackendNodeID[covering],
+	}
+}
@@ -0,0 +1,74 @@
+package domsnapshot
+
+import (
+	"errors"
+	"testing"
+)
+
+// A page with a button, and a fixed overlay which covers the top of
+// the page down to the given height, in a document scrolled down by 50px.
+func testSnapshot(overlayHeight float64) *snapshot {
+	strs := []string{"HTML", "BODY", "BUTTON", "#text", "DIV", "id", "ok", "overlay", "class", "modal", "visible", "auto"}
+	return &snapshot{strings: strs, doc: &DocumentSnapshot{
+		ScrollOffsetX: 0,
+		ScrollOffsetY: 50,
+		Nodes: NodeTreeSnapshot{
+			ParentIndex:   []int64{-1, 0, 1, 2, 1},
+			NodeType:      []int64{1, 1, 1, 3, 1},
+			NodeName:      []int64{0, 1, 2, 3, 4},
+			BackendNodeID: []int64{10, 11, 12, 13, 14},
+			Attributes:    []ArrayOfStrings{{}, {}, {5, 6}, {}, {5, 7, 8, 9}},
+		},
+		Layout: LayoutTreeSnapshot{
+			NodeIndex: []int64{1, 2, 3, 4},
+			Styles:    []ArrayOfStrings{{10, 11}, {10, 11}, {10, 11}, {10, 11}},
+			Bounds: []Rectangle{
+				{0, 0, 800, 1000},
+				{100, 150, 100, 40},
+				{110, 160, 30, 20},
+				{0, 50, 800, overlayHeight},
+			},
+			PaintOrders: []int64{1, 1, 1, 2},
+		},
+	}}
+}
+
+func TestClickPoint(t *testing.T) {
+	viewport := rect{0, 0, 800, 600}
+	tests := []struct {
+		name          string
+		overlayHeight float64
+		want          Point
+	}{
+		{"unobscured", 50, Point{150, 120}},
+		{"partially covered", 125, Point{125, 130}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := clickPoint(testSnapshot(test.overlayHeight), 12, viewport)
+			if err != nil {
+				t.Fatalf("clickPoint(); got error: %v", err)
+			}
+			if *got != test.want {
+				t.Errorf("clickPoint() = %v, want %v", *got, test.want)
+			}
+		})
+	}
+}
+
+func TestClickPointCovered(t *testing.T) {
+	_, err := clickPoint(testSnapshot(200), 12, rect{0, 0, 800, 600})
+	var covered *CoveredError
+	if !errors.As(err, &covered) {
+		t.Fatalf("clickPoint() error = %v, want *CoveredError", err)
+	}
+	if covered.Covering != "div#overlay.modal" || covered.CoveringBackendNodeID != 14 {
+		t.Errorf("clickPoint() error = %+v, want covering element div#overlay.modal (14)", covered)
+	}
+}
+
+func TestClickPointOutsideViewport(t *testing.T) {
+	if _, err := clickPoint(testSnapshot(0), 12, rect{0, 0, 800, 90}); err == nil {
+		t.Error("clickPoint() with element below the viewport; got nil error")
+	}
+}
@@ -120,17 +120,27 @@ type GetSnapshot struct {
 
 // NewGetSnapshot constructs a new GetSnapshot struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetSnapshotOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOMSnapshot/#method-getSnapshot
 //
 // This CDP method is deprecated.
-func NewGetSnapshot(computedStyleWhitelist []string) *GetSnapshot {
-	return &GetSnapshot{
+func NewGetSnapshot(computedStyleWhitelist []string, opts ...GetSnapshotOption) *GetSnapshot {
+	c := &GetSnapshot{
 		ComputedStyleWhitelist: computedStyleWhitelist,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetSnapshotOption is a functional option for the optional
+// parameters of the GetSnapshot CDP command, see the
+// NewGetSnapshot constructor. Options are applied in order.
+type GetSnapshotOption = func(*GetSnapshot)
+
 // SetIncludeEventListeners adds or modifies the value of the optional
 // parameter `includeEventListeners` in the GetSnapshot CDP command.
 //
@@ -140,6 +150,14 @@ func (t *GetSnapshot) SetIncludeEventListeners(v bool) *GetSnapshot {
 	return t
 }
 
+// GetSnapshotWithIncludeEventListeners is a functional option which sets the value of the
+// optional parameter `includeEventListeners` in the GetSnapshot CDP command, see SetIncludeEventListeners.
+func GetSnapshotWithIncludeEventListeners(v bool) GetSnapshotOption {
+	return func(c *GetSnapshot) {
+		c.SetIncludeEventListeners(v)
+	}
+}
+
 // SetIncludePaintOrder adds or modifies the value of the optional
 // parameter `includePaintOrder` in the GetSnapshot CDP command.
 //
@@ -149,6 +167,14 @@ func (t *GetSnapshot) SetIncludePaintOrder(v bool) *GetSnapshot {
 	return t
 }
 
+// GetSnapshotWithIncludePaintOrder is a functional option which sets the value of the
+// optional parameter `includePaintOrder` in the GetSnapshot CDP command, see SetIncludePaintOrder.
+func GetSnapshotWithIncludePaintOrder(v bool) GetSnapshotOption {
+	return func(c *GetSnapshot) {
+		c.SetIncludePaintOrder(v)
+	}
+}
+
 // SetIncludeUserAgentShadowTree adds or modifies the value of the optional
 // parameter `includeUserAgentShadowTree` in the GetSnapshot CDP command.
 //
@@ -158,6 +184,14 @@ func (t *GetSnapshot) SetIncludeUserAgentShadowTree(v bool) *GetSnapshot {
 	return t
 }
 
+// GetSnapshotWithIncludeUserAgentShadowTree is a functional option which sets the value of the
+// optional parameter `includeUserAgentShadowTree` in the GetSnapshot CDP command, see SetIncludeUserAgentShadowTree.
+func GetSnapshotWithIncludeUserAgentShadowTree(v bool) GetSnapshotOption {
+	return func(c *GetSnapshot) {
+		c.SetIncludeUserAgentShadowTree(v)
+	}
+}
+
 // GetSnapshotResult contains the browser's response
 // to calling the GetSnapshot CDP command with Do().
 type GetSnapshotResult struct {
@@ -240,15 +274,25 @@ type CaptureSnapshot struct {
 
 // NewCaptureSnapshot constructs a new CaptureSnapshot struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `CaptureSnapshotOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/DOMSnapshot/#method-captureSnapshot
-func NewCaptureSnapshot(computedStyles []string) *CaptureSnapshot {
-	return &CaptureSnapshot{
+func NewCaptureSnapshot(computedStyles []string, opts ...CaptureSnapshotOption) *CaptureSnapshot {
+	c := &CaptureSnapshot{
 		ComputedStyles: computedStyles,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// CaptureSnapshotOption is a functional option for the optional
+// parameters of the CaptureSnapshot CDP command, see the
+// NewCaptureSnapshot constructor. Options are applied in order.
+type CaptureSnapshotOption = func(*CaptureSnapshot)
+
 // SetIncludePaintOrder adds or modifies the value of the optional
 // parameter `includePaintOrder` in the CaptureSnapshot CDP command.
 //
@@ -258,6 +302,14 @@ func (t *CaptureSnapshot) SetIncludePaintOrder(v bool) *CaptureSnapshot {
 	return t
 }
 
+// CaptureSnapshotWithIncludePaintOrder is a functional option which sets the value of the
+// optional parameter `includePaintOrder` in the CaptureSnapshot CDP command, see SetIncludePaintOrder.
+func CaptureSnapshotWithIncludePaintOrder(v bool) CaptureSnapshotOption {
+	return func(c *CaptureSnapshot) {
+		c.SetIncludePaintOrder(v)
+	}
+}
+
 // SetIncludeDOMRects adds or modifies the value of the optional
 // parameter `includeDOMRects` in the CaptureSnapshot CDP command.
 //
@@ -267,6 +319,14 @@ func (t *CaptureSnapshot) SetIncludeDOMRects(v bool) *CaptureSnapshot {
 	return t
 }
 
+// CaptureSnapshotWithIncludeDOMRects is a functional option which sets the value of the
+// optional parameter `includeDOMRects` in the CaptureSnapshot CDP command, see SetIncludeDOMRects.
+func CaptureSnapshotWithIncludeDOMRects(v bool) CaptureSnapshotOption {
+	return func(c *CaptureSnapshot) {
+		c.SetIncludeDOMRects(v)
+	}
+}
+
 // SetIncludeBlendedBackgroundColors adds or modifies the value of the optional
 // parameter `includeBlendedBackgroundColors` in the CaptureSnapshot CDP command.
 //
@@ -280,6 +340,14 @@ func (t *CaptureSnapshot) SetIncludeBlendedBackgroundColors(v bool) *CaptureSnap
 	return t
 }
 
+// CaptureSnapshotWithIncludeBlendedBackgroundColors is a functional option which sets the value of the
+// optional parameter `includeBlendedBackgroundColors` in the CaptureSnapshot CDP command, see SetIncludeBlendedBackgroundColors.
+func CaptureSnapshotWithIncludeBlendedBackgroundColors(v bool) CaptureSnapshotOption {
+	return func(c *CaptureSnapshot) {
+		c.SetIncludeBlendedBackgroundColors(v)
+	}
+}
+
 // SetIncludeTextColorOpacities adds or modifies the value of the optional
 // parameter `includeTextColorOpacities` in the CaptureSnapshot CDP command.
 //
@@ -293,6 +361,14 @@ func (t *CaptureSnapshot) SetIncludeTextColorOpacities(v bool) *CaptureSnapshot
 	return t
 }
 
+// CaptureSnapshotWithIncludeTextColorOpacities is a functional option which sets the value of the
+// optional parameter `includeTextColorOpacities` in the CaptureSnapshot CDP command, see SetIncludeTextColorOpacities.
+func CaptureSnapshotWithIncludeTextColorOpacities(v bool) CaptureSnapshotOption {
+	return func(c *CaptureSnapshot) {
+		c.SetIncludeTextColorOpacities(v)
+	}
+}
+
 // CaptureSnapshotResult contains the browser's response
 // to calling the CaptureSnapshot CDP command with Do().
 type CaptureSnapshotResult struct {
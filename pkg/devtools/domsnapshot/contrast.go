@@ -0,0 +1,370 @@
+package domsnapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Computed styles which are captured by the `domsnapshot.AuditContrast`
+// function, in this order.
+var contrastStyles = []string{"color", "background-color", "font-size", "font-weight", "visibility"}
+
+// Minimum contrast ratios of the Web Content Accessibility Guidelines
+// (WCAG 2), for normal and large text.
+const (
+	MinContrastAA       = 4.5
+	MinContrastAALarge  = 3.0
+	MinContrastAAA      = 7.0
+	MinContrastAAALarge = 4.5
+)
+
+// ContrastViolation is a text node whose contrast ratio is
+// insufficient, see the `domsnapshot.AuditContrast` function.
+type ContrastViolation struct {
+	// Unique CSS selector of the text's parent element, within its document.
+	Selector string
+	// Backend node ID of the text's parent element.
+	BackendNodeID int64
+	// The text, with collapsed whitespace, up to 100 characters.
+	Text string
+	// Computed colors of the text and its background, e.g. "rgb(119, 119, 119)".
+	Foreground, Background string
+	// Contrast ratio of the colors, from 1 to 21, and the required minimum.
+	Ratio, Required float64
+	// Whether the text is large (at least 18pt, or 14pt and bold), which
+	// requires a lower contrast ratio.
+	LargeText bool
+	// Bounds of the text's first box (X, Y, width, height), in CSS pixels,
+	// relative to the document.
+	Bounds Rectangle
+}
+
+func (v *ContrastViolation) String() string {
+	return fmt.Sprintf("%s: contrast ratio %.2f:1 < %.1f:1 (%s on %s): %q",
+		v.Selector, v.Ratio, v.Required, v.Foreground, v.Background, v.Text)
+}
+
+type contrastConfig struct {
+	normal, large float64
+}
+
+// ContrastOption is used for customization in the `domsnapshot.AuditContrast` function.
+type ContrastOption = func(*contrastConfig)
+
+// ContrastLevelAAA allows the caller of the `domsnapshot.AuditContrast`
+// function to require the enhanced contrast of WCAG level AAA, instead of
+// the minimum contrast of level AA.
+func ContrastLevelAAA() ContrastOption {
+	return func(c *contrastConfig) {
+		c.normal, c.large = MinContrastAAA, MinContrastAAALarge
+	}
+}
+
+// AuditContrast returns the visible text nodes in the main frame of the page
+// associated with the given context, whose contrast ratio with their
+// background is lower than the minimum of WCAG level AA (by default, see
+// the `domsnapshot.ContrastLevelAAA` option), in document order.
+//
+// This function is based on a single `DOMSnapshot.captureSnapshot` command:
+// the text's computed color and opacity, and the background color which the
+// browser blends from the overlapping elements (or the backgrounds of the
+// text's ancestors, if the browser doesn't report it). Text over images or
+// gradients can't be judged this way, and is compared with the solid
+// background color behind them.
+func AuditContrast(ctx context.Context, opts ...ContrastOption) ([]ContrastViolation, error) {
+	cfg := &contrastConfig{normal: MinContrastAA, large: MinContrastAALarge}
+	for _, o := range opts {
+		o(cfg)
+	}
+	s, err := NewCaptureSnapshot(contrastStyles).SetIncludeBlendedBackgroundColors(true).
+		SetIncludeTextColorOpacities(true).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Documents) == 0 {
+		return nil, errors.New("empty DOM snapshot")
+	}
+	return auditContrast(&snapshot{doc: &s.Documents[0], strings: s.Strings}, cfg), nil
+}
+
+func auditContrast(s *snapshot, cfg *contrastConfig) []ContrastViolation {
+	layouts := make(map[int]int) // Node index to layout index.
+	for l, n := range s.doc.Layout.NodeIndex {
+		if _, ok := layouts[int(n)]; !ok {
+			layouts[int(n)] = l
+		}
+	}
+	boxes := make(map[int]Rectangle) // Layout index to its first text box.
+	for i, l := range s.doc.TextBoxes.LayoutIndex {
+		if _, ok := boxes[int(l)]; !ok && i < len(s.doc.TextBoxes.Bounds) {
+			boxes[int(l)] = s.doc.TextBoxes.Bounds[i]
+		}
+	}
+
+	var violations []ContrastViolation
+	for l, n := range s.doc.Layout.NodeIndex {
+		if s.doc.Nodes.NodeType[n] != 3 || l >= len(s.doc.Layout.Text) {
+			continue
+		}
+		text := strings.Join(strings.Fields(s.str(s.doc.Layout.Text[l])), " ")
+		parent := int(s.doc.Nodes.ParentIndex[n])
+		if text == "" || parent < 0 {
+			continue
+		}
+		style := func(name string) string {
+			if v := s.contrastStyle(l, name); v != "" {
+				return v
+			}
+			if pl, ok := layouts[parent]; ok {
+				return s.contrastStyle(pl, name)
+			}
+			return ""
+		}
+		if style("visibility") == "hidden" {
+			continue
+		}
+		fg, ok := parseColor(style("color"))
+		if !ok {
+			continue
+		}
+		if l < len(s.doc.Layout.TextColorOpacities) {
+			fg.a *= s.doc.Layout.TextColorOpacities[l]
+		}
+		if fg.a == 0 {
+			continue
+		}
+		bg, ok := s.background(l, int(n), layouts)
+		if !ok {
+			continue
+		}
+
+		ratio := contrastRatio(fg.over(bg), bg)
+		size := parseLength(style("font-size"))
+		weight, _ := strconv.Atoi(style("font-weight"))
+		large := size >= 24 || (size >= 18.66 && weight >= 700)
+		required := cfg.normal
+		if large {
+			required = cfg.large
+		}
+		if ratio >= required {
+			continue
+		}
+		if r := []rune(text); len(r) > 100 {
+			text = string(r[:100])
+		}
+		violations = append(violations, ContrastViolation{
+			Selector:      s.selector(parent),
+			BackendNodeID: s.doc.Nodes.BackendNodeID[parent],
+			Text:          text,
+			Foreground:    style("color"),
+			Background:    bg.String(),
+			Ratio:         math.Round(ratio*100) / 100,
+			Required:      required,
+			LargeText:     large,
+			Bounds:        boxes[l],
+		})
+	}
+	return violations
+}
+
+// Return the value of one of the `contrastStyles` of the given layout object.
+func (s *snapshot) contrastStyle(layout int, name string) string {
+	if layout >= len(s.doc.Layout.Styles) {
+		return ""
+	}
+	styles := s.doc.Layout.Styles[layout]
+	for i, n := range contrastStyles {
+		if n == name && i < len(styles) {
+			return s.str(int64(styles[i]))
+		}
+	}
+	return ""
+}
+
+// Return the opaque background color of the given layout object (of the
+// node with the given index): the blended background color which the
+// browser reported, or the composition of the ancestors' background colors
+// over a white canvas.
+func (s *snapshot) background(layout, n int, layouts map[int]int) (rgba, bool) {
+	if layout < len(s.doc.Layout.BlendedBackgroundColors) {
+		if c, ok := parseColor(s.str(s.doc.Layout.BlendedBackgroundColors[layout])); ok {
+			return c.over(white), true
+		}
+	}
+	var colors []rgba
+	for ; n >= 0; n = int(s.doc.Nodes.ParentIndex[n]) {
+		l, ok := layouts[n]
+		if !ok {
+			continue
+		}
+		c, ok := parseColor(s.contrastStyle(l, "background-color"))
+		if !ok {
+			return rgba{}, false
+		}
+		if c.a == 0 {
+			continue
+		}
+		colors = append(colors, c)
+		if c.a == 1 {
+			break
+		}
+	}
+	bg := white
+	for i := len(colors) - 1; i >= 0; i-- {
+		bg = colors[i].over(bg)
+	}
+	return bg, true
+}
+
+// Return a unique CSS selector of the element with the given index, within
+// its document: its ID, or its path from the nearest ancestor with an ID
+// (or the root), with ":nth-child" pseudo-classes where needed.
+func (s *snapshot) selector(n int) string {
+	var parts []string
+	for n >= 0 && s.doc.Nodes.NodeType[n] == 1 {
+		name := strings.ToLower(s.str(s.doc.Nodes.NodeName[n]))
+		if id := s.attribute(n, "id"); id != "" && !strings.ContainsAny(id, " \t\n\"'\\[]#.:>") {
+			parts = append(parts, "#"+id)
+			break
+		}
+		parent := int(s.doc.Nodes.ParentIndex[n])
+		if parent >= 0 && s.doc.Nodes.NodeType[parent] == 1 {
+			if i, count := s.childIndex(parent, n); count > 1 {
+				name += fmt.Sprintf(":nth-child(%d)", i)
+			}
+		}
+		parts = append(parts, name)
+		n = parent
+	}
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return strings.Join(parts, " > ")
+}
+
+// Return the 1-based position of the given element among the element
+// children of the given parent, and the number of these children.
+func (s *snapshot) childIndex(parent, child int) (index, count int) {
+	for i := parent + 1; i < len(s.doc.Nodes.ParentIndex); i++ {
+		if int(s.doc.Nodes.ParentIndex[i]) != parent || s.doc.Nodes.NodeType[i] != 1 {
+			continue
+		}
+		count++
+		if i == child {
+			index = count
+		}
+	}
+	return index, count
+}
+
+func (s *snapshot) attribute(n int, name string) string {
+	if n >= len(s.doc.Nodes.Attributes) {
+		return ""
+	}
+	attrs := s.doc.Nodes.Attributes[n]
+	for i := 0; i+1 < len(attrs); i += 2 {
+		if s.str(int64(attrs[i])) == name {
+			return s.str(int64(attrs[i+1]))
+		}
+	}
+	return ""
+}
+
+// Color with sRGB components from 0 to 255, and alpha from 0 to 1.
+type rgba struct {
+	r, g, b, a float64
+}
+
+var white = rgba{255, 255, 255, 1}
+
+// Parse a computed CSS color, e.g. "rgb(0, 0, 0)" or "rgba(0, 0, 0, 0.5)".
+func parseColor(s string) (rgba, bool) {
+	s = strings.TrimSpace(s)
+	i := strings.IndexByte(s, '(')
+	if i < 0 || !strings.HasSuffix(s, ")") || (s[:i] != "rgb" && s[:i] != "rgba") {
+		return rgba{}, false
+	}
+	fields := strings.FieldsFunc(s[i+1:len(s)-1], func(r rune) bool {
+		return r == ',' || r == ' ' || r == '/'
+	})
+	if len(fields) != 3 && len(fields) != 4 {
+		return rgba{}, false
+	}
+	c := []float64{0, 0, 0, 1}
+	for j, f := range fields {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(f, "%"), 64)
+		if err != nil {
+			return rgba{}, false
+		}
+		if strings.HasSuffix(f, "%") {
+			if j < 3 {
+				v *= 2.55
+			} else {
+				v /= 100
+			}
+		}
+		c[j] = v
+	}
+	return rgba{c[0], c[1], c[2], c[3]}, true
+}
+
+// Composite the color over the given opaque background color.
+func (c rgba) over(bg rgba) rgba {
+	return rgba{
+		r: c.r*c.a + bg.r*(1-c.a),
+		g: c.g*c.a + bg.g*(1-c.a),
+		b: c.b*c.a + bg.b*(1-c.a),
+		a: 1,
+	}
+}
+
+func (c rgba) String() string {
+	return fmt.Sprintf("rgb(%d, %d, %d)", int(math.Round(c.r)), int(math.Round(c.g)), int(math.Round(c.b)))
+}
+
+// Relative luminance, as defined by WCAG 2.
+func (c rgba) luminance() float64 {
+	channel := func(v float64) float64 {
+		v /= 255
+		if v <= 0.03928 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	return 0.2126*channel(c.r) + 0.7152*channel(c.g) + 0.0722*channel(c.b)
+}
+
+func contrastRatio(a, b rgba) float64 {
+	l1, l2 := a.luminance(), b.luminance()
+	if l1 < l2 {
+		l1, l2 = l2, l1
+	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
+
+// ContrastRatio returns the WCAG 2 contrast ratio (from 1 to 21) of the
+// given CSS colors in "rgb(...)" or "rgba(...)" notation, like computed
+// styles. A translucent foreground color is composited over the background
+// color, which is composited over white.
+func ContrastRatio(foreground, background string) (float64, error) {
+	fg, ok := parseColor(foreground)
+	if !ok {
+		return 0, fmt.Errorf("unsupported color: %q", foreground)
+	}
+	bg, ok := parseColor(background)
+	if !ok {
+		return 0, fmt.Errorf("unsupported color: %q", background)
+	}
+	bg = bg.over(white)
+	return contrastRatio(fg.over(bg), bg), nil
+}
+
+// Parse a CSS length in pixels, e.g. "16px", or return 0.
+func parseLength(s string) float64 {
+	v, _ := strconv.ParseFloat(strings.TrimSuffix(s, "px"), 64)
+	return v
+}
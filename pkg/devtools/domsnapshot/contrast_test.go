@@ -0,0 +1,107 @@
+package domsnapshot
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// A page with a white body, and 3 paragraphs: gray text, large gray text,
+// and black text over a dark background which the browser blended.
+func contrastSnapshot() *snapshot {
+	strs := []string{
+		"HTML", "BODY", "P", "#text", "H1", "id", "intro",
+		"rgb(0, 0, 0)", "rgb(255, 255, 255)", "rgb(119, 119, 119)", "rgba(0, 0, 0, 0)",
+		"16px", "32px", "400", "700", "visible",
+		"Gray  text", "Large gray text", "Black text", "rgb(40, 40, 40)",
+	}
+	style := func(color, bg, size, weight int) ArrayOfStrings {
+		return ArrayOfStrings{StringIndex(color), StringIndex(bg), StringIndex(size), StringIndex(weight), 15}
+	}
+	return &snapshot{strings: strs, doc: &DocumentSnapshot{
+		Nodes: NodeTreeSnapshot{
+			ParentIndex:   []int64{-1, 0, 1, 2, 1, 4, 1, 6},
+			NodeType:      []int64{1, 1, 1, 3, 1, 3, 1, 3},
+			NodeName:      []int64{0, 1, 2, 3, 4, 3, 2, 3},
+			BackendNodeID: []int64{10, 11, 12, 13, 14, 15, 16, 17},
+			Attributes:    []ArrayOfStrings{{}, {}, {}, {}, {5, 6}, {}, {}, {}},
+		},
+		Layout: LayoutTreeSnapshot{
+			NodeIndex: []int64{0, 1, 2, 3, 4, 5, 6, 7},
+			Styles: []ArrayOfStrings{
+				style(7, 10, 11, 13),
+				style(7, 8, 11, 13),
+				style(9, 10, 11, 13),
+				style(9, 10, 11, 13),
+				style(9, 10, 12, 14),
+				style(9, 10, 12, 14),
+				style(7, 10, 11, 13),
+				style(7, 10, 11, 13),
+			},
+			Bounds:                  []Rectangle{{}, {}, {}, {0, 0, 80, 20}, {}, {}, {}, {}},
+			Text:                    []int64{-1, -1, -1, 16, -1, 17, -1, 18},
+			BlendedBackgroundColors: []int64{8, 8, 8, 8, 8, 8, 8, 19},
+		},
+		TextBoxes: TextBoxSnapshot{
+			LayoutIndex: []int64{3},
+			Bounds:      []Rectangle{{0, 0, 80, 20}},
+		},
+	}}
+}
+
+func TestAuditContrast(t *testing.T) {
+	want := []ContrastViolation{{
+		Selector:      "html > body > p:nth-child(1)",
+		BackendNodeID: 12,
+		Text:          "Gray text",
+		Foreground:    "rgb(119, 119, 119)",
+		Background:    "rgb(255, 255, 255)",
+		Ratio:         4.48,
+		Required:      MinContrastAA,
+		Bounds:        Rectangle{0, 0, 80, 20},
+	}, {
+		Selector:      "html > body > p:nth-child(3)",
+		BackendNodeID: 16,
+		Text:          "Black text",
+		Foreground:    "rgb(0, 0, 0)",
+		Background:    "rgb(40, 40, 40)",
+		Ratio:         1.42,
+		Required:      MinContrastAA,
+	}}
+	got := auditContrast(contrastSnapshot(), &contrastConfig{normal: MinContrastAA, large: MinContrastAALarge})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("auditContrast() mismatch (-want +got):\n%s", diff)
+	}
+
+	cfg := &contrastConfig{}
+	ContrastLevelAAA()(cfg)
+	got = auditContrast(contrastSnapshot(), cfg)
+	if len(got) != 3 || got[1].Selector != "#intro" || !got[1].LargeText || got[2].Background != "rgb(40, 40, 40)" {
+		t.Errorf("auditContrast(AAA) = %v, want 3 violations", got)
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	tests := []struct {
+		fg, bg string
+		want   float64
+	}{
+		{"rgb(0, 0, 0)", "rgb(255, 255, 255)", 21},
+		{"rgb(255, 255, 255)", "rgb(255, 255, 255)", 1},
+		{"rgb(119, 119, 119)", "rgb(255, 255, 255)", 4.48},
+		{"rgba(0, 0, 0, 0.5)", "rgba(0, 0, 0, 0)", 3.98},
+	}
+	for _, test := range tests {
+		got, err := ContrastRatio(test.fg, test.bg)
+		if err != nil {
+			t.Fatalf("ContrastRatio(%q, %q); got error: %v", test.fg, test.bg, err)
+		}
+		if math.Round(got*100)/100 != test.want {
+			t.Errorf("ContrastRatio(%q, %q) = %.2f, want %.2f", test.fg, test.bg, got, test.want)
+		}
+	}
+	if _, err := ContrastRatio("hsl(0, 0%, 0%)", "white"); err == nil {
+		t.Error("ContrastRatio(unsupported colors) error = nil")
+	}
+}
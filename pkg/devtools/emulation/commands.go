@@ -278,15 +278,25 @@ type SetAutoDarkModeOverride struct {
 
 // NewSetAutoDarkModeOverride constructs a new SetAutoDarkModeOverride struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetAutoDarkModeOverrideOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setAutoDarkModeOverride
 //
 // This CDP method is experimental.
-func NewSetAutoDarkModeOverride() *SetAutoDarkModeOverride {
-	return &SetAutoDarkModeOverride{}
+func NewSetAutoDarkModeOverride(opts ...SetAutoDarkModeOverrideOption) *SetAutoDarkModeOverride {
+	c := &SetAutoDarkModeOverride{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetAutoDarkModeOverrideOption is a functional option for the optional
+// parameters of the SetAutoDarkModeOverride CDP command, see the
+// NewSetAutoDarkModeOverride constructor. Options are applied in order.
+type SetAutoDarkModeOverrideOption = func(*SetAutoDarkModeOverride)
+
 // SetEnabled adds or modifies the value of the optional
 // parameter `enabled` in the SetAutoDarkModeOverride CDP command.
 //
@@ -297,6 +307,14 @@ func (t *SetAutoDarkModeOverride) SetEnabled(v bool) *SetAutoDarkModeOverride {
 	return t
 }
 
+// SetAutoDarkModeOverrideWithEnabled is a functional option which sets the value of the
+// optional parameter `enabled` in the SetAutoDarkModeOverride CDP command, see SetEnabled.
+func SetAutoDarkModeOverrideWithEnabled(v bool) SetAutoDarkModeOverrideOption {
+	return func(c *SetAutoDarkModeOverride) {
+		c.SetEnabled(v)
+	}
+}
+
 // Do sends the SetAutoDarkModeOverride CDP command to a browser,
 // and returns the browser's response.
 func (t *SetAutoDarkModeOverride) Do(ctx context.Context) error {
@@ -408,13 +426,23 @@ type SetDefaultBackgroundColorOverride struct {
 
 // NewSetDefaultBackgroundColorOverride constructs a new SetDefaultBackgroundColorOverride struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetDefaultBackgroundColorOverrideOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setDefaultBackgroundColorOverride
-func NewSetDefaultBackgroundColorOverride() *SetDefaultBackgroundColorOverride {
-	return &SetDefaultBackgroundColorOverride{}
+func NewSetDefaultBackgroundColorOverride(opts ...SetDefaultBackgroundColorOverrideOption) *SetDefaultBackgroundColorOverride {
+	c := &SetDefaultBackgroundColorOverride{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetDefaultBackgroundColorOverrideOption is a functional option for the optional
+// parameters of the SetDefaultBackgroundColorOverride CDP command, see the
+// NewSetDefaultBackgroundColorOverride constructor. Options are applied in order.
+type SetDefaultBackgroundColorOverrideOption = func(*SetDefaultBackgroundColorOverride)
+
 // SetColor adds or modifies the value of the optional
 // parameter `color` in the SetDefaultBackgroundColorOverride CDP command.
 //
@@ -425,6 +453,14 @@ func (t *SetDefaultBackgroundColorOverride) SetColor(v dom.RGBA) *SetDefaultBack
 	return t
 }
 
+// SetDefaultBackgroundColorOverrideWithColor is a functional option which sets the value of the
+// optional parameter `color` in the SetDefaultBackgroundColorOverride CDP command, see SetColor.
+func SetDefaultBackgroundColorOverrideWithColor(v dom.RGBA) SetDefaultBackgroundColorOverrideOption {
+	return func(c *SetDefaultBackgroundColorOverride) {
+		c.SetColor(v)
+	}
+}
+
 // Do sends the SetDefaultBackgroundColorOverride CDP command to a browser,
 // and returns the browser's response.
 func (t *SetDefaultBackgroundColorOverride) Do(ctx context.Context) error {
@@ -518,18 +554,28 @@ type SetDeviceMetricsOverride struct {
 
 // NewSetDeviceMetricsOverride constructs a new SetDeviceMetricsOverride struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetDeviceMetricsOverrideOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setDeviceMetricsOverride
-func NewSetDeviceMetricsOverride(width int64, height int64, deviceScaleFactor float64, mobile bool) *SetDeviceMetricsOverride {
-	return &SetDeviceMetricsOverride{
+func NewSetDeviceMetricsOverride(width int64, height int64, deviceScaleFactor float64, mobile bool, opts ...SetDeviceMetricsOverrideOption) *SetDeviceMetricsOverride {
+	c := &SetDeviceMetricsOverride{
 		Width:             width,
 		Height:            height,
 		DeviceScaleFactor: deviceScaleFactor,
 		Mobile:            mobile,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetDeviceMetricsOverrideOption is a functional option for the optional
+// parameters of the SetDeviceMetricsOverride CDP command, see the
+// NewSetDeviceMetricsOverride constructor. Options are applied in order.
+type SetDeviceMetricsOverrideOption = func(*SetDeviceMetricsOverride)
+
 // SetScale adds or modifies the value of the optional
 // parameter `scale` in the SetDeviceMetricsOverride CDP command.
 //
@@ -541,6 +587,14 @@ func (t *SetDeviceMetricsOverride) SetScale(v float64) *SetDeviceMetricsOverride
 	return t
 }
 
+// SetDeviceMetricsOverrideWithScale is a functional option which sets the value of the
+// optional parameter `scale` in the SetDeviceMetricsOverride CDP command, see SetScale.
+func SetDeviceMetricsOverrideWithScale(v float64) SetDeviceMetricsOverrideOption {
+	return func(c *SetDeviceMetricsOverride) {
+		c.SetScale(v)
+	}
+}
+
 // SetScreenWidth adds or modifies the value of the optional
 // parameter `screenWidth` in the SetDeviceMetricsOverride CDP command.
 //
@@ -552,6 +606,14 @@ func (t *SetDeviceMetricsOverride) SetScreenWidth(v int64) *SetDeviceMetricsOver
 	return t
 }
 
+// SetDeviceMetricsOverrideWithScreenWidth is a functional option which sets the value of the
+// optional parameter `screenWidth` in the SetDeviceMetricsOverride CDP command, see SetScreenWidth.
+func SetDeviceMetricsOverrideWithScreenWidth(v int64) SetDeviceMetricsOverrideOption {
+	return func(c *SetDeviceMetricsOverride) {
+		c.SetScreenWidth(v)
+	}
+}
+
 // SetScreenHeight adds or modifies the value of the optional
 // parameter `screenHeight` in the SetDeviceMetricsOverride CDP command.
 //
@@ -563,6 +625,14 @@ func (t *SetDeviceMetricsOverride) SetScreenHeight(v int64) *SetDeviceMetricsOve
 	return t
 }
 
+// SetDeviceMetricsOverrideWithScreenHeight is a functional option which sets the value of the
+// optional parameter `screenHeight` in the SetDeviceMetricsOverride CDP command, see SetScreenHeight.
+func SetDeviceMetricsOverrideWithScreenHeight(v int64) SetDeviceMetricsOverrideOption {
+	return func(c *SetDeviceMetricsOverride) {
+		c.SetScreenHeight(v)
+	}
+}
+
 // SetPositionX adds or modifies the value of the optional
 // parameter `positionX` in the SetDeviceMetricsOverride CDP command.
 //
@@ -574,6 +644,14 @@ func (t *SetDeviceMetricsOverride) SetPositionX(v int64) *SetDeviceMetricsOverri
 	return t
 }
 
+// SetDeviceMetricsOverrideWithPositionX is a functional option which sets the value of the
+// optional parameter `positionX` in the SetDeviceMetricsOverride CDP command, see SetPositionX.
+func SetDeviceMetricsOverrideWithPositionX(v int64) SetDeviceMetricsOverrideOption {
+	return func(c *SetDeviceMetricsOverride) {
+		c.SetPositionX(v)
+	}
+}
+
 // SetPositionY adds or modifies the value of the optional
 // parameter `positionY` in the SetDeviceMetricsOverride CDP command.
 //
@@ -585,6 +663,14 @@ func (t *SetDeviceMetricsOverride) SetPositionY(v int64) *SetDeviceMetricsOverri
 	return t
 }
 
+// SetDeviceMetricsOverrideWithPositionY is a functional option which sets the value of the
+// optional parameter `positionY` in the SetDeviceMetricsOverride CDP command, see SetPositionY.
+func SetDeviceMetricsOverrideWithPositionY(v int64) SetDeviceMetricsOverrideOption {
+	return func(c *SetDeviceMetricsOverride) {
+		c.SetPositionY(v)
+	}
+}
+
 // SetDontSetVisibleSize adds or modifies the value of the optional
 // parameter `dontSetVisibleSize` in the SetDeviceMetricsOverride CDP command.
 //
@@ -596,6 +682,14 @@ func (t *SetDeviceMetricsOverride) SetDontSetVisibleSize(v bool) *SetDeviceMetri
 	return t
 }
 
+// SetDeviceMetricsOverrideWithDontSetVisibleSize is a functional option which sets the value of the
+// optional parameter `dontSetVisibleSize` in the SetDeviceMetricsOverride CDP command, see SetDontSetVisibleSize.
+func SetDeviceMetricsOverrideWithDontSetVisibleSize(v bool) SetDeviceMetricsOverrideOption {
+	return func(c *SetDeviceMetricsOverride) {
+		c.SetDontSetVisibleSize(v)
+	}
+}
+
 // SetScreenOrientation adds or modifies the value of the optional
 // parameter `screenOrientation` in the SetDeviceMetricsOverride CDP command.
 //
@@ -605,6 +699,14 @@ func (t *SetDeviceMetricsOverride) SetScreenOrientation(v ScreenOrientation) *Se
 	return t
 }
 
+// SetDeviceMetricsOverrideWithScreenOrientation is a functional option which sets the value of the
+// optional parameter `screenOrientation` in the SetDeviceMetricsOverride CDP command, see SetScreenOrientation.
+func SetDeviceMetricsOverrideWithScreenOrientation(v ScreenOrientation) SetDeviceMetricsOverrideOption {
+	return func(c *SetDeviceMetricsOverride) {
+		c.SetScreenOrientation(v)
+	}
+}
+
 // SetViewport adds or modifies the value of the optional
 // parameter `viewport` in the SetDeviceMetricsOverride CDP command.
 //
@@ -617,6 +719,14 @@ func (t *SetDeviceMetricsOverride) SetViewport(v page.Viewport) *SetDeviceMetric
 	return t
 }
 
+// SetDeviceMetricsOverrideWithViewport is a functional option which sets the value of the
+// optional parameter `viewport` in the SetDeviceMetricsOverride CDP command, see SetViewport.
+func SetDeviceMetricsOverrideWithViewport(v page.Viewport) SetDeviceMetricsOverrideOption {
+	return func(c *SetDeviceMetricsOverride) {
+		c.SetViewport(v)
+	}
+}
+
 // SetDisplayFeature adds or modifies the value of the optional
 // parameter `displayFeature` in the SetDeviceMetricsOverride CDP command.
 //
@@ -629,6 +739,14 @@ func (t *SetDeviceMetricsOverride) SetDisplayFeature(v DisplayFeature) *SetDevic
 	return t
 }
 
+// SetDeviceMetricsOverrideWithDisplayFeature is a functional option which sets the value of the
+// optional parameter `displayFeature` in the SetDeviceMetricsOverride CDP command, see SetDisplayFeature.
+func SetDeviceMetricsOverrideWithDisplayFeature(v DisplayFeature) SetDeviceMetricsOverrideOption {
+	return func(c *SetDeviceMetricsOverride) {
+		c.SetDisplayFeature(v)
+	}
+}
+
 // Do sends the SetDeviceMetricsOverride CDP command to a browser,
 // and returns the browser's response.
 func (t *SetDeviceMetricsOverride) Do(ctx context.Context) error {
@@ -797,17 +915,27 @@ type SetEmitTouchEventsForMouse struct {
 
 // NewSetEmitTouchEventsForMouse constructs a new SetEmitTouchEventsForMouse struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetEmitTouchEventsForMouseOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setEmitTouchEventsForMouse
 //
 // This CDP method is experimental.
-func NewSetEmitTouchEventsForMouse(enabled bool) *SetEmitTouchEventsForMouse {
-	return &SetEmitTouchEventsForMouse{
+func NewSetEmitTouchEventsForMouse(enabled bool, opts ...SetEmitTouchEventsForMouseOption) *SetEmitTouchEventsForMouse {
+	c := &SetEmitTouchEventsForMouse{
 		Enabled: enabled,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetEmitTouchEventsForMouseOption is a functional option for the optional
+// parameters of the SetEmitTouchEventsForMouse CDP command, see the
+// NewSetEmitTouchEventsForMouse constructor. Options are applied in order.
+type SetEmitTouchEventsForMouseOption = func(*SetEmitTouchEventsForMouse)
+
 // SetConfiguration adds or modifies the value of the optional
 // parameter `configuration` in the SetEmitTouchEventsForMouse CDP command.
 //
@@ -817,6 +945,14 @@ func (t *SetEmitTouchEventsForMouse) SetConfiguration(v string) *SetEmitTouchEve
 	return t
 }
 
+// SetEmitTouchEventsForMouseWithConfiguration is a functional option which sets the value of the
+// optional parameter `configuration` in the SetEmitTouchEventsForMouse CDP command, see SetConfiguration.
+func SetEmitTouchEventsForMouseWithConfiguration(v string) SetEmitTouchEventsForMouseOption {
+	return func(c *SetEmitTouchEventsForMouse) {
+		c.SetConfiguration(v)
+	}
+}
+
 // Do sends the SetEmitTouchEventsForMouse CDP command to a browser,
 // and returns the browser's response.
 func (t *SetEmitTouchEventsForMouse) Do(ctx context.Context) error {
@@ -867,13 +1003,23 @@ type SetEmulatedMedia struct {
 
 // NewSetEmulatedMedia constructs a new SetEmulatedMedia struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetEmulatedMediaOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setEmulatedMedia
-func NewSetEmulatedMedia() *SetEmulatedMedia {
-	return &SetEmulatedMedia{}
+func NewSetEmulatedMedia(opts ...SetEmulatedMediaOption) *SetEmulatedMedia {
+	c := &SetEmulatedMedia{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetEmulatedMediaOption is a functional option for the optional
+// parameters of the SetEmulatedMedia CDP command, see the
+// NewSetEmulatedMedia constructor. Options are applied in order.
+type SetEmulatedMediaOption = func(*SetEmulatedMedia)
+
 // SetMedia adds or modifies the value of the optional
 // parameter `media` in the SetEmulatedMedia CDP command.
 //
@@ -883,6 +1029,14 @@ func (t *SetEmulatedMedia) SetMedia(v string) *SetEmulatedMedia {
 	return t
 }
 
+// SetEmulatedMediaWithMedia is a functional option which sets the value of the
+// optional parameter `media` in the SetEmulatedMedia CDP command, see SetMedia.
+func SetEmulatedMediaWithMedia(v string) SetEmulatedMediaOption {
+	return func(c *SetEmulatedMedia) {
+		c.SetMedia(v)
+	}
+}
+
 // SetFeatures adds or modifies the value of the optional
 // parameter `features` in the SetEmulatedMedia CDP command.
 //
@@ -892,6 +1046,14 @@ func (t *SetEmulatedMedia) SetFeatures(v []MediaFeature) *SetEmulatedMedia {
 	return t
 }
 
+// SetEmulatedMediaWithFeatures is a functional option which sets the value of the
+// optional parameter `features` in the SetEmulatedMedia CDP command, see SetFeatures.
+func SetEmulatedMediaWithFeatures(v []MediaFeature) SetEmulatedMediaOption {
+	return func(c *SetEmulatedMedia) {
+		c.SetFeatures(v)
+	}
+}
+
 // Do sends the SetEmulatedMedia CDP command to a browser,
 // and returns the browser's response.
 func (t *SetEmulatedMedia) Do(ctx context.Context) error {
@@ -1006,13 +1168,23 @@ type SetGeolocationOverride struct {
 
 // NewSetGeolocationOverride constructs a new SetGeolocationOverride struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetGeolocationOverrideOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setGeolocationOverride
-func NewSetGeolocationOverride() *SetGeolocationOverride {
-	return &SetGeolocationOverride{}
+func NewSetGeolocationOverride(opts ...SetGeolocationOverrideOption) *SetGeolocationOverride {
+	c := &SetGeolocationOverride{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetGeolocationOverrideOption is a functional option for the optional
+// parameters of the SetGeolocationOverride CDP command, see the
+// NewSetGeolocationOverride constructor. Options are applied in order.
+type SetGeolocationOverrideOption = func(*SetGeolocationOverride)
+
 // SetLatitude adds or modifies the value of the optional
 // parameter `latitude` in the SetGeolocationOverride CDP command.
 //
@@ -1022,6 +1194,14 @@ func (t *SetGeolocationOverride) SetLatitude(v float64) *SetGeolocationOverride
 	return t
 }
 
+// SetGeolocationOverrideWithLatitude is a functional option which sets the value of the
+// optional parameter `latitude` in the SetGeolocationOverride CDP command, see SetLatitude.
+func SetGeolocationOverrideWithLatitude(v float64) SetGeolocationOverrideOption {
+	return func(c *SetGeolocationOverride) {
+		c.SetLatitude(v)
+	}
+}
+
 // SetLongitude adds or modifies the value of the optional
 // parameter `longitude` in the SetGeolocationOverride CDP command.
 //
@@ -1031,6 +1211,14 @@ func (t *SetGeolocationOverride) SetLongitude(v float64) *SetGeolocationOverride
 	return t
 }
 
+// SetGeolocationOverrideWithLongitude is a functional option which sets the value of the
+// optional parameter `longitude` in the SetGeolocationOverride CDP command, see SetLongitude.
+func SetGeolocationOverrideWithLongitude(v float64) SetGeolocationOverrideOption {
+	return func(c *SetGeolocationOverride) {
+		c.SetLongitude(v)
+	}
+}
+
 // SetAccuracy adds or modifies the value of the optional
 // parameter `accuracy` in the SetGeolocationOverride CDP command.
 //
@@ -1040,6 +1228,14 @@ func (t *SetGeolocationOverride) SetAccuracy(v float64) *SetGeolocationOverride
 	return t
 }
 
+// SetGeolocationOverrideWithAccuracy is a functional option which sets the value of the
+// optional parameter `accuracy` in the SetGeolocationOverride CDP command, see SetAccuracy.
+func SetGeolocationOverrideWithAccuracy(v float64) SetGeolocationOverrideOption {
+	return func(c *SetGeolocationOverride) {
+		c.SetAccuracy(v)
+	}
+}
+
 // Do sends the SetGeolocationOverride CDP command to a browser,
 // and returns the browser's response.
 func (t *SetGeolocationOverride) Do(ctx context.Context) error {
@@ -1378,29 +1574,47 @@ type SetTouchEmulationEnabled struct {
 	// Whether the touch event emulation should be enabled.
 	Enabled bool `json:"enabled"`
 	// Maximum touch points supported. Defaults to one.
-	MaxTouchPoints int64 `json:"maxTouchPoints,omitempty"`
+	MaxTouchPoints *int64 `json:"maxTouchPoints,omitempty"`
 }
 
 // NewSetTouchEmulationEnabled constructs a new SetTouchEmulationEnabled struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetTouchEmulationEnabledOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setTouchEmulationEnabled
-func NewSetTouchEmulationEnabled(enabled bool) *SetTouchEmulationEnabled {
-	return &SetTouchEmulationEnabled{
+func NewSetTouchEmulationEnabled(enabled bool, opts ...SetTouchEmulationEnabledOption) *SetTouchEmulationEnabled {
+	c := &SetTouchEmulationEnabled{
 		Enabled: enabled,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetTouchEmulationEnabledOption is a functional option for the optional
+// parameters of the SetTouchEmulationEnabled CDP command, see the
+// NewSetTouchEmulationEnabled constructor. Options are applied in order.
+type SetTouchEmulationEnabledOption = func(*SetTouchEmulationEnabled)
+
 // SetMaxTouchPoints adds or modifies the value of the optional
 // parameter `maxTouchPoints` in the SetTouchEmulationEnabled CDP command.
 //
 // Maximum touch points supported. Defaults to one.
 func (t *SetTouchEmulationEnabled) SetMaxTouchPoints(v int64) *SetTouchEmulationEnabled {
-	t.MaxTouchPoints = v
+	t.MaxTouchPoints = &v
 	return t
 }
 
+// SetTouchEmulationEnabledWithMaxTouchPoints is a functional option which sets the value of the
+// optional parameter `maxTouchPoints` in the SetTouchEmulationEnabled CDP command, see SetMaxTouchPoints.
+func SetTouchEmulationEnabledWithMaxTouchPoints(v int64) SetTouchEmulationEnabledOption {
+	return func(c *SetTouchEmulationEnabled) {
+		c.SetMaxTouchPoints(v)
+	}
+}
+
 // Do sends the SetTouchEmulationEnabled CDP command to a browser,
 // and returns the browser's response.
 func (t *SetTouchEmulationEnabled) Do(ctx context.Context) error {
@@ -1457,22 +1671,32 @@ type SetVirtualTimePolicy struct {
 	// Note any previous deferred policy change is superseded.
 	WaitForNavigation bool `json:"waitForNavigation,omitempty"`
 	// If set, base::Time::Now will be overridden to initially return this value.
-	InitialVirtualTime float64 `json:"initialVirtualTime,omitempty"`
+	InitialVirtualTime devtools.TimeSinceEpoch `json:"initialVirtualTime,omitempty"`
 }
 
 // NewSetVirtualTimePolicy constructs a new SetVirtualTimePolicy struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetVirtualTimePolicyOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setVirtualTimePolicy
 //
 // This CDP method is experimental.
-func NewSetVirtualTimePolicy(policy VirtualTimePolicy) *SetVirtualTimePolicy {
-	return &SetVirtualTimePolicy{
+func NewSetVirtualTimePolicy(policy VirtualTimePolicy, opts ...SetVirtualTimePolicyOption) *SetVirtualTimePolicy {
+	c := &SetVirtualTimePolicy{
 		Policy: policy,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetVirtualTimePolicyOption is a functional option for the optional
+// parameters of the SetVirtualTimePolicy CDP command, see the
+// NewSetVirtualTimePolicy constructor. Options are applied in order.
+type SetVirtualTimePolicyOption = func(*SetVirtualTimePolicy)
+
 // SetBudget adds or modifies the value of the optional
 // parameter `budget` in the SetVirtualTimePolicy CDP command.
 //
@@ -1483,6 +1707,14 @@ func (t *SetVirtualTimePolicy) SetBudget(v float64) *SetVirtualTimePolicy {
 	return t
 }
 
+// SetVirtualTimePolicyWithBudget is a functional option which sets the value of the
+// optional parameter `budget` in the SetVirtualTimePolicy CDP command, see SetBudget.
+func SetVirtualTimePolicyWithBudget(v float64) SetVirtualTimePolicyOption {
+	return func(c *SetVirtualTimePolicy) {
+		c.SetBudget(v)
+	}
+}
+
 // SetMaxVirtualTimeTaskStarvationCount adds or modifies the value of the optional
 // parameter `maxVirtualTimeTaskStarvationCount` in the SetVirtualTimePolicy CDP command.
 //
@@ -1493,6 +1725,14 @@ func (t *SetVirtualTimePolicy) SetMaxVirtualTimeTaskStarvationCount(v int64) *Se
 	return t
 }
 
+// SetVirtualTimePolicyWithMaxVirtualTimeTaskStarvationCount is a functional option which sets the value of the
+// optional parameter `maxVirtualTimeTaskStarvationCount` in the SetVirtualTimePolicy CDP command, see SetMaxVirtualTimeTaskStarvationCount.
+func SetVirtualTimePolicyWithMaxVirtualTimeTaskStarvationCount(v int64) SetVirtualTimePolicyOption {
+	return func(c *SetVirtualTimePolicy) {
+		c.SetMaxVirtualTimeTaskStarvationCount(v)
+	}
+}
+
 // SetWaitForNavigation adds or modifies the value of the optional
 // parameter `waitForNavigation` in the SetVirtualTimePolicy CDP command.
 //
@@ -1503,15 +1743,31 @@ func (t *SetVirtualTimePolicy) SetWaitForNavigation(v bool) *SetVirtualTimePolic
 	return t
 }
 
+// SetVirtualTimePolicyWithWaitForNavigation is a functional option which sets the value of the
+// optional parameter `waitForNavigation` in the SetVirtualTimePolicy CDP command, see SetWaitForNavigation.
+func SetVirtualTimePolicyWithWaitForNavigation(v bool) SetVirtualTimePolicyOption {
+	return func(c *SetVirtualTimePolicy) {
+		c.SetWaitForNavigation(v)
+	}
+}
+
 // SetInitialVirtualTime adds or modifies the value of the optional
 // parameter `initialVirtualTime` in the SetVirtualTimePolicy CDP command.
 //
 // If set, base::Time::Now will be overridden to initially return this value.
-func (t *SetVirtualTimePolicy) SetInitialVirtualTime(v float64) *SetVirtualTimePolicy {
+func (t *SetVirtualTimePolicy) SetInitialVirtualTime(v devtools.TimeSinceEpoch) *SetVirtualTimePolicy {
 	t.InitialVirtualTime = v
 	return t
 }
 
+// SetVirtualTimePolicyWithInitialVirtualTime is a functional option which sets the value of the
+// optional parameter `initialVirtualTime` in the SetVirtualTimePolicy CDP command, see SetInitialVirtualTime.
+func SetVirtualTimePolicyWithInitialVirtualTime(v devtools.TimeSinceEpoch) SetVirtualTimePolicyOption {
+	return func(c *SetVirtualTimePolicy) {
+		c.SetInitialVirtualTime(v)
+	}
+}
+
 // SetVirtualTimePolicyResult contains the browser's response
 // to calling the SetVirtualTimePolicy CDP command with Do().
 type SetVirtualTimePolicyResult struct {
@@ -1574,15 +1830,25 @@ type SetLocaleOverride struct {
 
 // NewSetLocaleOverride constructs a new SetLocaleOverride struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetLocaleOverrideOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setLocaleOverride
 //
 // This CDP method is experimental.
-func NewSetLocaleOverride() *SetLocaleOverride {
-	return &SetLocaleOverride{}
+func NewSetLocaleOverride(opts ...SetLocaleOverrideOption) *SetLocaleOverride {
+	c := &SetLocaleOverride{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetLocaleOverrideOption is a functional option for the optional
+// parameters of the SetLocaleOverride CDP command, see the
+// NewSetLocaleOverride constructor. Options are applied in order.
+type SetLocaleOverrideOption = func(*SetLocaleOverride)
+
 // SetLocale adds or modifies the value of the optional
 // parameter `locale` in the SetLocaleOverride CDP command.
 //
@@ -1593,6 +1859,14 @@ func (t *SetLocaleOverride) SetLocale(v string) *SetLocaleOverride {
 	return t
 }
 
+// SetLocaleOverrideWithLocale is a functional option which sets the value of the
+// optional parameter `locale` in the SetLocaleOverride CDP command, see SetLocale.
+func SetLocaleOverrideWithLocale(v string) SetLocaleOverrideOption {
+	return func(c *SetLocaleOverride) {
+		c.SetLocale(v)
+	}
+}
+
 // Do sends the SetLocaleOverride CDP command to a browser,
 // and returns the browser's response.
 func (t *SetLocaleOverride) Do(ctx context.Context) error {
@@ -1838,15 +2112,25 @@ type SetUserAgentOverride struct {
 
 // NewSetUserAgentOverride constructs a new SetUserAgentOverride struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetUserAgentOverrideOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setUserAgentOverride
-func NewSetUserAgentOverride(userAgent string) *SetUserAgentOverride {
-	return &SetUserAgentOverride{
+func NewSetUserAgentOverride(userAgent string, opts ...SetUserAgentOverrideOption) *SetUserAgentOverride {
+	c := &SetUserAgentOverride{
 		UserAgent: userAgent,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetUserAgentOverrideOption is a functional option for the optional
+// parameters of the SetUserAgentOverride CDP command, see the
+// NewSetUserAgentOverride constructor. Options are applied in order.
+type SetUserAgentOverrideOption = func(*SetUserAgentOverride)
+
 // SetAcceptLanguage adds or modifies the value of the optional
 // parameter `acceptLanguage` in the SetUserAgentOverride CDP command.
 //
@@ -1856,6 +2140,14 @@ func (t *SetUserAgentOverride) SetAcceptLanguage(v string) *SetUserAgentOverride
 	return t
 }
 
+// SetUserAgentOverrideWithAcceptLanguage is a functional option which sets the value of the
+// optional parameter `acceptLanguage` in the SetUserAgentOverride CDP command, see SetAcceptLanguage.
+func SetUserAgentOverrideWithAcceptLanguage(v string) SetUserAgentOverrideOption {
+	return func(c *SetUserAgentOverride) {
+		c.SetAcceptLanguage(v)
+	}
+}
+
 // SetPlatform adds or modifies the value of the optional
 // parameter `platform` in the SetUserAgentOverride CDP command.
 //
@@ -1865,6 +2157,14 @@ func (t *SetUserAgentOverride) SetPlatform(v string) *SetUserAgentOverride {
 	return t
 }
 
+// SetUserAgentOverrideWithPlatform is a functional option which sets the value of the
+// optional parameter `platform` in the SetUserAgentOverride CDP command, see SetPlatform.
+func SetUserAgentOverrideWithPlatform(v string) SetUserAgentOverrideOption {
+	return func(c *SetUserAgentOverride) {
+		c.SetPlatform(v)
+	}
+}
+
 // SetUserAgentMetadata adds or modifies the value of the optional
 // parameter `userAgentMetadata` in the SetUserAgentOverride CDP command.
 //
@@ -1876,6 +2176,14 @@ func (t *SetUserAgentOverride) SetUserAgentMetadata(v UserAgentMetadata) *SetUse
 	return t
 }
 
+// SetUserAgentOverrideWithUserAgentMetadata is a functional option which sets the value of the
+// optional parameter `userAgentMetadata` in the SetUserAgentOverride CDP command, see SetUserAgentMetadata.
+func SetUserAgentOverrideWithUserAgentMetadata(v UserAgentMetadata) SetUserAgentOverrideOption {
+	return func(c *SetUserAgentOverride) {
+		c.SetUserAgentMetadata(v)
+	}
+}
+
 // Do sends the SetUserAgentOverride CDP command to a browser,
 // and returns the browser's response.
 func (t *SetUserAgentOverride) Do(ctx context.Context) error {
@@ -0,0 +1,115 @@
+package emulation
+
+import (
+	"context"
+	"encoding/base64"
+	"math"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/browser"
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// DefaultScreenshotScale is the minimum device pixel ratio of screenshots
+// captured by the `emulation.CaptureScreenshot` function.
+const DefaultScreenshotScale = 2.0
+
+type screenshotConfig struct {
+	scale    float64
+	fullPage bool
+	format   string
+	quality  int64
+}
+
+// ScreenshotOption is used for customization in
+// the `emulation.CaptureScreenshot` function.
+type ScreenshotOption = func(*screenshotConfig)
+
+// ScreenshotScale allows the caller of the `emulation.CaptureScreenshot`
+// function to customize the device pixel ratio of the screenshot (default:
+// the ratio of the screen which displays the browser window, but
+// at least `emulation.DefaultScreenshotScale`).
+func ScreenshotScale(f float64) ScreenshotOption {
+	return func(c *screenshotConfig) {
+		c.scale = f
+	}
+}
+
+// FullPageScreenshot allows the caller of the `emulation.CaptureScreenshot`
+// function to capture the entire scrollable page, instead of the viewport.
+func FullPageScreenshot() ScreenshotOption {
+	return func(c *screenshotConfig) {
+		c.fullPage = true
+	}
+}
+
+// JPEGScreenshot allows the caller of the `emulation.CaptureScreenshot`
+// function to capture a JPEG image with the given quality (0-100),
+// instead of a PNG image.
+func JPEGScreenshot(quality int64) ScreenshotOption {
+	return func(c *screenshotConfig) {
+		c.format = "jpeg"
+		c.quality = quality
+	}
+}
+
+// CaptureScreenshot captures a crisp high-DPI screenshot of the page which
+// is associated with the given context, and returns the image data. It
+// temporarily overrides the device metrics of the page to match the size
+// and position of the browser window, with a high device pixel ratio (see
+// the `emulation.ScreenshotScale` option), and restores the previous
+// emulation overrides afterwards (see `emulation.Push` and `emulation.Pop`).
+func CaptureScreenshot(ctx context.Context, opts ...ScreenshotOption) ([]byte, error) {
+	cfg := &screenshotConfig{format: "png"}
+	for _, o := range opts {
+		o(cfg)
+	}
+
+	// The device pixel ratio of the screen which currently displays the
+	// window, e.g. when there are multiple monitors with different DPIs.
+	if cfg.scale == 0 {
+		dpr := 0.0
+		if err := runtime.Eval(ctx, "window.devicePixelRatio", &dpr); err != nil {
+			return nil, err
+		}
+		cfg.scale = math.Max(dpr, DefaultScreenshotScale)
+	}
+
+	m, err := page.NewGetLayoutMetrics().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	width, height := m.CSSLayoutViewport.ClientWidth, m.CSSLayoutViewport.ClientHeight
+	o := NewSetDeviceMetricsOverride(width, height, cfg.scale, false)
+	// Keep the window's position on the screen (e.g. `window.screenX`) intact.
+	if _, b, err := browser.Window(ctx); err == nil {
+		o.SetPositionX(b.Left).SetPositionY(b.Top)
+	}
+	if err := Push(ctx); err != nil {
+		return nil, err
+	}
+	defer Pop(ctx)
+	if err := o.Do(ctx); err != nil {
+		return nil, err
+	}
+
+	s := page.NewCaptureScreenshot().SetFormat(cfg.format)
+	if cfg.format == "jpeg" {
+		s.SetQuality(cfg.quality)
+	}
+	if cfg.fullPage {
+		// Re-measure, because the page may reflow after the override.
+		m, err := page.NewGetLayoutMetrics().Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.SetCaptureBeyondViewport(true).SetClip(page.Viewport{
+			Width: m.CSSContentSize.Width, Height: m.CSSContentSize.Height, Scale: 1,
+		})
+	}
+	r, err := s.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(r.Data)
+}
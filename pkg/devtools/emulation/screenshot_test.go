@@ -0,0 +1,67 @@
+package emulation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCaptureScreenshotRestoresOverrides(t *testing.T) {
+	tests := []struct {
+		name     string
+		override *SetDeviceMetricsOverride // Before the screenshot.
+		want     []string
+	}{
+		{
+			name: "no_previous_override",
+			want: []string{
+				`Emulation.setDeviceMetricsOverride {"width":1024,"height":768,"deviceScaleFactor":2,"mobile":false}`,
+				`Emulation.clearDeviceMetricsOverride `,
+			},
+		},
+		{
+			name:     "previous_override",
+			override: NewSetDeviceMetricsOverride(375, 812, 3, true),
+			want: []string{
+				`Emulation.setDeviceMetricsOverride {"width":375,"height":812,"deviceScaleFactor":3,"mobile":true}`,
+				`Emulation.setDeviceMetricsOverride {"width":1024,"height":768,"deviceScaleFactor":2,"mobile":false}`,
+				`Emulation.setDeviceMetricsOverride {"width":375,"height":812,"deviceScaleFactor":3,"mobile":true}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+			b.Respond("Page.getLayoutMetrics", &page.GetLayoutMetricsResult{
+				CSSLayoutViewport: page.LayoutViewport{ClientWidth: 1024, ClientHeight: 768},
+			})
+			b.Respond("Page.captureScreenshot", &page.CaptureScreenshotResult{Data: "aW1n"})
+			if tt.override != nil {
+				if err := tt.override.Do(ctx); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			img, err := CaptureScreenshot(ctx, ScreenshotScale(2))
+			if err != nil {
+				t.Fatalf("CaptureScreenshot(); got error: %v", err)
+			}
+			if string(img) != "img" {
+				t.Errorf("CaptureScreenshot() = %q, want %q", img, "img")
+			}
+
+			var got []string
+			for _, c := range b.Calls("Emulation.setDeviceMetricsOverride", "Emulation.clearDeviceMetricsOverride") {
+				got = append(got, c.Method+" "+string(c.Params))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("device metrics commands mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
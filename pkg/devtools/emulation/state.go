@@ -0,0 +1,152 @@
+package emulation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// An emulation override which `emulation.Push` and `emulation.Pop` snapshot
+// and restore: the command which sets it, and either the command which
+// clears it, or the parameters which reset it with the same command.
+type override struct {
+	set, clear string
+	reset      json.RawMessage
+}
+
+var overrides = []override{
+	{set: "Emulation.setDeviceMetricsOverride", clear: "Emulation.clearDeviceMetricsOverride"},
+	{set: "Emulation.setGeolocationOverride", clear: "Emulation.clearGeolocationOverride"},
+	{set: "Emulation.setUserAgentOverride", reset: json.RawMessage(`{"userAgent":""}`)},
+	{set: "Emulation.setEmulatedMedia", reset: json.RawMessage(`{"media":"","features":[]}`)},
+	{set: "Emulation.setTimezoneOverride", reset: json.RawMessage(`{"timezoneId":""}`)},
+	{set: "Emulation.setLocaleOverride", reset: json.RawMessage(`{}`)},
+	{set: "Emulation.setCPUThrottlingRate", reset: json.RawMessage(`{"rate":1}`)},
+}
+
+func init() {
+	for _, o := range overrides {
+		devtools.TrackCommands(o.set)
+		if o.clear != "" {
+			devtools.TrackCommands(o.clear)
+		}
+	}
+}
+
+// State of a single override: the latest "set" and "clear" commands (nil if
+// they weren't sent).
+type overrideState struct {
+	set, clear *devtools.CommandRecord
+}
+
+// Whether the override is in effect, and with which parameters.
+func (s overrideState) active() (json.RawMessage, bool) {
+	if s.set == nil || (s.clear != nil && s.clear.Seq > s.set.Seq) {
+		return nil, false
+	}
+	return s.set.Params, true
+}
+
+func (s overrideState) equal(o overrideState) bool {
+	p1, ok1 := s.active()
+	p2, ok2 := o.active()
+	return ok1 == ok2 && bytes.Equal(p1, p2)
+}
+
+// Snapshots of each session's overrides, pushed by `emulation.Push`.
+// A session's entry is deleted when the session ends (see `watch`).
+var (
+	stacksMu sync.Mutex
+	stacks   = make(map[*devtools.Session][][]overrideState)
+)
+
+func snapshot(ctx context.Context) []overrideState {
+	states := make([]overrideState, len(overrides))
+	for i, o := range overrides {
+		states[i].set = devtools.LastCommand(ctx, o.set)
+		if o.clear != "" {
+			states[i].clear = devtools.LastCommand(ctx, o.clear)
+		}
+	}
+	return states
+}
+
+// Push saves the current emulation overrides of the tab which is associated
+// with the given context: device metrics, user agent, geolocation, emulated
+// media type and features, timezone, locale, and CPU throttling. Helpers can
+// then change them temporarily, and call `emulation.Pop` to restore them,
+// without leaking state into subsequent steps. Calls may be nested.
+//
+// The browser doesn't report its current overrides, so this function is
+// based on the CDP commands which were sent to set and clear them (see
+// the `devtools.LastCommand` function).
+func Push(ctx context.Context) error {
+	s, ok := devtools.FromContext(ctx)
+	if !ok {
+		return errors.New("context not initialized with devtools.NewContext")
+	}
+	states := snapshot(ctx)
+	stacksMu.Lock()
+	defer stacksMu.Unlock()
+	stack, ok := stacks[s]
+	if !ok {
+		go watch(s, devtools.SessionDone(ctx))
+	}
+	stacks[s] = append(stack, states)
+	return nil
+}
+
+// Delete the session's snapshots when it ends, even if there are
+// more calls to `emulation.Push` than to `emulation.Pop`.
+func watch(s *devtools.Session, done <-chan struct{}) {
+	<-done
+	stacksMu.Lock()
+	defer stacksMu.Unlock()
+	delete(stacks, s)
+}
+
+// Pop restores the emulation overrides which the latest call to
+// `emulation.Push` saved in the tab which is associated with the given
+// context, by resending or clearing only the overrides which changed since
+// then. It returns an error if there's no such call.
+func Pop(ctx context.Context) error {
+	s, ok := devtools.FromContext(ctx)
+	if !ok {
+		return errors.New("context not initialized with devtools.NewContext")
+	}
+	stacksMu.Lock()
+	stack := stacks[s]
+	if len(stack) == 0 {
+		stacksMu.Unlock()
+		return errors.New("emulation.Pop called without emulation.Push")
+	}
+	saved := stack[len(stack)-1]
+	stacks[s] = stack[:len(stack)-1]
+	stacksMu.Unlock()
+
+	current := snapshot(ctx)
+	for i, o := range overrides {
+		if current[i].equal(saved[i]) {
+			continue
+		}
+		method, params := o.clear, o.reset
+		if p, ok := saved[i].active(); ok {
+			method, params = o.set, p
+		} else if method == "" {
+			method = o.set
+		}
+		m, err := devtools.SendAndWait(ctx, method, params)
+		if err != nil {
+			return fmt.Errorf("failed to restore %q: %w", o.set, err)
+		}
+		if m.Error != nil {
+			return fmt.Errorf("failed to restore %q: %s", o.set, m.Error.Error())
+		}
+	}
+	return nil
+}
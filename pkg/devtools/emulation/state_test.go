@@ -0,0 +1,69 @@
+package emulation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPushPop(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	if err := NewSetDeviceMetricsOverride(800, 600, 1, false).Do(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewSetTimezoneOverride("Europe/Paris").Do(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Push(ctx); err != nil {
+		t.Fatal(err)
+	}
+	NewSetDeviceMetricsOverride(375, 812, 3, true).Do(ctx)
+	NewSetGeolocationOverride(SetGeolocationOverrideWithLatitude(1), SetGeolocationOverrideWithLongitude(2)).Do(ctx)
+	NewSetUserAgentOverride("Test/1.0").Do(ctx)
+	NewClearDeviceMetricsOverride().Do(ctx)
+	n := len(b.Calls())
+	if err := Pop(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, c := range b.Calls()[n:] {
+		got = append(got, c.Method+" "+string(c.Params))
+	}
+	want := []string{
+		`Emulation.setDeviceMetricsOverride {"width":800,"height":600,"deviceScaleFactor":1,"mobile":false}`,
+		`Emulation.clearGeolocationOverride `,
+		`Emulation.setUserAgentOverride {"userAgent":""}`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Pop() commands mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := Pop(ctx); err == nil {
+		t.Error("Pop() without Push() error = nil")
+	}
+}
+
+func TestPushSessionEnd(t *testing.T) {
+	ctx, _ := devtoolstest.NewContext(context.Background())
+	s, _ := devtools.FromContext(ctx)
+	if err := Push(ctx); err != nil {
+		t.Fatal(err)
+	}
+	devtools.Cancel(ctx)
+	for i := 0; i < 100; i++ {
+		stacksMu.Lock()
+		_, ok := stacks[s]
+		stacksMu.Unlock()
+		if !ok {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Error("Push() snapshots weren't deleted when the session ended")
+}
@@ -0,0 +1,199 @@
+package emulation
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strconv"
+)
+
+// UserAgentPlatform is the operating system which is reported by
+// the `emulation.UserAgentPreset` function.
+type UserAgentPlatform string
+
+// UserAgentPlatform valid values.
+const (
+	WindowsPlatform  UserAgentPlatform = "Windows"
+	MacOSPlatform    UserAgentPlatform = "macOS"
+	LinuxPlatform    UserAgentPlatform = "Linux"
+	ChromeOSPlatform UserAgentPlatform = "Chrome OS"
+	AndroidPlatform  UserAgentPlatform = "Android"
+)
+
+// Platform details which Chrome reports after the user agent
+// reduction, see https://www.chromium.org/updates/ua-reduction/.
+type platformDetails struct {
+	userAgent       string // The platform part of the user agent string.
+	navigator       string // The value of navigator.platform.
+	platformVersion string
+	architecture    string
+}
+
+var platforms = map[UserAgentPlatform]platformDetails{
+	WindowsPlatform:  {"Windows NT 10.0; Win64; x64", "Win32", "10.0.0", "x86"},
+	MacOSPlatform:    {"Macintosh; Intel Mac OS X 10_15_7", "MacIntel", "10.15.7", "x86"},
+	LinuxPlatform:    {"X11; Linux x86_64", "Linux x86_64", "", "x86"},
+	ChromeOSPlatform: {"X11; CrOS x86_64 14541.0.0", "Linux x86_64", "14541.0.0", "x86"},
+	AndroidPlatform:  {"Linux; Android 10; K", "Linux armv81", "10.0.0", ""},
+}
+
+type userAgentConfig struct {
+	platform        UserAgentPlatform
+	platformVersion string
+	architecture    string
+	model           string
+	acceptLanguage  string
+}
+
+// UserAgentPresetOption is used for customization in
+// the `emulation.UserAgentPreset` function.
+type UserAgentPresetOption = func(*userAgentConfig)
+
+// PresetPlatform allows the caller of the `emulation.UserAgentPreset` function
+// to report a different operating system (default: the one which runs this
+// program, or Linux if it's not a desktop platform which Chrome supports).
+// It also resets the platform version and architecture to their defaults.
+func PresetPlatform(p UserAgentPlatform) UserAgentPresetOption {
+	return func(c *userAgentConfig) {
+		c.platform = p
+		c.platformVersion = platforms[p].platformVersion
+		c.architecture = platforms[p].architecture
+	}
+}
+
+// PresetPlatformVersion allows the caller of the `emulation.UserAgentPreset`
+// function to customize the operating system version in the client hints
+// (default: Windows 10, macOS 10.15.7, or Android 10, like the frozen values
+// in the user agent string). It doesn't affect the user agent string.
+func PresetPlatformVersion(v string) UserAgentPresetOption {
+	return func(c *userAgentConfig) {
+		c.platformVersion = v
+	}
+}
+
+// PresetArchitecture allows the caller of the `emulation.UserAgentPreset`
+// function to customize the CPU architecture in the client hints, e.g. "arm"
+// (default: "x86" in desktop platforms). It doesn't affect the user agent
+// string, which always reports an Intel CPU.
+func PresetArchitecture(a string) UserAgentPresetOption {
+	return func(c *userAgentConfig) {
+		c.architecture = a
+	}
+}
+
+// PresetModel allows the caller of the `emulation.UserAgentPreset` function
+// to report the model of an Android device in the client hints, e.g. "Pixel 7".
+// It doesn't affect the user agent string, which always reports "K".
+func PresetModel(m string) UserAgentPresetOption {
+	return func(c *userAgentConfig) {
+		c.model = m
+	}
+}
+
+// PresetAcceptLanguage allows the caller of the `emulation.UserAgentPreset`
+// function to override the Accept-Language HTTP header as well.
+func PresetAcceptLanguage(l string) UserAgentPresetOption {
+	return func(c *userAgentConfig) {
+		c.acceptLanguage = l
+	}
+}
+
+var chromeVersion = regexp.MustCompile(`^(\d+)(\.\d+\.\d+\.\d+)?$`)
+
+// UserAgentPreset returns a complete and consistent user agent override of
+// the given Chrome version (e.g. "120.0.6099.109", or only the major version
+// "120"), which may be sent with its `Do` method like any other CDP command.
+//
+// It follows the user agent reduction: the user agent string contains only
+// the major version and frozen platform details, and the client hints include
+// the same brands as real Chrome, with a "GREASE" brand which is generated
+// with Chromium's algorithm (seeded by the major version), in the same order.
+// This way, automation keeps up with future Chrome versions and reduction
+// changes, instead of relying on a hard-coded user agent string.
+func UserAgentPreset(version string, opts ...UserAgentPresetOption) (*SetUserAgentOverride, error) {
+	m := chromeVersion.FindStringSubmatch(version)
+	if m == nil {
+		return nil, fmt.Errorf("invalid Chrome version: %q", version)
+	}
+	major, err := strconv.Atoi(m[1])
+	if err != nil || major == 0 {
+		return nil, fmt.Errorf("invalid Chrome major version: %q", version)
+	}
+	if m[2] == "" {
+		version += ".0.0.0"
+	}
+
+	c := &userAgentConfig{}
+	switch runtime.GOOS {
+	case "windows":
+		PresetPlatform(WindowsPlatform)(c)
+	case "darwin":
+		PresetPlatform(MacOSPlatform)(c)
+	default:
+		PresetPlatform(LinuxPlatform)(c)
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	p, ok := platforms[c.platform]
+	if !ok {
+		return nil, fmt.Errorf("unsupported platform: %q", c.platform)
+	}
+
+	mobile := c.platform == AndroidPlatform
+	safari := "Safari/537.36"
+	if mobile {
+		safari = "Mobile " + safari
+	}
+	ua := fmt.Sprintf("Mozilla/5.0 (%s) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/%d.0.0.0 %s",
+		p.userAgent, major, safari)
+
+	grease, greaseVersion := greasedBrand(major)
+	brands := []UserAgentBrandVersion{
+		{Brand: grease, Version: greaseVersion},
+		{Brand: "Chromium", Version: m[1]},
+		{Brand: "Google Chrome", Version: m[1]},
+	}
+	fullVersions := []UserAgentBrandVersion{
+		{Brand: grease, Version: greaseVersion + ".0.0.0"},
+		{Brand: "Chromium", Version: version},
+		{Brand: "Google Chrome", Version: version},
+	}
+	md := UserAgentMetadata{
+		Brands:          permuteBrands(brands, major),
+		FullVersionList: permuteBrands(fullVersions, major),
+		FullVersion:     version,
+		Platform:        string(c.platform),
+		PlatformVersion: c.platformVersion,
+		Architecture:    c.architecture,
+		Model:           c.model,
+		Mobile:          mobile,
+	}
+	cmd := NewSetUserAgentOverride(ua).SetPlatform(p.navigator).SetUserAgentMetadata(md)
+	if c.acceptLanguage != "" {
+		cmd.SetAcceptLanguage(c.acceptLanguage)
+	}
+	return cmd, nil
+}
+
+// Return the name and version of the "GREASE" brand of the given Chrome
+// major version, like `GetGreasedUserAgentBrandVersion` in Chromium's
+// components/embedder_support/user_agent_utils.cc.
+func greasedBrand(seed int) (string, string) {
+	chars := []string{" ", "(", ":", "-", ".", "/", ")", ";", "=", "?", "_"}
+	versions := []string{"8", "99", "24"}
+	brand := "Not" + chars[seed%len(chars)] + "A" + chars[(seed+1)%len(chars)] + "Brand"
+	return brand, versions[seed%len(versions)]
+}
+
+// Reorder the given brands (GREASE, Chromium, Google Chrome) with
+// a stable permutation which is seeded by the major version.
+func permuteBrands(b []UserAgentBrandVersion, seed int) []UserAgentBrandVersion {
+	orders := [6][3]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}
+	order := orders[seed%len(orders)]
+	permuted := make([]UserAgentBrandVersion, len(b))
+	for i, j := range order {
+		permuted[j] = b[i]
+	}
+	return permuted
+}
@@ -0,0 +1,116 @@
+package emulation
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUserAgentPreset(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		opts    []UserAgentPresetOption
+		want    *SetUserAgentOverride
+	}{
+		{
+			name:    "windows",
+			version: "120.0.6099.109",
+			opts:    []UserAgentPresetOption{PresetPlatform(WindowsPlatform), PresetAcceptLanguage("en-US")},
+			want: &SetUserAgentOverride{
+				UserAgent:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+				AcceptLanguage: "en-US",
+				Platform:       "Win32",
+				UserAgentMetadata: &UserAgentMetadata{
+					Brands: []UserAgentBrandVersion{
+						{Brand: "Not_A Brand", Version: "8"},
+						{Brand: "Chromium", Version: "120"},
+						{Brand: "Google Chrome", Version: "120"},
+					},
+					FullVersionList: []UserAgentBrandVersion{
+						{Brand: "Not_A Brand", Version: "8.0.0.0"},
+						{Brand: "Chromium", Version: "120.0.6099.109"},
+						{Brand: "Google Chrome", Version: "120.0.6099.109"},
+					},
+					FullVersion:     "120.0.6099.109",
+					Platform:        "Windows",
+					PlatformVersion: "10.0.0",
+					Architecture:    "x86",
+				},
+			},
+		},
+		{
+			name:    "mac_arm",
+			version: "121",
+			opts:    []UserAgentPresetOption{PresetPlatform(MacOSPlatform), PresetPlatformVersion("14.1.0"), PresetArchitecture("arm")},
+			want: &SetUserAgentOverride{
+				UserAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/121.0.0.0 Safari/537.36",
+				Platform:  "MacIntel",
+				UserAgentMetadata: &UserAgentMetadata{
+					Brands: []UserAgentBrandVersion{
+						{Brand: "Not A(Brand", Version: "99"},
+						{Brand: "Google Chrome", Version: "121"},
+						{Brand: "Chromium", Version: "121"},
+					},
+					FullVersionList: []UserAgentBrandVersion{
+						{Brand: "Not A(Brand", Version: "99.0.0.0"},
+						{Brand: "Google Chrome", Version: "121.0.0.0"},
+						{Brand: "Chromium", Version: "121.0.0.0"},
+					},
+					FullVersion:     "121.0.0.0",
+					Platform:        "macOS",
+					PlatformVersion: "14.1.0",
+					Architecture:    "arm",
+				},
+			},
+		},
+		{
+			name:    "android",
+			version: "122.0.6261.64",
+			opts:    []UserAgentPresetOption{PresetPlatform(AndroidPlatform), PresetModel("Pixel 7")},
+			want: &SetUserAgentOverride{
+				UserAgent: "Mozilla/5.0 (Linux; Android 10; K) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/122.0.0.0 Mobile Safari/537.36",
+				Platform:  "Linux armv81",
+				UserAgentMetadata: &UserAgentMetadata{
+					Brands: []UserAgentBrandVersion{
+						{Brand: "Chromium", Version: "122"},
+						{Brand: "Not(A:Brand", Version: "24"},
+						{Brand: "Google Chrome", Version: "122"},
+					},
+					FullVersionList: []UserAgentBrandVersion{
+						{Brand: "Chromium", Version: "122.0.6261.64"},
+						{Brand: "Not(A:Brand", Version: "24.0.0.0"},
+						{Brand: "Google Chrome", Version: "122.0.6261.64"},
+					},
+					FullVersion:     "122.0.6261.64",
+					Platform:        "Android",
+					PlatformVersion: "10.0.0",
+					Model:           "Pixel 7",
+					Mobile:          true,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UserAgentPreset(tt.version, tt.opts...)
+			if err != nil {
+				t.Fatalf("UserAgentPreset(%q); got error: %v", tt.version, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("UserAgentPreset(%q) mismatch (-want +got):\n%s", tt.version, diff)
+			}
+		})
+	}
+}
+
+func TestUserAgentPresetErrors(t *testing.T) {
+	for _, v := range []string{"", "0", "120.0", "v120", "120.0.6099.109.1"} {
+		if _, err := UserAgentPreset(v); err == nil {
+			t.Errorf("UserAgentPreset(%q); got nil error", v)
+		}
+	}
+	if _, err := UserAgentPreset("120", PresetPlatform("BeOS")); err == nil {
+		t.Error("UserAgentPreset() with unsupported platform; got nil error")
+	}
+}
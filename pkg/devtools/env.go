@@ -0,0 +1,80 @@
+package devtools
+
+import (
+	"sort"
+	"strings"
+)
+
+// BrowserEnv allows the caller of the `devtools.NewContext` function to set
+// environment variables for the browser process (e.g. "LANG", "TZ" and
+// "HTTPS_PROXY"), in addition to the environment that it inherits from
+// the Go process, overriding inherited variables with the same names.
+// Multiple calls are merged.
+//
+// This is useful for reproducible rendering of dates, numbers and fonts.
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser.
+func BrowserEnv(vars map[string]string) SessionOption {
+	return func(s *Session) {
+		if s.browserEnv == nil {
+			s.browserEnv = make(map[string]string)
+		}
+		for k, v := range vars {
+			s.browserEnv[k] = v
+		}
+	}
+}
+
+// ClearBrowserEnv allows the caller of the `devtools.NewContext` function to
+// start the browser process with only the environment variables which are
+// set with the `devtools.BrowserEnv` option, instead of inheriting the
+// Go process's environment. Note that browsers may need some variables
+// to run, e.g. "HOME" and "DISPLAY" on Linux when not headless.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser.
+func ClearBrowserEnv() SessionOption {
+	return func(s *Session) {
+		s.clearEnv = true
+	}
+}
+
+// BrowserWorkingDir allows the caller of the `devtools.NewContext` function
+// to set the working directory of the browser process, instead of inheriting
+// the Go process's current directory.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser.
+func BrowserWorkingDir(dir string) SessionOption {
+	return func(s *Session) {
+		s.browserDir = dir
+	}
+}
+
+// Return the environment of the browser process, in the format of
+// `os.Environ`, or nil to inherit the Go process's environment as is.
+func processEnv(s *Session, environ []string) []string {
+	if len(s.browserEnv) == 0 && !s.clearEnv {
+		return nil
+	}
+	env := []string{}
+	if !s.clearEnv {
+		for _, kv := range environ {
+			if i := strings.Index(kv, "="); i > 0 {
+				if _, ok := s.browserEnv[kv[:i]]; ok {
+					continue // Overridden below.
+				}
+			}
+			env = append(env, kv)
+		}
+	}
+	keys := make([]string, 0, len(s.browserEnv))
+	for k := range s.browserEnv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		env = append(env, k+"="+s.browserEnv[k])
+	}
+	return env
+}
@@ -0,0 +1,46 @@
+package devtools
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProcessEnv(t *testing.T) {
+	environ := []string{"HOME=/home/user", "LANG=en_US.UTF-8", "PATH=/bin"}
+	tests := []struct {
+		name string
+		opts []SessionOption
+		want []string
+	}{
+		{
+			name: "inherit",
+		},
+		{
+			name: "override",
+			opts: []SessionOption{BrowserEnv(map[string]string{"TZ": "UTC"}), BrowserEnv(map[string]string{"LANG": "C"})},
+			want: []string{"HOME=/home/user", "PATH=/bin", "LANG=C", "TZ=UTC"},
+		},
+		{
+			name: "clear",
+			opts: []SessionOption{ClearBrowserEnv(), BrowserEnv(map[string]string{"TZ": "UTC"})},
+			want: []string{"TZ=UTC"},
+		},
+		{
+			name: "clear_all",
+			opts: []SessionOption{ClearBrowserEnv()},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Session{}
+			for _, o := range tt.opts {
+				o(s)
+			}
+			if diff := cmp.Diff(tt.want, processEnv(s, environ)); diff != "" {
+				t.Errorf("processEnv() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// Mapping of session IDs to target IDs, for all the sessions in a browser
+// connection, including sessions which were attached automatically (see
+// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-setAutoAttach).
+type targetRegistry struct {
+	mu      sync.RWMutex
+	targets map[string]string
+}
+
+func newTargetRegistry() *targetRegistry {
+	return &targetRegistry{targets: make(map[string]string)}
+}
+
+func (r *targetRegistry) set(sessionID, targetID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.targets[sessionID] = targetID
+}
+
+func (r *targetRegistry) lookup(sessionID string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.targets[sessionID]
+}
+
+// Partial copy of `target.AttachedToTarget` and `target.DetachedFromTarget`.
+type attachmentEvent struct {
+	SessionID  string `json:"sessionId"`
+	TargetInfo struct {
+		TargetID string `json:"targetId"`
+	} `json:"targetInfo"`
+}
+
+// Update the registry according to target attachment and detachment events.
+func (r *targetRegistry) track(m *Message) {
+	if m.Method != "Target.attachedToTarget" && m.Method != "Target.detachedFromTarget" {
+		return
+	}
+	e := &attachmentEvent{}
+	if err := json.Unmarshal(m.Params, e); err != nil {
+		return
+	}
+	if m.Method == "Target.attachedToTarget" {
+		r.set(e.SessionID, e.TargetInfo.TargetID)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.targets, e.SessionID)
+}
+
+// Partial copy of various CDP events, for extracting their frame ID.
+type frameEvent struct {
+	FrameID string `json:"frameId"`
+	Frame   struct {
+		ID string `json:"id"`
+	} `json:"frame"`
+	Context struct {
+		AuxData struct {
+			FrameID string `json:"frameId"`
+		} `json:"auxData"`
+	} `json:"context"`
+}
+
+// FrameID returns the ID of the frame which is associated with an event
+// message, based on its parameters, e.g. `frameId` (in events such as
+// `Page.lifecycleEvent` and `Network.requestWillBeSent`), `frame.id` (in
+// `Page.frameNavigated`) or `context.auxData.frameId` (in
+// `Runtime.executionContextCreated`). It returns an empty string if
+// the event isn't associated with a specific frame.
+func (m *Message) FrameID() string {
+	e := &frameEvent{}
+	if err := json.Unmarshal(m.Params, e); err != nil {
+		return ""
+	}
+	switch {
+	case e.FrameID != "":
+		return e.FrameID
+	case e.Frame.ID != "":
+		return e.Frame.ID
+	default:
+		return e.Context.AuxData.FrameID
+	}
+}
+
+// EventFilter reports whether an event message should be handled.
+type EventFilter = func(*Message) bool
+
+// EventsOfSession returns an event filter which accepts only events from the
+// browser tab associated with the given context, because event subscribers
+// receive events from all the tabs in the same browser.
+func EventsOfSession(ctx context.Context) EventFilter {
+	id := ""
+	if s, ok := FromContext(ctx); ok {
+		id = s.SessionID.Read()
+	}
+	return func(m *Message) bool {
+		return m.SessionID == id
+	}
+}
+
+// EventsOfTarget returns an event filter which accepts only
+// events from the given target (tab, iframe, worker, etc.).
+func EventsOfTarget(targetID string) EventFilter {
+	return func(m *Message) bool {
+		return m.TargetID == targetID
+	}
+}
+
+// EventsOfFrame returns an event filter which accepts only
+// events which are associated with the given frame ID.
+func EventsOfFrame(frameID string) EventFilter {
+	return func(m *Message) bool {
+		return m.FrameID() == frameID
+	}
+}
+
+// FilterEvents relays to the returned channel only the event messages from
+// the given channel which are accepted by all the given filters. It's closed
+// when the given channel is closed (see `devtools.UnsubscribeEvent`), and
+// it must be drained continuously, just like the given channel.
+func FilterEvents(ch <-chan *Message, filters ...EventFilter) <-chan *Message {
+	out := make(chan *Message)
+	go func() {
+		defer close(out)
+		for m := range ch {
+			accepted := true
+			for _, f := range filters {
+				if !f(m) {
+					accepted = false
+					break
+				}
+			}
+			if accepted {
+				out <- m
+			}
+		}
+	}()
+	return out
+}
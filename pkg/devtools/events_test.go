@@ -0,0 +1,47 @@
+package devtools_test
+
+import (
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+func TestMessageFrameID(t *testing.T) {
+	tests := []struct {
+		params string
+		want   string
+	}{
+		{`{"frameId":"A","name":"load"}`, "A"},
+		{`{"frame":{"id":"B","url":"about:blank"}}`, "B"},
+		{`{"context":{"id":1,"auxData":{"frameId":"C"}}}`, "C"},
+		{`{"targetId":"D"}`, ""},
+		{``, ""},
+	}
+	for _, tt := range tests {
+		m := &devtools.Message{Params: []byte(tt.params)}
+		if got := m.FrameID(); got != tt.want {
+			t.Errorf("Message{Params: %s}.FrameID() = %q, want %q", tt.params, got, tt.want)
+		}
+	}
+}
+
+func TestFilterEvents(t *testing.T) {
+	// Set up.
+	in := make(chan *devtools.Message)
+	go func() {
+		in <- &devtools.Message{TargetID: "1", Params: []byte(`{"frameId":"A"}`), Seq: 1}
+		in <- &devtools.Message{TargetID: "2", Params: []byte(`{"frameId":"A"}`), Seq: 2}
+		in <- &devtools.Message{TargetID: "1", Params: []byte(`{"frameId":"B"}`), Seq: 3}
+		in <- &devtools.Message{TargetID: "1", Params: []byte(`{"frameId":"A"}`), Seq: 4}
+		close(in)
+	}()
+
+	// Test.
+	var got []uint64
+	for m := range devtools.FilterEvents(in, devtools.EventsOfTarget("1"), devtools.EventsOfFrame("A")) {
+		got = append(got, m.Seq)
+	}
+	if len(got) != 2 || got[0] != 1 || got[1] != 4 {
+		t.Errorf("FilterEvents() relayed sequence numbers %v, want [1 4]", got)
+	}
+}
@@ -0,0 +1,70 @@
+package devtools
+
+import (
+	"context"
+	"sync"
+)
+
+// IDs of the target and CDP session in contexts which
+// are returned by the `devtools.NewFakeContext` function.
+const (
+	FakeTargetID  = "FAKE_TARGET_ID"
+	FakeSessionID = "FAKE_SESSION_ID"
+)
+
+// NewFakeContext returns a copy of the parent context which carries a fake
+// CDP session, without a browser, for testing code which uses CDP sessions
+// (see also the `devtoolstest` sub-package). All the commands which are sent
+// in it are handled by the given handler, instead of a browser, and events
+// are received only when they're dispatched with the `devtools.DispatchEvent`
+// function. Session options are applied too, e.g. command middleware, which
+// wraps the given handler, but options related to browsers are ignored.
+func NewFakeContext(parent context.Context, h CommandHandler, opts ...SessionOption) context.Context {
+	ctx, cancel := context.WithCancel(parent)
+	s := &Session{
+		cancel:              cancel,
+		done:                ctx.Done(),
+		responseSubscribers: make(map[int64]chan *Message),
+		responseMu:          &sync.Mutex{},
+		eventSubscribers:    make(map[string][]*Subscription),
+		subscribersMu:       &sync.Mutex{},
+		targets:             newTargetRegistry(),
+		domains:             newDomainRegistry(),
+		commands:            newCommandRegistry(),
+		pausedTabs:          &pausedTabs{},
+		health:              &healthState{},
+		clock:               &Clock{},
+		TargetID:            newSafeString(),
+		SessionID:           newSafeString(),
+	}
+	s.TargetID.Write(FakeTargetID)
+	s.SessionID.Write(FakeSessionID)
+	s.targets.set(FakeSessionID, FakeTargetID)
+	for _, o := range opts {
+		o(s)
+	}
+	// The handler is the innermost middleware, so the actual
+	// transport, which requires a browser, is never used.
+	s.middleware = append(s.middleware, func(CommandHandler) CommandHandler {
+		return h
+	})
+	ctx = context.WithValue(ctx, sessionKey{}, s)
+	s.crashes = &crashWatcher{ctx: ctx, session: s}
+	return ctx
+}
+
+// DispatchEvent relays the given event message to its subscribers in the
+// session associated with the given context, as if it was received from the
+// browser, and returns after all of them receive it. This is mainly useful
+// with the `devtools.NewFakeContext` function, in which case the session ID
+// of events from the fake tab should be `devtools.FakeSessionID` (see the
+// `devtools.EventsOfSession` filter), and it should be empty in events
+// of the browser itself.
+func DispatchEvent(ctx context.Context, m *Message) error {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return errNotInitialized
+	}
+	relayEvent(s, m)
+	return nil
+}
@@ -0,0 +1,162 @@
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// Authenticator answers HTTP authentication challenges (e.g. basic auth)
+// transparently in the background, see the `fetch.Authenticate` function.
+type Authenticator struct {
+	ctx                context.Context
+	username, password string
+	origins            map[string]bool
+	paused, auth       *devtools.Subscription
+	done               chan struct{}
+	// Request IDs which already got credentials.
+	// Only accessed by the intercept goroutine.
+	answered map[string]bool
+}
+
+// Maximum number of request IDs to remember, see `Authenticator.respond`.
+const maxAnswered = 1024
+
+// AuthOption is used for customization in the `fetch.Authenticate` function.
+type AuthOption = func(*Authenticator)
+
+// AuthOrigins allows the caller of the `fetch.Authenticate` function to
+// provide the credentials only to the given origins (e.g.
+// "https://files.example.com"), instead of any server or proxy which asks
+// for them. Challenges from other origins get the browser's default response.
+func AuthOrigins(origins ...string) AuthOption {
+	return func(a *Authenticator) {
+		if a.origins == nil {
+			a.origins = make(map[string]bool)
+		}
+		for _, o := range origins {
+			a.origins[o] = true
+		}
+	}
+}
+
+// Authenticate starts answering HTTP authentication challenges of the page
+// associated with the given context with the given credentials, until the
+// `Stop` method of the returned authenticator is called, instead of
+// embedding them in URLs or showing a dialog. This includes navigations,
+// subresources, and downloads (see `browser.AllowDownloads`), so files
+// behind basic auth endpoints can be downloaded end-to-end.
+//
+// Requests are paused only before they're sent, and then continued as-is,
+// so response bodies are never buffered here: the browser streams them as
+// usual (e.g. large downloads are written directly to disk). Rejected
+// credentials aren't retried, so a 401 response is received instead of
+// looping forever.
+//
+// This function enables the Fetch domain, overriding the request patterns
+// of any other interception in the same page.
+func Authenticate(ctx context.Context, username, password string, opts ...AuthOption) (*Authenticator, error) {
+	a := &Authenticator{
+		ctx:      ctx,
+		username: username,
+		password: password,
+		done:     make(chan struct{}),
+		answered: make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	var err error
+	if a.paused, err = devtools.Subscribe(ctx, "Fetch.requestPaused"); err != nil {
+		return nil, err
+	}
+	if a.auth, err = devtools.Subscribe(ctx, "Fetch.authRequired"); err != nil {
+		a.paused.Unsubscribe()
+		return nil, err
+	}
+	go a.intercept(devtools.EventsOfSession(ctx))
+
+	if err := NewEnable().SetHandleAuthRequests(true).Do(ctx); err != nil {
+		a.Stop()
+		return nil, err
+	}
+	return a, nil
+}
+
+// Receive paused requests and authentication challenges
+// until both subscriptions end.
+func (a *Authenticator) intercept(accept devtools.EventFilter) {
+	defer close(a.done)
+	paused, auth := a.paused.C, a.auth.C
+	for paused != nil || auth != nil {
+		var m *devtools.Message
+		var ok bool
+		select {
+		case m, ok = <-paused:
+			if !ok {
+				paused = nil
+				continue
+			}
+		case m, ok = <-auth:
+			if !ok {
+				auth = nil
+				continue
+			}
+		}
+		if !accept(m) {
+			continue
+		}
+		if m.Method == "Fetch.requestPaused" {
+			e := &RequestPaused{}
+			if json.Unmarshal(m.Params, e) != nil {
+				continue
+			}
+			go func() {
+				if err := NewContinueRequest(e.RequestID).Do(a.ctx); err != nil {
+					log.Printf("Failed to continue request for %s: %v", e.Request.URL, err)
+				}
+			}()
+			continue
+		}
+		e := &AuthRequired{}
+		if json.Unmarshal(m.Params, e) != nil {
+			continue
+		}
+		r := a.respond(e)
+		go func() {
+			if err := NewContinueWithAuth(e.RequestID, r).Do(a.ctx); err != nil {
+				log.Printf("Failed to respond to auth challenge for %s: %v", e.Request.URL, err)
+			}
+		}()
+	}
+}
+
+// Return the response to the given authentication challenge.
+func (a *Authenticator) respond(e *AuthRequired) AuthChallengeResponse {
+	if a.origins != nil && !a.origins[e.AuthChallenge.Origin] {
+		return AuthChallengeResponse{Response: "Default"}
+	}
+	if a.answered[e.RequestID] {
+		// The browser asks again only if the credentials were rejected.
+		delete(a.answered, e.RequestID)
+		return AuthChallengeResponse{Response: "CancelAuth"}
+	}
+	if len(a.answered) >= maxAnswered {
+		// Forget requests which probably succeeded long ago. At worst,
+		// rejected credentials are retried once more.
+		a.answered = make(map[string]bool)
+	}
+	a.answered[e.RequestID] = true
+	return AuthChallengeResponse{Response: "ProvideCredentials", Username: a.username, Password: a.password}
+}
+
+// Stop stops answering authentication challenges, and disables the Fetch domain.
+func (a *Authenticator) Stop() error {
+	a.paused.Unsubscribe()
+	a.auth.Unsubscribe()
+	<-a.done
+	return NewDisable().Do(a.ctx)
+}
@@ -0,0 +1,27 @@
+package fetch
+
+import "testing"
+
+func TestAuthenticatorRespond(t *testing.T) {
+	a := &Authenticator{username: "user", password: "pass", answered: make(map[string]bool)}
+	AuthOrigins("https://files.example.com")(a)
+	tests := []struct {
+		requestID, origin, want string
+	}{
+		{"1", "https://files.example.com", "ProvideCredentials"},
+		{"2", "https://files.example.com", "ProvideCredentials"},
+		// Rejected credentials aren't retried.
+		{"1", "https://files.example.com", "CancelAuth"},
+		{"3", "https://example.com", "Default"},
+	}
+	for _, test := range tests {
+		e := &AuthRequired{RequestID: test.requestID, AuthChallenge: AuthChallenge{Origin: test.origin}}
+		got := a.respond(e)
+		if got.Response != test.want {
+			t.Errorf("respond(%q, %q) = %q, want %q", test.requestID, test.origin, got.Response, test.want)
+		}
+		if (got.Username == "user" && got.Password == "pass") != (test.want == "ProvideCredentials") {
+			t.Errorf("respond(%q, %q) credentials = %q, %q", test.requestID, test.origin, got.Username, got.Password)
+		}
+	}
+}
@@ -72,13 +72,23 @@ type Enable struct {
 
 // NewEnable constructs a new Enable struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `EnableOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-enable
-func NewEnable() *Enable {
-	return &Enable{}
+func NewEnable(opts ...EnableOption) *Enable {
+	c := &Enable{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// EnableOption is a functional option for the optional
+// parameters of the Enable CDP command, see the
+// NewEnable constructor. Options are applied in order.
+type EnableOption = func(*Enable)
+
 // SetPatterns adds or modifies the value of the optional
 // parameter `patterns` in the Enable CDP command.
 //
@@ -90,6 +100,14 @@ func (t *Enable) SetPatterns(v []RequestPattern) *Enable {
 	return t
 }
 
+// EnableWithPatterns is a functional option which sets the value of the
+// optional parameter `patterns` in the Enable CDP command, see SetPatterns.
+func EnableWithPatterns(v []RequestPattern) EnableOption {
+	return func(c *Enable) {
+		c.SetPatterns(v)
+	}
+}
+
 // SetHandleAuthRequests adds or modifies the value of the optional
 // parameter `handleAuthRequests` in the Enable CDP command.
 //
@@ -100,6 +118,14 @@ func (t *Enable) SetHandleAuthRequests(v bool) *Enable {
 	return t
 }
 
+// EnableWithHandleAuthRequests is a functional option which sets the value of the
+// optional parameter `handleAuthRequests` in the Enable CDP command, see SetHandleAuthRequests.
+func EnableWithHandleAuthRequests(v bool) EnableOption {
+	return func(c *Enable) {
+		c.SetHandleAuthRequests(v)
+	}
+}
+
 // Do sends the Enable CDP command to a browser,
 // and returns the browser's response.
 func (t *Enable) Do(ctx context.Context) error {
@@ -224,16 +250,26 @@ type FulfillRequest struct {
 
 // NewFulfillRequest constructs a new FulfillRequest struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `FulfillRequestOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-fulfillRequest
-func NewFulfillRequest(requestID string, responseCode int64) *FulfillRequest {
-	return &FulfillRequest{
+func NewFulfillRequest(requestID string, responseCode int64, opts ...FulfillRequestOption) *FulfillRequest {
+	c := &FulfillRequest{
 		RequestID:    requestID,
 		ResponseCode: responseCode,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// FulfillRequestOption is a functional option for the optional
+// parameters of the FulfillRequest CDP command, see the
+// NewFulfillRequest constructor. Options are applied in order.
+type FulfillRequestOption = func(*FulfillRequest)
+
 // SetResponseHeaders adds or modifies the value of the optional
 // parameter `responseHeaders` in the FulfillRequest CDP command.
 //
@@ -243,6 +279,14 @@ func (t *FulfillRequest) SetResponseHeaders(v []HeaderEntry) *FulfillRequest {
 	return t
 }
 
+// FulfillRequestWithResponseHeaders is a functional option which sets the value of the
+// optional parameter `responseHeaders` in the FulfillRequest CDP command, see SetResponseHeaders.
+func FulfillRequestWithResponseHeaders(v []HeaderEntry) FulfillRequestOption {
+	return func(c *FulfillRequest) {
+		c.SetResponseHeaders(v)
+	}
+}
+
 // SetBinaryResponseHeaders adds or modifies the value of the optional
 // parameter `binaryResponseHeaders` in the FulfillRequest CDP command.
 //
@@ -255,6 +299,14 @@ func (t *FulfillRequest) SetBinaryResponseHeaders(v string) *FulfillRequest {
 	return t
 }
 
+// FulfillRequestWithBinaryResponseHeaders is a functional option which sets the value of the
+// optional parameter `binaryResponseHeaders` in the FulfillRequest CDP command, see SetBinaryResponseHeaders.
+func FulfillRequestWithBinaryResponseHeaders(v string) FulfillRequestOption {
+	return func(c *FulfillRequest) {
+		c.SetBinaryResponseHeaders(v)
+	}
+}
+
 // SetBody adds or modifies the value of the optional
 // parameter `body` in the FulfillRequest CDP command.
 //
@@ -266,6 +318,14 @@ func (t *FulfillRequest) SetBody(v string) *FulfillRequest {
 	return t
 }
 
+// FulfillRequestWithBody is a functional option which sets the value of the
+// optional parameter `body` in the FulfillRequest CDP command, see SetBody.
+func FulfillRequestWithBody(v string) FulfillRequestOption {
+	return func(c *FulfillRequest) {
+		c.SetBody(v)
+	}
+}
+
 // SetResponsePhrase adds or modifies the value of the optional
 // parameter `responsePhrase` in the FulfillRequest CDP command.
 //
@@ -276,6 +336,14 @@ func (t *FulfillRequest) SetResponsePhrase(v string) *FulfillRequest {
 	return t
 }
 
+// FulfillRequestWithResponsePhrase is a functional option which sets the value of the
+// optional parameter `responsePhrase` in the FulfillRequest CDP command, see SetResponsePhrase.
+func FulfillRequestWithResponsePhrase(v string) FulfillRequestOption {
+	return func(c *FulfillRequest) {
+		c.SetResponsePhrase(v)
+	}
+}
+
 // Do sends the FulfillRequest CDP command to a browser,
 // and returns the browser's response.
 func (t *FulfillRequest) Do(ctx context.Context) error {
@@ -336,15 +404,25 @@ type ContinueRequest struct {
 
 // NewContinueRequest constructs a new ContinueRequest struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ContinueRequestOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-continueRequest
-func NewContinueRequest(requestID string) *ContinueRequest {
-	return &ContinueRequest{
+func NewContinueRequest(requestID string, opts ...ContinueRequestOption) *ContinueRequest {
+	c := &ContinueRequest{
 		RequestID: requestID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ContinueRequestOption is a functional option for the optional
+// parameters of the ContinueRequest CDP command, see the
+// NewContinueRequest constructor. Options are applied in order.
+type ContinueRequestOption = func(*ContinueRequest)
+
 // SetURL adds or modifies the value of the optional
 // parameter `url` in the ContinueRequest CDP command.
 //
@@ -354,6 +432,14 @@ func (t *ContinueRequest) SetURL(v string) *ContinueRequest {
 	return t
 }
 
+// ContinueRequestWithURL is a functional option which sets the value of the
+// optional parameter `url` in the ContinueRequest CDP command, see SetURL.
+func ContinueRequestWithURL(v string) ContinueRequestOption {
+	return func(c *ContinueRequest) {
+		c.SetURL(v)
+	}
+}
+
 // SetMethod adds or modifies the value of the optional
 // parameter `method` in the ContinueRequest CDP command.
 //
@@ -363,6 +449,14 @@ func (t *ContinueRequest) SetMethod(v string) *ContinueRequest {
 	return t
 }
 
+// ContinueRequestWithMethod is a functional option which sets the value of the
+// optional parameter `method` in the ContinueRequest CDP command, see SetMethod.
+func ContinueRequestWithMethod(v string) ContinueRequestOption {
+	return func(c *ContinueRequest) {
+		c.SetMethod(v)
+	}
+}
+
 // SetPostData adds or modifies the value of the optional
 // parameter `postData` in the ContinueRequest CDP command.
 //
@@ -372,6 +466,14 @@ func (t *ContinueRequest) SetPostData(v string) *ContinueRequest {
 	return t
 }
 
+// ContinueRequestWithPostData is a functional option which sets the value of the
+// optional parameter `postData` in the ContinueRequest CDP command, see SetPostData.
+func ContinueRequestWithPostData(v string) ContinueRequestOption {
+	return func(c *ContinueRequest) {
+		c.SetPostData(v)
+	}
+}
+
 // SetHeaders adds or modifies the value of the optional
 // parameter `headers` in the ContinueRequest CDP command.
 //
@@ -381,6 +483,14 @@ func (t *ContinueRequest) SetHeaders(v []HeaderEntry) *ContinueRequest {
 	return t
 }
 
+// ContinueRequestWithHeaders is a functional option which sets the value of the
+// optional parameter `headers` in the ContinueRequest CDP command, see SetHeaders.
+func ContinueRequestWithHeaders(v []HeaderEntry) ContinueRequestOption {
+	return func(c *ContinueRequest) {
+		c.SetHeaders(v)
+	}
+}
+
 // SetInterceptResponse adds or modifies the value of the optional
 // parameter `interceptResponse` in the ContinueRequest CDP command.
 //
@@ -392,6 +502,14 @@ func (t *ContinueRequest) SetInterceptResponse(v bool) *ContinueRequest {
 	return t
 }
 
+// ContinueRequestWithInterceptResponse is a functional option which sets the value of the
+// optional parameter `interceptResponse` in the ContinueRequest CDP command, see SetInterceptResponse.
+func ContinueRequestWithInterceptResponse(v bool) ContinueRequestOption {
+	return func(c *ContinueRequest) {
+		c.SetInterceptResponse(v)
+	}
+}
+
 // Do sends the ContinueRequest CDP command to a browser,
 // and returns the browser's response.
 func (t *ContinueRequest) Do(ctx context.Context) error {
@@ -516,17 +634,27 @@ type ContinueResponse struct {
 
 // NewContinueResponse constructs a new ContinueResponse struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ContinueResponseOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Fetch/#method-continueResponse
 //
 // This CDP method is experimental.
-func NewContinueResponse(requestID string) *ContinueResponse {
-	return &ContinueResponse{
+func NewContinueResponse(requestID string, opts ...ContinueResponseOption) *ContinueResponse {
+	c := &ContinueResponse{
 		RequestID: requestID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ContinueResponseOption is a functional option for the optional
+// parameters of the ContinueResponse CDP command, see the
+// NewContinueResponse constructor. Options are applied in order.
+type ContinueResponseOption = func(*ContinueResponse)
+
 // SetResponseCode adds or modifies the value of the optional
 // parameter `responseCode` in the ContinueResponse CDP command.
 //
@@ -536,6 +664,14 @@ func (t *ContinueResponse) SetResponseCode(v int64) *ContinueResponse {
 	return t
 }
 
+// ContinueResponseWithResponseCode is a functional option which sets the value of the
+// optional parameter `responseCode` in the ContinueResponse CDP command, see SetResponseCode.
+func ContinueResponseWithResponseCode(v int64) ContinueResponseOption {
+	return func(c *ContinueResponse) {
+		c.SetResponseCode(v)
+	}
+}
+
 // SetResponsePhrase adds or modifies the value of the optional
 // parameter `responsePhrase` in the ContinueResponse CDP command.
 //
@@ -546,6 +682,14 @@ func (t *ContinueResponse) SetResponsePhrase(v string) *ContinueResponse {
 	return t
 }
 
+// ContinueResponseWithResponsePhrase is a functional option which sets the value of the
+// optional parameter `responsePhrase` in the ContinueResponse CDP command, see SetResponsePhrase.
+func ContinueResponseWithResponsePhrase(v string) ContinueResponseOption {
+	return func(c *ContinueResponse) {
+		c.SetResponsePhrase(v)
+	}
+}
+
 // SetResponseHeaders adds or modifies the value of the optional
 // parameter `responseHeaders` in the ContinueResponse CDP command.
 //
@@ -555,6 +699,14 @@ func (t *ContinueResponse) SetResponseHeaders(v []HeaderEntry) *ContinueResponse
 	return t
 }
 
+// ContinueResponseWithResponseHeaders is a functional option which sets the value of the
+// optional parameter `responseHeaders` in the ContinueResponse CDP command, see SetResponseHeaders.
+func ContinueResponseWithResponseHeaders(v []HeaderEntry) ContinueResponseOption {
+	return func(c *ContinueResponse) {
+		c.SetResponseHeaders(v)
+	}
+}
+
 // SetBinaryResponseHeaders adds or modifies the value of the optional
 // parameter `binaryResponseHeaders` in the ContinueResponse CDP command.
 //
@@ -567,6 +719,14 @@ func (t *ContinueResponse) SetBinaryResponseHeaders(v string) *ContinueResponse
 	return t
 }
 
+// ContinueResponseWithBinaryResponseHeaders is a functional option which sets the value of the
+// optional parameter `binaryResponseHeaders` in the ContinueResponse CDP command, see SetBinaryResponseHeaders.
+func ContinueResponseWithBinaryResponseHeaders(v string) ContinueResponseOption {
+	return func(c *ContinueResponse) {
+		c.SetBinaryResponseHeaders(v)
+	}
+}
+
 // Do sends the ContinueResponse CDP command to a browser,
 // and returns the browser's response.
 func (t *ContinueResponse) Do(ctx context.Context) error {
@@ -0,0 +1,283 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	cdpio "github.com/daabr/chrome-vision/pkg/devtools/io"
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+)
+
+// Download is the body of a response which the page received, streamed from
+// the browser in chunks (see `io.OpenReader` in the devtools/io package),
+// instead of being buffered and base64-encoded in a single CDP message. See
+// the `fetch.ExpectDownload` function. Reading it isn't safe for concurrent
+// use, and it must be closed when it's no longer needed.
+type Download struct {
+	io.ReadCloser
+	URL        string
+	StatusCode int
+	Header     http.Header
+	// Offset of the body's first byte in the resource: 0, or the start
+	// of a partial response (HTTP status 206) to a range request.
+	Offset int64
+	// Total size of the resource in bytes, or -1 if it's unknown.
+	Size int64
+}
+
+type downloadConfig struct {
+	offset int64
+}
+
+// DownloadOption is used for customization in the `fetch.ExpectDownload` function.
+type DownloadOption = func(*downloadConfig) error
+
+// DownloadFrom allows the caller of the `fetch.ExpectDownload` function to
+// request only the bytes of the resource from the given offset onward, with
+// a "Range" request header. Servers which don't support range requests
+// respond with the whole resource, see the `Download.Offset` field.
+func DownloadFrom(offset int64) DownloadOption {
+	return func(c *downloadConfig) error {
+		c.offset = offset
+		return nil
+	}
+}
+
+// ResumeDownload allows the caller of the `fetch.ExpectDownload` function to
+// resume a partial download in the given file (see the `Download.SaveTo`
+// method), by requesting only the bytes which it doesn't contain yet. It
+// has no effect if the file doesn't exist.
+func ResumeDownload(path string) DownloadOption {
+	return func(c *downloadConfig) error {
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		c.offset = info.Size()
+		return nil
+	}
+}
+
+// Downloader waits for a download, see the `fetch.ExpectDownload` function.
+type Downloader struct {
+	ctx    context.Context
+	cfg    *downloadConfig
+	i      *Interceptor
+	once   sync.Once
+	result chan downloadResult
+}
+
+type downloadResult struct {
+	d   *Download
+	err error
+}
+
+// ExpectDownload starts waiting for the response to the first request of
+// the page associated with the given context whose URL matches the given
+// pattern (see `fetch.RequestPattern`), e.g. a large video or export which
+// is initiated by the page. Call the `Wait` method of the returned
+// downloader to receive it, after triggering the request (e.g. by clicking
+// a link). Redirects are followed.
+//
+// The browser doesn't deliver the body to the page: when the download is
+// closed, the page's request fails as if it was aborted.
+//
+// This function enables the Fetch domain until the download is closed,
+// overriding the request patterns of any other interception in the same
+// page (see the `fetch.Intercept` function).
+func ExpectDownload(ctx context.Context, urlPattern string, opts ...DownloadOption) (*Downloader, error) {
+	d := &Downloader{ctx: ctx, cfg: &downloadConfig{}, result: make(chan downloadResult, 1)}
+	for _, opt := range opts {
+		if err := opt(d.cfg); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	d.i, err = Intercept(ctx, OnRequest(d.onRequest, urlPattern), OnResponse(d.onResponse, urlPattern))
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Add a "Range" header to the request, if needed.
+func (d *Downloader) onRequest(e *RequestPaused) Action {
+	if d.cfg.offset <= 0 {
+		return nil
+	}
+	h := e.Request.Headers.HTTPHeader()
+	h.Set("Range", fmt.Sprintf("bytes=%d-", d.cfg.offset))
+	return NewContinueRequest(e.RequestID).SetHeaders(HeaderEntries(h))
+}
+
+// Deliver the first final response to the `Wait` method.
+func (d *Downloader) onResponse(r *PausedResponse) Action {
+	if r.ResponseErrorReason == nil && r.ResponseStatusCode >= 300 && r.ResponseStatusCode < 400 {
+		return nil // Redirect.
+	}
+	delivered := false
+	d.once.Do(func() { delivered = true })
+	if !delivered {
+		return nil
+	}
+	return actionFunc(func(ctx context.Context) error {
+		res := downloadResult{}
+		res.d, res.err = d.take(ctx, r.RequestPaused)
+		d.result <- res
+		if res.err != nil {
+			return NewContinueRequest(r.RequestID).Do(ctx)
+		}
+		return nil
+	})
+}
+
+// Take the body of the given paused response as a stream.
+func (d *Downloader) take(ctx context.Context, e *RequestPaused) (*Download, error) {
+	url := e.Request.URL
+	if e.ResponseErrorReason != nil {
+		return nil, fmt.Errorf("download of %s failed: %s", url, *e.ResponseErrorReason)
+	}
+	if e.ResponseStatusCode >= 400 {
+		return nil, fmt.Errorf("download of %s failed: HTTP status %d", url, e.ResponseStatusCode)
+	}
+	s, err := NewTakeResponseBodyAsStream(e.RequestID).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dl := &Download{
+		URL:        url,
+		StatusCode: int(e.ResponseStatusCode),
+		Header:     e.ResponseHeader(),
+		Size:       -1,
+	}
+	dl.ReadCloser = &downloadStream{ReadCloser: cdpio.OpenReader(ctx, s.Stream), ctx: ctx, requestID: e.RequestID, stop: d.i.Stop}
+	if dl.StatusCode == http.StatusPartialContent {
+		dl.Offset, dl.Size = parseContentRange(dl.Header.Get("Content-Range"))
+	} else if n, err := strconv.ParseInt(dl.Header.Get("Content-Length"), 10, 64); err == nil {
+		dl.Size = n
+	}
+	return dl, nil
+}
+
+// Parse the start offset and the total size of a "Content-Range" response
+// header, e.g. "bytes 100-999/1000", or "bytes 100-999/*" if the total
+// size is unknown (-1).
+func parseContentRange(s string) (offset, size int64) {
+	size = -1
+	s = strings.TrimSpace(strings.TrimPrefix(s, "bytes"))
+	i, j := strings.IndexByte(s, '-'), strings.IndexByte(s, '/')
+	if i < 0 || j < i {
+		return 0, size
+	}
+	offset, _ = strconv.ParseInt(s[:i], 10, 64)
+	if n, err := strconv.ParseInt(s[j+1:], 10, 64); err == nil {
+		size = n
+	}
+	return offset, size
+}
+
+// An `Action` which is a function.
+type actionFunc func(ctx context.Context) error
+
+func (f actionFunc) Do(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Wait waits for the expected download, or until the downloader's context
+// is done. It returns an error if the request failed, or if the server
+// responded with an HTTP error status. Either way, interception stops after
+// the download is closed, or after an error.
+func (d *Downloader) Wait() (*Download, error) {
+	select {
+	case r := <-d.result:
+		if r.err != nil {
+			d.i.Stop()
+		}
+		return r.d, r.err
+	case <-d.ctx.Done():
+		d.i.Stop()
+		return nil, d.ctx.Err()
+	}
+}
+
+// Reader of a download's stream, which aborts the paused request and stops
+// intercepting requests when it's closed.
+type downloadStream struct {
+	io.ReadCloser
+	ctx       context.Context
+	requestID string
+	stop      func() error
+	closed    bool
+}
+
+func (s *downloadStream) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	err := s.ReadCloser.Close()
+	if e := NewFailRequest(s.requestID, network.ErrorReasonAborted).Do(s.ctx); err == nil {
+		err = e
+	}
+	if e := s.stop(); err == nil {
+		err = e
+	}
+	return err
+}
+
+// SaveTo writes the download into the given file, and closes it. If the
+// download starts at a non-zero offset (see the `fetch.ResumeDownload`
+// option), it's appended to the file's first `Offset` bytes, otherwise it
+// overwrites the file. The given function (if not nil) is called after
+// each chunk, with the file's size so far, and the total size of the
+// resource (-1 if it's unknown). A partial file is kept if reading fails,
+// so the download can be resumed.
+func (d *Download) SaveTo(path string, progress func(written, total int64)) error {
+	defer d.Close()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(d.Offset); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Seek(d.Offset, io.SeekStart); err != nil {
+		f.Close()
+		return err
+	}
+	w := &progressWriter{w: f, written: d.Offset, total: d.Size, progress: progress}
+	_, err = io.CopyBuffer(w, d, make([]byte, cdpio.ChunkSize))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = d.Close()
+	}
+	return err
+}
+
+type progressWriter struct {
+	w              io.Writer
+	written, total int64
+	progress       func(written, total int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	if p.progress != nil {
+		p.progress(p.written, p.total)
+	}
+	return n, err
+}
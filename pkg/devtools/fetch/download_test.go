@@ -0,0 +1,79 @@
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpectDownload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	if err := os.WriteFile(path, []byte("hello "), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, b := devtoolstest.NewContext(context.Background())
+	b.Respond("Fetch.takeResponseBodyAsStream", map[string]string{"stream": "s1"})
+	b.Respond("IO.read", map[string]interface{}{"data": "d29ybGQ=", "base64Encoded": true, "eof": true})
+
+	d, err := ExpectDownload(ctx, "*.mp4", ResumeDownload(path))
+	if err != nil {
+		t.Fatalf("ExpectDownload(); got error: %v", err)
+	}
+	a := d.onRequest(&RequestPaused{RequestID: "1", Request: network.Request{Headers: network.Headers{"Accept": "*/*"}}})
+	got, _ := json.Marshal(a)
+	want := `{"requestId":"1","headers":[{"name":"Accept","value":"*/*"},{"name":"Range","value":"bytes=6-"}]}`
+	if string(got) != want {
+		t.Errorf("onRequest() = %s, want %s", got, want)
+	}
+
+	for _, e := range []*RequestPaused{
+		{RequestID: "1", Request: network.Request{URL: "https://a.com/v.mp4"}, ResponseStatusCode: 302},
+		{RequestID: "1", Request: network.Request{URL: "https://b.com/v.mp4"}, ResponseStatusCode: 206,
+			ResponseHeaders: []HeaderEntry{{Name: "Content-Range", Value: "bytes 6-10/11"}}},
+	} {
+		if err := b.Emit("Fetch.requestPaused", e); err != nil {
+			t.Fatalf("Emit(); got error: %v", err)
+		}
+	}
+	dl, err := d.Wait()
+	if err != nil {
+		t.Fatalf("Wait(); got error: %v", err)
+	}
+	if dl.URL != "https://b.com/v.mp4" || dl.Offset != 6 || dl.Size != 11 {
+		t.Errorf("Wait() = %+v", dl)
+	}
+	var progress []int64
+	if err := dl.SaveTo(path, func(written, total int64) { progress = append(progress, written, total) }); err != nil {
+		t.Fatalf("SaveTo(); got error: %v", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "hello world" {
+		t.Errorf("SaveTo() wrote %q, want %q", content, "hello world")
+	}
+	if diff := cmp.Diff([]int64{11, 11}, progress); diff != "" {
+		t.Errorf("progress mismatch (-want +got):\n%s", diff)
+	}
+	for _, m := range []string{"IO.close", "Fetch.failRequest", "Fetch.disable"} {
+		if len(b.Calls(m)) != 1 {
+			t.Errorf("Close() didn't send a %q command: %v", m, b.Calls())
+		}
+	}
+}
+
+func TestParseContentRange(t *testing.T) {
+	tests := map[string][2]int64{
+		"bytes 100-999/1000": {100, 1000},
+		"bytes 100-999/*":    {100, -1},
+		"invalid":            {0, -1},
+	}
+	for s, want := range tests {
+		if offset, size := parseContentRange(s); offset != want[0] || size != want[1] {
+			t.Errorf("parseContentRange(%q) = %d, %d, want %d, %d", s, offset, size, want[0], want[1])
+		}
+	}
+}
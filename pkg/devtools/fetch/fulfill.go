@@ -0,0 +1,109 @@
+package fetch
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Hop-by-hop headers (https://tools.ietf.org/html/rfc7230#section-6.1)
+// which describe the connection to the original server, and don't apply
+// to a response body which is fulfilled as a whole by the browser.
+var hopByHopHeaders = []string{
+	"Connection", "Keep-Alive", "Proxy-Connection", "Te", "Trailer",
+	"Transfer-Encoding", "Upgrade",
+}
+
+// HeaderEntries converts a Go `http.Header` map into a slice of CDP header
+// entries, sorted by name, with one entry per value of multi-value headers.
+func HeaderEntries(h http.Header) []HeaderEntry {
+	names := make([]string, 0, len(h))
+	for n := range h {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	var entries []HeaderEntry
+	for _, n := range names {
+		for _, v := range h[n] {
+			entries = append(entries, HeaderEntry{Name: n, Value: v})
+		}
+	}
+	return entries
+}
+
+// Header converts a slice of CDP header entries (e.g. in the `ResponseHeaders`
+// field of `fetch.RequestPaused` events) into a Go `http.Header` map.
+func Header(entries []HeaderEntry) http.Header {
+	h := make(http.Header, len(entries))
+	for _, e := range entries {
+		h.Add(e.Name, e.Value)
+	}
+	return h
+}
+
+// NewFulfillResponse constructs a `fetch.FulfillRequest` command for the
+// given paused request, based on a Go HTTP status code, header and body,
+// instead of hand-assembled header entries and base64 strings.
+//
+// The body (which may be nil) is read until EOF and encoded on the fly.
+// Hop-by-hop headers such as "Transfer-Encoding" are dropped, and the
+// "Content-Length" header is set to the actual size of the body, because
+// the browser receives the body in one piece. Other headers, such as
+// "Cache-Control", "Location" and "Strict-Transport-Security", are passed
+// as-is, so the browser handles them like in any network response.
+func NewFulfillResponse(requestID string, statusCode int, header http.Header, body io.Reader) (*FulfillRequest, error) {
+	h := header.Clone()
+	if h == nil {
+		h = http.Header{}
+	}
+	for _, n := range hopByHopHeaders {
+		h.Del(n)
+	}
+
+	var b strings.Builder
+	n := int64(0)
+	if body != nil {
+		enc := base64.NewEncoder(base64.StdEncoding, &b)
+		var err error
+		if n, err = io.Copy(enc, body); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+	}
+	// 1xx, 204 and 304 responses never have a body, and a 304 response may
+	// specify the length of the cached body, so don't add or override it.
+	if n > 0 || !bodylessStatus(statusCode) {
+		h.Set("Content-Length", strconv.FormatInt(n, 10))
+	}
+
+	f := NewFulfillRequest(requestID, int64(statusCode)).SetResponseHeaders(HeaderEntries(h))
+	if b.Len() > 0 {
+		f.SetBody(b.String())
+	}
+	if phrase := http.StatusText(statusCode); phrase != "" {
+		f.SetResponsePhrase(phrase)
+	}
+	return f, nil
+}
+
+// NewFulfillRedirect constructs a `fetch.FulfillRequest` command which
+// redirects the given paused request to the given URL, with the given HTTP
+// status code (e.g. `http.StatusFound`, or `http.StatusTemporaryRedirect`
+// to preserve the request's method and body). The response is marked as
+// uncacheable, so the browser doesn't reuse it for future requests.
+func NewFulfillRedirect(requestID string, statusCode int, location string) *FulfillRequest {
+	h := http.Header{}
+	h.Set("Location", location)
+	h.Set("Cache-Control", "no-store")
+	f, _ := NewFulfillResponse(requestID, statusCode, h, nil)
+	return f
+}
+
+func bodylessStatus(code int) bool {
+	return (code >= 100 && code < 200) || code == http.StatusNoContent || code == http.StatusNotModified
+}
@@ -0,0 +1,64 @@
+package fetch
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHeaderEntries(t *testing.T) {
+	h := http.Header{"X-B": {"2"}, "X-A": {"1", "3"}}
+	got := HeaderEntries(h)
+	want := []HeaderEntry{{"X-A", "1"}, {"X-A", "3"}, {"X-B", "2"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("HeaderEntries() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(h, Header(got)); diff != "" {
+		t.Errorf("Header() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewFulfillResponse(t *testing.T) {
+	h := http.Header{}
+	h.Set("Content-Type", "text/plain")
+	h.Set("Transfer-Encoding", "chunked")
+	h.Set("Content-Length", "999")
+	f, err := NewFulfillResponse("id", http.StatusOK, h, strings.NewReader("hello"))
+	if err != nil {
+		t.Fatalf("NewFulfillResponse(); got error: %v", err)
+	}
+	want := &FulfillRequest{
+		RequestID:    "id",
+		ResponseCode: 200,
+		ResponseHeaders: []HeaderEntry{
+			{"Content-Length", "5"}, {"Content-Type", "text/plain"},
+		},
+		Body:           base64.StdEncoding.EncodeToString([]byte("hello")),
+		ResponsePhrase: "OK",
+	}
+	if diff := cmp.Diff(want, f); diff != "" {
+		t.Errorf("NewFulfillResponse() mismatch (-want +got):\n%s", diff)
+	}
+	if h.Get("Transfer-Encoding") == "" {
+		t.Errorf("NewFulfillResponse() modified the caller's header")
+	}
+}
+
+func TestNewFulfillRedirect(t *testing.T) {
+	f := NewFulfillRedirect("id", http.StatusFound, "https://example.com/")
+	want := &FulfillRequest{
+		RequestID:    "id",
+		ResponseCode: 302,
+		ResponseHeaders: []HeaderEntry{
+			{"Cache-Control", "no-store"}, {"Content-Length", "0"},
+			{"Location", "https://example.com/"},
+		},
+		ResponsePhrase: "Found",
+	}
+	if diff := cmp.Diff(want, f); diff != "" {
+		t.Errorf("NewFulfillRedirect() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,311 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+)
+
+// ErrBlockedURL is wrapped by the errors of the `URLPolicy.Check` method.
+var ErrBlockedURL = errors.New("blocked URL")
+
+// Maximum amount of time to resolve a host name while checking a URL.
+const resolveTimeout = 5 * time.Second
+
+// How long the handlers of `URLPolicy.RequestHandler` reuse
+// the result of resolving a host name.
+const resolveCacheTTL = time.Minute
+
+// Schemes of URLs which don't access any server or file.
+var localSchemes = map[string]bool{"about": true, "data": true, "blob": true}
+
+// Private, loopback, link-local (e.g. the cloud metadata endpoint
+// 169.254.169.254), and other non-public IP address ranges.
+var privateNetworks = parseCIDRs(
+	"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16",
+	"172.16.0.0/12", "192.168.0.0/16", "::/128", "::1/128", "fc00::/7", "fe80::/10",
+)
+
+// Host names of cloud metadata endpoints, which are blocked like private
+// networks even if they don't resolve from this program's network.
+var metadataHosts = map[string]bool{
+	"metadata":                 true,
+	"metadata.google.internal": true,
+	"metadata.azure.internal":  true,
+	"instance-data":            true,
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
+
+// URLPolicy decides which URLs a page may load, to protect servers which
+// load untrusted URLs or HTML (e.g. rendering services) from server-side
+// request forgery (SSRF), see the `fetch.EnforceURLPolicy` function.
+//
+// Host patterns in the policy's lists are host names (e.g. "example.com"),
+// wildcards which match subdomains (e.g. "*.example.com"), or IP address
+// ranges in CIDR notation (e.g. "203.0.113.0/24"), which match both IP
+// address hosts and the addresses that host names resolve to.
+//
+// The zero value blocks "file" URLs, URLs whose hosts are in private
+// networks or resolve to private IP addresses (e.g. "localhost", "10.0.0.1",
+// and cloud metadata endpoints), and URLs with schemes other than "http",
+// "https", "ws", "wss", "about", "data" and "blob".
+type URLPolicy struct {
+	// If not empty, only URLs whose hosts match these patterns are allowed.
+	Allow []string
+	// URLs whose hosts match these patterns are blocked, even if they're allowed.
+	Deny []string
+	// URLs whose hosts match these patterns may be in private networks,
+	// e.g. "wiki.intranet".
+	Intranet []string
+	// PrivateNetworks allows URLs in all private networks, which is
+	// unsafe if the URLs are untrusted.
+	PrivateNetworks bool
+	// FileURLs allows "file" URLs, i.e. local files of the browser's
+	// machine, which is unsafe if the URLs are untrusted.
+	FileURLs bool
+	// Resolver of host names (default: `net.DefaultResolver`).
+	Resolver *net.Resolver
+}
+
+// Check returns an error which wraps `fetch.ErrBlockedURL` if the policy
+// blocks the given URL, or if it isn't a valid URL. Host names are resolved
+// to check their IP addresses, and URLs whose host names don't resolve
+// are blocked.
+//
+// The browser resolves host names independently, so a malicious DNS server
+// could still answer it with a different address (DNS rebinding). Consider
+// blocking private networks at the network level too, if that's a concern.
+func (p *URLPolicy) Check(ctx context.Context, rawURL string) error {
+	return p.check(ctx, rawURL, p.resolve)
+}
+
+// Implementation of `URLPolicy.Check`, which resolves
+// host names with the given function.
+func (p *URLPolicy) check(ctx context.Context, rawURL string, resolve func(context.Context, string) ([]net.IP, error)) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrBlockedURL, err)
+	}
+	scheme := strings.ToLower(u.Scheme)
+	switch {
+	case localSchemes[scheme]:
+		return nil
+	case scheme == "file":
+		if p.FileURLs {
+			return nil
+		}
+		return fmt.Errorf("%w: %s (file URLs aren't allowed)", ErrBlockedURL, rawURL)
+	case scheme != "http" && scheme != "https" && scheme != "ws" && scheme != "wss":
+		return fmt.Errorf("%w: %s (unsupported scheme)", ErrBlockedURL, rawURL)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	var ips []net.IP
+	if !p.PrivateNetworks || p.hasCIDRs() {
+		if ips, err = resolve(ctx, host); err != nil {
+			return fmt.Errorf("%w: %s (%v)", ErrBlockedURL, rawURL, err)
+		}
+	}
+	if matchHost(p.Deny, host, ips) {
+		return fmt.Errorf("%w: %s (denied host)", ErrBlockedURL, rawURL)
+	}
+	if len(p.Allow) > 0 && !matchHost(p.Allow, host, ips) {
+		return fmt.Errorf("%w: %s (host isn't allowed)", ErrBlockedURL, rawURL)
+	}
+	if p.PrivateNetworks || matchHost(p.Intranet, host, ips) {
+		return nil
+	}
+	if metadataHosts[host] {
+		return fmt.Errorf("%w: %s (metadata endpoint)", ErrBlockedURL, rawURL)
+	}
+	for _, ip := range ips {
+		if inNetworks(privateNetworks, ip) {
+			return fmt.Errorf("%w: %s (private network address %s)", ErrBlockedURL, rawURL, ip)
+		}
+	}
+	return nil
+}
+
+// Return the IP addresses of the given host, which may be an IP address.
+// Metadata host names aren't resolved, because they're blocked anyway.
+func (p *URLPolicy) resolve(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+	if metadataHosts[host] {
+		return nil, nil
+	}
+	r := p.Resolver
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	ctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+	addrs, err := r.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	ips := make([]net.IP, len(addrs))
+	for i, a := range addrs {
+		ips[i] = a.IP
+	}
+	return ips, nil
+}
+
+// Cache of resolved host names, including failures.
+type resolveCache struct {
+	resolve func(context.Context, string) ([]net.IP, error)
+	ttl     time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*resolveEntry
+}
+
+type resolveEntry struct {
+	ips     []net.IP
+	err     error
+	expires time.Time
+}
+
+func newResolveCache(resolve func(context.Context, string) ([]net.IP, error), ttl time.Duration) *resolveCache {
+	return &resolveCache{resolve: resolve, ttl: ttl, entries: make(map[string]*resolveEntry)}
+}
+
+// Return the cached result of resolving the given host, if it hasn't expired
+// yet, otherwise resolve it again. Concurrent calls may resolve the same host.
+func (c *resolveCache) lookup(ctx context.Context, host string) ([]net.IP, error) {
+	now := time.Now()
+	c.mu.Lock()
+	e, ok := c.entries[host]
+	c.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.ips, e.err
+	}
+
+	ips, err := c.resolve(ctx, host)
+	if ctx.Err() != nil {
+		return ips, err // Don't cache the cancelation of a single request.
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for h, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, h)
+		}
+	}
+	c.entries[host] = &resolveEntry{ips: ips, err: err, expires: now.Add(c.ttl)}
+	return ips, err
+}
+
+// Report whether any of the policy's host patterns is an IP address range.
+func (p *URLPolicy) hasCIDRs() bool {
+	for _, list := range [][]string{p.Allow, p.Deny, p.Intranet} {
+		for _, pattern := range list {
+			if strings.Contains(pattern, "/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Report whether the given host, or any of its IP addresses,
+// matches any of the given host patterns.
+func matchHost(patterns []string, host string, ips []net.IP) bool {
+	for _, p := range patterns {
+		p = strings.ToLower(p)
+		if _, n, err := net.ParseCIDR(p); err == nil {
+			for _, ip := range ips {
+				if n.Contains(ip) {
+					return true
+				}
+			}
+			continue
+		}
+		if strings.HasPrefix(p, "*.") {
+			if strings.HasSuffix(host, p[1:]) {
+				return true
+			}
+			continue
+		}
+		if host == p {
+			return true
+		}
+	}
+	return false
+}
+
+func inNetworks(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestHandler returns a handler for the `fetch.OnRequest` option, which
+// fails requests whose URLs the policy blocks (see the `URLPolicy.Check`
+// method), and returns nil for other requests. Use it to combine the policy
+// with other request handling in a single interception, otherwise see the
+// `fetch.EnforceURLPolicy` function.
+//
+// The handler caches the IP addresses of host names for a minute, so pages
+// with many subresources don't wait for a DNS lookup in each request.
+func (p *URLPolicy) RequestHandler(ctx context.Context) RequestHandler {
+	cache := newResolveCache(p.resolve, resolveCacheTTL)
+	return func(e *RequestPaused) Action {
+		if p.check(ctx, e.Request.URL, cache.lookup) != nil {
+			return NewFailRequest(e.RequestID, network.ErrorReasonBlockedByClient)
+		}
+		return nil
+	}
+}
+
+// EnforceURLPolicy starts enforcing the given policy in the page associated
+// with the given context, until the `Stop` method of the returned interceptor
+// is called: navigations, redirects and subresource requests to blocked URLs
+// fail with the network error "net::ERR_BLOCKED_BY_CLIENT". Each page (i.e.
+// CDP session) may have its own policy. Consider checking URLs with the
+// `URLPolicy.Check` method before navigating to them too, for clearer errors.
+//
+// This function enables the Fetch domain, overriding the request patterns
+// of any other interception in the same page.
+//
+// The policy applies only to requests which the page's own CDP session can
+// intercept. It does NOT cover:
+//
+// • Out-of-process iframes (OOPIFs), i.e. cross-site iframes when site
+// isolation is enabled (the browser's default): their navigation requests
+// are checked, but their subresource requests aren't
+//
+// • Requests of dedicated, shared and service workers, which are separate
+// targets (service workers may also answer the page's requests themselves)
+//
+// • WebSocket connections, which the Fetch domain can't intercept
+//
+// If the page's content is untrusted, also block private networks at the
+// network level (e.g. with firewall rules or a proxy), or disable site
+// isolation with the browser flags "disable-site-isolation-trials" and
+// "disable-features=IsolateOrigins,site-per-process".
+func EnforceURLPolicy(ctx context.Context, p *URLPolicy) (*Interceptor, error) {
+	return Intercept(ctx, OnRequest(p.RequestHandler(ctx), "*"))
+}
@@ -0,0 +1,100 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestURLPolicyCheck(t *testing.T) {
+	tests := []struct {
+		url     string
+		policy  URLPolicy
+		blocked bool
+	}{
+		{url: "https://93.184.216.34/"},
+		{url: "data:text/html,<p>hi</p>"},
+		{url: "about:blank"},
+		{url: "http://127.0.0.1:8080/admin", blocked: true},
+		{url: "http://10.1.2.3/", blocked: true},
+		{url: "http://169.254.169.254/latest/meta-data/", blocked: true},
+		{url: "http://metadata.google.internal/computeMetadata/v1/", blocked: true},
+		{url: "http://[::1]/", blocked: true},
+		{url: "http://[::ffff:127.0.0.1]/", blocked: true},
+		{url: "http://10.1.2.3/", policy: URLPolicy{PrivateNetworks: true}},
+		{url: "http://wiki.intranet/", policy: URLPolicy{PrivateNetworks: true}},
+		{url: "http://10.1.2.3/", policy: URLPolicy{Intranet: []string{"10.1.0.0/16"}}},
+		{url: "http://10.2.3.4/", policy: URLPolicy{Intranet: []string{"10.1.0.0/16"}}, blocked: true},
+		{url: "https://93.184.216.34/", policy: URLPolicy{Allow: []string{"203.0.113.0/24"}}, blocked: true},
+		{url: "https://203.0.113.7/", policy: URLPolicy{Allow: []string{"203.0.113.0/24"}}},
+		{url: "https://203.0.113.7/", policy: URLPolicy{Allow: []string{"203.0.113.0/24"}, Deny: []string{"203.0.113.7/32"}}, blocked: true},
+		{url: "http://metadata/", policy: URLPolicy{Deny: []string{"*.example.com"}}, blocked: true},
+		{url: "file:///etc/passwd", blocked: true},
+		{url: "file:///srv/templates/a.css", policy: URLPolicy{FileURLs: true}},
+		{url: "chrome://settings", blocked: true},
+		{url: "javascript:alert(1)", blocked: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := tt.policy.Check(context.Background(), tt.url)
+			if tt.blocked && !errors.Is(err, ErrBlockedURL) {
+				t.Errorf("Check() = %v, want %v", err, ErrBlockedURL)
+			}
+			if !tt.blocked && err != nil {
+				t.Errorf("Check(); got error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMatchHost(t *testing.T) {
+	patterns := []string{"Example.com", "*.cdn.example.net"}
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{"example.com", true},
+		{"www.example.com", false},
+		{"a.cdn.example.net", true},
+		{"cdn.example.net", false},
+		{"evilcdn.example.net", false},
+	}
+	for _, tt := range tests {
+		if got := matchHost(patterns, tt.host, nil); got != tt.want {
+			t.Errorf("matchHost(%q) = %v, want %v", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestResolveCache(t *testing.T) {
+	lookups := 0
+	resolve := func(ctx context.Context, host string) ([]net.IP, error) {
+		lookups++
+		if host == "nx.example" {
+			return nil, errors.New("no such host")
+		}
+		return []net.IP{net.ParseIP("93.184.216.34")}, nil
+	}
+	c := newResolveCache(resolve, time.Minute)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if ips, err := c.lookup(ctx, "example.com"); err != nil || len(ips) != 1 {
+			t.Fatalf("lookup() = %v, %v", ips, err)
+		}
+		if _, err := c.lookup(ctx, "nx.example"); err == nil {
+			t.Fatal("lookup(); want error")
+		}
+	}
+	if lookups != 2 {
+		t.Errorf("resolved %d times, want 2", lookups)
+	}
+
+	c = newResolveCache(resolve, 0)
+	c.lookup(ctx, "example.com")
+	c.lookup(ctx, "example.com")
+	if lookups != 4 {
+		t.Errorf("resolved %d times, want 4 (expired)", lookups)
+	}
+}
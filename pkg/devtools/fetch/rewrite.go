@@ -0,0 +1,209 @@
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// Rewriter redirects requests transparently in the background,
+// see the `fetch.Rewrite` function.
+type Rewriter struct {
+	ctx    context.Context
+	rules  []rewriteRule
+	paused *devtools.Subscription
+	done   chan struct{}
+}
+
+type rewriteRule struct {
+	pattern, target string
+}
+
+// Rewrite starts redirecting requests of the page associated with the given
+// context, according to the given rewrite table, until the `Stop` method of
+// the returned rewriter is called. The page isn't aware of the redirection,
+// e.g. to point production assets at a local development server:
+//
+//	fetch.Rewrite(ctx, map[string]string{
+//		"https://cdn.example.com/assets/*": "http://localhost:8080/*",
+//	})
+//
+// The keys are URL patterns, in which `*` matches zero or more characters,
+// `?` matches exactly one, and a backslash escapes the next character, like
+// in `fetch.RequestPattern`. Each `*` in a value is replaced with the text
+// which was matched by the corresponding `*` in its key. Longer patterns
+// take precedence over shorter ones when more than one matches.
+//
+// The browser doesn't allow transparent redirection to a URL with a different
+// scheme (e.g. from "https" to "http"), so such requests are redirected with
+// a "307 Temporary Redirect" response instead, which the page is aware of.
+//
+// This function enables the Fetch domain, overriding the request patterns
+// of any other interception in the same page.
+func Rewrite(ctx context.Context, table map[string]string) (*Rewriter, error) {
+	r := &Rewriter{ctx: ctx, done: make(chan struct{})}
+	var patterns []RequestPattern
+	for p, t := range table {
+		r.rules = append(r.rules, rewriteRule{pattern: p, target: t})
+		patterns = append(patterns, RequestPattern{URLPattern: p})
+	}
+	sort.Slice(r.rules, func(i, j int) bool {
+		if len(r.rules[i].pattern) != len(r.rules[j].pattern) {
+			return len(r.rules[i].pattern) > len(r.rules[j].pattern)
+		}
+		return r.rules[i].pattern < r.rules[j].pattern
+	})
+
+	var err error
+	if r.paused, err = devtools.Subscribe(ctx, "Fetch.requestPaused"); err != nil {
+		return nil, err
+	}
+	go r.intercept(devtools.EventsOfSession(ctx))
+
+	if err := NewEnable().SetPatterns(patterns).Do(ctx); err != nil {
+		r.Stop()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Receive paused requests until the subscription ends.
+func (r *Rewriter) intercept(accept devtools.EventFilter) {
+	defer close(r.done)
+	for m := range r.paused.C {
+		e := &RequestPaused{}
+		if !accept(m) || json.Unmarshal(m.Params, e) != nil {
+			continue
+		}
+		var a Action = NewContinueRequest(e.RequestID)
+		if u, ok := r.rewrite(e.Request.URL); ok {
+			a = redirect(e.RequestID, e.Request.URL, u)
+		}
+		go func(a Action, url string) {
+			if err := a.Do(r.ctx); err != nil {
+				log.Printf("Failed to continue request for %s: %v", url, err)
+			}
+		}(a, e.Request.URL)
+	}
+}
+
+// Return the rewritten URL according to the first matching rule, if any.
+func (r *Rewriter) rewrite(url string) (string, bool) {
+	for _, rule := range r.rules {
+		if captures, ok := matchWildcards(rule.pattern, url); ok {
+			return expandWildcards(rule.target, captures), true
+		}
+	}
+	return "", false
+}
+
+// Return an action which redirects the paused request with the given ID
+// to the given target URL: transparently if the target has the same scheme
+// as the original URL, otherwise with a "307 Temporary Redirect" response
+// (which preserves the request's method and body), because the browser
+// rejects scheme changes in the `Fetch.continueRequest` command.
+func redirect(requestID, from, to string) Action {
+	if scheme(from) == scheme(to) {
+		return NewContinueRequest(requestID).SetURL(to)
+	}
+	return NewFulfillRequest(requestID, 307).SetResponseHeaders([]HeaderEntry{
+		{Name: "Location", Value: to},
+		{Name: "Cache-Control", Value: "no-store"},
+	})
+}
+
+func scheme(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Scheme)
+}
+
+// Stop stops redirecting requests, and disables the Fetch domain.
+func (r *Rewriter) Stop() error {
+	r.paused.Unsubscribe()
+	<-r.done
+	return NewDisable().Do(r.ctx)
+}
+
+// Match a string against a CDP URL pattern, and return the substrings
+// which were matched by each `*` in the pattern. Each `*` matches as few
+// characters as possible, so later literals anchor as early as possible.
+//
+// This runs in O(len(pattern) * len(s)) time, without recursion: when the
+// rest of the pattern doesn't match, only the latest `*` is extended, because
+// a match which requires extending an earlier `*` can also be achieved by
+// extending the latest one instead.
+func matchWildcards(pattern, s string) ([]string, bool) {
+	var starts, ends []int // Of the substrings which each `*` matched.
+	p, i := 0, 0
+	starP, starI := -1, 0 // Position of the latest `*` in the pattern and in s.
+	for i < len(s) {
+		if p < len(pattern) {
+			c, n := pattern[p], 1
+			switch {
+			case c == '*':
+				starts, ends = append(starts, i), append(ends, i)
+				starP, starI = p, i
+				p++
+				continue
+			case c == '?':
+				p, i = p+1, i+1
+				continue
+			case c == '\\' && p+1 < len(pattern):
+				c, n = pattern[p+1], 2
+			}
+			if s[i] == c {
+				p, i = p+n, i+1
+				continue
+			}
+		}
+		// Mismatch: extend the latest `*` by one character, and retry.
+		if starP < 0 {
+			return nil, false
+		}
+		starI++
+		ends[len(ends)-1] = starI
+		p, i = starP+1, starI
+	}
+	// Trailing `*` characters match the empty string.
+	for p < len(pattern) && pattern[p] == '*' {
+		starts, ends = append(starts, i), append(ends, i)
+		p++
+	}
+	if p < len(pattern) {
+		return nil, false
+	}
+	captures := make([]string, len(starts))
+	for k := range starts {
+		captures[k] = s[starts[k]:ends[k]]
+	}
+	return captures, true
+}
+
+// Replace each `*` in the given target with the corresponding capture.
+// Extra `*` characters are removed, and extra captures are ignored.
+func expandWildcards(target string, captures []string) string {
+	var b strings.Builder
+	for i := 0; i < len(target); i++ {
+		switch c := target[i]; {
+		case c == '\\' && i+1 < len(target):
+			i++
+			b.WriteByte(target[i])
+		case c == '*':
+			if len(captures) > 0 {
+				b.WriteString(captures[0])
+				captures = captures[1:]
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
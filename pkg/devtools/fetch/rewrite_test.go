@@ -0,0 +1,71 @@
+package fetch
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRewrite(t *testing.T) {
+	r := &Rewriter{rules: []rewriteRule{
+		{"https://cdn.example.com/assets/*", "http://localhost:8080/*"},
+		{"https://*.example.com/*", "https://staging.example.com/*/*"},
+		{"https://example.com/a?c", "https://example.com/\\*"},
+	}}
+	tests := []struct {
+		url, want string
+		ok        bool
+	}{
+		{"https://cdn.example.com/assets/app.js", "http://localhost:8080/app.js", true},
+		{"https://www.example.com/index.html", "https://staging.example.com/www/index.html", true},
+		{"https://example.com/abc", "https://example.com/*", true},
+		{"https://example.com/ac", "", false},
+		{"https://example.org/", "", false},
+	}
+	for _, test := range tests {
+		got, ok := r.rewrite(test.url)
+		if got != test.want || ok != test.ok {
+			t.Errorf("rewrite(%q) = %q, %v; want %q, %v", test.url, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestMatchWildcards(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       []string
+		ok         bool
+	}{
+		{"", "", []string{}, true},
+		{"*", "", []string{""}, true},
+		{"a*c*", "abcbc", []string{"b", "bc"}, true},
+		{"*/*", "a/b/c", []string{"a", "b/c"}, true},
+		{"a?c", "abc", []string{}, true},
+		{"a?c", "ac", nil, false},
+		{"a\\*", "a*", []string{}, true},
+		{"a\\*", "ab", nil, false},
+		{"*x*x", "axbxcx", []string{"a", "bxc"}, true},
+		{"*.js", "app.json", nil, false},
+		// Exponential with a naive backtracking matcher.
+		{strings.Repeat("*a", 30) + "b", strings.Repeat("a", 100), nil, false},
+	}
+	for _, tt := range tests {
+		got, ok := matchWildcards(tt.pattern, tt.s)
+		if ok != tt.ok || (ok && !cmp.Equal(got, tt.want)) {
+			t.Errorf("matchWildcards(%q, %q) = %q, %v; want %q, %v", tt.pattern, tt.s, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRedirect(t *testing.T) {
+	a := redirect("1", "https://cdn.example.com/a.js", "https://localhost:8443/a.js")
+	if c, ok := a.(*ContinueRequest); !ok || c.URL != "https://localhost:8443/a.js" {
+		t.Errorf("redirect(same scheme) = %#v, want continueRequest", a)
+	}
+	a = redirect("1", "https://cdn.example.com/a.js", "http://localhost:8080/a.js")
+	f, ok := a.(*FulfillRequest)
+	if !ok || f.ResponseCode != 307 || f.ResponseHeaders[0] != (HeaderEntry{Name: "Location", Value: "http://localhost:8080/a.js"}) {
+		t.Errorf("redirect(other scheme) = %#v, want a 307 response", a)
+	}
+}
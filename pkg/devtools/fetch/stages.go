@@ -0,0 +1,186 @@
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+)
+
+// Stage returns the stage at which the request was paused: before it was
+// sent (`fetch.RequestStageRequest`), or after its response headers were
+// received (`fetch.RequestStageResponse`), see `fetch.RequestPattern`.
+func (e *RequestPaused) Stage() RequestStage {
+	if e.ResponseStatusCode != 0 || e.ResponseErrorReason != nil {
+		return RequestStageResponse
+	}
+	return RequestStageRequest
+}
+
+// ResponseHeader returns the response headers of a request which was
+// paused at the response stage as a Go `http.Header` map.
+func (e *RequestPaused) ResponseHeader() http.Header {
+	return Header(e.ResponseHeaders)
+}
+
+// Action is a CDP command which resumes a paused request, e.g.
+// `fetch.ContinueRequest`, `fetch.ContinueResponse`, `fetch.FailRequest`,
+// or `fetch.FulfillRequest` (see `fetch.NewFulfillResponse`).
+type Action interface {
+	Do(ctx context.Context) error
+}
+
+// RequestHandler decides how to resume a request which was paused before it
+// was sent, see `fetch.OnRequest`. A nil action continues it unmodified.
+type RequestHandler = func(e *RequestPaused) Action
+
+// ResponseHandler decides how to resume a request which was paused after
+// its response headers were received, see `fetch.OnResponse`. A nil action
+// continues it unmodified.
+type ResponseHandler = func(r *PausedResponse) Action
+
+// PausedResponse is a request which was paused at the response stage,
+// see the `fetch.OnResponse` function. Its status code and headers are
+// available in the `ResponseStatusCode` field and the `ResponseHeader`
+// method, and its body is fetched only if the handler asks for it.
+type PausedResponse struct {
+	*RequestPaused
+	ctx context.Context
+
+	once sync.Once
+	body *network.Body
+	err  error
+}
+
+// Body returns the body of the paused response, with the
+// `fetch.GetResponseBody` CDP command, which is sent only once, on the first
+// call. It returns an error if the response failed (see the
+// `ResponseErrorReason` field), or it's a redirect. Note that the browser
+// receives the whole body before it's returned, so streaming large responses
+// is better done with the `fetch.ExpectDownload` function.
+func (r *PausedResponse) Body() (*network.Body, error) {
+	r.once.Do(func() {
+		var b *GetResponseBodyResult
+		if b, r.err = NewGetResponseBody(r.RequestID).Do(r.ctx); r.err != nil {
+			return
+		}
+		r.body, r.err = network.NewBody(b.Body, b.Base64Encoded, r.ResponseHeader().Get("Content-Type"))
+	})
+	return r.body, r.err
+}
+
+// Interceptor dispatches paused requests to request-stage and
+// response-stage handlers, see the `fetch.Intercept` function.
+type Interceptor struct {
+	ctx        context.Context
+	patterns   []RequestPattern
+	onRequest  RequestHandler
+	onResponse ResponseHandler
+	paused     *devtools.Subscription
+	done       chan struct{}
+}
+
+// InterceptOption is used for customization in the `fetch.Intercept` function.
+type InterceptOption = func(*Interceptor)
+
+// OnRequest allows the caller of the `fetch.Intercept` function to handle
+// requests which match the given URL patterns (all requests if there are
+// none) before they're sent, e.g. to modify, fulfill or block them.
+func OnRequest(h RequestHandler, urlPatterns ...string) InterceptOption {
+	return func(i *Interceptor) {
+		i.onRequest = h
+		i.addPatterns(RequestStageRequest, urlPatterns)
+	}
+}
+
+// OnResponse allows the caller of the `fetch.Intercept` function to handle
+// requests which match the given URL patterns (all requests if there are
+// none) after their response headers are received, e.g. to inspect or
+// replace responses.
+func OnResponse(h ResponseHandler, urlPatterns ...string) InterceptOption {
+	return func(i *Interceptor) {
+		i.onResponse = h
+		i.addPatterns(RequestStageResponse, urlPatterns)
+	}
+}
+
+func (i *Interceptor) addPatterns(stage RequestStage, urlPatterns []string) {
+	if len(urlPatterns) == 0 {
+		urlPatterns = []string{"*"}
+	}
+	for _, p := range urlPatterns {
+		i.patterns = append(i.patterns, RequestPattern{URLPattern: p, RequestStage: &stage})
+	}
+}
+
+// Intercept starts pausing requests of the page associated with the given
+// context at the stages which have handlers (see `fetch.OnRequest` and
+// `fetch.OnResponse`), until the `Stop` method of the returned interceptor
+// is called. Requests which match patterns of both stages are paused twice.
+//
+// Handlers are called in separate goroutines, so a slow handler doesn't
+// delay other requests, and their actions are sent as soon as they return.
+//
+// This function enables the Fetch domain, overriding the request patterns
+// of any other interception in the same page.
+func Intercept(ctx context.Context, opts ...InterceptOption) (*Interceptor, error) {
+	i := &Interceptor{ctx: ctx, done: make(chan struct{})}
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	var err error
+	if i.paused, err = devtools.Subscribe(ctx, "Fetch.requestPaused"); err != nil {
+		return nil, err
+	}
+	go i.intercept(devtools.EventsOfSession(ctx))
+
+	if err := NewEnable().SetPatterns(i.patterns).Do(ctx); err != nil {
+		i.Stop()
+		return nil, err
+	}
+	return i, nil
+}
+
+// Receive paused requests until the subscription ends.
+func (i *Interceptor) intercept(accept devtools.EventFilter) {
+	defer close(i.done)
+	for m := range i.paused.C {
+		e := &RequestPaused{}
+		if !accept(m) || json.Unmarshal(m.Params, e) != nil {
+			continue
+		}
+		go func() {
+			if err := i.handle(e).Do(i.ctx); err != nil {
+				log.Printf("Failed to resume request for %s: %v", e.Request.URL, err)
+			}
+		}()
+	}
+}
+
+// Return the action of the handler of the given paused request's stage.
+func (i *Interceptor) handle(e *RequestPaused) Action {
+	var a Action
+	if e.Stage() == RequestStageRequest {
+		if i.onRequest != nil {
+			a = i.onRequest(e)
+		}
+	} else if i.onResponse != nil {
+		a = i.onResponse(&PausedResponse{RequestPaused: e, ctx: i.ctx})
+	}
+	if a == nil {
+		return NewContinueRequest(e.RequestID)
+	}
+	return a
+}
+
+// Stop stops intercepting requests, and disables the Fetch domain.
+func (i *Interceptor) Stop() error {
+	i.paused.Unsubscribe()
+	<-i.done
+	return NewDisable().Do(i.ctx)
+}
@@ -0,0 +1,97 @@
+package fetch
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInterceptorHandle(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	b.Respond("Fetch.getResponseBody", map[string]interface{}{"body": "aGVsbG8=", "base64Encoded": true})
+	i := &Interceptor{ctx: ctx}
+	OnRequest(func(e *RequestPaused) Action {
+		if e.ResourceType == network.ResourceTypeImage {
+			return NewFailRequest(e.RequestID, network.ErrorReasonBlockedByClient)
+		}
+		return nil
+	}, "*.png", "*.jpg")(i)
+	OnResponse(func(r *PausedResponse) Action {
+		if r.ResponseStatusCode != 200 || r.ResponseHeader().Get("Content-Type") != "text/plain" {
+			return nil
+		}
+		body, err := r.Body()
+		if err != nil {
+			t.Errorf("Body(); got error: %v", err)
+			return nil
+		}
+		r.Body() // Cached.
+		f, _ := NewFulfillResponse(r.RequestID, 200, r.ResponseHeader(), strings.NewReader(strings.ToUpper(string(body.Bytes))))
+		return f
+	})(i)
+
+	request, response := RequestStageRequest, RequestStageResponse
+	wantPatterns := []RequestPattern{
+		{URLPattern: "*.png", RequestStage: &request},
+		{URLPattern: "*.jpg", RequestStage: &request},
+		{URLPattern: "*", RequestStage: &response},
+	}
+	if diff := cmp.Diff(wantPatterns, i.patterns); diff != "" {
+		t.Errorf("patterns mismatch (-want +got):\n%s", diff)
+	}
+
+	textHeaders := []HeaderEntry{{Name: "content-type", Value: "text/plain"}}
+	tests := []struct {
+		name      string
+		event     *RequestPaused
+		wantStage RequestStage
+		want      string
+	}{
+		{
+			name:      "request_image",
+			event:     &RequestPaused{RequestID: "1", ResourceType: network.ResourceTypeImage},
+			wantStage: RequestStageRequest,
+			want:      `{"requestId":"1","errorReason":"BlockedByClient"}`,
+		},
+		{
+			name:      "request_other",
+			event:     &RequestPaused{RequestID: "2", ResourceType: network.ResourceTypeScript},
+			wantStage: RequestStageRequest,
+			want:      `{"requestId":"2"}`,
+		},
+		{
+			name:      "response_text",
+			event:     &RequestPaused{RequestID: "3", ResponseStatusCode: 200, ResponseHeaders: textHeaders},
+			wantStage: RequestStageResponse,
+			want:      `{"requestId":"3","responseCode":200,"responseHeaders":[{"name":"Content-Length","value":"5"},{"name":"Content-Type","value":"text/plain"}],"body":"SEVMTE8=","responsePhrase":"OK"}`,
+		},
+		{
+			name:      "response_not_found",
+			event:     &RequestPaused{RequestID: "4", ResponseStatusCode: 404, ResponseHeaders: textHeaders},
+			wantStage: RequestStageResponse,
+			want:      `{"requestId":"4"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.Stage(); got != tt.wantStage {
+				t.Errorf("Stage() = %q, want %q", got, tt.wantStage)
+			}
+			got, err := json.Marshal(i.handle(tt.event))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("handle() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+	if n := len(b.Calls("Fetch.getResponseBody")); n != 1 {
+		t.Errorf("Fetch.getResponseBody sent %d times, want 1", n)
+	}
+}
@@ -0,0 +1,179 @@
+package devtools
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotSupported is returned by the `devtools.Send` and `devtools.SendAndWait`
+// functions (and by the CDP commands in the domain sub-packages) when the
+// browser is known not to implement the command, see `devtools.Supports`.
+var ErrNotSupported = errors.New("CDP command not supported by the browser")
+
+// firefoxCommands is the subset of CDP commands which is implemented by
+// Firefox's remote agent (https://firefox-source-docs.mozilla.org/remote/),
+// enough for navigation, screenshots, script evaluation and console messages.
+var firefoxCommands = map[string]bool{
+	"Browser.close":      true,
+	"Browser.getVersion": true,
+
+	"DOM.describeNode":           true,
+	"DOM.disable":                true,
+	"DOM.enable":                 true,
+	"DOM.getBoxModel":            true,
+	"DOM.getContentQuads":        true,
+	"DOM.resolveNode":            true,
+	"DOM.scrollIntoViewIfNeeded": true,
+	"DOM.setFileInputFiles":      true,
+
+	"Emulation.setDeviceMetricsOverride": true,
+	"Emulation.setTouchEmulationEnabled": true,
+	"Emulation.setUserAgentOverride":     true,
+
+	"Input.dispatchKeyEvent":   true,
+	"Input.dispatchMouseEvent": true,
+	"Input.insertText":         true,
+
+	"Log.disable": true,
+	"Log.enable":  true,
+
+	"Network.clearBrowserCookies":  true,
+	"Network.deleteCookies":        true,
+	"Network.disable":              true,
+	"Network.enable":               true,
+	"Network.getAllCookies":        true,
+	"Network.getCookies":           true,
+	"Network.setCacheDisabled":     true,
+	"Network.setCookie":            true,
+	"Network.setCookies":           true,
+	"Network.setExtraHTTPHeaders":  true,
+	"Network.setUserAgentOverride": true,
+
+	"Page.addScriptToEvaluateOnNewDocument":    true,
+	"Page.bringToFront":                        true,
+	"Page.captureScreenshot":                   true,
+	"Page.close":                               true,
+	"Page.createIsolatedWorld":                 true,
+	"Page.disable":                             true,
+	"Page.enable":                              true,
+	"Page.getFrameTree":                        true,
+	"Page.getLayoutMetrics":                    true,
+	"Page.getNavigationHistory":                true,
+	"Page.handleJavaScriptDialog":              true,
+	"Page.navigate":                            true,
+	"Page.navigateToHistoryEntry":              true,
+	"Page.printToPDF":                          true,
+	"Page.reload":                              true,
+	"Page.removeScriptToEvaluateOnNewDocument": true,
+	"Page.setBypassCSP":                        true,
+	"Page.setInterceptFileChooserDialog":       true,
+	"Page.setLifecycleEventsEnabled":           true,
+	"Page.stopLoading":                         true,
+
+	"Runtime.addBinding":     true,
+	"Runtime.callFunctionOn": true,
+	"Runtime.disable":        true,
+	"Runtime.enable":         true,
+	"Runtime.evaluate":       true,
+	"Runtime.getProperties":  true,
+	"Runtime.releaseObject":  true,
+
+	"Security.setIgnoreCertificateErrors": true,
+
+	"Target.activateTarget":        true,
+	"Target.attachToTarget":        true,
+	"Target.closeTarget":           true,
+	"Target.createBrowserContext":  true,
+	"Target.createTarget":          true,
+	"Target.disposeBrowserContext": true,
+	"Target.getBrowserContexts":    true,
+	"Target.getTargets":            true,
+	"Target.setDiscoverTargets":    true,
+}
+
+// Preferences which are required for automating Firefox, written to the
+// "user.js" file in its profile directory. Based on:
+// https://github.com/puppeteer/puppeteer/blob/main/packages/browsers/src/browser-data/firefox.ts
+const firefoxPrefs = `user_pref("remote.active-protocols", 3);
+user_pref("browser.shell.checkDefaultBrowser", false);
+user_pref("browser.startup.homepage_override.mstone", "ignore");
+user_pref("browser.tabs.warnOnClose", false);
+user_pref("datareporting.policy.dataSubmissionEnabled", false);
+user_pref("toolkit.telemetry.reportingpolicy.firstRun", false);
+`
+
+// Firefox allows the caller of the `devtools.NewContext` function to start
+// Firefox instead of Chrome, and to use only the subset of the CDP which
+// it implements: navigation, screenshots, script evaluation, and console
+// messages via `Runtime.consoleAPICalled` events. Other commands fail with
+// `devtools.ErrNotSupported` errors, instead of hanging or failing
+// obscurely. Firefox is always driven over a WebSocket, not pipes.
+//
+// This compatibility mode is also enabled automatically when the executable
+// (see the `devtools.BrowserPath` session option) or the remote browser
+// (see the `devtools.RemoteBrowser` session option) is detected as Firefox.
+// The `devtools.Stealth` session option is not supported in this mode.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser.
+func Firefox() SessionOption {
+	return func(s *Session) {
+		s.firefox = true
+	}
+}
+
+// DefaultFirefoxFlags returns a map of default command-line flags for starting
+// Firefox, the equivalent of `devtools.DefaultBrowserFlags` for Chrome. Pass
+// a modified copy to the `devtools.BrowserFlags` session option to override
+// them, together with the `devtools.Firefox` session option.
+func DefaultFirefoxFlags() map[string]interface{} {
+	return map[string]interface{}{
+		"headless":     true,
+		"new-instance": true,
+		"no-remote":    true,
+	}
+}
+
+// Supports reports whether the browser associated with the given context is
+// known to implement the given CDP command (e.g. "Page.navigate"). This is
+// always true for Chrome, and for the subset of the CDP which is implemented
+// by Firefox (see the `devtools.Firefox` session option).
+func Supports(ctx context.Context, method string) bool {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return false
+	}
+	return !s.firefox || firefoxCommands[method]
+}
+
+// Report whether the given executable path belongs to Firefox.
+func isFirefox(name string) bool {
+	return strings.Contains(strings.ToLower(filepath.Base(name)), "firefox")
+}
+
+func adjustFirefoxFlags(s *Session) []string {
+	// Firefox doesn't support pipes, only WebSockets, on a random port
+	// which is reported in STDERR.
+	delete(s.browserFlags, "remote-debugging-pipe")
+	s.browserFlags["remote-debugging-port"] = "0"
+
+	if s.UserDataDir == "" {
+		s.UserDataDir = filepath.Join(s.OutputDir, "user_data")
+	}
+	s.browserFlags["profile"] = s.UserDataDir
+
+	return flagsToArgs(s.browserFlags)
+}
+
+// Write the preferences which are required for automation in the given
+// Firefox profile directory, unless it already has a "user.js" file.
+func writeFirefoxPrefs(dir string) error {
+	path := filepath.Join(dir, "user.js")
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	return os.WriteFile(path, []byte(firefoxPrefs), 0644)
+}
@@ -0,0 +1,66 @@
+package devtools
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAdjustFirefoxFlags(t *testing.T) {
+	s := &Session{OutputDir: "out", browserFlags: DefaultFirefoxFlags()}
+	s.browserFlags["remote-debugging-pipe"] = true
+	got := adjustFirefoxFlags(s)
+	want := []string{
+		"--headless",
+		"--new-instance",
+		"--no-remote",
+		"--profile=" + filepath.Join("out", "user_data"),
+		"--remote-debugging-port=0",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("adjustFirefoxFlags() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsFirefox(t *testing.T) {
+	tests := map[string]bool{
+		"/usr/bin/firefox": true,
+		"/Applications/Firefox.app/Contents/MacOS/firefox": true,
+		"/usr/bin/google-chrome":                           false,
+		"/opt/firefox-profiles/chromium":                   false,
+	}
+	for path, want := range tests {
+		if got := isFirefox(path); got != want {
+			t.Errorf("isFirefox(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestSupports(t *testing.T) {
+	chrome := context.WithValue(context.Background(), sessionKey{}, &Session{})
+	firefox := context.WithValue(context.Background(), sessionKey{}, &Session{firefox: true})
+	tests := []struct {
+		ctx    context.Context
+		method string
+		want   bool
+	}{
+		{chrome, "Fetch.enable", true},
+		{firefox, "Page.navigate", true},
+		{firefox, "Page.captureScreenshot", true},
+		{firefox, "Runtime.evaluate", true},
+		{firefox, "Fetch.enable", false},
+		{context.Background(), "Page.navigate", false},
+	}
+	for _, test := range tests {
+		if got := Supports(test.ctx, test.method); got != test.want {
+			t.Errorf("Supports(%q) = %v, want %v", test.method, got, test.want)
+		}
+	}
+
+	if _, err := Send(firefox, "Fetch.enable", nil); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Send() error = %v, want %v", err, ErrNotSupported)
+	}
+}
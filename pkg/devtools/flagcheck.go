@@ -0,0 +1,249 @@
+package devtools
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// knownBrowserFlags are Chrome flags which are recognized by the
+// `devtools.ValidateFlags` function, in addition to the default flags,
+// in order to detect misspelled flags.
+var knownBrowserFlags = []string{
+	"allow-running-insecure-content",
+	"app",
+	"auto-open-devtools-for-tabs",
+	"autoplay-policy",
+	"crash-dumps-dir",
+	"disable-blink-features",
+	"disable-device-discovery-notifications",
+	"disable-gpu",
+	"disable-http2",
+	"disable-notifications",
+	"disable-quic",
+	"disable-setuid-sandbox",
+	"disable-software-rasterizer",
+	"disable-web-security",
+	"enable-crash-reporter",
+	"enable-logging",
+	"enable-quic",
+	"force-device-scale-factor",
+	"hide-scrollbars",
+	"host-resolver-rules",
+	"ignore-certificate-errors",
+	"incognito",
+	"js-flags",
+	"kiosk",
+	"lang",
+	"log-level",
+	"no-proxy-server",
+	"no-sandbox",
+	"origin-to-force-quic-on",
+	"proxy-auto-detect",
+	"proxy-bypass-list",
+	"proxy-pac-url",
+	"proxy-server",
+	"remote-debugging-pipe",
+	"remote-debugging-port",
+	"start-maximized",
+	"use-fake-device-for-media-stream",
+	"use-fake-ui-for-media-stream",
+	"use-file-for-fake-video-capture",
+	"user-agent",
+	"user-data-dir",
+	"window-position",
+	"window-size",
+}
+
+// Mutually-exclusive proxy configuration flags.
+var proxyFlags = []string{"proxy-server", "proxy-pac-url", "proxy-auto-detect", "no-proxy-server"}
+
+var pairFlag = regexp.MustCompile(`^\d+,\d+$`)
+
+// StrictFlags allows the caller of the `devtools.NewContext` function to fail
+// when starting the browser if its flags have warnings, not only errors (see
+// the `devtools.ValidateFlags` function). By default, warnings are logged.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser, with remote browsers, and with Firefox.
+func StrictFlags() SessionOption {
+	return func(s *Session) {
+		s.strictFlags = true
+	}
+}
+
+// ValidateFlags checks the given Chrome command-line flags (e.g. from
+// `devtools.DefaultBrowserFlags`, after the caller's changes), and returns
+// warnings about flags which are probably misspelled or ineffective, and an
+// error about flags which are malformed or conflicting. The `devtools.NewContext`
+// function calls it before starting the browser, so mistakes are reported
+// immediately rather than causing silent misbehavior.
+func ValidateFlags(flags map[string]interface{}) ([]string, error) {
+	var warnings, errs []string
+	known := make(map[string]bool)
+	for k := range defaultBrowserFlags {
+		known[k] = true
+	}
+	for _, k := range knownBrowserFlags {
+		known[k] = true
+	}
+
+	keys := make([]string, 0, len(flags))
+	for k := range flags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		// Values of any type are allowed, like in the browser's
+		// command line, where they're formatted with "%v".
+		switch {
+		case strings.HasPrefix(k, "-"):
+			errs = append(errs, fmt.Sprintf("flag %q must not start with dashes", k))
+		case strings.Contains(k, "="):
+			errs = append(errs, fmt.Sprintf("flag %q must not contain its value", k))
+		case !known[k]:
+			if s := suggestFlag(k, known); s != "" {
+				warnings = append(warnings, fmt.Sprintf("unknown flag %q, did you mean %q?", k, s))
+			}
+		}
+	}
+
+	for _, k := range []string{"window-size", "window-position"} {
+		if v, ok := flags[k]; ok && !pairFlag.MatchString(fmt.Sprint(v)) {
+			errs = append(errs, fmt.Sprintf(`flag %q must be "x,y" with non-negative integers, not %q`, k, fmt.Sprint(v)))
+		}
+	}
+	headless, isHeadless := flags["headless"]
+	if v, ok := headless.(string); ok && v != "new" && v != "old" {
+		errs = append(errs, fmt.Sprintf(`flag "headless" must be true, "new" or "old", not %q`, v))
+	}
+	if b, ok := headless.(bool); ok && !b {
+		isHeadless = false
+	}
+	if isHeadless {
+		for _, k := range []string{"start-maximized", "auto-open-devtools-for-tabs", "kiosk"} {
+			if isSet(flags, k) {
+				warnings = append(warnings, fmt.Sprintf("flag %q has no effect in headless mode", k))
+			}
+		}
+		if headless == "new" && isSet(flags, "window-size") {
+			warnings = append(warnings, `flag "window-size" sets the outer window size with "headless=new", so the viewport is smaller`)
+		}
+	}
+
+	var proxies []string
+	for _, k := range proxyFlags {
+		if isSet(flags, k) {
+			proxies = append(proxies, k)
+		}
+	}
+	if len(proxies) > 1 {
+		errs = append(errs, fmt.Sprintf("conflicting proxy flags: %s", strings.Join(proxies, ", ")))
+	}
+	if isSet(flags, "proxy-bypass-list") && !isSet(flags, "proxy-server") {
+		warnings = append(warnings, `flag "proxy-bypass-list" has no effect without "proxy-server"`)
+	}
+
+	if isSet(flags, "disable-quic") {
+		for _, k := range []string{"enable-quic", "origin-to-force-quic-on"} {
+			if isSet(flags, k) {
+				errs = append(errs, fmt.Sprintf("conflicting QUIC flags: disable-quic, %s", k))
+			}
+		}
+	}
+
+	for _, f := range overlappingFeatures(flags, "enable-features", "disable-features") {
+		errs = append(errs, fmt.Sprintf("feature %q is both enabled and disabled", f))
+	}
+	for _, f := range overlappingFeatures(flags, "enable-blink-features", "disable-blink-features") {
+		errs = append(errs, fmt.Sprintf("Blink feature %q is both enabled and disabled", f))
+	}
+
+	for _, k := range []string{"remote-debugging-pipe", "remote-debugging-port", "user-data-dir"} {
+		if _, ok := flags[k]; ok {
+			warnings = append(warnings, fmt.Sprintf("flag %q is overridden when the browser starts", k))
+		}
+	}
+
+	if len(errs) > 0 {
+		return warnings, fmt.Errorf("invalid browser flags: %s", strings.Join(errs, "; "))
+	}
+	return warnings, nil
+}
+
+// Report whether a flag is specified, and isn't false.
+func isSet(flags map[string]interface{}, k string) bool {
+	v, ok := flags[k]
+	if b, isBool := v.(bool); isBool {
+		return b
+	}
+	return ok
+}
+
+// Return the features which are listed in both of the given
+// flags (comma-separated lists), in sorted order.
+func overlappingFeatures(flags map[string]interface{}, enable, disable string) []string {
+	enabled := make(map[string]bool)
+	if v, ok := flags[enable].(string); ok {
+		for _, f := range strings.Split(v, ",") {
+			enabled[strings.TrimSpace(f)] = true
+		}
+	}
+	var both []string
+	if v, ok := flags[disable].(string); ok {
+		for _, f := range strings.Split(v, ",") {
+			if f = strings.TrimSpace(f); f != "" && enabled[f] {
+				both = append(both, f)
+			}
+		}
+	}
+	sort.Strings(both)
+	return both
+}
+
+// Return the known flag which is closest to the given unknown flag,
+// if it's close enough to be a probable misspelling.
+func suggestFlag(k string, known map[string]bool) string {
+	best, bestDist := "", 3
+	for f := range known {
+		if d := editDistance(k, f); d < bestDist || (d == bestDist && f < best) {
+			best, bestDist = f, d
+		}
+	}
+	if bestDist > 2 {
+		return ""
+	}
+	return best
+}
+
+// Levenshtein distance (https://en.wikipedia.org/wiki/Levenshtein_distance).
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,98 @@
+package devtools
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateFlags(t *testing.T) {
+	tests := []struct {
+		name         string
+		flags        map[string]interface{}
+		wantWarnings []string
+		wantErr      string
+	}{
+		{
+			name:  "defaults",
+			flags: DefaultBrowserFlags(),
+		},
+		{
+			name:         "misspelled",
+			flags:        map[string]interface{}{"windows-position": "0,0", "disable-gpus": true, "my-custom-switch": true},
+			wantWarnings: []string{`unknown flag "disable-gpus", did you mean "disable-gpu"?`, `unknown flag "windows-position", did you mean "window-position"?`},
+		},
+		{
+			name:    "dashes",
+			flags:   map[string]interface{}{"--headless": true},
+			wantErr: `flag "--headless" must not start with dashes`,
+		},
+		{
+			name:    "window_size",
+			flags:   map[string]interface{}{"window-size": "1920x1080"},
+			wantErr: `flag "window-size" must be "x,y"`,
+		},
+		{
+			name:         "headless_new",
+			flags:        map[string]interface{}{"headless": "new", "window-size": "800,600", "start-maximized": true},
+			wantWarnings: []string{`flag "start-maximized" has no effect in headless mode`, `flag "window-size" sets the outer window size with "headless=new", so the viewport is smaller`},
+		},
+		{
+			name:  "headful",
+			flags: map[string]interface{}{"headless": false, "start-maximized": true},
+		},
+		{
+			name:    "proxies",
+			flags:   map[string]interface{}{"proxy-server": "localhost:8080", "proxy-pac-url": "http://pac"},
+			wantErr: "conflicting proxy flags: proxy-server, proxy-pac-url",
+		},
+		{
+			name:    "quic",
+			flags:   map[string]interface{}{"disable-quic": true, "enable-quic": true},
+			wantErr: "conflicting QUIC flags: disable-quic, enable-quic",
+		},
+		{
+			name:    "features",
+			flags:   map[string]interface{}{"enable-features": "A, B", "disable-features": "B,C"},
+			wantErr: `feature "B" is both enabled and disabled`,
+		},
+		{
+			name:  "value_types",
+			flags: map[string]interface{}{"window-size": pair{800, 600}, "renderer-process-limit": uint(4), "force-device-scale-factor": float32(2), "js-flags": []string{"--expose-gc"}},
+		},
+		{
+			name:         "overridden",
+			flags:        map[string]interface{}{"user-data-dir": "/tmp"},
+			wantWarnings: []string{`flag "user-data-dir" is overridden when the browser starts`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := ValidateFlags(tt.flags)
+			if diff := cmp.Diff(tt.wantWarnings, warnings); diff != "" {
+				t.Errorf("ValidateFlags() warnings mismatch (-want +got):\n%s", diff)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("ValidateFlags(); got error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("ValidateFlags() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	if got := editDistance("kitten", "sitting"); got != 3 {
+		t.Errorf("editDistance() = %d, want 3", got)
+	}
+}
+
+// A flag value which implements `fmt.Stringer`.
+type pair [2]int
+
+func (p pair) String() string {
+	return fmt.Sprintf("%d,%d", p[0], p[1])
+}
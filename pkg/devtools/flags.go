@@ -69,6 +69,8 @@ var defaultBrowserFlags = map[string]interface{}{
 // https://github.com/GoogleChrome/chrome-launcher/blob/master/docs/chrome-flags-for-tools.md
 // and https://peter.sh/experiments/chromium-command-line-switches.
 //
+// Flags are validated when the browser starts, see `devtools.ValidateFlags`.
+//
 // Examples of a few other common flags to consider:
 // 	flags := devtools.DefaultBrowserFlags()
 // 	flags["allow-running-insecure-content"] = true
@@ -85,7 +87,7 @@ var defaultBrowserFlags = map[string]interface{}{
 // 	flags["use-fake-device-for-media-stream"] = true // Required for the next flag.
 // 	flags["use-file-for-fake-video-capture"] = "<path-to-file>" // .y4m or .mjpeg.
 // 	flags["user-agent"] = "..."
-// 	flags["windows-position"] = "x,y" // "x" and "y" are non-negative integers.
+// 	flags["window-position"] = "x,y" // "x" and "y" are non-negative integers.
 // 	flags["window-size"] = "x,y" // "x" and "y" are positive integers.
 func DefaultBrowserFlags() map[string]interface{} {
 	// Initialize a copy of the default map.
@@ -104,13 +106,15 @@ func DefaultBrowserFlags() map[string]interface{} {
 
 func adjustFlags(s *Session) []string {
 	// Runtime adjustments to set-up communication with the browser process.
-	if runtime.GOOS != "windows" {
-		// Prefer pipes over WebSockets in POSIX-compliant operating systems.
+	if runtime.GOOS != "windows" && !s.resumable {
+		// Prefer pipes over WebSockets in POSIX-compliant operating systems,
+		// unless the session must be resumable (see `devtools.Resumable`).
 		s.browserFlags["remote-debugging-pipe"] = true
 		delete(s.browserFlags, "remote-debugging-port")
 	} else {
 		// https://golang.org/pkg/os/exec/#Cmd.ExtraFiles
-		// is not supported by Go in Windows.
+		// is not supported by Go in Windows, and resumable
+		// sessions need an address to re-connect to.
 		delete(s.browserFlags, "remote-debugging-pipe")
 		if _, ok := s.browserFlags["remote-debugging-port"]; !ok {
 			s.browserFlags["remote-debugging-port"] = "0"
@@ -127,15 +131,32 @@ func adjustFlags(s *Session) []string {
 	}
 	s.browserFlags["user-data-dir"] = s.UserDataDir
 
-	// Convert the map to a sorted slice.
+	if s.stealth {
+		adjustStealthFlags(s)
+	}
+	if s.unsafeSecurity {
+		adjustUnsafeSecurityFlags(s)
+	}
+	if s.protocols != nil {
+		adjustProtocolFlags(s)
+	}
+	if s.crashDumps != nil {
+		adjustCrashDumpFlags(s)
+	}
+
+	return flagsToArgs(s.browserFlags)
+}
+
+// Convert a map of browser flags to a sorted slice of command-line args.
+func flagsToArgs(flags map[string]interface{}) []string {
 	var args, keys []string
-	for k := range s.browserFlags {
+	for k := range flags {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
 		flag := "--" + k
-		switch v := s.browserFlags[k].(type) {
+		switch v := flags[k].(type) {
 		case bool:
 			if v {
 				args = append(args, flag)
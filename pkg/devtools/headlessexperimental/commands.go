@@ -36,13 +36,23 @@ type BeginFrame struct {
 
 // NewBeginFrame constructs a new BeginFrame struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `BeginFrameOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/HeadlessExperimental/#method-beginFrame
-func NewBeginFrame() *BeginFrame {
-	return &BeginFrame{}
+func NewBeginFrame(opts ...BeginFrameOption) *BeginFrame {
+	c := &BeginFrame{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// BeginFrameOption is a functional option for the optional
+// parameters of the BeginFrame CDP command, see the
+// NewBeginFrame constructor. Options are applied in order.
+type BeginFrameOption = func(*BeginFrame)
+
 // SetFrameTimeTicks adds or modifies the value of the optional
 // parameter `frameTimeTicks` in the BeginFrame CDP command.
 //
@@ -53,6 +63,14 @@ func (t *BeginFrame) SetFrameTimeTicks(v float64) *BeginFrame {
 	return t
 }
 
+// BeginFrameWithFrameTimeTicks is a functional option which sets the value of the
+// optional parameter `frameTimeTicks` in the BeginFrame CDP command, see SetFrameTimeTicks.
+func BeginFrameWithFrameTimeTicks(v float64) BeginFrameOption {
+	return func(c *BeginFrame) {
+		c.SetFrameTimeTicks(v)
+	}
+}
+
 // SetInterval adds or modifies the value of the optional
 // parameter `interval` in the BeginFrame CDP command.
 //
@@ -63,6 +81,14 @@ func (t *BeginFrame) SetInterval(v float64) *BeginFrame {
 	return t
 }
 
+// BeginFrameWithInterval is a functional option which sets the value of the
+// optional parameter `interval` in the BeginFrame CDP command, see SetInterval.
+func BeginFrameWithInterval(v float64) BeginFrameOption {
+	return func(c *BeginFrame) {
+		c.SetInterval(v)
+	}
+}
+
 // SetNoDisplayUpdates adds or modifies the value of the optional
 // parameter `noDisplayUpdates` in the BeginFrame CDP command.
 //
@@ -74,6 +100,14 @@ func (t *BeginFrame) SetNoDisplayUpdates(v bool) *BeginFrame {
 	return t
 }
 
+// BeginFrameWithNoDisplayUpdates is a functional option which sets the value of the
+// optional parameter `noDisplayUpdates` in the BeginFrame CDP command, see SetNoDisplayUpdates.
+func BeginFrameWithNoDisplayUpdates(v bool) BeginFrameOption {
+	return func(c *BeginFrame) {
+		c.SetNoDisplayUpdates(v)
+	}
+}
+
 // SetScreenshot adds or modifies the value of the optional
 // parameter `screenshot` in the BeginFrame CDP command.
 //
@@ -85,6 +119,14 @@ func (t *BeginFrame) SetScreenshot(v ScreenshotParams) *BeginFrame {
 	return t
 }
 
+// BeginFrameWithScreenshot is a functional option which sets the value of the
+// optional parameter `screenshot` in the BeginFrame CDP command, see SetScreenshot.
+func BeginFrameWithScreenshot(v ScreenshotParams) BeginFrameOption {
+	return func(c *BeginFrame) {
+		c.SetScreenshot(v)
+	}
+}
+
 // BeginFrameResult contains the browser's response
 // to calling the BeginFrame CDP command with Do().
 type BeginFrameResult struct {
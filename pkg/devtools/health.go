@@ -0,0 +1,166 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// HealthTimeout is the maximum amount of time to wait for a browser to respond
+// to health pings, see the `devtools.Healthy` function and the `devtools.KeepAlive`
+// session option.
+const HealthTimeout = 10 * time.Second
+
+// ErrConnectionBroken is wrapped by the errors of the `devtools.Healthy` function
+// when a browser connection didn't respond to a keep-alive ping in time, and
+// was closed because it's probably half-open (e.g. the remote host or a proxy
+// between them dropped it silently), or when the browser connection has ended.
+var ErrConnectionBroken = errors.New("browser connection is broken")
+
+// Health state of a browser connection, shared by all its sessions.
+type healthState struct {
+	mu  sync.Mutex
+	err error // Why the connection is considered broken, if it is.
+}
+
+func (h *healthState) fail(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.err == nil {
+		h.err = err
+	}
+}
+
+func (h *healthState) failure() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.err
+}
+
+// KeepAlive allows the caller of the `devtools.NewContext` function to ping
+// the browser periodically at the given interval, when it's connected via
+// WebSocket (see the `devtools.RemoteBrowser` session option, and the
+// `devtools.Resume` function). This keeps idle connections alive through
+// proxies and load balancers which drop them, and detects half-open
+// connections: if the browser doesn't respond within `devtools.HealthTimeout`,
+// the connection is closed, which ends the session (its context is canceled),
+// and the `devtools.Healthy` function reports why.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser, or when it communicates with the browser via pipes.
+func KeepAlive(interval time.Duration) SessionOption {
+	return func(s *Session) {
+		s.keepAlive = interval
+	}
+}
+
+// Ping the browser at the session's keep-alive interval, until
+// the given context is done, or until the browser doesn't respond.
+func keepAlive(ctx context.Context, s *Session) {
+	ticker := time.NewTicker(s.keepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		// https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-getVersion
+		// (we don't use the browser sub-package to avoid circular dependencies).
+		if err := ping(ctx, s, "Browser.getVersion"); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			err = fmt.Errorf("%w: keep-alive ping failed: %v", ErrConnectionBroken, err)
+			log.Printf("Closing browser connection: %v", err)
+			s.health.fail(err)
+			s.webSocket.Close(1001, []byte("keep-alive timeout"))
+			return
+		}
+	}
+}
+
+// Send a browser-level command (without a session ID) and wait for its
+// response, at most `devtools.HealthTimeout`, see `sendToBrowser`.
+func ping(ctx context.Context, s *Session, method string) error {
+	ctx, cancel := context.WithTimeout(ctx, HealthTimeout)
+	defer cancel()
+	return sendToBrowser(ctx, s, method, nil, nil)
+}
+
+// Send a browser-level command (without a session ID), wait for its
+// response, and parse its result into the given struct (if not nil).
+// The command is sent directly through the message queue (like in the
+// `disconnect` function), so nothing is left blocked if the browser doesn't
+// respond before the context is done. Fake sessions, which have no message
+// queue (see `devtools.NewFakeContext`), use their command handler.
+func sendToBrowser(ctx context.Context, s *Session, method string, params json.RawMessage, result interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err // The message queue may be closed already.
+	}
+	var m *Message
+	if s.msgQ == nil {
+		var err error
+		if m, err = s.handler()(ctx, method, params); err != nil {
+			return err
+		}
+	} else {
+		ch := make(chan *Message, 1)
+		am := asyncMessage{requestMsg: Message{Method: method, Params: params}, responseChan: ch}
+		select {
+		case s.msgQ <- am:
+		case <-ctx.Done():
+			return fmt.Errorf("%q command not sent: %v", method, ctx.Err())
+		}
+		select {
+		case m = <-ch:
+		case <-ctx.Done():
+			return fmt.Errorf("no response to %q command: %v", method, ctx.Err())
+		}
+		if m.err != nil {
+			return m.err
+		}
+	}
+	if m.Error != nil {
+		return errors.New(m.Error.Error())
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(m.Result, result)
+}
+
+// Healthy checks whether the browser which is associated with the given
+// context is responsive, for orchestrators which need to pull bad browsers
+// from rotation. It pings the browser with the CDP commands
+// "Browser.getVersion" and "Target.getTargets", waiting at most
+// `devtools.HealthTimeout` for each of them, and reports why it isn't
+// healthy, if it isn't. The error wraps `devtools.ErrConnectionBroken`
+// if the browser connection has ended, or if it was closed because
+// of a keep-alive failure (see the `devtools.KeepAlive` session option).
+func Healthy(ctx context.Context) (bool, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return false, errNotInitialized
+	}
+	if err := s.health.failure(); err != nil {
+		return false, err
+	}
+	select {
+	case <-s.browserDone:
+		return false, fmt.Errorf("%w: the connection has ended", ErrConnectionBroken)
+	default:
+	}
+	// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-getTargets
+	// (we don't use the target sub-package to avoid circular dependencies).
+	for _, method := range []string{"Browser.getVersion", "Target.getTargets"} {
+		if err := ping(ctx, s, method); err != nil {
+			return false, fmt.Errorf("health check failed: %v", err)
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,101 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/websocket"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHealthy(t *testing.T) {
+	tests := []struct {
+		name      string
+		failOn    string
+		want      bool
+		wantCalls []string
+	}{
+		{
+			name:      "healthy",
+			want:      true,
+			wantCalls: []string{"Browser.getVersion", "Target.getTargets"},
+		},
+		{
+			name:      "unresponsive",
+			failOn:    "Browser.getVersion",
+			wantCalls: []string{"Browser.getVersion"},
+		},
+		{
+			name:      "partially_responsive",
+			failOn:    "Target.getTargets",
+			wantCalls: []string{"Browser.getVersion", "Target.getTargets"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			ctx := NewFakeContext(context.Background(), func(_ context.Context, method string, _ json.RawMessage) (*Message, error) {
+				calls = append(calls, method)
+				if method == tt.failOn {
+					return nil, errors.New("fake error")
+				}
+				return &Message{Result: json.RawMessage(`{}`)}, nil
+			})
+			got, err := Healthy(ctx)
+			if got != tt.want || (err == nil) != tt.want {
+				t.Errorf("Healthy() = %v, %v; want %v", got, err, tt.want)
+			}
+			if diff := cmp.Diff(tt.wantCalls, calls); diff != "" {
+				t.Errorf("Healthy() commands mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestKeepAlive(t *testing.T) {
+	var mu sync.Mutex
+	pings := 0
+	ctx := NewFakeContext(context.Background(), func(_ context.Context, method string, _ json.RawMessage) (*Message, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if pings++; pings > 2 {
+			return nil, errors.New("fake error")
+		}
+		return &Message{Result: json.RawMessage(`{}`)}, nil
+	}, KeepAlive(time.Millisecond))
+	s, _ := FromContext(ctx)
+	server, client := net.Pipe()
+	s.webSocket = websocket.NewConn(client)
+	closed := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(ioutil.Discard, server)
+		closed <- err
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		keepAlive(ctx, s)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("keepAlive() didn't detect the broken connection")
+	}
+	if err := <-closed; err != nil {
+		t.Errorf("keepAlive() didn't close the connection: %v", err)
+	}
+	if pings != 3 {
+		t.Errorf("keepAlive() sent %d pings, want 3", pings)
+	}
+	if ok, err := Healthy(ctx); ok || !errors.Is(err, ErrConnectionBroken) {
+		t.Errorf("Healthy() = %v, %v; want false, %v", ok, err, ErrConnectionBroken)
+	}
+}
@@ -271,15 +271,25 @@ type GetObjectByHeapObjectID struct {
 
 // NewGetObjectByHeapObjectID constructs a new GetObjectByHeapObjectID struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetObjectByHeapObjectIDOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#method-getObjectByHeapObjectId
-func NewGetObjectByHeapObjectID(objectID string) *GetObjectByHeapObjectID {
-	return &GetObjectByHeapObjectID{
+func NewGetObjectByHeapObjectID(objectID string, opts ...GetObjectByHeapObjectIDOption) *GetObjectByHeapObjectID {
+	c := &GetObjectByHeapObjectID{
 		ObjectID: objectID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetObjectByHeapObjectIDOption is a functional option for the optional
+// parameters of the GetObjectByHeapObjectID CDP command, see the
+// NewGetObjectByHeapObjectID constructor. Options are applied in order.
+type GetObjectByHeapObjectIDOption = func(*GetObjectByHeapObjectID)
+
 // SetObjectGroup adds or modifies the value of the optional
 // parameter `objectGroup` in the GetObjectByHeapObjectID CDP command.
 //
@@ -289,6 +299,14 @@ func (t *GetObjectByHeapObjectID) SetObjectGroup(v string) *GetObjectByHeapObjec
 	return t
 }
 
+// GetObjectByHeapObjectIDWithObjectGroup is a functional option which sets the value of the
+// optional parameter `objectGroup` in the GetObjectByHeapObjectID CDP command, see SetObjectGroup.
+func GetObjectByHeapObjectIDWithObjectGroup(v string) GetObjectByHeapObjectIDOption {
+	return func(c *GetObjectByHeapObjectID) {
+		c.SetObjectGroup(v)
+	}
+}
+
 // GetObjectByHeapObjectIDResult contains the browser's response
 // to calling the GetObjectByHeapObjectID CDP command with Do().
 type GetObjectByHeapObjectIDResult struct {
@@ -395,28 +413,46 @@ func (t *GetSamplingProfile) ParseResponse(m *devtools.Message) (*GetSamplingPro
 type StartSampling struct {
 	// Average sample interval in bytes. Poisson distribution is used for the intervals. The
 	// default value is 32768 bytes.
-	SamplingInterval float64 `json:"samplingInterval,omitempty"`
+	SamplingInterval *float64 `json:"samplingInterval,omitempty"`
 }
 
 // NewStartSampling constructs a new StartSampling struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `StartSamplingOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#method-startSampling
-func NewStartSampling() *StartSampling {
-	return &StartSampling{}
+func NewStartSampling(opts ...StartSamplingOption) *StartSampling {
+	c := &StartSampling{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// StartSamplingOption is a functional option for the optional
+// parameters of the StartSampling CDP command, see the
+// NewStartSampling constructor. Options are applied in order.
+type StartSamplingOption = func(*StartSampling)
+
 // SetSamplingInterval adds or modifies the value of the optional
 // parameter `samplingInterval` in the StartSampling CDP command.
 //
 // Average sample interval in bytes. Poisson distribution is used for the intervals. The
 // default value is 32768 bytes.
 func (t *StartSampling) SetSamplingInterval(v float64) *StartSampling {
-	t.SamplingInterval = v
+	t.SamplingInterval = &v
 	return t
 }
 
+// StartSamplingWithSamplingInterval is a functional option which sets the value of the
+// optional parameter `samplingInterval` in the StartSampling CDP command, see SetSamplingInterval.
+func StartSamplingWithSamplingInterval(v float64) StartSamplingOption {
+	return func(c *StartSampling) {
+		c.SetSamplingInterval(v)
+	}
+}
+
 // Do sends the StartSampling CDP command to a browser,
 // and returns the browser's response.
 func (t *StartSampling) Do(ctx context.Context) error {
@@ -462,13 +498,23 @@ type StartTrackingHeapObjects struct {
 
 // NewStartTrackingHeapObjects constructs a new StartTrackingHeapObjects struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `StartTrackingHeapObjectsOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#method-startTrackingHeapObjects
-func NewStartTrackingHeapObjects() *StartTrackingHeapObjects {
-	return &StartTrackingHeapObjects{}
+func NewStartTrackingHeapObjects(opts ...StartTrackingHeapObjectsOption) *StartTrackingHeapObjects {
+	c := &StartTrackingHeapObjects{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// StartTrackingHeapObjectsOption is a functional option for the optional
+// parameters of the StartTrackingHeapObjects CDP command, see the
+// NewStartTrackingHeapObjects constructor. Options are applied in order.
+type StartTrackingHeapObjectsOption = func(*StartTrackingHeapObjects)
+
 // SetTrackAllocations adds or modifies the value of the optional
 // parameter `trackAllocations` in the StartTrackingHeapObjects CDP command.
 func (t *StartTrackingHeapObjects) SetTrackAllocations(v bool) *StartTrackingHeapObjects {
@@ -476,6 +522,14 @@ func (t *StartTrackingHeapObjects) SetTrackAllocations(v bool) *StartTrackingHea
 	return t
 }
 
+// StartTrackingHeapObjectsWithTrackAllocations is a functional option which sets the value of the
+// optional parameter `trackAllocations` in the StartTrackingHeapObjects CDP command, see SetTrackAllocations.
+func StartTrackingHeapObjectsWithTrackAllocations(v bool) StartTrackingHeapObjectsOption {
+	return func(c *StartTrackingHeapObjects) {
+		c.SetTrackAllocations(v)
+	}
+}
+
 // Do sends the StartTrackingHeapObjects CDP command to a browser,
 // and returns the browser's response.
 func (t *StartTrackingHeapObjects) Do(ctx context.Context) error {
@@ -579,13 +633,23 @@ type StopTrackingHeapObjects struct {
 
 // NewStopTrackingHeapObjects constructs a new StopTrackingHeapObjects struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `StopTrackingHeapObjectsOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#method-stopTrackingHeapObjects
-func NewStopTrackingHeapObjects() *StopTrackingHeapObjects {
-	return &StopTrackingHeapObjects{}
+func NewStopTrackingHeapObjects(opts ...StopTrackingHeapObjectsOption) *StopTrackingHeapObjects {
+	c := &StopTrackingHeapObjects{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// StopTrackingHeapObjectsOption is a functional option for the optional
+// parameters of the StopTrackingHeapObjects CDP command, see the
+// NewStopTrackingHeapObjects constructor. Options are applied in order.
+type StopTrackingHeapObjectsOption = func(*StopTrackingHeapObjects)
+
 // SetReportProgress adds or modifies the value of the optional
 // parameter `reportProgress` in the StopTrackingHeapObjects CDP command.
 //
@@ -596,6 +660,14 @@ func (t *StopTrackingHeapObjects) SetReportProgress(v bool) *StopTrackingHeapObj
 	return t
 }
 
+// StopTrackingHeapObjectsWithReportProgress is a functional option which sets the value of the
+// optional parameter `reportProgress` in the StopTrackingHeapObjects CDP command, see SetReportProgress.
+func StopTrackingHeapObjectsWithReportProgress(v bool) StopTrackingHeapObjectsOption {
+	return func(c *StopTrackingHeapObjects) {
+		c.SetReportProgress(v)
+	}
+}
+
 // SetTreatGlobalObjectsAsRoots adds or modifies the value of the optional
 // parameter `treatGlobalObjectsAsRoots` in the StopTrackingHeapObjects CDP command.
 func (t *StopTrackingHeapObjects) SetTreatGlobalObjectsAsRoots(v bool) *StopTrackingHeapObjects {
@@ -603,6 +675,14 @@ func (t *StopTrackingHeapObjects) SetTreatGlobalObjectsAsRoots(v bool) *StopTrac
 	return t
 }
 
+// StopTrackingHeapObjectsWithTreatGlobalObjectsAsRoots is a functional option which sets the value of the
+// optional parameter `treatGlobalObjectsAsRoots` in the StopTrackingHeapObjects CDP command, see SetTreatGlobalObjectsAsRoots.
+func StopTrackingHeapObjectsWithTreatGlobalObjectsAsRoots(v bool) StopTrackingHeapObjectsOption {
+	return func(c *StopTrackingHeapObjects) {
+		c.SetTreatGlobalObjectsAsRoots(v)
+	}
+}
+
 // SetCaptureNumericValue adds or modifies the value of the optional
 // parameter `captureNumericValue` in the StopTrackingHeapObjects CDP command.
 //
@@ -612,6 +692,14 @@ func (t *StopTrackingHeapObjects) SetCaptureNumericValue(v bool) *StopTrackingHe
 	return t
 }
 
+// StopTrackingHeapObjectsWithCaptureNumericValue is a functional option which sets the value of the
+// optional parameter `captureNumericValue` in the StopTrackingHeapObjects CDP command, see SetCaptureNumericValue.
+func StopTrackingHeapObjectsWithCaptureNumericValue(v bool) StopTrackingHeapObjectsOption {
+	return func(c *StopTrackingHeapObjects) {
+		c.SetCaptureNumericValue(v)
+	}
+}
+
 // Do sends the StopTrackingHeapObjects CDP command to a browser,
 // and returns the browser's response.
 func (t *StopTrackingHeapObjects) Do(ctx context.Context) error {
@@ -662,13 +750,23 @@ type TakeHeapSnapshot struct {
 
 // NewTakeHeapSnapshot constructs a new TakeHeapSnapshot struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `TakeHeapSnapshotOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/HeapProfiler/#method-takeHeapSnapshot
-func NewTakeHeapSnapshot() *TakeHeapSnapshot {
-	return &TakeHeapSnapshot{}
+func NewTakeHeapSnapshot(opts ...TakeHeapSnapshotOption) *TakeHeapSnapshot {
+	c := &TakeHeapSnapshot{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// TakeHeapSnapshotOption is a functional option for the optional
+// parameters of the TakeHeapSnapshot CDP command, see the
+// NewTakeHeapSnapshot constructor. Options are applied in order.
+type TakeHeapSnapshotOption = func(*TakeHeapSnapshot)
+
 // SetReportProgress adds or modifies the value of the optional
 // parameter `reportProgress` in the TakeHeapSnapshot CDP command.
 //
@@ -678,6 +776,14 @@ func (t *TakeHeapSnapshot) SetReportProgress(v bool) *TakeHeapSnapshot {
 	return t
 }
 
+// TakeHeapSnapshotWithReportProgress is a functional option which sets the value of the
+// optional parameter `reportProgress` in the TakeHeapSnapshot CDP command, see SetReportProgress.
+func TakeHeapSnapshotWithReportProgress(v bool) TakeHeapSnapshotOption {
+	return func(c *TakeHeapSnapshot) {
+		c.SetReportProgress(v)
+	}
+}
+
 // SetTreatGlobalObjectsAsRoots adds or modifies the value of the optional
 // parameter `treatGlobalObjectsAsRoots` in the TakeHeapSnapshot CDP command.
 //
@@ -687,6 +793,14 @@ func (t *TakeHeapSnapshot) SetTreatGlobalObjectsAsRoots(v bool) *TakeHeapSnapsho
 	return t
 }
 
+// TakeHeapSnapshotWithTreatGlobalObjectsAsRoots is a functional option which sets the value of the
+// optional parameter `treatGlobalObjectsAsRoots` in the TakeHeapSnapshot CDP command, see SetTreatGlobalObjectsAsRoots.
+func TakeHeapSnapshotWithTreatGlobalObjectsAsRoots(v bool) TakeHeapSnapshotOption {
+	return func(c *TakeHeapSnapshot) {
+		c.SetTreatGlobalObjectsAsRoots(v)
+	}
+}
+
 // SetCaptureNumericValue adds or modifies the value of the optional
 // parameter `captureNumericValue` in the TakeHeapSnapshot CDP command.
 //
@@ -696,6 +810,14 @@ func (t *TakeHeapSnapshot) SetCaptureNumericValue(v bool) *TakeHeapSnapshot {
 	return t
 }
 
+// TakeHeapSnapshotWithCaptureNumericValue is a functional option which sets the value of the
+// optional parameter `captureNumericValue` in the TakeHeapSnapshot CDP command, see SetCaptureNumericValue.
+func TakeHeapSnapshotWithCaptureNumericValue(v bool) TakeHeapSnapshotOption {
+	return func(c *TakeHeapSnapshot) {
+		c.SetCaptureNumericValue(v)
+	}
+}
+
 // Do sends the TakeHeapSnapshot CDP command to a browser,
 // and returns the browser's response.
 func (t *TakeHeapSnapshot) Do(ctx context.Context) error {
@@ -0,0 +1,183 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// How long `devtools.ShowInteractions` flashes each interaction point.
+const interactionFlashDuration = 500 * time.Millisecond
+
+// Partial copy of `overlay.RGBA`.
+type rgba struct {
+	R int64   `json:"r"`
+	G int64   `json:"g"`
+	B int64   `json:"b"`
+	A float64 `json:"a,omitempty"`
+}
+
+// Partial copy of `overlay.HighlightConfig`.
+type highlightConfig struct {
+	ShowInfo     bool  `json:"showInfo,omitempty"`
+	ContentColor *rgba `json:"contentColor,omitempty"`
+	PaddingColor *rgba `json:"paddingColor,omitempty"`
+	BorderColor  *rgba `json:"borderColor,omitempty"`
+	MarginColor  *rgba `json:"marginColor,omitempty"`
+}
+
+// Same colors as the element highlighting in Chrome DevTools.
+var defaultHighlightConfig = &highlightConfig{
+	ShowInfo:     true,
+	ContentColor: &rgba{111, 168, 220, 0.66},
+	PaddingColor: &rgba{147, 196, 125, 0.55},
+	BorderColor:  &rgba{255, 229, 153, 0.66},
+	MarginColor:  &rgba{246, 178, 107, 0.66},
+}
+
+// ShowInteractions allows the caller of the `devtools.NewContext` function
+// to flash a marker on the page at the location of every automated mouse
+// press (i.e. `Input.dispatchMouseEvent` CDP commands of type
+// "mousePressed"), which is helpful when debugging non-headless runs.
+//
+// Unlike most other session options, this one is inherited by new tabs
+// (i.e. descendant contexts).
+func ShowInteractions() SessionOption {
+	return func(s *Session) {
+		s.showInteractions = true
+	}
+}
+
+// Highlight highlights the first element which matches the given CSS selector,
+// in the main frame of the page associated with the given context, for the
+// given duration. It returns early with the context's error if the context
+// is done first.
+func Highlight(ctx context.Context, selector string, d time.Duration) error {
+	nodeID, err := querySelector(ctx, selector)
+	if err != nil {
+		return err
+	}
+	release, err := enableOverlay(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	// https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#method-highlightNode
+	// (we don't use the Overlay sub-package to avoid circular dependencies).
+	params := &struct {
+		HighlightConfig *highlightConfig `json:"highlightConfig"`
+		NodeID          int64            `json:"nodeId"`
+	}{defaultHighlightConfig, nodeID}
+	if err := sendAndParse(ctx, "Overlay.highlightNode", params, nil); err != nil {
+		return fmt.Errorf(`"Overlay.highlightNode" command error: %v`, err)
+	}
+	return hideHighlightAfter(ctx, d)
+}
+
+// Return the node ID of the first element which matches the given CSS
+// selector. We don't use the DOM sub-package to avoid circular dependencies.
+func querySelector(ctx context.Context, selector string) (int64, error) {
+	// https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-getDocument
+	doc := &struct {
+		Root struct {
+			NodeID int64 `json:"nodeId"`
+		} `json:"root"`
+	}{}
+	if err := sendAndParse(ctx, "DOM.getDocument", nil, doc); err != nil {
+		return 0, fmt.Errorf(`"DOM.getDocument" command error: %v`, err)
+	}
+	// https://chromedevtools.github.io/devtools-protocol/tot/DOM/#method-querySelector
+	params := &struct {
+		NodeID   int64  `json:"nodeId"`
+		Selector string `json:"selector"`
+	}{doc.Root.NodeID, selector}
+	result := &struct {
+		NodeID int64 `json:"nodeId"`
+	}{}
+	if err := sendAndParse(ctx, "DOM.querySelector", params, result); err != nil {
+		return 0, fmt.Errorf(`"DOM.querySelector" command error: %v`, err)
+	}
+	if result.NodeID == 0 {
+		return 0, fmt.Errorf("no element matches the selector %q", selector)
+	}
+	return result.NodeID, nil
+}
+
+// Enable the Overlay domain, which depends on the DOM domain, and return
+// a function to release both of them (see `devtools.EnableDomain`).
+func enableOverlay(ctx context.Context) (func(), error) {
+	releaseDOM, err := EnableDomain(ctx, "DOM")
+	if err != nil {
+		return nil, err
+	}
+	releaseOverlay, err := EnableDomain(ctx, "Overlay")
+	if err != nil {
+		releaseDOM()
+		return nil, err
+	}
+	return func() {
+		releaseOverlay()
+		releaseDOM()
+	}, nil
+}
+
+func hideHighlightAfter(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	// https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#method-hideHighlight
+	if err := sendAndParse(ctx, "Overlay.hideHighlight", nil, nil); err != nil {
+		return fmt.Errorf(`"Overlay.hideHighlight" command error: %v`, err)
+	}
+	return nil
+}
+
+// Flash the location of an automated mouse press, if the given
+// command is one. See the `devtools.ShowInteractions` session option.
+func showInteraction(ctx context.Context, method string, params json.RawMessage) {
+	if method != "Input.dispatchMouseEvent" {
+		return
+	}
+	e := &struct {
+		Type string  `json:"type"`
+		X    float64 `json:"x"`
+		Y    float64 `json:"y"`
+	}{}
+	if err := json.Unmarshal(params, e); err != nil || e.Type != "mousePressed" {
+		return
+	}
+	// Don't delay the mouse event.
+	go func() {
+		if err := flashPoint(ctx, e.X, e.Y); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to show interaction at (%v, %v): %v", e.X, e.Y, err)
+		}
+	}()
+}
+
+func flashPoint(ctx context.Context, x, y float64) error {
+	release, err := enableOverlay(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	const size = 20
+	// https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#method-highlightRect
+	params := &struct {
+		X            int64 `json:"x"`
+		Y            int64 `json:"y"`
+		Width        int64 `json:"width"`
+		Height       int64 `json:"height"`
+		Color        *rgba `json:"color"`
+		OutlineColor *rgba `json:"outlineColor"`
+	}{int64(x) - size/2, int64(y) - size/2, size, size, &rgba{255, 0, 0, 0.5}, &rgba{255, 0, 0, 1}}
+	if err := sendAndParse(ctx, "Overlay.highlightRect", params, nil); err != nil {
+		return fmt.Errorf(`"Overlay.highlightRect" command error: %v`, err)
+	}
+	return hideHighlightAfter(ctx, interactionFlashDuration)
+}
@@ -0,0 +1,162 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodeID  int64
+		timeout time.Duration // Of the context, if any.
+		want    []string
+		wantErr error
+	}{
+		{
+			name:   "highlight",
+			nodeID: 5,
+			want: []string{
+				"DOM.getDocument", "DOM.querySelector", "DOM.enable", "Overlay.enable",
+				`Overlay.highlightNode {"highlightConfig":{"showInfo":true,"contentColor":{"r":111,"g":168,"b":220,"a":0.66},` +
+					`"paddingColor":{"r":147,"g":196,"b":125,"a":0.55},"borderColor":{"r":255,"g":229,"b":153,"a":0.66},` +
+					`"marginColor":{"r":246,"g":178,"b":107,"a":0.66}},"nodeId":5}`,
+				"Overlay.hideHighlight", "Overlay.disable", "DOM.disable",
+			},
+		},
+		{
+			name:    "no_match",
+			want:    []string{"DOM.getDocument", "DOM.querySelector"},
+			wantErr: errors.New(`no element matches the selector "#a"`),
+		},
+		{
+			name:    "context_done",
+			nodeID:  5,
+			timeout: 50 * time.Millisecond,
+			want: []string{
+				"DOM.getDocument", "DOM.querySelector", "DOM.enable", "Overlay.enable",
+				"Overlay.highlightNode", "Overlay.disable", "DOM.disable",
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			d := time.Millisecond
+			if tt.timeout > 0 {
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+				d = time.Hour
+			}
+			var got []string
+			ctx = NewFakeContext(ctx, func(_ context.Context, method string, params json.RawMessage) (*Message, error) {
+				switch method {
+				case "DOM.getDocument":
+					got = append(got, method)
+					return &Message{Result: json.RawMessage(`{"root":{"nodeId":1}}`)}, nil
+				case "DOM.querySelector":
+					got = append(got, method)
+					if string(params) != `{"nodeId":1,"selector":"#a"}` {
+						return nil, errors.New("unexpected params: " + string(params))
+					}
+					r, _ := json.Marshal(map[string]int64{"nodeId": tt.nodeID})
+					return &Message{Result: r}, nil
+				case "Overlay.highlightNode":
+					if tt.timeout == 0 {
+						method += " " + string(params)
+					}
+				}
+				got = append(got, method)
+				return &Message{Result: json.RawMessage(`{}`)}, nil
+			})
+
+			err := Highlight(ctx, "#a", d)
+			if (err != nil) != (tt.wantErr != nil) || (err != nil && err.Error() != tt.wantErr.Error()) {
+				t.Errorf("Highlight() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Highlight() commands mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestShowInteractions(t *testing.T) {
+	// A session whose commands are sent through the transport (the `send`
+	// function, which calls `showInteraction`), to a fake browser which
+	// reports their methods and params, and responds with empty results.
+	s := &Session{
+		msgQ:      make(chan asyncMessage),
+		SessionID: newSafeString(),
+		domains:   newDomainRegistry(),
+	}
+	ShowInteractions()(s)
+	sent := make(chan string, 16)
+	go func() {
+		for m := range s.msgQ {
+			sent <- m.requestMsg.Method + " " + string(m.requestMsg.Params)
+			m.responseChan <- &Message{Result: json.RawMessage(`{}`)}
+		}
+	}()
+	defer close(s.msgQ)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = context.WithValue(ctx, sessionKey{}, s)
+
+	receive := func() string {
+		select {
+		case m := <-sent:
+			return m
+		case <-time.After(5 * time.Second):
+			t.Fatal("command not sent")
+			return ""
+		}
+	}
+
+	// Mouse events other than presses aren't shown.
+	moved := `{"type":"mouseMoved","x":100,"y":50}`
+	if _, err := SendAndWait(ctx, "Input.dispatchMouseEvent", json.RawMessage(moved)); err != nil {
+		t.Fatalf("SendAndWait(); got error: %v", err)
+	}
+	if got, want := receive(), "Input.dispatchMouseEvent "+moved; got != want {
+		t.Errorf("sent command = %s, want %s", got, want)
+	}
+
+	// Mouse presses are shown, without delaying them.
+	pressed := `{"type":"mousePressed","x":100.5,"y":50}`
+	if _, err := SendAndWait(ctx, "Input.dispatchMouseEvent", json.RawMessage(pressed)); err != nil {
+		t.Fatalf("SendAndWait(); got error: %v", err)
+	}
+	// The mouse press and the overlay commands are sent concurrently.
+	var input, overlay []string
+	for i := 0; i < 7; i++ {
+		if m := receive(); strings.HasPrefix(m, "Input.") {
+			input = append(input, m)
+		} else {
+			overlay = append(overlay, m)
+		}
+	}
+	if diff := cmp.Diff([]string{"Input.dispatchMouseEvent " + pressed}, input); diff != "" {
+		t.Errorf("sent input commands mismatch (-want +got):\n%s", diff)
+	}
+	want := []string{
+		"DOM.enable ",
+		"Overlay.enable ",
+		`Overlay.highlightRect {"x":90,"y":40,"width":20,"height":20,` +
+			`"color":{"r":255,"g":0,"b":0,"a":0.5},"outlineColor":{"r":255,"g":0,"b":0,"a":1}}`,
+		"Overlay.hideHighlight ",
+		"Overlay.disable ",
+		"DOM.disable ",
+	}
+	if diff := cmp.Diff(want, overlay); diff != "" {
+		t.Errorf("sent overlay commands mismatch (-want +got):\n%s", diff)
+	}
+}
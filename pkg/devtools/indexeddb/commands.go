@@ -307,11 +307,12 @@ type RequestData struct {
 
 // NewRequestData constructs a new RequestData struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `RequestDataOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/IndexedDB/#method-requestData
-func NewRequestData(securityOrigin string, databaseName string, objectStoreName string, indexName string, skipCount int64, pageSize int64) *RequestData {
-	return &RequestData{
+func NewRequestData(securityOrigin string, databaseName string, objectStoreName string, indexName string, skipCount int64, pageSize int64, opts ...RequestDataOption) *RequestData {
+	c := &RequestData{
 		SecurityOrigin:  securityOrigin,
 		DatabaseName:    databaseName,
 		ObjectStoreName: objectStoreName,
@@ -319,8 +320,17 @@ func NewRequestData(securityOrigin string, databaseName string, objectStoreName
 		SkipCount:       skipCount,
 		PageSize:        pageSize,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// RequestDataOption is a functional option for the optional
+// parameters of the RequestData CDP command, see the
+// NewRequestData constructor. Options are applied in order.
+type RequestDataOption = func(*RequestData)
+
 // SetKeyRange adds or modifies the value of the optional
 // parameter `keyRange` in the RequestData CDP command.
 //
@@ -330,6 +340,14 @@ func (t *RequestData) SetKeyRange(v KeyRange) *RequestData {
 	return t
 }
 
+// RequestDataWithKeyRange is a functional option which sets the value of the
+// optional parameter `keyRange` in the RequestData CDP command, see SetKeyRange.
+func RequestDataWithKeyRange(v KeyRange) RequestDataOption {
+	return func(c *RequestData) {
+		c.SetKeyRange(v)
+	}
+}
+
 // RequestDataResult contains the browser's response
 // to calling the RequestData CDP command with Do().
 type RequestDataResult struct {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sync/atomic"
 
 	"github.com/daabr/chrome-vision/pkg/devtools"
 )
@@ -32,20 +33,30 @@ type DispatchDragEvent struct {
 
 // NewDispatchDragEvent constructs a new DispatchDragEvent struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `DispatchDragEventOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Input/#method-dispatchDragEvent
 //
 // This CDP method is experimental.
-func NewDispatchDragEvent(t string, x float64, y float64, data DragData) *DispatchDragEvent {
-	return &DispatchDragEvent{
+func NewDispatchDragEvent(t string, x float64, y float64, data DragData, opts ...DispatchDragEventOption) *DispatchDragEvent {
+	c := &DispatchDragEvent{
 		Type: t,
 		X:    x,
 		Y:    y,
 		Data: data,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// DispatchDragEventOption is a functional option for the optional
+// parameters of the DispatchDragEvent CDP command, see the
+// NewDispatchDragEvent constructor. Options are applied in order.
+type DispatchDragEventOption = func(*DispatchDragEvent)
+
 // SetModifiers adds or modifies the value of the optional
 // parameter `modifiers` in the DispatchDragEvent CDP command.
 //
@@ -56,6 +67,14 @@ func (t *DispatchDragEvent) SetModifiers(v int64) *DispatchDragEvent {
 	return t
 }
 
+// DispatchDragEventWithModifiers is a functional option which sets the value of the
+// optional parameter `modifiers` in the DispatchDragEvent CDP command, see SetModifiers.
+func DispatchDragEventWithModifiers(v int64) DispatchDragEventOption {
+	return func(c *DispatchDragEvent) {
+		c.SetModifiers(v)
+	}
+}
+
 // Do sends the DispatchDragEvent CDP command to a browser,
 // and returns the browser's response.
 func (t *DispatchDragEvent) Do(ctx context.Context) error {
@@ -104,7 +123,7 @@ type DispatchKeyEvent struct {
 	// (default: 0).
 	Modifiers int64 `json:"modifiers,omitempty"`
 	// Time at which the event occurred.
-	Timestamp float64 `json:"timestamp,omitempty"`
+	Timestamp devtools.TimeSinceEpoch `json:"timestamp,omitempty"`
 	// Text as generated by processing a virtual key code with a keyboard layout. Not needed for
 	// for `keyUp` and `rawKeyDown` events (default: "")
 	Text string `json:"text,omitempty"`
@@ -141,15 +160,25 @@ type DispatchKeyEvent struct {
 
 // NewDispatchKeyEvent constructs a new DispatchKeyEvent struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `DispatchKeyEventOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Input/#method-dispatchKeyEvent
-func NewDispatchKeyEvent(t string) *DispatchKeyEvent {
-	return &DispatchKeyEvent{
+func NewDispatchKeyEvent(t string, opts ...DispatchKeyEventOption) *DispatchKeyEvent {
+	c := &DispatchKeyEvent{
 		Type: t,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// DispatchKeyEventOption is a functional option for the optional
+// parameters of the DispatchKeyEvent CDP command, see the
+// NewDispatchKeyEvent constructor. Options are applied in order.
+type DispatchKeyEventOption = func(*DispatchKeyEvent)
+
 // SetModifiers adds or modifies the value of the optional
 // parameter `modifiers` in the DispatchKeyEvent CDP command.
 //
@@ -160,15 +189,31 @@ func (t *DispatchKeyEvent) SetModifiers(v int64) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithModifiers is a functional option which sets the value of the
+// optional parameter `modifiers` in the DispatchKeyEvent CDP command, see SetModifiers.
+func DispatchKeyEventWithModifiers(v int64) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetModifiers(v)
+	}
+}
+
 // SetTimestamp adds or modifies the value of the optional
 // parameter `timestamp` in the DispatchKeyEvent CDP command.
 //
 // Time at which the event occurred.
-func (t *DispatchKeyEvent) SetTimestamp(v float64) *DispatchKeyEvent {
+func (t *DispatchKeyEvent) SetTimestamp(v devtools.TimeSinceEpoch) *DispatchKeyEvent {
 	t.Timestamp = v
 	return t
 }
 
+// DispatchKeyEventWithTimestamp is a functional option which sets the value of the
+// optional parameter `timestamp` in the DispatchKeyEvent CDP command, see SetTimestamp.
+func DispatchKeyEventWithTimestamp(v devtools.TimeSinceEpoch) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetTimestamp(v)
+	}
+}
+
 // SetText adds or modifies the value of the optional
 // parameter `text` in the DispatchKeyEvent CDP command.
 //
@@ -179,6 +224,14 @@ func (t *DispatchKeyEvent) SetText(v string) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithText is a functional option which sets the value of the
+// optional parameter `text` in the DispatchKeyEvent CDP command, see SetText.
+func DispatchKeyEventWithText(v string) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetText(v)
+	}
+}
+
 // SetUnmodifiedText adds or modifies the value of the optional
 // parameter `unmodifiedText` in the DispatchKeyEvent CDP command.
 //
@@ -189,6 +242,14 @@ func (t *DispatchKeyEvent) SetUnmodifiedText(v string) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithUnmodifiedText is a functional option which sets the value of the
+// optional parameter `unmodifiedText` in the DispatchKeyEvent CDP command, see SetUnmodifiedText.
+func DispatchKeyEventWithUnmodifiedText(v string) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetUnmodifiedText(v)
+	}
+}
+
 // SetKeyIdentifier adds or modifies the value of the optional
 // parameter `keyIdentifier` in the DispatchKeyEvent CDP command.
 //
@@ -198,6 +259,14 @@ func (t *DispatchKeyEvent) SetKeyIdentifier(v string) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithKeyIdentifier is a functional option which sets the value of the
+// optional parameter `keyIdentifier` in the DispatchKeyEvent CDP command, see SetKeyIdentifier.
+func DispatchKeyEventWithKeyIdentifier(v string) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetKeyIdentifier(v)
+	}
+}
+
 // SetCode adds or modifies the value of the optional
 // parameter `code` in the DispatchKeyEvent CDP command.
 //
@@ -207,6 +276,14 @@ func (t *DispatchKeyEvent) SetCode(v string) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithCode is a functional option which sets the value of the
+// optional parameter `code` in the DispatchKeyEvent CDP command, see SetCode.
+func DispatchKeyEventWithCode(v string) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetCode(v)
+	}
+}
+
 // SetKey adds or modifies the value of the optional
 // parameter `key` in the DispatchKeyEvent CDP command.
 //
@@ -217,6 +294,14 @@ func (t *DispatchKeyEvent) SetKey(v string) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithKey is a functional option which sets the value of the
+// optional parameter `key` in the DispatchKeyEvent CDP command, see SetKey.
+func DispatchKeyEventWithKey(v string) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetKey(v)
+	}
+}
+
 // SetWindowsVirtualKeyCode adds or modifies the value of the optional
 // parameter `windowsVirtualKeyCode` in the DispatchKeyEvent CDP command.
 //
@@ -226,6 +311,14 @@ func (t *DispatchKeyEvent) SetWindowsVirtualKeyCode(v int64) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithWindowsVirtualKeyCode is a functional option which sets the value of the
+// optional parameter `windowsVirtualKeyCode` in the DispatchKeyEvent CDP command, see SetWindowsVirtualKeyCode.
+func DispatchKeyEventWithWindowsVirtualKeyCode(v int64) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetWindowsVirtualKeyCode(v)
+	}
+}
+
 // SetNativeVirtualKeyCode adds or modifies the value of the optional
 // parameter `nativeVirtualKeyCode` in the DispatchKeyEvent CDP command.
 //
@@ -235,6 +328,14 @@ func (t *DispatchKeyEvent) SetNativeVirtualKeyCode(v int64) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithNativeVirtualKeyCode is a functional option which sets the value of the
+// optional parameter `nativeVirtualKeyCode` in the DispatchKeyEvent CDP command, see SetNativeVirtualKeyCode.
+func DispatchKeyEventWithNativeVirtualKeyCode(v int64) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetNativeVirtualKeyCode(v)
+	}
+}
+
 // SetAutoRepeat adds or modifies the value of the optional
 // parameter `autoRepeat` in the DispatchKeyEvent CDP command.
 //
@@ -244,6 +345,14 @@ func (t *DispatchKeyEvent) SetAutoRepeat(v bool) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithAutoRepeat is a functional option which sets the value of the
+// optional parameter `autoRepeat` in the DispatchKeyEvent CDP command, see SetAutoRepeat.
+func DispatchKeyEventWithAutoRepeat(v bool) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetAutoRepeat(v)
+	}
+}
+
 // SetIsKeypad adds or modifies the value of the optional
 // parameter `isKeypad` in the DispatchKeyEvent CDP command.
 //
@@ -253,6 +362,14 @@ func (t *DispatchKeyEvent) SetIsKeypad(v bool) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithIsKeypad is a functional option which sets the value of the
+// optional parameter `isKeypad` in the DispatchKeyEvent CDP command, see SetIsKeypad.
+func DispatchKeyEventWithIsKeypad(v bool) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetIsKeypad(v)
+	}
+}
+
 // SetIsSystemKey adds or modifies the value of the optional
 // parameter `isSystemKey` in the DispatchKeyEvent CDP command.
 //
@@ -262,6 +379,14 @@ func (t *DispatchKeyEvent) SetIsSystemKey(v bool) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithIsSystemKey is a functional option which sets the value of the
+// optional parameter `isSystemKey` in the DispatchKeyEvent CDP command, see SetIsSystemKey.
+func DispatchKeyEventWithIsSystemKey(v bool) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetIsSystemKey(v)
+	}
+}
+
 // SetLocation adds or modifies the value of the optional
 // parameter `location` in the DispatchKeyEvent CDP command.
 //
@@ -272,6 +397,14 @@ func (t *DispatchKeyEvent) SetLocation(v int64) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithLocation is a functional option which sets the value of the
+// optional parameter `location` in the DispatchKeyEvent CDP command, see SetLocation.
+func DispatchKeyEventWithLocation(v int64) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetLocation(v)
+	}
+}
+
 // SetCommands adds or modifies the value of the optional
 // parameter `commands` in the DispatchKeyEvent CDP command.
 //
@@ -285,6 +418,14 @@ func (t *DispatchKeyEvent) SetCommands(v []string) *DispatchKeyEvent {
 	return t
 }
 
+// DispatchKeyEventWithCommands is a functional option which sets the value of the
+// optional parameter `commands` in the DispatchKeyEvent CDP command, see SetCommands.
+func DispatchKeyEventWithCommands(v []string) DispatchKeyEventOption {
+	return func(c *DispatchKeyEvent) {
+		c.SetCommands(v)
+	}
+}
+
 // Do sends the DispatchKeyEvent CDP command to a browser,
 // and returns the browser's response.
 func (t *DispatchKeyEvent) Do(ctx context.Context) error {
@@ -407,19 +548,29 @@ type ImeSetComposition struct {
 
 // NewImeSetComposition constructs a new ImeSetComposition struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ImeSetCompositionOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Input/#method-imeSetComposition
 //
 // This CDP method is experimental.
-func NewImeSetComposition(text string, selectionStart int64, selectionEnd int64) *ImeSetComposition {
-	return &ImeSetComposition{
+func NewImeSetComposition(text string, selectionStart int64, selectionEnd int64, opts ...ImeSetCompositionOption) *ImeSetComposition {
+	c := &ImeSetComposition{
 		Text:           text,
 		SelectionStart: selectionStart,
 		SelectionEnd:   selectionEnd,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ImeSetCompositionOption is a functional option for the optional
+// parameters of the ImeSetComposition CDP command, see the
+// NewImeSetComposition constructor. Options are applied in order.
+type ImeSetCompositionOption = func(*ImeSetComposition)
+
 // SetReplacementStart adds or modifies the value of the optional
 // parameter `replacementStart` in the ImeSetComposition CDP command.
 //
@@ -429,6 +580,14 @@ func (t *ImeSetComposition) SetReplacementStart(v int64) *ImeSetComposition {
 	return t
 }
 
+// ImeSetCompositionWithReplacementStart is a functional option which sets the value of the
+// optional parameter `replacementStart` in the ImeSetComposition CDP command, see SetReplacementStart.
+func ImeSetCompositionWithReplacementStart(v int64) ImeSetCompositionOption {
+	return func(c *ImeSetComposition) {
+		c.SetReplacementStart(v)
+	}
+}
+
 // SetReplacementEnd adds or modifies the value of the optional
 // parameter `replacementEnd` in the ImeSetComposition CDP command.
 //
@@ -438,6 +597,14 @@ func (t *ImeSetComposition) SetReplacementEnd(v int64) *ImeSetComposition {
 	return t
 }
 
+// ImeSetCompositionWithReplacementEnd is a functional option which sets the value of the
+// optional parameter `replacementEnd` in the ImeSetComposition CDP command, see SetReplacementEnd.
+func ImeSetCompositionWithReplacementEnd(v int64) ImeSetCompositionOption {
+	return func(c *ImeSetComposition) {
+		c.SetReplacementEnd(v)
+	}
+}
+
 // Do sends the ImeSetComposition CDP command to a browser,
 // and returns the browser's response.
 func (t *ImeSetComposition) Do(ctx context.Context) error {
@@ -491,7 +658,7 @@ type DispatchMouseEvent struct {
 	// (default: 0).
 	Modifiers int64 `json:"modifiers,omitempty"`
 	// Time at which the event occurred.
-	Timestamp float64 `json:"timestamp,omitempty"`
+	Timestamp devtools.TimeSinceEpoch `json:"timestamp,omitempty"`
 	// Mouse button (default: "none").
 	Button *MouseButton `json:"button,omitempty"`
 	// A number indicating which buttons are pressed on the mouse when a mouse event is triggered.
@@ -525,21 +692,34 @@ type DispatchMouseEvent struct {
 	DeltaY float64 `json:"deltaY,omitempty"`
 	// Pointer type (default: "mouse").
 	PointerType string `json:"pointerType,omitempty"`
+
+	// Cached JSON encoding of the parameters, see the `marshal` method.
+	cache atomic.Value
 }
 
 // NewDispatchMouseEvent constructs a new DispatchMouseEvent struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `DispatchMouseEventOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Input/#method-dispatchMouseEvent
-func NewDispatchMouseEvent(t string, x float64, y float64) *DispatchMouseEvent {
-	return &DispatchMouseEvent{
+func NewDispatchMouseEvent(t string, x float64, y float64, opts ...DispatchMouseEventOption) *DispatchMouseEvent {
+	c := &DispatchMouseEvent{
 		Type: t,
 		X:    x,
 		Y:    y,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// DispatchMouseEventOption is a functional option for the optional
+// parameters of the DispatchMouseEvent CDP command, see the
+// NewDispatchMouseEvent constructor. Options are applied in order.
+type DispatchMouseEventOption = func(*DispatchMouseEvent)
+
 // SetModifiers adds or modifies the value of the optional
 // parameter `modifiers` in the DispatchMouseEvent CDP command.
 //
@@ -550,15 +730,31 @@ func (t *DispatchMouseEvent) SetModifiers(v int64) *DispatchMouseEvent {
 	return t
 }
 
+// DispatchMouseEventWithModifiers is a functional option which sets the value of the
+// optional parameter `modifiers` in the DispatchMouseEvent CDP command, see SetModifiers.
+func DispatchMouseEventWithModifiers(v int64) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetModifiers(v)
+	}
+}
+
 // SetTimestamp adds or modifies the value of the optional
 // parameter `timestamp` in the DispatchMouseEvent CDP command.
 //
 // Time at which the event occurred.
-func (t *DispatchMouseEvent) SetTimestamp(v float64) *DispatchMouseEvent {
+func (t *DispatchMouseEvent) SetTimestamp(v devtools.TimeSinceEpoch) *DispatchMouseEvent {
 	t.Timestamp = v
 	return t
 }
 
+// DispatchMouseEventWithTimestamp is a functional option which sets the value of the
+// optional parameter `timestamp` in the DispatchMouseEvent CDP command, see SetTimestamp.
+func DispatchMouseEventWithTimestamp(v devtools.TimeSinceEpoch) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetTimestamp(v)
+	}
+}
+
 // SetButton adds or modifies the value of the optional
 // parameter `button` in the DispatchMouseEvent CDP command.
 //
@@ -568,6 +764,14 @@ func (t *DispatchMouseEvent) SetButton(v MouseButton) *DispatchMouseEvent {
 	return t
 }
 
+// DispatchMouseEventWithButton is a functional option which sets the value of the
+// optional parameter `button` in the DispatchMouseEvent CDP command, see SetButton.
+func DispatchMouseEventWithButton(v MouseButton) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetButton(v)
+	}
+}
+
 // SetButtons adds or modifies the value of the optional
 // parameter `buttons` in the DispatchMouseEvent CDP command.
 //
@@ -578,6 +782,14 @@ func (t *DispatchMouseEvent) SetButtons(v int64) *DispatchMouseEvent {
 	return t
 }
 
+// DispatchMouseEventWithButtons is a functional option which sets the value of the
+// optional parameter `buttons` in the DispatchMouseEvent CDP command, see SetButtons.
+func DispatchMouseEventWithButtons(v int64) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetButtons(v)
+	}
+}
+
 // SetClickCount adds or modifies the value of the optional
 // parameter `clickCount` in the DispatchMouseEvent CDP command.
 //
@@ -587,6 +799,14 @@ func (t *DispatchMouseEvent) SetClickCount(v int64) *DispatchMouseEvent {
 	return t
 }
 
+// DispatchMouseEventWithClickCount is a functional option which sets the value of the
+// optional parameter `clickCount` in the DispatchMouseEvent CDP command, see SetClickCount.
+func DispatchMouseEventWithClickCount(v int64) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetClickCount(v)
+	}
+}
+
 // SetForce adds or modifies the value of the optional
 // parameter `force` in the DispatchMouseEvent CDP command.
 //
@@ -598,6 +818,14 @@ func (t *DispatchMouseEvent) SetForce(v float64) *DispatchMouseEvent {
 	return t
 }
 
+// DispatchMouseEventWithForce is a functional option which sets the value of the
+// optional parameter `force` in the DispatchMouseEvent CDP command, see SetForce.
+func DispatchMouseEventWithForce(v float64) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetForce(v)
+	}
+}
+
 // SetTangentialPressure adds or modifies the value of the optional
 // parameter `tangentialPressure` in the DispatchMouseEvent CDP command.
 //
@@ -609,6 +837,14 @@ func (t *DispatchMouseEvent) SetTangentialPressure(v float64) *DispatchMouseEven
 	return t
 }
 
+// DispatchMouseEventWithTangentialPressure is a functional option which sets the value of the
+// optional parameter `tangentialPressure` in the DispatchMouseEvent CDP command, see SetTangentialPressure.
+func DispatchMouseEventWithTangentialPressure(v float64) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetTangentialPressure(v)
+	}
+}
+
 // SetTiltX adds or modifies the value of the optional
 // parameter `tiltX` in the DispatchMouseEvent CDP command.
 //
@@ -620,6 +856,14 @@ func (t *DispatchMouseEvent) SetTiltX(v int64) *DispatchMouseEvent {
 	return t
 }
 
+// DispatchMouseEventWithTiltX is a functional option which sets the value of the
+// optional parameter `tiltX` in the DispatchMouseEvent CDP command, see SetTiltX.
+func DispatchMouseEventWithTiltX(v int64) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetTiltX(v)
+	}
+}
+
 // SetTiltY adds or modifies the value of the optional
 // parameter `tiltY` in the DispatchMouseEvent CDP command.
 //
@@ -631,6 +875,14 @@ func (t *DispatchMouseEvent) SetTiltY(v int64) *DispatchMouseEvent {
 	return t
 }
 
+// DispatchMouseEventWithTiltY is a functional option which sets the value of the
+// optional parameter `tiltY` in the DispatchMouseEvent CDP command, see SetTiltY.
+func DispatchMouseEventWithTiltY(v int64) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetTiltY(v)
+	}
+}
+
 // SetTwist adds or modifies the value of the optional
 // parameter `twist` in the DispatchMouseEvent CDP command.
 //
@@ -642,6 +894,14 @@ func (t *DispatchMouseEvent) SetTwist(v int64) *DispatchMouseEvent {
 	return t
 }
 
+// DispatchMouseEventWithTwist is a functional option which sets the value of the
+// optional parameter `twist` in the DispatchMouseEvent CDP command, see SetTwist.
+func DispatchMouseEventWithTwist(v int64) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetTwist(v)
+	}
+}
+
 // SetDeltaX adds or modifies the value of the optional
 // parameter `deltaX` in the DispatchMouseEvent CDP command.
 //
@@ -651,6 +911,14 @@ func (t *DispatchMouseEvent) SetDeltaX(v float64) *DispatchMouseEvent {
 	return t
 }
 
+// DispatchMouseEventWithDeltaX is a functional option which sets the value of the
+// optional parameter `deltaX` in the DispatchMouseEvent CDP command, see SetDeltaX.
+func DispatchMouseEventWithDeltaX(v float64) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetDeltaX(v)
+	}
+}
+
 // SetDeltaY adds or modifies the value of the optional
 // parameter `deltaY` in the DispatchMouseEvent CDP command.
 //
@@ -660,6 +928,14 @@ func (t *DispatchMouseEvent) SetDeltaY(v float64) *DispatchMouseEvent {
 	return t
 }
 
+// DispatchMouseEventWithDeltaY is a functional option which sets the value of the
+// optional parameter `deltaY` in the DispatchMouseEvent CDP command, see SetDeltaY.
+func DispatchMouseEventWithDeltaY(v float64) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetDeltaY(v)
+	}
+}
+
 // SetPointerType adds or modifies the value of the optional
 // parameter `pointerType` in the DispatchMouseEvent CDP command.
 //
@@ -669,10 +945,18 @@ func (t *DispatchMouseEvent) SetPointerType(v string) *DispatchMouseEvent {
 	return t
 }
 
+// DispatchMouseEventWithPointerType is a functional option which sets the value of the
+// optional parameter `pointerType` in the DispatchMouseEvent CDP command, see SetPointerType.
+func DispatchMouseEventWithPointerType(v string) DispatchMouseEventOption {
+	return func(c *DispatchMouseEvent) {
+		c.SetPointerType(v)
+	}
+}
+
 // Do sends the DispatchMouseEvent CDP command to a browser,
 // and returns the browser's response.
 func (t *DispatchMouseEvent) Do(ctx context.Context) error {
-	b, err := json.Marshal(t)
+	b, err := t.marshal()
 	if err != nil {
 		return err
 	}
@@ -688,7 +972,7 @@ func (t *DispatchMouseEvent) Do(ctx context.Context) error {
 // Callers should close the returned channel on their own,
 // although closing unused channels isn't strictly required.
 func (t *DispatchMouseEvent) Start(ctx context.Context) (chan *devtools.Message, error) {
-	b, err := json.Marshal(t)
+	b, err := t.marshal()
 	if err != nil {
 		return nil, err
 	}
@@ -704,6 +988,71 @@ func (t *DispatchMouseEvent) ParseResponse(m *devtools.Message) error {
 	return nil
 }
 
+// Cached JSON encoding of the DispatchMouseEvent CDP command's parameters.
+type dispatchMouseEventCache struct {
+	params DispatchMouseEvent
+	b      []byte
+}
+
+// Report whether the cached parameters are equal to the given ones.
+func (c *dispatchMouseEventCache) equal(t *DispatchMouseEvent) bool {
+	return c.params.Type == t.Type &&
+		c.params.X == t.X &&
+		c.params.Y == t.Y &&
+		c.params.Modifiers == t.Modifiers &&
+		c.params.Timestamp == t.Timestamp &&
+		(c.params.Button == nil) == (t.Button == nil) &&
+		(c.params.Button == nil || *c.params.Button == *t.Button) &&
+		c.params.Buttons == t.Buttons &&
+		c.params.ClickCount == t.ClickCount &&
+		c.params.Force == t.Force &&
+		c.params.TangentialPressure == t.TangentialPressure &&
+		c.params.TiltX == t.TiltX &&
+		c.params.TiltY == t.TiltY &&
+		c.params.Twist == t.Twist &&
+		c.params.DeltaX == t.DeltaX &&
+		c.params.DeltaY == t.DeltaY &&
+		c.params.PointerType == t.PointerType
+}
+
+// Return the JSON encoding of the parameters, and cache it for
+// subsequent calls, as long as the parameters don't change (whether
+// they're changed by the builder-like methods above, or directly).
+func (t *DispatchMouseEvent) marshal() ([]byte, error) {
+	if c, ok := t.cache.Load().(*dispatchMouseEventCache); ok && c.equal(t) {
+		return c.b, nil
+	}
+	c := &dispatchMouseEventCache{params: DispatchMouseEvent{
+		Type:               t.Type,
+		X:                  t.X,
+		Y:                  t.Y,
+		Modifiers:          t.Modifiers,
+		Timestamp:          t.Timestamp,
+		Button:             t.Button,
+		Buttons:            t.Buttons,
+		ClickCount:         t.ClickCount,
+		Force:              t.Force,
+		TangentialPressure: t.TangentialPressure,
+		TiltX:              t.TiltX,
+		TiltY:              t.TiltY,
+		Twist:              t.Twist,
+		DeltaX:             t.DeltaX,
+		DeltaY:             t.DeltaY,
+		PointerType:        t.PointerType,
+	}}
+	if t.Button != nil {
+		v := *t.Button
+		c.params.Button = &v
+	}
+	b, err := json.Marshal(&c.params)
+	if err != nil {
+		return nil, err
+	}
+	c.b = b
+	t.cache.Store(c)
+	return b, nil
+}
+
 // DispatchTouchEvent contains the parameters, and acts as
 // a Go receiver, for the CDP command `dispatchTouchEvent`.
 //
@@ -722,21 +1071,31 @@ type DispatchTouchEvent struct {
 	// (default: 0).
 	Modifiers int64 `json:"modifiers,omitempty"`
 	// Time at which the event occurred.
-	Timestamp float64 `json:"timestamp,omitempty"`
+	Timestamp devtools.TimeSinceEpoch `json:"timestamp,omitempty"`
 }
 
 // NewDispatchTouchEvent constructs a new DispatchTouchEvent struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `DispatchTouchEventOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Input/#method-dispatchTouchEvent
-func NewDispatchTouchEvent(t string, touchPoints []TouchPoint) *DispatchTouchEvent {
-	return &DispatchTouchEvent{
+func NewDispatchTouchEvent(t string, touchPoints []TouchPoint, opts ...DispatchTouchEventOption) *DispatchTouchEvent {
+	c := &DispatchTouchEvent{
 		Type:        t,
 		TouchPoints: touchPoints,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// DispatchTouchEventOption is a functional option for the optional
+// parameters of the DispatchTouchEvent CDP command, see the
+// NewDispatchTouchEvent constructor. Options are applied in order.
+type DispatchTouchEventOption = func(*DispatchTouchEvent)
+
 // SetModifiers adds or modifies the value of the optional
 // parameter `modifiers` in the DispatchTouchEvent CDP command.
 //
@@ -747,15 +1106,31 @@ func (t *DispatchTouchEvent) SetModifiers(v int64) *DispatchTouchEvent {
 	return t
 }
 
+// DispatchTouchEventWithModifiers is a functional option which sets the value of the
+// optional parameter `modifiers` in the DispatchTouchEvent CDP command, see SetModifiers.
+func DispatchTouchEventWithModifiers(v int64) DispatchTouchEventOption {
+	return func(c *DispatchTouchEvent) {
+		c.SetModifiers(v)
+	}
+}
+
 // SetTimestamp adds or modifies the value of the optional
 // parameter `timestamp` in the DispatchTouchEvent CDP command.
 //
 // Time at which the event occurred.
-func (t *DispatchTouchEvent) SetTimestamp(v float64) *DispatchTouchEvent {
+func (t *DispatchTouchEvent) SetTimestamp(v devtools.TimeSinceEpoch) *DispatchTouchEvent {
 	t.Timestamp = v
 	return t
 }
 
+// DispatchTouchEventWithTimestamp is a functional option which sets the value of the
+// optional parameter `timestamp` in the DispatchTouchEvent CDP command, see SetTimestamp.
+func DispatchTouchEventWithTimestamp(v devtools.TimeSinceEpoch) DispatchTouchEventOption {
+	return func(c *DispatchTouchEvent) {
+		c.SetTimestamp(v)
+	}
+}
+
 // Do sends the DispatchTouchEvent CDP command to a browser,
 // and returns the browser's response.
 func (t *DispatchTouchEvent) Do(ctx context.Context) error {
@@ -809,7 +1184,7 @@ type EmulateTouchFromMouseEvent struct {
 	// Mouse button. Only "none", "left", "right" are supported.
 	Button MouseButton `json:"button"`
 	// Time at which the event occurred (default: current time).
-	Timestamp float64 `json:"timestamp,omitempty"`
+	Timestamp devtools.TimeSinceEpoch `json:"timestamp,omitempty"`
 	// X delta in DIP for mouse wheel event (default: 0).
 	DeltaX float64 `json:"deltaX,omitempty"`
 	// Y delta in DIP for mouse wheel event (default: 0).
@@ -819,33 +1194,54 @@ type EmulateTouchFromMouseEvent struct {
 	Modifiers int64 `json:"modifiers,omitempty"`
 	// Number of times the mouse button was clicked (default: 0).
 	ClickCount int64 `json:"clickCount,omitempty"`
+
+	// Cached JSON encoding of the parameters, see the `marshal` method.
+	cache atomic.Value
 }
 
 // NewEmulateTouchFromMouseEvent constructs a new EmulateTouchFromMouseEvent struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `EmulateTouchFromMouseEventOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Input/#method-emulateTouchFromMouseEvent
 //
 // This CDP method is experimental.
-func NewEmulateTouchFromMouseEvent(t string, x int64, y int64, button MouseButton) *EmulateTouchFromMouseEvent {
-	return &EmulateTouchFromMouseEvent{
+func NewEmulateTouchFromMouseEvent(t string, x int64, y int64, button MouseButton, opts ...EmulateTouchFromMouseEventOption) *EmulateTouchFromMouseEvent {
+	c := &EmulateTouchFromMouseEvent{
 		Type:   t,
 		X:      x,
 		Y:      y,
 		Button: button,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// EmulateTouchFromMouseEventOption is a functional option for the optional
+// parameters of the EmulateTouchFromMouseEvent CDP command, see the
+// NewEmulateTouchFromMouseEvent constructor. Options are applied in order.
+type EmulateTouchFromMouseEventOption = func(*EmulateTouchFromMouseEvent)
+
 // SetTimestamp adds or modifies the value of the optional
 // parameter `timestamp` in the EmulateTouchFromMouseEvent CDP command.
 //
 // Time at which the event occurred (default: current time).
-func (t *EmulateTouchFromMouseEvent) SetTimestamp(v float64) *EmulateTouchFromMouseEvent {
+func (t *EmulateTouchFromMouseEvent) SetTimestamp(v devtools.TimeSinceEpoch) *EmulateTouchFromMouseEvent {
 	t.Timestamp = v
 	return t
 }
 
+// EmulateTouchFromMouseEventWithTimestamp is a functional option which sets the value of the
+// optional parameter `timestamp` in the EmulateTouchFromMouseEvent CDP command, see SetTimestamp.
+func EmulateTouchFromMouseEventWithTimestamp(v devtools.TimeSinceEpoch) EmulateTouchFromMouseEventOption {
+	return func(c *EmulateTouchFromMouseEvent) {
+		c.SetTimestamp(v)
+	}
+}
+
 // SetDeltaX adds or modifies the value of the optional
 // parameter `deltaX` in the EmulateTouchFromMouseEvent CDP command.
 //
@@ -855,6 +1251,14 @@ func (t *EmulateTouchFromMouseEvent) SetDeltaX(v float64) *EmulateTouchFromMouse
 	return t
 }
 
+// EmulateTouchFromMouseEventWithDeltaX is a functional option which sets the value of the
+// optional parameter `deltaX` in the EmulateTouchFromMouseEvent CDP command, see SetDeltaX.
+func EmulateTouchFromMouseEventWithDeltaX(v float64) EmulateTouchFromMouseEventOption {
+	return func(c *EmulateTouchFromMouseEvent) {
+		c.SetDeltaX(v)
+	}
+}
+
 // SetDeltaY adds or modifies the value of the optional
 // parameter `deltaY` in the EmulateTouchFromMouseEvent CDP command.
 //
@@ -864,6 +1268,14 @@ func (t *EmulateTouchFromMouseEvent) SetDeltaY(v float64) *EmulateTouchFromMouse
 	return t
 }
 
+// EmulateTouchFromMouseEventWithDeltaY is a functional option which sets the value of the
+// optional parameter `deltaY` in the EmulateTouchFromMouseEvent CDP command, see SetDeltaY.
+func EmulateTouchFromMouseEventWithDeltaY(v float64) EmulateTouchFromMouseEventOption {
+	return func(c *EmulateTouchFromMouseEvent) {
+		c.SetDeltaY(v)
+	}
+}
+
 // SetModifiers adds or modifies the value of the optional
 // parameter `modifiers` in the EmulateTouchFromMouseEvent CDP command.
 //
@@ -874,6 +1286,14 @@ func (t *EmulateTouchFromMouseEvent) SetModifiers(v int64) *EmulateTouchFromMous
 	return t
 }
 
+// EmulateTouchFromMouseEventWithModifiers is a functional option which sets the value of the
+// optional parameter `modifiers` in the EmulateTouchFromMouseEvent CDP command, see SetModifiers.
+func EmulateTouchFromMouseEventWithModifiers(v int64) EmulateTouchFromMouseEventOption {
+	return func(c *EmulateTouchFromMouseEvent) {
+		c.SetModifiers(v)
+	}
+}
+
 // SetClickCount adds or modifies the value of the optional
 // parameter `clickCount` in the EmulateTouchFromMouseEvent CDP command.
 //
@@ -883,10 +1303,18 @@ func (t *EmulateTouchFromMouseEvent) SetClickCount(v int64) *EmulateTouchFromMou
 	return t
 }
 
+// EmulateTouchFromMouseEventWithClickCount is a functional option which sets the value of the
+// optional parameter `clickCount` in the EmulateTouchFromMouseEvent CDP command, see SetClickCount.
+func EmulateTouchFromMouseEventWithClickCount(v int64) EmulateTouchFromMouseEventOption {
+	return func(c *EmulateTouchFromMouseEvent) {
+		c.SetClickCount(v)
+	}
+}
+
 // Do sends the EmulateTouchFromMouseEvent CDP command to a browser,
 // and returns the browser's response.
 func (t *EmulateTouchFromMouseEvent) Do(ctx context.Context) error {
-	b, err := json.Marshal(t)
+	b, err := t.marshal()
 	if err != nil {
 		return err
 	}
@@ -902,7 +1330,7 @@ func (t *EmulateTouchFromMouseEvent) Do(ctx context.Context) error {
 // Callers should close the returned channel on their own,
 // although closing unused channels isn't strictly required.
 func (t *EmulateTouchFromMouseEvent) Start(ctx context.Context) (chan *devtools.Message, error) {
-	b, err := json.Marshal(t)
+	b, err := t.marshal()
 	if err != nil {
 		return nil, err
 	}
@@ -918,6 +1346,52 @@ func (t *EmulateTouchFromMouseEvent) ParseResponse(m *devtools.Message) error {
 	return nil
 }
 
+// Cached JSON encoding of the EmulateTouchFromMouseEvent CDP command's parameters.
+type emulateTouchFromMouseEventCache struct {
+	params EmulateTouchFromMouseEvent
+	b      []byte
+}
+
+// Report whether the cached parameters are equal to the given ones.
+func (c *emulateTouchFromMouseEventCache) equal(t *EmulateTouchFromMouseEvent) bool {
+	return c.params.Type == t.Type &&
+		c.params.X == t.X &&
+		c.params.Y == t.Y &&
+		c.params.Button == t.Button &&
+		c.params.Timestamp == t.Timestamp &&
+		c.params.DeltaX == t.DeltaX &&
+		c.params.DeltaY == t.DeltaY &&
+		c.params.Modifiers == t.Modifiers &&
+		c.params.ClickCount == t.ClickCount
+}
+
+// Return the JSON encoding of the parameters, and cache it for
+// subsequent calls, as long as the parameters don't change (whether
+// they're changed by the builder-like methods above, or directly).
+func (t *EmulateTouchFromMouseEvent) marshal() ([]byte, error) {
+	if c, ok := t.cache.Load().(*emulateTouchFromMouseEventCache); ok && c.equal(t) {
+		return c.b, nil
+	}
+	c := &emulateTouchFromMouseEventCache{params: EmulateTouchFromMouseEvent{
+		Type:       t.Type,
+		X:          t.X,
+		Y:          t.Y,
+		Button:     t.Button,
+		Timestamp:  t.Timestamp,
+		DeltaX:     t.DeltaX,
+		DeltaY:     t.DeltaY,
+		Modifiers:  t.Modifiers,
+		ClickCount: t.ClickCount,
+	}}
+	b, err := json.Marshal(&c.params)
+	if err != nil {
+		return nil, err
+	}
+	c.b = b
+	t.cache.Store(c)
+	return b, nil
+}
+
 // SetIgnoreInputEvents contains the parameters, and acts as
 // a Go receiver, for the CDP command `setIgnoreInputEvents`.
 //
@@ -1052,7 +1526,7 @@ type SynthesizePinchGesture struct {
 	// Relative scale factor after zooming (>1.0 zooms in, <1.0 zooms out).
 	ScaleFactor float64 `json:"scaleFactor"`
 	// Relative pointer speed in pixels per second (default: 800).
-	RelativeSpeed int64 `json:"relativeSpeed,omitempty"`
+	RelativeSpeed *int64 `json:"relativeSpeed,omitempty"`
 	// Which type of input events to be generated (default: 'default', which queries the platform
 	// for the preferred input type).
 	GestureSourceType *GestureSourceType `json:"gestureSourceType,omitempty"`
@@ -1060,28 +1534,46 @@ type SynthesizePinchGesture struct {
 
 // NewSynthesizePinchGesture constructs a new SynthesizePinchGesture struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SynthesizePinchGestureOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Input/#method-synthesizePinchGesture
 //
 // This CDP method is experimental.
-func NewSynthesizePinchGesture(x float64, y float64, scaleFactor float64) *SynthesizePinchGesture {
-	return &SynthesizePinchGesture{
+func NewSynthesizePinchGesture(x float64, y float64, scaleFactor float64, opts ...SynthesizePinchGestureOption) *SynthesizePinchGesture {
+	c := &SynthesizePinchGesture{
 		X:           x,
 		Y:           y,
 		ScaleFactor: scaleFactor,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SynthesizePinchGestureOption is a functional option for the optional
+// parameters of the SynthesizePinchGesture CDP command, see the
+// NewSynthesizePinchGesture constructor. Options are applied in order.
+type SynthesizePinchGestureOption = func(*SynthesizePinchGesture)
+
 // SetRelativeSpeed adds or modifies the value of the optional
 // parameter `relativeSpeed` in the SynthesizePinchGesture CDP command.
 //
 // Relative pointer speed in pixels per second (default: 800).
 func (t *SynthesizePinchGesture) SetRelativeSpeed(v int64) *SynthesizePinchGesture {
-	t.RelativeSpeed = v
+	t.RelativeSpeed = &v
 	return t
 }
 
+// SynthesizePinchGestureWithRelativeSpeed is a functional option which sets the value of the
+// optional parameter `relativeSpeed` in the SynthesizePinchGesture CDP command, see SetRelativeSpeed.
+func SynthesizePinchGestureWithRelativeSpeed(v int64) SynthesizePinchGestureOption {
+	return func(c *SynthesizePinchGesture) {
+		c.SetRelativeSpeed(v)
+	}
+}
+
 // SetGestureSourceType adds or modifies the value of the optional
 // parameter `gestureSourceType` in the SynthesizePinchGesture CDP command.
 //
@@ -1092,6 +1584,14 @@ func (t *SynthesizePinchGesture) SetGestureSourceType(v GestureSourceType) *Synt
 	return t
 }
 
+// SynthesizePinchGestureWithGestureSourceType is a functional option which sets the value of the
+// optional parameter `gestureSourceType` in the SynthesizePinchGesture CDP command, see SetGestureSourceType.
+func SynthesizePinchGestureWithGestureSourceType(v GestureSourceType) SynthesizePinchGestureOption {
+	return func(c *SynthesizePinchGesture) {
+		c.SetGestureSourceType(v)
+	}
+}
+
 // Do sends the SynthesizePinchGesture CDP command to a browser,
 // and returns the browser's response.
 func (t *SynthesizePinchGesture) Do(ctx context.Context) error {
@@ -1151,34 +1651,44 @@ type SynthesizeScrollGesture struct {
 	// distance.
 	YOverscroll float64 `json:"yOverscroll,omitempty"`
 	// Prevent fling (default: true).
-	PreventFling bool `json:"preventFling,omitempty"`
+	PreventFling *bool `json:"preventFling,omitempty"`
 	// Swipe speed in pixels per second (default: 800).
-	Speed int64 `json:"speed,omitempty"`
+	Speed *int64 `json:"speed,omitempty"`
 	// Which type of input events to be generated (default: 'default', which queries the platform
 	// for the preferred input type).
 	GestureSourceType *GestureSourceType `json:"gestureSourceType,omitempty"`
 	// The number of times to repeat the gesture (default: 0).
 	RepeatCount int64 `json:"repeatCount,omitempty"`
 	// The number of milliseconds delay between each repeat. (default: 250).
-	RepeatDelayMs int64 `json:"repeatDelayMs,omitempty"`
+	RepeatDelayMs *int64 `json:"repeatDelayMs,omitempty"`
 	// The name of the interaction markers to generate, if not empty (default: "").
 	InteractionMarkerName string `json:"interactionMarkerName,omitempty"`
 }
 
 // NewSynthesizeScrollGesture constructs a new SynthesizeScrollGesture struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SynthesizeScrollGestureOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Input/#method-synthesizeScrollGesture
 //
 // This CDP method is experimental.
-func NewSynthesizeScrollGesture(x float64, y float64) *SynthesizeScrollGesture {
-	return &SynthesizeScrollGesture{
+func NewSynthesizeScrollGesture(x float64, y float64, opts ...SynthesizeScrollGestureOption) *SynthesizeScrollGesture {
+	c := &SynthesizeScrollGesture{
 		X: x,
 		Y: y,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SynthesizeScrollGestureOption is a functional option for the optional
+// parameters of the SynthesizeScrollGesture CDP command, see the
+// NewSynthesizeScrollGesture constructor. Options are applied in order.
+type SynthesizeScrollGestureOption = func(*SynthesizeScrollGesture)
+
 // SetXDistance adds or modifies the value of the optional
 // parameter `xDistance` in the SynthesizeScrollGesture CDP command.
 //
@@ -1188,6 +1698,14 @@ func (t *SynthesizeScrollGesture) SetXDistance(v float64) *SynthesizeScrollGestu
 	return t
 }
 
+// SynthesizeScrollGestureWithXDistance is a functional option which sets the value of the
+// optional parameter `xDistance` in the SynthesizeScrollGesture CDP command, see SetXDistance.
+func SynthesizeScrollGestureWithXDistance(v float64) SynthesizeScrollGestureOption {
+	return func(c *SynthesizeScrollGesture) {
+		c.SetXDistance(v)
+	}
+}
+
 // SetYDistance adds or modifies the value of the optional
 // parameter `yDistance` in the SynthesizeScrollGesture CDP command.
 //
@@ -1197,6 +1715,14 @@ func (t *SynthesizeScrollGesture) SetYDistance(v float64) *SynthesizeScrollGestu
 	return t
 }
 
+// SynthesizeScrollGestureWithYDistance is a functional option which sets the value of the
+// optional parameter `yDistance` in the SynthesizeScrollGesture CDP command, see SetYDistance.
+func SynthesizeScrollGestureWithYDistance(v float64) SynthesizeScrollGestureOption {
+	return func(c *SynthesizeScrollGesture) {
+		c.SetYDistance(v)
+	}
+}
+
 // SetXOverscroll adds or modifies the value of the optional
 // parameter `xOverscroll` in the SynthesizeScrollGesture CDP command.
 //
@@ -1207,6 +1733,14 @@ func (t *SynthesizeScrollGesture) SetXOverscroll(v float64) *SynthesizeScrollGes
 	return t
 }
 
+// SynthesizeScrollGestureWithXOverscroll is a functional option which sets the value of the
+// optional parameter `xOverscroll` in the SynthesizeScrollGesture CDP command, see SetXOverscroll.
+func SynthesizeScrollGestureWithXOverscroll(v float64) SynthesizeScrollGestureOption {
+	return func(c *SynthesizeScrollGesture) {
+		c.SetXOverscroll(v)
+	}
+}
+
 // SetYOverscroll adds or modifies the value of the optional
 // parameter `yOverscroll` in the SynthesizeScrollGesture CDP command.
 //
@@ -1217,24 +1751,48 @@ func (t *SynthesizeScrollGesture) SetYOverscroll(v float64) *SynthesizeScrollGes
 	return t
 }
 
+// SynthesizeScrollGestureWithYOverscroll is a functional option which sets the value of the
+// optional parameter `yOverscroll` in the SynthesizeScrollGesture CDP command, see SetYOverscroll.
+func SynthesizeScrollGestureWithYOverscroll(v float64) SynthesizeScrollGestureOption {
+	return func(c *SynthesizeScrollGesture) {
+		c.SetYOverscroll(v)
+	}
+}
+
 // SetPreventFling adds or modifies the value of the optional
 // parameter `preventFling` in the SynthesizeScrollGesture CDP command.
 //
 // Prevent fling (default: true).
 func (t *SynthesizeScrollGesture) SetPreventFling(v bool) *SynthesizeScrollGesture {
-	t.PreventFling = v
+	t.PreventFling = &v
 	return t
 }
 
+// SynthesizeScrollGestureWithPreventFling is a functional option which sets the value of the
+// optional parameter `preventFling` in the SynthesizeScrollGesture CDP command, see SetPreventFling.
+func SynthesizeScrollGestureWithPreventFling(v bool) SynthesizeScrollGestureOption {
+	return func(c *SynthesizeScrollGesture) {
+		c.SetPreventFling(v)
+	}
+}
+
 // SetSpeed adds or modifies the value of the optional
 // parameter `speed` in the SynthesizeScrollGesture CDP command.
 //
 // Swipe speed in pixels per second (default: 800).
 func (t *SynthesizeScrollGesture) SetSpeed(v int64) *SynthesizeScrollGesture {
-	t.Speed = v
+	t.Speed = &v
 	return t
 }
 
+// SynthesizeScrollGestureWithSpeed is a functional option which sets the value of the
+// optional parameter `speed` in the SynthesizeScrollGesture CDP command, see SetSpeed.
+func SynthesizeScrollGestureWithSpeed(v int64) SynthesizeScrollGestureOption {
+	return func(c *SynthesizeScrollGesture) {
+		c.SetSpeed(v)
+	}
+}
+
 // SetGestureSourceType adds or modifies the value of the optional
 // parameter `gestureSourceType` in the SynthesizeScrollGesture CDP command.
 //
@@ -1245,6 +1803,14 @@ func (t *SynthesizeScrollGesture) SetGestureSourceType(v GestureSourceType) *Syn
 	return t
 }
 
+// SynthesizeScrollGestureWithGestureSourceType is a functional option which sets the value of the
+// optional parameter `gestureSourceType` in the SynthesizeScrollGesture CDP command, see SetGestureSourceType.
+func SynthesizeScrollGestureWithGestureSourceType(v GestureSourceType) SynthesizeScrollGestureOption {
+	return func(c *SynthesizeScrollGesture) {
+		c.SetGestureSourceType(v)
+	}
+}
+
 // SetRepeatCount adds or modifies the value of the optional
 // parameter `repeatCount` in the SynthesizeScrollGesture CDP command.
 //
@@ -1254,15 +1820,31 @@ func (t *SynthesizeScrollGesture) SetRepeatCount(v int64) *SynthesizeScrollGestu
 	return t
 }
 
+// SynthesizeScrollGestureWithRepeatCount is a functional option which sets the value of the
+// optional parameter `repeatCount` in the SynthesizeScrollGesture CDP command, see SetRepeatCount.
+func SynthesizeScrollGestureWithRepeatCount(v int64) SynthesizeScrollGestureOption {
+	return func(c *SynthesizeScrollGesture) {
+		c.SetRepeatCount(v)
+	}
+}
+
 // SetRepeatDelayMs adds or modifies the value of the optional
 // parameter `repeatDelayMs` in the SynthesizeScrollGesture CDP command.
 //
 // The number of milliseconds delay between each repeat. (default: 250).
 func (t *SynthesizeScrollGesture) SetRepeatDelayMs(v int64) *SynthesizeScrollGesture {
-	t.RepeatDelayMs = v
+	t.RepeatDelayMs = &v
 	return t
 }
 
+// SynthesizeScrollGestureWithRepeatDelayMs is a functional option which sets the value of the
+// optional parameter `repeatDelayMs` in the SynthesizeScrollGesture CDP command, see SetRepeatDelayMs.
+func SynthesizeScrollGestureWithRepeatDelayMs(v int64) SynthesizeScrollGestureOption {
+	return func(c *SynthesizeScrollGesture) {
+		c.SetRepeatDelayMs(v)
+	}
+}
+
 // SetInteractionMarkerName adds or modifies the value of the optional
 // parameter `interactionMarkerName` in the SynthesizeScrollGesture CDP command.
 //
@@ -1272,6 +1854,14 @@ func (t *SynthesizeScrollGesture) SetInteractionMarkerName(v string) *Synthesize
 	return t
 }
 
+// SynthesizeScrollGestureWithInteractionMarkerName is a functional option which sets the value of the
+// optional parameter `interactionMarkerName` in the SynthesizeScrollGesture CDP command, see SetInteractionMarkerName.
+func SynthesizeScrollGestureWithInteractionMarkerName(v string) SynthesizeScrollGestureOption {
+	return func(c *SynthesizeScrollGesture) {
+		c.SetInteractionMarkerName(v)
+	}
+}
+
 // Do sends the SynthesizeScrollGesture CDP command to a browser,
 // and returns the browser's response.
 func (t *SynthesizeScrollGesture) Do(ctx context.Context) error {
@@ -1321,9 +1911,9 @@ type SynthesizeTapGesture struct {
 	// Y coordinate of the start of the gesture in CSS pixels.
 	Y float64 `json:"y"`
 	// Duration between touchdown and touchup events in ms (default: 50).
-	Duration int64 `json:"duration,omitempty"`
+	Duration *int64 `json:"duration,omitempty"`
 	// Number of times to perform the tap (e.g. 2 for double tap, default: 1).
-	TapCount int64 `json:"tapCount,omitempty"`
+	TapCount *int64 `json:"tapCount,omitempty"`
 	// Which type of input events to be generated (default: 'default', which queries the platform
 	// for the preferred input type).
 	GestureSourceType *GestureSourceType `json:"gestureSourceType,omitempty"`
@@ -1331,36 +1921,62 @@ type SynthesizeTapGesture struct {
 
 // NewSynthesizeTapGesture constructs a new SynthesizeTapGesture struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SynthesizeTapGestureOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Input/#method-synthesizeTapGesture
 //
 // This CDP method is experimental.
-func NewSynthesizeTapGesture(x float64, y float64) *SynthesizeTapGesture {
-	return &SynthesizeTapGesture{
+func NewSynthesizeTapGesture(x float64, y float64, opts ...SynthesizeTapGestureOption) *SynthesizeTapGesture {
+	c := &SynthesizeTapGesture{
 		X: x,
 		Y: y,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SynthesizeTapGestureOption is a functional option for the optional
+// parameters of the SynthesizeTapGesture CDP command, see the
+// NewSynthesizeTapGesture constructor. Options are applied in order.
+type SynthesizeTapGestureOption = func(*SynthesizeTapGesture)
+
 // SetDuration adds or modifies the value of the optional
 // parameter `duration` in the SynthesizeTapGesture CDP command.
 //
 // Duration between touchdown and touchup events in ms (default: 50).
 func (t *SynthesizeTapGesture) SetDuration(v int64) *SynthesizeTapGesture {
-	t.Duration = v
+	t.Duration = &v
 	return t
 }
 
+// SynthesizeTapGestureWithDuration is a functional option which sets the value of the
+// optional parameter `duration` in the SynthesizeTapGesture CDP command, see SetDuration.
+func SynthesizeTapGestureWithDuration(v int64) SynthesizeTapGestureOption {
+	return func(c *SynthesizeTapGesture) {
+		c.SetDuration(v)
+	}
+}
+
 // SetTapCount adds or modifies the value of the optional
 // parameter `tapCount` in the SynthesizeTapGesture CDP command.
 //
 // Number of times to perform the tap (e.g. 2 for double tap, default: 1).
 func (t *SynthesizeTapGesture) SetTapCount(v int64) *SynthesizeTapGesture {
-	t.TapCount = v
+	t.TapCount = &v
 	return t
 }
 
+// SynthesizeTapGestureWithTapCount is a functional option which sets the value of the
+// optional parameter `tapCount` in the SynthesizeTapGesture CDP command, see SetTapCount.
+func SynthesizeTapGestureWithTapCount(v int64) SynthesizeTapGestureOption {
+	return func(c *SynthesizeTapGesture) {
+		c.SetTapCount(v)
+	}
+}
+
 // SetGestureSourceType adds or modifies the value of the optional
 // parameter `gestureSourceType` in the SynthesizeTapGesture CDP command.
 //
@@ -1371,6 +1987,14 @@ func (t *SynthesizeTapGesture) SetGestureSourceType(v GestureSourceType) *Synthe
 	return t
 }
 
+// SynthesizeTapGestureWithGestureSourceType is a functional option which sets the value of the
+// optional parameter `gestureSourceType` in the SynthesizeTapGesture CDP command, see SetGestureSourceType.
+func SynthesizeTapGestureWithGestureSourceType(v GestureSourceType) SynthesizeTapGestureOption {
+	return func(c *SynthesizeTapGesture) {
+		c.SetGestureSourceType(v)
+	}
+}
+
 // Do sends the SynthesizeTapGesture CDP command to a browser,
 // and returns the browser's response.
 func (t *SynthesizeTapGesture) Do(ctx context.Context) error {
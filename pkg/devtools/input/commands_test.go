@@ -0,0 +1,57 @@
+package input
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalCache(t *testing.T) {
+	e := NewDispatchMouseEvent("mouseMoved", 1, 2)
+	button := MouseButtonMiddle
+	steps := []struct {
+		name   string
+		update func()
+		want   string
+	}{
+		{"initial", func() {}, `{"type":"mouseMoved","x":1,"y":2}`},
+		{"unchanged", func() {}, `{"type":"mouseMoved","x":1,"y":2}`},
+		{"setter", func() { e.SetButton(MouseButtonLeft) }, `{"type":"mouseMoved","x":1,"y":2,"button":"left"}`},
+		{"direct", func() { e.X = 3 }, `{"type":"mouseMoved","x":3,"y":2,"button":"left"}`},
+		{"pointer", func() { e.Button = &button }, `{"type":"mouseMoved","x":3,"y":2,"button":"middle"}`},
+		{"pointer_value", func() { button = MouseButtonRight }, `{"type":"mouseMoved","x":3,"y":2,"button":"right"}`},
+	}
+	for _, s := range steps {
+		s.update()
+		b, err := e.marshal()
+		if err != nil {
+			t.Fatalf("%s: marshal(); got error: %v", s.name, err)
+		}
+		if string(b) != s.want {
+			t.Errorf("%s: marshal() = %s, want %s", s.name, b, s.want)
+		}
+	}
+
+	if n := testing.AllocsPerRun(100, func() { e.marshal() }); n != 0 {
+		t.Errorf("marshal() of unchanged parameters: %v allocations, want 0", n)
+	}
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	e := NewDispatchMouseEvent("mouseMoved", 1, 2).SetButton(MouseButtonLeft).SetButtons(1)
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := e.marshal(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := json.Marshal(e); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package input
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// Bits of the `dragOperationsMask` field in `input.DragData`.
+const (
+	DragOperationCopy int64 = 1
+	DragOperationLink int64 = 2
+	DragOperationMove int64 = 16
+)
+
+// DropFiles simulates dragging the given local files from outside the
+// browser and dropping them onto the center of the first element which
+// matches the given CSS selector, in the main frame of the page associated
+// with the given context. The page receives "dragenter", "dragover" and
+// "drop" DOM events, with the files in the `DataTransfer` object, which is
+// what many upload widgets ("dropzones") require instead of a file input.
+//
+// Relative file paths are resolved relative to the current directory of
+// this program, and the files must be accessible to the browser too.
+func DropFiles(ctx context.Context, selector string, files ...string) error {
+	data := DragData{Items: []DragDataItem{}, DragOperationsMask: DragOperationCopy}
+	for _, f := range files {
+		abs, err := filepath.Abs(f)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(abs); err != nil {
+			return err
+		}
+		data.Files = append(data.Files, abs)
+	}
+	x, y, err := elementCenter(ctx, selector)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range []string{"dragEnter", "dragOver", "drop"} {
+		if err := NewDispatchDragEvent(t, x, y, data).Do(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Return the coordinates of the center of the first element which matches
+// the given CSS selector, relative to the main frame's viewport, after
+// scrolling it into view if needed.
+func elementCenter(ctx context.Context, selector string) (float64, float64, error) {
+	s, err := json.Marshal(selector)
+	if err != nil {
+		return 0, 0, err
+	}
+	expr := fmt.Sprintf(`(() => {
+		const e = document.querySelector(%s);
+		if (!e) {
+			throw new Error("no element matches the selector");
+		}
+		e.scrollIntoView({block: "center", inline: "center"});
+		const r = e.getBoundingClientRect();
+		return [r.left + r.width / 2, r.top + r.height / 2];
+	})()`, s)
+	var center [2]float64
+	if err := runtime.Eval(ctx, expr, &center); err != nil {
+		return 0, 0, err
+	}
+	return center[0], center[1], nil
+}
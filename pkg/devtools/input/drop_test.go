@@ -0,0 +1,104 @@
+package input
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDropFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	if err := os.WriteFile(file, []byte("a"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	center := &runtime.EvaluateResult{Result: runtime.RemoteObject{Type: "object", Value: json.RawMessage(`[10.5,20]`)}}
+	notFound := &runtime.EvaluateResult{ExceptionDetails: &runtime.ExceptionDetails{Text: "Uncaught"}}
+
+	tests := []struct {
+		name       string
+		files      []string
+		evalResult *runtime.EvaluateResult
+		failDrag   bool
+		wantTypes  []string
+		wantErr    bool
+	}{
+		{
+			name:       "drop",
+			files:      []string{file},
+			evalResult: center,
+			wantTypes:  []string{"dragEnter", "dragOver", "drop"},
+		},
+		{
+			name:    "missing_file",
+			files:   []string{filepath.Join(dir, "missing.txt")},
+			wantErr: true,
+		},
+		{
+			name:       "element_not_found",
+			files:      []string{file},
+			evalResult: notFound,
+			wantErr:    true,
+		},
+		{
+			name:       "drag_event_error",
+			files:      []string{file},
+			evalResult: center,
+			failDrag:   true,
+			wantTypes:  []string{"dragEnter"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+			b.Respond("Runtime.evaluate", tt.evalResult)
+			if tt.failDrag {
+				b.Fail("Input.dispatchDragEvent", "failed")
+			}
+
+			err := DropFiles(ctx, `#drop "zone"`, tt.files...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DropFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if evals := b.Calls("Runtime.evaluate"); len(evals) == 1 {
+				e := &runtime.Evaluate{}
+				if err := json.Unmarshal(evals[0].Params, e); err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(e.Expression, `document.querySelector("#drop \"zone\"")`) {
+					t.Errorf("DropFiles() expression doesn't quote the selector: %s", e.Expression)
+				}
+			}
+
+			var gotTypes []string
+			for _, c := range b.Calls("Input.dispatchDragEvent") {
+				e := &DispatchDragEvent{}
+				if err := json.Unmarshal(c.Params, e); err != nil {
+					t.Fatal(err)
+				}
+				gotTypes = append(gotTypes, e.Type)
+				want := &DispatchDragEvent{Type: e.Type, X: 10.5, Y: 20, Data: DragData{
+					Items:              []DragDataItem{},
+					Files:              []string{file},
+					DragOperationsMask: DragOperationCopy,
+				}}
+				if diff := cmp.Diff(want, e); diff != "" {
+					t.Errorf("DropFiles() %s event mismatch (-want +got):\n%s", e.Type, diff)
+				}
+			}
+			if diff := cmp.Diff(tt.wantTypes, gotTypes); diff != "" {
+				t.Errorf("DropFiles() event types mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package input
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// Bits of the `buttons` parameter of the `Input.dispatchMouseEvent` CDP
+// command, which indicates all the currently-pressed mouse buttons.
+var buttonBits = map[MouseButton]int64{
+	MouseButtonLeft:    1,
+	MouseButtonRight:   2,
+	MouseButtonMiddle:  4,
+	MouseButtonBack:    8,
+	MouseButtonForward: 16,
+}
+
+// Mouse simulates a stateful pointing device, which keeps track of its
+// position and pressed buttons between events, so that every event it
+// dispatches reports them consistently, like a real device. This matters
+// in pages which track drags and pointer movement themselves, e.g. games
+// in a canvas element.
+//
+// The browser reports the difference between the positions of consecutive
+// events as the `movementX` and `movementY` properties of DOM mouse events.
+// These are the raw deltas which pages use when the pointer is locked (see
+// `input.RequestPointerLock`), in which case `clientX` and `clientY` don't
+// change.
+type Mouse struct {
+	X, Y float64
+	// Bit field of pressed modifier keys, see `DispatchMouseEvent.SetModifiers`.
+	Modifiers int64
+	// Pointer type, "mouse" (default) or "pen".
+	PointerType string
+
+	buttons int64
+}
+
+// Movement is a relative movement of a `input.Mouse`, in CSS pixels.
+type Movement struct {
+	DX, DY float64
+}
+
+// NewMouse returns a new simulated mouse, initially
+// positioned at the given coordinates, with no pressed buttons.
+func NewMouse(x, y float64) *Mouse {
+	return &Mouse{X: x, Y: y, PointerType: "mouse"}
+}
+
+// Buttons returns the bit field of the currently-pressed
+// buttons: Left=1, Right=2, Middle=4, Back=8, Forward=16.
+func (m *Mouse) Buttons() int64 {
+	return m.buttons
+}
+
+// MoveTo moves the mouse to the given coordinates, in CSS pixels
+// relative to the main frame's viewport, with a single event.
+func (m *Mouse) MoveTo(ctx context.Context, x, y float64) error {
+	m.X, m.Y = x, y
+	return m.dispatch(ctx, "mouseMoved", MouseButtonNone, 0)
+}
+
+// MoveBy moves the mouse relative to its current position, with a single event.
+func (m *Mouse) MoveBy(ctx context.Context, dx, dy float64) error {
+	return m.MoveTo(ctx, m.X+dx, m.Y+dy)
+}
+
+// Stream dispatches a high-frequency stream of relative movements, one event
+// per movement, with the given interval between them (e.g. 16ms to match a
+// 60Hz display). The buttons which are pressed at the time of the call stay
+// pressed throughout the stream, so this is also suitable for drags. It
+// returns early with the context's error if the context is done first.
+func (m *Mouse) Stream(ctx context.Context, movements []Movement, interval time.Duration) error {
+	for i, mv := range movements {
+		if i > 0 && interval > 0 {
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		if err := m.MoveBy(ctx, mv.DX, mv.DY); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Down presses the given mouse button at the current position.
+func (m *Mouse) Down(ctx context.Context, b MouseButton) error {
+	m.buttons = pressButton(m.buttons, b)
+	return m.dispatch(ctx, "mousePressed", b, 1)
+}
+
+// Up releases the given mouse button at the current position.
+func (m *Mouse) Up(ctx context.Context, b MouseButton) error {
+	m.buttons = releaseButton(m.buttons, b)
+	return m.dispatch(ctx, "mouseReleased", b, 1)
+}
+
+func (m *Mouse) dispatch(ctx context.Context, t string, b MouseButton, clickCount int64) error {
+	e := NewDispatchMouseEvent(t, m.X, m.Y).SetButton(b).SetButtons(m.buttons).SetModifiers(m.Modifiers)
+	if clickCount > 0 {
+		e.SetClickCount(clickCount)
+	}
+	if m.PointerType != "" {
+		e.SetPointerType(m.PointerType)
+	}
+	return e.Do(ctx)
+}
+
+func pressButton(buttons int64, b MouseButton) int64 {
+	return buttons | buttonBits[b]
+}
+
+func releaseButton(buttons int64, b MouseButton) int64 {
+	return buttons &^ buttonBits[b]
+}
+
+// RequestPointerLock locks the pointer to the first element which matches
+// the given CSS selector in the main frame of the page associated with the
+// given context, as if the user clicked on it to start a game. The
+// request is sent with a simulated user gesture, which browsers require.
+func RequestPointerLock(ctx context.Context, selector string) error {
+	s, err := json.Marshal(selector)
+	if err != nil {
+		return err
+	}
+	expr := fmt.Sprintf(`(async () => {
+		const e = document.querySelector(%s);
+		if (!e) {
+			throw new Error("no element matches the selector");
+		}
+		await e.requestPointerLock();
+	})()`, s)
+	return evalWithUserGesture(ctx, expr)
+}
+
+// ExitPointerLock releases the pointer lock, if there is
+// one, in the page associated with the given context.
+func ExitPointerLock(ctx context.Context) error {
+	return evalWithUserGesture(ctx, "document.exitPointerLock()")
+}
+
+func evalWithUserGesture(ctx context.Context, expr string) error {
+	r, err := runtime.NewEvaluate(expr).SetUserGesture(true).SetAwaitPromise(true).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if r.ExceptionDetails != nil {
+		return r.ExceptionDetails
+	}
+	return nil
+}
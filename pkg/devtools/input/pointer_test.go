@@ -0,0 +1,20 @@
+package input
+
+import "testing"
+
+func TestButtons(t *testing.T) {
+	// Set up.
+	b := pressButton(0, MouseButtonLeft)
+	b = pressButton(b, MouseButtonMiddle)
+	b = pressButton(b, MouseButtonLeft)
+	// Test.
+	if b != 5 {
+		t.Errorf("pressButton(left, middle, left) = %d, want 5", b)
+	}
+	if b = releaseButton(b, MouseButtonLeft); b != 4 {
+		t.Errorf("releaseButton(left) = %d, want 4", b)
+	}
+	if b = releaseButton(b, MouseButtonNone); b != 4 {
+		t.Errorf("releaseButton(none) = %d, want 4", b)
+	}
+}
@@ -0,0 +1,245 @@
+package input
+
+import (
+	"context"
+	"fmt"
+	goruntime "runtime"
+	"strings"
+)
+
+// Bits of the `modifiers` parameter of the `Input.dispatchKeyEvent` and
+// `Input.dispatchMouseEvent` CDP commands.
+const (
+	ModifierAlt   int64 = 1
+	ModifierCtrl  int64 = 2
+	ModifierMeta  int64 = 4
+	ModifierShift int64 = 8
+)
+
+// Common keyboard shortcuts, for the `input.Shortcut` function. "Mod" is
+// the platform's primary modifier: Command on macOS, Ctrl elsewhere.
+const (
+	ShortcutSelectAll  = "Mod+A"
+	ShortcutCopy       = "Mod+C"
+	ShortcutCut        = "Mod+X"
+	ShortcutPaste      = "Mod+V"
+	ShortcutUndo       = "Mod+Z"
+	ShortcutRedo       = "Mod+Shift+Z"
+	ShortcutReload     = "Mod+R"
+	ShortcutHardReload = "Mod+Shift+R"
+)
+
+// Chord is a parsed keyboard shortcut, see the `input.ParseShortcut` function.
+type Chord struct {
+	// Bit field of the modifier keys: Alt=1, Ctrl=2, Meta/Command=4, Shift=8.
+	Modifiers int64
+	// DOM `KeyboardEvent.key` and `KeyboardEvent.code` values of the main key.
+	Key, Code string
+	// Windows virtual key code of the main key.
+	KeyCode int64
+	// Editing commands which the browser should perform, because it doesn't
+	// map this shortcut to them by itself (only on macOS, where this is done
+	// by the OS), e.g. "selectAll", "copy" and "paste".
+	Commands []string
+}
+
+type keyDefinition struct {
+	key, code string
+	keyCode   int64
+}
+
+type modifierKey struct {
+	bit int64
+	def keyDefinition
+}
+
+var modifierKeys = []modifierKey{
+	{ModifierCtrl, keyDefinition{"Control", "ControlLeft", 17}},
+	{ModifierMeta, keyDefinition{"Meta", "MetaLeft", 91}},
+	{ModifierAlt, keyDefinition{"Alt", "AltLeft", 18}},
+	{ModifierShift, keyDefinition{"Shift", "ShiftLeft", 16}},
+}
+
+var modifierNames = map[string]int64{
+	"ctrl":    ModifierCtrl,
+	"control": ModifierCtrl,
+	"shift":   ModifierShift,
+	"alt":     ModifierAlt,
+	"option":  ModifierAlt,
+	"opt":     ModifierAlt,
+	"meta":    ModifierMeta,
+	"cmd":     ModifierMeta,
+	"command": ModifierMeta,
+	"super":   ModifierMeta,
+	"win":     ModifierMeta,
+}
+
+var namedKeys = map[string]keyDefinition{
+	"enter":      {"Enter", "Enter", 13},
+	"return":     {"Enter", "Enter", 13},
+	"tab":        {"Tab", "Tab", 9},
+	"escape":     {"Escape", "Escape", 27},
+	"esc":        {"Escape", "Escape", 27},
+	"backspace":  {"Backspace", "Backspace", 8},
+	"delete":     {"Delete", "Delete", 46},
+	"del":        {"Delete", "Delete", 46},
+	"insert":     {"Insert", "Insert", 45},
+	"space":      {" ", "Space", 32},
+	"plus":       {"+", "NumpadAdd", 107},
+	"+":          {"+", "NumpadAdd", 107},
+	"home":       {"Home", "Home", 36},
+	"end":        {"End", "End", 35},
+	"pageup":     {"PageUp", "PageUp", 33},
+	"pagedown":   {"PageDown", "PageDown", 34},
+	"arrowup":    {"ArrowUp", "ArrowUp", 38},
+	"up":         {"ArrowUp", "ArrowUp", 38},
+	"arrowdown":  {"ArrowDown", "ArrowDown", 40},
+	"down":       {"ArrowDown", "ArrowDown", 40},
+	"arrowleft":  {"ArrowLeft", "ArrowLeft", 37},
+	"left":       {"ArrowLeft", "ArrowLeft", 37},
+	"arrowright": {"ArrowRight", "ArrowRight", 39},
+	"right":      {"ArrowRight", "ArrowRight", 39},
+}
+
+// Editing commands which macOS maps to Command-based shortcuts.
+var macCommands = map[string]string{
+	"KeyA":       "selectAll",
+	"KeyC":       "copy",
+	"KeyX":       "cut",
+	"KeyV":       "paste",
+	"KeyZ":       "undo",
+	"Shift+KeyZ": "redo",
+}
+
+// ParseShortcut parses a human-readable keyboard shortcut, e.g. "Ctrl+Shift+R",
+// "Mod+A" or "Alt+F4", for the platform which this program runs on (so "Mod"
+// means Command on macOS, and Ctrl elsewhere). Modifier and key names are
+// case-insensitive. The last part of the shortcut is the main key: a letter,
+// a digit, a function key (F1-F24), or a named key such as "Enter", "Tab",
+// "Esc", "Space" or "ArrowUp". The "+" key is written either as "Plus"
+// (e.g. "Ctrl+Plus") or as a trailing "+" (e.g. "Ctrl++").
+func ParseShortcut(s string) (*Chord, error) {
+	return parseShortcut(s, goruntime.GOOS)
+}
+
+func parseShortcut(s, goos string) (*Chord, error) {
+	parts := strings.Split(s, "+")
+	// A trailing "+" is the main key, not a separator.
+	if n := len(parts); n > 1 && strings.TrimSpace(parts[n-1]) == "" && strings.TrimSpace(parts[n-2]) == "" {
+		parts = append(parts[:n-2], "+")
+	}
+	c := &Chord{}
+	for _, p := range parts[:len(parts)-1] {
+		name := strings.ToLower(strings.TrimSpace(p))
+		if name == "mod" || name == "cmdorctrl" {
+			if goos == "darwin" {
+				c.Modifiers |= ModifierMeta
+			} else {
+				c.Modifiers |= ModifierCtrl
+			}
+			continue
+		}
+		bit, ok := modifierNames[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid modifier %q in keyboard shortcut %q", p, s)
+		}
+		c.Modifiers |= bit
+	}
+
+	def, err := parseKey(strings.TrimSpace(parts[len(parts)-1]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid key in keyboard shortcut %q: %v", s, err)
+	}
+	c.Key, c.Code, c.KeyCode = def.key, def.code, def.keyCode
+	if len(c.Key) == 1 && c.Key != " " && c.Modifiers&ModifierShift == 0 {
+		c.Key = strings.ToLower(c.Key)
+	}
+
+	if goos == "darwin" && c.Modifiers&^ModifierShift == ModifierMeta {
+		code := c.Code
+		if c.Modifiers&ModifierShift != 0 {
+			code = "Shift+" + code
+		}
+		if cmd, ok := macCommands[code]; ok {
+			c.Commands = []string{cmd}
+		}
+	}
+	return c, nil
+}
+
+func parseKey(k string) (keyDefinition, error) {
+	if def, ok := namedKeys[strings.ToLower(k)]; ok {
+		return def, nil
+	}
+	if len(k) == 1 {
+		switch r := strings.ToUpper(k)[0]; {
+		case r >= 'A' && r <= 'Z':
+			return keyDefinition{string(r), "Key" + string(r), int64(r)}, nil
+		case r >= '0' && r <= '9':
+			return keyDefinition{string(r), "Digit" + string(r), int64(r)}, nil
+		}
+	}
+	n := 0
+	if _, err := fmt.Sscanf(strings.ToUpper(k), "F%d", &n); err == nil && n >= 1 && n <= 24 {
+		name := fmt.Sprintf("F%d", n)
+		return keyDefinition{name, name, int64(111 + n)}, nil
+	}
+	return keyDefinition{}, fmt.Errorf("unknown key %q", k)
+}
+
+// Shortcut presses and releases a keyboard shortcut (see the
+// `input.ParseShortcut` function for its syntax) in the page associated
+// with the given context: it presses the modifier keys one by one, then
+// the main key, and releases them all in reverse order.
+//
+// Browser-level shortcuts (e.g. reload) reach the page's event handlers,
+// but the browser doesn't necessarily act on them, especially in headless
+// mode. Use the corresponding CDP commands (e.g. `page.NewReload`) for that.
+func Shortcut(ctx context.Context, s string) error {
+	c, err := ParseShortcut(s)
+	if err != nil {
+		return err
+	}
+
+	var pressed []modifierKey
+	modifiers := int64(0)
+	for _, m := range modifierKeys {
+		if c.Modifiers&m.bit == 0 {
+			continue
+		}
+		modifiers |= m.bit
+		if err := dispatchKey("rawKeyDown", m.def, modifiers).Do(ctx); err != nil {
+			return err
+		}
+		pressed = append(pressed, m)
+	}
+
+	main := keyDefinition{c.Key, c.Code, c.KeyCode}
+	down := dispatchKey("rawKeyDown", main, modifiers)
+	if len(c.Key) == 1 && modifiers&^ModifierShift == 0 {
+		down.Type = "keyDown"
+		down.SetText(c.Key).SetUnmodifiedText(c.Key)
+	}
+	if len(c.Commands) > 0 {
+		down.SetCommands(c.Commands)
+	}
+	if err := down.Do(ctx); err != nil {
+		return err
+	}
+	if err := dispatchKey("keyUp", main, modifiers).Do(ctx); err != nil {
+		return err
+	}
+
+	for i := len(pressed) - 1; i >= 0; i-- {
+		modifiers &^= pressed[i].bit
+		if err := dispatchKey("keyUp", pressed[i].def, modifiers).Do(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func dispatchKey(t string, def keyDefinition, modifiers int64) *DispatchKeyEvent {
+	return NewDispatchKeyEvent(t).SetKey(def.key).SetCode(def.code).
+		SetWindowsVirtualKeyCode(def.keyCode).SetModifiers(modifiers)
+}
@@ -0,0 +1,44 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseShortcut(t *testing.T) {
+	tests := []struct {
+		s, goos string
+		want    *Chord
+	}{
+		{"Ctrl+Shift+R", "linux", &Chord{Modifiers: ModifierCtrl | ModifierShift, Key: "R", Code: "KeyR", KeyCode: 82}},
+		{"mod+a", "linux", &Chord{Modifiers: ModifierCtrl, Key: "a", Code: "KeyA", KeyCode: 65}},
+		{"Mod+A", "darwin", &Chord{Modifiers: ModifierMeta, Key: "a", Code: "KeyA", KeyCode: 65, Commands: []string{"selectAll"}}},
+		{"Cmd+Shift+Z", "darwin", &Chord{Modifiers: ModifierMeta | ModifierShift, Key: "Z", Code: "KeyZ", KeyCode: 90, Commands: []string{"redo"}}},
+		{"Alt+F4", "windows", &Chord{Modifiers: ModifierAlt, Key: "F4", Code: "F4", KeyCode: 115}},
+		{"Shift + Tab", "linux", &Chord{Modifiers: ModifierShift, Key: "Tab", Code: "Tab", KeyCode: 9}},
+		{"Esc", "linux", &Chord{Key: "Escape", Code: "Escape", KeyCode: 27}},
+		{"Ctrl+1", "linux", &Chord{Modifiers: ModifierCtrl, Key: "1", Code: "Digit1", KeyCode: 49}},
+		{"Ctrl++", "linux", &Chord{Modifiers: ModifierCtrl, Key: "+", Code: "NumpadAdd", KeyCode: 107}},
+		{"Ctrl + +", "linux", &Chord{Modifiers: ModifierCtrl, Key: "+", Code: "NumpadAdd", KeyCode: 107}},
+		{"Shift+Plus", "linux", &Chord{Modifiers: ModifierShift, Key: "+", Code: "NumpadAdd", KeyCode: 107}},
+		{"+", "linux", &Chord{Key: "+", Code: "NumpadAdd", KeyCode: 107}},
+	}
+	for _, tt := range tests {
+		got, err := parseShortcut(tt.s, tt.goos)
+		if err != nil {
+			t.Errorf("parseShortcut(%q, %q) error: %v", tt.s, tt.goos, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseShortcut(%q, %q) = %+v, want %+v", tt.s, tt.goos, got, tt.want)
+		}
+	}
+}
+
+func TestParseShortcutErrors(t *testing.T) {
+	for _, s := range []string{"", "Hyper+A", "Ctrl+F25", "Ctrl+Foo", "Ctrl+", "++", "Ctrl+++"} {
+		if _, err := parseShortcut(s, "linux"); err == nil {
+			t.Errorf("parseShortcut(%q) error = nil, want an error", s)
+		}
+	}
+}
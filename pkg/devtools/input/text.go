@@ -0,0 +1,94 @@
+package input
+
+import (
+	"context"
+	"unicode"
+	"unicode/utf16"
+)
+
+// TypeText types the given text in the focused element of the page associated
+// with the given context. Printable ASCII characters are typed with key
+// events, like a physical US keyboard would. Other characters (e.g. CJK,
+// accented letters and emoji sequences) are inserted one user-perceived
+// character at a time with the `Input.insertText` CDP command, because they
+// don't correspond to a single key, and splitting them into separate key
+// events mangles multi-byte and multi-rune characters.
+func TypeText(ctx context.Context, text string) error {
+	for _, c := range splitCharacters(text) {
+		r := []rune(c)
+		if len(r) == 1 && r[0] <= unicode.MaxASCII && (unicode.IsPrint(r[0]) || r[0] == '\r') {
+			if err := NewDispatchKeyEvent("keyDown").SetText(c).Do(ctx); err != nil {
+				return err
+			}
+			if err := NewDispatchKeyEvent("keyUp").Do(ctx); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := NewInsertText(c).Do(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ComposeText simulates an Input Method Editor (IME) composition session in
+// the focused element of the page associated with the given context: it
+// shows each of the given intermediate composition strings (e.g. "に",
+// "にほ", "にほん" while typing Japanese phonetically), with the caret at the
+// end, and then commits the final text (e.g. "日本語"), which replaces them.
+//
+// Unlike `input.TypeText`, this triggers the "compositionstart",
+// "compositionupdate" and "compositionend" DOM events, which
+// some web applications rely on for non-Latin text input.
+func ComposeText(ctx context.Context, final string, intermediate ...string) error {
+	for _, s := range intermediate {
+		n := utf16Len(s)
+		if err := NewImeSetComposition(s, n, n).Do(ctx); err != nil {
+			return err
+		}
+	}
+	// Inserting text during an active composition commits it.
+	return NewInsertText(final).Do(ctx)
+}
+
+// Return the length of the given string in UTF-16 code units, which is
+// how JavaScript (and therefore the CDP) measures string offsets.
+func utf16Len(s string) int64 {
+	return int64(len(utf16.Encode([]rune(s))))
+}
+
+// Split the given text into user-perceived characters, i.e. an approximation
+// of extended grapheme clusters (https://unicode.org/reports/tr29/) which
+// keeps combining marks, emoji modifiers and sequences, variation selectors,
+// and flags together with their base characters.
+func splitCharacters(text string) []string {
+	var result []string
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		for j < len(runes) {
+			r := runes[j]
+			switch {
+			case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc):
+				j++ // Combining mark.
+			case r >= 0xFE00 && r <= 0xFE0F, r >= 0x1F3FB && r <= 0x1F3FF, r >= 0xE0020 && r <= 0xE007F:
+				j++ // Variation selector, emoji skin tone modifier, or emoji tag.
+			case r == 0x200D && j+1 < len(runes):
+				j += 2 // Zero-width joiner, and the next character.
+			case isRegionalIndicator(r) && isRegionalIndicator(runes[j-1]) && j-i == 1:
+				j++ // Second half of a flag.
+			default:
+				goto split
+			}
+		}
+	split:
+		result = append(result, string(runes[i:j]))
+		i = j
+	}
+	return result
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
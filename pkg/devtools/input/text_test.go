@@ -0,0 +1,43 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitCharacters(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", nil},
+		{"ab", []string{"a", "b"}},
+		{"日本語", []string{"日", "本", "語"}},
+		{"e\u0301x", []string{"e\u0301", "x"}},
+		{"👍🏽!", []string{"👍🏽", "!"}},
+		{"👩‍💻a", []string{"👩‍💻", "a"}},
+		{"🇮🇱🇺🇸", []string{"🇮🇱", "🇺🇸"}},
+		{"❤️", []string{"❤️"}},
+	}
+	for _, tt := range tests {
+		if got := splitCharacters(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitCharacters(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestUTF16Len(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int64
+	}{
+		{"abc", 3},
+		{"にほん", 3},
+		{"😀", 2},
+	}
+	for _, tt := range tests {
+		if got := utf16Len(tt.s); got != tt.want {
+			t.Errorf("utf16Len(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
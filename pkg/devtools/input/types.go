@@ -1,5 +1,7 @@
 package input
 
+import "github.com/daabr/chrome-vision/pkg/devtools"
+
 // TouchPoint data type.
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Input/#type-TouchPoint
@@ -10,13 +12,13 @@ type TouchPoint struct {
 	// the top of the viewport and Y increases as it proceeds towards the bottom of the viewport.
 	Y float64 `json:"y"`
 	// X radius of the touch area (default: 1.0).
-	RadiusX float64 `json:"radiusX,omitempty"`
+	RadiusX *float64 `json:"radiusX,omitempty"`
 	// Y radius of the touch area (default: 1.0).
-	RadiusY float64 `json:"radiusY,omitempty"`
+	RadiusY *float64 `json:"radiusY,omitempty"`
 	// Rotation angle (default: 0.0).
 	RotationAngle float64 `json:"rotationAngle,omitempty"`
 	// Force (default: 1.0).
-	Force float64 `json:"force,omitempty"`
+	Force *float64 `json:"force,omitempty"`
 	// The normalized tangential pressure, which has a range of [-1,1] (default: 0).
 	//
 	// This CDP property is experimental.
@@ -79,7 +81,7 @@ func (t MouseButton) String() string {
 // TimeSinceEpoch data type. UTC time in seconds, counted from January 1, 1970.
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Input/#type-TimeSinceEpoch
-type TimeSinceEpoch float64
+type TimeSinceEpoch = devtools.TimeSinceEpoch
 
 // DragDataItem data type.
 //
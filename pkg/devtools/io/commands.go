@@ -84,15 +84,25 @@ type Read struct {
 
 // NewRead constructs a new Read struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ReadOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/IO/#method-read
-func NewRead(handle string) *Read {
-	return &Read{
+func NewRead(handle string, opts ...ReadOption) *Read {
+	c := &Read{
 		Handle: handle,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ReadOption is a functional option for the optional
+// parameters of the Read CDP command, see the
+// NewRead constructor. Options are applied in order.
+type ReadOption = func(*Read)
+
 // SetOffset adds or modifies the value of the optional
 // parameter `offset` in the Read CDP command.
 //
@@ -103,6 +113,14 @@ func (t *Read) SetOffset(v int64) *Read {
 	return t
 }
 
+// ReadWithOffset is a functional option which sets the value of the
+// optional parameter `offset` in the Read CDP command, see SetOffset.
+func ReadWithOffset(v int64) ReadOption {
+	return func(c *Read) {
+		c.SetOffset(v)
+	}
+}
+
 // SetSize adds or modifies the value of the optional
 // parameter `size` in the Read CDP command.
 //
@@ -112,6 +130,14 @@ func (t *Read) SetSize(v int64) *Read {
 	return t
 }
 
+// ReadWithSize is a functional option which sets the value of the
+// optional parameter `size` in the Read CDP command, see SetSize.
+func ReadWithSize(v int64) ReadOption {
+	return func(c *Read) {
+		c.SetSize(v)
+	}
+}
+
 // ReadResult contains the browser's response
 // to calling the Read CDP command with Do().
 type ReadResult struct {
@@ -0,0 +1,98 @@
+package io
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"os"
+)
+
+// ChunkSize is the maximum number of bytes which readers of CDP streams
+// request in each `IO.read` command, to keep responses well below the
+// maximum size of CDP messages (`devtools.DefaultMaxMessageSize`), even
+// after base64 encoding.
+const ChunkSize = 1 << 20
+
+// streamReader reads a CDP stream in chunks, see `io.OpenReader`.
+type streamReader struct {
+	ctx    context.Context
+	handle string
+	buf    []byte // Unread data of the last chunk.
+	eof    bool
+	closed bool
+}
+
+// OpenReader returns a reader of the given CDP stream, e.g. a PDF document
+// (`page.PrintToPDFResult.Stream`), a trace, or a response body, which sends
+// `IO.read` commands as needed, and decodes base64-encoded data. Closing it
+// sends an `IO.close` command, to release the stream in the browser, so
+// it must be closed even after reading all of it. It's not safe for
+// concurrent use.
+func OpenReader(ctx context.Context, handle string) io.ReadCloser {
+	return &streamReader{ctx: ctx, handle: handle}
+}
+
+func (r *streamReader) Read(p []byte) (int, error) {
+	if r.closed {
+		return 0, os.ErrClosed
+	}
+	for len(r.buf) == 0 {
+		if r.eof {
+			return 0, io.EOF
+		}
+		if err := r.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *streamReader) readChunk() error {
+	result, err := NewRead(r.handle, ReadWithSize(ChunkSize)).Do(r.ctx)
+	if err != nil {
+		return err
+	}
+	r.eof = result.EOF
+	if !result.Base64Encoded {
+		r.buf = []byte(result.Data)
+		return nil
+	}
+	r.buf, err = base64.StdEncoding.DecodeString(result.Data)
+	return err
+}
+
+func (r *streamReader) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	r.buf = nil
+	return NewClose(r.handle).Do(r.ctx)
+}
+
+// CopyToFile reads the given CDP stream (see `io.OpenReader`) into a new
+// file in the given path, or overwrites it if it already exists, and closes
+// the stream. It returns the number of bytes written. If reading fails,
+// the partial file is removed.
+func CopyToFile(ctx context.Context, handle, path string) (int64, error) {
+	r := OpenReader(ctx, handle)
+	defer r.Close()
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(f, r)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = r.Close()
+	}
+	if err != nil {
+		os.Remove(path)
+		return 0, err
+	}
+	return n, nil
+}
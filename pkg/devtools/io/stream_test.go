@@ -0,0 +1,80 @@
+package io_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	cdpio "github.com/daabr/chrome-vision/pkg/devtools/io"
+)
+
+// Serve a stream in 2 chunks: base64-encoded, and then plain text.
+func fakeStream(b *devtoolstest.Browser) {
+	chunks := []*cdpio.ReadResult{
+		{Base64Encoded: true, Data: "aGVsbG8g"},
+		{Data: "world", EOF: true},
+	}
+	b.Handle("IO.read", func(json.RawMessage) (interface{}, error) {
+		r := chunks[0]
+		if len(chunks) > 1 {
+			chunks = chunks[1:]
+		}
+		return r, nil
+	})
+}
+
+func TestOpenReader(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	fakeStream(b)
+	r := cdpio.OpenReader(ctx, "stream")
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("io.ReadAll(); got error: %v", err)
+	}
+	if string(got) != "hello world" {
+		t.Errorf("io.ReadAll() = %q, want %q", got, "hello world")
+	}
+	if err := r.Close(); err != nil {
+		t.Errorf("Close(); got error: %v", err)
+	}
+	if n := len(b.Calls("IO.read")); n != 2 {
+		t.Errorf("IO.read calls = %d, want 2", n)
+	}
+	calls := b.Calls("IO.close")
+	if len(calls) != 1 || string(calls[0].Params) != `{"handle":"stream"}` {
+		t.Errorf("IO.close calls = %+v", calls)
+	}
+	if _, err := r.Read(make([]byte, 1)); err == nil {
+		t.Error("Read() after Close(); got nil error")
+	}
+}
+
+func TestCopyToFile(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	fakeStream(b)
+	path := filepath.Join(t.TempDir(), "out.txt")
+	n, err := cdpio.CopyToFile(ctx, "stream", path)
+	if err != nil {
+		t.Fatalf("CopyToFile(); got error: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 11 || string(got) != "hello world" {
+		t.Errorf("CopyToFile() = %d, %q; want 11, %q", n, got, "hello world")
+	}
+
+	b.Fail("IO.read", "invalid stream")
+	path = filepath.Join(t.TempDir(), "failed.txt")
+	if _, err := cdpio.CopyToFile(ctx, "stream", path); err == nil {
+		t.Error("CopyToFile() with invalid stream; got nil error")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("partial file %s wasn't removed: %v", path, err)
+	}
+}
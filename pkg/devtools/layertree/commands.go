@@ -322,15 +322,25 @@ type ProfileSnapshot struct {
 
 // NewProfileSnapshot constructs a new ProfileSnapshot struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ProfileSnapshotOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/LayerTree/#method-profileSnapshot
-func NewProfileSnapshot(snapshotID string) *ProfileSnapshot {
-	return &ProfileSnapshot{
+func NewProfileSnapshot(snapshotID string, opts ...ProfileSnapshotOption) *ProfileSnapshot {
+	c := &ProfileSnapshot{
 		SnapshotID: snapshotID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ProfileSnapshotOption is a functional option for the optional
+// parameters of the ProfileSnapshot CDP command, see the
+// NewProfileSnapshot constructor. Options are applied in order.
+type ProfileSnapshotOption = func(*ProfileSnapshot)
+
 // SetMinRepeatCount adds or modifies the value of the optional
 // parameter `minRepeatCount` in the ProfileSnapshot CDP command.
 //
@@ -340,6 +350,14 @@ func (t *ProfileSnapshot) SetMinRepeatCount(v int64) *ProfileSnapshot {
 	return t
 }
 
+// ProfileSnapshotWithMinRepeatCount is a functional option which sets the value of the
+// optional parameter `minRepeatCount` in the ProfileSnapshot CDP command, see SetMinRepeatCount.
+func ProfileSnapshotWithMinRepeatCount(v int64) ProfileSnapshotOption {
+	return func(c *ProfileSnapshot) {
+		c.SetMinRepeatCount(v)
+	}
+}
+
 // SetMinDuration adds or modifies the value of the optional
 // parameter `minDuration` in the ProfileSnapshot CDP command.
 //
@@ -349,6 +367,14 @@ func (t *ProfileSnapshot) SetMinDuration(v float64) *ProfileSnapshot {
 	return t
 }
 
+// ProfileSnapshotWithMinDuration is a functional option which sets the value of the
+// optional parameter `minDuration` in the ProfileSnapshot CDP command, see SetMinDuration.
+func ProfileSnapshotWithMinDuration(v float64) ProfileSnapshotOption {
+	return func(c *ProfileSnapshot) {
+		c.SetMinDuration(v)
+	}
+}
+
 // SetClipRect adds or modifies the value of the optional
 // parameter `clipRect` in the ProfileSnapshot CDP command.
 //
@@ -358,6 +384,14 @@ func (t *ProfileSnapshot) SetClipRect(v dom.Rect) *ProfileSnapshot {
 	return t
 }
 
+// ProfileSnapshotWithClipRect is a functional option which sets the value of the
+// optional parameter `clipRect` in the ProfileSnapshot CDP command, see SetClipRect.
+func ProfileSnapshotWithClipRect(v dom.Rect) ProfileSnapshotOption {
+	return func(c *ProfileSnapshot) {
+		c.SetClipRect(v)
+	}
+}
+
 // ProfileSnapshotResult contains the browser's response
 // to calling the ProfileSnapshot CDP command with Do().
 type ProfileSnapshotResult struct {
@@ -475,20 +509,30 @@ type ReplaySnapshot struct {
 	// The last step to replay to (replay till the end if not specified).
 	ToStep int64 `json:"toStep,omitempty"`
 	// The scale to apply while replaying (defaults to 1).
-	Scale float64 `json:"scale,omitempty"`
+	Scale *float64 `json:"scale,omitempty"`
 }
 
 // NewReplaySnapshot constructs a new ReplaySnapshot struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ReplaySnapshotOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/LayerTree/#method-replaySnapshot
-func NewReplaySnapshot(snapshotID string) *ReplaySnapshot {
-	return &ReplaySnapshot{
+func NewReplaySnapshot(snapshotID string, opts ...ReplaySnapshotOption) *ReplaySnapshot {
+	c := &ReplaySnapshot{
 		SnapshotID: snapshotID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ReplaySnapshotOption is a functional option for the optional
+// parameters of the ReplaySnapshot CDP command, see the
+// NewReplaySnapshot constructor. Options are applied in order.
+type ReplaySnapshotOption = func(*ReplaySnapshot)
+
 // SetFromStep adds or modifies the value of the optional
 // parameter `fromStep` in the ReplaySnapshot CDP command.
 //
@@ -498,6 +542,14 @@ func (t *ReplaySnapshot) SetFromStep(v int64) *ReplaySnapshot {
 	return t
 }
 
+// ReplaySnapshotWithFromStep is a functional option which sets the value of the
+// optional parameter `fromStep` in the ReplaySnapshot CDP command, see SetFromStep.
+func ReplaySnapshotWithFromStep(v int64) ReplaySnapshotOption {
+	return func(c *ReplaySnapshot) {
+		c.SetFromStep(v)
+	}
+}
+
 // SetToStep adds or modifies the value of the optional
 // parameter `toStep` in the ReplaySnapshot CDP command.
 //
@@ -507,15 +559,31 @@ func (t *ReplaySnapshot) SetToStep(v int64) *ReplaySnapshot {
 	return t
 }
 
+// ReplaySnapshotWithToStep is a functional option which sets the value of the
+// optional parameter `toStep` in the ReplaySnapshot CDP command, see SetToStep.
+func ReplaySnapshotWithToStep(v int64) ReplaySnapshotOption {
+	return func(c *ReplaySnapshot) {
+		c.SetToStep(v)
+	}
+}
+
 // SetScale adds or modifies the value of the optional
 // parameter `scale` in the ReplaySnapshot CDP command.
 //
 // The scale to apply while replaying (defaults to 1).
 func (t *ReplaySnapshot) SetScale(v float64) *ReplaySnapshot {
-	t.Scale = v
+	t.Scale = &v
 	return t
 }
 
+// ReplaySnapshotWithScale is a functional option which sets the value of the
+// optional parameter `scale` in the ReplaySnapshot CDP command, see SetScale.
+func ReplaySnapshotWithScale(v float64) ReplaySnapshotOption {
+	return func(c *ReplaySnapshot) {
+		c.SetScale(v)
+	}
+}
+
 // ReplaySnapshotResult contains the browser's response
 // to calling the ReplaySnapshot CDP command with Do().
 type ReplaySnapshotResult struct {
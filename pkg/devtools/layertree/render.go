@@ -0,0 +1,185 @@
+package layertree
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/dom"
+)
+
+// LayerReasons describes why a layer was composited separately.
+type LayerReasons struct {
+	Layer Layer
+	// Human-readable compositing reasons, e.g. "Has a 3D transform".
+	Reasons []string
+	// Machine-readable compositing reason IDs, e.g. "3DTransform".
+	ReasonIDs []string
+}
+
+// PaintTiming is the result of profiling the painting of a layer.
+type PaintTiming struct {
+	LayerID string
+	// Duration of each replay of the layer's paint commands.
+	Replays []time.Duration
+}
+
+// Min returns the shortest replay duration, which is the
+// least noisy estimate of how long the layer takes to paint.
+func (t *PaintTiming) Min() time.Duration {
+	var shortest time.Duration
+	for i, d := range t.Replays {
+		if i == 0 || d < shortest {
+			shortest = d
+		}
+	}
+	return shortest
+}
+
+// Total returns the sum of the durations of all the paint commands in the
+// profile. Durations are reported by the browser in seconds.
+func (p PaintProfile) Total() time.Duration {
+	total := 0.0
+	for _, s := range p {
+		total += s
+	}
+	return time.Duration(total * float64(time.Second))
+}
+
+// How long `layertree.Layers` waits for the browser to report the layer tree.
+var layersTimeout = 10 * time.Second
+
+// Layers enables the LayerTree domain in the page associated with the given
+// context, and returns the current layer tree of the page, as reported by
+// the next `LayerTree.layerTreeDidChange` event with layers. The browser
+// reports the layer tree when the domain is enabled (even if it was enabled
+// already), and again whenever a new frame changes it. Events without
+// layers (e.g. before the first frame is composited) are skipped, and this
+// function returns an error if no layers are reported within 10 seconds.
+func Layers(ctx context.Context) ([]Layer, error) {
+	sub, err := devtools.Subscribe(ctx, "LayerTree.layerTreeDidChange")
+	if err != nil {
+		return nil, err
+	}
+	defer sub.Unsubscribe()
+	if err := NewEnable().Do(ctx); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(layersTimeout)
+	defer timer.Stop()
+	accept := devtools.EventsOfSession(ctx)
+	for {
+		select {
+		case m, ok := <-sub.C:
+			if !ok {
+				return nil, ctx.Err() // The subscription ended with the context.
+			}
+			e := &DidChange{}
+			if !accept(m) {
+				continue
+			}
+			if err := json.Unmarshal(m.Params, e); err != nil {
+				return nil, err
+			}
+			if e.Layers != nil {
+				return e.Layers, nil
+			}
+		case <-timer.C:
+			return nil, fmt.Errorf("layer tree wasn't reported within %v", layersTimeout)
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// WhyLayer answers the question "why is this element its own layer?": it
+// returns the compositing reasons of all the layers which belong to the
+// first element that matches the given selector (see `dom.Query`), in the
+// main frame of the page associated with the given context. The result is
+// empty if the element isn't composited separately.
+func WhyLayer(ctx context.Context, selector string) ([]LayerReasons, error) {
+	id, err := backendNodeID(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := Layers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []LayerReasons
+	for _, l := range layers {
+		if l.BackendNodeID != id {
+			continue
+		}
+		r, err := NewCompositingReasons(l.LayerID).Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, LayerReasons{Layer: l, Reasons: r.CompositingReasons, ReasonIDs: r.CompositingReasonIds})
+	}
+	return result, nil
+}
+
+// Return the backend node ID of the first element which matches the given
+// selector, because layers refer to DOM nodes only by backend node IDs.
+func backendNodeID(ctx context.Context, selector string) (int64, error) {
+	h, err := dom.Query(ctx, selector, dom.NoRequery())
+	if err != nil {
+		return 0, err
+	}
+	return h.BackendNodeID(ctx)
+}
+
+// ProfilePaint answers the question "how long did paints take?": it takes
+// a snapshot of the paint commands of the given layer, and replays it the
+// given number of times (at least once), measuring each replay.
+func ProfilePaint(ctx context.Context, layerID string, replays int) (*PaintTiming, error) {
+	s, err := NewMakeSnapshot(layerID).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer NewReleaseSnapshot(s.SnapshotID).Do(ctx)
+
+	p := NewProfileSnapshot(s.SnapshotID)
+	if replays > 1 {
+		p.SetMinRepeatCount(int64(replays))
+	}
+	r, err := p.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t := &PaintTiming{LayerID: layerID}
+	for _, profile := range r.Timings {
+		t.Replays = append(t.Replays, profile.Total())
+	}
+	return t, nil
+}
+
+// ProfilePaints profiles the painting of all the visible layers which
+// draw content in the page associated with the given context, see
+// `layertree.ProfilePaint`. Layers which can't be snapshotted
+// (e.g. because they were removed in the meantime) are skipped.
+func ProfilePaints(ctx context.Context, replays int) ([]*PaintTiming, error) {
+	layers, err := Layers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var result []*PaintTiming
+	for _, l := range layers {
+		if !l.DrawsContent || l.Invisible {
+			continue
+		}
+		t, err := ProfilePaint(ctx, l.LayerID, replays)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+		result = append(result, t)
+	}
+	return result, nil
+}
@@ -0,0 +1,91 @@
+package layertree
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPaintTiming(t *testing.T) {
+	// Set up.
+	pt := &PaintTiming{}
+	for _, p := range []PaintProfile{{0.001, 0.002}, {0.0005, 0.001}, {0.004}} {
+		pt.Replays = append(pt.Replays, p.Total())
+	}
+	// Test.
+	want := []time.Duration{3 * time.Millisecond, 1500 * time.Microsecond, 4 * time.Millisecond}
+	for i, d := range pt.Replays {
+		if diff := d - want[i]; diff < -time.Microsecond || diff > time.Microsecond {
+			t.Errorf("PaintProfile.Total() #%d = %v, want %v", i, d, want[i])
+		}
+	}
+	if got := pt.Min(); got != pt.Replays[1] {
+		t.Errorf("PaintTiming.Min() = %v, want %v", got, pt.Replays[1])
+	}
+}
+
+func TestLayers(t *testing.T) {
+	defer func(d time.Duration) { layersTimeout = d }(layersTimeout)
+	layersTimeout = 100 * time.Millisecond
+
+	layers := []Layer{{LayerID: "1", Width: 800, Height: 600}, {LayerID: "2", ParentLayerID: "1"}}
+	tests := []struct {
+		name    string
+		events  []*DidChange
+		want    []Layer
+		wantErr bool
+	}{
+		{
+			name:   "reported_on_enable",
+			events: []*DidChange{{Layers: layers}},
+			want:   layers,
+		},
+		{
+			name:   "skip_without_layers",
+			events: []*DidChange{{}, {Layers: layers}},
+			want:   layers,
+		},
+		{
+			name:    "not_reported",
+			wantErr: true,
+		},
+		{
+			name:    "never_composited",
+			events:  []*DidChange{{}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+			b.Handle("LayerTree.enable", func(json.RawMessage) (interface{}, error) {
+				// Another session's layer tree.
+				if err := devtools.DispatchEvent(ctx, &devtools.Message{SessionID: "other",
+					Method: "LayerTree.layerTreeDidChange", Params: json.RawMessage(`{"layers":[]}`)}); err != nil {
+					return nil, err
+				}
+				for _, e := range tt.events {
+					if err := b.Emit("LayerTree.layerTreeDidChange", e); err != nil {
+						return nil, err
+					}
+				}
+				return nil, nil
+			})
+
+			got, err := Layers(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Layers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Layers() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
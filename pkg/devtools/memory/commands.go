@@ -277,13 +277,23 @@ type StartSampling struct {
 
 // NewStartSampling constructs a new StartSampling struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `StartSamplingOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Memory/#method-startSampling
-func NewStartSampling() *StartSampling {
-	return &StartSampling{}
+func NewStartSampling(opts ...StartSamplingOption) *StartSampling {
+	c := &StartSampling{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// StartSamplingOption is a functional option for the optional
+// parameters of the StartSampling CDP command, see the
+// NewStartSampling constructor. Options are applied in order.
+type StartSamplingOption = func(*StartSampling)
+
 // SetSamplingInterval adds or modifies the value of the optional
 // parameter `samplingInterval` in the StartSampling CDP command.
 //
@@ -293,6 +303,14 @@ func (t *StartSampling) SetSamplingInterval(v int64) *StartSampling {
 	return t
 }
 
+// StartSamplingWithSamplingInterval is a functional option which sets the value of the
+// optional parameter `samplingInterval` in the StartSampling CDP command, see SetSamplingInterval.
+func StartSamplingWithSamplingInterval(v int64) StartSamplingOption {
+	return func(c *StartSampling) {
+		c.SetSamplingInterval(v)
+	}
+}
+
 // SetSuppressRandomness adds or modifies the value of the optional
 // parameter `suppressRandomness` in the StartSampling CDP command.
 //
@@ -302,6 +320,14 @@ func (t *StartSampling) SetSuppressRandomness(v bool) *StartSampling {
 	return t
 }
 
+// StartSamplingWithSuppressRandomness is a functional option which sets the value of the
+// optional parameter `suppressRandomness` in the StartSampling CDP command, see SetSuppressRandomness.
+func StartSamplingWithSuppressRandomness(v bool) StartSamplingOption {
+	return func(c *StartSampling) {
+		c.SetSuppressRandomness(v)
+	}
+}
+
 // Do sends the StartSampling CDP command to a browser,
 // and returns the browser's response.
 func (t *StartSampling) Do(ctx context.Context) error {
@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/dom"
+	"github.com/daabr/chrome-vision/pkg/devtools/heapprofiler"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// DOMCounters is a snapshot of the DOM counters of a page,
+// taken after forcing garbage collection.
+type DOMCounters struct {
+	GetDOMCountersResult
+	// Nodes which are attached to the main frame's document, including the
+	// documents of iframes in the same renderer process, shadow trees (also
+	// closed and user-agent ones, e.g. in <input> elements), template
+	// contents, and pseudo-elements.
+	AttachedNodes int64
+}
+
+// DetachedNodes returns the number of live DOM nodes which are not attached
+// to the page's documents (see `DOMCounters.AttachedNodes`), e.g. removed
+// elements which are still referenced by JavaScript. The browser counts
+// nodes per renderer process, so nodes of other pages in the same process
+// (e.g. same-site popups) are counted as detached too.
+func (c DOMCounters) DetachedNodes() int64 {
+	return c.Nodes - c.AttachedNodes
+}
+
+// LeakReport compares the DOM counters of a page before and after
+// running a scenario, as reported by the `memory.CheckLeaks` function.
+type LeakReport struct {
+	Before, After DOMCounters
+}
+
+// LeakLimits specifies the maximum allowed growth of DOM counters
+// in a `memory.LeakReport`. Zero values mean that no growth is allowed.
+type LeakLimits struct {
+	Documents, Nodes, DetachedNodes, JsEventListeners int64
+}
+
+// Check returns an error which describes all the DOM counters in
+// the report whose growth exceeds the given limits, or nil.
+func (r *LeakReport) Check(l LeakLimits) error {
+	var msgs []string
+	check := func(name string, before, after, limit int64) {
+		if after-before > limit {
+			msgs = append(msgs, fmt.Sprintf("%s: %d -> %d (+%d, limit %d)",
+				name, before, after, after-before, limit))
+		}
+	}
+	check("documents", r.Before.Documents, r.After.Documents, l.Documents)
+	check("nodes", r.Before.Nodes, r.After.Nodes, l.Nodes)
+	check("detached nodes", r.Before.DetachedNodes(), r.After.DetachedNodes(), l.DetachedNodes)
+	check("JS event listeners", r.Before.JsEventListeners, r.After.JsEventListeners, l.JsEventListeners)
+	if len(msgs) > 0 {
+		return fmt.Errorf("DOM leak detected: %s", strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+// String returns a human-readable summary of the report.
+func (r *LeakReport) String() string {
+	return fmt.Sprintf("documents %+d, nodes %+d, detached nodes %+d, JS event listeners %+d",
+		r.After.Documents-r.Before.Documents, r.After.Nodes-r.Before.Nodes,
+		r.After.DetachedNodes()-r.Before.DetachedNodes(),
+		r.After.JsEventListeners-r.Before.JsEventListeners)
+}
+
+// CheckLeaks is a leak-check harness, usable in tests: it prepares the page
+// associated with the given context for leak detection, records baseline
+// DOM counters, runs the given scenario, forces garbage collection, and
+// records the DOM counters again.
+//
+// Example:
+//
+//	r, err := memory.CheckLeaks(ctx, openAndCloseDialog)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	if err := r.Check(memory.LeakLimits{}); err != nil {
+//		t.Error(err)
+//	}
+func CheckLeaks(ctx context.Context, scenario func(context.Context) error) (*LeakReport, error) {
+	if err := NewPrepareForLeakDetection().Do(ctx); err != nil {
+		return nil, err
+	}
+	before, err := snapshotCounters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := scenario(ctx); err != nil {
+		return nil, fmt.Errorf("leak-check scenario error: %v", err)
+	}
+	after, err := snapshotCounters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &LeakReport{Before: *before, After: *after}, nil
+}
+
+// Force garbage collection and take a snapshot of the page's DOM counters.
+func snapshotCounters(ctx context.Context) (*DOMCounters, error) {
+	if err := NewForciblyPurgeJavaScriptMemory().Do(ctx); err != nil {
+		return nil, err
+	}
+	if err := heapprofiler.NewCollectGarbage().Do(ctx); err != nil {
+		return nil, err
+	}
+	c, err := NewGetDOMCounters().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &DOMCounters{GetDOMCountersResult: *c}
+	if result.AttachedNodes, err = countAttachedNodes(ctx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Count all the nodes which are attached to the main frame's document
+// (including the document itself), to distinguish them from detached ones.
+// The `DOM.describeNode` command (unlike `DOM.getDocument`) doesn't
+// invalidate the node IDs of other components.
+func countAttachedNodes(ctx context.Context) (int64, error) {
+	r, err := runtime.NewEvaluate("document").Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if r.ExceptionDetails != nil {
+		return 0, r.ExceptionDetails
+	}
+	defer runtime.NewReleaseObject(r.Result.ObjectID).Do(ctx)
+	d, err := dom.NewDescribeNode().SetObjectID(runtime.RemoteObjectID(r.Result.ObjectID)).
+		SetDepth(-1).SetPierce(true).Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return countNodes(&d.Node), nil
+}
+
+// Count the given node and all of its descendants, including those
+// in iframe documents, shadow trees, templates and pseudo-elements.
+func countNodes(n *dom.Node) int64 {
+	count := int64(1)
+	for _, list := range [][]dom.Node{n.Children, n.ShadowRoots, n.PseudoElements} {
+		for i := range list {
+			count += countNodes(&list[i])
+		}
+	}
+	for _, d := range []*dom.Node{n.ContentDocument, n.TemplateContent, n.ImportedDocument} {
+		if d != nil {
+			count += countNodes(d)
+		}
+	}
+	return count
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+)
+
+// A document with a text input (which has a user-agent shadow root), an
+// element with a ::before pseudo-element, a template, and a same-process
+// iframe: 15 attached nodes.
+const describedDocument = `{"node": {"nodeType": 9, "children": [
+	{"nodeType": 10},
+	{"nodeType": 1, "children": [
+		{"nodeType": 1, "children": [
+			{"nodeType": 1, "shadowRoots": [{"nodeType": 11, "children": [{"nodeType": 1}]}]},
+			{"nodeType": 1, "pseudoElements": [{"nodeType": 1}]},
+			{"nodeType": 1, "templateContent": {"nodeType": 11, "children": [{"nodeType": 3}]}},
+			{"nodeType": 1, "contentDocument": {"nodeType": 9, "children": [{"nodeType": 1}]}}
+		]}
+	]}
+]}}`
+
+func TestCheckLeaks(t *testing.T) {
+	// Set up.
+	ctx, b := devtoolstest.NewContext(context.Background())
+	b.Respond("Runtime.evaluate", map[string]interface{}{"result": map[string]string{"type": "object", "objectId": "doc"}})
+	b.Respond("DOM.describeNode", json.RawMessage(describedDocument))
+	nodes := []int64{20, 23}
+	b.Handle("Memory.getDOMCounters", func(json.RawMessage) (interface{}, error) {
+		n := nodes[0]
+		nodes = nodes[1:]
+		return map[string]int64{"documents": 2, "nodes": n, "jsEventListeners": 5}, nil
+	})
+	// Test.
+	r, err := CheckLeaks(ctx, func(context.Context) error { return nil })
+	if err != nil {
+		t.Fatalf("CheckLeaks(); got error: %v", err)
+	}
+	if r.Before.AttachedNodes != 15 || r.Before.DetachedNodes() != 5 || r.After.DetachedNodes() != 8 {
+		t.Errorf("CheckLeaks() = %+v, want 15 attached nodes, and 5 -> 8 detached nodes", r)
+	}
+	err = r.Check(LeakLimits{Nodes: 5})
+	if err == nil || !strings.Contains(err.Error(), "detached nodes: 5 -> 8 (+3, limit 0)") {
+		t.Errorf("Check() = %v, want detached nodes error", err)
+	}
+	if err := r.Check(LeakLimits{Nodes: 3, DetachedNodes: 3}); err != nil {
+		t.Errorf("Check(); got error: %v", err)
+	}
+	d := b.Calls("DOM.describeNode")
+	if len(d) != 2 || !strings.Contains(string(d[0].Params), `"pierce":true`) {
+		t.Errorf("CheckLeaks() describeNode calls = %v, want 2 with pierce", d)
+	}
+	if n := len(b.Calls("DOM.getDocument")); n != 0 {
+		t.Errorf("CheckLeaks() sent %d DOM.getDocument commands, want 0", n)
+	}
+}
@@ -0,0 +1,129 @@
+package devtools
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Metrics is a registry of counters and gauges for monitoring long-running
+// services which embed this package. It's exposed in the Prometheus text
+// format (https://prometheus.io/docs/instrumenting/exposition_formats/)
+// by its `ServeHTTP` and `WriteTo` methods, so it can be scraped with
+// standard tooling. Attach it to sessions with the `devtools.CollectMetrics`
+// session option. Multiple browsers may share the same registry.
+//
+// The methods of a nil registry are no-ops.
+type Metrics struct {
+	mu              sync.Mutex
+	commands        map[string]uint64
+	errors          map[int64]uint64
+	events          map[string]uint64
+	eventsDropped   map[string]uint64
+	activeSessions  int64
+	browsersStarted uint64
+	browserExits    uint64
+	crashes         uint64
+}
+
+// NewMetrics constructs a new, empty metrics registry.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		commands:      make(map[string]uint64),
+		errors:        make(map[int64]uint64),
+		events:        make(map[string]uint64),
+		eventsDropped: make(map[string]uint64),
+	}
+}
+
+// CollectMetrics allows the caller of the `devtools.NewContext` function to
+// update the given metrics registry with the activity of the browser, and
+// all of its tabs (i.e. descendant contexts).
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser.
+func CollectMetrics(m *Metrics) SessionOption {
+	return func(s *Session) {
+		s.metrics = m
+	}
+}
+
+func (m *Metrics) update(f func()) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f()
+}
+
+func (m *Metrics) commandSent(method string)   { m.update(func() { m.commands[method]++ }) }
+func (m *Metrics) commandFailed(code int64)    { m.update(func() { m.errors[code]++ }) }
+func (m *Metrics) eventReceived(method string) { m.update(func() { m.events[method]++ }) }
+func (m *Metrics) eventDropped(method string)  { m.update(func() { m.eventsDropped[method]++ }) }
+func (m *Metrics) sessionOpened()              { m.update(func() { m.activeSessions++ }) }
+func (m *Metrics) sessionClosed()              { m.update(func() { m.activeSessions-- }) }
+func (m *Metrics) browserStarted()             { m.update(func() { m.browsersStarted++ }) }
+func (m *Metrics) browserExited()              { m.update(func() { m.browserExits++ }) }
+func (m *Metrics) crashed()                    { m.update(func() { m.crashes++ }) }
+
+// ServeHTTP satisfies the `http.Handler` interface, so the registry can be
+// served directly, e.g. `http.Handle("/metrics", metrics)`.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes a snapshot of the registry to the given writer,
+// in the Prometheus text format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	b := &bytes.Buffer{}
+	if m != nil {
+		m.mu.Lock()
+		writeCounterVec(b, "chrome_vision_commands_sent_total", "CDP commands sent to browsers.",
+			"method", m.commands)
+		errors := make(map[string]uint64, len(m.errors))
+		for code, n := range m.errors {
+			errors[strconv.FormatInt(code, 10)] = n
+		}
+		writeCounterVec(b, "chrome_vision_command_errors_total", "CDP error responses, by error code.",
+			"code", errors)
+		writeCounterVec(b, "chrome_vision_events_received_total", "CDP events received from browsers.",
+			"method", m.events)
+		writeCounterVec(b, "chrome_vision_events_dropped_total", "CDP events discarded by full subscription buffers.",
+			"method", m.eventsDropped)
+		writeMetric(b, "chrome_vision_active_sessions", "Open CDP sessions (browser tabs).",
+			"gauge", float64(m.activeSessions))
+		writeMetric(b, "chrome_vision_browsers_started_total", "Browser processes started.",
+			"counter", float64(m.browsersStarted))
+		writeMetric(b, "chrome_vision_browser_exits_total", "Browser processes which have ended.",
+			"counter", float64(m.browserExits))
+		writeMetric(b, "chrome_vision_tab_crashes_total", "Reported crashes of browser tabs.",
+			"counter", float64(m.crashes))
+		m.mu.Unlock()
+	}
+	return b.WriteTo(w)
+}
+
+func writeMetric(b *bytes.Buffer, name, help, kind string, v float64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	fmt.Fprintf(b, "%s %s\n", name, strconv.FormatFloat(v, 'g', -1, 64))
+}
+
+func writeCounterVec(b *bytes.Buffer, name, help, label string, values map[string]uint64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s{%s=\"%s\"} %d\n", name, label, escaper.Replace(k), values[k])
+	}
+}
@@ -0,0 +1,56 @@
+package devtools
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMetrics(t *testing.T) {
+	// Set up.
+	m := NewMetrics()
+	m.commandSent("Page.navigate")
+	m.commandSent("Page.navigate")
+	m.commandSent("DOM.enable")
+	m.commandFailed(-32000)
+	m.eventReceived(`Page."quoted"`)
+	m.eventDropped("Network.dataReceived")
+	m.sessionOpened()
+	m.sessionOpened()
+	m.sessionClosed()
+	m.browserStarted()
+	// Test.
+	w := httptest.NewRecorder()
+	m.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	var got []string
+	for _, line := range strings.Split(w.Body.String(), "\n") {
+		if line != "" && !strings.HasPrefix(line, "#") {
+			got = append(got, line)
+		}
+	}
+	want := []string{
+		`chrome_vision_commands_sent_total{method="DOM.enable"} 1`,
+		`chrome_vision_commands_sent_total{method="Page.navigate"} 2`,
+		`chrome_vision_command_errors_total{code="-32000"} 1`,
+		`chrome_vision_events_received_total{method="Page.\"quoted\""} 1`,
+		`chrome_vision_events_dropped_total{method="Network.dataReceived"} 1`,
+		`chrome_vision_active_sessions 1`,
+		`chrome_vision_browsers_started_total 1`,
+		`chrome_vision_browser_exits_total 0`,
+		`chrome_vision_tab_crashes_total 0`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ServeHTTP() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNilMetrics(t *testing.T) {
+	var m *Metrics
+	m.commandSent("Page.navigate")
+	m.sessionOpened()
+	if n, err := m.WriteTo(&strings.Builder{}); n != 0 || err != nil {
+		t.Errorf("WriteTo() = %d, %v; want 0, nil", n, err)
+	}
+}
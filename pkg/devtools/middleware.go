@@ -0,0 +1,77 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// CommandHandler sends a CDP command to the browser associated with the
+// given context, and returns the browser's response message, like the
+// `devtools.SendAndWait` function.
+type CommandHandler func(ctx context.Context, method string, params json.RawMessage) (*Message, error)
+
+// Middleware wraps the handling of CDP commands, to add cross-cutting
+// behavior to all the commands of a session (e.g. auditing, measuring,
+// rate limiting, and modifying parameters), without changing the code
+// which sends them. A middleware may also return a response without
+// calling the next handler, to short-circuit a command. See the
+// `devtools.CommandMiddleware` session option. For example:
+//
+//	timer := func(next devtools.CommandHandler) devtools.CommandHandler {
+//		return func(ctx context.Context, method string, params json.RawMessage) (*devtools.Message, error) {
+//			start := time.Now()
+//			defer func() { log.Printf("%s: %v", method, time.Since(start)) }()
+//			return next(ctx, method, params)
+//		}
+//	}
+type Middleware func(next CommandHandler) CommandHandler
+
+// CommandMiddleware allows the caller of the `devtools.NewContext` function to
+// wrap all the CDP commands which are sent in the session (including those of
+// the domain sub-packages, and the commands which initialize the session)
+// with the given middleware, in order: the first one is the outermost.
+// New tabs which are opened in an existing browser inherit the middleware
+// of their parent session, and wrap it with their own.
+//
+// Responses of commands which are sent with the `devtools.Send` function
+// are relayed to its channel after all the middleware returns.
+func CommandMiddleware(mw ...Middleware) SessionOption {
+	return func(s *Session) {
+		s.middleware = append(s.middleware, mw...)
+	}
+}
+
+// Return the session's command handler, with all of its middleware.
+func (s *Session) handler() CommandHandler {
+	h := CommandHandler(sendAndReceive)
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		h = s.middleware[i](h)
+	}
+	return h
+}
+
+// The innermost command handler, which actually sends the command.
+func sendAndReceive(ctx context.Context, method string, params json.RawMessage) (*Message, error) {
+	ch, err := send(ctx, method, params)
+	if err != nil {
+		return nil, err
+	}
+	s, _ := FromContext(ctx)
+	select {
+	case m := <-ch:
+		close(ch)
+		if m.err != nil {
+			return nil, m.err
+		}
+		return m, nil
+	case <-s.commandDone(ctx):
+		// The sender relays an error to the channel when it stops waiting
+		// for the response, and it must not be blocked by that.
+		go func() {
+			<-ch
+			close(ch)
+		}()
+		return nil, fmt.Errorf("no response to %q command: %w", method, ctx.Err())
+	}
+}
@@ -0,0 +1,76 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Return a context with a fake session, whose browser responds to every
+// command with its own method and params as the result.
+func fakeSession(t *testing.T, opts ...SessionOption) context.Context {
+	s := &Session{msgQ: make(chan asyncMessage), SessionID: newSafeString()}
+	for _, o := range opts {
+		o(s)
+	}
+	go func() {
+		for m := range s.msgQ {
+			r, _ := json.Marshal(m.requestMsg)
+			m.responseChan <- &Message{Result: r}
+		}
+	}()
+	t.Cleanup(func() { close(s.msgQ) })
+	return context.WithValue(context.Background(), sessionKey{}, s)
+}
+
+func TestCommandMiddleware(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next CommandHandler) CommandHandler {
+			return func(ctx context.Context, method string, params json.RawMessage) (*Message, error) {
+				calls = append(calls, name+" "+method)
+				return next(ctx, method, params)
+			}
+		}
+	}
+	rewrite := func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, method string, params json.RawMessage) (*Message, error) {
+			return next(ctx, method, json.RawMessage(`{"rewritten":true}`))
+		}
+	}
+	block := func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, method string, params json.RawMessage) (*Message, error) {
+			if method == "Browser.close" {
+				return nil, errors.New("blocked")
+			}
+			return next(ctx, method, params)
+		}
+	}
+	ctx := fakeSession(t, CommandMiddleware(record("outer"), block), CommandMiddleware(record("inner"), rewrite))
+
+	m, err := SendAndWait(ctx, "Page.enable", nil)
+	if err != nil {
+		t.Fatalf("SendAndWait(); got error: %v", err)
+	}
+	want := `{"method":"Page.enable","params":{"rewritten":true}}`
+	if string(m.Result) != want {
+		t.Errorf("SendAndWait() result = %s, want %s", m.Result, want)
+	}
+
+	ch, err := Send(ctx, "Browser.close", nil)
+	if err != nil {
+		t.Fatalf("Send(); got error: %v", err)
+	}
+	if m := <-ch; m.err == nil || m.err.Error() != "blocked" {
+		t.Errorf("Send() response error = %v, want %q", m.err, "blocked")
+	}
+	close(ch)
+
+	wantCalls := []string{"outer Page.enable", "inner Page.enable", "outer Browser.close"}
+	if diff := cmp.Diff(wantCalls, calls); diff != "" {
+		t.Errorf("middleware calls mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,221 @@
+package devtools
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Name of the session's log file for incoming/outgoing CDP messages,
+// under its output directory.
+const msgLogName = "cdp_json.log"
+
+// Settings of the CDP message log file. See the `devtools.DisableMessageLog`,
+// `devtools.RotateMessageLog` and `devtools.CompressMessageLog` session options.
+type msgLogConfig struct {
+	disabled   bool
+	maxSize    int64
+	maxAge     time.Duration
+	maxBackups int
+	compress   bool
+}
+
+// DisableMessageLog allows the caller of the `devtools.NewContext` function
+// to turn off the log file of incoming and outgoing CDP messages in the
+// session's output directory, for high-throughput production use.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser.
+func DisableMessageLog() SessionOption {
+	return func(s *Session) {
+		s.msgLogConfig.disabled = true
+	}
+}
+
+// RotateMessageLog allows the caller of the `devtools.NewContext` function
+// to limit the growth of the log file of CDP messages in long sessions: when
+// the file exceeds maxSize bytes, or becomes older than maxAge, it's renamed
+// with a timestamp suffix and a new file is started. Zero values disable
+// either limit. Only the newest maxBackups rotated files are kept, unless
+// maxBackups is zero.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser.
+func RotateMessageLog(maxSize int64, maxAge time.Duration, maxBackups int) SessionOption {
+	return func(s *Session) {
+		s.msgLogConfig.maxSize = maxSize
+		s.msgLogConfig.maxAge = maxAge
+		s.msgLogConfig.maxBackups = maxBackups
+	}
+}
+
+// CompressMessageLog allows the caller of the `devtools.NewContext` function
+// to compress rotated log files of CDP messages with gzip, in the background
+// (see the `devtools.RotateMessageLog` session option).
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser.
+func CompressMessageLog() SessionOption {
+	return func(s *Session) {
+		s.msgLogConfig.compress = true
+	}
+}
+
+// Initialize the session's logger of CDP messages, according to its settings.
+func newMsgLog(dir string, cfg msgLogConfig) (*log.Logger, error) {
+	var w io.Writer = io.Discard
+	if !cfg.disabled {
+		r := &rotatingFile{path: filepath.Join(dir, msgLogName), cfg: cfg}
+		if err := r.open(); err != nil {
+			return nil, err
+		}
+		w = r
+	}
+	return log.New(w, "", log.Ldate|log.Ltime|log.Lmicroseconds), nil
+}
+
+// Close the session's logger of CDP messages, if it's backed by a file.
+func closeMsgLog(l *log.Logger) {
+	if c, ok := l.Writer().(io.Closer); ok {
+		c.Close()
+	}
+}
+
+// rotatingFile is a log file which is rotated according to its size and age.
+// It doesn't need its own lock for writes, because `log.Logger` serializes them.
+type rotatingFile struct {
+	path   string
+	cfg    msgLogConfig
+	f      *os.File
+	size   int64
+	opened time.Time
+
+	// Background compressions of rotated files, one at a time.
+	compressions sync.WaitGroup
+	compressMu   sync.Mutex
+}
+
+// Open the log file for appending, because resumed sessions
+// (see `devtools.Resume`) continue the log of the original one.
+func (r *rotatingFile) open() error {
+	f, err := os.OpenFile(r.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	r.f, r.size, r.opened = f, info.Size(), time.Now()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	if r.needsRotation(int64(len(p))) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) needsRotation(n int64) bool {
+	if r.size == 0 {
+		return false // Never rotate an empty file, even for a huge message.
+	}
+	if r.cfg.maxSize > 0 && r.size+n > r.cfg.maxSize {
+		return true
+	}
+	return r.cfg.maxAge > 0 && time.Since(r.opened) > r.cfg.maxAge
+}
+
+// Rename the current file with a timestamp suffix, and start a new one.
+func (r *rotatingFile) rotate() error {
+	if err := r.f.Close(); err != nil {
+		return err
+	}
+	ext := filepath.Ext(r.path)
+	suffix := time.Now().UTC().Format("20060102_150405.000000000")
+	rotated := fmt.Sprintf("%s.%s%s", strings.TrimSuffix(r.path, ext), suffix, ext)
+	if err := os.Rename(r.path, rotated); err != nil {
+		return err
+	}
+	if r.cfg.compress {
+		r.compressions.Add(1)
+		go func() {
+			defer r.compressions.Done()
+			r.compressMu.Lock()
+			defer r.compressMu.Unlock()
+			if err := gzipFile(rotated); err != nil {
+				log.Printf("WARNING: failed to compress CDP log file %s: %v", rotated, err)
+			}
+			r.prune()
+		}()
+	} else {
+		r.prune()
+	}
+	return r.open()
+}
+
+// Delete the oldest rotated files, beyond the maximum number of backups.
+func (r *rotatingFile) prune() {
+	if r.cfg.maxBackups <= 0 {
+		return
+	}
+	ext := filepath.Ext(r.path)
+	backups, err := filepath.Glob(strings.TrimSuffix(r.path, ext) + ".*" + ext + "*")
+	if err != nil {
+		return
+	}
+	// The timestamp suffixes are sorted chronologically, even with ".gz".
+	sort.Strings(backups)
+	for len(backups) > r.cfg.maxBackups {
+		os.Remove(backups[0])
+		backups = backups[1:]
+	}
+}
+
+// Close closes the current file, after waiting for background compressions.
+func (r *rotatingFile) Close() error {
+	r.compressions.Wait()
+	r.f.Sync()
+	return r.f.Close()
+}
+
+// Compress the given file into a new file with the extension ".gz",
+// and delete the original file.
+func gzipFile(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(path + ".gz")
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(out)
+	if _, err := io.Copy(zw, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	in.Close()
+	return os.Remove(path)
+}
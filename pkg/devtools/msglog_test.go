@@ -0,0 +1,62 @@
+package devtools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFile(t *testing.T) {
+	// Set up.
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		t.Fatalf(`os.MkdirTemp("", ""); got error: %v`, err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := msgLogConfig{maxSize: 10, maxBackups: 2, compress: true}
+	l, err := newMsgLog(dir, cfg)
+	if err != nil {
+		t.Fatalf("newMsgLog(); got error: %v", err)
+	}
+	// Test.
+	for i := 0; i < 5; i++ {
+		l.Writer().Write([]byte("0123456789"))
+	}
+	closeMsgLog(l)
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		t.Fatalf("filepath.Glob(); got error: %v", err)
+	}
+	var current, compressed int
+	for _, f := range files {
+		switch {
+		case filepath.Base(f) == msgLogName:
+			current++
+		case strings.HasSuffix(f, ".log.gz"):
+			compressed++
+		default:
+			t.Errorf("unexpected file: %s", f)
+		}
+	}
+	if current != 1 || compressed != 2 {
+		t.Errorf("got %d current and %d compressed log files, want 1 and 2", current, compressed)
+	}
+}
+
+func TestDisabledMsgLog(t *testing.T) {
+	dir, err := os.MkdirTemp("", "")
+	if err != nil {
+		t.Fatalf(`os.MkdirTemp("", ""); got error: %v`, err)
+	}
+	defer os.RemoveAll(dir)
+	l, err := newMsgLog(dir, msgLogConfig{disabled: true})
+	if err != nil {
+		t.Fatalf("newMsgLog(); got error: %v", err)
+	}
+	l.Printf("-> %s", "{}")
+	closeMsgLog(l)
+	if _, err := os.Stat(filepath.Join(dir, msgLogName)); !os.IsNotExist(err) {
+		t.Errorf("os.Stat(%s) = %v, want not-exist error", msgLogName, err)
+	}
+}
@@ -0,0 +1,207 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// Body is the content of a network response or a page resource, see the
+// `network.ResponseBody` function, and the `network.NewBody` function for
+// other CDP commands which return content.
+type Body struct {
+	// Bytes of the body, after base64 decoding. Text which the browser
+	// already decoded (i.e. it wasn't base64-encoded) is UTF-8 encoded.
+	Bytes []byte
+	// MIME type, from the Content-Type header, or sniffed from the content
+	// with the algorithm in https://mimesniff.spec.whatwg.org/.
+	MIMEType string
+	// Character encoding of the bytes (e.g. "utf-8", "windows-1252", or
+	// "shift_jis"), from a byte order mark, the Content-Type header, an HTML
+	// `<meta>` tag or an XML declaration, in this order, or empty if the
+	// body isn't text.
+	Charset string
+}
+
+var (
+	metaCharset = regexp.MustCompile(`(?i)<meta[^>]+charset\s*=\s*["']?\s*([\w.:-]+)`)
+	xmlEncoding = regexp.MustCompile(`^<\?xml[^>]+encoding\s*=\s*["']([\w.:-]+)["']`)
+	charsetName = regexp.MustCompile(`^[\w.:-]+$`)
+)
+
+// Charset labels which are handled without the browser. Like browsers, and
+// https://encoding.spec.whatwg.org/#names-and-labels, ISO-8859-1 and ASCII
+// are decoded as Windows-1252, their superset.
+var charsetAliases = map[string]string{
+	"utf-8": "utf-8", "utf8": "utf-8", "unicode-1-1-utf-8": "utf-8",
+	"utf-16": "utf-16le", "utf-16le": "utf-16le", "utf-16be": "utf-16be",
+	"windows-1252": "windows-1252", "cp1252": "windows-1252", "x-cp1252": "windows-1252",
+	"iso-8859-1": "windows-1252", "iso8859-1": "windows-1252", "latin1": "windows-1252",
+	"l1": "windows-1252", "us-ascii": "windows-1252", "ascii": "windows-1252",
+}
+
+// NewBody constructs a body from the results of CDP commands such as
+// `network.GetResponseBody` and `page.GetResourceContent`, and the
+// Content-Type header of the response, if it's known (e.g. from the
+// `network.Response` in a `network.ResponseReceived` event).
+func NewBody(content string, base64Encoded bool, contentType string) (*Body, error) {
+	if !base64Encoded {
+		return newBody([]byte(content), true, contentType), nil
+	}
+	b, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 body: %v", err)
+	}
+	return newBody(b, false, contentType), nil
+}
+
+// Construct a body from the given bytes, which are UTF-8 encoded
+// text if the browser already decoded them, or raw bytes otherwise.
+func newBody(content []byte, decoded bool, contentType string) *Body {
+	b := &Body{Bytes: content}
+	var declared string
+	if mt, params, err := mime.ParseMediaType(contentType); err == nil {
+		b.MIMEType = mt
+		declared = params["charset"]
+	} else {
+		b.MIMEType, _, _ = mime.ParseMediaType(http.DetectContentType(b.Bytes))
+	}
+	switch {
+	case !isText(b.MIMEType):
+	case decoded:
+		b.Charset = "utf-8"
+	default:
+		b.Charset = detectCharset(b.Bytes, declared, b.MIMEType)
+	}
+	return b
+}
+
+// ResponseBody returns the body of the network response with the given
+// request ID, and its Content-Type header (which may be empty), with
+// the `network.GetResponseBody` CDP command. See also `Body.Text`.
+func ResponseBody(ctx context.Context, requestID, contentType string) (*Body, error) {
+	r, err := NewGetResponseBody(requestID).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewBody(r.Body, r.Base64Encoded, contentType)
+}
+
+func isText(mimeType string) bool {
+	switch {
+	case strings.HasPrefix(mimeType, "text/"), strings.HasSuffix(mimeType, "+xml"),
+		strings.HasSuffix(mimeType, "+json"):
+		return true
+	}
+	switch mimeType {
+	case "application/json", "application/javascript", "application/ecmascript",
+		"application/x-javascript", "application/xml", "application/x-www-form-urlencoded":
+		return true
+	}
+	return false
+}
+
+func detectCharset(b []byte, declared, mimeType string) string {
+	switch {
+	case bytes.HasPrefix(b, []byte("\xef\xbb\xbf")):
+		return "utf-8"
+	case bytes.HasPrefix(b, []byte("\xfe\xff")):
+		return "utf-16be"
+	case bytes.HasPrefix(b, []byte("\xff\xfe")):
+		return "utf-16le"
+	}
+	if declared == "" {
+		prefix := b
+		if len(prefix) > 1024 {
+			prefix = prefix[:1024]
+		}
+		if m := xmlEncoding.FindSubmatch(prefix); m != nil {
+			declared = string(m[1])
+		} else if m := metaCharset.FindSubmatch(prefix); m != nil && mimeType == "text/html" {
+			declared = string(m[1])
+		}
+	}
+	if declared = strings.ToLower(strings.TrimSpace(declared)); declared != "" {
+		if c, ok := charsetAliases[declared]; ok {
+			return c
+		}
+		return declared
+	}
+	if utf8.Valid(b) || strings.HasSuffix(mimeType, "json") {
+		return "utf-8"
+	}
+	return "windows-1252"
+}
+
+// The characters of Windows-1252 bytes 0x80-0x9F,
+// which differ from ISO-8859-1 (U+FFFD if undefined).
+var windows1252 = [32]rune{
+	'€', '\uFFFD', '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', '\uFFFD', 'Ž', '\uFFFD',
+	'\uFFFD', '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', '\uFFFD', 'ž', 'Ÿ',
+}
+
+// Text returns the body as a UTF-8 string, decoded according to its charset.
+// Invalid byte sequences are replaced with U+FFFD, like in browsers. UTF-8,
+// UTF-16, Windows-1252 and ISO-8859-1 are decoded in Go. Other charsets
+// (e.g. "shift_jis", "euc-kr" or "gbk") are decoded by the browser which is
+// associated with the given context (with JavaScript's `TextDecoder`), to
+// support every encoding which it supports, without decoding tables here.
+func (b *Body) Text(ctx context.Context) (string, error) {
+	switch b.Charset {
+	case "":
+		return "", fmt.Errorf("not a text body: %q", b.MIMEType)
+	case "utf-8":
+		s := string(bytes.TrimPrefix(b.Bytes, []byte("\xef\xbb\xbf")))
+		return strings.ToValidUTF8(s, "\uFFFD"), nil
+	case "utf-16le", "utf-16be":
+		return decodeUTF16(b.Bytes, b.Charset == "utf-16be"), nil
+	case "windows-1252":
+		var sb strings.Builder
+		for _, c := range b.Bytes {
+			if c >= 0x80 && c < 0xa0 {
+				sb.WriteRune(windows1252[c-0x80])
+			} else {
+				sb.WriteRune(rune(c))
+			}
+		}
+		return sb.String(), nil
+	}
+	if !charsetName.MatchString(b.Charset) {
+		return "", fmt.Errorf("invalid charset: %q", b.Charset)
+	}
+	var s string
+	expr := fmt.Sprintf("new TextDecoder(%q).decode(Uint8Array.from(atob(%q), c => c.charCodeAt(0)))",
+		b.Charset, base64.StdEncoding.EncodeToString(b.Bytes))
+	if err := runtime.Eval(ctx, expr, &s); err != nil {
+		return "", fmt.Errorf("failed to decode %q text in the browser: %v", b.Charset, err)
+	}
+	return s, nil
+}
+
+func decodeUTF16(b []byte, bigEndian bool) string {
+	if bytes.HasPrefix(b, []byte("\xfe\xff")) || bytes.HasPrefix(b, []byte("\xff\xfe")) {
+		b = b[2:]
+	}
+	u := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		if bigEndian {
+			u = append(u, uint16(b[i])<<8|uint16(b[i+1]))
+		} else {
+			u = append(u, uint16(b[i+1])<<8|uint16(b[i]))
+		}
+	}
+	s := string(utf16.Decode(u))
+	if len(b)%2 == 1 {
+		s += "\uFFFD" // Truncated code unit.
+	}
+	return s
+}
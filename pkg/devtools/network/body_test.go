@@ -0,0 +1,132 @@
+package network
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+func TestBody(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		base64Encoded bool
+		contentType   string
+		wantMIMEType  string
+		wantCharset   string
+		wantText      string
+	}{
+		{
+			name:         "decoded_by_browser",
+			content:      "héllo",
+			contentType:  "text/html; charset=ISO-8859-1",
+			wantMIMEType: "text/html",
+			wantCharset:  "utf-8",
+			wantText:     "héllo",
+		},
+		{
+			name:          "latin1_header",
+			content:       b64("caf\xe9 \x80"),
+			base64Encoded: true,
+			contentType:   "text/plain; charset=ISO-8859-1",
+			wantMIMEType:  "text/plain",
+			wantCharset:   "windows-1252",
+			wantText:      "café €",
+		},
+		{
+			name:          "meta_tag",
+			content:       b64("<html><head><meta charset=\"windows-1252\"></head>na\xefve</html>"),
+			base64Encoded: true,
+			wantMIMEType:  "text/html",
+			wantCharset:   "windows-1252",
+			wantText:      `<html><head><meta charset="windows-1252"></head>naïve</html>`,
+		},
+		{
+			name:          "xml_declaration",
+			content:       b64(`<?xml version="1.0" encoding="Shift_JIS"?><a/>`),
+			base64Encoded: true,
+			contentType:   "application/xml",
+			wantMIMEType:  "application/xml",
+			wantCharset:   "shift_jis",
+		},
+		{
+			name:          "utf16_bom",
+			content:       b64("\xff\xfeh\x00i\x00"),
+			base64Encoded: true,
+			contentType:   "text/plain; charset=windows-1252",
+			wantMIMEType:  "text/plain",
+			wantCharset:   "utf-16le",
+			wantText:      "hi",
+		},
+		{
+			name:          "invalid_utf8",
+			content:       b64("a\xffb"),
+			base64Encoded: true,
+			contentType:   "application/json; charset=utf-8",
+			wantMIMEType:  "application/json",
+			wantCharset:   "utf-8",
+			wantText:      "a�b",
+		},
+		{
+			name:          "binary",
+			content:       b64("\x89PNG\r\n\x1a\n"),
+			base64Encoded: true,
+			wantMIMEType:  "image/png",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := NewBody(test.content, test.base64Encoded, test.contentType)
+			if err != nil {
+				t.Fatalf("NewBody(); got error: %v", err)
+			}
+			if b.MIMEType != test.wantMIMEType || b.Charset != test.wantCharset {
+				t.Errorf("NewBody() = %q, %q; want %q, %q", b.MIMEType, b.Charset, test.wantMIMEType, test.wantCharset)
+			}
+			if test.wantText == "" {
+				return
+			}
+			got, err := b.Text(context.Background())
+			if err != nil {
+				t.Fatalf("Text(); got error: %v", err)
+			}
+			if got != test.wantText {
+				t.Errorf("Text() = %q, want %q", got, test.wantText)
+			}
+		})
+	}
+}
+
+func TestBodyTextInBrowser(t *testing.T) {
+	var expr string
+	ctx := devtools.NewFakeContext(context.Background(), func(_ context.Context, method string, params json.RawMessage) (*devtools.Message, error) {
+		p := struct{ Expression string }{}
+		json.Unmarshal(params, &p)
+		expr = p.Expression
+		return &devtools.Message{Result: json.RawMessage(`{"result":{"type":"string","value":"日本"}}`)}, nil
+	})
+	b := &Body{Bytes: []byte("\x93\xfa\x96\x7b"), MIMEType: "text/plain", Charset: "shift_jis"}
+	got, err := b.Text(ctx)
+	if err != nil {
+		t.Fatalf("Text(); got error: %v", err)
+	}
+	if got != "日本" {
+		t.Errorf("Text() = %q, want %q", got, "日本")
+	}
+	if !strings.Contains(expr, `new TextDecoder("shift_jis")`) || !strings.Contains(expr, b64(string(b.Bytes))) {
+		t.Errorf("Text() expression = %s", expr)
+	}
+
+	b.Charset = `x"); alert("`
+	if _, err := b.Text(ctx); err == nil {
+		t.Error("Text() with an invalid charset; got nil error")
+	}
+}
+
+func b64(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
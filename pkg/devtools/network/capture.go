@@ -0,0 +1,271 @@
+package network
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// CaptureEntry is a single line in a network capture log, which describes
+// one request (or one hop in a chain of redirects), see the
+// `network.StartCapture` function.
+type CaptureEntry struct {
+	// Wall time when the request was sent.
+	Time      time.Time `json:"time"`
+	RequestID string    `json:"requestId"`
+	URL       string    `json:"url"`
+	Method    string    `json:"method"`
+	Type      string    `json:"type,omitempty"`
+	// HTTP response status code, or 0 if there was no response.
+	Status   int64  `json:"status,omitempty"`
+	MimeType string `json:"mimeType,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
+	RemoteIP string `json:"remoteIP,omitempty"`
+	// Served from the disk cache, prefetch cache or a service worker.
+	FromCache bool `json:"fromCache,omitempty"`
+	// The URL of the next hop, if this response is a redirect.
+	RedirectURL string `json:"redirectURL,omitempty"`
+	// Total number of bytes received over the network,
+	// including headers, before decompression.
+	EncodedSize int64 `json:"encodedSize"`
+	// Timing breakdown (in nanoseconds), if the response was received.
+	Timing *TimingBreakdown `json:"timing,omitempty"`
+	// SHA-256 digest of the decoded response body (hex), if requested with
+	// the `network.HashBodies` option, and the body is still available.
+	BodySHA256 string `json:"bodySHA256,omitempty"`
+	// Decoded size of the response body, if it was hashed.
+	BodySize int64  `json:"bodySize,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+type captureConfig struct {
+	hashBodies bool
+}
+
+// CaptureOption is used for customization in the `network.StartCapture` function.
+type CaptureOption = func(*captureConfig)
+
+// HashBodies allows the caller of the `network.StartCapture` function to
+// add a SHA-256 digest of each response body to its log line. This requires
+// fetching each body from the browser, so it's slower than the default.
+func HashBodies() CaptureOption {
+	return func(c *captureConfig) {
+		c.hashBodies = true
+	}
+}
+
+// Capture writes a network capture log in the background, see the
+// `network.StartCapture` function.
+type Capture struct {
+	ctx  context.Context
+	cfg  *captureConfig
+	sub  *devtools.Subscription
+	done chan struct{}
+
+	mu       sync.Mutex
+	enc      *json.Encoder
+	err      error
+	inflight map[string]*CaptureEntry
+	timings  map[string]*ResourceTiming
+	pending  sync.WaitGroup
+}
+
+// StartCapture starts writing a streaming JSONL log of the network requests
+// of the page associated with the given context to the given writer, one
+// JSON object (`network.CaptureEntry`) per line, until the `Stop` method
+// of the returned capture is called. This is a lightweight alternative to
+// HAR files for long crawls, which can be analyzed with tools such as jq.
+//
+// Lines are written when requests finish, so their order may differ from
+// the order in which the requests were sent. This function enables the
+// Network domain until the capture is stopped, or until the context is done
+// (see `devtools.SubscribeEvents`). Requests which started before calling it
+// are not logged.
+func StartCapture(ctx context.Context, w io.Writer, opts ...CaptureOption) (*Capture, error) {
+	c := &Capture{
+		ctx:      ctx,
+		cfg:      &captureConfig{},
+		done:     make(chan struct{}),
+		enc:      json.NewEncoder(w),
+		inflight: make(map[string]*CaptureEntry),
+		timings:  make(map[string]*ResourceTiming),
+	}
+	for _, o := range opts {
+		o(c.cfg)
+	}
+	names := []string{"Network.requestWillBeSent", "Network.responseReceived", "Network.loadingFinished", "Network.loadingFailed"}
+	sub, err := devtools.SubscribeEvents(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	c.sub = sub
+	go c.collect(devtools.EventsOfSession(ctx))
+	return c, nil
+}
+
+// Receive events until the subscription ends.
+func (c *Capture) collect(accept devtools.EventFilter) {
+	defer close(c.done)
+	for m := range c.sub.C {
+		if !accept(m) {
+			continue
+		}
+		switch m.Method {
+		case "Network.requestWillBeSent":
+			e := &RequestWillBeSent{}
+			if json.Unmarshal(m.Params, e) == nil {
+				c.requestWillBeSent(e)
+			}
+		case "Network.responseReceived":
+			e := &ResponseReceived{}
+			if json.Unmarshal(m.Params, e) == nil {
+				c.responseReceived(e)
+			}
+		case "Network.loadingFinished":
+			e := &LoadingFinished{}
+			if json.Unmarshal(m.Params, e) == nil {
+				c.loadingFinished(e)
+			}
+		case "Network.loadingFailed":
+			e := &LoadingFailed{}
+			if json.Unmarshal(m.Params, e) == nil {
+				c.loadingFailed(e)
+			}
+		}
+	}
+}
+
+func (c *Capture) requestWillBeSent(e *RequestWillBeSent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Redirects reuse the same request ID: complete the previous hop.
+	if prev, ok := c.inflight[e.RequestID]; ok && e.RedirectResponse != nil {
+		c.setResponse(prev, e.RedirectResponse)
+		c.setTiming(prev, e.RedirectResponse.Timing, e.Timestamp)
+		prev.RedirectURL = e.Request.URL
+		c.write(prev)
+	}
+	entry := &CaptureEntry{
+		Time:      e.WallTime.Time(),
+		RequestID: e.RequestID,
+		URL:       e.Request.URL + e.Request.URLFragment,
+		Method:    e.Request.Method,
+	}
+	if e.Type != nil {
+		entry.Type = string(*e.Type)
+	}
+	c.inflight[e.RequestID] = entry
+	delete(c.timings, e.RequestID)
+}
+
+func (c *Capture) responseReceived(e *ResponseReceived) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.inflight[e.RequestID]; ok {
+		entry.Type = string(e.Type)
+		c.setResponse(entry, &e.Response)
+		c.timings[e.RequestID] = e.Response.Timing
+	}
+}
+
+func (c *Capture) setResponse(entry *CaptureEntry, r *Response) {
+	entry.Status = r.Status
+	entry.MimeType = r.MimeType
+	entry.Protocol = r.Protocol
+	entry.RemoteIP = r.RemoteIPAddress
+	entry.FromCache = r.FromDiskCache || r.FromPrefetchCache || r.FromServiceWorker
+	entry.EncodedSize = int64(r.EncodedDataLength)
+}
+
+func (c *Capture) setTiming(entry *CaptureEntry, t *ResourceTiming, finished devtools.MonotonicTime) {
+	if t != nil {
+		b := t.Breakdown(finished)
+		entry.Timing = &b
+	}
+}
+
+func (c *Capture) loadingFinished(e *LoadingFinished) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.inflight[e.RequestID]
+	if !ok {
+		return
+	}
+	c.setTiming(entry, c.timings[e.RequestID], e.Timestamp)
+	delete(c.inflight, e.RequestID)
+	delete(c.timings, e.RequestID)
+	entry.EncodedSize = int64(e.EncodedDataLength)
+	if !c.cfg.hashBodies {
+		c.write(entry)
+		return
+	}
+	c.pending.Add(1)
+	go func() {
+		defer c.pending.Done()
+		if r, err := NewGetResponseBody(e.RequestID).Do(c.ctx); err == nil {
+			body := []byte(r.Body)
+			if r.Base64Encoded {
+				body, _ = base64.StdEncoding.DecodeString(r.Body)
+			}
+			sum := sha256.Sum256(body)
+			entry.BodySHA256 = hex.EncodeToString(sum[:])
+			entry.BodySize = int64(len(body))
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.write(entry)
+	}()
+}
+
+func (c *Capture) loadingFailed(e *LoadingFailed) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.inflight[e.RequestID]
+	if !ok {
+		return
+	}
+	c.setTiming(entry, c.timings[e.RequestID], 0)
+	delete(c.inflight, e.RequestID)
+	delete(c.timings, e.RequestID)
+	entry.Type = string(e.Type)
+	entry.Error = e.ErrorText
+	if e.Canceled {
+		entry.Error = "canceled"
+	}
+	c.write(entry)
+}
+
+// Write a single line. Must be called while holding the lock.
+func (c *Capture) write(entry *CaptureEntry) {
+	if c.err != nil {
+		return
+	}
+	c.err = c.enc.Encode(entry)
+}
+
+// Stop stops the capture, writes the requests which haven't finished yet
+// (with the error "unfinished"), and returns the first write error, if any.
+// It also releases the Network domain, which is disabled only if
+// nothing else requires it.
+func (c *Capture) Stop() error {
+	c.sub.Unsubscribe()
+	<-c.done
+	c.pending.Wait()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, entry := range c.inflight {
+		entry.Error = "unfinished"
+		c.setTiming(entry, c.timings[id], 0)
+		c.write(entry)
+		delete(c.inflight, id)
+		delete(c.timings, id)
+	}
+	return c.err
+}
@@ -0,0 +1,87 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+)
+
+func TestCapture(t *testing.T) {
+	// Set up.
+	ctx, _ := devtoolstest.NewContext(context.Background())
+	sub, err := devtools.Subscribe(ctx, "Network.requestWillBeSent")
+	if err != nil {
+		t.Fatalf("Subscribe(); got error: %v", err)
+	}
+	buf := &bytes.Buffer{}
+	c := &Capture{
+		sub:      sub,
+		cfg:      &captureConfig{},
+		enc:      json.NewEncoder(buf),
+		inflight: make(map[string]*CaptureEntry),
+		timings:  make(map[string]*ResourceTiming),
+		done:     make(chan struct{}),
+	}
+	close(c.done)
+	doc := ResourceTypeDocument
+	c.requestWillBeSent(&RequestWillBeSent{
+		RequestID: "1", WallTime: 1600000000.5, Type: &doc,
+		Request: Request{URL: "http://example.com/", Method: "GET"},
+	})
+	c.requestWillBeSent(&RequestWillBeSent{
+		RequestID: "1", WallTime: 1600000001, Type: &doc,
+		Request:          Request{URL: "https://example.com/", Method: "GET"},
+		RedirectResponse: &Response{Status: 301, EncodedDataLength: 100},
+	})
+	c.responseReceived(&ResponseReceived{
+		RequestID: "1", Type: doc,
+		Response: Response{Status: 200, MimeType: "text/html", Protocol: "h2", Timing: &ResourceTiming{
+			RequestTime: 10, ProxyStart: -1, ProxyEnd: -1, DNSStart: -1, DNSEnd: -1,
+			ConnectStart: -1, ConnectEnd: -1, SslStart: -1, SslEnd: -1,
+			SendStart: 0, SendEnd: 1, ReceiveHeadersEnd: 11,
+		}},
+	})
+	c.loadingFinished(&LoadingFinished{RequestID: "1", Timestamp: 10.25, EncodedDataLength: 1234})
+	c.requestWillBeSent(&RequestWillBeSent{RequestID: "2", Request: Request{URL: "https://example.com/a.js"}})
+	c.loadingFailed(&LoadingFailed{RequestID: "2", Type: ResourceTypeScript, ErrorText: "net::ERR_FAILED"})
+	c.requestWillBeSent(&RequestWillBeSent{RequestID: "3", Request: Request{URL: "https://example.com/b.js"}})
+	// Test.
+	if err := c.Stop(); err != nil {
+		t.Fatalf("Stop(); got error: %v", err)
+	}
+	var got []CaptureEntry
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		e := CaptureEntry{}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("json.Unmarshal(%q); got error: %v", line, err)
+		}
+		got = append(got, e)
+	}
+	if len(got) != 4 {
+		t.Fatalf("got %d lines, want 4:\n%s", len(got), buf)
+	}
+	if got[0].Status != 301 || got[0].RedirectURL != "https://example.com/" {
+		t.Errorf("redirect hop = %+v", got[0])
+	}
+	if got[0].Time != time.Unix(1600000000, 5e8).UTC() {
+		t.Errorf("redirect hop time = %v", got[0].Time)
+	}
+	if got[1].Status != 200 || got[1].EncodedSize != 1234 || got[1].Timing == nil {
+		t.Fatalf("final hop = %+v", got[1])
+	}
+	if got[1].Timing.Total != 250*time.Millisecond {
+		t.Errorf("final hop total time = %v, want 250ms", got[1].Timing.Total)
+	}
+	if got[2].Error != "net::ERR_FAILED" || got[2].Type != "Script" {
+		t.Errorf("failed request = %+v", got[2])
+	}
+	if got[3].Error != "unfinished" {
+		t.Errorf("unfinished request = %+v", got[3])
+	}
+}
@@ -422,18 +422,28 @@ type ContinueInterceptedRequest struct {
 
 // NewContinueInterceptedRequest constructs a new ContinueInterceptedRequest struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ContinueInterceptedRequestOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-continueInterceptedRequest
 //
 // This CDP method is deprecated.
 // This CDP method is experimental.
-func NewContinueInterceptedRequest(interceptionID string) *ContinueInterceptedRequest {
-	return &ContinueInterceptedRequest{
+func NewContinueInterceptedRequest(interceptionID string, opts ...ContinueInterceptedRequestOption) *ContinueInterceptedRequest {
+	c := &ContinueInterceptedRequest{
 		InterceptionID: interceptionID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ContinueInterceptedRequestOption is a functional option for the optional
+// parameters of the ContinueInterceptedRequest CDP command, see the
+// NewContinueInterceptedRequest constructor. Options are applied in order.
+type ContinueInterceptedRequestOption = func(*ContinueInterceptedRequest)
+
 // SetErrorReason adds or modifies the value of the optional
 // parameter `errorReason` in the ContinueInterceptedRequest CDP command.
 //
@@ -445,6 +455,14 @@ func (t *ContinueInterceptedRequest) SetErrorReason(v ErrorReason) *ContinueInte
 	return t
 }
 
+// ContinueInterceptedRequestWithErrorReason is a functional option which sets the value of the
+// optional parameter `errorReason` in the ContinueInterceptedRequest CDP command, see SetErrorReason.
+func ContinueInterceptedRequestWithErrorReason(v ErrorReason) ContinueInterceptedRequestOption {
+	return func(c *ContinueInterceptedRequest) {
+		c.SetErrorReason(v)
+	}
+}
+
 // SetRawResponse adds or modifies the value of the optional
 // parameter `rawResponse` in the ContinueInterceptedRequest CDP command.
 //
@@ -455,6 +473,14 @@ func (t *ContinueInterceptedRequest) SetRawResponse(v string) *ContinueIntercept
 	return t
 }
 
+// ContinueInterceptedRequestWithRawResponse is a functional option which sets the value of the
+// optional parameter `rawResponse` in the ContinueInterceptedRequest CDP command, see SetRawResponse.
+func ContinueInterceptedRequestWithRawResponse(v string) ContinueInterceptedRequestOption {
+	return func(c *ContinueInterceptedRequest) {
+		c.SetRawResponse(v)
+	}
+}
+
 // SetURL adds or modifies the value of the optional
 // parameter `url` in the ContinueInterceptedRequest CDP command.
 //
@@ -465,6 +491,14 @@ func (t *ContinueInterceptedRequest) SetURL(v string) *ContinueInterceptedReques
 	return t
 }
 
+// ContinueInterceptedRequestWithURL is a functional option which sets the value of the
+// optional parameter `url` in the ContinueInterceptedRequest CDP command, see SetURL.
+func ContinueInterceptedRequestWithURL(v string) ContinueInterceptedRequestOption {
+	return func(c *ContinueInterceptedRequest) {
+		c.SetURL(v)
+	}
+}
+
 // SetMethod adds or modifies the value of the optional
 // parameter `method` in the ContinueInterceptedRequest CDP command.
 //
@@ -475,6 +509,14 @@ func (t *ContinueInterceptedRequest) SetMethod(v string) *ContinueInterceptedReq
 	return t
 }
 
+// ContinueInterceptedRequestWithMethod is a functional option which sets the value of the
+// optional parameter `method` in the ContinueInterceptedRequest CDP command, see SetMethod.
+func ContinueInterceptedRequestWithMethod(v string) ContinueInterceptedRequestOption {
+	return func(c *ContinueInterceptedRequest) {
+		c.SetMethod(v)
+	}
+}
+
 // SetPostData adds or modifies the value of the optional
 // parameter `postData` in the ContinueInterceptedRequest CDP command.
 //
@@ -484,6 +526,14 @@ func (t *ContinueInterceptedRequest) SetPostData(v string) *ContinueInterceptedR
 	return t
 }
 
+// ContinueInterceptedRequestWithPostData is a functional option which sets the value of the
+// optional parameter `postData` in the ContinueInterceptedRequest CDP command, see SetPostData.
+func ContinueInterceptedRequestWithPostData(v string) ContinueInterceptedRequestOption {
+	return func(c *ContinueInterceptedRequest) {
+		c.SetPostData(v)
+	}
+}
+
 // SetHeaders adds or modifies the value of the optional
 // parameter `headers` in the ContinueInterceptedRequest CDP command.
 //
@@ -494,6 +544,14 @@ func (t *ContinueInterceptedRequest) SetHeaders(v Headers) *ContinueInterceptedR
 	return t
 }
 
+// ContinueInterceptedRequestWithHeaders is a functional option which sets the value of the
+// optional parameter `headers` in the ContinueInterceptedRequest CDP command, see SetHeaders.
+func ContinueInterceptedRequestWithHeaders(v Headers) ContinueInterceptedRequestOption {
+	return func(c *ContinueInterceptedRequest) {
+		c.SetHeaders(v)
+	}
+}
+
 // SetAuthChallengeResponse adds or modifies the value of the optional
 // parameter `authChallengeResponse` in the ContinueInterceptedRequest CDP command.
 //
@@ -503,6 +561,14 @@ func (t *ContinueInterceptedRequest) SetAuthChallengeResponse(v AuthChallengeRes
 	return t
 }
 
+// ContinueInterceptedRequestWithAuthChallengeResponse is a functional option which sets the value of the
+// optional parameter `authChallengeResponse` in the ContinueInterceptedRequest CDP command, see SetAuthChallengeResponse.
+func ContinueInterceptedRequestWithAuthChallengeResponse(v AuthChallengeResponse) ContinueInterceptedRequestOption {
+	return func(c *ContinueInterceptedRequest) {
+		c.SetAuthChallengeResponse(v)
+	}
+}
+
 // Do sends the ContinueInterceptedRequest CDP command to a browser,
 // and returns the browser's response.
 func (t *ContinueInterceptedRequest) Do(ctx context.Context) error {
@@ -558,15 +624,25 @@ type DeleteCookies struct {
 
 // NewDeleteCookies constructs a new DeleteCookies struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `DeleteCookiesOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-deleteCookies
-func NewDeleteCookies(name string) *DeleteCookies {
-	return &DeleteCookies{
+func NewDeleteCookies(name string, opts ...DeleteCookiesOption) *DeleteCookies {
+	c := &DeleteCookies{
 		Name: name,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// DeleteCookiesOption is a functional option for the optional
+// parameters of the DeleteCookies CDP command, see the
+// NewDeleteCookies constructor. Options are applied in order.
+type DeleteCookiesOption = func(*DeleteCookies)
+
 // SetURL adds or modifies the value of the optional
 // parameter `url` in the DeleteCookies CDP command.
 //
@@ -577,6 +653,14 @@ func (t *DeleteCookies) SetURL(v string) *DeleteCookies {
 	return t
 }
 
+// DeleteCookiesWithURL is a functional option which sets the value of the
+// optional parameter `url` in the DeleteCookies CDP command, see SetURL.
+func DeleteCookiesWithURL(v string) DeleteCookiesOption {
+	return func(c *DeleteCookies) {
+		c.SetURL(v)
+	}
+}
+
 // SetDomain adds or modifies the value of the optional
 // parameter `domain` in the DeleteCookies CDP command.
 //
@@ -586,6 +670,14 @@ func (t *DeleteCookies) SetDomain(v string) *DeleteCookies {
 	return t
 }
 
+// DeleteCookiesWithDomain is a functional option which sets the value of the
+// optional parameter `domain` in the DeleteCookies CDP command, see SetDomain.
+func DeleteCookiesWithDomain(v string) DeleteCookiesOption {
+	return func(c *DeleteCookies) {
+		c.SetDomain(v)
+	}
+}
+
 // SetPath adds or modifies the value of the optional
 // parameter `path` in the DeleteCookies CDP command.
 //
@@ -595,6 +687,14 @@ func (t *DeleteCookies) SetPath(v string) *DeleteCookies {
 	return t
 }
 
+// DeleteCookiesWithPath is a functional option which sets the value of the
+// optional parameter `path` in the DeleteCookies CDP command, see SetPath.
+func DeleteCookiesWithPath(v string) DeleteCookiesOption {
+	return func(c *DeleteCookies) {
+		c.SetPath(v)
+	}
+}
+
 // Do sends the DeleteCookies CDP command to a browser,
 // and returns the browser's response.
 func (t *DeleteCookies) Do(ctx context.Context) error {
@@ -695,18 +795,28 @@ type EmulateNetworkConditions struct {
 
 // NewEmulateNetworkConditions constructs a new EmulateNetworkConditions struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `EmulateNetworkConditionsOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-emulateNetworkConditions
-func NewEmulateNetworkConditions(offline bool, latency float64, downloadThroughput float64, uploadThroughput float64) *EmulateNetworkConditions {
-	return &EmulateNetworkConditions{
+func NewEmulateNetworkConditions(offline bool, latency float64, downloadThroughput float64, uploadThroughput float64, opts ...EmulateNetworkConditionsOption) *EmulateNetworkConditions {
+	c := &EmulateNetworkConditions{
 		Offline:            offline,
 		Latency:            latency,
 		DownloadThroughput: downloadThroughput,
 		UploadThroughput:   uploadThroughput,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// EmulateNetworkConditionsOption is a functional option for the optional
+// parameters of the EmulateNetworkConditions CDP command, see the
+// NewEmulateNetworkConditions constructor. Options are applied in order.
+type EmulateNetworkConditionsOption = func(*EmulateNetworkConditions)
+
 // SetConnectionType adds or modifies the value of the optional
 // parameter `connectionType` in the EmulateNetworkConditions CDP command.
 //
@@ -716,6 +826,14 @@ func (t *EmulateNetworkConditions) SetConnectionType(v ConnectionType) *EmulateN
 	return t
 }
 
+// EmulateNetworkConditionsWithConnectionType is a functional option which sets the value of the
+// optional parameter `connectionType` in the EmulateNetworkConditions CDP command, see SetConnectionType.
+func EmulateNetworkConditionsWithConnectionType(v ConnectionType) EmulateNetworkConditionsOption {
+	return func(c *EmulateNetworkConditions) {
+		c.SetConnectionType(v)
+	}
+}
+
 // Do sends the EmulateNetworkConditions CDP command to a browser,
 // and returns the browser's response.
 func (t *EmulateNetworkConditions) Do(ctx context.Context) error {
@@ -772,13 +890,23 @@ type Enable struct {
 
 // NewEnable constructs a new Enable struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `EnableOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-enable
-func NewEnable() *Enable {
-	return &Enable{}
+func NewEnable(opts ...EnableOption) *Enable {
+	c := &Enable{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// EnableOption is a functional option for the optional
+// parameters of the Enable CDP command, see the
+// NewEnable constructor. Options are applied in order.
+type EnableOption = func(*Enable)
+
 // SetMaxTotalBufferSize adds or modifies the value of the optional
 // parameter `maxTotalBufferSize` in the Enable CDP command.
 //
@@ -790,6 +918,14 @@ func (t *Enable) SetMaxTotalBufferSize(v int64) *Enable {
 	return t
 }
 
+// EnableWithMaxTotalBufferSize is a functional option which sets the value of the
+// optional parameter `maxTotalBufferSize` in the Enable CDP command, see SetMaxTotalBufferSize.
+func EnableWithMaxTotalBufferSize(v int64) EnableOption {
+	return func(c *Enable) {
+		c.SetMaxTotalBufferSize(v)
+	}
+}
+
 // SetMaxResourceBufferSize adds or modifies the value of the optional
 // parameter `maxResourceBufferSize` in the Enable CDP command.
 //
@@ -801,6 +937,14 @@ func (t *Enable) SetMaxResourceBufferSize(v int64) *Enable {
 	return t
 }
 
+// EnableWithMaxResourceBufferSize is a functional option which sets the value of the
+// optional parameter `maxResourceBufferSize` in the Enable CDP command, see SetMaxResourceBufferSize.
+func EnableWithMaxResourceBufferSize(v int64) EnableOption {
+	return func(c *Enable) {
+		c.SetMaxResourceBufferSize(v)
+	}
+}
+
 // SetMaxPostDataSize adds or modifies the value of the optional
 // parameter `maxPostDataSize` in the Enable CDP command.
 //
@@ -810,6 +954,14 @@ func (t *Enable) SetMaxPostDataSize(v int64) *Enable {
 	return t
 }
 
+// EnableWithMaxPostDataSize is a functional option which sets the value of the
+// optional parameter `maxPostDataSize` in the Enable CDP command, see SetMaxPostDataSize.
+func EnableWithMaxPostDataSize(v int64) EnableOption {
+	return func(c *Enable) {
+		c.SetMaxPostDataSize(v)
+	}
+}
+
 // Do sends the Enable CDP command to a browser,
 // and returns the browser's response.
 func (t *Enable) Do(ctx context.Context) error {
@@ -988,13 +1140,23 @@ type GetCookies struct {
 
 // NewGetCookies constructs a new GetCookies struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetCookiesOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-getCookies
-func NewGetCookies() *GetCookies {
-	return &GetCookies{}
+func NewGetCookies(opts ...GetCookiesOption) *GetCookies {
+	c := &GetCookies{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetCookiesOption is a functional option for the optional
+// parameters of the GetCookies CDP command, see the
+// NewGetCookies constructor. Options are applied in order.
+type GetCookiesOption = func(*GetCookies)
+
 // SetURLs adds or modifies the value of the optional
 // parameter `urls` in the GetCookies CDP command.
 //
@@ -1006,6 +1168,14 @@ func (t *GetCookies) SetURLs(v []string) *GetCookies {
 	return t
 }
 
+// GetCookiesWithURLs is a functional option which sets the value of the
+// optional parameter `urls` in the GetCookies CDP command, see SetURLs.
+func GetCookiesWithURLs(v []string) GetCookiesOption {
+	return func(c *GetCookies) {
+		c.SetURLs(v)
+	}
+}
+
 // GetCookiesResult contains the browser's response
 // to calling the GetCookies CDP command with Do().
 type GetCookiesResult struct {
@@ -1421,18 +1591,28 @@ type SearchInResponseBody struct {
 
 // NewSearchInResponseBody constructs a new SearchInResponseBody struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SearchInResponseBodyOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-searchInResponseBody
 //
 // This CDP method is experimental.
-func NewSearchInResponseBody(requestID string, query string) *SearchInResponseBody {
-	return &SearchInResponseBody{
+func NewSearchInResponseBody(requestID string, query string, opts ...SearchInResponseBodyOption) *SearchInResponseBody {
+	c := &SearchInResponseBody{
 		RequestID: requestID,
 		Query:     query,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SearchInResponseBodyOption is a functional option for the optional
+// parameters of the SearchInResponseBody CDP command, see the
+// NewSearchInResponseBody constructor. Options are applied in order.
+type SearchInResponseBodyOption = func(*SearchInResponseBody)
+
 // SetCaseSensitive adds or modifies the value of the optional
 // parameter `caseSensitive` in the SearchInResponseBody CDP command.
 //
@@ -1442,6 +1622,14 @@ func (t *SearchInResponseBody) SetCaseSensitive(v bool) *SearchInResponseBody {
 	return t
 }
 
+// SearchInResponseBodyWithCaseSensitive is a functional option which sets the value of the
+// optional parameter `caseSensitive` in the SearchInResponseBody CDP command, see SetCaseSensitive.
+func SearchInResponseBodyWithCaseSensitive(v bool) SearchInResponseBodyOption {
+	return func(c *SearchInResponseBody) {
+		c.SetCaseSensitive(v)
+	}
+}
+
 // SetIsRegex adds or modifies the value of the optional
 // parameter `isRegex` in the SearchInResponseBody CDP command.
 //
@@ -1451,6 +1639,14 @@ func (t *SearchInResponseBody) SetIsRegex(v bool) *SearchInResponseBody {
 	return t
 }
 
+// SearchInResponseBodyWithIsRegex is a functional option which sets the value of the
+// optional parameter `isRegex` in the SearchInResponseBody CDP command, see SetIsRegex.
+func SearchInResponseBodyWithIsRegex(v bool) SearchInResponseBodyOption {
+	return func(c *SearchInResponseBody) {
+		c.SetIsRegex(v)
+	}
+}
+
 // SearchInResponseBodyResult contains the browser's response
 // to calling the SearchInResponseBody CDP command with Do().
 type SearchInResponseBodyResult struct {
@@ -1701,7 +1897,7 @@ type SetCookie struct {
 	// Cookie SameSite type.
 	SameSite *CookieSameSite `json:"sameSite,omitempty"`
 	// Cookie expiration date, session cookie if not set
-	Expires float64 `json:"expires,omitempty"`
+	Expires devtools.TimeSinceEpoch `json:"expires,omitempty"`
 	// Cookie Priority type.
 	//
 	// This CDP parameter is experimental.
@@ -1730,16 +1926,26 @@ type SetCookie struct {
 
 // NewSetCookie constructs a new SetCookie struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetCookieOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-setCookie
-func NewSetCookie(name string, value string) *SetCookie {
-	return &SetCookie{
+func NewSetCookie(name string, value string, opts ...SetCookieOption) *SetCookie {
+	c := &SetCookie{
 		Name:  name,
 		Value: value,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetCookieOption is a functional option for the optional
+// parameters of the SetCookie CDP command, see the
+// NewSetCookie constructor. Options are applied in order.
+type SetCookieOption = func(*SetCookie)
+
 // SetURL adds or modifies the value of the optional
 // parameter `url` in the SetCookie CDP command.
 //
@@ -1750,6 +1956,14 @@ func (t *SetCookie) SetURL(v string) *SetCookie {
 	return t
 }
 
+// SetCookieWithURL is a functional option which sets the value of the
+// optional parameter `url` in the SetCookie CDP command, see SetURL.
+func SetCookieWithURL(v string) SetCookieOption {
+	return func(c *SetCookie) {
+		c.SetURL(v)
+	}
+}
+
 // SetDomain adds or modifies the value of the optional
 // parameter `domain` in the SetCookie CDP command.
 //
@@ -1759,6 +1973,14 @@ func (t *SetCookie) SetDomain(v string) *SetCookie {
 	return t
 }
 
+// SetCookieWithDomain is a functional option which sets the value of the
+// optional parameter `domain` in the SetCookie CDP command, see SetDomain.
+func SetCookieWithDomain(v string) SetCookieOption {
+	return func(c *SetCookie) {
+		c.SetDomain(v)
+	}
+}
+
 // SetPath adds or modifies the value of the optional
 // parameter `path` in the SetCookie CDP command.
 //
@@ -1768,6 +1990,14 @@ func (t *SetCookie) SetPath(v string) *SetCookie {
 	return t
 }
 
+// SetCookieWithPath is a functional option which sets the value of the
+// optional parameter `path` in the SetCookie CDP command, see SetPath.
+func SetCookieWithPath(v string) SetCookieOption {
+	return func(c *SetCookie) {
+		c.SetPath(v)
+	}
+}
+
 // SetSecure adds or modifies the value of the optional
 // parameter `secure` in the SetCookie CDP command.
 //
@@ -1777,6 +2007,14 @@ func (t *SetCookie) SetSecure(v bool) *SetCookie {
 	return t
 }
 
+// SetCookieWithSecure is a functional option which sets the value of the
+// optional parameter `secure` in the SetCookie CDP command, see SetSecure.
+func SetCookieWithSecure(v bool) SetCookieOption {
+	return func(c *SetCookie) {
+		c.SetSecure(v)
+	}
+}
+
 // SetHTTPOnly adds or modifies the value of the optional
 // parameter `httpOnly` in the SetCookie CDP command.
 //
@@ -1786,6 +2024,14 @@ func (t *SetCookie) SetHTTPOnly(v bool) *SetCookie {
 	return t
 }
 
+// SetCookieWithHTTPOnly is a functional option which sets the value of the
+// optional parameter `httpOnly` in the SetCookie CDP command, see SetHTTPOnly.
+func SetCookieWithHTTPOnly(v bool) SetCookieOption {
+	return func(c *SetCookie) {
+		c.SetHTTPOnly(v)
+	}
+}
+
 // SetSameSite adds or modifies the value of the optional
 // parameter `sameSite` in the SetCookie CDP command.
 //
@@ -1795,15 +2041,31 @@ func (t *SetCookie) SetSameSite(v CookieSameSite) *SetCookie {
 	return t
 }
 
+// SetCookieWithSameSite is a functional option which sets the value of the
+// optional parameter `sameSite` in the SetCookie CDP command, see SetSameSite.
+func SetCookieWithSameSite(v CookieSameSite) SetCookieOption {
+	return func(c *SetCookie) {
+		c.SetSameSite(v)
+	}
+}
+
 // SetExpires adds or modifies the value of the optional
 // parameter `expires` in the SetCookie CDP command.
 //
 // Cookie expiration date, session cookie if not set
-func (t *SetCookie) SetExpires(v float64) *SetCookie {
+func (t *SetCookie) SetExpires(v devtools.TimeSinceEpoch) *SetCookie {
 	t.Expires = v
 	return t
 }
 
+// SetCookieWithExpires is a functional option which sets the value of the
+// optional parameter `expires` in the SetCookie CDP command, see SetExpires.
+func SetCookieWithExpires(v devtools.TimeSinceEpoch) SetCookieOption {
+	return func(c *SetCookie) {
+		c.SetExpires(v)
+	}
+}
+
 // SetPriority adds or modifies the value of the optional
 // parameter `priority` in the SetCookie CDP command.
 //
@@ -1815,6 +2077,14 @@ func (t *SetCookie) SetPriority(v CookiePriority) *SetCookie {
 	return t
 }
 
+// SetCookieWithPriority is a functional option which sets the value of the
+// optional parameter `priority` in the SetCookie CDP command, see SetPriority.
+func SetCookieWithPriority(v CookiePriority) SetCookieOption {
+	return func(c *SetCookie) {
+		c.SetPriority(v)
+	}
+}
+
 // SetSameParty adds or modifies the value of the optional
 // parameter `sameParty` in the SetCookie CDP command.
 //
@@ -1826,6 +2096,14 @@ func (t *SetCookie) SetSameParty(v bool) *SetCookie {
 	return t
 }
 
+// SetCookieWithSameParty is a functional option which sets the value of the
+// optional parameter `sameParty` in the SetCookie CDP command, see SetSameParty.
+func SetCookieWithSameParty(v bool) SetCookieOption {
+	return func(c *SetCookie) {
+		c.SetSameParty(v)
+	}
+}
+
 // SetSourceScheme adds or modifies the value of the optional
 // parameter `sourceScheme` in the SetCookie CDP command.
 //
@@ -1837,6 +2115,14 @@ func (t *SetCookie) SetSourceScheme(v CookieSourceScheme) *SetCookie {
 	return t
 }
 
+// SetCookieWithSourceScheme is a functional option which sets the value of the
+// optional parameter `sourceScheme` in the SetCookie CDP command, see SetSourceScheme.
+func SetCookieWithSourceScheme(v CookieSourceScheme) SetCookieOption {
+	return func(c *SetCookie) {
+		c.SetSourceScheme(v)
+	}
+}
+
 // SetSourcePort adds or modifies the value of the optional
 // parameter `sourcePort` in the SetCookie CDP command.
 //
@@ -1850,6 +2136,14 @@ func (t *SetCookie) SetSourcePort(v int64) *SetCookie {
 	return t
 }
 
+// SetCookieWithSourcePort is a functional option which sets the value of the
+// optional parameter `sourcePort` in the SetCookie CDP command, see SetSourcePort.
+func SetCookieWithSourcePort(v int64) SetCookieOption {
+	return func(c *SetCookie) {
+		c.SetSourcePort(v)
+	}
+}
+
 // SetPartitionKey adds or modifies the value of the optional
 // parameter `partitionKey` in the SetCookie CDP command.
 //
@@ -1863,6 +2157,14 @@ func (t *SetCookie) SetPartitionKey(v string) *SetCookie {
 	return t
 }
 
+// SetCookieWithPartitionKey is a functional option which sets the value of the
+// optional parameter `partitionKey` in the SetCookie CDP command, see SetPartitionKey.
+func SetCookieWithPartitionKey(v string) SetCookieOption {
+	return func(c *SetCookie) {
+		c.SetPartitionKey(v)
+	}
+}
+
 // SetCookieResult contains the browser's response
 // to calling the SetCookie CDP command with Do().
 type SetCookieResult struct {
@@ -2166,15 +2468,25 @@ type GetSecurityIsolationStatus struct {
 
 // NewGetSecurityIsolationStatus constructs a new GetSecurityIsolationStatus struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetSecurityIsolationStatusOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-getSecurityIsolationStatus
 //
 // This CDP method is experimental.
-func NewGetSecurityIsolationStatus() *GetSecurityIsolationStatus {
-	return &GetSecurityIsolationStatus{}
+func NewGetSecurityIsolationStatus(opts ...GetSecurityIsolationStatusOption) *GetSecurityIsolationStatus {
+	c := &GetSecurityIsolationStatus{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetSecurityIsolationStatusOption is a functional option for the optional
+// parameters of the GetSecurityIsolationStatus CDP command, see the
+// NewGetSecurityIsolationStatus constructor. Options are applied in order.
+type GetSecurityIsolationStatusOption = func(*GetSecurityIsolationStatus)
+
 // SetFrameID adds or modifies the value of the optional
 // parameter `frameId` in the GetSecurityIsolationStatus CDP command.
 //
@@ -2184,6 +2496,14 @@ func (t *GetSecurityIsolationStatus) SetFrameID(v string) *GetSecurityIsolationS
 	return t
 }
 
+// GetSecurityIsolationStatusWithFrameID is a functional option which sets the value of the
+// optional parameter `frameId` in the GetSecurityIsolationStatus CDP command, see SetFrameID.
+func GetSecurityIsolationStatusWithFrameID(v string) GetSecurityIsolationStatusOption {
+	return func(c *GetSecurityIsolationStatus) {
+		c.SetFrameID(v)
+	}
+}
+
 // GetSecurityIsolationStatusResult contains the browser's response
 // to calling the GetSecurityIsolationStatus CDP command with Do().
 type GetSecurityIsolationStatusResult struct {
@@ -2311,18 +2631,28 @@ type LoadNetworkResource struct {
 
 // NewLoadNetworkResource constructs a new LoadNetworkResource struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `LoadNetworkResourceOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#method-loadNetworkResource
 //
 // This CDP method is experimental.
-func NewLoadNetworkResource(url string, options LoadNetworkResourceOptions) *LoadNetworkResource {
-	return &LoadNetworkResource{
+func NewLoadNetworkResource(url string, options LoadNetworkResourceOptions, opts ...LoadNetworkResourceOption) *LoadNetworkResource {
+	c := &LoadNetworkResource{
 		URL:     url,
 		Options: options,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// LoadNetworkResourceOption is a functional option for the optional
+// parameters of the LoadNetworkResource CDP command, see the
+// NewLoadNetworkResource constructor. Options are applied in order.
+type LoadNetworkResourceOption = func(*LoadNetworkResource)
+
 // SetFrameID adds or modifies the value of the optional
 // parameter `frameId` in the LoadNetworkResource CDP command.
 //
@@ -2333,6 +2663,14 @@ func (t *LoadNetworkResource) SetFrameID(v string) *LoadNetworkResource {
 	return t
 }
 
+// LoadNetworkResourceWithFrameID is a functional option which sets the value of the
+// optional parameter `frameId` in the LoadNetworkResource CDP command, see SetFrameID.
+func LoadNetworkResourceWithFrameID(v string) LoadNetworkResourceOption {
+	return func(c *LoadNetworkResource) {
+		c.SetFrameID(v)
+	}
+}
+
 // LoadNetworkResourceResult contains the browser's response
 // to calling the LoadNetworkResource CDP command with Do().
 type LoadNetworkResourceResult struct {
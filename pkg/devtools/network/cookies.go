@@ -0,0 +1,205 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// The Netscape cookie file format (cookies.txt), which is used by curl, wget,
+// and many cookie stores, has one cookie per line, with tab-separated fields:
+// domain, include subdomains, path, secure, expiration time (Unix seconds,
+// or 0 for session cookies), name, value. Lines which start with "#" are
+// comments, except for the "#HttpOnly_" prefix which curl adds to the
+// domains of HTTP-only cookies.
+const (
+	netscapeHeader   = "# Netscape HTTP Cookie File\n"
+	netscapeHTTPOnly = "#HttpOnly_"
+)
+
+// CookieFilter selects cookies in the `network.ExportCookiesNetscape` and
+// `network.DeleteCookiesMatching` functions, see the
+// `network.CookieDomainSuffix` and `network.CookieDomainRegexp` functions.
+type CookieFilter = func(*Cookie) bool
+
+// CookieDomainSuffix returns a filter of cookies whose domain is the given
+// domain (e.g. "example.com"), or one of its subdomains (e.g.
+// "www.example.com"), regardless of a leading dot.
+func CookieDomainSuffix(domain string) CookieFilter {
+	domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+	return func(c *Cookie) bool {
+		d := strings.ToLower(strings.TrimPrefix(c.Domain, "."))
+		return d == domain || strings.HasSuffix(d, "."+domain)
+	}
+}
+
+// CookieDomainRegexp returns a filter of cookies whose domain matches
+// the given regular expression, without a leading dot.
+func CookieDomainRegexp(re *regexp.Regexp) CookieFilter {
+	return func(c *Cookie) bool {
+		return re.MatchString(strings.TrimPrefix(c.Domain, "."))
+	}
+}
+
+// Return all the browser cookies which match all the given filters.
+func filterCookies(ctx context.Context, filters []CookieFilter) ([]Cookie, error) {
+	r, err := NewGetAllCookies().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var cookies []Cookie
+	for i := range r.Cookies {
+		if matchesCookie(&r.Cookies[i], filters) {
+			cookies = append(cookies, r.Cookies[i])
+		}
+	}
+	return cookies, nil
+}
+
+func matchesCookie(c *Cookie, filters []CookieFilter) bool {
+	for _, f := range filters {
+		if !f(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// DeleteCookiesMatching deletes all the browser cookies which match all the
+// given filters (e.g. `network.CookieDomainSuffix`), in all the browser's
+// tabs, and returns the number of deleted cookies.
+func DeleteCookiesMatching(ctx context.Context, filters ...CookieFilter) (int, error) {
+	cookies, err := filterCookies(ctx, filters)
+	if err != nil {
+		return 0, err
+	}
+	for i, c := range cookies {
+		d := NewDeleteCookies(c.Name).SetDomain(c.Domain).SetPath(c.Path)
+		if err := d.Do(ctx); err != nil {
+			return i, fmt.Errorf("failed to delete cookie %q of %s: %w", c.Name, c.Domain, err)
+		}
+	}
+	return len(cookies), nil
+}
+
+// ExportCookiesNetscape writes all the browser cookies which match all the
+// given filters (if any) to the given writer, in the Netscape cookie file
+// format (cookies.txt) which curl and wget read, and returns the number of
+// exported cookies. See the `network.WriteNetscapeCookies` function.
+func ExportCookiesNetscape(ctx context.Context, w io.Writer, filters ...CookieFilter) (int, error) {
+	cookies, err := filterCookies(ctx, filters)
+	if err != nil {
+		return 0, err
+	}
+	return len(cookies), WriteNetscapeCookies(w, cookies)
+}
+
+// ImportCookiesNetscape reads cookies in the Netscape cookie file format
+// (cookies.txt) from the given reader, e.g. a file which curl wrote, sets
+// them in the browser, and returns the number of imported cookies. Expired
+// cookies are skipped. See the `network.ParseNetscapeCookies` function.
+func ImportCookiesNetscape(ctx context.Context, r io.Reader) (int, error) {
+	cookies, err := ParseNetscapeCookies(r)
+	if err != nil {
+		return 0, err
+	}
+	var valid []CookieParam
+	now := devtools.NewTimeSinceEpoch(time.Now())
+	for _, c := range cookies {
+		if c.Expires == 0 || c.Expires > now {
+			valid = append(valid, c)
+		}
+	}
+	if len(valid) == 0 {
+		return 0, nil
+	}
+	if err := NewSetCookies(valid).Do(ctx); err != nil {
+		return 0, err
+	}
+	return len(valid), nil
+}
+
+// WriteNetscapeCookies writes the given cookies in the Netscape cookie file
+// format (cookies.txt), with a header line. HTTP-only cookies are written
+// with curl's "#HttpOnly_" prefix, and session cookies expire at 0.
+func WriteNetscapeCookies(w io.Writer, cookies []Cookie) error {
+	b := &strings.Builder{}
+	b.WriteString(netscapeHeader)
+	for _, c := range cookies {
+		domain := c.Domain
+		if c.HTTPOnly {
+			domain = netscapeHTTPOnly + domain
+		}
+		expires := int64(0)
+		if !c.Session && c.Expires > 0 {
+			expires = int64(math.Ceil(c.Expires))
+		}
+		fmt.Fprintf(b, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n", domain, netscapeBool(strings.HasPrefix(c.Domain, ".")),
+			c.Path, netscapeBool(c.Secure), expires, c.Name, c.Value)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func netscapeBool(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// ParseNetscapeCookies parses cookies in the Netscape cookie file format
+// (cookies.txt), e.g. for the `network.SetCookies` CDP command. Cookies
+// which don't include subdomains are scoped to a URL which is based on their
+// domain, path and security, instead of a domain, so the browser doesn't
+// share them with subdomains.
+func ParseNetscapeCookies(r io.Reader) ([]CookieParam, error) {
+	var cookies []CookieParam
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimRight(s.Text(), "\r")
+		httpOnly := strings.HasPrefix(line, netscapeHTTPOnly)
+		line = strings.TrimPrefix(line, netscapeHTTPOnly)
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f := strings.Split(line, "\t")
+		if len(f) == 6 {
+			f = append(f, "") // Some writers omit empty values.
+		}
+		if len(f) != 7 {
+			return nil, fmt.Errorf("line %d: %d fields instead of 7", n, len(f))
+		}
+		expires, err := strconv.ParseFloat(f[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid expiration time %q", n, f[4])
+		}
+		c := CookieParam{
+			Name:     f[5],
+			Value:    f[6],
+			Path:     f[2],
+			Secure:   strings.EqualFold(f[3], "TRUE"),
+			HTTPOnly: httpOnly,
+			Expires:  devtools.TimeSinceEpoch(expires),
+		}
+		if strings.EqualFold(f[1], "TRUE") {
+			c.Domain = "." + strings.TrimPrefix(f[0], ".")
+		} else {
+			scheme := "http"
+			if c.Secure {
+				scheme = "https"
+			}
+			c.URL = fmt.Sprintf("%s://%s%s", scheme, strings.TrimPrefix(f[0], "."), c.Path)
+		}
+		cookies = append(cookies, c)
+	}
+	return cookies, s.Err()
+}
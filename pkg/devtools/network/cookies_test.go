@@ -0,0 +1,88 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/google/go-cmp/cmp"
+)
+
+var testCookies = []Cookie{
+	{Name: "a", Value: "1", Domain: ".example.com", Path: "/", Expires: 1900000000.5, Secure: true},
+	{Name: "b", Value: "2", Domain: "www.example.com", Path: "/app", Expires: -1, Session: true, HTTPOnly: true},
+	{Name: "c", Value: "3", Domain: "other.org", Path: "/", Expires: -1, Session: true},
+}
+
+func TestExportCookiesNetscape(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	b.Respond("Network.getAllCookies", map[string]interface{}{"cookies": testCookies})
+
+	buf := &bytes.Buffer{}
+	n, err := ExportCookiesNetscape(ctx, buf, CookieDomainSuffix("example.com"))
+	if err != nil {
+		t.Fatalf("ExportCookiesNetscape(); got error: %v", err)
+	}
+	want := "# Netscape HTTP Cookie File\n" +
+		".example.com\tTRUE\t/\tTRUE\t1900000001\ta\t1\n" +
+		"#HttpOnly_www.example.com\tFALSE\t/app\tFALSE\t0\tb\t2\n"
+	if n != 2 || buf.String() != want {
+		t.Errorf("ExportCookiesNetscape() = %d, %q, want 2, %q", n, buf.String(), want)
+	}
+}
+
+func TestImportCookiesNetscape(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	in := "# Netscape HTTP Cookie File\n\n" +
+		".example.com\tTRUE\t/\tTRUE\t1900000001\ta\t1\n" +
+		"#HttpOnly_www.example.com\tFALSE\t/app\tFALSE\t0\tb\t2\r\n" +
+		"old.com\tFALSE\t/\tFALSE\t1000\texpired\tx\n"
+	n, err := ImportCookiesNetscape(ctx, strings.NewReader(in))
+	if err != nil || n != 2 {
+		t.Fatalf("ImportCookiesNetscape() = %d, %v, want 2", n, err)
+	}
+	calls := b.Calls("Network.setCookies")
+	if len(calls) != 1 {
+		t.Fatalf("got %d Network.setCookies commands, want 1", len(calls))
+	}
+	got := &SetCookies{}
+	if err := json.Unmarshal(calls[0].Params, got); err != nil {
+		t.Fatal(err)
+	}
+	want := []CookieParam{
+		{Name: "a", Value: "1", Domain: ".example.com", Path: "/", Secure: true, Expires: 1900000001},
+		{Name: "b", Value: "2", URL: "http://www.example.com/app", Path: "/app", HTTPOnly: true},
+	}
+	if diff := cmp.Diff(want, got.Cookies); diff != "" {
+		t.Errorf("ImportCookiesNetscape() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := ParseNetscapeCookies(strings.NewReader("a\tb\n")); err == nil {
+		t.Errorf("ParseNetscapeCookies(malformed line); got no error")
+	}
+}
+
+func TestDeleteCookiesMatching(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	b.Respond("Network.getAllCookies", map[string]interface{}{"cookies": testCookies})
+
+	n, err := DeleteCookiesMatching(ctx, CookieDomainRegexp(regexp.MustCompile(`^(www\.example\.com|other\.org)$`)))
+	if err != nil || n != 2 {
+		t.Fatalf("DeleteCookiesMatching() = %d, %v, want 2", n, err)
+	}
+	var got []string
+	for _, c := range b.Calls("Network.deleteCookies") {
+		got = append(got, string(c.Params))
+	}
+	want := []string{
+		`{"name":"b","domain":"www.example.com","path":"/app"}`,
+		`{"name":"c","domain":"other.org","path":"/"}`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Network.deleteCookies parameters mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -1,5 +1,7 @@
 package network
 
+import "github.com/daabr/chrome-vision/pkg/devtools"
+
 // DataReceived asynchronous event. Fired when data chunk was received over the network.
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#event-dataReceived
@@ -7,7 +9,7 @@ type DataReceived struct {
 	// Request identifier.
 	RequestID string `json:"requestId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 	// Data chunk length.
 	DataLength int64 `json:"dataLength"`
 	// Actual bytes received (might be less than dataLength for compressed encodings).
@@ -21,7 +23,7 @@ type EventSourceMessageReceived struct {
 	// Request identifier.
 	RequestID string `json:"requestId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 	// Message type.
 	EventName string `json:"eventName"`
 	// Message identifier.
@@ -37,7 +39,7 @@ type LoadingFailed struct {
 	// Request identifier.
 	RequestID string `json:"requestId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 	// Resource type.
 	Type ResourceType `json:"type"`
 	// User friendly error message.
@@ -57,7 +59,7 @@ type LoadingFinished struct {
 	// Request identifier.
 	RequestID string `json:"requestId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 	// Total number of bytes received for this request.
 	EncodedDataLength float64 `json:"encodedDataLength"`
 	// Set when 1) response was blocked by Cross-Origin Read Blocking and also
@@ -128,9 +130,9 @@ type RequestWillBeSent struct {
 	// Request data.
 	Request Request `json:"request"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 	// Timestamp.
-	WallTime float64 `json:"wallTime"`
+	WallTime devtools.TimeSinceEpoch `json:"wallTime"`
 	// Request initiator.
 	Initiator Initiator `json:"initiator"`
 	// In the case that redirectResponse is populated, this flag indicates whether
@@ -160,7 +162,7 @@ type ResourceChangedPriority struct {
 	// New priority
 	NewPriority ResourcePriority `json:"newPriority"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 }
 
 // SignedExchangeReceived asynchronous event. Fired when a signed exchange was received over the network
@@ -184,7 +186,7 @@ type ResponseReceived struct {
 	// Loader identifier. Empty string if the request is fetched from worker.
 	LoaderID string `json:"loaderId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 	// Resource type.
 	Type ResourceType `json:"type"`
 	// Response data.
@@ -205,7 +207,7 @@ type WebSocketClosed struct {
 	// Request identifier.
 	RequestID string `json:"requestId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 }
 
 // WebSocketCreated asynchronous event. Fired upon WebSocket creation.
@@ -227,7 +229,7 @@ type WebSocketFrameError struct {
 	// Request identifier.
 	RequestID string `json:"requestId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 	// WebSocket error message.
 	ErrorMessage string `json:"errorMessage"`
 }
@@ -239,7 +241,7 @@ type WebSocketFrameReceived struct {
 	// Request identifier.
 	RequestID string `json:"requestId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 	// WebSocket response data.
 	Response WebSocketFrame `json:"response"`
 }
@@ -251,7 +253,7 @@ type WebSocketFrameSent struct {
 	// Request identifier.
 	RequestID string `json:"requestId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 	// WebSocket response data.
 	Response WebSocketFrame `json:"response"`
 }
@@ -263,7 +265,7 @@ type WebSocketHandshakeResponseReceived struct {
 	// Request identifier.
 	RequestID string `json:"requestId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 	// WebSocket response data.
 	Response WebSocketResponse `json:"response"`
 }
@@ -275,9 +277,9 @@ type WebSocketWillSendHandshakeRequest struct {
 	// Request identifier.
 	RequestID string `json:"requestId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 	// UTC Timestamp.
-	WallTime float64 `json:"wallTime"`
+	WallTime devtools.TimeSinceEpoch `json:"wallTime"`
 	// WebSocket request data.
 	Request WebSocketRequest `json:"request"`
 }
@@ -291,7 +293,7 @@ type WebTransportCreated struct {
 	// WebTransport request URL.
 	URL string `json:"url"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 	// Request initiator.
 	Initiator *Initiator `json:"initiator,omitempty"`
 }
@@ -303,7 +305,7 @@ type WebTransportConnectionEstablished struct {
 	// WebTransport identifier.
 	TransportID string `json:"transportId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 }
 
 // WebTransportClosed asynchronous event. Fired when WebTransport is disposed.
@@ -313,7 +315,7 @@ type WebTransportClosed struct {
 	// WebTransport identifier.
 	TransportID string `json:"transportId"`
 	// Timestamp.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 }
 
 // RequestWillBeSentExtraInfo asynchronous event. Fired when additional information about a requestWillBeSent event is available from the
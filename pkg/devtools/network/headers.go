@@ -0,0 +1,122 @@
+package network
+
+import (
+	"net/http"
+	"net/textproto"
+	"sort"
+	"strings"
+)
+
+// The browser joins the values of repeated response headers with newlines
+// in `network.Headers` maps, because some of them (e.g. "Set-Cookie") may
+// contain commas. Values which are added with the `Headers.Add` method are
+// joined the same way only for these headers, and with commas otherwise,
+// because request headers can't contain newlines.
+var newlineSeparatedHeaders = map[string]bool{
+	"Set-Cookie":         true,
+	"Www-Authenticate":   true,
+	"Proxy-Authenticate": true,
+}
+
+// Get returns the value of the given header, case-insensitively, or an
+// empty string if it's not set. Values of repeated response headers are
+// joined with newlines, see the `Headers.Values` method.
+func (h Headers) Get(name string) string {
+	if v, ok := h[name]; ok {
+		return v
+	}
+	for k, v := range h {
+		if strings.EqualFold(k, name) {
+			return v
+		}
+	}
+	return ""
+}
+
+// Values returns the values of the given header, case-insensitively,
+// e.g. one per cookie in "Set-Cookie" response headers, or nil
+// if it's not set.
+func (h Headers) Values(name string) []string {
+	k, ok := h.key(name)
+	if !ok {
+		return nil
+	}
+	return strings.Split(h[k], "\n")
+}
+
+// Set sets the value of the given header with canonical casing (see
+// `textproto.CanonicalMIMEHeaderKey`), replacing any existing
+// value, regardless of its name's casing.
+func (h Headers) Set(name, value string) {
+	h.Del(name)
+	h[textproto.CanonicalMIMEHeaderKey(name)] = value
+}
+
+// Add adds a value to the given header, case-insensitively, or sets it
+// with canonical casing if it's not set yet (see `Headers.Set`).
+func (h Headers) Add(name, value string) {
+	k, ok := h.key(name)
+	if !ok {
+		h.Set(name, value)
+		return
+	}
+	sep := ", "
+	if newlineSeparatedHeaders[textproto.CanonicalMIMEHeaderKey(name)] {
+		sep = "\n"
+	}
+	h[k] += sep + value
+}
+
+// Del deletes the given header, case-insensitively.
+func (h Headers) Del(name string) {
+	for k := range h {
+		if strings.EqualFold(k, name) {
+			delete(h, k)
+		}
+	}
+}
+
+// Return the key of the given header in the map, case-insensitively.
+func (h Headers) key(name string) (string, bool) {
+	if _, ok := h[name]; ok {
+		return name, true
+	}
+	for k := range h {
+		if strings.EqualFold(k, name) {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// HTTPHeader converts the headers into a Go `http.Header` map, with canonical
+// casing, and with separate values for repeated response headers (see
+// `Headers.Values`). Use `fetch.HeaderEntries` to convert the result into
+// CDP header entries, e.g. to fulfill a paused request.
+func (h Headers) HTTPHeader() http.Header {
+	hh := make(http.Header, len(h))
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys) // Deterministic order of values from duplicate keys.
+	for _, k := range keys {
+		for _, v := range strings.Split(h[k], "\n") {
+			hh.Add(k, v)
+		}
+	}
+	return hh
+}
+
+// HeadersFromHTTP converts a Go `http.Header` map into a `network.Headers` map,
+// with canonical casing, e.g. for the `network.SetExtraHTTPHeaders` command.
+// Multiple values are joined as described in the `Headers.Add` method.
+func HeadersFromHTTP(hh http.Header) Headers {
+	h := make(Headers, len(hh))
+	for k, vs := range hh {
+		for _, v := range vs {
+			h.Add(k, v)
+		}
+	}
+	return h
+}
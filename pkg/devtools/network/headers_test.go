@@ -0,0 +1,41 @@
+package network
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHeaders(t *testing.T) {
+	h := Headers{}
+	if err := json.Unmarshal([]byte(`{"content-type":"text/html","set-cookie":"a=1\nb=2"}`), &h); err != nil {
+		t.Fatalf("json.Unmarshal(); got error: %v", err)
+	}
+	if got := h.Get("Content-Type"); got != "text/html" {
+		t.Errorf("Get() = %q, want %q", got, "text/html")
+	}
+	if diff := cmp.Diff([]string{"a=1", "b=2"}, h.Values("Set-Cookie")); diff != "" {
+		t.Errorf("Values() mismatch (-want +got):\n%s", diff)
+	}
+
+	h.Set("CONTENT-TYPE", "text/plain")
+	h.Add("set-cookie", "c=3")
+	h.Add("x-a", "1")
+	h.Add("X-A", "2")
+	h.Del("missing")
+	want := Headers{"Content-Type": "text/plain", "set-cookie": "a=1\nb=2\nc=3", "X-A": "1, 2"}
+	if diff := cmp.Diff(want, h); diff != "" {
+		t.Errorf("Headers mismatch (-want +got):\n%s", diff)
+	}
+
+	hh := http.Header{"Content-Type": {"text/plain"}, "Set-Cookie": {"a=1", "b=2", "c=3"}, "X-A": {"1, 2"}}
+	if diff := cmp.Diff(hh, h.HTTPHeader()); diff != "" {
+		t.Errorf("HTTPHeader() mismatch (-want +got):\n%s", diff)
+	}
+	want = Headers{"Content-Type": "text/plain", "Set-Cookie": "a=1\nb=2\nc=3", "X-A": "1, 2"}
+	if diff := cmp.Diff(want, HeadersFromHTTP(hh)); diff != "" {
+		t.Errorf("HeadersFromHTTP() mismatch (-want +got):\n%s", diff)
+	}
+}
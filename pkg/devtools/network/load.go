@@ -0,0 +1,72 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+
+	cdpio "github.com/daabr/chrome-vision/pkg/devtools/io"
+)
+
+// LoadedResource is the response to a request which was sent with the
+// `network.LoadResource` function.
+type LoadedResource struct {
+	// HTTP status code of the response.
+	StatusCode int
+	// Response headers.
+	Headers Headers
+	// Body of the response, if there is one. Its MIME type and charset are
+	// based on the Content-Type header, see the `network.NewBody` function.
+	Body *Body
+}
+
+// Header returns the value of the given response header, case-insensitively.
+func (r *LoadedResource) Header(name string) string {
+	return r.Headers.Get(name)
+}
+
+// LoadResource fetches the given URL through the network stack of the
+// browser associated with the given context, with the
+// `network.LoadNetworkResource` CDP command, as if it were requested by the
+// frame with the given ID (empty for workers): with its cookies, proxy and
+// credentials, but without affecting the page or its network events. This is
+// useful for auxiliary resources, e.g. "robots.txt" and sitemap files.
+//
+// It returns an error only if there's no HTTP response at all (e.g. DNS
+// or connection errors). HTTP error responses (e.g. 404) are returned as
+// resources, and callers should check their status codes.
+func LoadResource(ctx context.Context, frameID, url string) (*LoadedResource, error) {
+	params := NewLoadNetworkResource(url, LoadNetworkResourceOptions{IncludeCredentials: true})
+	if frameID != "" {
+		params.SetFrameID(frameID)
+	}
+	result, err := params.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := result.Resource
+	if res.HTTPStatusCode == 0 {
+		if res.Stream != "" {
+			cdpio.NewClose(res.Stream).Do(ctx)
+		}
+		return nil, fmt.Errorf("failed to load %s: %s (%v)", url, res.NetErrorName, res.NetError)
+	}
+	r := &LoadedResource{StatusCode: int(res.HTTPStatusCode)}
+	if res.Headers != nil {
+		r.Headers = *res.Headers
+	}
+	if res.Stream == "" {
+		return r, nil
+	}
+	stream := cdpio.OpenReader(ctx, res.Stream)
+	content, err := ioutil.ReadAll(stream)
+	if closeErr := stream.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", url, err)
+	}
+	r.Body = newBody(content, false, r.Header("Content-Type"))
+	return r, nil
+}
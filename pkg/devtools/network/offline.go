@@ -0,0 +1,185 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/target"
+)
+
+// Types of targets which send network requests, and can emulate network
+// conditions in their own CDP sessions.
+var offlineTargetTypes = []string{"page", "iframe", "worker", "shared_worker", "service_worker"}
+
+// ContextOffline emulates internet disconnection coherently in all the
+// targets of a browser context: tabs, out-of-process iframes and workers
+// (including service workers), not only in a single tab, so multi-tab
+// offline/online transition tests behave like a real disconnection.
+// Targets which are created in the browser context while it's offline
+// become offline too, shortly after they start. See the
+// `network.NewContextOffline` function.
+//
+// Network conditions are emulated per CDP session, so targets other than
+// the tab of the given context are kept attached while the browser context
+// is offline. Multiple goroutines may use it simultaneously.
+type ContextOffline struct {
+	ctx              context.Context
+	targetID         string
+	browserContextID string
+	stop             context.CancelFunc
+
+	mu       sync.Mutex
+	offline  bool
+	sessions map[string]context.Context // By target ID, excluding the tab's.
+}
+
+// NewContextOffline returns a controller of the internet connection of all the
+// targets in the browser context of the tab associated with the given context
+// (e.g. the default browser context, or one which was created by
+// `devtools.NewParallel`). They're online initially, and they're not affected
+// until `ContextOffline.SetOffline` is called. Callers must call
+// `ContextOffline.Close` when they're done with it.
+func NewContextOffline(ctx context.Context) (*ContextOffline, error) {
+	s, ok := devtools.FromContext(ctx)
+	if !ok {
+		return nil, errors.New("context not initialized with devtools.NewContext")
+	}
+	info, err := target.NewGetTargetInfo().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c := &ContextOffline{
+		ctx:              ctx,
+		targetID:         s.TargetID.Read(),
+		browserContextID: info.TargetInfo.BrowserContextID,
+		sessions:         make(map[string]context.Context),
+	}
+	watchCtx, stop := context.WithCancel(ctx)
+	events, err := devtools.WatchTargets(watchCtx, devtools.TargetTypes(offlineTargetTypes...),
+		devtools.TargetFilter(func(t *devtools.TargetInfo) bool {
+			return t.BrowserContextID == c.browserContextID
+		}))
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	c.stop = stop
+	go c.watch(events)
+	return c, nil
+}
+
+// SetOffline emulates internet disconnection (or reconnection) in all the
+// targets of the browser context. Going online also resets other network
+// conditions which were emulated in the tab (see `network.EmulateNetworkConditions`),
+// and detaches from the other targets. It tries to update all the targets
+// even if some of them fail (e.g. because they were just closed), and
+// returns the first error.
+func (c *ContextOffline) SetOffline(offline bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offline = offline
+	targets, err := target.NewGetTargets().Do(c.ctx)
+	if err != nil {
+		return err
+	}
+	var firstErr error
+	for _, t := range targets.TargetInfos {
+		if c.matches(t.Type, t.BrowserContextID) {
+			if err := c.apply(t.TargetID); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if !offline {
+		for id, ctx := range c.sessions {
+			s, _ := devtools.FromContext(ctx)
+			if err := target.NewDetachFromTarget().SetSessionID(s.SessionID.Read()).Do(c.ctx); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			delete(c.sessions, id)
+		}
+	}
+	return firstErr
+}
+
+// Offline reports whether the browser context is currently offline.
+func (c *ContextOffline) Offline() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.offline
+}
+
+// Close stops tracking new targets, and brings the
+// browser context back online, if it's offline.
+func (c *ContextOffline) Close() error {
+	c.stop()
+	if !c.Offline() {
+		return nil
+	}
+	return c.SetOffline(false)
+}
+
+func (c *ContextOffline) matches(targetType, browserContextID string) bool {
+	if browserContextID != c.browserContextID {
+		return false
+	}
+	for _, t := range offlineTargetTypes {
+		if t == targetType {
+			return true
+		}
+	}
+	return false
+}
+
+// Emulate the current connection state in the given target, attaching
+// to it if needed. Call only while holding the lock.
+func (c *ContextOffline) apply(targetID string) error {
+	ctx := c.ctx
+	if targetID != c.targetID {
+		var ok bool
+		if ctx, ok = c.sessions[targetID]; !ok {
+			if !c.offline {
+				return nil // Not attached, so it's already online.
+			}
+			r, err := target.NewAttachToTarget(targetID).SetFlatten(true).Do(c.ctx)
+			if err != nil {
+				return fmt.Errorf("failed to attach to target %s: %v", targetID, err)
+			}
+			if ctx, err = devtools.WithAttachedSession(c.ctx, targetID, r.SessionID); err != nil {
+				return err
+			}
+			c.sessions[targetID] = ctx
+		}
+	}
+	// -1 disables throttling.
+	if err := NewEmulateNetworkConditions(c.offline, 0, -1, -1).Do(ctx); err != nil {
+		return fmt.Errorf("failed to emulate network conditions in target %s: %v", targetID, err)
+	}
+	return nil
+}
+
+// Apply the current connection state to new targets, and forget
+// destroyed ones, until the watch is stopped.
+func (c *ContextOffline) watch(events <-chan *devtools.TargetEvent) {
+	for e := range events {
+		c.mu.Lock()
+		switch e.Kind {
+		case devtools.TargetCreated:
+			// Existing targets are reported too, and they may
+			// have been updated already by `SetOffline`.
+			_, attached := c.sessions[e.Target.TargetID]
+			if c.offline && !attached && e.Target.TargetID != c.targetID {
+				if err := c.apply(e.Target.TargetID); err != nil {
+					log.Printf("Failed to make new target offline: %v", err)
+				}
+			}
+		case devtools.TargetDestroyed:
+			delete(c.sessions, e.Target.TargetID)
+		}
+		c.mu.Unlock()
+	}
+}
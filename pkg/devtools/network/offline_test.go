@@ -0,0 +1,110 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/google/go-cmp/cmp"
+)
+
+// A fake browser with 2 browser contexts, which records the commands that
+// change network conditions or detach, as "<session ID> <method> <params>".
+type fakeTargets struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (f *fakeTargets) handle(ctx context.Context, method string, params json.RawMessage) (*devtools.Message, error) {
+	result := `{}`
+	switch method {
+	case "Target.getTargetInfo":
+		result = `{"targetInfo":{"targetId":"FAKE_TARGET_ID","type":"page","browserContextId":"A"}}`
+	case "Target.getTargets":
+		result = `{"targetInfos":[
+			{"targetId":"FAKE_TARGET_ID","type":"page","browserContextId":"A"},
+			{"targetId":"2","type":"page","browserContextId":"A"},
+			{"targetId":"3","type":"service_worker","browserContextId":"A"},
+			{"targetId":"4","type":"page","browserContextId":"B"},
+			{"targetId":"5","type":"browser"}]}`
+	case "Target.attachToTarget":
+		p := struct{ TargetID string }{}
+		json.Unmarshal(params, &p)
+		result = `{"sessionId":"S` + p.TargetID + `"}`
+	case "Network.emulateNetworkConditions", "Target.detachFromTarget":
+		s, _ := devtools.FromContext(ctx)
+		f.mu.Lock()
+		f.calls = append(f.calls, s.SessionID.Read()+" "+method+" "+string(params))
+		f.mu.Unlock()
+	}
+	return &devtools.Message{Result: json.RawMessage(result)}, nil
+}
+
+// Return and reset the recorded calls, in sorted order.
+func (f *fakeTargets) flush() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	calls := f.calls
+	f.calls = nil
+	sort.Strings(calls)
+	return calls
+}
+
+func TestContextOffline(t *testing.T) {
+	f := &fakeTargets{}
+	ctx := devtools.NewFakeContext(context.Background(), f.handle)
+	c, err := NewContextOffline(ctx)
+	if err != nil {
+		t.Fatalf("NewContextOffline(); got error: %v", err)
+	}
+
+	if err := c.SetOffline(true); err != nil {
+		t.Fatalf("SetOffline(true); got error: %v", err)
+	}
+	offline := ` Network.emulateNetworkConditions {"offline":true,"latency":0,"downloadThroughput":-1,"uploadThroughput":-1}`
+	want := []string{"FAKE_SESSION_ID" + offline, "S2" + offline, "S3" + offline}
+	if diff := cmp.Diff(want, f.flush()); diff != "" {
+		t.Errorf("SetOffline(true) calls mismatch (-want +got):\n%s", diff)
+	}
+
+	// New targets in the same browser context become offline too.
+	for _, id := range []string{"6", "7"} {
+		e := &devtools.Message{Method: "Target.targetCreated", Params: json.RawMessage(
+			`{"targetInfo":{"targetId":"` + id + `","type":"page","browserContextId":"` + map[string]string{"6": "A", "7": "B"}[id] + `"}}`)}
+		if err := devtools.DispatchEvent(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var got []string
+	for deadline := time.Now().Add(5 * time.Second); len(got) == 0 && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		got = f.flush()
+	}
+	if diff := cmp.Diff([]string{"S6" + offline}, got); diff != "" {
+		t.Errorf("new targets calls mismatch (-want +got):\n%s", diff)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close(); got error: %v", err)
+	}
+	online := strings.Replace(offline, "true", "false", 1)
+	want = []string{
+		"FAKE_SESSION_ID" + online,
+		`FAKE_SESSION_ID Target.detachFromTarget {"sessionId":"S2"}`,
+		`FAKE_SESSION_ID Target.detachFromTarget {"sessionId":"S3"}`,
+		`FAKE_SESSION_ID Target.detachFromTarget {"sessionId":"S6"}`,
+		"S2" + online,
+		"S3" + online,
+	}
+	if diff := cmp.Diff(want, f.flush()); diff != "" {
+		t.Errorf("Close() calls mismatch (-want +got):\n%s", diff)
+	}
+	if c.Offline() {
+		t.Error("Offline() = true after Close()")
+	}
+}
@@ -0,0 +1,125 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// ProtocolLog records the network protocol which was negotiated for each
+// response in a page, e.g. "http/1.1", "h2" or "h3", to verify HTTP/3
+// rollouts (see the `devtools.ForceQUIC` session option). See the
+// `network.RecordProtocols` function. Multiple goroutines may use it
+// simultaneously.
+type ProtocolLog struct {
+	sub  *devtools.Subscription
+	done chan struct{}
+	// Releases the Network domain, see `devtools.EnableDomain`.
+	release func() error
+
+	mu     sync.Mutex
+	byURL  map[string]string
+	counts map[string]int
+}
+
+// RecordProtocols starts recording the network protocol of each response
+// which the page associated with the given context receives, until the
+// `Stop` method of the returned log is called. This function enables the
+// Network domain until the log is stopped (see `devtools.EnableDomain`).
+func RecordProtocols(ctx context.Context) (*ProtocolLog, error) {
+	sub, err := devtools.Subscribe(ctx, "Network.responseReceived")
+	if err != nil {
+		return nil, err
+	}
+	l := &ProtocolLog{
+		sub:    sub,
+		done:   make(chan struct{}),
+		byURL:  make(map[string]string),
+		counts: make(map[string]int),
+	}
+	go l.collect(devtools.EventsOfSession(ctx))
+
+	release, err := devtools.EnableDomain(ctx, "Network")
+	if err != nil {
+		l.Stop()
+		return nil, err
+	}
+	l.release = release
+	return l, nil
+}
+
+// Receive events until the subscription ends.
+func (l *ProtocolLog) collect(accept devtools.EventFilter) {
+	defer close(l.done)
+	for m := range l.sub.C {
+		e := &ResponseReceived{}
+		if accept(m) && json.Unmarshal(m.Params, e) == nil {
+			l.record(&e.Response)
+		}
+	}
+}
+
+func (l *ProtocolLog) record(r *Response) {
+	if r.Protocol == "" {
+		return // E.g. "data:" URLs.
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.byURL[r.URL] = r.Protocol
+	l.counts[r.Protocol]++
+}
+
+// Protocol returns the network protocol of the latest response from the
+// given URL, or an empty string if no response was recorded for it.
+func (l *ProtocolLog) Protocol(url string) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.byURL[url]
+}
+
+// Counts returns the number of recorded responses per network protocol.
+func (l *ProtocolLog) Counts() map[string]int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	counts := make(map[string]int, len(l.counts))
+	for p, n := range l.counts {
+		counts[p] = n
+	}
+	return counts
+}
+
+// HTTP3 returns the number of recorded responses which used HTTP/3
+// (or an older draft of HTTP over QUIC), and the total number of
+// recorded responses.
+func (l *ProtocolLog) HTTP3() (n, total int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for p, c := range l.counts {
+		if IsQUIC(p) {
+			n += c
+		}
+		total += c
+	}
+	return n, total
+}
+
+// Stop stops recording protocols. The log can still be read afterwards.
+func (l *ProtocolLog) Stop() error {
+	l.sub.Unsubscribe()
+	<-l.done
+	if l.release != nil {
+		return l.release()
+	}
+	return nil
+}
+
+// IsQUIC reports whether the given network protocol (see the `Protocol`
+// field of `network.Response`) is HTTP/3, e.g. "h3", or one of its drafts,
+// e.g. "h3-29" or "http/2+quic/46".
+func IsQUIC(protocol string) bool {
+	p := strings.ToLower(protocol)
+	return strings.HasPrefix(p, "h3") || strings.Contains(p, "quic")
+}
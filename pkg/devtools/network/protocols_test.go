@@ -0,0 +1,51 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRecordProtocols(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	l, err := RecordProtocols(ctx)
+	if err != nil {
+		t.Fatalf("RecordProtocols(); got error: %v", err)
+	}
+	for _, e := range []string{
+		`{"requestId":"1","type":"Document","response":{"url":"https://a.com/","protocol":"h3"}}`,
+		`{"requestId":"2","type":"Script","response":{"url":"https://a.com/a.js","protocol":"h3-29"}}`,
+		`{"requestId":"3","type":"Script","response":{"url":"https://b.com/b.js","protocol":"h2"}}`,
+		`{"requestId":"4","type":"Image","response":{"url":"data:image/png;base64,"}}`,
+	} {
+		if err := b.Emit("Network.responseReceived", json.RawMessage(e)); err != nil {
+			t.Fatalf("Emit(); got error: %v", err)
+		}
+	}
+	// Stopping discards events which weren't received yet.
+	deadline := time.Now().Add(5 * time.Second)
+	for len(l.Counts()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := l.Stop(); err != nil {
+		t.Fatalf("Stop(); got error: %v", err)
+	}
+
+	if got := l.Protocol("https://b.com/b.js"); got != "h2" {
+		t.Errorf("Protocol() = %q, want %q", got, "h2")
+	}
+	want := map[string]int{"h3": 1, "h3-29": 1, "h2": 1}
+	if diff := cmp.Diff(want, l.Counts()); diff != "" {
+		t.Errorf("Counts() mismatch (-want +got):\n%s", diff)
+	}
+	if n, total := l.HTTP3(); n != 2 || total != 3 {
+		t.Errorf("HTTP3() = %d, %d, want 2, 3", n, total)
+	}
+	if len(b.Calls("Network.enable")) != 1 || len(b.Calls("Network.disable")) != 1 {
+		t.Errorf("RecordProtocols() didn't enable and release the Network domain: %v", b.Calls())
+	}
+}
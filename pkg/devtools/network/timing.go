@@ -0,0 +1,132 @@
+package network
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// TimingBreakdown is a friendlier representation of `network.ResourceTiming`,
+// which splits the lifetime of a network request into consecutive phases.
+// Phases which didn't happen (e.g. DNS resolution and connection setup
+// when reusing an existing connection) are zero.
+type TimingBreakdown struct {
+	// Queueing and stalling before the request was started.
+	Blocked time.Duration
+	Proxy   time.Duration
+	DNS     time.Duration
+	// TCP connection setup, excluding the TLS handshake.
+	Connect time.Duration
+	TLS     time.Duration
+	Send    time.Duration
+	// Time to first byte: waiting for the response headers, after sending the request.
+	TTFB time.Duration
+	// Receiving the response body. Zero if the timestamp of
+	// the `Network.loadingFinished` event is unknown.
+	Download time.Duration
+	// All of the above.
+	Total time.Duration
+}
+
+// Breakdown converts the raw timing information of a request into phase
+// durations. The finished argument is the timestamp of the request's
+// `Network.loadingFinished` event (`network.LoadingFinished.Timestamp`),
+// or 0 if it's unknown.
+func (t *ResourceTiming) Breakdown(finished devtools.MonotonicTime) TimingBreakdown {
+	b := TimingBreakdown{
+		Proxy:   span(t.ProxyStart, t.ProxyEnd),
+		DNS:     span(t.DNSStart, t.DNSEnd),
+		Connect: span(t.ConnectStart, t.ConnectEnd),
+		TLS:     span(t.SslStart, t.SslEnd),
+		Send:    span(t.SendStart, t.SendEnd),
+		TTFB:    span(t.SendEnd, t.ReceiveHeadersEnd),
+	}
+	// In the CDP, the SSL handshake is a part of the connection setup.
+	if b.TLS > 0 && b.Connect >= b.TLS {
+		b.Connect -= b.TLS
+	}
+	// The request starts with the first phase that happened.
+	start := t.SendStart
+	for _, s := range []float64{t.ProxyStart, t.DNSStart, t.ConnectStart} {
+		if s >= 0 && s < start {
+			start = s
+		}
+	}
+	b.Blocked = span(0, start)
+	if finished > 0 {
+		b.Download = span(t.ReceiveHeadersEnd, (float64(finished)-t.RequestTime)*1000)
+	}
+	b.Total = b.Blocked + b.Proxy + b.DNS + b.Connect + b.TLS + b.Send + b.TTFB + b.Download
+	return b
+}
+
+// Return the duration between two timing ticks, in milliseconds.
+// Negative ticks indicate that the phase didn't happen.
+func span(start, end float64) time.Duration {
+	if start < 0 || end < start {
+		return 0
+	}
+	return time.Duration((end - start) * float64(time.Millisecond))
+}
+
+// TimingSummary aggregates the timing breakdowns of multiple requests,
+// e.g. all the requests of a page, with per-phase percentiles.
+type TimingSummary struct {
+	Count              int
+	P50, P90, P95, P99 TimingBreakdown
+	Max                TimingBreakdown
+}
+
+// SummarizeTimings calculates per-phase percentiles of the given
+// timing breakdowns. Each phase is aggregated independently, so the
+// phases of a percentile don't necessarily add up to its total.
+func SummarizeTimings(bs []TimingBreakdown) TimingSummary {
+	return TimingSummary{
+		Count: len(bs),
+		P50:   TimingPercentile(bs, 50),
+		P90:   TimingPercentile(bs, 90),
+		P95:   TimingPercentile(bs, 95),
+		P99:   TimingPercentile(bs, 99),
+		Max:   TimingPercentile(bs, 100),
+	}
+}
+
+// TimingPercentile calculates the p-th percentile (0-100) of each phase
+// in the given timing breakdowns, using the nearest-rank method.
+func TimingPercentile(bs []TimingBreakdown, p float64) TimingBreakdown {
+	field := func(f func(TimingBreakdown) time.Duration) time.Duration {
+		ds := make([]time.Duration, len(bs))
+		for i, b := range bs {
+			ds[i] = f(b)
+		}
+		return percentile(ds, p)
+	}
+	return TimingBreakdown{
+		Blocked:  field(func(b TimingBreakdown) time.Duration { return b.Blocked }),
+		Proxy:    field(func(b TimingBreakdown) time.Duration { return b.Proxy }),
+		DNS:      field(func(b TimingBreakdown) time.Duration { return b.DNS }),
+		Connect:  field(func(b TimingBreakdown) time.Duration { return b.Connect }),
+		TLS:      field(func(b TimingBreakdown) time.Duration { return b.TLS }),
+		Send:     field(func(b TimingBreakdown) time.Duration { return b.Send }),
+		TTFB:     field(func(b TimingBreakdown) time.Duration { return b.TTFB }),
+		Download: field(func(b TimingBreakdown) time.Duration { return b.Download }),
+		Total:    field(func(b TimingBreakdown) time.Duration { return b.Total }),
+	}
+}
+
+func percentile(ds []time.Duration, p float64) time.Duration {
+	if len(ds) == 0 {
+		return 0
+	}
+	sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+	rank := int(math.Ceil(p / 100 * float64(len(ds))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(ds) {
+		rank = len(ds)
+	}
+	return ds[rank-1]
+}
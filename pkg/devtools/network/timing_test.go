@@ -0,0 +1,97 @@
+package network
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreakdown(t *testing.T) {
+	// Set up.
+	rt := &ResourceTiming{
+		RequestTime:       100,
+		ProxyStart:        -1,
+		ProxyEnd:          -1,
+		DNSStart:          2,
+		DNSEnd:            12,
+		ConnectStart:      12,
+		ConnectEnd:        42,
+		SslStart:          22,
+		SslEnd:            42,
+		SendStart:         42,
+		SendEnd:           43,
+		ReceiveHeadersEnd: 143,
+	}
+	// Test.
+	got := rt.Breakdown(100.2)
+	want := TimingBreakdown{
+		Blocked:  2 * time.Millisecond,
+		DNS:      10 * time.Millisecond,
+		Connect:  10 * time.Millisecond,
+		TLS:      20 * time.Millisecond,
+		Send:     1 * time.Millisecond,
+		TTFB:     100 * time.Millisecond,
+		Download: 57 * time.Millisecond,
+		Total:    200 * time.Millisecond,
+	}
+	if !approxEqual(got, want) {
+		t.Errorf("Breakdown() = %+v, want %+v", got, want)
+	}
+}
+
+func TestBreakdownReusedConnection(t *testing.T) {
+	// Set up.
+	rt := &ResourceTiming{
+		ProxyStart: -1, ProxyEnd: -1, DNSStart: -1, DNSEnd: -1,
+		ConnectStart: -1, ConnectEnd: -1, SslStart: -1, SslEnd: -1,
+		SendStart: 5, SendEnd: 6, ReceiveHeadersEnd: 16,
+	}
+	// Test.
+	got := rt.Breakdown(0)
+	want := TimingBreakdown{
+		Blocked: 5 * time.Millisecond,
+		Send:    1 * time.Millisecond,
+		TTFB:    10 * time.Millisecond,
+		Total:   16 * time.Millisecond,
+	}
+	if !approxEqual(got, want) {
+		t.Errorf("Breakdown() = %+v, want %+v", got, want)
+	}
+}
+
+// Floating-point ticks may be off by a few nanoseconds.
+func approxEqual(a, b TimingBreakdown) bool {
+	near := func(x, y time.Duration) bool {
+		d := x - y
+		return d > -time.Microsecond && d < time.Microsecond
+	}
+	return near(a.Blocked, b.Blocked) && near(a.Proxy, b.Proxy) && near(a.DNS, b.DNS) &&
+		near(a.Connect, b.Connect) && near(a.TLS, b.TLS) && near(a.Send, b.Send) &&
+		near(a.TTFB, b.TTFB) && near(a.Download, b.Download) && near(a.Total, b.Total)
+}
+
+func TestTimingPercentile(t *testing.T) {
+	// Set up.
+	var bs []TimingBreakdown
+	for i := 10; i >= 1; i-- {
+		bs = append(bs, TimingBreakdown{TTFB: time.Duration(i) * time.Second})
+	}
+	tests := []struct {
+		p    float64
+		want time.Duration
+	}{
+		{0, time.Second},
+		{50, 5 * time.Second},
+		{90, 9 * time.Second},
+		{99, 10 * time.Second},
+		{100, 10 * time.Second},
+	}
+	// Test.
+	for _, tt := range tests {
+		if got := TimingPercentile(bs, tt.p).TTFB; got != tt.want {
+			t.Errorf("TimingPercentile(%v).TTFB = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := SummarizeTimings(nil); got.Count != 0 || got.Max.Total != 0 {
+		t.Errorf("SummarizeTimings(nil) = %+v, want zero values", got)
+	}
+}
@@ -3,6 +3,7 @@ package network
 import (
 	"encoding/json"
 
+	"github.com/daabr/chrome-vision/pkg/devtools"
 	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
 	"github.com/daabr/chrome-vision/pkg/devtools/security"
 )
@@ -84,17 +85,17 @@ func (t ErrorReason) String() string {
 // TimeSinceEpoch data type. UTC time in seconds, counted from January 1, 1970.
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#type-TimeSinceEpoch
-type TimeSinceEpoch float64
+type TimeSinceEpoch = devtools.TimeSinceEpoch
 
 // MonotonicTime data type. Monotonically increasing time in seconds since an arbitrary point in the past.
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#type-MonotonicTime
-type MonotonicTime float64
+type MonotonicTime = devtools.MonotonicTime
 
 // Headers data type. Request / response headers as keys / values of JSON object.
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Network/#type-Headers
-type Headers struct{}
+type Headers map[string]string
 
 // ConnectionType data type. The underlying connection technology that the browser is supposedly using.
 //
@@ -345,9 +346,9 @@ type SecurityDetails struct {
 	// Name of the issuing CA.
 	Issuer string `json:"issuer"`
 	// Certificate valid from date.
-	ValidFrom float64 `json:"validFrom"`
+	ValidFrom devtools.TimeSinceEpoch `json:"validFrom"`
 	// Certificate valid to (expiration) date
-	ValidTo float64 `json:"validTo"`
+	ValidTo devtools.TimeSinceEpoch `json:"validTo"`
 	// List of signed certificate timestamps (SCTs).
 	SignedCertificateTimestampList []SignedCertificateTimestamp `json:"signedCertificateTimestampList"`
 	// Whether the request complied with Certificate Transparency policy
@@ -548,7 +549,7 @@ type Response struct {
 	// Response source of response from ServiceWorker.
 	ServiceWorkerResponseSource *ServiceWorkerResponseSource `json:"serviceWorkerResponseSource,omitempty"`
 	// The time at which the returned response was generated.
-	ResponseTime float64 `json:"responseTime,omitempty"`
+	ResponseTime devtools.TimeSinceEpoch `json:"responseTime,omitempty"`
 	// Cache Storage Cache Name.
 	CacheStorageCacheName string `json:"cacheStorageCacheName,omitempty"`
 	// Protocol used to fetch this request.
@@ -801,7 +802,7 @@ type CookieParam struct {
 	// Cookie SameSite type.
 	SameSite *CookieSameSite `json:"sameSite,omitempty"`
 	// Cookie expiration date, session cookie if not set
-	Expires float64 `json:"expires,omitempty"`
+	Expires devtools.TimeSinceEpoch `json:"expires,omitempty"`
 	// Cookie Priority.
 	//
 	// This CDP property is experimental.
@@ -1191,7 +1192,7 @@ type ReportingAPIReport struct {
 	// The type of the report (specifies the set of data that is contained in the report body).
 	Type string `json:"type"`
 	// When the report was generated.
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.TimeSinceEpoch `json:"timestamp"`
 	// How many uploads deep the related request was.
 	Depth int64 `json:"depth"`
 	// The number of delivery attempts made so far, not including an active attempt.
@@ -0,0 +1,314 @@
+package network
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// OriginUsage is the network usage of a single origin (e.g.
+// "https://example.com:8443"), see the `network.StartUsageMeter` function.
+// URLs without a host (e.g. "data" URLs) are grouped by scheme (e.g. "data:").
+type OriginUsage struct {
+	Origin string `json:"origin"`
+	// Number of requests, including each hop in a chain of redirects.
+	Requests int64 `json:"requests"`
+	// Number of requests which failed or were canceled.
+	Failed int64 `json:"failed,omitempty"`
+	// Number of responses which were served from the disk cache,
+	// prefetch cache or a service worker.
+	Cached int64 `json:"cached,omitempty"`
+	// Estimated number of bytes sent: request lines, headers (without
+	// HTTP/2 and HTTP/3 header compression), and bodies.
+	BytesSent int64 `json:"bytesSent"`
+	// Total number of bytes received over the network,
+	// including headers, before decompression.
+	BytesReceived int64 `json:"bytesReceived"`
+}
+
+// Bytes returns the total number of bytes sent and received.
+func (u OriginUsage) Bytes() int64 {
+	return u.BytesSent + u.BytesReceived
+}
+
+type usageConfig struct {
+	quota   int64
+	onQuota func(OriginUsage)
+}
+
+// UsageOption is used for customization in the `network.StartUsageMeter` function.
+type UsageOption = func(*usageConfig)
+
+// OriginQuota allows the caller of the `network.StartUsageMeter` function
+// to be notified once per origin, when the total number of bytes which were
+// sent to and received from it exceeds the given limit. The given function
+// is called in the meter's event loop, so it must return quickly, e.g. by
+// blocking the origin's URLs in a new goroutine:
+//
+//	network.OriginQuota(50<<20, func(u network.OriginUsage) {
+//		go network.NewSetBlockedURLs([]string{u.Origin + "/*"}).Do(ctx)
+//	})
+func OriginQuota(maxBytes int64, f func(OriginUsage)) UsageOption {
+	return func(c *usageConfig) {
+		c.quota = maxBytes
+		c.onQuota = f
+	}
+}
+
+// UsageMeter counts requests and bytes per origin in the background,
+// see the `network.StartUsageMeter` function.
+type UsageMeter struct {
+	cfg  *usageConfig
+	sub  *devtools.Subscription
+	done chan struct{}
+
+	mu       sync.Mutex
+	origins  map[string]*OriginUsage
+	inflight map[string]*usageRequest
+	exceeded map[string]bool
+}
+
+// An unfinished request, and the number of bytes
+// which were already received for it.
+type usageRequest struct {
+	origin   string
+	received int64
+}
+
+// StartUsageMeter starts counting the requests and bytes which the page
+// associated with the given context sends and receives, per origin, until
+// the `Stop` method of the returned meter is called. Use its `Report` method
+// to attribute bandwidth usage (e.g. in long crawls), and the
+// `network.OriginQuota` option to enforce per-site quotas.
+//
+// This function enables the Network domain until the meter is stopped, or
+// until the context is done (see `devtools.SubscribeEvents`). Requests which
+// started before calling it are not counted.
+func StartUsageMeter(ctx context.Context, opts ...UsageOption) (*UsageMeter, error) {
+	m := &UsageMeter{
+		cfg:      &usageConfig{},
+		done:     make(chan struct{}),
+		origins:  make(map[string]*OriginUsage),
+		inflight: make(map[string]*usageRequest),
+		exceeded: make(map[string]bool),
+	}
+	for _, o := range opts {
+		o(m.cfg)
+	}
+	names := []string{"Network.requestWillBeSent", "Network.responseReceived", "Network.loadingFinished", "Network.loadingFailed"}
+	sub, err := devtools.SubscribeEvents(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	m.sub = sub
+	go m.collect(devtools.EventsOfSession(ctx))
+	return m, nil
+}
+
+// Receive events until the subscription ends.
+func (m *UsageMeter) collect(accept devtools.EventFilter) {
+	defer close(m.done)
+	for msg := range m.sub.C {
+		if !accept(msg) {
+			continue
+		}
+		switch msg.Method {
+		case "Network.requestWillBeSent":
+			e := &RequestWillBeSent{}
+			if json.Unmarshal(msg.Params, e) == nil {
+				m.requestWillBeSent(e)
+			}
+		case "Network.responseReceived":
+			e := &ResponseReceived{}
+			if json.Unmarshal(msg.Params, e) == nil {
+				m.responseReceived(e)
+			}
+		case "Network.loadingFinished":
+			e := &LoadingFinished{}
+			if json.Unmarshal(msg.Params, e) == nil {
+				m.loadingFinished(e)
+			}
+		case "Network.loadingFailed":
+			e := &LoadingFailed{}
+			if json.Unmarshal(msg.Params, e) == nil {
+				m.loadingFailed(e)
+			}
+		}
+	}
+}
+
+func (m *UsageMeter) requestWillBeSent(e *RequestWillBeSent) {
+	var notify []OriginUsage
+	m.mu.Lock()
+	// Redirects reuse the same request ID: complete the previous hop.
+	if prev, ok := m.inflight[e.RequestID]; ok && e.RedirectResponse != nil {
+		u := m.origins[prev.origin]
+		m.countResponse(u, e.RedirectResponse)
+		u.BytesReceived += int64(e.RedirectResponse.EncodedDataLength) - prev.received
+		notify = m.checkQuota(u, notify)
+	}
+	origin := originOf(e.Request.URL)
+	u, ok := m.origins[origin]
+	if !ok {
+		u = &OriginUsage{Origin: origin}
+		m.origins[origin] = u
+	}
+	u.Requests++
+	u.BytesSent += requestSize(&e.Request)
+	m.inflight[e.RequestID] = &usageRequest{origin: origin}
+	notify = m.checkQuota(u, notify)
+	m.mu.Unlock()
+	m.notify(notify)
+}
+
+func (m *UsageMeter) responseReceived(e *ResponseReceived) {
+	var notify []OriginUsage
+	m.mu.Lock()
+	if r, ok := m.inflight[e.RequestID]; ok {
+		u := m.origins[r.origin]
+		m.countResponse(u, &e.Response)
+		// At this point, this is the size of the response headers.
+		n := int64(e.Response.EncodedDataLength)
+		u.BytesReceived += n - r.received
+		r.received = n
+		notify = m.checkQuota(u, notify)
+	}
+	m.mu.Unlock()
+	m.notify(notify)
+}
+
+func (m *UsageMeter) countResponse(u *OriginUsage, r *Response) {
+	if r.FromDiskCache || r.FromPrefetchCache || r.FromServiceWorker {
+		u.Cached++
+	}
+}
+
+func (m *UsageMeter) loadingFinished(e *LoadingFinished) {
+	var notify []OriginUsage
+	m.mu.Lock()
+	if r, ok := m.inflight[e.RequestID]; ok {
+		delete(m.inflight, e.RequestID)
+		u := m.origins[r.origin]
+		u.BytesReceived += int64(e.EncodedDataLength) - r.received
+		notify = m.checkQuota(u, notify)
+	}
+	m.mu.Unlock()
+	m.notify(notify)
+}
+
+func (m *UsageMeter) loadingFailed(e *LoadingFailed) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if r, ok := m.inflight[e.RequestID]; ok {
+		delete(m.inflight, e.RequestID)
+		m.origins[r.origin].Failed++
+	}
+}
+
+// Append a copy of the given usage to the given list, if it exceeded the
+// quota for the first time. Must be called while holding the lock.
+func (m *UsageMeter) checkQuota(u *OriginUsage, notify []OriginUsage) []OriginUsage {
+	if m.cfg.onQuota == nil || m.exceeded[u.Origin] || u.Bytes() <= m.cfg.quota {
+		return notify
+	}
+	m.exceeded[u.Origin] = true
+	return append(notify, *u)
+}
+
+// Call the quota function (without holding the lock).
+func (m *UsageMeter) notify(usages []OriginUsage) {
+	for _, u := range usages {
+		m.cfg.onQuota(u)
+	}
+}
+
+// Report returns the current usage of all the origins which the page sent
+// requests to, sorted by their total number of bytes (in descending order).
+// Responses which are still loading are partially counted.
+func (m *UsageMeter) Report() []OriginUsage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	report := make([]OriginUsage, 0, len(m.origins))
+	for _, u := range m.origins {
+		report = append(report, *u)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Bytes() != report[j].Bytes() {
+			return report[i].Bytes() > report[j].Bytes()
+		}
+		return report[i].Origin < report[j].Origin
+	})
+	return report
+}
+
+// Usage returns the current usage of the given origin (e.g.
+// "https://example.com"), or zero counters if the page didn't
+// send any requests to it.
+func (m *UsageMeter) Usage(origin string) OriginUsage {
+	origin = strings.ToLower(strings.TrimSuffix(origin, "/"))
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if u, ok := m.origins[origin]; ok {
+		return *u
+	}
+	return OriginUsage{Origin: origin}
+}
+
+// Stop stops counting, and releases the Network domain, which is disabled
+// only if nothing else requires it. The meter's report remains available.
+func (m *UsageMeter) Stop() {
+	m.sub.Unsubscribe()
+	<-m.done
+}
+
+// Return the origin of the given URL: its scheme, host, and port (if it's
+// not the scheme's default port), or only its scheme if it has no host.
+func originOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if u.Host == "" {
+		return scheme + ":"
+	}
+	host := strings.ToLower(u.Host)
+	switch {
+	case (scheme == "http" || scheme == "ws") && u.Port() == "80",
+		(scheme == "https" || scheme == "wss") && u.Port() == "443":
+		host = strings.ToLower(u.Hostname())
+		if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+	}
+	return scheme + "://" + host
+}
+
+// Estimate the size of the given HTTP/1.1 request, which the browser
+// doesn't report: the request line, headers and body. URLs without a host
+// (e.g. "data" URLs) aren't sent over the network.
+func requestSize(r *Request) int64 {
+	u, err := url.Parse(r.URL)
+	if err != nil || u.Host == "" {
+		return 0
+	}
+	n := len(r.Method) + 1 + len(u.RequestURI()) + len(" HTTP/1.1\r\n")
+	for k, v := range r.Headers {
+		n += len(k) + len(": ") + len(v) + len("\r\n")
+	}
+	n += len("\r\n")
+	if r.PostData != "" {
+		n += len(r.PostData)
+	} else {
+		for _, e := range r.PostDataEntries {
+			n += base64.StdEncoding.DecodedLen(len(e.Bytes))
+		}
+	}
+	return int64(n)
+}
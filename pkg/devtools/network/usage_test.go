@@ -0,0 +1,68 @@
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUsageMeter(t *testing.T) {
+	// Set up.
+	ctx, _ := devtoolstest.NewContext(context.Background())
+	sub, err := devtools.Subscribe(ctx, "Network.requestWillBeSent")
+	if err != nil {
+		t.Fatalf("Subscribe(); got error: %v", err)
+	}
+	var exceeded []OriginUsage
+	m := &UsageMeter{
+		sub:      sub,
+		cfg:      &usageConfig{},
+		origins:  make(map[string]*OriginUsage),
+		inflight: make(map[string]*usageRequest),
+		exceeded: make(map[string]bool),
+		done:     make(chan struct{}),
+	}
+	OriginQuota(1000, func(u OriginUsage) { exceeded = append(exceeded, u) })(m.cfg)
+	close(m.done)
+	get := Request{URL: "http://example.com/", Method: "GET"} // 18 bytes.
+	m.requestWillBeSent(&RequestWillBeSent{RequestID: "1", Request: get})
+	m.requestWillBeSent(&RequestWillBeSent{
+		RequestID: "1", Request: Request{URL: "https://example.com:443/?q", Method: "GET"}, // 20 bytes.
+		RedirectResponse: &Response{Status: 301, EncodedDataLength: 100},
+	})
+	m.responseReceived(&ResponseReceived{RequestID: "1", Response: Response{Status: 200, EncodedDataLength: 200}})
+	m.loadingFinished(&LoadingFinished{RequestID: "1", EncodedDataLength: 1200})
+	m.requestWillBeSent(&RequestWillBeSent{RequestID: "2", Request: Request{
+		URL: "https://cdn.example.com/a.js", Method: "POST", // 21 bytes.
+		Headers: Headers{"A": "b"}, PostData: "12345", // 6 + 2 + 5 bytes.
+	}})
+	m.responseReceived(&ResponseReceived{RequestID: "2", Response: Response{FromDiskCache: true}})
+	m.loadingFinished(&LoadingFinished{RequestID: "2"})
+	m.requestWillBeSent(&RequestWillBeSent{RequestID: "3", Request: Request{URL: "https://cdn.example.com/b.js", Method: "GET"}}) // 22 bytes.
+	m.responseReceived(&ResponseReceived{RequestID: "3", Response: Response{EncodedDataLength: 50}})
+	m.loadingFailed(&LoadingFailed{RequestID: "3", Canceled: true})
+	m.requestWillBeSent(&RequestWillBeSent{RequestID: "4", Request: Request{URL: "data:image/png;base64,AA==", Method: "GET"}})
+	// Test.
+	m.Stop()
+	want := []OriginUsage{
+		{Origin: "https://example.com", Requests: 1, BytesSent: 20, BytesReceived: 1200},
+		{Origin: "http://example.com", Requests: 1, BytesSent: 18, BytesReceived: 100},
+		{Origin: "https://cdn.example.com", Requests: 2, Failed: 1, Cached: 1, BytesSent: 34 + 22, BytesReceived: 50},
+		{Origin: "data:", Requests: 1},
+	}
+	if diff := cmp.Diff(want, m.Report()); diff != "" {
+		t.Errorf("Report() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(want[:1], exceeded); diff != "" {
+		t.Errorf("OriginQuota() calls mismatch (-want +got):\n%s", diff)
+	}
+	if got := m.Usage("HTTPS://cdn.example.com/"); got.Requests != 2 {
+		t.Errorf("Usage() = %+v, want 2 requests", got)
+	}
+	if got := m.Usage("https://example.org"); got.Requests != 0 {
+		t.Errorf("Usage(unknown origin) = %+v, want 0 requests", got)
+	}
+}
@@ -0,0 +1,110 @@
+package devtools
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OutputLine is a line which a locally-started browser process wrote to
+// its STDOUT or STDERR, e.g. GPU, sandbox and network errors. See the
+// `devtools.BrowserOutput` and `devtools.LogBrowserOutput` session options.
+type OutputLine struct {
+	// Stream is either "stdout" or "stderr".
+	Stream string
+	Text   string
+	Time   time.Time
+}
+
+// BrowserOutput allows the caller of the `devtools.NewContext` function to
+// receive the lines that the browser process writes to its STDOUT and STDERR,
+// in addition to the files in the session's output directory. Sending never
+// blocks the browser: lines are dropped if the channel isn't ready, so it
+// should be buffered and drained continuously. The channel isn't closed.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser, and with remote browsers.
+func BrowserOutput(ch chan<- OutputLine) SessionOption {
+	return func(s *Session) {
+		s.outputFuncs = append(s.outputFuncs, func(l OutputLine) {
+			select {
+			case ch <- l:
+			default:
+			}
+		})
+	}
+}
+
+// LogBrowserOutput allows the caller of the `devtools.NewContext` function to
+// forward the lines that the browser process writes to its STDOUT and STDERR
+// to the given logger (or the standard logger, if it's nil), with the prefix
+// "[browser stdout]" or "[browser stderr]", so they're visible in the
+// application's logs, and not only in the session's output directory.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser, and with remote browsers.
+func LogBrowserOutput(l *log.Logger) SessionOption {
+	if l == nil {
+		l = log.Default()
+	}
+	return func(s *Session) {
+		s.outputFuncs = append(s.outputFuncs, func(line OutputLine) {
+			l.Printf("[browser %s] %s", line.Stream, line.Text)
+		})
+	}
+}
+
+// Wrap the given writer of a browser output stream, if the session has
+// consumers of its lines (see `devtools.BrowserOutput`), and return the
+// wrapper and a function to flush the last line when the process ends.
+func outputWriter(s *Session, stream string, w io.Writer) (io.Writer, func()) {
+	if len(s.outputFuncs) == 0 {
+		return w, func() {}
+	}
+	lw := &lineWriter{stream: stream, funcs: s.outputFuncs}
+	return io.MultiWriter(w, lw), lw.flush
+}
+
+// lineWriter splits its input into lines, and passes them to functions.
+type lineWriter struct {
+	stream string
+	funcs  []func(OutputLine)
+
+	mu  sync.Mutex
+	buf []byte // Incomplete last line.
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.emit(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+func (w *lineWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buf) > 0 {
+		w.emit(string(w.buf))
+		w.buf = nil
+	}
+}
+
+// Call only while holding the lock.
+func (w *lineWriter) emit(text string) {
+	l := OutputLine{Stream: w.stream, Text: strings.TrimSuffix(text, "\r"), Time: time.Now()}
+	for _, f := range w.funcs {
+		f(l)
+	}
+}
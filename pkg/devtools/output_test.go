@@ -0,0 +1,44 @@
+package devtools
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOutputWriter(t *testing.T) {
+	ch := make(chan OutputLine, 10)
+	s := &Session{}
+	BrowserOutput(ch)(s)
+	var file bytes.Buffer
+	w, flush := outputWriter(s, "stderr", &file)
+
+	input := "first\r\nsec"
+	w.Write([]byte(input))
+	w.Write([]byte("ond\n\nlast"))
+	flush()
+	close(ch)
+
+	var got []string
+	for l := range ch {
+		if l.Stream != "stderr" {
+			t.Errorf("line %q stream = %q, want %q", l.Text, l.Stream, "stderr")
+		}
+		got = append(got, l.Text)
+	}
+	want := []string{"first", "second", "", "last"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("lines mismatch (-want +got):\n%s", diff)
+	}
+	if file.String() != input+"ond\n\nlast" {
+		t.Errorf("file content = %q", file.String())
+	}
+}
+
+func TestOutputWriterWithoutConsumers(t *testing.T) {
+	var file bytes.Buffer
+	if w, _ := outputWriter(&Session{}, "stdout", &file); w != &file {
+		t.Error("outputWriter() wrapped the writer without consumers")
+	}
+}
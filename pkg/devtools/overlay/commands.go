@@ -114,20 +114,30 @@ type GetHighlightObjectForTest struct {
 	// The color format to get config with (default: hex).
 	ColorFormat *ColorFormat `json:"colorFormat,omitempty"`
 	// Whether to show accessibility info (default: true).
-	ShowAccessibilityInfo bool `json:"showAccessibilityInfo,omitempty"`
+	ShowAccessibilityInfo *bool `json:"showAccessibilityInfo,omitempty"`
 }
 
 // NewGetHighlightObjectForTest constructs a new GetHighlightObjectForTest struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetHighlightObjectForTestOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#method-getHighlightObjectForTest
-func NewGetHighlightObjectForTest(nodeID int64) *GetHighlightObjectForTest {
-	return &GetHighlightObjectForTest{
+func NewGetHighlightObjectForTest(nodeID int64, opts ...GetHighlightObjectForTestOption) *GetHighlightObjectForTest {
+	c := &GetHighlightObjectForTest{
 		NodeID: nodeID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetHighlightObjectForTestOption is a functional option for the optional
+// parameters of the GetHighlightObjectForTest CDP command, see the
+// NewGetHighlightObjectForTest constructor. Options are applied in order.
+type GetHighlightObjectForTestOption = func(*GetHighlightObjectForTest)
+
 // SetIncludeDistance adds or modifies the value of the optional
 // parameter `includeDistance` in the GetHighlightObjectForTest CDP command.
 //
@@ -137,6 +147,14 @@ func (t *GetHighlightObjectForTest) SetIncludeDistance(v bool) *GetHighlightObje
 	return t
 }
 
+// GetHighlightObjectForTestWithIncludeDistance is a functional option which sets the value of the
+// optional parameter `includeDistance` in the GetHighlightObjectForTest CDP command, see SetIncludeDistance.
+func GetHighlightObjectForTestWithIncludeDistance(v bool) GetHighlightObjectForTestOption {
+	return func(c *GetHighlightObjectForTest) {
+		c.SetIncludeDistance(v)
+	}
+}
+
 // SetIncludeStyle adds or modifies the value of the optional
 // parameter `includeStyle` in the GetHighlightObjectForTest CDP command.
 //
@@ -146,6 +164,14 @@ func (t *GetHighlightObjectForTest) SetIncludeStyle(v bool) *GetHighlightObjectF
 	return t
 }
 
+// GetHighlightObjectForTestWithIncludeStyle is a functional option which sets the value of the
+// optional parameter `includeStyle` in the GetHighlightObjectForTest CDP command, see SetIncludeStyle.
+func GetHighlightObjectForTestWithIncludeStyle(v bool) GetHighlightObjectForTestOption {
+	return func(c *GetHighlightObjectForTest) {
+		c.SetIncludeStyle(v)
+	}
+}
+
 // SetColorFormat adds or modifies the value of the optional
 // parameter `colorFormat` in the GetHighlightObjectForTest CDP command.
 //
@@ -155,15 +181,31 @@ func (t *GetHighlightObjectForTest) SetColorFormat(v ColorFormat) *GetHighlightO
 	return t
 }
 
+// GetHighlightObjectForTestWithColorFormat is a functional option which sets the value of the
+// optional parameter `colorFormat` in the GetHighlightObjectForTest CDP command, see SetColorFormat.
+func GetHighlightObjectForTestWithColorFormat(v ColorFormat) GetHighlightObjectForTestOption {
+	return func(c *GetHighlightObjectForTest) {
+		c.SetColorFormat(v)
+	}
+}
+
 // SetShowAccessibilityInfo adds or modifies the value of the optional
 // parameter `showAccessibilityInfo` in the GetHighlightObjectForTest CDP command.
 //
 // Whether to show accessibility info (default: true).
 func (t *GetHighlightObjectForTest) SetShowAccessibilityInfo(v bool) *GetHighlightObjectForTest {
-	t.ShowAccessibilityInfo = v
+	t.ShowAccessibilityInfo = &v
 	return t
 }
 
+// GetHighlightObjectForTestWithShowAccessibilityInfo is a functional option which sets the value of the
+// optional parameter `showAccessibilityInfo` in the GetHighlightObjectForTest CDP command, see SetShowAccessibilityInfo.
+func GetHighlightObjectForTestWithShowAccessibilityInfo(v bool) GetHighlightObjectForTestOption {
+	return func(c *GetHighlightObjectForTest) {
+		c.SetShowAccessibilityInfo(v)
+	}
+}
+
 // GetHighlightObjectForTestResult contains the browser's response
 // to calling the GetHighlightObjectForTest CDP command with Do().
 type GetHighlightObjectForTestResult struct {
@@ -412,17 +454,27 @@ type HighlightFrame struct {
 
 // NewHighlightFrame constructs a new HighlightFrame struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `HighlightFrameOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#method-highlightFrame
 //
 // This CDP method is deprecated.
-func NewHighlightFrame(frameID string) *HighlightFrame {
-	return &HighlightFrame{
+func NewHighlightFrame(frameID string, opts ...HighlightFrameOption) *HighlightFrame {
+	c := &HighlightFrame{
 		FrameID: frameID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// HighlightFrameOption is a functional option for the optional
+// parameters of the HighlightFrame CDP command, see the
+// NewHighlightFrame constructor. Options are applied in order.
+type HighlightFrameOption = func(*HighlightFrame)
+
 // SetContentColor adds or modifies the value of the optional
 // parameter `contentColor` in the HighlightFrame CDP command.
 //
@@ -432,6 +484,14 @@ func (t *HighlightFrame) SetContentColor(v dom.RGBA) *HighlightFrame {
 	return t
 }
 
+// HighlightFrameWithContentColor is a functional option which sets the value of the
+// optional parameter `contentColor` in the HighlightFrame CDP command, see SetContentColor.
+func HighlightFrameWithContentColor(v dom.RGBA) HighlightFrameOption {
+	return func(c *HighlightFrame) {
+		c.SetContentColor(v)
+	}
+}
+
 // SetContentOutlineColor adds or modifies the value of the optional
 // parameter `contentOutlineColor` in the HighlightFrame CDP command.
 //
@@ -441,6 +501,14 @@ func (t *HighlightFrame) SetContentOutlineColor(v dom.RGBA) *HighlightFrame {
 	return t
 }
 
+// HighlightFrameWithContentOutlineColor is a functional option which sets the value of the
+// optional parameter `contentOutlineColor` in the HighlightFrame CDP command, see SetContentOutlineColor.
+func HighlightFrameWithContentOutlineColor(v dom.RGBA) HighlightFrameOption {
+	return func(c *HighlightFrame) {
+		c.SetContentOutlineColor(v)
+	}
+}
+
 // Do sends the HighlightFrame CDP command to a browser,
 // and returns the browser's response.
 func (t *HighlightFrame) Do(ctx context.Context) error {
@@ -498,15 +566,25 @@ type HighlightNode struct {
 
 // NewHighlightNode constructs a new HighlightNode struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `HighlightNodeOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#method-highlightNode
-func NewHighlightNode(highlightConfig HighlightConfig) *HighlightNode {
-	return &HighlightNode{
+func NewHighlightNode(highlightConfig HighlightConfig, opts ...HighlightNodeOption) *HighlightNode {
+	c := &HighlightNode{
 		HighlightConfig: highlightConfig,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// HighlightNodeOption is a functional option for the optional
+// parameters of the HighlightNode CDP command, see the
+// NewHighlightNode constructor. Options are applied in order.
+type HighlightNodeOption = func(*HighlightNode)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the HighlightNode CDP command.
 //
@@ -516,6 +594,14 @@ func (t *HighlightNode) SetNodeID(v int64) *HighlightNode {
 	return t
 }
 
+// HighlightNodeWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the HighlightNode CDP command, see SetNodeID.
+func HighlightNodeWithNodeID(v int64) HighlightNodeOption {
+	return func(c *HighlightNode) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the HighlightNode CDP command.
 //
@@ -525,6 +611,14 @@ func (t *HighlightNode) SetBackendNodeID(v int64) *HighlightNode {
 	return t
 }
 
+// HighlightNodeWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the HighlightNode CDP command, see SetBackendNodeID.
+func HighlightNodeWithBackendNodeID(v int64) HighlightNodeOption {
+	return func(c *HighlightNode) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the HighlightNode CDP command.
 //
@@ -534,6 +628,14 @@ func (t *HighlightNode) SetObjectID(v runtime.RemoteObjectID) *HighlightNode {
 	return t
 }
 
+// HighlightNodeWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the HighlightNode CDP command, see SetObjectID.
+func HighlightNodeWithObjectID(v runtime.RemoteObjectID) HighlightNodeOption {
+	return func(c *HighlightNode) {
+		c.SetObjectID(v)
+	}
+}
+
 // SetSelector adds or modifies the value of the optional
 // parameter `selector` in the HighlightNode CDP command.
 //
@@ -543,6 +645,14 @@ func (t *HighlightNode) SetSelector(v string) *HighlightNode {
 	return t
 }
 
+// HighlightNodeWithSelector is a functional option which sets the value of the
+// optional parameter `selector` in the HighlightNode CDP command, see SetSelector.
+func HighlightNodeWithSelector(v string) HighlightNodeOption {
+	return func(c *HighlightNode) {
+		c.SetSelector(v)
+	}
+}
+
 // Do sends the HighlightNode CDP command to a browser,
 // and returns the browser's response.
 func (t *HighlightNode) Do(ctx context.Context) error {
@@ -595,15 +705,25 @@ type HighlightQuad struct {
 
 // NewHighlightQuad constructs a new HighlightQuad struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `HighlightQuadOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#method-highlightQuad
-func NewHighlightQuad(quad dom.Quad) *HighlightQuad {
-	return &HighlightQuad{
+func NewHighlightQuad(quad dom.Quad, opts ...HighlightQuadOption) *HighlightQuad {
+	c := &HighlightQuad{
 		Quad: quad,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// HighlightQuadOption is a functional option for the optional
+// parameters of the HighlightQuad CDP command, see the
+// NewHighlightQuad constructor. Options are applied in order.
+type HighlightQuadOption = func(*HighlightQuad)
+
 // SetColor adds or modifies the value of the optional
 // parameter `color` in the HighlightQuad CDP command.
 //
@@ -613,6 +733,14 @@ func (t *HighlightQuad) SetColor(v dom.RGBA) *HighlightQuad {
 	return t
 }
 
+// HighlightQuadWithColor is a functional option which sets the value of the
+// optional parameter `color` in the HighlightQuad CDP command, see SetColor.
+func HighlightQuadWithColor(v dom.RGBA) HighlightQuadOption {
+	return func(c *HighlightQuad) {
+		c.SetColor(v)
+	}
+}
+
 // SetOutlineColor adds or modifies the value of the optional
 // parameter `outlineColor` in the HighlightQuad CDP command.
 //
@@ -622,6 +750,14 @@ func (t *HighlightQuad) SetOutlineColor(v dom.RGBA) *HighlightQuad {
 	return t
 }
 
+// HighlightQuadWithOutlineColor is a functional option which sets the value of the
+// optional parameter `outlineColor` in the HighlightQuad CDP command, see SetOutlineColor.
+func HighlightQuadWithOutlineColor(v dom.RGBA) HighlightQuadOption {
+	return func(c *HighlightQuad) {
+		c.SetOutlineColor(v)
+	}
+}
+
 // Do sends the HighlightQuad CDP command to a browser,
 // and returns the browser's response.
 func (t *HighlightQuad) Do(ctx context.Context) error {
@@ -680,18 +816,28 @@ type HighlightRect struct {
 
 // NewHighlightRect constructs a new HighlightRect struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `HighlightRectOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#method-highlightRect
-func NewHighlightRect(x int64, y int64, width int64, height int64) *HighlightRect {
-	return &HighlightRect{
+func NewHighlightRect(x int64, y int64, width int64, height int64, opts ...HighlightRectOption) *HighlightRect {
+	c := &HighlightRect{
 		X:      x,
 		Y:      y,
 		Width:  width,
 		Height: height,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// HighlightRectOption is a functional option for the optional
+// parameters of the HighlightRect CDP command, see the
+// NewHighlightRect constructor. Options are applied in order.
+type HighlightRectOption = func(*HighlightRect)
+
 // SetColor adds or modifies the value of the optional
 // parameter `color` in the HighlightRect CDP command.
 //
@@ -701,6 +847,14 @@ func (t *HighlightRect) SetColor(v dom.RGBA) *HighlightRect {
 	return t
 }
 
+// HighlightRectWithColor is a functional option which sets the value of the
+// optional parameter `color` in the HighlightRect CDP command, see SetColor.
+func HighlightRectWithColor(v dom.RGBA) HighlightRectOption {
+	return func(c *HighlightRect) {
+		c.SetColor(v)
+	}
+}
+
 // SetOutlineColor adds or modifies the value of the optional
 // parameter `outlineColor` in the HighlightRect CDP command.
 //
@@ -710,6 +864,14 @@ func (t *HighlightRect) SetOutlineColor(v dom.RGBA) *HighlightRect {
 	return t
 }
 
+// HighlightRectWithOutlineColor is a functional option which sets the value of the
+// optional parameter `outlineColor` in the HighlightRect CDP command, see SetOutlineColor.
+func HighlightRectWithOutlineColor(v dom.RGBA) HighlightRectOption {
+	return func(c *HighlightRect) {
+		c.SetOutlineColor(v)
+	}
+}
+
 // Do sends the HighlightRect CDP command to a browser,
 // and returns the browser's response.
 func (t *HighlightRect) Do(ctx context.Context) error {
@@ -765,15 +927,25 @@ type HighlightSourceOrder struct {
 
 // NewHighlightSourceOrder constructs a new HighlightSourceOrder struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `HighlightSourceOrderOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#method-highlightSourceOrder
-func NewHighlightSourceOrder(sourceOrderConfig SourceOrderConfig) *HighlightSourceOrder {
-	return &HighlightSourceOrder{
+func NewHighlightSourceOrder(sourceOrderConfig SourceOrderConfig, opts ...HighlightSourceOrderOption) *HighlightSourceOrder {
+	c := &HighlightSourceOrder{
 		SourceOrderConfig: sourceOrderConfig,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// HighlightSourceOrderOption is a functional option for the optional
+// parameters of the HighlightSourceOrder CDP command, see the
+// NewHighlightSourceOrder constructor. Options are applied in order.
+type HighlightSourceOrderOption = func(*HighlightSourceOrder)
+
 // SetNodeID adds or modifies the value of the optional
 // parameter `nodeId` in the HighlightSourceOrder CDP command.
 //
@@ -783,6 +955,14 @@ func (t *HighlightSourceOrder) SetNodeID(v int64) *HighlightSourceOrder {
 	return t
 }
 
+// HighlightSourceOrderWithNodeID is a functional option which sets the value of the
+// optional parameter `nodeId` in the HighlightSourceOrder CDP command, see SetNodeID.
+func HighlightSourceOrderWithNodeID(v int64) HighlightSourceOrderOption {
+	return func(c *HighlightSourceOrder) {
+		c.SetNodeID(v)
+	}
+}
+
 // SetBackendNodeID adds or modifies the value of the optional
 // parameter `backendNodeId` in the HighlightSourceOrder CDP command.
 //
@@ -792,6 +972,14 @@ func (t *HighlightSourceOrder) SetBackendNodeID(v int64) *HighlightSourceOrder {
 	return t
 }
 
+// HighlightSourceOrderWithBackendNodeID is a functional option which sets the value of the
+// optional parameter `backendNodeId` in the HighlightSourceOrder CDP command, see SetBackendNodeID.
+func HighlightSourceOrderWithBackendNodeID(v int64) HighlightSourceOrderOption {
+	return func(c *HighlightSourceOrder) {
+		c.SetBackendNodeID(v)
+	}
+}
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the HighlightSourceOrder CDP command.
 //
@@ -801,6 +989,14 @@ func (t *HighlightSourceOrder) SetObjectID(v runtime.RemoteObjectID) *HighlightS
 	return t
 }
 
+// HighlightSourceOrderWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the HighlightSourceOrder CDP command, see SetObjectID.
+func HighlightSourceOrderWithObjectID(v runtime.RemoteObjectID) HighlightSourceOrderOption {
+	return func(c *HighlightSourceOrder) {
+		c.SetObjectID(v)
+	}
+}
+
 // Do sends the HighlightSourceOrder CDP command to a browser,
 // and returns the browser's response.
 func (t *HighlightSourceOrder) Do(ctx context.Context) error {
@@ -853,15 +1049,25 @@ type SetInspectMode struct {
 
 // NewSetInspectMode constructs a new SetInspectMode struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetInspectModeOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#method-setInspectMode
-func NewSetInspectMode(mode InspectMode) *SetInspectMode {
-	return &SetInspectMode{
+func NewSetInspectMode(mode InspectMode, opts ...SetInspectModeOption) *SetInspectMode {
+	c := &SetInspectMode{
 		Mode: mode,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetInspectModeOption is a functional option for the optional
+// parameters of the SetInspectMode CDP command, see the
+// NewSetInspectMode constructor. Options are applied in order.
+type SetInspectModeOption = func(*SetInspectMode)
+
 // SetHighlightConfig adds or modifies the value of the optional
 // parameter `highlightConfig` in the SetInspectMode CDP command.
 //
@@ -872,6 +1078,14 @@ func (t *SetInspectMode) SetHighlightConfig(v HighlightConfig) *SetInspectMode {
 	return t
 }
 
+// SetInspectModeWithHighlightConfig is a functional option which sets the value of the
+// optional parameter `highlightConfig` in the SetInspectMode CDP command, see SetHighlightConfig.
+func SetInspectModeWithHighlightConfig(v HighlightConfig) SetInspectModeOption {
+	return func(c *SetInspectMode) {
+		c.SetHighlightConfig(v)
+	}
+}
+
 // Do sends the SetInspectMode CDP command to a browser,
 // and returns the browser's response.
 func (t *SetInspectMode) Do(ctx context.Context) error {
@@ -975,13 +1189,23 @@ type SetPausedInDebuggerMessage struct {
 
 // NewSetPausedInDebuggerMessage constructs a new SetPausedInDebuggerMessage struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetPausedInDebuggerMessageOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#method-setPausedInDebuggerMessage
-func NewSetPausedInDebuggerMessage() *SetPausedInDebuggerMessage {
-	return &SetPausedInDebuggerMessage{}
+func NewSetPausedInDebuggerMessage(opts ...SetPausedInDebuggerMessageOption) *SetPausedInDebuggerMessage {
+	c := &SetPausedInDebuggerMessage{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetPausedInDebuggerMessageOption is a functional option for the optional
+// parameters of the SetPausedInDebuggerMessage CDP command, see the
+// NewSetPausedInDebuggerMessage constructor. Options are applied in order.
+type SetPausedInDebuggerMessageOption = func(*SetPausedInDebuggerMessage)
+
 // SetMessage adds or modifies the value of the optional
 // parameter `message` in the SetPausedInDebuggerMessage CDP command.
 //
@@ -991,6 +1215,14 @@ func (t *SetPausedInDebuggerMessage) SetMessage(v string) *SetPausedInDebuggerMe
 	return t
 }
 
+// SetPausedInDebuggerMessageWithMessage is a functional option which sets the value of the
+// optional parameter `message` in the SetPausedInDebuggerMessage CDP command, see SetMessage.
+func SetPausedInDebuggerMessageWithMessage(v string) SetPausedInDebuggerMessageOption {
+	return func(c *SetPausedInDebuggerMessage) {
+		c.SetMessage(v)
+	}
+}
+
 // Do sends the SetPausedInDebuggerMessage CDP command to a browser,
 // and returns the browser's response.
 func (t *SetPausedInDebuggerMessage) Do(ctx context.Context) error {
@@ -1716,13 +1948,23 @@ type SetShowHinge struct {
 
 // NewSetShowHinge constructs a new SetShowHinge struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetShowHingeOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Overlay/#method-setShowHinge
-func NewSetShowHinge() *SetShowHinge {
-	return &SetShowHinge{}
+func NewSetShowHinge(opts ...SetShowHingeOption) *SetShowHinge {
+	c := &SetShowHinge{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetShowHingeOption is a functional option for the optional
+// parameters of the SetShowHinge CDP command, see the
+// NewSetShowHinge constructor. Options are applied in order.
+type SetShowHingeOption = func(*SetShowHinge)
+
 // SetHingeConfig adds or modifies the value of the optional
 // parameter `hingeConfig` in the SetShowHinge CDP command.
 //
@@ -1732,6 +1974,14 @@ func (t *SetShowHinge) SetHingeConfig(v HingeConfig) *SetShowHinge {
 	return t
 }
 
+// SetShowHingeWithHingeConfig is a functional option which sets the value of the
+// optional parameter `hingeConfig` in the SetShowHinge CDP command, see SetHingeConfig.
+func SetShowHingeWithHingeConfig(v HingeConfig) SetShowHingeOption {
+	return func(c *SetShowHinge) {
+		c.SetHingeConfig(v)
+	}
+}
+
 // Do sends the SetShowHinge CDP command to a browser,
 // and returns the browser's response.
 func (t *SetShowHinge) Do(ctx context.Context) error {
@@ -144,7 +144,7 @@ type HighlightConfig struct {
 	// Whether the rulers should be shown (default: false).
 	ShowRulers bool `json:"showRulers,omitempty"`
 	// Whether the a11y info should be shown (default: true).
-	ShowAccessibilityInfo bool `json:"showAccessibilityInfo,omitempty"`
+	ShowAccessibilityInfo *bool `json:"showAccessibilityInfo,omitempty"`
 	// Whether the extension lines from node to the rulers should be shown (default: false).
 	ShowExtensionLines bool `json:"showExtensionLines,omitempty"`
 	// The content box highlight fill color (default: transparent).
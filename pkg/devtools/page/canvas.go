@@ -0,0 +1,183 @@
+package page
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/dom"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+type canvasConfig struct {
+	screenshot bool
+}
+
+// CanvasOption is used for customization in the `page.CaptureCanvas` function.
+type CanvasOption = func(*canvasConfig)
+
+// CanvasFromScreenshot allows the caller of the `page.CaptureCanvas` function
+// to capture the canvas with a clipped screenshot, instead of reading its
+// pixels with `toDataURL`. This is needed for WebGL canvases which are
+// created without the `preserveDrawingBuffer` attribute, because their
+// drawing buffer is usually cleared when it's read outside of rendering.
+func CanvasFromScreenshot() CanvasOption {
+	return func(c *canvasConfig) {
+		c.screenshot = true
+	}
+}
+
+// Return the PNG data URL of `this` canvas element, or an empty
+// string if it's tainted by cross-origin data.
+const canvasDataURL = `function() {
+	if (!(this instanceof HTMLCanvasElement)) {
+		throw new Error("element is not a canvas: " + this.localName);
+	}
+	try {
+		return this.toDataURL("image/png");
+	} catch (e) {
+		if (e.name === "SecurityError") {
+			return "";
+		}
+		throw e;
+	}
+}`
+
+// Return the pixel size of `this` canvas element, the device pixel ratio,
+// and the canvas's content box in CSS pixels, relative to the viewport.
+const canvasGeometry = `function() {
+	const r = this.getBoundingClientRect();
+	const s = getComputedStyle(this);
+	const px = (p) => parseFloat(s[p]) || 0;
+	const left = px("borderLeftWidth") + px("paddingLeft");
+	const top = px("borderTopWidth") + px("paddingTop");
+	return {
+		width: this.width,
+		height: this.height,
+		dpr: window.devicePixelRatio,
+		x: r.left + left,
+		y: r.top + top,
+		cssWidth: r.width - left - px("borderRightWidth") - px("paddingRight"),
+		cssHeight: r.height - top - px("borderBottomWidth") - px("paddingBottom"),
+	};
+}`
+
+type canvasInfo struct {
+	Width     float64 `json:"width"`
+	Height    float64 `json:"height"`
+	DPR       float64 `json:"dpr"`
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	CSSWidth  float64 `json:"cssWidth"`
+	CSSHeight float64 `json:"cssHeight"`
+}
+
+// CaptureCanvas returns the current content of the first <canvas> element
+// which matches the given selector (see `dom.Query`), in the main frame of
+// the page associated with the given context, as PNG image data, e.g. to
+// validate the rendering of charts and maps.
+//
+// By default, it reads the canvas's pixels with `toDataURL`, so the image
+// has the canvas's own resolution, and it's unaffected by overlapping
+// elements. Canvases which are tainted by cross-origin data can't be read,
+// so they're captured with a clipped screenshot instead, like with the
+// `page.CanvasFromScreenshot` option. Screenshots are scaled to the
+// canvas's resolution too, regardless of the device pixel ratio.
+func CaptureCanvas(ctx context.Context, selector string, opts ...CanvasOption) ([]byte, error) {
+	cfg := &canvasConfig{}
+	for _, o := range opts {
+		o(cfg)
+	}
+
+	h, err := dom.Query(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+	var img []byte
+	err = h.Do(ctx, func(ctx context.Context, h *dom.ElementHandle) error {
+		obj, err := h.ObjectID(ctx)
+		if err != nil {
+			return err
+		}
+		if !cfg.screenshot {
+			var u string
+			if err := callCanvasFunction(ctx, obj, canvasDataURL, &u); err != nil {
+				return err
+			}
+			if u != "" {
+				img, err = decodeDataURL(u)
+				return err
+			}
+		}
+
+		id, err := h.NodeID(ctx)
+		if err != nil {
+			return err
+		}
+		if err := dom.NewScrollIntoViewIfNeeded().SetNodeID(id).Do(ctx); err != nil {
+			return err
+		}
+		info := &canvasInfo{}
+		if err := callCanvasFunction(ctx, obj, canvasGeometry, info); err != nil {
+			return err
+		}
+		m, err := NewGetLayoutMetrics().Do(ctx)
+		if err != nil {
+			return err
+		}
+		clip, err := canvasClip(info, float64(m.CSSLayoutViewport.PageX), float64(m.CSSLayoutViewport.PageY))
+		if err != nil {
+			return err
+		}
+		r, err := NewCaptureScreenshot().SetFormat("png").SetClip(*clip).SetCaptureBeyondViewport(true).Do(ctx)
+		if err != nil {
+			return err
+		}
+		img, err = base64.StdEncoding.DecodeString(r.Data)
+		return err
+	})
+	return img, err
+}
+
+func callCanvasFunction(ctx context.Context, obj runtime.RemoteObjectID, f string, v interface{}) error {
+	r, err := runtime.NewCallFunctionOn(f).SetObjectID(string(obj)).SetReturnByValue(true).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if r.ExceptionDetails != nil {
+		return r.ExceptionDetails
+	}
+	return json.Unmarshal(r.Result.Value, v)
+}
+
+// Return the screenshot clip of a canvas, in CSS pixels relative to the
+// document, with a scale which makes the screenshot's size (which is also
+// multiplied by the device pixel ratio) equal to the canvas's size.
+func canvasClip(info *canvasInfo, pageX, pageY float64) (*Viewport, error) {
+	if info.CSSWidth <= 0 || info.CSSHeight <= 0 || info.Width <= 0 {
+		return nil, errors.New("canvas is not rendered, or it's empty")
+	}
+	dpr := info.DPR
+	if dpr <= 0 {
+		dpr = 1
+	}
+	return &Viewport{
+		X:      info.X + pageX,
+		Y:      info.Y + pageY,
+		Width:  info.CSSWidth,
+		Height: info.CSSHeight,
+		Scale:  info.Width / (info.CSSWidth * dpr),
+	}, nil
+}
+
+// Decode the data of a base64 "data:" URL.
+func decodeDataURL(u string) ([]byte, error) {
+	i := strings.Index(u, ",")
+	if !strings.HasPrefix(u, "data:") || i < 0 || !strings.HasSuffix(u[:i], ";base64") {
+		return nil, fmt.Errorf("unexpected canvas data URL: %.30q", u)
+	}
+	return base64.StdEncoding.DecodeString(u[i+1:])
+}
@@ -0,0 +1,37 @@
+package page
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCanvasClip(t *testing.T) {
+	// A 600x300 canvas which is displayed at 300x150 CSS pixels, on a 2x screen.
+	info := &canvasInfo{Width: 600, Height: 300, DPR: 2, X: 10, Y: 20, CSSWidth: 300, CSSHeight: 150}
+	got, err := canvasClip(info, 0, 500)
+	if err != nil {
+		t.Fatalf("canvasClip(); got error: %v", err)
+	}
+	want := &Viewport{X: 10, Y: 520, Width: 300, Height: 150, Scale: 1}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("canvasClip() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := canvasClip(&canvasInfo{Width: 600, Height: 300, DPR: 1}, 0, 0); err == nil {
+		t.Error("canvasClip() with hidden canvas; got nil error")
+	}
+}
+
+func TestDecodeDataURL(t *testing.T) {
+	got, err := decodeDataURL("data:image/png;base64,iVBORw0KGgo=")
+	if err != nil {
+		t.Fatalf("decodeDataURL(); got error: %v", err)
+	}
+	if want := "\x89PNG\r\n\x1a\n"; string(got) != want {
+		t.Errorf("decodeDataURL() = %q, want %q", got, want)
+	}
+	if _, err := decodeDataURL("data:,"); err == nil {
+		t.Error("decodeDataURL() with empty canvas; got nil error")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sync/atomic"
 
 	"github.com/daabr/chrome-vision/pkg/devtools"
 	"github.com/daabr/chrome-vision/pkg/devtools/debugger"
@@ -107,15 +108,25 @@ type AddScriptToEvaluateOnNewDocument struct {
 
 // NewAddScriptToEvaluateOnNewDocument constructs a new AddScriptToEvaluateOnNewDocument struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `AddScriptToEvaluateOnNewDocumentOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-addScriptToEvaluateOnNewDocument
-func NewAddScriptToEvaluateOnNewDocument(source string) *AddScriptToEvaluateOnNewDocument {
-	return &AddScriptToEvaluateOnNewDocument{
+func NewAddScriptToEvaluateOnNewDocument(source string, opts ...AddScriptToEvaluateOnNewDocumentOption) *AddScriptToEvaluateOnNewDocument {
+	c := &AddScriptToEvaluateOnNewDocument{
 		Source: source,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// AddScriptToEvaluateOnNewDocumentOption is a functional option for the optional
+// parameters of the AddScriptToEvaluateOnNewDocument CDP command, see the
+// NewAddScriptToEvaluateOnNewDocument constructor. Options are applied in order.
+type AddScriptToEvaluateOnNewDocumentOption = func(*AddScriptToEvaluateOnNewDocument)
+
 // SetWorldName adds or modifies the value of the optional
 // parameter `worldName` in the AddScriptToEvaluateOnNewDocument CDP command.
 //
@@ -129,6 +140,14 @@ func (t *AddScriptToEvaluateOnNewDocument) SetWorldName(v string) *AddScriptToEv
 	return t
 }
 
+// AddScriptToEvaluateOnNewDocumentWithWorldName is a functional option which sets the value of the
+// optional parameter `worldName` in the AddScriptToEvaluateOnNewDocument CDP command, see SetWorldName.
+func AddScriptToEvaluateOnNewDocumentWithWorldName(v string) AddScriptToEvaluateOnNewDocumentOption {
+	return func(c *AddScriptToEvaluateOnNewDocument) {
+		c.SetWorldName(v)
+	}
+}
+
 // SetIncludeCommandLineAPI adds or modifies the value of the optional
 // parameter `includeCommandLineAPI` in the AddScriptToEvaluateOnNewDocument CDP command.
 //
@@ -141,6 +160,14 @@ func (t *AddScriptToEvaluateOnNewDocument) SetIncludeCommandLineAPI(v bool) *Add
 	return t
 }
 
+// AddScriptToEvaluateOnNewDocumentWithIncludeCommandLineAPI is a functional option which sets the value of the
+// optional parameter `includeCommandLineAPI` in the AddScriptToEvaluateOnNewDocument CDP command, see SetIncludeCommandLineAPI.
+func AddScriptToEvaluateOnNewDocumentWithIncludeCommandLineAPI(v bool) AddScriptToEvaluateOnNewDocumentOption {
+	return func(c *AddScriptToEvaluateOnNewDocument) {
+		c.SetIncludeCommandLineAPI(v)
+	}
+}
+
 // AddScriptToEvaluateOnNewDocumentResult contains the browser's response
 // to calling the AddScriptToEvaluateOnNewDocument CDP command with Do().
 type AddScriptToEvaluateOnNewDocumentResult struct {
@@ -247,7 +274,7 @@ type CaptureScreenshot struct {
 	// Capture the screenshot from the surface, rather than the view. Defaults to true.
 	//
 	// This CDP parameter is experimental.
-	FromSurface bool `json:"fromSurface,omitempty"`
+	FromSurface *bool `json:"fromSurface,omitempty"`
 	// Capture the screenshot beyond the viewport. Defaults to false.
 	//
 	// This CDP parameter is experimental.
@@ -256,13 +283,23 @@ type CaptureScreenshot struct {
 
 // NewCaptureScreenshot constructs a new CaptureScreenshot struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `CaptureScreenshotOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-captureScreenshot
-func NewCaptureScreenshot() *CaptureScreenshot {
-	return &CaptureScreenshot{}
+func NewCaptureScreenshot(opts ...CaptureScreenshotOption) *CaptureScreenshot {
+	c := &CaptureScreenshot{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// CaptureScreenshotOption is a functional option for the optional
+// parameters of the CaptureScreenshot CDP command, see the
+// NewCaptureScreenshot constructor. Options are applied in order.
+type CaptureScreenshotOption = func(*CaptureScreenshot)
+
 // SetFormat adds or modifies the value of the optional
 // parameter `format` in the CaptureScreenshot CDP command.
 //
@@ -272,6 +309,14 @@ func (t *CaptureScreenshot) SetFormat(v string) *CaptureScreenshot {
 	return t
 }
 
+// CaptureScreenshotWithFormat is a functional option which sets the value of the
+// optional parameter `format` in the CaptureScreenshot CDP command, see SetFormat.
+func CaptureScreenshotWithFormat(v string) CaptureScreenshotOption {
+	return func(c *CaptureScreenshot) {
+		c.SetFormat(v)
+	}
+}
+
 // SetQuality adds or modifies the value of the optional
 // parameter `quality` in the CaptureScreenshot CDP command.
 //
@@ -281,6 +326,14 @@ func (t *CaptureScreenshot) SetQuality(v int64) *CaptureScreenshot {
 	return t
 }
 
+// CaptureScreenshotWithQuality is a functional option which sets the value of the
+// optional parameter `quality` in the CaptureScreenshot CDP command, see SetQuality.
+func CaptureScreenshotWithQuality(v int64) CaptureScreenshotOption {
+	return func(c *CaptureScreenshot) {
+		c.SetQuality(v)
+	}
+}
+
 // SetClip adds or modifies the value of the optional
 // parameter `clip` in the CaptureScreenshot CDP command.
 //
@@ -290,6 +343,14 @@ func (t *CaptureScreenshot) SetClip(v Viewport) *CaptureScreenshot {
 	return t
 }
 
+// CaptureScreenshotWithClip is a functional option which sets the value of the
+// optional parameter `clip` in the CaptureScreenshot CDP command, see SetClip.
+func CaptureScreenshotWithClip(v Viewport) CaptureScreenshotOption {
+	return func(c *CaptureScreenshot) {
+		c.SetClip(v)
+	}
+}
+
 // SetFromSurface adds or modifies the value of the optional
 // parameter `fromSurface` in the CaptureScreenshot CDP command.
 //
@@ -297,10 +358,18 @@ func (t *CaptureScreenshot) SetClip(v Viewport) *CaptureScreenshot {
 //
 // This CDP parameter is experimental.
 func (t *CaptureScreenshot) SetFromSurface(v bool) *CaptureScreenshot {
-	t.FromSurface = v
+	t.FromSurface = &v
 	return t
 }
 
+// CaptureScreenshotWithFromSurface is a functional option which sets the value of the
+// optional parameter `fromSurface` in the CaptureScreenshot CDP command, see SetFromSurface.
+func CaptureScreenshotWithFromSurface(v bool) CaptureScreenshotOption {
+	return func(c *CaptureScreenshot) {
+		c.SetFromSurface(v)
+	}
+}
+
 // SetCaptureBeyondViewport adds or modifies the value of the optional
 // parameter `captureBeyondViewport` in the CaptureScreenshot CDP command.
 //
@@ -312,6 +381,14 @@ func (t *CaptureScreenshot) SetCaptureBeyondViewport(v bool) *CaptureScreenshot
 	return t
 }
 
+// CaptureScreenshotWithCaptureBeyondViewport is a functional option which sets the value of the
+// optional parameter `captureBeyondViewport` in the CaptureScreenshot CDP command, see SetCaptureBeyondViewport.
+func CaptureScreenshotWithCaptureBeyondViewport(v bool) CaptureScreenshotOption {
+	return func(c *CaptureScreenshot) {
+		c.SetCaptureBeyondViewport(v)
+	}
+}
+
 // CaptureScreenshotResult contains the browser's response
 // to calling the CaptureScreenshot CDP command with Do().
 type CaptureScreenshotResult struct {
@@ -374,15 +451,25 @@ type CaptureSnapshot struct {
 
 // NewCaptureSnapshot constructs a new CaptureSnapshot struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `CaptureSnapshotOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-captureSnapshot
 //
 // This CDP method is experimental.
-func NewCaptureSnapshot() *CaptureSnapshot {
-	return &CaptureSnapshot{}
+func NewCaptureSnapshot(opts ...CaptureSnapshotOption) *CaptureSnapshot {
+	c := &CaptureSnapshot{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// CaptureSnapshotOption is a functional option for the optional
+// parameters of the CaptureSnapshot CDP command, see the
+// NewCaptureSnapshot constructor. Options are applied in order.
+type CaptureSnapshotOption = func(*CaptureSnapshot)
+
 // SetFormat adds or modifies the value of the optional
 // parameter `format` in the CaptureSnapshot CDP command.
 //
@@ -392,6 +479,14 @@ func (t *CaptureSnapshot) SetFormat(v string) *CaptureSnapshot {
 	return t
 }
 
+// CaptureSnapshotWithFormat is a functional option which sets the value of the
+// optional parameter `format` in the CaptureSnapshot CDP command, see SetFormat.
+func CaptureSnapshotWithFormat(v string) CaptureSnapshotOption {
+	return func(c *CaptureSnapshot) {
+		c.SetFormat(v)
+	}
+}
+
 // CaptureSnapshotResult contains the browser's response
 // to calling the CaptureSnapshot CDP command with Do().
 type CaptureSnapshotResult struct {
@@ -456,15 +551,25 @@ type CreateIsolatedWorld struct {
 
 // NewCreateIsolatedWorld constructs a new CreateIsolatedWorld struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `CreateIsolatedWorldOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-createIsolatedWorld
-func NewCreateIsolatedWorld(frameID string) *CreateIsolatedWorld {
-	return &CreateIsolatedWorld{
+func NewCreateIsolatedWorld(frameID string, opts ...CreateIsolatedWorldOption) *CreateIsolatedWorld {
+	c := &CreateIsolatedWorld{
 		FrameID: frameID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// CreateIsolatedWorldOption is a functional option for the optional
+// parameters of the CreateIsolatedWorld CDP command, see the
+// NewCreateIsolatedWorld constructor. Options are applied in order.
+type CreateIsolatedWorldOption = func(*CreateIsolatedWorld)
+
 // SetWorldName adds or modifies the value of the optional
 // parameter `worldName` in the CreateIsolatedWorld CDP command.
 //
@@ -474,6 +579,14 @@ func (t *CreateIsolatedWorld) SetWorldName(v string) *CreateIsolatedWorld {
 	return t
 }
 
+// CreateIsolatedWorldWithWorldName is a functional option which sets the value of the
+// optional parameter `worldName` in the CreateIsolatedWorld CDP command, see SetWorldName.
+func CreateIsolatedWorldWithWorldName(v string) CreateIsolatedWorldOption {
+	return func(c *CreateIsolatedWorld) {
+		c.SetWorldName(v)
+	}
+}
+
 // SetGrantUniveralAccess adds or modifies the value of the optional
 // parameter `grantUniveralAccess` in the CreateIsolatedWorld CDP command.
 //
@@ -484,6 +597,14 @@ func (t *CreateIsolatedWorld) SetGrantUniveralAccess(v bool) *CreateIsolatedWorl
 	return t
 }
 
+// CreateIsolatedWorldWithGrantUniveralAccess is a functional option which sets the value of the
+// optional parameter `grantUniveralAccess` in the CreateIsolatedWorld CDP command, see SetGrantUniveralAccess.
+func CreateIsolatedWorldWithGrantUniveralAccess(v bool) CreateIsolatedWorldOption {
+	return func(c *CreateIsolatedWorld) {
+		c.SetGrantUniveralAccess(v)
+	}
+}
+
 // CreateIsolatedWorldResult contains the browser's response
 // to calling the CreateIsolatedWorld CDP command with Do().
 type CreateIsolatedWorldResult struct {
@@ -1231,15 +1352,25 @@ type HandleJavaScriptDialog struct {
 
 // NewHandleJavaScriptDialog constructs a new HandleJavaScriptDialog struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `HandleJavaScriptDialogOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-handleJavaScriptDialog
-func NewHandleJavaScriptDialog(accept bool) *HandleJavaScriptDialog {
-	return &HandleJavaScriptDialog{
+func NewHandleJavaScriptDialog(accept bool, opts ...HandleJavaScriptDialogOption) *HandleJavaScriptDialog {
+	c := &HandleJavaScriptDialog{
 		Accept: accept,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// HandleJavaScriptDialogOption is a functional option for the optional
+// parameters of the HandleJavaScriptDialog CDP command, see the
+// NewHandleJavaScriptDialog constructor. Options are applied in order.
+type HandleJavaScriptDialogOption = func(*HandleJavaScriptDialog)
+
 // SetPromptText adds or modifies the value of the optional
 // parameter `promptText` in the HandleJavaScriptDialog CDP command.
 //
@@ -1250,6 +1381,14 @@ func (t *HandleJavaScriptDialog) SetPromptText(v string) *HandleJavaScriptDialog
 	return t
 }
 
+// HandleJavaScriptDialogWithPromptText is a functional option which sets the value of the
+// optional parameter `promptText` in the HandleJavaScriptDialog CDP command, see SetPromptText.
+func HandleJavaScriptDialogWithPromptText(v string) HandleJavaScriptDialogOption {
+	return func(c *HandleJavaScriptDialog) {
+		c.SetPromptText(v)
+	}
+}
+
 // Do sends the HandleJavaScriptDialog CDP command to a browser,
 // and returns the browser's response.
 func (t *HandleJavaScriptDialog) Do(ctx context.Context) error {
@@ -1308,15 +1447,25 @@ type Navigate struct {
 
 // NewNavigate constructs a new Navigate struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `NavigateOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-navigate
-func NewNavigate(url string) *Navigate {
-	return &Navigate{
+func NewNavigate(url string, opts ...NavigateOption) *Navigate {
+	c := &Navigate{
 		URL: url,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// NavigateOption is a functional option for the optional
+// parameters of the Navigate CDP command, see the
+// NewNavigate constructor. Options are applied in order.
+type NavigateOption = func(*Navigate)
+
 // SetReferrer adds or modifies the value of the optional
 // parameter `referrer` in the Navigate CDP command.
 //
@@ -1326,6 +1475,14 @@ func (t *Navigate) SetReferrer(v string) *Navigate {
 	return t
 }
 
+// NavigateWithReferrer is a functional option which sets the value of the
+// optional parameter `referrer` in the Navigate CDP command, see SetReferrer.
+func NavigateWithReferrer(v string) NavigateOption {
+	return func(c *Navigate) {
+		c.SetReferrer(v)
+	}
+}
+
 // SetTransitionType adds or modifies the value of the optional
 // parameter `transitionType` in the Navigate CDP command.
 //
@@ -1335,6 +1492,14 @@ func (t *Navigate) SetTransitionType(v TransitionType) *Navigate {
 	return t
 }
 
+// NavigateWithTransitionType is a functional option which sets the value of the
+// optional parameter `transitionType` in the Navigate CDP command, see SetTransitionType.
+func NavigateWithTransitionType(v TransitionType) NavigateOption {
+	return func(c *Navigate) {
+		c.SetTransitionType(v)
+	}
+}
+
 // SetFrameID adds or modifies the value of the optional
 // parameter `frameId` in the Navigate CDP command.
 //
@@ -1344,6 +1509,14 @@ func (t *Navigate) SetFrameID(v string) *Navigate {
 	return t
 }
 
+// NavigateWithFrameID is a functional option which sets the value of the
+// optional parameter `frameId` in the Navigate CDP command, see SetFrameID.
+func NavigateWithFrameID(v string) NavigateOption {
+	return func(c *Navigate) {
+		c.SetFrameID(v)
+	}
+}
+
 // SetReferrerPolicy adds or modifies the value of the optional
 // parameter `referrerPolicy` in the Navigate CDP command.
 //
@@ -1355,6 +1528,14 @@ func (t *Navigate) SetReferrerPolicy(v ReferrerPolicy) *Navigate {
 	return t
 }
 
+// NavigateWithReferrerPolicy is a functional option which sets the value of the
+// optional parameter `referrerPolicy` in the Navigate CDP command, see SetReferrerPolicy.
+func NavigateWithReferrerPolicy(v ReferrerPolicy) NavigateOption {
+	return func(c *Navigate) {
+		c.SetReferrerPolicy(v)
+	}
+}
+
 // NavigateResult contains the browser's response
 // to calling the Navigate CDP command with Do().
 type NavigateResult struct {
@@ -1479,19 +1660,19 @@ type PrintToPDF struct {
 	// Print background graphics. Defaults to false.
 	PrintBackground bool `json:"printBackground,omitempty"`
 	// Scale of the webpage rendering. Defaults to 1.
-	Scale float64 `json:"scale,omitempty"`
+	Scale *float64 `json:"scale,omitempty"`
 	// Paper width in inches. Defaults to 8.5 inches.
-	PaperWidth float64 `json:"paperWidth,omitempty"`
+	PaperWidth *float64 `json:"paperWidth,omitempty"`
 	// Paper height in inches. Defaults to 11 inches.
-	PaperHeight float64 `json:"paperHeight,omitempty"`
+	PaperHeight *float64 `json:"paperHeight,omitempty"`
 	// Top margin in inches. Defaults to 1cm (~0.4 inches).
-	MarginTop float64 `json:"marginTop,omitempty"`
+	MarginTop *float64 `json:"marginTop,omitempty"`
 	// Bottom margin in inches. Defaults to 1cm (~0.4 inches).
-	MarginBottom float64 `json:"marginBottom,omitempty"`
+	MarginBottom *float64 `json:"marginBottom,omitempty"`
 	// Left margin in inches. Defaults to 1cm (~0.4 inches).
-	MarginLeft float64 `json:"marginLeft,omitempty"`
+	MarginLeft *float64 `json:"marginLeft,omitempty"`
 	// Right margin in inches. Defaults to 1cm (~0.4 inches).
-	MarginRight float64 `json:"marginRight,omitempty"`
+	MarginRight *float64 `json:"marginRight,omitempty"`
 	// Paper ranges to print, e.g., '1-5, 8, 11-13'. Defaults to the empty string, which means
 	// print all pages.
 	PageRanges string `json:"pageRanges,omitempty"`
@@ -1521,13 +1702,23 @@ type PrintToPDF struct {
 
 // NewPrintToPDF constructs a new PrintToPDF struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `PrintToPDFOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-printToPDF
-func NewPrintToPDF() *PrintToPDF {
-	return &PrintToPDF{}
+func NewPrintToPDF(opts ...PrintToPDFOption) *PrintToPDF {
+	c := &PrintToPDF{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// PrintToPDFOption is a functional option for the optional
+// parameters of the PrintToPDF CDP command, see the
+// NewPrintToPDF constructor. Options are applied in order.
+type PrintToPDFOption = func(*PrintToPDF)
+
 // SetLandscape adds or modifies the value of the optional
 // parameter `landscape` in the PrintToPDF CDP command.
 //
@@ -1537,6 +1728,14 @@ func (t *PrintToPDF) SetLandscape(v bool) *PrintToPDF {
 	return t
 }
 
+// PrintToPDFWithLandscape is a functional option which sets the value of the
+// optional parameter `landscape` in the PrintToPDF CDP command, see SetLandscape.
+func PrintToPDFWithLandscape(v bool) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetLandscape(v)
+	}
+}
+
 // SetDisplayHeaderFooter adds or modifies the value of the optional
 // parameter `displayHeaderFooter` in the PrintToPDF CDP command.
 //
@@ -1546,6 +1745,14 @@ func (t *PrintToPDF) SetDisplayHeaderFooter(v bool) *PrintToPDF {
 	return t
 }
 
+// PrintToPDFWithDisplayHeaderFooter is a functional option which sets the value of the
+// optional parameter `displayHeaderFooter` in the PrintToPDF CDP command, see SetDisplayHeaderFooter.
+func PrintToPDFWithDisplayHeaderFooter(v bool) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetDisplayHeaderFooter(v)
+	}
+}
+
 // SetPrintBackground adds or modifies the value of the optional
 // parameter `printBackground` in the PrintToPDF CDP command.
 //
@@ -1555,69 +1762,133 @@ func (t *PrintToPDF) SetPrintBackground(v bool) *PrintToPDF {
 	return t
 }
 
+// PrintToPDFWithPrintBackground is a functional option which sets the value of the
+// optional parameter `printBackground` in the PrintToPDF CDP command, see SetPrintBackground.
+func PrintToPDFWithPrintBackground(v bool) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetPrintBackground(v)
+	}
+}
+
 // SetScale adds or modifies the value of the optional
 // parameter `scale` in the PrintToPDF CDP command.
 //
 // Scale of the webpage rendering. Defaults to 1.
 func (t *PrintToPDF) SetScale(v float64) *PrintToPDF {
-	t.Scale = v
+	t.Scale = &v
 	return t
 }
 
+// PrintToPDFWithScale is a functional option which sets the value of the
+// optional parameter `scale` in the PrintToPDF CDP command, see SetScale.
+func PrintToPDFWithScale(v float64) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetScale(v)
+	}
+}
+
 // SetPaperWidth adds or modifies the value of the optional
 // parameter `paperWidth` in the PrintToPDF CDP command.
 //
 // Paper width in inches. Defaults to 8.5 inches.
 func (t *PrintToPDF) SetPaperWidth(v float64) *PrintToPDF {
-	t.PaperWidth = v
+	t.PaperWidth = &v
 	return t
 }
 
+// PrintToPDFWithPaperWidth is a functional option which sets the value of the
+// optional parameter `paperWidth` in the PrintToPDF CDP command, see SetPaperWidth.
+func PrintToPDFWithPaperWidth(v float64) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetPaperWidth(v)
+	}
+}
+
 // SetPaperHeight adds or modifies the value of the optional
 // parameter `paperHeight` in the PrintToPDF CDP command.
 //
 // Paper height in inches. Defaults to 11 inches.
 func (t *PrintToPDF) SetPaperHeight(v float64) *PrintToPDF {
-	t.PaperHeight = v
+	t.PaperHeight = &v
 	return t
 }
 
+// PrintToPDFWithPaperHeight is a functional option which sets the value of the
+// optional parameter `paperHeight` in the PrintToPDF CDP command, see SetPaperHeight.
+func PrintToPDFWithPaperHeight(v float64) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetPaperHeight(v)
+	}
+}
+
 // SetMarginTop adds or modifies the value of the optional
 // parameter `marginTop` in the PrintToPDF CDP command.
 //
 // Top margin in inches. Defaults to 1cm (~0.4 inches).
 func (t *PrintToPDF) SetMarginTop(v float64) *PrintToPDF {
-	t.MarginTop = v
+	t.MarginTop = &v
 	return t
 }
 
+// PrintToPDFWithMarginTop is a functional option which sets the value of the
+// optional parameter `marginTop` in the PrintToPDF CDP command, see SetMarginTop.
+func PrintToPDFWithMarginTop(v float64) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetMarginTop(v)
+	}
+}
+
 // SetMarginBottom adds or modifies the value of the optional
 // parameter `marginBottom` in the PrintToPDF CDP command.
 //
 // Bottom margin in inches. Defaults to 1cm (~0.4 inches).
 func (t *PrintToPDF) SetMarginBottom(v float64) *PrintToPDF {
-	t.MarginBottom = v
+	t.MarginBottom = &v
 	return t
 }
 
+// PrintToPDFWithMarginBottom is a functional option which sets the value of the
+// optional parameter `marginBottom` in the PrintToPDF CDP command, see SetMarginBottom.
+func PrintToPDFWithMarginBottom(v float64) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetMarginBottom(v)
+	}
+}
+
 // SetMarginLeft adds or modifies the value of the optional
 // parameter `marginLeft` in the PrintToPDF CDP command.
 //
 // Left margin in inches. Defaults to 1cm (~0.4 inches).
 func (t *PrintToPDF) SetMarginLeft(v float64) *PrintToPDF {
-	t.MarginLeft = v
+	t.MarginLeft = &v
 	return t
 }
 
+// PrintToPDFWithMarginLeft is a functional option which sets the value of the
+// optional parameter `marginLeft` in the PrintToPDF CDP command, see SetMarginLeft.
+func PrintToPDFWithMarginLeft(v float64) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetMarginLeft(v)
+	}
+}
+
 // SetMarginRight adds or modifies the value of the optional
 // parameter `marginRight` in the PrintToPDF CDP command.
 //
 // Right margin in inches. Defaults to 1cm (~0.4 inches).
 func (t *PrintToPDF) SetMarginRight(v float64) *PrintToPDF {
-	t.MarginRight = v
+	t.MarginRight = &v
 	return t
 }
 
+// PrintToPDFWithMarginRight is a functional option which sets the value of the
+// optional parameter `marginRight` in the PrintToPDF CDP command, see SetMarginRight.
+func PrintToPDFWithMarginRight(v float64) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetMarginRight(v)
+	}
+}
+
 // SetPageRanges adds or modifies the value of the optional
 // parameter `pageRanges` in the PrintToPDF CDP command.
 //
@@ -1628,6 +1899,14 @@ func (t *PrintToPDF) SetPageRanges(v string) *PrintToPDF {
 	return t
 }
 
+// PrintToPDFWithPageRanges is a functional option which sets the value of the
+// optional parameter `pageRanges` in the PrintToPDF CDP command, see SetPageRanges.
+func PrintToPDFWithPageRanges(v string) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetPageRanges(v)
+	}
+}
+
 // SetIgnoreInvalidPageRanges adds or modifies the value of the optional
 // parameter `ignoreInvalidPageRanges` in the PrintToPDF CDP command.
 //
@@ -1638,6 +1917,14 @@ func (t *PrintToPDF) SetIgnoreInvalidPageRanges(v bool) *PrintToPDF {
 	return t
 }
 
+// PrintToPDFWithIgnoreInvalidPageRanges is a functional option which sets the value of the
+// optional parameter `ignoreInvalidPageRanges` in the PrintToPDF CDP command, see SetIgnoreInvalidPageRanges.
+func PrintToPDFWithIgnoreInvalidPageRanges(v bool) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetIgnoreInvalidPageRanges(v)
+	}
+}
+
 // SetHeaderTemplate adds or modifies the value of the optional
 // parameter `headerTemplate` in the PrintToPDF CDP command.
 //
@@ -1655,6 +1942,14 @@ func (t *PrintToPDF) SetHeaderTemplate(v string) *PrintToPDF {
 	return t
 }
 
+// PrintToPDFWithHeaderTemplate is a functional option which sets the value of the
+// optional parameter `headerTemplate` in the PrintToPDF CDP command, see SetHeaderTemplate.
+func PrintToPDFWithHeaderTemplate(v string) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetHeaderTemplate(v)
+	}
+}
+
 // SetFooterTemplate adds or modifies the value of the optional
 // parameter `footerTemplate` in the PrintToPDF CDP command.
 //
@@ -1664,6 +1959,14 @@ func (t *PrintToPDF) SetFooterTemplate(v string) *PrintToPDF {
 	return t
 }
 
+// PrintToPDFWithFooterTemplate is a functional option which sets the value of the
+// optional parameter `footerTemplate` in the PrintToPDF CDP command, see SetFooterTemplate.
+func PrintToPDFWithFooterTemplate(v string) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetFooterTemplate(v)
+	}
+}
+
 // SetPreferCSSPageSize adds or modifies the value of the optional
 // parameter `preferCSSPageSize` in the PrintToPDF CDP command.
 //
@@ -1674,6 +1977,14 @@ func (t *PrintToPDF) SetPreferCSSPageSize(v bool) *PrintToPDF {
 	return t
 }
 
+// PrintToPDFWithPreferCSSPageSize is a functional option which sets the value of the
+// optional parameter `preferCSSPageSize` in the PrintToPDF CDP command, see SetPreferCSSPageSize.
+func PrintToPDFWithPreferCSSPageSize(v bool) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetPreferCSSPageSize(v)
+	}
+}
+
 // SetTransferMode adds or modifies the value of the optional
 // parameter `transferMode` in the PrintToPDF CDP command.
 //
@@ -1685,6 +1996,14 @@ func (t *PrintToPDF) SetTransferMode(v string) *PrintToPDF {
 	return t
 }
 
+// PrintToPDFWithTransferMode is a functional option which sets the value of the
+// optional parameter `transferMode` in the PrintToPDF CDP command, see SetTransferMode.
+func PrintToPDFWithTransferMode(v string) PrintToPDFOption {
+	return func(c *PrintToPDF) {
+		c.SetTransferMode(v)
+	}
+}
+
 // PrintToPDFResult contains the browser's response
 // to calling the PrintToPDF CDP command with Do().
 type PrintToPDFResult struct {
@@ -1751,13 +2070,23 @@ type Reload struct {
 
 // NewReload constructs a new Reload struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ReloadOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-reload
-func NewReload() *Reload {
-	return &Reload{}
+func NewReload(opts ...ReloadOption) *Reload {
+	c := &Reload{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ReloadOption is a functional option for the optional
+// parameters of the Reload CDP command, see the
+// NewReload constructor. Options are applied in order.
+type ReloadOption = func(*Reload)
+
 // SetIgnoreCache adds or modifies the value of the optional
 // parameter `ignoreCache` in the Reload CDP command.
 //
@@ -1767,6 +2096,14 @@ func (t *Reload) SetIgnoreCache(v bool) *Reload {
 	return t
 }
 
+// ReloadWithIgnoreCache is a functional option which sets the value of the
+// optional parameter `ignoreCache` in the Reload CDP command, see SetIgnoreCache.
+func ReloadWithIgnoreCache(v bool) ReloadOption {
+	return func(c *Reload) {
+		c.SetIgnoreCache(v)
+	}
+}
+
 // SetScriptToEvaluateOnLoad adds or modifies the value of the optional
 // parameter `scriptToEvaluateOnLoad` in the Reload CDP command.
 //
@@ -1777,6 +2114,14 @@ func (t *Reload) SetScriptToEvaluateOnLoad(v string) *Reload {
 	return t
 }
 
+// ReloadWithScriptToEvaluateOnLoad is a functional option which sets the value of the
+// optional parameter `scriptToEvaluateOnLoad` in the Reload CDP command, see SetScriptToEvaluateOnLoad.
+func ReloadWithScriptToEvaluateOnLoad(v string) ReloadOption {
+	return func(c *Reload) {
+		c.SetScriptToEvaluateOnLoad(v)
+	}
+}
+
 // Do sends the Reload CDP command to a browser,
 // and returns the browser's response.
 func (t *Reload) Do(ctx context.Context) error {
@@ -1941,6 +2286,9 @@ func (t *RemoveScriptToEvaluateOnNewDocument) ParseResponse(m *devtools.Message)
 type ScreencastFrameAck struct {
 	// Frame number.
 	SessionID int64 `json:"sessionId"`
+
+	// Cached JSON encoding of the parameters, see the `marshal` method.
+	cache atomic.Value
 }
 
 // NewScreencastFrameAck constructs a new ScreencastFrameAck struct instance, with
@@ -1959,7 +2307,7 @@ func NewScreencastFrameAck(sessionID int64) *ScreencastFrameAck {
 // Do sends the ScreencastFrameAck CDP command to a browser,
 // and returns the browser's response.
 func (t *ScreencastFrameAck) Do(ctx context.Context) error {
-	b, err := json.Marshal(t)
+	b, err := t.marshal()
 	if err != nil {
 		return err
 	}
@@ -1975,7 +2323,7 @@ func (t *ScreencastFrameAck) Do(ctx context.Context) error {
 // Callers should close the returned channel on their own,
 // although closing unused channels isn't strictly required.
 func (t *ScreencastFrameAck) Start(ctx context.Context) (chan *devtools.Message, error) {
-	b, err := json.Marshal(t)
+	b, err := t.marshal()
 	if err != nil {
 		return nil, err
 	}
@@ -1991,6 +2339,36 @@ func (t *ScreencastFrameAck) ParseResponse(m *devtools.Message) error {
 	return nil
 }
 
+// Cached JSON encoding of the ScreencastFrameAck CDP command's parameters.
+type screencastFrameAckCache struct {
+	params ScreencastFrameAck
+	b      []byte
+}
+
+// Report whether the cached parameters are equal to the given ones.
+func (c *screencastFrameAckCache) equal(t *ScreencastFrameAck) bool {
+	return c.params.SessionID == t.SessionID
+}
+
+// Return the JSON encoding of the parameters, and cache it for
+// subsequent calls, as long as the parameters don't change (whether
+// they're changed by the builder-like methods above, or directly).
+func (t *ScreencastFrameAck) marshal() ([]byte, error) {
+	if c, ok := t.cache.Load().(*screencastFrameAckCache); ok && c.equal(t) {
+		return c.b, nil
+	}
+	c := &screencastFrameAckCache{params: ScreencastFrameAck{
+		SessionID: t.SessionID,
+	}}
+	b, err := json.Marshal(&c.params)
+	if err != nil {
+		return nil, err
+	}
+	c.b = b
+	t.cache.Store(c)
+	return b, nil
+}
+
 // SearchInResource contains the parameters, and acts as
 // a Go receiver, for the CDP command `searchInResource`.
 //
@@ -2014,19 +2392,29 @@ type SearchInResource struct {
 
 // NewSearchInResource constructs a new SearchInResource struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SearchInResourceOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-searchInResource
 //
 // This CDP method is experimental.
-func NewSearchInResource(frameID string, url string, query string) *SearchInResource {
-	return &SearchInResource{
+func NewSearchInResource(frameID string, url string, query string, opts ...SearchInResourceOption) *SearchInResource {
+	c := &SearchInResource{
 		FrameID: frameID,
 		URL:     url,
 		Query:   query,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SearchInResourceOption is a functional option for the optional
+// parameters of the SearchInResource CDP command, see the
+// NewSearchInResource constructor. Options are applied in order.
+type SearchInResourceOption = func(*SearchInResource)
+
 // SetCaseSensitive adds or modifies the value of the optional
 // parameter `caseSensitive` in the SearchInResource CDP command.
 //
@@ -2036,6 +2424,14 @@ func (t *SearchInResource) SetCaseSensitive(v bool) *SearchInResource {
 	return t
 }
 
+// SearchInResourceWithCaseSensitive is a functional option which sets the value of the
+// optional parameter `caseSensitive` in the SearchInResource CDP command, see SetCaseSensitive.
+func SearchInResourceWithCaseSensitive(v bool) SearchInResourceOption {
+	return func(c *SearchInResource) {
+		c.SetCaseSensitive(v)
+	}
+}
+
 // SetIsRegex adds or modifies the value of the optional
 // parameter `isRegex` in the SearchInResource CDP command.
 //
@@ -2045,6 +2441,14 @@ func (t *SearchInResource) SetIsRegex(v bool) *SearchInResource {
 	return t
 }
 
+// SearchInResourceWithIsRegex is a functional option which sets the value of the
+// optional parameter `isRegex` in the SearchInResource CDP command, see SetIsRegex.
+func SearchInResourceWithIsRegex(v bool) SearchInResourceOption {
+	return func(c *SearchInResource) {
+		c.SetIsRegex(v)
+	}
+}
+
 // SearchInResourceResult contains the browser's response
 // to calling the SearchInResource CDP command with Do().
 type SearchInResourceResult struct {
@@ -2554,18 +2958,28 @@ type SetDownloadBehavior struct {
 
 // NewSetDownloadBehavior constructs a new SetDownloadBehavior struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetDownloadBehaviorOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-setDownloadBehavior
 //
 // This CDP method is deprecated.
 // This CDP method is experimental.
-func NewSetDownloadBehavior(behavior string) *SetDownloadBehavior {
-	return &SetDownloadBehavior{
+func NewSetDownloadBehavior(behavior string, opts ...SetDownloadBehaviorOption) *SetDownloadBehavior {
+	c := &SetDownloadBehavior{
 		Behavior: behavior,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetDownloadBehaviorOption is a functional option for the optional
+// parameters of the SetDownloadBehavior CDP command, see the
+// NewSetDownloadBehavior constructor. Options are applied in order.
+type SetDownloadBehaviorOption = func(*SetDownloadBehavior)
+
 // SetDownloadPath adds or modifies the value of the optional
 // parameter `downloadPath` in the SetDownloadBehavior CDP command.
 //
@@ -2575,6 +2989,14 @@ func (t *SetDownloadBehavior) SetDownloadPath(v string) *SetDownloadBehavior {
 	return t
 }
 
+// SetDownloadBehaviorWithDownloadPath is a functional option which sets the value of the
+// optional parameter `downloadPath` in the SetDownloadBehavior CDP command, see SetDownloadPath.
+func SetDownloadBehaviorWithDownloadPath(v string) SetDownloadBehaviorOption {
+	return func(c *SetDownloadBehavior) {
+		c.SetDownloadPath(v)
+	}
+}
+
 // Do sends the SetDownloadBehavior CDP command to a browser,
 // and returns the browser's response.
 func (t *SetDownloadBehavior) Do(ctx context.Context) error {
@@ -2694,15 +3116,25 @@ type StartScreencast struct {
 
 // NewStartScreencast constructs a new StartScreencast struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `StartScreencastOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-startScreencast
 //
 // This CDP method is experimental.
-func NewStartScreencast() *StartScreencast {
-	return &StartScreencast{}
+func NewStartScreencast(opts ...StartScreencastOption) *StartScreencast {
+	c := &StartScreencast{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// StartScreencastOption is a functional option for the optional
+// parameters of the StartScreencast CDP command, see the
+// NewStartScreencast constructor. Options are applied in order.
+type StartScreencastOption = func(*StartScreencast)
+
 // SetFormat adds or modifies the value of the optional
 // parameter `format` in the StartScreencast CDP command.
 //
@@ -2712,6 +3144,14 @@ func (t *StartScreencast) SetFormat(v string) *StartScreencast {
 	return t
 }
 
+// StartScreencastWithFormat is a functional option which sets the value of the
+// optional parameter `format` in the StartScreencast CDP command, see SetFormat.
+func StartScreencastWithFormat(v string) StartScreencastOption {
+	return func(c *StartScreencast) {
+		c.SetFormat(v)
+	}
+}
+
 // SetQuality adds or modifies the value of the optional
 // parameter `quality` in the StartScreencast CDP command.
 //
@@ -2721,6 +3161,14 @@ func (t *StartScreencast) SetQuality(v int64) *StartScreencast {
 	return t
 }
 
+// StartScreencastWithQuality is a functional option which sets the value of the
+// optional parameter `quality` in the StartScreencast CDP command, see SetQuality.
+func StartScreencastWithQuality(v int64) StartScreencastOption {
+	return func(c *StartScreencast) {
+		c.SetQuality(v)
+	}
+}
+
 // SetMaxWidth adds or modifies the value of the optional
 // parameter `maxWidth` in the StartScreencast CDP command.
 //
@@ -2730,6 +3178,14 @@ func (t *StartScreencast) SetMaxWidth(v int64) *StartScreencast {
 	return t
 }
 
+// StartScreencastWithMaxWidth is a functional option which sets the value of the
+// optional parameter `maxWidth` in the StartScreencast CDP command, see SetMaxWidth.
+func StartScreencastWithMaxWidth(v int64) StartScreencastOption {
+	return func(c *StartScreencast) {
+		c.SetMaxWidth(v)
+	}
+}
+
 // SetMaxHeight adds or modifies the value of the optional
 // parameter `maxHeight` in the StartScreencast CDP command.
 //
@@ -2739,6 +3195,14 @@ func (t *StartScreencast) SetMaxHeight(v int64) *StartScreencast {
 	return t
 }
 
+// StartScreencastWithMaxHeight is a functional option which sets the value of the
+// optional parameter `maxHeight` in the StartScreencast CDP command, see SetMaxHeight.
+func StartScreencastWithMaxHeight(v int64) StartScreencastOption {
+	return func(c *StartScreencast) {
+		c.SetMaxHeight(v)
+	}
+}
+
 // SetEveryNthFrame adds or modifies the value of the optional
 // parameter `everyNthFrame` in the StartScreencast CDP command.
 //
@@ -2748,6 +3212,14 @@ func (t *StartScreencast) SetEveryNthFrame(v int64) *StartScreencast {
 	return t
 }
 
+// StartScreencastWithEveryNthFrame is a functional option which sets the value of the
+// optional parameter `everyNthFrame` in the StartScreencast CDP command, see SetEveryNthFrame.
+func StartScreencastWithEveryNthFrame(v int64) StartScreencastOption {
+	return func(c *StartScreencast) {
+		c.SetEveryNthFrame(v)
+	}
+}
+
 // Do sends the StartScreencast CDP command to a browser,
 // and returns the browser's response.
 func (t *StartScreencast) Do(ctx context.Context) error {
@@ -3289,17 +3761,27 @@ type GenerateTestReport struct {
 
 // NewGenerateTestReport constructs a new GenerateTestReport struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GenerateTestReportOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-generateTestReport
 //
 // This CDP method is experimental.
-func NewGenerateTestReport(message string) *GenerateTestReport {
-	return &GenerateTestReport{
+func NewGenerateTestReport(message string, opts ...GenerateTestReportOption) *GenerateTestReport {
+	c := &GenerateTestReport{
 		Message: message,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GenerateTestReportOption is a functional option for the optional
+// parameters of the GenerateTestReport CDP command, see the
+// NewGenerateTestReport constructor. Options are applied in order.
+type GenerateTestReportOption = func(*GenerateTestReport)
+
 // SetGroup adds or modifies the value of the optional
 // parameter `group` in the GenerateTestReport CDP command.
 //
@@ -3309,6 +3791,14 @@ func (t *GenerateTestReport) SetGroup(v string) *GenerateTestReport {
 	return t
 }
 
+// GenerateTestReportWithGroup is a functional option which sets the value of the
+// optional parameter `group` in the GenerateTestReport CDP command, see SetGroup.
+func GenerateTestReportWithGroup(v string) GenerateTestReportOption {
+	return func(c *GenerateTestReport) {
+		c.SetGroup(v)
+	}
+}
+
 // Do sends the GenerateTestReport CDP command to a browser,
 // and returns the browser's response.
 func (t *GenerateTestReport) Do(ctx context.Context) error {
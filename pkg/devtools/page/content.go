@@ -0,0 +1,50 @@
+package page
+
+import (
+	"context"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// SetContentAndWait replaces the document of the main frame of the page
+// associated with the given context with the given HTML, like the
+// `page.NewSetDocumentContent` command, and then waits until the content's
+// subresources (e.g. scripts, stylesheets and images) finish loading: the
+// network is idle (see the `page.WaitForNetworkIdle` function, and the
+// `page.QuietPeriod` and `page.MaxInflightRequests` options), the document
+// is fully loaded, and so are its web fonts. This is needed before printing
+// or capturing the content (e.g. with `page.NewPrintToPDF`), because the
+// `Page.setDocumentContent` CDP command returns before the content finishes
+// loading. It returns early with the context's error if the context is
+// done first.
+//
+// Relative URLs in the HTML are resolved against the URL of the current
+// document, so consider navigating to the base URL first, or adding a
+// <base> element. This function enables the Network domain (see
+// `devtools.SubscribeEvents`) while it's running.
+func SetContentAndWait(ctx context.Context, html string, opts ...WaitOption) error {
+	cfg := newWaitConfig(opts)
+	tree, err := NewGetFrameTree().Do(ctx)
+	if err != nil {
+		return err
+	}
+	events, unsubscribe, err := subscribe(ctx, "Network.requestWillBeSent",
+		"Network.loadingFinished", "Network.loadingFailed")
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	set := make(chan error, 1)
+	go func() {
+		set <- NewSetDocumentContent(tree.FrameTree.Frame.ID, html).Do(ctx)
+	}()
+	if err := waitIdle(ctx, cfg, devtools.EventsOfSession(ctx), set, events); err != nil {
+		return err
+	}
+
+	if err := WaitForLoad(ctx); err != nil {
+		return err
+	}
+	return WaitForFonts(ctx)
+}
@@ -0,0 +1,44 @@
+package page
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+)
+
+func TestSetContentAndWait(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	b.Respond("Page.getFrameTree", map[string]interface{}{"frameTree": map[string]interface{}{"frame": map[string]string{"id": "main"}}})
+	b.Respond("Runtime.evaluate", map[string]interface{}{"result": map[string]string{"type": "string", "value": "complete"}})
+	finished := make(chan bool)
+	b.Handle("Page.setDocumentContent", func(json.RawMessage) (interface{}, error) {
+		// The content starts loading an image, which finishes later.
+		b.Emit("Network.requestWillBeSent", map[string]string{"requestId": "img"})
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			b.Emit("Network.loadingFinished", map[string]string{"requestId": "img"})
+			close(finished)
+		}()
+		return nil, nil
+	})
+
+	if err := SetContentAndWait(ctx, `<img src="https://example.com/a.png">`, QuietPeriod(10*time.Millisecond)); err != nil {
+		t.Fatalf("SetContentAndWait(); got error: %v", err)
+	}
+	select {
+	case <-finished:
+	default:
+		t.Error("SetContentAndWait() returned before the image finished loading")
+	}
+	calls := b.Calls("Page.setDocumentContent")
+	params := &SetDocumentContent{}
+	if len(calls) != 1 || json.Unmarshal(calls[0].Params, params) != nil || params.FrameID != "main" {
+		t.Errorf("Page.setDocumentContent calls = %v", calls)
+	}
+	if len(b.Calls("Network.enable")) != 1 || len(waitForCalls(b, "Network.disable", 1)) != 1 {
+		t.Errorf("SetContentAndWait() didn't enable and release the Network domain: %v", b.Calls())
+	}
+}
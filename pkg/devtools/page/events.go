@@ -1,12 +1,15 @@
 package page
 
-import "github.com/daabr/chrome-vision/pkg/devtools/runtime"
+import (
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
 
 // DomContentEventFired asynchronous event.
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-domContentEventFired
 type DomContentEventFired struct {
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 }
 
 // FileChooserOpened asynchronous event. Emitted only when `page.interceptFileChooser` is enabled.
@@ -221,9 +224,9 @@ type LifecycleEvent struct {
 	// Id of the frame.
 	FrameID string `json:"frameId"`
 	// Loader identifier. Empty string if the request is fetched from worker.
-	LoaderID  string  `json:"loaderId"`
-	Name      string  `json:"name"`
-	Timestamp float64 `json:"timestamp"`
+	LoaderID  string                 `json:"loaderId"`
+	Name      string                 `json:"name"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 }
 
 // BackForwardCacheNotUsed asynchronous event. Fired for failed bfcache history navigations if BackForwardCache feature is enabled. Do
@@ -249,7 +252,7 @@ type BackForwardCacheNotUsed struct {
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Page/#event-loadEventFired
 type LoadEventFired struct {
-	Timestamp float64 `json:"timestamp"`
+	Timestamp devtools.MonotonicTime `json:"timestamp"`
 }
 
 // NavigatedWithinDocument asynchronous event. Fired when same-document navigation happens, e.g. due to history API usage or anchor navigation.
@@ -0,0 +1,126 @@
+package page
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// ErrNoHistoryEntry is returned by the `page.Back`, `page.Forward` and
+// `page.GoTo` functions when the requested entry doesn't exist in the
+// navigation history, e.g. when going back from the first entry.
+var ErrNoHistoryEntry = errors.New("navigation history entry not found")
+
+// Back navigates the page associated with the given context to the
+// previous entry in its navigation history, and waits for the navigation
+// like the `page.GoTo` function.
+func Back(ctx context.Context) error {
+	return goRelative(ctx, -1)
+}
+
+// Forward navigates the page associated with the given context to the
+// next entry in its navigation history, and waits for the navigation
+// like the `page.GoTo` function.
+func Forward(ctx context.Context) error {
+	return goRelative(ctx, 1)
+}
+
+func goRelative(ctx context.Context, delta int64) error {
+	h, err := NewGetNavigationHistory().Do(ctx)
+	if err != nil {
+		return err
+	}
+	return goTo(ctx, h, h.CurrentIndex+delta)
+}
+
+// GoTo navigates the page associated with the given context to the entry
+// with the given index in its navigation history (see the CDP command
+// `Page.getNavigationHistory`), and waits until the main frame navigates.
+// If the navigation replaces the document, it also waits for the new
+// document to load (see `page.WaitForLoad`). Same-document navigations
+// (e.g. fragment changes and `history.pushState` entries) don't wait
+// for anything else.
+//
+// It returns `page.ErrNoHistoryEntry` (wrapped) if the index is out of
+// range, or the context's error if it's done first. Going to the current
+// entry doesn't navigate at all, use `page.NewReload` to reload it.
+func GoTo(ctx context.Context, index int64) error {
+	h, err := NewGetNavigationHistory().Do(ctx)
+	if err != nil {
+		return err
+	}
+	return goTo(ctx, h, index)
+}
+
+func goTo(ctx context.Context, h *GetNavigationHistoryResult, index int64) error {
+	if index < 0 || index >= int64(len(h.Entries)) {
+		return fmt.Errorf("%w: index %d, history length %d", ErrNoHistoryEntry, index, len(h.Entries))
+	}
+	if index == h.CurrentIndex {
+		return nil
+	}
+	sameDocument, err := navigateToEntry(ctx, h.Entries[index].ID)
+	if err != nil || sameDocument {
+		return err
+	}
+	return WaitForLoad(ctx)
+}
+
+// Navigate to the given history entry, wait until the main frame navigates,
+// and report whether it was a same-document navigation. The subscriptions
+// end before returning, so the caller may wait for other events.
+func navigateToEntry(ctx context.Context, entryID int64) (bool, error) {
+	tree, err := NewGetFrameTree().Do(ctx)
+	if err != nil {
+		return false, err
+	}
+	mainFrame := tree.FrameTree.Frame.ID
+
+	events, unsubscribe, err := subscribe(ctx, "Page.frameNavigated", "Page.navigatedWithinDocument")
+	if err != nil {
+		return false, err
+	}
+	defer unsubscribe()
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- NewNavigateToHistoryEntry(entryID).Do(ctx)
+	}()
+
+	accept := devtools.EventsOfSession(ctx)
+	sent, navigated, sameDocument := false, false, false
+	for !sent || !navigated {
+		select {
+		case err := <-errc:
+			if err != nil {
+				return false, err
+			}
+			sent = true
+		case m, ok := <-events:
+			if !ok {
+				return false, ctx.Err()
+			}
+			if !accept(m) {
+				continue
+			}
+			switch m.Method {
+			case "Page.frameNavigated":
+				e := &FrameNavigated{}
+				if json.Unmarshal(m.Params, e) == nil && e.Frame.ParentID == "" {
+					navigated = true
+				}
+			case "Page.navigatedWithinDocument":
+				e := &NavigatedWithinDocument{}
+				if json.Unmarshal(m.Params, e) == nil && e.FrameID == mainFrame {
+					navigated, sameDocument = true, true
+				}
+			}
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	}
+	return sameDocument, nil
+}
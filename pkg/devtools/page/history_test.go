@@ -0,0 +1,19 @@
+package page
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestGoToOutOfRange(t *testing.T) {
+	h := &GetNavigationHistoryResult{CurrentIndex: 0, Entries: []NavigationEntry{{ID: 1}, {ID: 2}}}
+	for _, i := range []int64{-1, 2} {
+		if err := goTo(context.Background(), h, i); !errors.Is(err, ErrNoHistoryEntry) {
+			t.Errorf("goTo(%d) error = %v, want %v", i, err, ErrNoHistoryEntry)
+		}
+	}
+	if err := goTo(context.Background(), h, 0); err != nil {
+		t.Errorf("goTo(current index); got error: %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package page
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+)
+
+// ErrHTTPStatus is wrapped by the errors of the `page.NavigateChecked`
+// function when the main resource's HTTP status code is 400 or higher.
+var ErrHTTPStatus = errors.New("HTTP error status")
+
+// Navigation is the result of the `page.NavigateChecked` function: the
+// result of the `page.Navigate` CDP command, and the details of the main
+// resource's response, from the correlated `network.ResponseReceived` event.
+type Navigation struct {
+	NavigateResult
+	// URL of the main resource, after redirects.
+	URL string
+	// HTTP status code and text of the main resource's response, or 0 and
+	// empty if it wasn't fetched from the network (e.g. "about:blank", or
+	// a same-document navigation to a fragment).
+	StatusCode int
+	StatusText string
+	// MIME type of the main resource, e.g. "text/html".
+	MIMEType string
+	// IP address and port of the server, or of the proxy.
+	RemoteIPAddress string
+	RemotePort      int64
+	// Network protocol, e.g. "http/1.1", "h2" or "h3".
+	Protocol string
+	// Complete response of the main resource, with its
+	// security state, TLS details and timing, if it has one.
+	Response *network.Response
+}
+
+// NavigateChecked navigates the page associated with the given context to
+// the given URL, like the `page.Navigate` CDP command, and returns the
+// details of the main resource's response: its HTTP status code, MIME type,
+// remote IP address and protocol, without correlating network events
+// manually. It returns as soon as the response is received, without waiting
+// for the page to load (see `page.WaitForLoad`).
+//
+// It returns an error if the navigation failed (e.g. DNS errors), and an
+// error which wraps `page.ErrHTTPStatus` along with the navigation's
+// details if the HTTP status code is 400 or higher.
+//
+// This function enables the Network domain (see `devtools.SubscribeEvents`)
+// until it returns.
+func NavigateChecked(ctx context.Context, url string) (*Navigation, error) {
+	events, unsubscribe, err := subscribe(ctx, "Network.responseReceived", "Page.frameNavigated")
+	if err != nil {
+		return nil, err
+	}
+	defer unsubscribe()
+
+	done := make(chan navigateDone, 1)
+	go func() {
+		r, err := NewNavigate(url).Do(ctx)
+		done <- navigateDone{r, err}
+	}()
+	return waitNavigation(ctx, url, devtools.EventsOfSession(ctx), done, events)
+}
+
+type navigateDone struct {
+	result *NavigateResult
+	err    error
+}
+
+// Implementation of `page.NavigateChecked`, separated for testability.
+func waitNavigation(ctx context.Context, url string, accept func(*devtools.Message) bool,
+	done <-chan navigateDone, events <-chan *devtools.Message) (*Navigation, error) {
+	// Events may arrive before the command's response, so they're
+	// collected by loader ID until the navigation's loader ID is known.
+	received := make(map[string]*network.Response)
+	committed := make(map[string]bool)
+	var result *NavigateResult
+	for {
+		if result != nil {
+			if r, ok := received[result.LoaderID]; ok {
+				return newNavigation(result, r)
+			}
+			// Same-document navigations don't have loaders,
+			// and "about:blank" doesn't have a response.
+			if result.LoaderID == "" || committed[result.LoaderID] {
+				return &Navigation{NavigateResult: *result, URL: url}, nil
+			}
+		}
+
+		select {
+		case d := <-done:
+			if d.err != nil {
+				return nil, d.err
+			}
+			if d.result.ErrorText != "" {
+				return nil, fmt.Errorf("navigation to %s failed: %s", url, d.result.ErrorText)
+			}
+			result, done = d.result, nil
+		case m, ok := <-events:
+			if !ok {
+				return nil, ctx.Err()
+			}
+			if !accept(m) {
+				continue
+			}
+			switch m.Method {
+			case "Network.responseReceived":
+				e := &network.ResponseReceived{}
+				if json.Unmarshal(m.Params, e) == nil && e.Type == network.ResourceTypeDocument {
+					received[e.LoaderID] = &e.Response
+				}
+			case "Page.frameNavigated":
+				e := &FrameNavigated{}
+				if json.Unmarshal(m.Params, e) == nil {
+					committed[e.Frame.LoaderID] = true
+				}
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func newNavigation(result *NavigateResult, r *network.Response) (*Navigation, error) {
+	n := &Navigation{
+		NavigateResult:  *result,
+		URL:             r.URL,
+		StatusCode:      int(r.Status),
+		StatusText:      r.StatusText,
+		MIMEType:        r.MimeType,
+		RemoteIPAddress: r.RemoteIPAddress,
+		RemotePort:      r.RemotePort,
+		Protocol:        r.Protocol,
+		Response:        r,
+	}
+	if n.StatusCode >= 400 {
+		return n, fmt.Errorf("%w: %d %s (%s)", ErrHTTPStatus, n.StatusCode, n.StatusText, n.URL)
+	}
+	return n, nil
+}
@@ -0,0 +1,89 @@
+package page
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+)
+
+func TestNavigateChecked(t *testing.T) {
+	tests := []struct {
+		name       string
+		result     map[string]string
+		events     []string
+		wantStatus int
+		wantErr    error
+	}{
+		{
+			name:   "ok",
+			result: map[string]string{"frameId": "main", "loaderId": "L1"},
+			events: []string{
+				// A subframe's document, and then the main frame's.
+				`{"requestId":"L0","loaderId":"L0","type":"Document","response":{"url":"https://a.com/frame","status":200,"mimeType":"text/html"}}`,
+				`{"requestId":"L1","loaderId":"L1","type":"Document","response":{"url":"https://b.com/","status":200,"statusText":"OK","mimeType":"text/html","remoteIPAddress":"10.0.0.1","remotePort":443,"protocol":"h2"}}`,
+			},
+			wantStatus: 200,
+		},
+		{
+			name:       "not_found",
+			result:     map[string]string{"frameId": "main", "loaderId": "L1"},
+			events:     []string{`{"requestId":"L1","loaderId":"L1","type":"Document","response":{"url":"https://b.com/","status":404,"statusText":"Not Found","protocol":"h2"}}`},
+			wantStatus: 404,
+			wantErr:    ErrHTTPStatus,
+		},
+		{
+			name:   "same_document",
+			result: map[string]string{"frameId": "main"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, b := devtoolstest.NewContext(context.Background())
+			b.Handle("Page.navigate", func(json.RawMessage) (interface{}, error) {
+				for _, e := range tt.events {
+					if err := b.Emit("Network.responseReceived", json.RawMessage(e)); err != nil {
+						return nil, err
+					}
+				}
+				return tt.result, nil
+			})
+			n, err := NavigateChecked(ctx, "https://a.com/")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NavigateChecked() error = %v, want %v", err, tt.wantErr)
+			}
+			if n.StatusCode != tt.wantStatus {
+				t.Errorf("NavigateChecked().StatusCode = %d, want %d", n.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus == 200 && (n.URL != "https://b.com/" || n.RemoteIPAddress != "10.0.0.1" || n.Protocol != "h2" || n.MIMEType != "text/html") {
+				t.Errorf("NavigateChecked() = %+v", n)
+			}
+			if len(b.Calls("Network.enable")) != 1 || len(waitForCalls(b, "Network.disable", 1)) != 1 {
+				t.Errorf("NavigateChecked() didn't enable and release the Network domain: %v", b.Calls())
+			}
+		})
+	}
+
+	ctx, b := devtoolstest.NewContext(context.Background())
+	b.Respond("Page.navigate", map[string]string{"frameId": "main", "loaderId": "L1", "errorText": "net::ERR_NAME_NOT_RESOLVED"})
+	if _, err := NavigateChecked(ctx, "https://a.invalid/"); err == nil {
+		t.Error("NavigateChecked() of a failed navigation; got nil error")
+	}
+}
+
+func TestWaitNavigationCommitted(t *testing.T) {
+	done := make(chan navigateDone, 1)
+	navigated := make(chan *devtools.Message, 1)
+	done <- navigateDone{result: &NavigateResult{FrameID: "main", LoaderID: "L1"}}
+	navigated <- &devtools.Message{Method: "Page.frameNavigated", Params: []byte(`{"frame":{"id":"main","loaderId":"L1"}}`)}
+	n, err := waitNavigation(context.Background(), "about:blank", acceptAll, done, navigated)
+	if err != nil {
+		t.Fatalf("waitNavigation(); got error: %v", err)
+	}
+	if n.URL != "about:blank" || n.StatusCode != 0 {
+		t.Errorf("waitNavigation() = %+v", n)
+	}
+}
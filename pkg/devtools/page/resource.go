@@ -0,0 +1,20 @@
+package page
+
+import (
+	"context"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+)
+
+// ResourceBody returns the content of the resource with the given URL in the
+// frame with the given ID, with the `page.GetResourceContent` CDP command,
+// decoded from base64 if needed. The content type (which may be empty) is
+// the resource's Content-Type header, if it's known, otherwise the MIME type
+// is sniffed from the content. See `network.Body.Text` for charset decoding.
+func ResourceBody(ctx context.Context, frameID, url, contentType string) (*network.Body, error) {
+	r, err := NewGetResourceContent(frameID, url).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return network.NewBody(r.Content, r.Base64Encoded, contentType)
+}
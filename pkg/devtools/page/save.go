@@ -0,0 +1,219 @@
+package page
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"log"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+)
+
+// A document or a subresource which is saved by `page.SaveComplete`.
+type savedResource struct {
+	frameID, url, mimeType string
+	file                   string // Path relative to the output directory, with slashes.
+	body                   *network.Body
+}
+
+var (
+	// References in HTML attributes and CSS, with their original quotes.
+	htmlAttrRef  = regexp.MustCompile(`(?i)(\s(?:src|href|poster|data|background)\s*=\s*)("[^"]*"|'[^']*'|[^\s"'>]+)`)
+	htmlSrcset   = regexp.MustCompile(`(?i)(\s(?:srcset|imagesrcset)\s*=\s*)("[^"]*"|'[^']*')`)
+	cssURLRef    = regexp.MustCompile(`(?i)url\(\s*("[^"]*"|'[^']*'|[^)\s]*)\s*\)`)
+	cssImportRef = regexp.MustCompile(`(?i)(@import\s+)("[^"]*"|'[^']*')`)
+	htmlBaseTag  = regexp.MustCompile(`(?i)<base\s[^>]*>`)
+	htmlBaseHref = regexp.MustCompile(`(?i)<base\s[^>]*href\s*=\s*("[^"]*"|'[^']*'|[^\s"'>]+)`)
+	unsafeChars  = regexp.MustCompile(`[^\w.-]+`)
+)
+
+// SaveComplete writes a browsable offline copy of the page associated with
+// the given context to the given directory (which is created if needed),
+// like the "Web Page, Complete" option in browsers' "Save as" dialog, as an
+// alternative to MHTML snapshots (see `page.CaptureSnapshot`).
+//
+// The main frame's document is saved as "index.html", and the documents of
+// child frames and all the resources of all the frames (as listed by
+// `page.GetResourceTree`) are saved in the "files" subdirectory. References
+// to saved resources in HTML attributes (e.g. "src", "href" and "srcset")
+// and in CSS (stylesheets and inline styles) are rewritten to local relative
+// paths, and HTML and CSS files are saved with UTF-8 byte order marks,
+// so they're decoded correctly regardless of their declared charsets.
+//
+// Documents are saved as they were received, not their current DOM state.
+// References in scripts aren't rewritten. Resources which the browser no
+// longer has (e.g. evicted from its cache) are skipped, and references
+// to them are left pointing to their original URLs.
+func SaveComplete(ctx context.Context, dir string) error {
+	tree, err := NewGetResourceTree().Do(ctx)
+	if err != nil {
+		return err
+	}
+	saved := map[string]*savedResource{}
+	var order []*savedResource
+	add := func(frameID, u, mimeType, file string) {
+		if _, ok := saved[u]; ok || !strings.HasPrefix(u, "http") {
+			return // Also skip "about:", "data:", "blob:", etc.
+		}
+		if file == "" {
+			file = localFileName(len(order), u, mimeType)
+		}
+		r := &savedResource{frameID: frameID, url: u, mimeType: mimeType, file: file}
+		saved[u] = r
+		order = append(order, r)
+	}
+	var walk func(t *FrameResourceTree, main bool)
+	walk = func(t *FrameResourceTree, main bool) {
+		file := ""
+		if main {
+			file = "index.html"
+		}
+		add(t.Frame.ID, t.Frame.URL, t.Frame.MimeType, file)
+		for _, r := range t.Resources {
+			if !r.Failed && !r.Canceled {
+				add(t.Frame.ID, r.URL, r.MimeType, "")
+			}
+		}
+		for i := range t.ChildFrames {
+			walk(&t.ChildFrames[i], false)
+		}
+	}
+	walk(&tree.FrameTree, true)
+
+	for _, r := range order {
+		if r.body, err = ResourceBody(ctx, r.frameID, r.url, r.mimeType); err != nil {
+			log.Printf("Skipping resource %s: %v", r.url, err)
+			delete(saved, r.url)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "files"), 0755); err != nil {
+		return err
+	}
+	for _, r := range order {
+		if r.body == nil {
+			continue
+		}
+		b := r.body.Bytes
+		if r.body.MIMEType == "text/html" || r.body.MIMEType == "text/css" {
+			if text, err := r.body.Text(ctx); err == nil {
+				b = []byte("\ufeff" + rewriteReferences(text, r, saved))
+			}
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, filepath.FromSlash(r.file)), b, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Return a unique file name for a resource, based on its URL, with an
+// extension which matches its MIME type if it doesn't have one already.
+func localFileName(i int, u, mimeType string) string {
+	name := "resource"
+	if p, err := url.Parse(u); err == nil && path.Base(p.Path) != "/" && path.Base(p.Path) != "." {
+		name = path.Base(p.Path)
+	}
+	name = unsafeChars.ReplaceAllString(name, "_")
+	if len(name) > 64 {
+		name = name[len(name)-64:]
+	}
+	if path.Ext(name) == "" || mimeType == "text/html" && !strings.HasPrefix(path.Ext(name), ".htm") {
+		if exts, _ := mime.ExtensionsByType(mimeType); len(exts) > 0 {
+			name += exts[0]
+		}
+	}
+	return fmt.Sprintf("files/%d-%s", i, name)
+}
+
+// Rewrite references to saved resources in the given HTML or CSS
+// text to local paths, relative to the given resource's file.
+func rewriteReferences(text string, r *savedResource, saved map[string]*savedResource) string {
+	base, err := url.Parse(r.url)
+	if err != nil {
+		return text
+	}
+	isHTML := r.body.MIMEType == "text/html"
+	if isHTML {
+		if m := htmlBaseHref.FindStringSubmatch(text); m != nil {
+			if b, err := base.Parse(unquote(m[1])); err == nil {
+				base = b
+			}
+			text = htmlBaseTag.ReplaceAllString(text, "")
+		}
+	}
+	local := func(ref string) (string, bool) {
+		u, err := base.Parse(strings.TrimSpace(ref))
+		if err != nil {
+			return "", false
+		}
+		fragment := ""
+		if u.Fragment != "" {
+			fragment = "#" + u.EscapedFragment()
+		}
+		u.Fragment = ""
+		s, ok := saved[u.String()]
+		if !ok || s == r {
+			return "", false
+		}
+		rel, err := filepath.Rel(path.Dir(r.file), s.file)
+		if err != nil {
+			return "", false
+		}
+		return filepath.ToSlash(rel) + fragment, true
+	}
+	replace := func(re *regexp.Regexp, text string, f func(string) (string, bool)) string {
+		return re.ReplaceAllStringFunc(text, func(m string) string {
+			sub := re.FindStringSubmatch(m)
+			prefix, ref := "", sub[1]
+			if len(sub) > 2 {
+				prefix, ref = sub[1], sub[2]
+			}
+			if p, ok := f(unquote(ref)); ok {
+				if re == cssURLRef {
+					return `url("` + p + `")`
+				}
+				return prefix + `"` + p + `"`
+			}
+			return m
+		})
+	}
+
+	if isHTML {
+		text = replace(htmlAttrRef, text, local)
+		text = replace(htmlSrcset, text, func(srcset string) (string, bool) {
+			var out []string
+			changed := false
+			for _, c := range strings.Split(srcset, ",") {
+				fields := strings.Fields(c)
+				if len(fields) == 0 {
+					continue
+				}
+				if p, ok := local(fields[0]); ok {
+					fields[0], changed = p, true
+				}
+				out = append(out, strings.Join(fields, " "))
+			}
+			return strings.Join(out, ", "), changed
+		})
+	}
+	text = replace(cssURLRef, text, local)
+	return replace(cssImportRef, text, local)
+}
+
+// Remove the quotes around an HTML attribute or a CSS
+// string, and unescape HTML character references.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		s = s[1 : len(s)-1]
+	}
+	return html.UnescapeString(s)
+}
@@ -0,0 +1,92 @@
+package page
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSaveComplete(t *testing.T) {
+	png := base64.StdEncoding.EncodeToString([]byte("\x89PNG\r\n\x1a\n"))
+	contents := map[string]*GetResourceContentResult{
+		"https://example.com/a/": {Content: `<html><head><link rel="stylesheet" href="style.css?v=1"></head>` +
+			`<body><img src='/img.png' srcset="/img.png 1x, https://cdn.example.com/big.png 2x">` +
+			`<iframe src="frame.html"></iframe><a href="#top">Top</a></body></html>`},
+		"https://example.com/a/style.css?v=1": {Content: `body { background: url(../img.png#x); }`},
+		"https://example.com/img.png":         {Content: png, Base64Encoded: true},
+		"https://example.com/a/frame.html":    {Content: `<img src="https://example.com/img.png">`},
+	}
+	ctx := devtools.NewFakeContext(context.Background(), func(_ context.Context, method string, params json.RawMessage) (*devtools.Message, error) {
+		var result interface{}
+		switch method {
+		case "Page.getResourceTree":
+			result = &GetResourceTreeResult{FrameTree: FrameResourceTree{
+				Frame: Frame{ID: "main", URL: "https://example.com/a/", MimeType: "text/html"},
+				Resources: []FrameResource{
+					{URL: "https://example.com/a/style.css?v=1", MimeType: "text/css"},
+					{URL: "https://example.com/img.png", MimeType: "image/png"},
+					{URL: "https://cdn.example.com/big.png", MimeType: "image/png"},
+					{URL: "https://example.com/failed.js", MimeType: "text/javascript", Failed: true},
+				},
+				ChildFrames: []FrameResourceTree{{
+					Frame:     Frame{ID: "child", URL: "https://example.com/a/frame.html", MimeType: "text/html"},
+					Resources: []FrameResource{{URL: "https://example.com/img.png", MimeType: "image/png"}},
+				}},
+			}}
+		case "Page.getResourceContent":
+			p := &GetResourceContent{}
+			json.Unmarshal(params, p)
+			r, ok := contents[p.URL]
+			if !ok {
+				return nil, errors.New("no resource with given URL found")
+			}
+			result = r
+		}
+		b, _ := json.Marshal(result)
+		return &devtools.Message{Result: b}, nil
+	})
+
+	dir := t.TempDir()
+	if err := SaveComplete(ctx, dir); err != nil {
+		t.Fatalf("SaveComplete(); got error: %v", err)
+	}
+	want := map[string]string{
+		"index.html": "\ufeff" + `<html><head><link rel="stylesheet" href="files/1-style.css"></head>` +
+			`<body><img src="files/2-img.png" srcset="files/2-img.png 1x, https://cdn.example.com/big.png 2x">` +
+			`<iframe src="files/4-frame.html"></iframe><a href="#top">Top</a></body></html>`,
+		"files/1-style.css":  "\ufeff" + `body { background: url("2-img.png#x"); }`,
+		"files/2-img.png":    "\x89PNG\r\n\x1a\n",
+		"files/4-frame.html": "\ufeff" + `<img src="2-img.png">`,
+	}
+	got := map[string]string{}
+	for _, dir := range []string{dir, filepath.Join(dir, "files")} {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range files {
+			if f.IsDir() {
+				continue
+			}
+			b, err := ioutil.ReadFile(filepath.Join(dir, f.Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			name := f.Name()
+			if filepath.Base(dir) == "files" {
+				name = "files/" + name
+			}
+			got[name] = string(b)
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SaveComplete() files mismatch (-want +got):\n%s", diff)
+	}
+}
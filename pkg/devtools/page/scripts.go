@@ -0,0 +1,134 @@
+package page
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"text/template"
+)
+
+// InitScripts manages named scripts which are evaluated in every frame of
+// a page upon creation, before the frame's own scripts (e.g. stealth patches,
+// polyfills, and instrumentation snippets), see the `page.NewInitScripts`
+// function. Multiple goroutines may use the same manager simultaneously.
+type InitScripts struct {
+	ctx context.Context
+
+	mu  sync.Mutex
+	ids map[string]string // Script name -> CDP identifier.
+}
+
+// NewInitScripts constructs a new manager of init scripts for the page
+// associated with the given context. The page keeps evaluating the scripts
+// across navigations, until they're removed, or until the context is done.
+func NewInitScripts(ctx context.Context) *InitScripts {
+	return &InitScripts{ctx: ctx, ids: make(map[string]string)}
+}
+
+// Add registers a new init script with the given name, or replaces the
+// existing script with the same name. The change applies to documents
+// which are created after calling this method (use `runtime.Eval` to
+// apply it to the current document as well).
+//
+// If data isn't nil, the source is parsed as a Go template
+// (https://golang.org/pkg/text/template/) and executed with that data.
+// The template function "json" converts Go values into JavaScript
+// literals, for example:
+//
+//	scripts.Add("config", "window.__config = {{json .}};", cfg)
+func (s *InitScripts) Add(name, source string, data interface{}) error {
+	if data != nil {
+		var err error
+		if source, err = renderScript(name, source, data); err != nil {
+			return err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.remove(name); err != nil {
+		return err
+	}
+	r, err := NewAddScriptToEvaluateOnNewDocument(source).Do(s.ctx)
+	if err != nil {
+		return err
+	}
+	s.ids[name] = r.Identifier
+	return nil
+}
+
+// Remove unregisters the init script with the given name, if it exists.
+func (s *InitScripts) Remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.remove(name)
+}
+
+// Call only while holding the lock.
+func (s *InitScripts) remove(name string) error {
+	id, ok := s.ids[name]
+	if !ok {
+		return nil
+	}
+	if err := NewRemoveScriptToEvaluateOnNewDocument(id).Do(s.ctx); err != nil {
+		return err
+	}
+	delete(s.ids, name)
+	return nil
+}
+
+// RemoveAll unregisters all the init scripts which were added by this manager.
+func (s *InitScripts) RemoveAll() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for name := range s.ids {
+		if err := s.remove(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Names returns the sorted names of the registered init scripts.
+func (s *InitScripts) Names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.ids))
+	for name := range s.ids {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Identifier returns the CDP identifier of the init script with
+// the given name, and whether it's registered.
+func (s *InitScripts) Identifier(name string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id, ok := s.ids[name]
+	return id, ok
+}
+
+var scriptFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// Execute the given script source as a Go template with the given data.
+func renderScript(name, source string, data interface{}) (string, error) {
+	t, err := template.New(name).Funcs(scriptFuncs).Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("invalid init script template %q: %v", name, err)
+	}
+	b := &bytes.Buffer{}
+	if err := t.Execute(b, data); err != nil {
+		return "", fmt.Errorf("failed to render init script %q: %v", name, err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,23 @@
+package page
+
+import "testing"
+
+func TestRenderScript(t *testing.T) {
+	data := struct {
+		Name  string
+		Langs []string
+	}{`O'Brien "</script>"`, []string{"en-US", "en"}}
+	got, err := renderScript("test", `window.__cfg = {{json .}}; window.__langs = {{json .Langs}};`, data)
+	if err != nil {
+		t.Fatalf("renderScript(); got error: %v", err)
+	}
+	want := `window.__cfg = {"Name":"O'Brien \"\u003c/script\u003e\"","Langs":["en-US","en"]}; ` +
+		`window.__langs = ["en-US","en"];`
+	if got != want {
+		t.Errorf("renderScript() = %s, want %s", got, want)
+	}
+
+	if _, err := renderScript("bad", "{{json .", data); err == nil {
+		t.Error("renderScript() with invalid template; got nil error")
+	}
+}
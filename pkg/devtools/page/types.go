@@ -1,6 +1,9 @@
 package page
 
-import "github.com/daabr/chrome-vision/pkg/devtools/network"
+import (
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+)
 
 // FrameID data type. Unique frame identifier.
 //
@@ -309,7 +312,7 @@ type OriginTrialToken struct {
 	Origin           string                      `json:"origin"`
 	MatchSubDomains  bool                        `json:"matchSubDomains"`
 	TrialName        string                      `json:"trialName"`
-	ExpiryTime       float64                     `json:"expiryTime"`
+	ExpiryTime       devtools.TimeSinceEpoch     `json:"expiryTime"`
 	IsThirdParty     bool                        `json:"isThirdParty"`
 	UsageRestriction OriginTrialUsageRestriction `json:"usageRestriction"`
 }
@@ -402,7 +405,7 @@ type FrameResource struct {
 	// Resource mimeType as determined by the browser.
 	MimeType string `json:"mimeType"`
 	// last-modified timestamp as reported by server.
-	LastModified float64 `json:"lastModified,omitempty"`
+	LastModified devtools.TimeSinceEpoch `json:"lastModified,omitempty"`
 	// Resource content size.
 	ContentSize float64 `json:"contentSize,omitempty"`
 	// True if the resource failed to load.
@@ -502,7 +505,7 @@ type ScreencastFrameMetadata struct {
 	// Position of vertical scroll in CSS pixels.
 	ScrollOffsetY float64 `json:"scrollOffsetY"`
 	// Frame swap timestamp.
-	Timestamp float64 `json:"timestamp,omitempty"`
+	Timestamp devtools.TimeSinceEpoch `json:"timestamp,omitempty"`
 }
 
 // DialogType data type. Javascript dialog type.
@@ -0,0 +1,275 @@
+package page
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// Default values for the `page.WaitFor...` functions.
+const (
+	DefaultQuietPeriod         = 500 * time.Millisecond
+	DefaultMaxInflightRequests = 0
+)
+
+type waitConfig struct {
+	quiet       time.Duration
+	maxInflight int
+}
+
+// WaitOption is used for customization in the `page.WaitFor...` functions.
+type WaitOption = func(*waitConfig)
+
+// QuietPeriod allows the caller of the `page.WaitForNetworkIdle` and
+// `page.WaitForStable` functions to customize how long the page must be
+// idle (default: `page.DefaultQuietPeriod`).
+func QuietPeriod(d time.Duration) WaitOption {
+	return func(c *waitConfig) {
+		c.quiet = d
+	}
+}
+
+// MaxInflightRequests allows the caller of the `page.WaitForNetworkIdle`
+// function to tolerate a few long-lived network requests (e.g. 2 for
+// "networkidle2" semantics, default: 0 for "networkidle0" semantics).
+func MaxInflightRequests(n int) WaitOption {
+	return func(c *waitConfig) {
+		c.maxInflight = n
+	}
+}
+
+func newWaitConfig(opts []WaitOption) *waitConfig {
+	c := &waitConfig{quiet: DefaultQuietPeriod, maxInflight: DefaultMaxInflightRequests}
+	for _, o := range opts {
+		o(c)
+	}
+	return c
+}
+
+// Subscribe to the given events in the given context through a single channel,
+// in the order in which they were sent (see `devtools.SubscribeEvents`), and
+// return the channel, which is closed when the context is done, along with a
+// function to unsubscribe. Commands which trigger the events are sent in
+// separate goroutines, so the channel's buffer doesn't fill up while waiting
+// for their responses.
+func subscribe(ctx context.Context, names ...string) (<-chan *devtools.Message, func(), error) {
+	sub, err := devtools.SubscribeEvents(ctx, names)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sub.C, sub.Unsubscribe, nil
+}
+
+// WaitForLoad waits until the main frame of the page associated with the
+// given context fires its "load" event, or returns immediately if the
+// current document is already fully loaded. It returns early with the
+// context's error if the context is done first.
+func WaitForLoad(ctx context.Context) error {
+	events, unsubscribe, err := subscribe(ctx, "Page.loadEventFired")
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+
+	// Check the current state only after subscribing, to avoid a race.
+	complete := make(chan bool, 1)
+	go func() {
+		state := ""
+		err := runtime.Eval(ctx, "document.readyState", &state)
+		complete <- err == nil && state == "complete"
+	}()
+
+	accept := devtools.EventsOfSession(ctx)
+	for {
+		select {
+		case ok := <-complete:
+			if ok {
+				return nil
+			}
+		case m, ok := <-events:
+			if !ok {
+				return ctx.Err()
+			}
+			if accept(m) {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// WaitForNetworkIdle waits until the page associated with the given context
+// has no more than a given number of in-flight network requests (see the
+// `page.MaxInflightRequests` option) for a quiet period (see the
+// `page.QuietPeriod` option). It returns early with the context's error
+// if the context is done first.
+//
+// This function enables the Network domain (see `devtools.SubscribeEvents`)
+// while it's running. Requests which started before calling this function
+// are not tracked.
+func WaitForNetworkIdle(ctx context.Context, opts ...WaitOption) error {
+	cfg := newWaitConfig(opts)
+	events, unsubscribe, err := subscribe(ctx, "Network.requestWillBeSent",
+		"Network.loadingFinished", "Network.loadingFailed")
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+	return waitIdle(ctx, cfg, devtools.EventsOfSession(ctx), nil, events)
+}
+
+// Implementation of `page.WaitForNetworkIdle`, separated for testability.
+// If the given channel isn't nil, the quiet period starts only after it
+// delivers a nil error.
+func waitIdle(ctx context.Context, cfg *waitConfig, accept func(*devtools.Message) bool,
+	started <-chan error, events <-chan *devtools.Message) error {
+	inflight := make(map[string]bool)
+	t := time.NewTimer(cfg.quiet)
+	defer t.Stop()
+	if started != nil {
+		t.Stop() // Until tracking starts.
+	}
+	resetIfIdle := func() {
+		if !t.Stop() {
+			select {
+			case <-t.C:
+			default:
+			}
+		}
+		if started == nil && len(inflight) <= cfg.maxInflight {
+			t.Reset(cfg.quiet)
+		}
+	}
+	requestID := func(m *devtools.Message) string {
+		e := &struct {
+			RequestID string `json:"requestId"`
+		}{}
+		json.Unmarshal(m.Params, e)
+		return e.RequestID
+	}
+
+	for {
+		select {
+		case err := <-started:
+			if err != nil {
+				return err
+			}
+			started = nil
+			resetIfIdle() // The quiet period starts when tracking starts.
+		case m, ok := <-events:
+			if !ok {
+				return ctx.Err()
+			}
+			if !accept(m) {
+				continue
+			}
+			if m.Method == "Network.requestWillBeSent" {
+				inflight[requestID(m)] = true
+			} else {
+				delete(inflight, requestID(m))
+			}
+			resetIfIdle()
+		case <-t.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// WaitForStable waits until the page associated with the given context
+// doesn't fire any page lifecycle events for a quiet period (see the
+// `page.QuietPeriod` option). It returns early with the context's
+// error if the context is done first.
+func WaitForStable(ctx context.Context, opts ...WaitOption) error {
+	cfg := newWaitConfig(opts)
+	events, unsubscribe, err := subscribe(ctx, "Page.lifecycleEvent")
+	if err != nil {
+		return err
+	}
+	defer unsubscribe()
+	return waitQuiet(ctx, cfg.quiet, devtools.EventsOfSession(ctx), events)
+}
+
+// Implementation of `page.WaitForStable`, separated for testability.
+func waitQuiet(ctx context.Context, quiet time.Duration, accept func(*devtools.Message) bool,
+	events <-chan *devtools.Message) error {
+	t := time.NewTimer(quiet)
+	defer t.Stop()
+	for {
+		select {
+		case m, ok := <-events:
+			if !ok {
+				return ctx.Err()
+			}
+			if !accept(m) {
+				continue
+			}
+			if !t.Stop() {
+				select {
+				case <-t.C:
+				default:
+				}
+			}
+			t.Reset(quiet)
+		case <-t.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// WaitForFonts waits until the web fonts of the page associated with the
+// given context finish loading (i.e. `document.fonts.ready`), so text isn't
+// captured in a fallback font (FOUT) or invisible (FOIT) in screenshots.
+// It returns early with the context's error if the context is done first.
+func WaitForFonts(ctx context.Context) error {
+	return runtime.Eval(ctx, "document.fonts.ready.then(() => null)", nil)
+}
+
+// Wait until all the images which are loading or loaded are decoded, and
+// return the URLs of images which failed to load or decode. Lazy images
+// which haven't started loading (i.e. far from the viewport) are skipped.
+const decodeImages = `Promise.all(Array.from(document.images)
+	.filter((img) => (img.currentSrc || img.src) && (img.complete || img.loading !== "lazy"))
+	.map((img) => img.decode().then(() => "", () => img.currentSrc || img.src)))
+	.then((urls) => urls.filter((u) => u))`
+
+// WaitForImages waits until the <img> elements in the main frame of the page
+// associated with the given context finish loading and decoding, so they
+// aren't missing or partially rendered in screenshots, and fails if any of
+// them is broken. Lazy-loaded images which haven't started loading are
+// ignored, and so are CSS background images. It returns early with the
+// context's error if the context is done first.
+func WaitForImages(ctx context.Context) error {
+	var broken []string
+	if err := runtime.Eval(ctx, decodeImages, &broken); err != nil {
+		return err
+	}
+	if len(broken) > 0 {
+		return fmt.Errorf("%d images failed to load or decode: %s", len(broken), strings.Join(broken, ", "))
+	}
+	return nil
+}
+
+// WaitForRendering waits for the page associated with the given context to
+// load, and then for its web fonts and images (see the `page.WaitForFonts`
+// and `page.WaitForImages` functions), e.g. before taking a screenshot in
+// a visual test. It returns early with the context's error if the context
+// is done first.
+func WaitForRendering(ctx context.Context) error {
+	if err := WaitForLoad(ctx); err != nil {
+		return err
+	}
+	if err := WaitForFonts(ctx); err != nil {
+		return err
+	}
+	return WaitForImages(ctx)
+}
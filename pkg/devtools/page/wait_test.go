@@ -0,0 +1,267 @@
+package page
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+	"github.com/google/go-cmp/cmp"
+)
+
+func acceptAll(*devtools.Message) bool { return true }
+
+func request(method, id string) *devtools.Message {
+	return &devtools.Message{Method: method, Params: []byte(`{"requestId":"` + id + `"}`)}
+}
+
+// Wait until the fake browser receives the given number of calls of the
+// given command, e.g. "Network.disable", which is sent asynchronously
+// when subscriptions end.
+func waitForCalls(b *devtoolstest.Browser, method string, n int) []devtoolstest.Call {
+	deadline := time.Now().Add(5 * time.Second)
+	for len(b.Calls(method)) < n && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	return b.Calls(method)
+}
+
+func TestWaitQuiet(t *testing.T) {
+	ctx := context.Background()
+	events := make(chan *devtools.Message)
+	quiet := 50 * time.Millisecond
+
+	// Keep the page "busy" for a while.
+	go func() {
+		for i := 0; i < 5; i++ {
+			events <- &devtools.Message{}
+			time.Sleep(quiet / 5)
+		}
+	}()
+
+	start := time.Now()
+	if err := waitQuiet(ctx, quiet, acceptAll, events); err != nil {
+		t.Fatalf("waitQuiet(); got error: %v", err)
+	}
+	if d := time.Since(start); d < quiet+4*quiet/5 {
+		t.Errorf("waitQuiet() returned after %v, want >= %v", d, quiet+4*quiet/5)
+	}
+}
+
+func TestWaitQuietDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := waitQuiet(ctx, time.Minute, acceptAll, nil); err != context.DeadlineExceeded {
+		t.Errorf("waitQuiet() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestWaitIdle(t *testing.T) {
+	tests := []struct {
+		desc        string
+		maxInflight int
+		wantIdle    bool
+	}{
+		{desc: "networkidle0", maxInflight: 0, wantIdle: false},
+		{desc: "networkidle2", maxInflight: 2, wantIdle: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			// Set up.
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			cfg := &waitConfig{quiet: 20 * time.Millisecond, maxInflight: tt.maxInflight}
+			events := make(chan *devtools.Message)
+			go func() {
+				events <- request("Network.requestWillBeSent", "1")
+				events <- request("Network.requestWillBeSent", "2")
+				events <- request("Network.requestWillBeSent", "3")
+				events <- request("Network.loadingFinished", "3")
+			}()
+
+			// Test.
+			err := waitIdle(ctx, cfg, acceptAll, nil, events)
+			if tt.wantIdle && err != nil {
+				t.Errorf("waitIdle(); got error: %v", err)
+			}
+			if !tt.wantIdle && err != context.DeadlineExceeded {
+				t.Errorf("waitIdle() = %v, want %v", err, context.DeadlineExceeded)
+			}
+		})
+	}
+}
+
+// Respond to "Runtime.evaluate" commands with the values of the given
+// expressions, and return a function which returns the evaluated expressions.
+// The given function, if not nil, is called with each expression first.
+func evaluate(b *devtoolstest.Browser, values map[string]*runtime.EvaluateResult, f func(string) error) func() []string {
+	var mu sync.Mutex
+	var exprs []string
+	b.Handle("Runtime.evaluate", func(params json.RawMessage) (interface{}, error) {
+		e := &runtime.Evaluate{}
+		if err := json.Unmarshal(params, e); err != nil {
+			return nil, err
+		}
+		if !e.ReturnByValue || !e.AwaitPromise {
+			return nil, fmt.Errorf("unexpected Runtime.evaluate params: %s", params)
+		}
+		mu.Lock()
+		exprs = append(exprs, e.Expression)
+		mu.Unlock()
+		if f != nil {
+			if err := f(e.Expression); err != nil {
+				return nil, err
+			}
+		}
+		if r, ok := values[e.Expression]; ok {
+			return r, nil
+		}
+		return &runtime.EvaluateResult{Result: runtime.RemoteObject{Type: "undefined"}}, nil
+	})
+	return func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), exprs...)
+	}
+}
+
+func value(v string) *runtime.EvaluateResult {
+	return &runtime.EvaluateResult{Result: runtime.RemoteObject{Type: "object", Value: json.RawMessage(v)}}
+}
+
+var exception = &runtime.EvaluateResult{ExceptionDetails: &runtime.ExceptionDetails{Text: "Uncaught"}}
+
+func TestWaitForFonts(t *testing.T) {
+	const fontsReady = "document.fonts.ready.then(() => null)"
+	tests := []struct {
+		name    string
+		result  *runtime.EvaluateResult
+		wantErr bool
+	}{
+		{name: "ready", result: value("null")},
+		{name: "exception", result: exception, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+			exprs := evaluate(b, map[string]*runtime.EvaluateResult{fontsReady: tt.result}, nil)
+
+			if err := WaitForFonts(ctx); (err != nil) != tt.wantErr {
+				t.Errorf("WaitForFonts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff([]string{fontsReady}, exprs()); diff != "" {
+				t.Errorf("WaitForFonts() expressions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWaitForImages(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  *runtime.EvaluateResult
+		wantErr string
+	}{
+		{name: "decoded", result: value("[]")},
+		{
+			name:    "broken",
+			result:  value(`["https://example.com/a.png","https://example.com/b.png"]`),
+			wantErr: "2 images failed to load or decode: https://example.com/a.png, https://example.com/b.png",
+		},
+		{name: "exception", result: exception, wantErr: "Uncaught"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+			exprs := evaluate(b, map[string]*runtime.EvaluateResult{decodeImages: tt.result}, nil)
+
+			err := WaitForImages(ctx)
+			if (err != nil) != (tt.wantErr != "") || (err != nil && !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("WaitForImages() error = %v, want %q", err, tt.wantErr)
+			}
+			if diff := cmp.Diff([]string{decodeImages}, exprs()); diff != "" {
+				t.Errorf("WaitForImages() expressions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWaitForRendering(t *testing.T) {
+	const (
+		readyState = "document.readyState"
+		fontsReady = "document.fonts.ready.then(() => null)"
+	)
+	tests := []struct {
+		name      string
+		loaded    bool // Whether the page is loaded before waiting.
+		fonts     *runtime.EvaluateResult
+		images    *runtime.EvaluateResult
+		wantExprs []string
+		wantErr   bool
+	}{
+		{
+			name:      "loaded",
+			loaded:    true,
+			fonts:     value("null"),
+			images:    value("[]"),
+			wantExprs: []string{readyState, fontsReady, decodeImages},
+		},
+		{
+			name:      "load_event",
+			fonts:     value("null"),
+			images:    value("[]"),
+			wantExprs: []string{readyState, fontsReady, decodeImages},
+		},
+		{
+			name:      "fonts_error",
+			loaded:    true,
+			fonts:     exception,
+			wantExprs: []string{readyState, fontsReady},
+			wantErr:   true,
+		},
+		{
+			name:      "broken_images",
+			loaded:    true,
+			fonts:     value("null"),
+			images:    value(`["https://example.com/a.png"]`),
+			wantExprs: []string{readyState, fontsReady, decodeImages},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+			state := value(`"complete"`)
+			if !tt.loaded {
+				state = value(`"interactive"`)
+			}
+			values := map[string]*runtime.EvaluateResult{readyState: state, fontsReady: tt.fonts, decodeImages: tt.images}
+			exprs := evaluate(b, values, func(expr string) error {
+				if expr != readyState || tt.loaded {
+					return nil
+				}
+				// The page finishes loading after it's checked.
+				return b.Emit("Page.loadEventFired", map[string]float64{"timestamp": 1})
+			})
+
+			if err := WaitForRendering(ctx); (err != nil) != tt.wantErr {
+				t.Errorf("WaitForRendering() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantExprs, exprs()); diff != "" {
+				t.Errorf("WaitForRendering() expressions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package page
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/dom"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// IsolatedWorld manages isolated JavaScript worlds with the same name in
+// the frames of a page, like the content scripts of browser extensions:
+// they share the DOM with the page, but not its JavaScript globals, so
+// page scripts can't interfere with automation code (e.g. by overriding
+// built-in functions), and they can't detect it either. See the
+// `page.NewIsolatedWorld` function. Multiple goroutines may use the
+// same manager simultaneously.
+type IsolatedWorld struct {
+	ctx     context.Context
+	name    string
+	scripts []string
+
+	mu       sync.Mutex
+	contexts map[string]runtime.ExecutionContextID // Frame ID -> world.
+}
+
+// NewIsolatedWorld constructs a new manager of isolated worlds with the given
+// name, in the page associated with the given context. Each world is created
+// lazily, when it's used for the first time in a frame (with the CDP command
+// `Page.createIsolatedWorld`), and then the given utility scripts are
+// evaluated in it, in order, so later evaluations may use the functions
+// and variables that they define.
+//
+// Worlds are destroyed when their frames navigate, so the manager creates
+// them again, and re-evaluates the utility scripts, when it detects this.
+func NewIsolatedWorld(ctx context.Context, name string, scripts ...string) *IsolatedWorld {
+	return &IsolatedWorld{
+		ctx:      ctx,
+		name:     name,
+		scripts:  scripts,
+		contexts: make(map[string]runtime.ExecutionContextID),
+	}
+}
+
+// ContextID returns the execution context ID of the world in the frame with
+// the given ID (or the main frame, if the ID is empty), creating it if needed.
+func (w *IsolatedWorld) ContextID(frameID string) (runtime.ExecutionContextID, error) {
+	if frameID == "" {
+		tree, err := NewGetFrameTree().Do(w.ctx)
+		if err != nil {
+			return 0, err
+		}
+		frameID = tree.FrameTree.Frame.ID
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if id, ok := w.contexts[frameID]; ok {
+		return id, nil
+	}
+	r, err := NewCreateIsolatedWorld(frameID).SetWorldName(w.name).Do(w.ctx)
+	if err != nil {
+		return 0, err
+	}
+	for i, s := range w.scripts {
+		if err := evalInWorld(w.ctx, r.ExecutionContextID, s, nil); err != nil {
+			return 0, fmt.Errorf("utility script %d in isolated world %q: %w", i, w.name, err)
+		}
+	}
+	w.contexts[frameID] = r.ExecutionContextID
+	return r.ExecutionContextID, nil
+}
+
+// Forget discards the cached world of the frame with the given ID (or of all
+// the frames, if the ID is empty), so it's created again when it's used next.
+// This is done automatically when a world is found to be destroyed.
+func (w *IsolatedWorld) Forget(frameID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if frameID == "" {
+		w.contexts = make(map[string]runtime.ExecutionContextID)
+		return
+	}
+	delete(w.contexts, frameID)
+}
+
+// Eval evaluates the given JavaScript expression in the world of the frame
+// with the given ID (or the main frame, if the ID is empty), waits for its
+// result if it's a promise, and stores its JSON value in the value pointed
+// to by v (if v isn't nil), like `json.Unmarshal`.
+func (w *IsolatedWorld) Eval(frameID, expression string, v interface{}) error {
+	return w.retry(frameID, func(id runtime.ExecutionContextID) error {
+		return evalInWorld(w.ctx, id, expression, v)
+	})
+}
+
+// CallFunctionOn calls the given JavaScript function declaration with the
+// given element as `this`, in the world of the element's frame (the main
+// frame, or the frame with the given ID), with the given arguments (which
+// must be JSON-serializable), and stores its JSON result in the value
+// pointed to by v (if v isn't nil), like the `IsolatedWorld.Eval` method.
+func (w *IsolatedWorld) CallFunctionOn(frameID string, h *dom.ElementHandle, function string, v interface{}, args ...interface{}) error {
+	var a []runtime.CallArgument
+	for _, arg := range args {
+		b, err := json.Marshal(arg)
+		if err != nil {
+			return err
+		}
+		a = append(a, runtime.CallArgument{Value: b})
+	}
+	backendNodeID, err := h.BackendNodeID(w.ctx)
+	if err != nil {
+		return err
+	}
+	return w.retry(frameID, func(id runtime.ExecutionContextID) error {
+		r, err := dom.NewResolveNode().SetBackendNodeID(backendNodeID).SetExecutionContextID(id).Do(w.ctx)
+		if err != nil {
+			return err
+		}
+		defer runtime.NewReleaseObject(r.Object.ObjectID).Do(w.ctx)
+		c := runtime.NewCallFunctionOn(function).SetObjectID(r.Object.ObjectID).
+			SetReturnByValue(true).SetAwaitPromise(true)
+		if len(a) > 0 {
+			c.SetArguments(a)
+		}
+		result, err := c.Do(w.ctx)
+		if err != nil {
+			return err
+		}
+		return decodeResult(result.ExceptionDetails, result.Result.Value, v)
+	})
+}
+
+// Query is like the `dom.Query` function, but JavaScript-based selector
+// engines (e.g. "xpath=" and "text=") run in the world of the main frame,
+// so page scripts can't interfere with them. Note that stale handles are
+// re-queried with the context which is passed to `ElementHandle.Do`.
+func (w *IsolatedWorld) Query(selector string, opts ...dom.QueryOption) (*dom.ElementHandle, error) {
+	var h *dom.ElementHandle
+	err := w.retry("", func(id runtime.ExecutionContextID) error {
+		var err error
+		h, err = dom.Query(dom.InExecutionContext(w.ctx, id), selector, opts...)
+		return err
+	})
+	return h, err
+}
+
+// QueryAll is like the `dom.QueryAll` function, but JavaScript-based selector
+// engines run in the world of the main frame, like the `IsolatedWorld.Query`
+// method.
+func (w *IsolatedWorld) QueryAll(selector string) ([]*dom.ElementHandle, error) {
+	var hs []*dom.ElementHandle
+	err := w.retry("", func(id runtime.ExecutionContextID) error {
+		var err error
+		hs, err = dom.QueryAll(dom.InExecutionContext(w.ctx, id), selector)
+		return err
+	})
+	return hs, err
+}
+
+// Call the given function with the world of the given frame, and if
+// it fails because the world was destroyed (e.g. after a navigation),
+// create the world again, and call the function one more time.
+func (w *IsolatedWorld) retry(frameID string, f func(runtime.ExecutionContextID) error) error {
+	id, err := w.ContextID(frameID)
+	if err != nil {
+		return err
+	}
+	if err = f(id); !isDestroyedContextError(err) {
+		return err
+	}
+	w.forgetContext(id)
+	if id, err = w.ContextID(frameID); err != nil {
+		return err
+	}
+	return f(id)
+}
+
+// Discard the cached world with the given execution context ID, if it's
+// still cached (i.e. another goroutine didn't replace it already).
+func (w *IsolatedWorld) forgetContext(id runtime.ExecutionContextID) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for frameID, c := range w.contexts {
+		if c == id {
+			delete(w.contexts, frameID)
+		}
+	}
+}
+
+func isDestroyedContextError(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "cannot find context with specified id")
+}
+
+func evalInWorld(ctx context.Context, id runtime.ExecutionContextID, expression string, v interface{}) error {
+	r, err := runtime.NewEvaluate(expression).SetContextID(int64(id)).
+		SetReturnByValue(true).SetAwaitPromise(true).Do(ctx)
+	if err != nil {
+		return err
+	}
+	return decodeResult(r.ExceptionDetails, r.Result.Value, v)
+}
+
+// Return the exception of an evaluation, if there is one, or unmarshal
+// its JSON value into v (if v isn't nil, and the value isn't undefined).
+func decodeResult(e *runtime.ExceptionDetails, value json.RawMessage, v interface{}) error {
+	if e != nil {
+		return e
+	}
+	if v == nil || len(value) == 0 {
+		return nil
+	}
+	return json.Unmarshal(value, v)
+}
@@ -0,0 +1,38 @@
+package page
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+func TestDecodeResult(t *testing.T) {
+	var n int
+	if err := decodeResult(nil, []byte("42"), &n); err != nil || n != 42 {
+		t.Errorf("decodeResult(42) = %d, %v; want 42, nil", n, err)
+	}
+	if err := decodeResult(nil, nil, &n); err != nil {
+		t.Errorf("decodeResult(undefined); got error: %v", err)
+	}
+	e := &runtime.ExceptionDetails{Text: "Uncaught"}
+	if err := decodeResult(e, []byte("1"), &n); err != e {
+		t.Errorf("decodeResult(exception) error = %v, want %v", err, e)
+	}
+}
+
+func TestIsolatedWorldForgetContext(t *testing.T) {
+	w := NewIsolatedWorld(context.Background(), "test")
+	w.contexts["a"], w.contexts["b"] = 1, 2
+	w.forgetContext(1)
+	if _, ok := w.contexts["a"]; ok {
+		t.Error("forgetContext(1) didn't discard frame a")
+	}
+	if _, ok := w.contexts["b"]; !ok {
+		t.Error("forgetContext(1) discarded frame b")
+	}
+	if !isDestroyedContextError(errors.New("Cannot find context with specified id")) {
+		t.Error("isDestroyedContextError() = false, want true")
+	}
+}
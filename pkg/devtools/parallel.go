@@ -0,0 +1,197 @@
+package devtools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Task is a unit of work for the `devtools.RunParallel` function. The given
+// context is associated with a dedicated browser tab, which is not shared
+// with any other task that runs at the same time.
+type Task = func(ctx context.Context) error
+
+// TaskError is the error of a single task in the `devtools.RunParallel`
+// function, along with the task's index in the input slice.
+type TaskError struct {
+	Index int
+	Err   error
+}
+
+// Error satisfies the Go error interface (https://golang.org/pkg/builtin/#error).
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the task's original error.
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// TaskErrors aggregates the errors of all the failed tasks in the
+// `devtools.RunParallel` function, sorted by their index.
+type TaskErrors []*TaskError
+
+// Error satisfies the Go error interface (https://golang.org/pkg/builtin/#error).
+func (e TaskErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d tasks failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+type parallelConfig struct {
+	taskTimeout     time.Duration
+	browserContexts bool
+}
+
+// ParallelOption is used for customization in the `devtools.RunParallel` function.
+type ParallelOption = func(*parallelConfig)
+
+// TaskTimeout allows the caller of the `devtools.RunParallel` function to
+// limit the duration of each task, with a deadline in the task's context.
+func TaskTimeout(d time.Duration) ParallelOption {
+	return func(c *parallelConfig) {
+		c.taskTimeout = d
+	}
+}
+
+// IsolatedBrowserContexts allows the caller of the `devtools.RunParallel`
+// function to open each worker's tab in a separate browser context (similar
+// to an incognito profile), so tasks don't share cookies, cache, storage, etc.
+func IsolatedBrowserContexts() ParallelOption {
+	return func(c *parallelConfig) {
+		c.browserContexts = true
+	}
+}
+
+// Copy of `target.CreateBrowserContextResult`.
+type createBrowserContextResult struct {
+	BrowserContextID string `json:"browserContextId"`
+}
+
+// RunParallel runs the given tasks with a pool of n workers (at least 1),
+// each of which opens its own tab in the browser associated with the given
+// context (which must be initialized with the `devtools.NewContext`
+// function), and closes it when there are no more tasks to run.
+//
+// It returns after all the tasks have ended. If any of them failed, the
+// returned error is a `devtools.TaskErrors` slice. Tasks are expected to
+// respect their context's cancelation and deadline (see the
+// `devtools.TaskTimeout` option).
+func RunParallel(ctx context.Context, n int, tasks []Task, opts ...ParallelOption) error {
+	if _, ok := FromContext(ctx); !ok {
+		return errNotInitialized
+	}
+	cfg := &parallelConfig{}
+	for _, o := range opts {
+		o(cfg)
+	}
+	if n < 1 {
+		n = 1
+	}
+	if n > len(tasks) {
+		n = len(tasks)
+	}
+
+	indices := make(chan int)
+	errs := make([]error, len(tasks))
+	var wg sync.WaitGroup
+	for w := 0; w < n; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			tabCtx, cleanup, err := newWorkerContext(ctx, cfg)
+			if err != nil {
+				log.Printf("Parallel worker %d initialization error: %v", w, err)
+				// Fail the tasks that this worker receives, instead of
+				// leaving them to the other workers, to avoid starvation.
+				for i := range indices {
+					errs[i] = err
+				}
+				return
+			}
+			defer cleanup()
+			for i := range indices {
+				errs[i] = runTask(tabCtx, tasks[i], cfg.taskTimeout)
+			}
+		}(w)
+	}
+	for i := range tasks {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	var result TaskErrors
+	for i, err := range errs {
+		if err != nil {
+			result = append(result, &TaskError{Index: i, Err: err})
+		}
+	}
+	if len(result) > 0 {
+		return result
+	}
+	return nil
+}
+
+// Open a new tab for a parallel worker, optionally in a new browser context,
+// and return its context along with a function to close them.
+func newWorkerContext(ctx context.Context, cfg *parallelConfig) (context.Context, func(), error) {
+	var opts []SessionOption
+	browserContextID := ""
+	if cfg.browserContexts {
+		// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-createBrowserContext
+		// (we don't use the target sub-package to avoid circular dependencies).
+		result := &createBrowserContextResult{}
+		if err := sendAndParse(ctx, "Target.createBrowserContext", nil, result); err != nil {
+			return nil, nil, fmt.Errorf(`"Target.createBrowserContext" command error: %v`, err)
+		}
+		browserContextID = result.BrowserContextID
+		opts = append(opts, BrowserContextID(browserContextID))
+	}
+
+	disposeBrowserContext := func() {
+		if browserContextID == "" {
+			return
+		}
+		// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-disposeBrowserContext
+		params := fmt.Sprintf(`{"browserContextId":%q}`, browserContextID)
+		SendAndWait(ctx, "Target.disposeBrowserContext", []byte(params))
+	}
+
+	tabCtx, err := NewContext(ctx, opts...)
+	if err != nil {
+		disposeBrowserContext()
+		return nil, nil, err
+	}
+	cleanup := func() {
+		if s, ok := FromContext(tabCtx); ok {
+			// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-closeTarget
+			params := fmt.Sprintf(`{"targetId":%q}`, s.TargetID.Read())
+			SendAndWait(ctx, "Target.closeTarget", []byte(params))
+		}
+		Cancel(tabCtx)
+		disposeBrowserContext()
+	}
+	return tabCtx, cleanup, nil
+}
+
+// Run a single task, with an optional timeout, and recover from panics.
+func runTask(ctx context.Context, t Task, timeout time.Duration) (err error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return t(ctx)
+}
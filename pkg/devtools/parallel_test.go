@@ -0,0 +1,104 @@
+package devtools_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+)
+
+// Return a fake browser which opens tabs with unique target and session IDs.
+func newParallelBrowser() (context.Context, *devtoolstest.Browser) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	var mu sync.Mutex
+	tabs := 0
+	b.Handle("Target.createTarget", func(json.RawMessage) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		tabs++
+		return map[string]string{"targetId": fmt.Sprintf("T%d", tabs)}, nil
+	})
+	b.Handle("Target.attachToTarget", func(params json.RawMessage) (interface{}, error) {
+		p := struct {
+			TargetID string `json:"targetId"`
+		}{}
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, err
+		}
+		return map[string]string{"sessionId": "S" + p.TargetID}, nil
+	})
+	return ctx, b
+}
+
+func TestRunParallel(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		wantTabs int
+	}{
+		{name: "zero_workers", n: 0, wantTabs: 1},
+		{name: "negative_workers", n: -1, wantTabs: 1},
+		{name: "more_workers_than_tasks", n: 10, wantTabs: 4},
+		{name: "some_workers", n: 2, wantTabs: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Set up.
+			ctx, b := newParallelBrowser()
+			var mu sync.Mutex
+			var tabCtxs []context.Context
+			fail := errors.New("fail")
+			tasks := make([]devtools.Task, 4)
+			for i := range tasks {
+				i := i
+				tasks[i] = func(ctx context.Context) error {
+					mu.Lock()
+					tabCtxs = append(tabCtxs, ctx)
+					mu.Unlock()
+					if i%2 == 1 {
+						return fail
+					}
+					return nil
+				}
+			}
+			// Test.
+			err := devtools.RunParallel(ctx, tt.n, tasks)
+			var errs devtools.TaskErrors
+			if !errors.As(err, &errs) || len(errs) != 2 || errs[0].Index != 1 || errs[1].Index != 3 {
+				t.Fatalf("RunParallel() = %v, want errors of tasks 1 and 3", err)
+			}
+			if !errors.Is(errs[0], fail) {
+				t.Errorf("RunParallel() task 1 error = %v, want %v", errs[0].Err, fail)
+			}
+			if got := len(b.Calls("Target.createTarget")); got != tt.wantTabs {
+				t.Errorf("RunParallel() opened %d tabs, want %d", got, tt.wantTabs)
+			}
+			if got := len(b.Calls("Target.closeTarget")); got != tt.wantTabs {
+				t.Errorf("RunParallel() closed %d tabs, want %d", got, tt.wantTabs)
+			}
+			for i, c := range tabCtxs {
+				if c.Err() == nil {
+					t.Errorf("RunParallel() didn't cancel the context of task call %d", i)
+				}
+			}
+			if ctx.Err() != nil {
+				t.Errorf("RunParallel() canceled the browser's context: %v", ctx.Err())
+			}
+		})
+	}
+}
+
+func TestRunParallelNoTasks(t *testing.T) {
+	ctx, b := newParallelBrowser()
+	if err := devtools.RunParallel(ctx, 0, nil); err != nil {
+		t.Errorf("RunParallel(); got error: %v", err)
+	}
+	if calls := b.Calls(); len(calls) != 0 {
+		t.Errorf("RunParallel() sent commands without tasks: %v", calls)
+	}
+}
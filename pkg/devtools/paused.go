@@ -0,0 +1,234 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// NewContextPaused is like the `devtools.NewContext` function, but it opens a
+// new tab which starts navigating to the given URL only after the caller
+// resumes it with the returned function, which sends the CDP command
+// `Runtime.runIfWaitingForDebugger`. This lets the caller install
+// interception and instrumentation in the new tab before its first
+// request, e.g. with `fetch.NewEnable` or `page.NewInitScripts`, so
+// nothing that the page does at startup is missed.
+//
+// If the parent context doesn't carry a CDP session yet, this function
+// starts a new browser first, like the `devtools.NewContext` function,
+// and opens the paused tab in addition to the browser's first tab.
+//
+// The tab is paused by enabling the automatic attachment of the browser
+// to new targets (the CDP command `Target.setAutoAttach`, without a
+// session). Waiting for the debugger is disabled as soon as the new tab
+// is attached, so other tabs and popups aren't paused. The browser's
+// previous setting is restored when the last paused tab is resumed, or
+// when its context is done, which also detaches the browser from the
+// other targets that were attached automatically in the meantime. The
+// returned context's session is attached to the new tab separately, so
+// it isn't affected by this.
+func NewContextPaused(parent context.Context, url string, opts ...SessionOption) (context.Context, func() error, error) {
+	if _, ok := FromContext(parent); !ok {
+		ctx, err := NewContext(parent, opts...)
+		if err != nil {
+			return parent, nil, err
+		}
+		parent = ctx
+	}
+	ctx, err := newContext(parent, &url, opts)
+	if err != nil {
+		return parent, nil, err
+	}
+	s, _ := FromContext(ctx)
+	return ctx, s.resume, nil
+}
+
+func init() {
+	// Snapshot the browser's automatic attachment before pausing tabs.
+	TrackCommands("Target.setAutoAttach")
+}
+
+// Browser-level automatic attachment to new targets, shared by all the
+// paused tabs of a browser. It stays enabled until the last one of them
+// is resumed, and then it's restored to its previous setting.
+type pausedTabs struct {
+	mu      sync.Mutex // Also serializes the creation of paused tabs.
+	count   int
+	restore *setAutoAttachParams
+}
+
+// Create a new paused browser tab with the given URL for the session in
+// the given context (which isn't attached to a target yet), set up the
+// session's resume function, and return the new tab's target ID.
+func newPausedTab(ctx, parent context.Context, url string) (string, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return "", errNotInitialized
+	}
+	// Browser-level copies of the session, the first one for creating
+	// the tab with the new session's options, and the second one for
+	// restoring the browser's setting after the new session ends.
+	create, err := WithAttachedSession(ctx, "", "")
+	if err != nil {
+		return "", err
+	}
+	restore, err := WithAttachedSession(parent, "", "")
+	if err != nil {
+		return "", err
+	}
+
+	p := s.pausedTabs
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.count == 0 {
+		p.restore = &setAutoAttachParams{Flatten: true}
+		if c := LastCommand(create, "Target.setAutoAttach"); c != nil {
+			json.Unmarshal(c.Params, p.restore)
+		}
+	}
+	targetID, sessionID, err := createPausedTarget(create, url)
+	if err != nil {
+		if p.count == 0 {
+			sendAndParse(restore, "Target.setAutoAttach", p.restore, nil)
+		}
+		return "", err
+	}
+	p.count++
+
+	var releaseOnce sync.Once
+	var releaseErr error
+	release := func() error {
+		releaseOnce.Do(func() {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if p.count--; p.count == 0 {
+				releaseErr = sendAndParse(restore, "Target.setAutoAttach", p.restore, nil)
+			}
+		})
+		return releaseErr
+	}
+	go func() {
+		<-ctx.Done()
+		// Don't send anything if the browser is gone.
+		if parent.Err() == nil {
+			release()
+		}
+	}()
+
+	// The tab waits for the session which was attached to it
+	// automatically, not for the session in the given context.
+	waiting, err := WithAttachedSession(create, targetID, sessionID)
+	if err != nil {
+		return "", err
+	}
+	var resumeOnce sync.Once
+	var resumeErr error
+	s.resume = func() error {
+		resumeOnce.Do(func() {
+			// https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#method-runIfWaitingForDebugger
+			// (we don't use the runtime sub-package to avoid circular dependencies).
+			resumeErr = sendAndParse(waiting, "Runtime.runIfWaitingForDebugger", nil, nil)
+			if err := release(); resumeErr == nil {
+				resumeErr = err
+			}
+		})
+		return resumeErr
+	}
+	return targetID, nil
+}
+
+// Partial copy of `target.SetAutoAttach`.
+type setAutoAttachParams struct {
+	AutoAttach             bool `json:"autoAttach"`
+	WaitForDebuggerOnStart bool `json:"waitForDebuggerOnStart"`
+	Flatten                bool `json:"flatten"`
+}
+
+// Partial copy of `target.AttachedToTarget`.
+type attachedToTarget struct {
+	SessionID          string     `json:"sessionId"`
+	TargetInfo         targetInfo `json:"targetInfo"`
+	WaitingForDebugger bool       `json:"waitingForDebugger"`
+}
+
+// Create a new browser tab with the given URL, which waits for the debugger
+// before it starts, and return its target ID and the ID of the session which
+// was attached to it automatically. This must be called with a context whose
+// session isn't attached to a target, so its commands are sent to the browser
+// rather than to a tab. The other targets which are attached automatically
+// are detached when the browser's previous setting is restored.
+func createPausedTarget(ctx context.Context, url string) (string, string, error) {
+	sub, err := Subscribe(ctx, "Target.attachedToTarget")
+	if err != nil {
+		return "", "", err
+	}
+
+	// Collect attachment events in the background, because the events
+	// of existing targets arrive before the responses to our commands.
+	var mu sync.Mutex
+	var attached []attachedToTarget
+	notify := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for m := range sub.C {
+			e := attachedToTarget{}
+			if m.SessionID != "" || json.Unmarshal(m.Params, &e) != nil {
+				continue // Not attached to the browser directly.
+			}
+			mu.Lock()
+			attached = append(attached, e)
+			mu.Unlock()
+			select {
+			case notify <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	defer func() {
+		sub.Unsubscribe()
+		<-done
+	}()
+
+	// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-setAutoAttach
+	// (we don't use the target sub-package to avoid circular dependencies).
+	params := &setAutoAttachParams{AutoAttach: true, WaitForDebuggerOnStart: true, Flatten: true}
+	if err := sendAndParse(ctx, "Target.setAutoAttach", params, nil); err != nil {
+		return "", "", fmt.Errorf(`"Target.setAutoAttach" command error: %v`, err)
+	}
+	params.WaitForDebuggerOnStart = false
+	// Disabling auto-attachment would detach the new tab's session,
+	// which resumes it, so only the waiting is disabled afterwards.
+	defer sendAndParse(ctx, "Target.setAutoAttach", params, nil)
+
+	targetID, err := createTarget(ctx, url)
+	if err != nil {
+		return "", "", fmt.Errorf(`"Target.createTarget" command error: %v`, err)
+	}
+
+	timer := time.NewTimer(TargetTimeout)
+	defer timer.Stop()
+	for {
+		mu.Lock()
+		sessionID := ""
+		for _, e := range attached {
+			if e.TargetInfo.TargetID == targetID {
+				sessionID = e.SessionID
+			}
+		}
+		mu.Unlock()
+		if sessionID != "" {
+			return targetID, sessionID, nil
+		}
+
+		select {
+		case <-notify:
+		case <-timer.C:
+			return "", "", fmt.Errorf("new tab %s wasn't attached automatically", targetID)
+		case <-ctx.Done():
+			return "", "", ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Fake browser which attaches automatically to an existing target and to
+// each new tab, and records the paused-tab-related commands it receives.
+type pausedBrowser struct {
+	mu    sync.Mutex
+	calls []string
+	tabs  int
+}
+
+func (b *pausedBrowser) handle(ctx context.Context, method string, params json.RawMessage) (*Message, error) {
+	s, _ := FromContext(ctx)
+	switch method {
+	case "Target.setAutoAttach", "Runtime.runIfWaitingForDebugger":
+		call := method
+		if id := s.SessionID.Read(); id != "" {
+			call = id + " " + call
+		}
+		if method == "Target.setAutoAttach" {
+			call += " " + string(params)
+		}
+		b.mu.Lock()
+		b.calls = append(b.calls, call)
+		b.mu.Unlock()
+		if strings.Contains(string(params), `"waitForDebuggerOnStart":true`) {
+			DispatchEvent(ctx, &Message{Method: "Target.attachedToTarget", Params: json.RawMessage(
+				`{"sessionId":"OTHER","targetInfo":{"targetId":"EXISTING"}}`)})
+		}
+	case "Target.createTarget":
+		b.mu.Lock()
+		b.tabs++
+		id := fmt.Sprintf("TAB%d", b.tabs)
+		b.mu.Unlock()
+		DispatchEvent(ctx, &Message{Method: "Target.attachedToTarget", Params: json.RawMessage(fmt.Sprintf(
+			`{"sessionId":"AUTO_%s","targetInfo":{"targetId":%q},"waitingForDebugger":true}`, id, id))})
+		return &Message{Result: json.RawMessage(fmt.Sprintf(`{"targetId":%q}`, id))}, nil
+	case "Target.attachToTarget":
+		t := &struct {
+			TargetID string `json:"targetId"`
+		}{}
+		json.Unmarshal(params, t)
+		return &Message{Result: json.RawMessage(fmt.Sprintf(`{"sessionId":"MANUAL_%s"}`, t.TargetID))}, nil
+	}
+	return &Message{Result: json.RawMessage(`{}`)}, nil
+}
+
+func (b *pausedBrowser) recorded() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.calls...)
+}
+
+const (
+	enableAutoAttach  = `Target.setAutoAttach {"autoAttach":true,"waitForDebuggerOnStart":true,"flatten":true}`
+	stopWaiting       = `Target.setAutoAttach {"autoAttach":true,"waitForDebuggerOnStart":false,"flatten":true}`
+	disableAutoAttach = `Target.setAutoAttach {"autoAttach":false,"waitForDebuggerOnStart":false,"flatten":true}`
+)
+
+func TestNewContextPaused(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := &pausedBrowser{}
+	ctx = NewFakeContext(ctx, b.handle)
+
+	tab, resume, err := NewContextPaused(ctx, "https://example.com/")
+	if err != nil {
+		t.Fatalf("NewContextPaused(); got error: %v", err)
+	}
+	s, _ := FromContext(tab)
+	if got, want := s.SessionID.Read(), "MANUAL_TAB1"; got != want {
+		t.Errorf("NewContextPaused() session ID = %q, want %q", got, want)
+	}
+	if err := resume(); err != nil {
+		t.Fatalf("resume(); got error: %v", err)
+	}
+	if err := resume(); err != nil {
+		t.Fatalf("resume() again; got error: %v", err)
+	}
+
+	want := []string{
+		enableAutoAttach,
+		stopWaiting,
+		"AUTO_TAB1 Runtime.runIfWaitingForDebugger",
+		disableAutoAttach,
+	}
+	if diff := cmp.Diff(want, b.recorded()); diff != "" {
+		t.Errorf("recorded commands mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewContextPausedConcurrent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := &pausedBrowser{}
+	ctx = NewFakeContext(ctx, b.handle)
+
+	_, resume1, err := NewContextPaused(ctx, "https://example.com/1")
+	if err != nil {
+		t.Fatalf("NewContextPaused(1); got error: %v", err)
+	}
+	_, resume2, err := NewContextPaused(ctx, "https://example.com/2")
+	if err != nil {
+		t.Fatalf("NewContextPaused(2); got error: %v", err)
+	}
+	if err := resume1(); err != nil {
+		t.Fatalf("resume1(); got error: %v", err)
+	}
+	if got := b.recorded(); got[len(got)-1] == disableAutoAttach {
+		t.Errorf("automatic attachment disabled before the second tab was resumed")
+	}
+	if err := resume2(); err != nil {
+		t.Fatalf("resume2(); got error: %v", err)
+	}
+
+	want := []string{
+		enableAutoAttach,
+		stopWaiting,
+		enableAutoAttach,
+		stopWaiting,
+		"AUTO_TAB1 Runtime.runIfWaitingForDebugger",
+		"AUTO_TAB2 Runtime.runIfWaitingForDebugger",
+		disableAutoAttach,
+	}
+	if diff := cmp.Diff(want, b.recorded()); diff != "" {
+		t.Errorf("recorded commands mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewContextPausedRestore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := &pausedBrowser{}
+	ctx = NewFakeContext(ctx, b.handle)
+
+	// The caller enabled the automatic attachment before.
+	browser, err := WithAttachedSession(ctx, "", "")
+	if err != nil {
+		t.Fatalf("WithAttachedSession(); got error: %v", err)
+	}
+	params := json.RawMessage(`{"autoAttach":true,"waitForDebuggerOnStart":false,"flatten":true}`)
+	if _, err := SendAndWait(browser, "Target.setAutoAttach", params); err != nil {
+		t.Fatalf("SendAndWait(); got error: %v", err)
+	}
+
+	tab, _, err := NewContextPaused(ctx, "https://example.com/")
+	if err != nil {
+		t.Fatalf("NewContextPaused(); got error: %v", err)
+	}
+	// Not resumed, but the tab's context is done.
+	Cancel(tab)
+
+	want := []string{
+		stopWaiting,
+		enableAutoAttach,
+		stopWaiting,
+		stopWaiting, // Restored.
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for len(b.recorded()) < len(want) && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if diff := cmp.Diff(want, b.recorded()); diff != "" {
+		t.Errorf("recorded commands mismatch (-want +got):\n%s", diff)
+	}
+}
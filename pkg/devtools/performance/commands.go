@@ -65,13 +65,23 @@ type Enable struct {
 
 // NewEnable constructs a new Enable struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `EnableOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Performance/#method-enable
-func NewEnable() *Enable {
-	return &Enable{}
+func NewEnable(opts ...EnableOption) *Enable {
+	c := &Enable{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// EnableOption is a functional option for the optional
+// parameters of the Enable CDP command, see the
+// NewEnable constructor. Options are applied in order.
+type EnableOption = func(*Enable)
+
 // SetTimeDomain adds or modifies the value of the optional
 // parameter `timeDomain` in the Enable CDP command.
 //
@@ -81,6 +91,14 @@ func (t *Enable) SetTimeDomain(v string) *Enable {
 	return t
 }
 
+// EnableWithTimeDomain is a functional option which sets the value of the
+// optional parameter `timeDomain` in the Enable CDP command, see SetTimeDomain.
+func EnableWithTimeDomain(v string) EnableOption {
+	return func(c *Enable) {
+		c.SetTimeDomain(v)
+	}
+}
+
 // Do sends the Enable CDP command to a browser,
 // and returns the browser's response.
 func (t *Enable) Do(ctx context.Context) error {
@@ -1,13 +1,16 @@
 package performancetimeline
 
-import "github.com/daabr/chrome-vision/pkg/devtools/dom"
+import (
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/dom"
+)
 
 // LargestContentfulPaint data type. See https://github.com/WICG/LargestContentfulPaint and largest_contentful_paint.idl
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/PerformanceTimeline/#type-LargestContentfulPaint
 type LargestContentfulPaint struct {
-	RenderTime float64 `json:"renderTime"`
-	LoadTime   float64 `json:"loadTime"`
+	RenderTime devtools.TimeSinceEpoch `json:"renderTime"`
+	LoadTime   devtools.TimeSinceEpoch `json:"loadTime"`
 	// The number of pixels being painted.
 	Size float64 `json:"size"`
 	// The id attribute of the element, if available.
@@ -33,7 +36,7 @@ type LayoutShift struct {
 	// Score increment produced by this event.
 	Value          float64                  `json:"value"`
 	HadRecentInput bool                     `json:"hadRecentInput"`
-	LastInputTime  float64                  `json:"lastInputTime"`
+	LastInputTime  devtools.TimeSinceEpoch  `json:"lastInputTime"`
 	Sources        []LayoutShiftAttribution `json:"sources"`
 }
 
@@ -49,7 +52,7 @@ type TimelineEvent struct {
 	// Name may be empty depending on the type.
 	Name string `json:"name"`
 	// Time in seconds since Epoch, monotonically increasing within document lifetime.
-	Time float64 `json:"time"`
+	Time devtools.TimeSinceEpoch `json:"time"`
 	// Event duration, if applicable.
 	Duration           float64                 `json:"duration,omitempty"`
 	LcpDetails         *LargestContentfulPaint `json:"lcpDetails,omitempty"`
@@ -0,0 +1,138 @@
+package devtools
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Pref is the dot-separated path of a Chrome preference, in the "Preferences"
+// or "Local State" JSON file of a user data directory, e.g.
+// "download.default_directory". See the `devtools.Preferences` and
+// `devtools.LocalState` session options.
+type Pref string
+
+// Common profile preferences (for the `devtools.Preferences` option). See
+// https://source.chromium.org/chromium/chromium/src/+/main:chrome/common/pref_names.h
+// for others.
+const (
+	// DownloadDirectory is the default directory of downloads (a string).
+	DownloadDirectory Pref = "download.default_directory"
+	// PromptForDownload shows a "Save as" dialog for downloads (a bool).
+	PromptForDownload Pref = "download.prompt_for_download"
+	// AlwaysOpenPDFExternally disables the built-in PDF viewer,
+	// so PDF files are downloaded instead (a bool).
+	AlwaysOpenPDFExternally Pref = "plugins.always_open_pdf_externally"
+	// TranslateEnabled offers to translate pages in other languages (a bool).
+	TranslateEnabled Pref = "translate.enabled"
+	// PasswordManagerEnabled offers to save passwords (a bool).
+	PasswordManagerEnabled Pref = "credentials_enable_service"
+	// NotificationsSetting is the default permission of websites to show
+	// notifications (an int: 1 = allow, 2 = block, 3 = ask).
+	NotificationsSetting Pref = "profile.default_content_setting_values.notifications"
+	// SpellcheckEnabled checks the spelling of text fields (a bool).
+	SpellcheckEnabled Pref = "browser.enable_spellchecking"
+)
+
+// Common browser-wide preferences (for the `devtools.LocalState` option).
+const (
+	// BrowserEnabledLabsExperiments are enabled "chrome://flags" entries,
+	// e.g. "enable-parallel-downloading" (a []string).
+	BrowserEnabledLabsExperiments Pref = "browser.enabled_labs_experiments"
+)
+
+// Preferences allows the caller of the `devtools.NewContext` function to set
+// preferences of the browser's default profile, by patching the "Preferences"
+// JSON file in the user data directory (in the "Default" sub-directory) before
+// the browser starts, e.g. to set the default download directory, disable the
+// PDF viewer, or turn off translation prompts, without relying only on
+// command-line flags. Other preferences in the file (e.g. from a seeded
+// profile, see `devtools.SeedProfile`) are preserved. Multiple calls
+// are merged.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser, with remote browsers, and with Firefox.
+func Preferences(prefs map[Pref]interface{}) SessionOption {
+	return func(s *Session) {
+		s.prefs = mergePrefs(s.prefs, prefs)
+	}
+}
+
+// LocalState is like the `devtools.Preferences` option, but for browser-wide
+// preferences, in the "Local State" JSON file of the user data directory.
+func LocalState(prefs map[Pref]interface{}) SessionOption {
+	return func(s *Session) {
+		s.localState = mergePrefs(s.localState, prefs)
+	}
+}
+
+func mergePrefs(dst, src map[Pref]interface{}) map[Pref]interface{} {
+	if dst == nil {
+		dst = make(map[Pref]interface{})
+	}
+	for k, v := range src {
+		dst[k] = v
+	}
+	return dst
+}
+
+// Write the session's preferences into the user data directory.
+func writePrefs(s *Session) error {
+	if len(s.prefs) > 0 {
+		path := filepath.Join(s.UserDataDir, "Default", "Preferences")
+		if err := patchPrefsFile(path, s.prefs); err != nil {
+			return err
+		}
+	}
+	if len(s.localState) > 0 {
+		path := filepath.Join(s.UserDataDir, "Local State")
+		if err := patchPrefsFile(path, s.localState); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Set the given preferences in the given JSON file,
+// creating it (and its parent directory) if needed.
+func patchPrefsFile(path string, prefs map[Pref]interface{}) error {
+	doc := make(map[string]interface{})
+	b, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		// Keep large integers (e.g. timestamps) intact.
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		if err := d.Decode(&doc); err != nil {
+			return err
+		}
+	case !os.IsNotExist(err):
+		return err
+	}
+	for k, v := range prefs {
+		setPref(doc, strings.Split(string(k), "."), v)
+	}
+	if b, err = json.Marshal(doc); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0644)
+}
+
+// Set the value at the given path in a JSON object, replacing
+// intermediate values which aren't objects.
+func setPref(doc map[string]interface{}, path []string, v interface{}) {
+	for _, p := range path[:len(path)-1] {
+		next, ok := doc[p].(map[string]interface{})
+		if !ok {
+			next = make(map[string]interface{})
+			doc[p] = next
+		}
+		doc = next
+	}
+	doc[path[len(path)-1]] = v
+}
@@ -0,0 +1,69 @@
+package devtools
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWritePrefs(t *testing.T) {
+	s := &Session{UserDataDir: t.TempDir()}
+	path := filepath.Join(s.UserDataDir, "Default", "Preferences")
+	// A seeded profile, with existing preferences.
+	os.MkdirAll(filepath.Dir(path), 0755)
+	seeded := `{"download":{"prompt_for_download":true,"directory_upgrade":true},"translate":"invalid",` +
+		`"profile":{"last_engagement_time":13312345678901234567}}`
+	if err := os.WriteFile(path, []byte(seeded), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	Preferences(map[Pref]interface{}{DownloadDirectory: "/tmp/dl", PromptForDownload: false})(s)
+	Preferences(map[Pref]interface{}{TranslateEnabled: false})(s)
+	LocalState(map[Pref]interface{}{BrowserEnabledLabsExperiments: []string{"a"}})(s)
+	if err := writePrefs(s); err != nil {
+		t.Fatalf("writePrefs(); got error: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want map[string]interface{}
+	}{
+		{
+			path: path,
+			want: map[string]interface{}{
+				"download": map[string]interface{}{
+					"default_directory":   "/tmp/dl",
+					"prompt_for_download": false,
+					"directory_upgrade":   true,
+				},
+				"translate": map[string]interface{}{"enabled": false},
+				"profile":   map[string]interface{}{"last_engagement_time": json.Number("13312345678901234567")},
+			},
+		},
+		{
+			path: filepath.Join(s.UserDataDir, "Local State"),
+			want: map[string]interface{}{
+				"browser": map[string]interface{}{"enabled_labs_experiments": []interface{}{"a"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		b, err := os.ReadFile(tt.path)
+		if err != nil {
+			t.Fatalf("os.ReadFile(%q); got error: %v", tt.path, err)
+		}
+		got := make(map[string]interface{})
+		d := json.NewDecoder(bytes.NewReader(b))
+		d.UseNumber()
+		if err := d.Decode(&got); err != nil {
+			t.Fatalf("Decode(%q); got error: %v", tt.path, err)
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("%s mismatch (-want +got):\n%s", filepath.Base(tt.path), diff)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+//go:build !windows
+// +build !windows
+
+package devtools
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// Send the given signal to the process group which
+// is led by the given process (see `setProcessGroup`).
+func signalProcessGroup(p *os.Process, sig syscall.Signal) error {
+	return syscall.Kill(-p.Pid, sig)
+}
+
+// Report whether a process with the given ID exists.
+func processAlive(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// Return the command line of the process with the given ID.
+func processCommandLine(pid int) (string, error) {
+	if b, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/cmdline"); err == nil {
+		return strings.ReplaceAll(string(b), "\x00", " "), nil
+	}
+	// Operating systems without procfs, e.g. macOS.
+	b, err := exec.Command("ps", "-o", "command=", "-p", strconv.Itoa(pid)).Output()
+	return string(b), err
+}
+
+// Return the resource usage of all the processes, from procfs,
+// or with the "ps" command on operating systems without it.
+func listProcesses() ([]processStat, error) {
+	dirs, err := os.ReadDir("/proc")
+	if err != nil {
+		return listProcessesWithPS()
+	}
+	var procs []processStat
+	for _, d := range dirs {
+		if _, err := strconv.Atoi(d.Name()); err != nil {
+			continue
+		}
+		b, err := os.ReadFile("/proc/" + d.Name() + "/stat")
+		if err != nil {
+			continue // The process ended.
+		}
+		if p, err := parseProcStat(string(b)); err == nil {
+			procs = append(procs, p)
+		}
+	}
+	return procs, nil
+}
+
+func listProcessesWithPS() ([]processStat, error) {
+	b, err := exec.Command("ps", "-A", "-o", "pid=,ppid=,pgid=,rss=,time=").Output()
+	if err != nil {
+		return nil, err
+	}
+	var procs []processStat
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for scanner.Scan() {
+		if p, err := parsePSLine(scanner.Text()); err == nil {
+			procs = append(procs, p)
+		}
+	}
+	return procs, scanner.Err()
+}
@@ -0,0 +1,41 @@
+package devtools
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// Windows doesn't support sending signals other than kill,
+// so both termination and killing end the browser process
+// forcefully (its child processes end when it does).
+func signalProcessGroup(p *os.Process, _ syscall.Signal) error {
+	return p.Kill()
+}
+
+// Report whether a process with the given ID exists.
+func processAlive(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	p.Release()
+	return true
+}
+
+// Reading the command lines of other processes isn't supported on Windows,
+// so orphaned browsers are never killed by `devtools.CleanupStale`.
+func processCommandLine(pid int) (string, error) {
+	return "", errors.New("not supported on Windows")
+}
+
+// Reading the resource usage of other processes isn't
+// supported on Windows, so watchdogs stop immediately.
+func listProcesses() ([]processStat, error) {
+	return nil, errors.New("not supported on Windows")
+}
@@ -0,0 +1,124 @@
+package devtools
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Files which browsers use to lock their profile directories while they
+// run. They're never copied, because they would make the new browser
+// think that the profile is already in use.
+var profileLockFiles = map[string]bool{
+	// Chrome on Linux and macOS (symbolic links).
+	"SingletonLock":   true,
+	"SingletonSocket": true,
+	"SingletonCookie": true,
+	// Chrome on Windows.
+	"lockfile": true,
+	// Firefox.
+	"parent.lock": true,
+	".parentlock": true,
+	"lock":        true,
+}
+
+// SeedProfile allows the caller of the `devtools.NewContext` function to
+// start the browser with a copy of a prepared user data directory (e.g. with
+// extensions, preferences and logins), instead of an empty one, so sessions
+// start pre-configured without sharing a live profile. The copy is made
+// before the browser starts, in the session's user data directory (see the
+// `devtools.UserDataDir` option), which must be empty.
+//
+// Lock files are not copied, and symbolic links are skipped. Starting the
+// browser fails if the source directory is locked by a running Chrome on
+// this machine, because its files may be inconsistent while it's in use.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser, and with remote browsers.
+func SeedProfile(srcDir string) SessionOption {
+	return func(s *Session) {
+		s.profileSeed = srcDir
+	}
+}
+
+// Copy the source profile directory into the destination directory,
+// which must be empty, skipping lock files and symbolic links.
+func seedProfile(src, dst string) error {
+	if err := checkProfileLock(src); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dst)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("user data directory %s is not empty", dst)
+	}
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir():
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case profileLockFiles[d.Name()], d.Type()&fs.ModeSymlink != 0, !d.Type().IsRegular():
+			return nil
+		default:
+			return copyFile(path, target)
+		}
+	})
+}
+
+// Fail if the given Chrome profile directory is locked by a
+// process which is still running on this machine. The lock is a
+// symbolic link to "<hostname>-<PID>" (on Linux and macOS).
+func checkProfileLock(dir string) error {
+	target, err := os.Readlink(filepath.Join(dir, "SingletonLock"))
+	if err != nil {
+		return nil // Not locked, or not a Chrome profile on Linux or macOS.
+	}
+	i := strings.LastIndex(target, "-")
+	if i < 0 {
+		return nil
+	}
+	host, _ := os.Hostname()
+	pid, err := strconv.Atoi(target[i+1:])
+	if err != nil || target[:i] != host || !processAlive(pid) {
+		return nil // Stale lock, e.g. after a crash.
+	}
+	return fmt.Errorf("profile %s is in use by browser process %d", dir, pid)
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm()|0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
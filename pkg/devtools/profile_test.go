@@ -0,0 +1,61 @@
+package devtools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSeedProfile(t *testing.T) {
+	src, dst := t.TempDir(), filepath.Join(t.TempDir(), "user_data")
+	for _, f := range []string{"Local State", "Default/Preferences", "Default/Extensions/a/manifest.json", "lockfile"} {
+		path := filepath.Join(src, f)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(f), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if runtime.GOOS != "windows" {
+		// A stale lock, of a process which doesn't exist.
+		os.Symlink("other-host-1", filepath.Join(src, "SingletonLock"))
+	}
+
+	if err := seedProfile(src, dst); err != nil {
+		t.Fatalf("seedProfile(); got error: %v", err)
+	}
+	var got []string
+	filepath.Walk(dst, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(dst, path)
+			got = append(got, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	sort.Strings(got)
+	want := []string{"Default/Extensions/a/manifest.json", "Default/Preferences", "Local State"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("seedProfile() files mismatch (-want +got):\n%s", diff)
+	}
+
+	// The destination isn't empty anymore.
+	if err := seedProfile(src, dst); err == nil {
+		t.Error("seedProfile(non-empty destination); got nil error")
+	}
+}
+
+func TestSeedProfileInUse(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Chrome doesn't use symbolic links as locks on Windows")
+	}
+	src := t.TempDir()
+	host, _ := os.Hostname()
+	os.Symlink(fmt.Sprintf("%s-%d", host, os.Getpid()), filepath.Join(src, "SingletonLock"))
+	if err := seedProfile(src, t.TempDir()); err == nil {
+		t.Error("seedProfile(locked profile); got nil error")
+	}
+}
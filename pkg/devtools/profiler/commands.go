@@ -266,13 +266,23 @@ type StartPreciseCoverage struct {
 
 // NewStartPreciseCoverage constructs a new StartPreciseCoverage struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `StartPreciseCoverageOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Profiler/#method-startPreciseCoverage
-func NewStartPreciseCoverage() *StartPreciseCoverage {
-	return &StartPreciseCoverage{}
+func NewStartPreciseCoverage(opts ...StartPreciseCoverageOption) *StartPreciseCoverage {
+	c := &StartPreciseCoverage{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// StartPreciseCoverageOption is a functional option for the optional
+// parameters of the StartPreciseCoverage CDP command, see the
+// NewStartPreciseCoverage constructor. Options are applied in order.
+type StartPreciseCoverageOption = func(*StartPreciseCoverage)
+
 // SetCallCount adds or modifies the value of the optional
 // parameter `callCount` in the StartPreciseCoverage CDP command.
 //
@@ -282,6 +292,14 @@ func (t *StartPreciseCoverage) SetCallCount(v bool) *StartPreciseCoverage {
 	return t
 }
 
+// StartPreciseCoverageWithCallCount is a functional option which sets the value of the
+// optional parameter `callCount` in the StartPreciseCoverage CDP command, see SetCallCount.
+func StartPreciseCoverageWithCallCount(v bool) StartPreciseCoverageOption {
+	return func(c *StartPreciseCoverage) {
+		c.SetCallCount(v)
+	}
+}
+
 // SetDetailed adds or modifies the value of the optional
 // parameter `detailed` in the StartPreciseCoverage CDP command.
 //
@@ -291,6 +309,14 @@ func (t *StartPreciseCoverage) SetDetailed(v bool) *StartPreciseCoverage {
 	return t
 }
 
+// StartPreciseCoverageWithDetailed is a functional option which sets the value of the
+// optional parameter `detailed` in the StartPreciseCoverage CDP command, see SetDetailed.
+func StartPreciseCoverageWithDetailed(v bool) StartPreciseCoverageOption {
+	return func(c *StartPreciseCoverage) {
+		c.SetDetailed(v)
+	}
+}
+
 // SetAllowTriggeredUpdates adds or modifies the value of the optional
 // parameter `allowTriggeredUpdates` in the StartPreciseCoverage CDP command.
 //
@@ -300,6 +326,14 @@ func (t *StartPreciseCoverage) SetAllowTriggeredUpdates(v bool) *StartPreciseCov
 	return t
 }
 
+// StartPreciseCoverageWithAllowTriggeredUpdates is a functional option which sets the value of the
+// optional parameter `allowTriggeredUpdates` in the StartPreciseCoverage CDP command, see SetAllowTriggeredUpdates.
+func StartPreciseCoverageWithAllowTriggeredUpdates(v bool) StartPreciseCoverageOption {
+	return func(c *StartPreciseCoverage) {
+		c.SetAllowTriggeredUpdates(v)
+	}
+}
+
 // StartPreciseCoverageResult contains the browser's response
 // to calling the StartPreciseCoverage CDP command with Do().
 type StartPreciseCoverageResult struct {
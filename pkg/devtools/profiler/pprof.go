@@ -0,0 +1,252 @@
+package profiler
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// CaptureCPU records a CPU profile of the JavaScript code in the page
+// associated with the given context, using the V8 sampling profiler, for
+// the given duration. It returns early with the context's error if the
+// context is done first. See `Profile.WritePprof` for analysis with
+// standard tools.
+func CaptureCPU(ctx context.Context, d time.Duration) (*Profile, error) {
+	release, err := devtools.EnableDomain(ctx, "Profiler")
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if err := NewStart().Do(ctx); err != nil {
+		return nil, err
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	r, err := NewStop().Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &r.Profile, nil
+}
+
+// WritePprof writes the profile in the gzip-compressed protocol buffer
+// format of pprof (https://github.com/google/pprof/blob/master/proto/profile.proto),
+// so it can be analyzed with `go tool pprof` and other compatible tools.
+// Each sample has 2 values: a count, and CPU time in nanoseconds.
+func (p *Profile) WritePprof(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	if _, err := gz.Write(newPprof(p).encode()); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Intermediate representation of a pprof profile. Location IDs are the
+// same as node IDs in the CDP profile, and function IDs are 1-based
+// indices in the functions slice.
+type pprof struct {
+	samples   []pprofSample
+	locations []pprofLocation
+	functions []pprofFunction
+	strings   []string
+	stringIDs map[string]int64
+
+	durationNanos, periodNanos int64
+}
+
+type pprofSample struct {
+	locationIDs  []uint64 // Leaf first.
+	count, nanos int64
+}
+
+type pprofLocation struct {
+	id, functionID uint64
+	line           int64
+}
+
+type pprofFunction struct {
+	name, filename, startLine int64
+}
+
+// Convert a CDP CPU profile into the intermediate representation.
+func newPprof(p *Profile) *pprof {
+	pp := &pprof{
+		strings:       []string{""},
+		stringIDs:     map[string]int64{"": 0},
+		durationNanos: int64(p.EndTime-p.StartTime) * 1000,
+	}
+
+	// Locations, and the parent of each node.
+	parents := make(map[int64]int64)
+	functionIDs := make(map[pprofFunction]uint64)
+	for _, n := range p.Nodes {
+		for _, c := range n.Children {
+			parents[c] = n.ID
+		}
+		f := pprofFunction{
+			name:      pp.stringID(n.CallFrame.FunctionName),
+			filename:  pp.stringID(n.CallFrame.URL),
+			startLine: n.CallFrame.LineNumber + 1,
+		}
+		if n.CallFrame.FunctionName == "" {
+			f.name = pp.stringID("(anonymous)")
+		}
+		id, ok := functionIDs[f]
+		if !ok {
+			pp.functions = append(pp.functions, f)
+			id = uint64(len(pp.functions))
+			functionIDs[f] = id
+		}
+		pp.locations = append(pp.locations, pprofLocation{
+			id: uint64(n.ID), functionID: id, line: n.CallFrame.LineNumber + 1,
+		})
+	}
+
+	// Aggregate samples by node. Each sample's time delta is
+	// the time which elapsed since the previous sample.
+	counts := make(map[int64]int64)
+	nanos := make(map[int64]int64)
+	for i, id := range p.Samples {
+		counts[id]++
+		if i < len(p.TimeDeltas) {
+			nanos[id] += p.TimeDeltas[i] * 1000
+		}
+	}
+	if len(p.Samples) == 0 {
+		// Older browsers report only hit counts.
+		for _, n := range p.Nodes {
+			counts[n.ID] += n.HitCount
+		}
+	}
+	total := int64(0)
+	for _, c := range counts {
+		total += c
+	}
+	if total > 0 {
+		pp.periodNanos = pp.durationNanos / total
+	}
+
+	for _, n := range p.Nodes {
+		c := counts[n.ID]
+		if c == 0 {
+			continue
+		}
+		s := pprofSample{count: c, nanos: nanos[n.ID]}
+		if len(p.Samples) == 0 {
+			s.nanos = c * pp.periodNanos
+		}
+		for id, ok := n.ID, true; ok; id, ok = parents[id] {
+			s.locationIDs = append(s.locationIDs, uint64(id))
+		}
+		// Omit the synthetic root node.
+		if len(s.locationIDs) > 1 {
+			s.locationIDs = s.locationIDs[:len(s.locationIDs)-1]
+		}
+		pp.samples = append(pp.samples, s)
+	}
+	return pp
+}
+
+func (pp *pprof) stringID(s string) int64 {
+	id, ok := pp.stringIDs[s]
+	if !ok {
+		id = int64(len(pp.strings))
+		pp.strings = append(pp.strings, s)
+		pp.stringIDs[s] = id
+	}
+	return id
+}
+
+// Encode the profile as an uncompressed protocol buffer message.
+func (pp *pprof) encode() []byte {
+	valueType := func(typ, unit string) []byte {
+		var b protoBuffer
+		b.int64Field(1, pp.stringID(typ))
+		b.int64Field(2, pp.stringID(unit))
+		return b
+	}
+
+	var b protoBuffer
+	b.bytesField(1, valueType("samples", "count"))
+	b.bytesField(1, valueType("cpu", "nanoseconds"))
+	for _, s := range pp.samples {
+		var sb protoBuffer
+		sb.packedField(1, s.locationIDs)
+		sb.packedField(2, []uint64{uint64(s.count), uint64(s.nanos)})
+		b.bytesField(2, sb)
+	}
+	for _, l := range pp.locations {
+		var line protoBuffer
+		line.int64Field(1, int64(l.functionID))
+		line.int64Field(2, l.line)
+		var lb protoBuffer
+		lb.int64Field(1, int64(l.id))
+		lb.bytesField(4, line)
+		b.bytesField(4, lb)
+	}
+	for i, f := range pp.functions {
+		var fb protoBuffer
+		fb.int64Field(1, int64(i+1))
+		fb.int64Field(2, f.name)
+		fb.int64Field(3, f.name)
+		fb.int64Field(4, f.filename)
+		fb.int64Field(5, f.startLine)
+		b.bytesField(5, fb)
+	}
+	period := valueType("cpu", "nanoseconds")
+	for _, s := range pp.strings {
+		b.bytesField(6, []byte(s))
+	}
+	b.int64Field(10, pp.durationNanos)
+	b.bytesField(11, period)
+	b.int64Field(12, pp.periodNanos)
+	return b
+}
+
+// Minimal protocol buffer encoder (https://developers.google.com/protocol-buffers/docs/encoding).
+type protoBuffer []byte
+
+func (b *protoBuffer) varint(v uint64) {
+	for v >= 0x80 {
+		*b = append(*b, byte(v)|0x80)
+		v >>= 7
+	}
+	*b = append(*b, byte(v))
+}
+
+// Append a varint field, unless it has the default value.
+func (b *protoBuffer) int64Field(tag int, v int64) {
+	if v == 0 {
+		return
+	}
+	b.varint(uint64(tag) << 3)
+	b.varint(uint64(v))
+}
+
+// Append a length-delimited field. Strings in a repeated field
+// must be appended even if they're empty.
+func (b *protoBuffer) bytesField(tag int, v []byte) {
+	b.varint(uint64(tag)<<3 | 2)
+	b.varint(uint64(len(v)))
+	*b = append(*b, v...)
+}
+
+// Append a packed repeated varint field.
+func (b *protoBuffer) packedField(tag int, vs []uint64) {
+	if len(vs) == 0 {
+		return
+	}
+	var p protoBuffer
+	for _, v := range vs {
+		p.varint(v)
+	}
+	b.bytesField(tag, p)
+}
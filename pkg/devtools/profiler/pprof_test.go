@@ -0,0 +1,83 @@
+package profiler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+func testProfile() *Profile {
+	return &Profile{
+		Nodes: []ProfileNode{
+			{ID: 1, CallFrame: runtime.CallFrame{FunctionName: "(root)"}, Children: []int64{2}},
+			{ID: 2, CallFrame: runtime.CallFrame{FunctionName: "main", URL: "https://example.com/app.js", LineNumber: 9}, Children: []int64{3, 4}},
+			{ID: 3, CallFrame: runtime.CallFrame{FunctionName: "", URL: "https://example.com/app.js", LineNumber: 19}},
+			{ID: 4, CallFrame: runtime.CallFrame{FunctionName: "(garbage collector)"}},
+		},
+		StartTime:  1000,
+		EndTime:    5000,
+		Samples:    []int64{3, 3, 2, 4},
+		TimeDeltas: []int64{1000, 1000, 1000, 1000},
+	}
+}
+
+func TestNewPprof(t *testing.T) {
+	// Set up.
+	pp := newPprof(testProfile())
+	// Test.
+	want := []pprofSample{
+		{locationIDs: []uint64{2}, count: 1, nanos: 1000000},
+		{locationIDs: []uint64{3, 2}, count: 2, nanos: 2000000},
+		{locationIDs: []uint64{4, 2}, count: 1, nanos: 1000000},
+	}
+	if !reflect.DeepEqual(pp.samples, want) {
+		t.Errorf("newPprof().samples = %+v, want %+v", pp.samples, want)
+	}
+	if len(pp.locations) != 4 || len(pp.functions) != 4 {
+		t.Errorf("newPprof() has %d locations and %d functions, want 4 and 4", len(pp.locations), len(pp.functions))
+	}
+	if got := pp.strings[pp.functions[2].name]; got != "(anonymous)" {
+		t.Errorf("newPprof().functions[2] name = %q, want %q", got, "(anonymous)")
+	}
+	if pp.durationNanos != 4000000 || pp.periodNanos != 1000000 {
+		t.Errorf("newPprof() duration, period = %d, %d, want 4000000, 1000000", pp.durationNanos, pp.periodNanos)
+	}
+}
+
+func TestProtoBuffer(t *testing.T) {
+	// Set up.
+	var b protoBuffer
+	b.int64Field(1, 150)
+	b.int64Field(2, 0)
+	b.bytesField(3, []byte("hi"))
+	b.packedField(4, []uint64{3, 270})
+	// Test.
+	want := []byte{0x08, 0x96, 0x01, 0x1a, 0x02, 'h', 'i', 0x22, 0x03, 0x03, 0x8e, 0x02}
+	if !bytes.Equal(b, want) {
+		t.Errorf("protoBuffer = % x, want % x", []byte(b), want)
+	}
+}
+
+func TestWritePprof(t *testing.T) {
+	// Set up.
+	var buf bytes.Buffer
+	if err := testProfile().WritePprof(&buf); err != nil {
+		t.Fatalf("WritePprof() error: %v", err)
+	}
+	// Test.
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("WritePprof() output isn't gzip-compressed: %v", err)
+	}
+	b, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b, []byte("https://example.com/app.js")) {
+		t.Errorf("WritePprof() output doesn't contain the script URL")
+	}
+}
@@ -0,0 +1,110 @@
+package devtools
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Network protocols which the browser is allowed or forced to use,
+// see the `devtools.ForceQUIC`, `devtools.DisableQUIC` and
+// `devtools.DisableHTTP2` session options.
+type protocolConfig struct {
+	forceQUIC    bool
+	quicOrigins  []string // "host:port".
+	disableQUIC  bool
+	disableHTTP2 bool
+}
+
+// ForceQUIC allows the caller of the `devtools.NewContext` function to enable
+// QUIC (HTTP/3) in the browser, and to use it for the given origins (e.g.
+// "example.com", "example.com:8443" or "https://example.com") from the first
+// request, without discovering it in "Alt-Svc" response headers, e.g. to test
+// HTTP/3 rollouts. Origins without ports use port 443. Requests to these
+// origins fail if the server doesn't support QUIC, instead of falling back
+// to TCP. Without origins, the browser uses QUIC only after discovering it.
+//
+// The negotiated protocol of each request is reported in the `Protocol` field
+// of `network.Response`, and by the `network.RecordProtocols` function.
+func ForceQUIC(origins ...string) SessionOption {
+	return func(s *Session) {
+		if s.protocols == nil {
+			s.protocols = &protocolConfig{}
+		}
+		s.protocols.forceQUIC, s.protocols.disableQUIC = true, false
+		for _, o := range origins {
+			s.protocols.quicOrigins = append(s.protocols.quicOrigins, quicOrigin(o))
+		}
+	}
+}
+
+// DisableQUIC allows the caller of the `devtools.NewContext` function to
+// prevent the browser from using QUIC (HTTP/3), even if servers advertise
+// it, e.g. to compare results with and without HTTP/3. It overrides
+// a previous `devtools.ForceQUIC` option.
+func DisableQUIC() SessionOption {
+	return func(s *Session) {
+		s.protocols = &protocolConfig{disableQUIC: true, disableHTTP2: s.protocols != nil && s.protocols.disableHTTP2}
+	}
+}
+
+// DisableHTTP2 allows the caller of the `devtools.NewContext` function to
+// prevent the browser from negotiating HTTP/2 over TCP, so it uses HTTP/1.1
+// unless QUIC is used (combine with `devtools.DisableQUIC` to force HTTP/1.1).
+func DisableHTTP2() SessionOption {
+	return func(s *Session) {
+		if s.protocols == nil {
+			s.protocols = &protocolConfig{}
+		}
+		s.protocols.disableHTTP2 = true
+	}
+}
+
+// Convert an origin in any of the formats which `devtools.ForceQUIC`
+// accepts to the "host:port" format of Chrome's "origin-to-force-quic-on" flag.
+func quicOrigin(origin string) string {
+	if strings.Contains(origin, "://") {
+		if u, err := url.Parse(origin); err == nil && u.Host != "" {
+			origin = u.Host
+		}
+	}
+	origin = strings.TrimSuffix(origin, "/")
+	if _, _, err := net.SplitHostPort(origin); err != nil {
+		origin = net.JoinHostPort(strings.Trim(origin, "[]"), "443")
+	}
+	return origin
+}
+
+// Adjust the browser flags before starting the browser, if the caller
+// specified any of the `devtools.ForceQUIC`, `devtools.DisableQUIC`
+// or `devtools.DisableHTTP2` session options.
+func adjustProtocolFlags(s *Session) {
+	p := s.protocols
+	if p.disableQUIC {
+		delete(s.browserFlags, "enable-quic")
+		delete(s.browserFlags, "origin-to-force-quic-on")
+		s.browserFlags["disable-quic"] = true
+	}
+	if p.forceQUIC {
+		delete(s.browserFlags, "disable-quic")
+		s.browserFlags["enable-quic"] = true
+		origins := append([]string(nil), p.quicOrigins...)
+		if v, ok := s.browserFlags["origin-to-force-quic-on"].(string); ok && v != "" {
+			origins = append(origins, strings.Split(v, ",")...)
+		}
+		if len(origins) > 0 {
+			sort.Strings(origins)
+			unique := origins[:1]
+			for _, o := range origins[1:] {
+				if o != unique[len(unique)-1] {
+					unique = append(unique, o)
+				}
+			}
+			s.browserFlags["origin-to-force-quic-on"] = strings.Join(unique, ",")
+		}
+	}
+	if p.disableHTTP2 {
+		s.browserFlags["disable-http2"] = true
+	}
+}
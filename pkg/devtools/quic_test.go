@@ -0,0 +1,52 @@
+package devtools
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAdjustProtocolFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]interface{}
+		opts  []SessionOption
+		want  map[string]interface{}
+	}{
+		{
+			name:  "force",
+			flags: map[string]interface{}{"disable-quic": true, "origin-to-force-quic-on": "a.com:443"},
+			opts:  []SessionOption{ForceQUIC("b.com", "https://a.com/", "c.com:8443", "[::1]")},
+			want: map[string]interface{}{
+				"enable-quic":             true,
+				"origin-to-force-quic-on": "[::1]:443,a.com:443,b.com:443,c.com:8443",
+			},
+		},
+		{
+			name: "force_without_origins",
+			opts: []SessionOption{ForceQUIC()},
+			want: map[string]interface{}{"enable-quic": true},
+		},
+		{
+			name:  "disable",
+			flags: map[string]interface{}{"enable-quic": true, "origin-to-force-quic-on": "a.com:443"},
+			opts:  []SessionOption{DisableHTTP2(), ForceQUIC("b.com"), DisableQUIC()},
+			want:  map[string]interface{}{"disable-quic": true, "disable-http2": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Session{browserFlags: map[string]interface{}{}}
+			for k, v := range tt.flags {
+				s.browserFlags[k] = v
+			}
+			for _, opt := range tt.opts {
+				opt(s)
+			}
+			adjustProtocolFlags(s)
+			if diff := cmp.Diff(tt.want, s.browserFlags); diff != "" {
+				t.Errorf("adjustProtocolFlags() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/websocket"
+)
+
+// RemoteTimeout is the maximum amount of time to wait for a remote browser
+// to respond to HTTP requests, and to close tabs when a session ends.
+const RemoteTimeout = 10 * time.Second
+
+// Details of a connection to a remote browser,
+// see the `devtools.RemoteBrowser` session option.
+type remoteBrowser struct {
+	addr   string
+	wsPath string
+
+	// Tabs opened through this connection, to close when the session ends.
+	mu      sync.Mutex
+	targets []string
+}
+
+// Copy of the relevant fields in the response to the HTTP endpoint
+// "/json/version" (https://chromedevtools.github.io/devtools-protocol/#endpoints).
+type versionInfo struct {
+	Browser              string `json:"Browser"`
+	WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+}
+
+// RemoteBrowser allows the caller of the `devtools.NewContext` function to
+// connect to an existing browser which listens for DevTools connections on
+// the given address ("host:port", e.g. a browser which was started with the
+// flag "--remote-debugging-port=9222"), instead of starting a new browser.
+//
+// Each session opens a new tab in the remote browser, and closes it when the
+// session's context is done. Closing the session with the `devtools.Close`
+// function doesn't close the remote browser itself.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser.
+func RemoteBrowser(addr string) SessionOption {
+	return func(s *Session) {
+		s.remote = &remoteBrowser{addr: addr}
+	}
+}
+
+// RemoteVersion returns the product name and version of the browser which
+// listens for DevTools connections on the given address ("host:port"),
+// e.g. for health checks of remote browsers.
+func RemoteVersion(ctx context.Context, addr string) (string, error) {
+	v, err := fetchVersion(ctx, addr)
+	if err != nil {
+		return "", err
+	}
+	return v.Browser, nil
+}
+
+func fetchVersion(ctx context.Context, addr string) (*versionInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, RemoteTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+"/json/version", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status from %s: %s", addr, resp.Status)
+	}
+	v := &versionInfo{}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Connect to a remote browser via WebSocket. This is the
+// equivalent of the `start` function in `browser.go`.
+func connect(ctx context.Context, s *Session) (err error) {
+	// If connecting fails, delete the unused output directory
+	// (unless it belongs to a resumed session).
+	defer func() {
+		if err != nil && s.resumeFrom == nil {
+			os.RemoveAll(s.OutputDir)
+		}
+	}()
+	v, err := fetchVersion(ctx, s.remote.addr)
+	if err != nil {
+		return fmt.Errorf("failed to query remote browser %s: %v", s.remote.addr, err)
+	}
+	u, err := url.Parse(v.WebSocketDebuggerURL)
+	if err != nil {
+		return fmt.Errorf("invalid WebSocket address of remote browser %s: %v", s.remote.addr, err)
+	}
+	log.Printf("Remote browser: %s (%s)", v.Browser, v.WebSocketDebuggerURL)
+	if strings.HasPrefix(v.Browser, "Firefox") {
+		s.firefox = true
+	}
+	// The browser may report a different host (e.g. "127.0.0.1"),
+	// so use only the path, with the address that we know works.
+	conn, err := websocket.Handshake(ctx, s.remote.addr, u.Path)
+	if err != nil {
+		return err
+	}
+	s.remote.wsPath = u.Path
+	conn.SetReadLimit(int64(s.maxMessageSize))
+	s.webSocket = conn
+	s.browserDone = make(chan struct{})
+	go func() {
+		receiveFromWebSocket(s)
+		// The connection is broken, e.g. the remote browser has ended.
+		s.cancel()
+	}()
+	return nil
+}
+
+// Remember a tab which was opened in a remote browser.
+func (r *remoteBrowser) addTarget(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.targets = append(r.targets, id)
+}
+
+// Wait for the session's context to be done, and then close all the tabs
+// which were opened through the remote connection, and release any resources
+// associated with it. This is the equivalent of the goroutine at the bottom
+// of the `start` function in `browser.go`, but for remote browsers.
+func disconnect(ctx context.Context, s *Session) {
+	<-ctx.Done()
+	s.remote.mu.Lock()
+	targets := s.remote.targets
+	s.remote.mu.Unlock()
+
+	// The session's context is already done, so use the message queue directly.
+	timer := time.NewTimer(RemoteTimeout)
+	defer timer.Stop()
+	closeTab := func(id string) bool {
+		params := fmt.Sprintf(`{"targetId":%q}`, id)
+		ch := make(chan *Message, 1)
+		// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-closeTarget
+		// (we don't use the target sub-package to avoid circular dependencies).
+		m := asyncMessage{
+			requestMsg:   Message{Method: "Target.closeTarget", Params: json.RawMessage(params)},
+			responseChan: ch,
+		}
+		select {
+		case s.msgQ <- m:
+		case <-timer.C:
+			return false
+		}
+		select {
+		case <-ch:
+			return true
+		case <-timer.C:
+			return false
+		}
+	}
+	for _, id := range targets {
+		if !closeTab(id) {
+			log.Printf("Timeout while closing remote tabs")
+			break
+		}
+	}
+
+	s.webSocket.Close(1000, []byte{})
+	close(s.msgQ)
+	closeMsgLog(s.msgLog)
+	close(s.browserDone)
+}
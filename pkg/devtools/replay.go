@@ -0,0 +1,131 @@
+package devtools
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ErrNoRecording is returned (wrapped) by CDP commands in replay contexts
+// (see the `devtools.NewReplayContext` function) when the recording doesn't
+// contain an unused response for the command.
+var ErrNoRecording = errors.New("no recorded response for CDP command")
+
+// A recorded command and its response, in JSON Lines format
+// (https://jsonlines.org/), see the `devtools.RecordCommands` option.
+type recording struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+	// Go errors of the transport, e.g. `devtools.ErrResponseTooLarge`.
+	Failure string `json:"failure,omitempty"`
+}
+
+// RecordCommands allows the caller of the `devtools.NewContext` function to
+// record all the CDP commands which are sent in the session, along with their
+// responses, by writing them to the given writer (e.g. a file), one JSON
+// object per line. The recording can be replayed without a browser with the
+// `devtools.NewReplayContext` function, e.g. in fast and deterministic unit
+// tests of automation code. Events are not recorded.
+//
+// This is implemented as a command middleware (see `devtools.CommandMiddleware`),
+// so it records the commands after the changes of middleware which is added
+// before it.
+func RecordCommands(w io.Writer) SessionOption {
+	var mu sync.Mutex
+	return CommandMiddleware(func(next CommandHandler) CommandHandler {
+		return func(ctx context.Context, method string, params json.RawMessage) (*Message, error) {
+			m, err := next(ctx, method, params)
+			r := &recording{Method: method, Params: params}
+			if err != nil {
+				r.Failure = err.Error()
+			} else {
+				r.Result, r.Error = m.Result, m.Error
+			}
+			if b, jsonErr := json.Marshal(r); jsonErr == nil {
+				mu.Lock()
+				w.Write(append(b, '\n'))
+				mu.Unlock()
+			}
+			return m, err
+		}
+	})
+}
+
+// replayer serves recorded responses, see `devtools.NewReplayContext`.
+type replayer struct {
+	mu         sync.Mutex
+	recordings []*recording
+	used       []bool
+}
+
+// NewReplayContext returns a copy of the parent context which carries a fake
+// CDP session, without a browser: all the commands which are sent in it are
+// answered with the responses which were recorded with the `devtools.RecordCommands`
+// option and read from the given reader, so automation code can be tested
+// quickly and deterministically.
+//
+// Each command is answered with the first unused recording of the same method,
+// preferring one with identical parameters. Commands without such a recording
+// fail with `devtools.ErrNoRecording` (wrapped). Events are never received.
+// Session options are applied too, e.g. command middleware, which wraps the
+// replaying. See also the `devtools.NewFakeContext` function.
+func NewReplayContext(parent context.Context, r io.Reader, opts ...SessionOption) (context.Context, error) {
+	rp := &replayer{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, DefaultMaxMessageSize)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		rec := &recording{}
+		if err := json.Unmarshal(scanner.Bytes(), rec); err != nil {
+			return parent, fmt.Errorf("invalid CDP recording #%d: %v", len(rp.recordings)+1, err)
+		}
+		rp.recordings = append(rp.recordings, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return parent, err
+	}
+	rp.used = make([]bool, len(rp.recordings))
+
+	return NewFakeContext(parent, rp.handle, opts...), nil
+}
+
+func (rp *replayer) handle(_ context.Context, method string, params json.RawMessage) (*Message, error) {
+	// Recorded parameters are compacted when they're written.
+	var compact bytes.Buffer
+	if json.Compact(&compact, params) == nil {
+		params = compact.Bytes()
+	}
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+	match := -1
+	for i, r := range rp.recordings {
+		if rp.used[i] || r.Method != method {
+			continue
+		}
+		if bytes.Equal(r.Params, params) {
+			match = i
+			break
+		}
+		if match < 0 {
+			match = i
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoRecording, method)
+	}
+	rp.used[match] = true
+	r := rp.recordings[match]
+	if r.Failure != "" {
+		return nil, errors.New(r.Failure)
+	}
+	return &Message{Result: r.Result, Error: r.Error}, nil
+}
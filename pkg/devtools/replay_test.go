@@ -0,0 +1,50 @@
+package devtools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	var log bytes.Buffer
+	ctx := fakeSession(t, RecordCommands(&log))
+	for _, p := range []string{`{"url":"a"}`, `{"url":"b"}`} {
+		if _, err := SendAndWait(ctx, "Page.navigate", []byte(p)); err != nil {
+			t.Fatalf("SendAndWait(); got error: %v", err)
+		}
+	}
+	if _, err := SendAndWait(ctx, "Page.enable", nil); err != nil {
+		t.Fatalf("SendAndWait(); got error: %v", err)
+	}
+	if n := strings.Count(log.String(), "\n"); n != 3 {
+		t.Fatalf("recorded %d lines, want 3:\n%s", n, log.String())
+	}
+
+	replay, err := NewReplayContext(context.Background(), &log)
+	if err != nil {
+		t.Fatalf("NewReplayContext(); got error: %v", err)
+	}
+	// Out of order, and with different formatting of the parameters.
+	tests := []struct {
+		method, params, want string
+	}{
+		{"Page.navigate", `{ "url": "b" }`, `{"method":"Page.navigate","params":{"url":"b"}}`},
+		{"Page.enable", "", `{"method":"Page.enable"}`},
+		{"Page.navigate", `{"url":"c"}`, `{"method":"Page.navigate","params":{"url":"a"}}`},
+	}
+	for _, tt := range tests {
+		m, err := SendAndWait(replay, tt.method, []byte(tt.params))
+		if err != nil {
+			t.Fatalf("replay SendAndWait(%s); got error: %v", tt.method, err)
+		}
+		if string(m.Result) != tt.want {
+			t.Errorf("replay SendAndWait(%s) = %s, want %s", tt.method, m.Result, tt.want)
+		}
+	}
+	if _, err := SendAndWait(replay, "Page.navigate", nil); !errors.Is(err, ErrNoRecording) {
+		t.Errorf("replay SendAndWait(exhausted) error = %v, want %v", err, ErrNoRecording)
+	}
+}
@@ -0,0 +1,161 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// DescriptorName is the name of the file in which the `SessionDescriptor.Save`
+// method stores session descriptors, in the session's output directory.
+const DescriptorName = "session.json"
+
+// ErrNotResumable is returned by the `devtools.Describe` function for sessions
+// which communicate with their browser through pipes, because the browser
+// can't be re-attached by another process, and it ends with the Go process.
+var ErrNotResumable = errors.New("CDP session uses pipes, see the devtools.Resumable option")
+
+// SessionDescriptor is the minimal serializable description of a CDP session,
+// which allows another process (e.g. a supervisor) to re-attach to a browser
+// that is still running after the Go process which started it has crashed,
+// instead of orphaning or killing it. See the `devtools.Describe` and
+// `devtools.Resume` functions.
+type SessionDescriptor struct {
+	// WebSocketURL is the browser's DevTools endpoint,
+	// e.g. "ws://127.0.0.1:9222/devtools/browser/<id>".
+	WebSocketURL string `json:"webSocketUrl"`
+	// TargetIDs are the IDs of the browser's tabs. The first
+	// one is the tab of the described session, if it still exists.
+	TargetIDs []string `json:"targetIds"`
+	// OutputDir and UserDataDir are the same as in `devtools.Session`.
+	OutputDir   string `json:"outputDir"`
+	UserDataDir string `json:"userDataDir,omitempty"`
+}
+
+// Resumable allows the caller of the `devtools.NewContext` function to
+// communicate with a locally-started browser over a WebSocket instead of
+// pipes (which is already the case on Windows, and with Firefox), so the
+// session can be described with the `devtools.Describe` function, and the
+// browser can outlive the Go process if it crashes. The browser is still
+// killed when the session's context is canceled.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser, and with the `devtools.RemoteBrowser` option
+// (which is always resumable).
+func Resumable() SessionOption {
+	return func(s *Session) {
+		s.resumable = true
+	}
+}
+
+// Describe returns the descriptor of the session associated with the given
+// context. It fails with `devtools.ErrNotResumable` (wrapped) if the session
+// communicates with its browser through pipes.
+func Describe(ctx context.Context) (*SessionDescriptor, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return nil, errNotInitialized
+	}
+	d := &SessionDescriptor{OutputDir: s.OutputDir, UserDataDir: s.UserDataDir}
+	switch {
+	case s.remote != nil:
+		d.WebSocketURL = "ws://" + s.remote.addr + s.remote.wsPath
+	case s.wsAddress != nil && s.wsPath.Read() != "":
+		d.WebSocketURL = "ws://" + s.wsAddress.Read() + s.wsPath.Read()
+	default:
+		return nil, fmt.Errorf("%w (output directory: %s)", ErrNotResumable, s.OutputDir)
+	}
+
+	// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-getTargets
+	// (we don't use the target sub-package to avoid circular dependencies).
+	result := &getTargetsResult{}
+	if err := sendAndParse(ctx, "Target.getTargets", nil, result); err != nil {
+		return nil, fmt.Errorf(`"Target.getTargets" command error: %v`, err)
+	}
+	d.TargetIDs = pageTargets(s.TargetID.Read(), result.TargetInfos)
+	return d, nil
+}
+
+// Return the IDs of the page targets, starting with the given one.
+func pageTargets(first string, infos []targetInfo) []string {
+	ids := []string{first}
+	for _, t := range infos {
+		if t.Type == "page" && t.TargetID != first {
+			ids = append(ids, t.TargetID)
+		}
+	}
+	return ids
+}
+
+// Save writes the descriptor as a JSON file in its output directory (see
+// `devtools.DescriptorName`), and returns the file's path. The file is
+// replaced atomically, so it's safe to save it again whenever the session
+// changes (e.g. after opening a tab), even if the process crashes while
+// doing so.
+func (d *SessionDescriptor) Save() (string, error) {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(d.OutputDir, DescriptorName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0644); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return "", err
+	}
+	return path, nil
+}
+
+// LoadDescriptor reads a session descriptor which was written by the
+// `SessionDescriptor.Save` method. The path may be the file itself,
+// or the output directory which contains it.
+func LoadDescriptor(path string) (*SessionDescriptor, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, DescriptorName)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	d := &SessionDescriptor{}
+	if err := json.Unmarshal(b, d); err != nil {
+		return nil, fmt.Errorf("invalid session descriptor %s: %v", path, err)
+	}
+	return d, nil
+}
+
+// Resume constructs a new `devtools.Session` which re-attaches to the first
+// tab in the given descriptor (reorder its `TargetIDs` to choose another one),
+// in a browser that is still running, and returns a copy of the parent context
+// which carries this session. It reuses the descriptor's output directory,
+// and appends to its log of CDP messages.
+//
+// The browser is treated like a remote browser (see the `devtools.RemoteBrowser`
+// option): canceling or closing the resumed session only disconnects from it,
+// and it doesn't close the resumed tab, so resuming is safe to retry. To end
+// the browser itself, send it the CDP command `Browser.close`.
+func Resume(parent context.Context, d *SessionDescriptor, opts ...SessionOption) (context.Context, error) {
+	if len(d.TargetIDs) == 0 {
+		return parent, errors.New("session descriptor doesn't contain any target IDs")
+	}
+	u, err := url.Parse(d.WebSocketURL)
+	if err != nil || u.Host == "" {
+		return parent, fmt.Errorf("invalid WebSocket URL in session descriptor: %q", d.WebSocketURL)
+	}
+	if _, ok := FromContext(parent); ok {
+		// Don't reuse the parent's browser.
+		parent = context.WithValue(parent, sessionKey{}, (*Session)(nil))
+	}
+	opts = append(opts, RemoteBrowser(u.Host), func(s *Session) {
+		s.resumeFrom = d
+		s.UserDataDir = d.UserDataDir
+	})
+	return NewContext(parent, opts...)
+}
@@ -0,0 +1,61 @@
+package devtools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPageTargets(t *testing.T) {
+	infos := []targetInfo{
+		{TargetID: "a", Type: "page"},
+		{TargetID: "b", Type: "service_worker"},
+		{TargetID: "c", Type: "page"},
+	}
+	want := []string{"c", "a"}
+	if diff := cmp.Diff(want, pageTargets("c", infos)); diff != "" {
+		t.Errorf("pageTargets() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestSaveAndLoadDescriptor(t *testing.T) {
+	want := &SessionDescriptor{
+		WebSocketURL: "ws://127.0.0.1:9222/devtools/browser/id",
+		TargetIDs:    []string{"a", "b"},
+		OutputDir:    t.TempDir(),
+	}
+	path, err := want.Save()
+	if err != nil {
+		t.Fatalf("Save(); got error: %v", err)
+	}
+	// Load by file path, and by directory path.
+	for _, p := range []string{path, want.OutputDir} {
+		got, err := LoadDescriptor(p)
+		if err != nil {
+			t.Fatalf("LoadDescriptor(%q); got error: %v", p, err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("LoadDescriptor(%q) mismatch (-want +got):\n%s", p, diff)
+		}
+	}
+}
+
+func TestDescribePipes(t *testing.T) {
+	ctx := context.WithValue(context.Background(), sessionKey{}, &Session{})
+	if _, err := Describe(ctx); !errors.Is(err, ErrNotResumable) {
+		t.Errorf("Describe() error = %v, want %v", err, ErrNotResumable)
+	}
+}
+
+func TestResumeInvalid(t *testing.T) {
+	for _, d := range []*SessionDescriptor{
+		{WebSocketURL: "ws://127.0.0.1:9222/devtools/browser/id"},
+		{WebSocketURL: "not a URL", TargetIDs: []string{"a"}},
+	} {
+		if _, err := Resume(context.Background(), d); err == nil {
+			t.Errorf("Resume(%+v); got nil error", d)
+		}
+	}
+}
@@ -25,15 +25,25 @@ type AwaitPromise struct {
 
 // NewAwaitPromise constructs a new AwaitPromise struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `AwaitPromiseOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#method-awaitPromise
-func NewAwaitPromise(promiseObjectID string) *AwaitPromise {
-	return &AwaitPromise{
+func NewAwaitPromise(promiseObjectID string, opts ...AwaitPromiseOption) *AwaitPromise {
+	c := &AwaitPromise{
 		PromiseObjectID: promiseObjectID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// AwaitPromiseOption is a functional option for the optional
+// parameters of the AwaitPromise CDP command, see the
+// NewAwaitPromise constructor. Options are applied in order.
+type AwaitPromiseOption = func(*AwaitPromise)
+
 // SetReturnByValue adds or modifies the value of the optional
 // parameter `returnByValue` in the AwaitPromise CDP command.
 //
@@ -43,6 +53,14 @@ func (t *AwaitPromise) SetReturnByValue(v bool) *AwaitPromise {
 	return t
 }
 
+// AwaitPromiseWithReturnByValue is a functional option which sets the value of the
+// optional parameter `returnByValue` in the AwaitPromise CDP command, see SetReturnByValue.
+func AwaitPromiseWithReturnByValue(v bool) AwaitPromiseOption {
+	return func(c *AwaitPromise) {
+		c.SetReturnByValue(v)
+	}
+}
+
 // SetGeneratePreview adds or modifies the value of the optional
 // parameter `generatePreview` in the AwaitPromise CDP command.
 //
@@ -52,6 +70,14 @@ func (t *AwaitPromise) SetGeneratePreview(v bool) *AwaitPromise {
 	return t
 }
 
+// AwaitPromiseWithGeneratePreview is a functional option which sets the value of the
+// optional parameter `generatePreview` in the AwaitPromise CDP command, see SetGeneratePreview.
+func AwaitPromiseWithGeneratePreview(v bool) AwaitPromiseOption {
+	return func(c *AwaitPromise) {
+		c.SetGeneratePreview(v)
+	}
+}
+
 // AwaitPromiseResult contains the browser's response
 // to calling the AwaitPromise CDP command with Do().
 type AwaitPromiseResult struct {
@@ -144,15 +170,25 @@ type CallFunctionOn struct {
 
 // NewCallFunctionOn constructs a new CallFunctionOn struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `CallFunctionOnOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#method-callFunctionOn
-func NewCallFunctionOn(functionDeclaration string) *CallFunctionOn {
-	return &CallFunctionOn{
+func NewCallFunctionOn(functionDeclaration string, opts ...CallFunctionOnOption) *CallFunctionOn {
+	c := &CallFunctionOn{
 		FunctionDeclaration: functionDeclaration,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// CallFunctionOnOption is a functional option for the optional
+// parameters of the CallFunctionOn CDP command, see the
+// NewCallFunctionOn constructor. Options are applied in order.
+type CallFunctionOnOption = func(*CallFunctionOn)
+
 // SetObjectID adds or modifies the value of the optional
 // parameter `objectId` in the CallFunctionOn CDP command.
 //
@@ -163,6 +199,14 @@ func (t *CallFunctionOn) SetObjectID(v string) *CallFunctionOn {
 	return t
 }
 
+// CallFunctionOnWithObjectID is a functional option which sets the value of the
+// optional parameter `objectId` in the CallFunctionOn CDP command, see SetObjectID.
+func CallFunctionOnWithObjectID(v string) CallFunctionOnOption {
+	return func(c *CallFunctionOn) {
+		c.SetObjectID(v)
+	}
+}
+
 // SetArguments adds or modifies the value of the optional
 // parameter `arguments` in the CallFunctionOn CDP command.
 //
@@ -173,6 +217,14 @@ func (t *CallFunctionOn) SetArguments(v []CallArgument) *CallFunctionOn {
 	return t
 }
 
+// CallFunctionOnWithArguments is a functional option which sets the value of the
+// optional parameter `arguments` in the CallFunctionOn CDP command, see SetArguments.
+func CallFunctionOnWithArguments(v []CallArgument) CallFunctionOnOption {
+	return func(c *CallFunctionOn) {
+		c.SetArguments(v)
+	}
+}
+
 // SetSilent adds or modifies the value of the optional
 // parameter `silent` in the CallFunctionOn CDP command.
 //
@@ -183,6 +235,14 @@ func (t *CallFunctionOn) SetSilent(v bool) *CallFunctionOn {
 	return t
 }
 
+// CallFunctionOnWithSilent is a functional option which sets the value of the
+// optional parameter `silent` in the CallFunctionOn CDP command, see SetSilent.
+func CallFunctionOnWithSilent(v bool) CallFunctionOnOption {
+	return func(c *CallFunctionOn) {
+		c.SetSilent(v)
+	}
+}
+
 // SetReturnByValue adds or modifies the value of the optional
 // parameter `returnByValue` in the CallFunctionOn CDP command.
 //
@@ -192,6 +252,14 @@ func (t *CallFunctionOn) SetReturnByValue(v bool) *CallFunctionOn {
 	return t
 }
 
+// CallFunctionOnWithReturnByValue is a functional option which sets the value of the
+// optional parameter `returnByValue` in the CallFunctionOn CDP command, see SetReturnByValue.
+func CallFunctionOnWithReturnByValue(v bool) CallFunctionOnOption {
+	return func(c *CallFunctionOn) {
+		c.SetReturnByValue(v)
+	}
+}
+
 // SetGeneratePreview adds or modifies the value of the optional
 // parameter `generatePreview` in the CallFunctionOn CDP command.
 //
@@ -203,6 +271,14 @@ func (t *CallFunctionOn) SetGeneratePreview(v bool) *CallFunctionOn {
 	return t
 }
 
+// CallFunctionOnWithGeneratePreview is a functional option which sets the value of the
+// optional parameter `generatePreview` in the CallFunctionOn CDP command, see SetGeneratePreview.
+func CallFunctionOnWithGeneratePreview(v bool) CallFunctionOnOption {
+	return func(c *CallFunctionOn) {
+		c.SetGeneratePreview(v)
+	}
+}
+
 // SetUserGesture adds or modifies the value of the optional
 // parameter `userGesture` in the CallFunctionOn CDP command.
 //
@@ -212,6 +288,14 @@ func (t *CallFunctionOn) SetUserGesture(v bool) *CallFunctionOn {
 	return t
 }
 
+// CallFunctionOnWithUserGesture is a functional option which sets the value of the
+// optional parameter `userGesture` in the CallFunctionOn CDP command, see SetUserGesture.
+func CallFunctionOnWithUserGesture(v bool) CallFunctionOnOption {
+	return func(c *CallFunctionOn) {
+		c.SetUserGesture(v)
+	}
+}
+
 // SetAwaitPromise adds or modifies the value of the optional
 // parameter `awaitPromise` in the CallFunctionOn CDP command.
 //
@@ -222,6 +306,14 @@ func (t *CallFunctionOn) SetAwaitPromise(v bool) *CallFunctionOn {
 	return t
 }
 
+// CallFunctionOnWithAwaitPromise is a functional option which sets the value of the
+// optional parameter `awaitPromise` in the CallFunctionOn CDP command, see SetAwaitPromise.
+func CallFunctionOnWithAwaitPromise(v bool) CallFunctionOnOption {
+	return func(c *CallFunctionOn) {
+		c.SetAwaitPromise(v)
+	}
+}
+
 // SetExecutionContextID adds or modifies the value of the optional
 // parameter `executionContextId` in the CallFunctionOn CDP command.
 //
@@ -232,6 +324,14 @@ func (t *CallFunctionOn) SetExecutionContextID(v int64) *CallFunctionOn {
 	return t
 }
 
+// CallFunctionOnWithExecutionContextID is a functional option which sets the value of the
+// optional parameter `executionContextId` in the CallFunctionOn CDP command, see SetExecutionContextID.
+func CallFunctionOnWithExecutionContextID(v int64) CallFunctionOnOption {
+	return func(c *CallFunctionOn) {
+		c.SetExecutionContextID(v)
+	}
+}
+
 // SetObjectGroup adds or modifies the value of the optional
 // parameter `objectGroup` in the CallFunctionOn CDP command.
 //
@@ -242,6 +342,14 @@ func (t *CallFunctionOn) SetObjectGroup(v string) *CallFunctionOn {
 	return t
 }
 
+// CallFunctionOnWithObjectGroup is a functional option which sets the value of the
+// optional parameter `objectGroup` in the CallFunctionOn CDP command, see SetObjectGroup.
+func CallFunctionOnWithObjectGroup(v string) CallFunctionOnOption {
+	return func(c *CallFunctionOn) {
+		c.SetObjectGroup(v)
+	}
+}
+
 // SetThrowOnSideEffect adds or modifies the value of the optional
 // parameter `throwOnSideEffect` in the CallFunctionOn CDP command.
 //
@@ -253,6 +361,14 @@ func (t *CallFunctionOn) SetThrowOnSideEffect(v bool) *CallFunctionOn {
 	return t
 }
 
+// CallFunctionOnWithThrowOnSideEffect is a functional option which sets the value of the
+// optional parameter `throwOnSideEffect` in the CallFunctionOn CDP command, see SetThrowOnSideEffect.
+func CallFunctionOnWithThrowOnSideEffect(v bool) CallFunctionOnOption {
+	return func(c *CallFunctionOn) {
+		c.SetThrowOnSideEffect(v)
+	}
+}
+
 // CallFunctionOnResult contains the browser's response
 // to calling the CallFunctionOn CDP command with Do().
 type CallFunctionOnResult struct {
@@ -321,17 +437,27 @@ type CompileScript struct {
 
 // NewCompileScript constructs a new CompileScript struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `CompileScriptOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#method-compileScript
-func NewCompileScript(expression string, sourceURL string, persistScript bool) *CompileScript {
-	return &CompileScript{
+func NewCompileScript(expression string, sourceURL string, persistScript bool, opts ...CompileScriptOption) *CompileScript {
+	c := &CompileScript{
 		Expression:    expression,
 		SourceURL:     sourceURL,
 		PersistScript: persistScript,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// CompileScriptOption is a functional option for the optional
+// parameters of the CompileScript CDP command, see the
+// NewCompileScript constructor. Options are applied in order.
+type CompileScriptOption = func(*CompileScript)
+
 // SetExecutionContextID adds or modifies the value of the optional
 // parameter `executionContextId` in the CompileScript CDP command.
 //
@@ -342,6 +468,14 @@ func (t *CompileScript) SetExecutionContextID(v int64) *CompileScript {
 	return t
 }
 
+// CompileScriptWithExecutionContextID is a functional option which sets the value of the
+// optional parameter `executionContextId` in the CompileScript CDP command, see SetExecutionContextID.
+func CompileScriptWithExecutionContextID(v int64) CompileScriptOption {
+	return func(c *CompileScript) {
+		c.SetExecutionContextID(v)
+	}
+}
+
 // CompileScriptResult contains the browser's response
 // to calling the CompileScript CDP command with Do().
 type CompileScriptResult struct {
@@ -582,7 +716,7 @@ type Evaluate struct {
 	// evaluation and allows unsafe-eval. Defaults to true.
 	//
 	// This CDP parameter is experimental.
-	AllowUnsafeEvalBlockedByCSP bool `json:"allowUnsafeEvalBlockedByCSP,omitempty"`
+	AllowUnsafeEvalBlockedByCSP *bool `json:"allowUnsafeEvalBlockedByCSP,omitempty"`
 	// An alternative way to specify the execution context to evaluate in.
 	// Compared to contextId that may be reused across processes, this is guaranteed to be
 	// system-unique, so it can be used to prevent accidental evaluation of the expression
@@ -596,15 +730,25 @@ type Evaluate struct {
 
 // NewEvaluate constructs a new Evaluate struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `EvaluateOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#method-evaluate
-func NewEvaluate(expression string) *Evaluate {
-	return &Evaluate{
+func NewEvaluate(expression string, opts ...EvaluateOption) *Evaluate {
+	c := &Evaluate{
 		Expression: expression,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// EvaluateOption is a functional option for the optional
+// parameters of the Evaluate CDP command, see the
+// NewEvaluate constructor. Options are applied in order.
+type EvaluateOption = func(*Evaluate)
+
 // SetObjectGroup adds or modifies the value of the optional
 // parameter `objectGroup` in the Evaluate CDP command.
 //
@@ -614,6 +758,14 @@ func (t *Evaluate) SetObjectGroup(v string) *Evaluate {
 	return t
 }
 
+// EvaluateWithObjectGroup is a functional option which sets the value of the
+// optional parameter `objectGroup` in the Evaluate CDP command, see SetObjectGroup.
+func EvaluateWithObjectGroup(v string) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetObjectGroup(v)
+	}
+}
+
 // SetIncludeCommandLineAPI adds or modifies the value of the optional
 // parameter `includeCommandLineAPI` in the Evaluate CDP command.
 //
@@ -623,6 +775,14 @@ func (t *Evaluate) SetIncludeCommandLineAPI(v bool) *Evaluate {
 	return t
 }
 
+// EvaluateWithIncludeCommandLineAPI is a functional option which sets the value of the
+// optional parameter `includeCommandLineAPI` in the Evaluate CDP command, see SetIncludeCommandLineAPI.
+func EvaluateWithIncludeCommandLineAPI(v bool) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetIncludeCommandLineAPI(v)
+	}
+}
+
 // SetSilent adds or modifies the value of the optional
 // parameter `silent` in the Evaluate CDP command.
 //
@@ -633,6 +793,14 @@ func (t *Evaluate) SetSilent(v bool) *Evaluate {
 	return t
 }
 
+// EvaluateWithSilent is a functional option which sets the value of the
+// optional parameter `silent` in the Evaluate CDP command, see SetSilent.
+func EvaluateWithSilent(v bool) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetSilent(v)
+	}
+}
+
 // SetContextID adds or modifies the value of the optional
 // parameter `contextId` in the Evaluate CDP command.
 //
@@ -646,6 +814,14 @@ func (t *Evaluate) SetContextID(v int64) *Evaluate {
 	return t
 }
 
+// EvaluateWithContextID is a functional option which sets the value of the
+// optional parameter `contextId` in the Evaluate CDP command, see SetContextID.
+func EvaluateWithContextID(v int64) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetContextID(v)
+	}
+}
+
 // SetReturnByValue adds or modifies the value of the optional
 // parameter `returnByValue` in the Evaluate CDP command.
 //
@@ -655,6 +831,14 @@ func (t *Evaluate) SetReturnByValue(v bool) *Evaluate {
 	return t
 }
 
+// EvaluateWithReturnByValue is a functional option which sets the value of the
+// optional parameter `returnByValue` in the Evaluate CDP command, see SetReturnByValue.
+func EvaluateWithReturnByValue(v bool) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetReturnByValue(v)
+	}
+}
+
 // SetGeneratePreview adds or modifies the value of the optional
 // parameter `generatePreview` in the Evaluate CDP command.
 //
@@ -666,6 +850,14 @@ func (t *Evaluate) SetGeneratePreview(v bool) *Evaluate {
 	return t
 }
 
+// EvaluateWithGeneratePreview is a functional option which sets the value of the
+// optional parameter `generatePreview` in the Evaluate CDP command, see SetGeneratePreview.
+func EvaluateWithGeneratePreview(v bool) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetGeneratePreview(v)
+	}
+}
+
 // SetUserGesture adds or modifies the value of the optional
 // parameter `userGesture` in the Evaluate CDP command.
 //
@@ -675,6 +867,14 @@ func (t *Evaluate) SetUserGesture(v bool) *Evaluate {
 	return t
 }
 
+// EvaluateWithUserGesture is a functional option which sets the value of the
+// optional parameter `userGesture` in the Evaluate CDP command, see SetUserGesture.
+func EvaluateWithUserGesture(v bool) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetUserGesture(v)
+	}
+}
+
 // SetAwaitPromise adds or modifies the value of the optional
 // parameter `awaitPromise` in the Evaluate CDP command.
 //
@@ -685,6 +885,14 @@ func (t *Evaluate) SetAwaitPromise(v bool) *Evaluate {
 	return t
 }
 
+// EvaluateWithAwaitPromise is a functional option which sets the value of the
+// optional parameter `awaitPromise` in the Evaluate CDP command, see SetAwaitPromise.
+func EvaluateWithAwaitPromise(v bool) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetAwaitPromise(v)
+	}
+}
+
 // SetThrowOnSideEffect adds or modifies the value of the optional
 // parameter `throwOnSideEffect` in the Evaluate CDP command.
 //
@@ -697,6 +905,14 @@ func (t *Evaluate) SetThrowOnSideEffect(v bool) *Evaluate {
 	return t
 }
 
+// EvaluateWithThrowOnSideEffect is a functional option which sets the value of the
+// optional parameter `throwOnSideEffect` in the Evaluate CDP command, see SetThrowOnSideEffect.
+func EvaluateWithThrowOnSideEffect(v bool) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetThrowOnSideEffect(v)
+	}
+}
+
 // SetTimeout adds or modifies the value of the optional
 // parameter `timeout` in the Evaluate CDP command.
 //
@@ -708,6 +924,14 @@ func (t *Evaluate) SetTimeout(v float64) *Evaluate {
 	return t
 }
 
+// EvaluateWithTimeout is a functional option which sets the value of the
+// optional parameter `timeout` in the Evaluate CDP command, see SetTimeout.
+func EvaluateWithTimeout(v float64) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetTimeout(v)
+	}
+}
+
 // SetDisableBreaks adds or modifies the value of the optional
 // parameter `disableBreaks` in the Evaluate CDP command.
 //
@@ -719,6 +943,14 @@ func (t *Evaluate) SetDisableBreaks(v bool) *Evaluate {
 	return t
 }
 
+// EvaluateWithDisableBreaks is a functional option which sets the value of the
+// optional parameter `disableBreaks` in the Evaluate CDP command, see SetDisableBreaks.
+func EvaluateWithDisableBreaks(v bool) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetDisableBreaks(v)
+	}
+}
+
 // SetReplMode adds or modifies the value of the optional
 // parameter `replMode` in the Evaluate CDP command.
 //
@@ -732,6 +964,14 @@ func (t *Evaluate) SetReplMode(v bool) *Evaluate {
 	return t
 }
 
+// EvaluateWithReplMode is a functional option which sets the value of the
+// optional parameter `replMode` in the Evaluate CDP command, see SetReplMode.
+func EvaluateWithReplMode(v bool) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetReplMode(v)
+	}
+}
+
 // SetAllowUnsafeEvalBlockedByCSP adds or modifies the value of the optional
 // parameter `allowUnsafeEvalBlockedByCSP` in the Evaluate CDP command.
 //
@@ -742,10 +982,18 @@ func (t *Evaluate) SetReplMode(v bool) *Evaluate {
 //
 // This CDP parameter is experimental.
 func (t *Evaluate) SetAllowUnsafeEvalBlockedByCSP(v bool) *Evaluate {
-	t.AllowUnsafeEvalBlockedByCSP = v
+	t.AllowUnsafeEvalBlockedByCSP = &v
 	return t
 }
 
+// EvaluateWithAllowUnsafeEvalBlockedByCSP is a functional option which sets the value of the
+// optional parameter `allowUnsafeEvalBlockedByCSP` in the Evaluate CDP command, see SetAllowUnsafeEvalBlockedByCSP.
+func EvaluateWithAllowUnsafeEvalBlockedByCSP(v bool) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetAllowUnsafeEvalBlockedByCSP(v)
+	}
+}
+
 // SetUniqueContextID adds or modifies the value of the optional
 // parameter `uniqueContextId` in the Evaluate CDP command.
 //
@@ -762,6 +1010,14 @@ func (t *Evaluate) SetUniqueContextID(v string) *Evaluate {
 	return t
 }
 
+// EvaluateWithUniqueContextID is a functional option which sets the value of the
+// optional parameter `uniqueContextId` in the Evaluate CDP command, see SetUniqueContextID.
+func EvaluateWithUniqueContextID(v string) EvaluateOption {
+	return func(c *Evaluate) {
+		c.SetUniqueContextID(v)
+	}
+}
+
 // EvaluateResult contains the browser's response
 // to calling the Evaluate CDP command with Do().
 type EvaluateResult struct {
@@ -961,15 +1217,25 @@ type GetProperties struct {
 
 // NewGetProperties constructs a new GetProperties struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetPropertiesOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#method-getProperties
-func NewGetProperties(objectID string) *GetProperties {
-	return &GetProperties{
+func NewGetProperties(objectID string, opts ...GetPropertiesOption) *GetProperties {
+	c := &GetProperties{
 		ObjectID: objectID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetPropertiesOption is a functional option for the optional
+// parameters of the GetProperties CDP command, see the
+// NewGetProperties constructor. Options are applied in order.
+type GetPropertiesOption = func(*GetProperties)
+
 // SetOwnProperties adds or modifies the value of the optional
 // parameter `ownProperties` in the GetProperties CDP command.
 //
@@ -980,6 +1246,14 @@ func (t *GetProperties) SetOwnProperties(v bool) *GetProperties {
 	return t
 }
 
+// GetPropertiesWithOwnProperties is a functional option which sets the value of the
+// optional parameter `ownProperties` in the GetProperties CDP command, see SetOwnProperties.
+func GetPropertiesWithOwnProperties(v bool) GetPropertiesOption {
+	return func(c *GetProperties) {
+		c.SetOwnProperties(v)
+	}
+}
+
 // SetAccessorPropertiesOnly adds or modifies the value of the optional
 // parameter `accessorPropertiesOnly` in the GetProperties CDP command.
 //
@@ -992,6 +1266,14 @@ func (t *GetProperties) SetAccessorPropertiesOnly(v bool) *GetProperties {
 	return t
 }
 
+// GetPropertiesWithAccessorPropertiesOnly is a functional option which sets the value of the
+// optional parameter `accessorPropertiesOnly` in the GetProperties CDP command, see SetAccessorPropertiesOnly.
+func GetPropertiesWithAccessorPropertiesOnly(v bool) GetPropertiesOption {
+	return func(c *GetProperties) {
+		c.SetAccessorPropertiesOnly(v)
+	}
+}
+
 // SetGeneratePreview adds or modifies the value of the optional
 // parameter `generatePreview` in the GetProperties CDP command.
 //
@@ -1003,6 +1285,14 @@ func (t *GetProperties) SetGeneratePreview(v bool) *GetProperties {
 	return t
 }
 
+// GetPropertiesWithGeneratePreview is a functional option which sets the value of the
+// optional parameter `generatePreview` in the GetProperties CDP command, see SetGeneratePreview.
+func GetPropertiesWithGeneratePreview(v bool) GetPropertiesOption {
+	return func(c *GetProperties) {
+		c.SetGeneratePreview(v)
+	}
+}
+
 // SetNonIndexedPropertiesOnly adds or modifies the value of the optional
 // parameter `nonIndexedPropertiesOnly` in the GetProperties CDP command.
 //
@@ -1014,6 +1304,14 @@ func (t *GetProperties) SetNonIndexedPropertiesOnly(v bool) *GetProperties {
 	return t
 }
 
+// GetPropertiesWithNonIndexedPropertiesOnly is a functional option which sets the value of the
+// optional parameter `nonIndexedPropertiesOnly` in the GetProperties CDP command, see SetNonIndexedPropertiesOnly.
+func GetPropertiesWithNonIndexedPropertiesOnly(v bool) GetPropertiesOption {
+	return func(c *GetProperties) {
+		c.SetNonIndexedPropertiesOnly(v)
+	}
+}
+
 // GetPropertiesResult contains the browser's response
 // to calling the GetProperties CDP command with Do().
 type GetPropertiesResult struct {
@@ -1081,13 +1379,23 @@ type GlobalLexicalScopeNames struct {
 
 // NewGlobalLexicalScopeNames constructs a new GlobalLexicalScopeNames struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GlobalLexicalScopeNamesOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#method-globalLexicalScopeNames
-func NewGlobalLexicalScopeNames() *GlobalLexicalScopeNames {
-	return &GlobalLexicalScopeNames{}
+func NewGlobalLexicalScopeNames(opts ...GlobalLexicalScopeNamesOption) *GlobalLexicalScopeNames {
+	c := &GlobalLexicalScopeNames{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GlobalLexicalScopeNamesOption is a functional option for the optional
+// parameters of the GlobalLexicalScopeNames CDP command, see the
+// NewGlobalLexicalScopeNames constructor. Options are applied in order.
+type GlobalLexicalScopeNamesOption = func(*GlobalLexicalScopeNames)
+
 // SetExecutionContextID adds or modifies the value of the optional
 // parameter `executionContextId` in the GlobalLexicalScopeNames CDP command.
 //
@@ -1097,6 +1405,14 @@ func (t *GlobalLexicalScopeNames) SetExecutionContextID(v int64) *GlobalLexicalS
 	return t
 }
 
+// GlobalLexicalScopeNamesWithExecutionContextID is a functional option which sets the value of the
+// optional parameter `executionContextId` in the GlobalLexicalScopeNames CDP command, see SetExecutionContextID.
+func GlobalLexicalScopeNamesWithExecutionContextID(v int64) GlobalLexicalScopeNamesOption {
+	return func(c *GlobalLexicalScopeNames) {
+		c.SetExecutionContextID(v)
+	}
+}
+
 // GlobalLexicalScopeNamesResult contains the browser's response
 // to calling the GlobalLexicalScopeNames CDP command with Do().
 type GlobalLexicalScopeNamesResult struct {
@@ -1155,15 +1471,25 @@ type QueryObjects struct {
 
 // NewQueryObjects constructs a new QueryObjects struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `QueryObjectsOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#method-queryObjects
-func NewQueryObjects(prototypeObjectID string) *QueryObjects {
-	return &QueryObjects{
+func NewQueryObjects(prototypeObjectID string, opts ...QueryObjectsOption) *QueryObjects {
+	c := &QueryObjects{
 		PrototypeObjectID: prototypeObjectID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// QueryObjectsOption is a functional option for the optional
+// parameters of the QueryObjects CDP command, see the
+// NewQueryObjects constructor. Options are applied in order.
+type QueryObjectsOption = func(*QueryObjects)
+
 // SetObjectGroup adds or modifies the value of the optional
 // parameter `objectGroup` in the QueryObjects CDP command.
 //
@@ -1173,6 +1499,14 @@ func (t *QueryObjects) SetObjectGroup(v string) *QueryObjects {
 	return t
 }
 
+// QueryObjectsWithObjectGroup is a functional option which sets the value of the
+// optional parameter `objectGroup` in the QueryObjects CDP command, see SetObjectGroup.
+func QueryObjectsWithObjectGroup(v string) QueryObjectsOption {
+	return func(c *QueryObjects) {
+		c.SetObjectGroup(v)
+	}
+}
+
 // QueryObjectsResult contains the browser's response
 // to calling the QueryObjects CDP command with Do().
 type QueryObjectsResult struct {
@@ -1407,15 +1741,25 @@ type RunScript struct {
 
 // NewRunScript constructs a new RunScript struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `RunScriptOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#method-runScript
-func NewRunScript(scriptID string) *RunScript {
-	return &RunScript{
+func NewRunScript(scriptID string, opts ...RunScriptOption) *RunScript {
+	c := &RunScript{
 		ScriptID: scriptID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// RunScriptOption is a functional option for the optional
+// parameters of the RunScript CDP command, see the
+// NewRunScript constructor. Options are applied in order.
+type RunScriptOption = func(*RunScript)
+
 // SetExecutionContextID adds or modifies the value of the optional
 // parameter `executionContextId` in the RunScript CDP command.
 //
@@ -1426,6 +1770,14 @@ func (t *RunScript) SetExecutionContextID(v int64) *RunScript {
 	return t
 }
 
+// RunScriptWithExecutionContextID is a functional option which sets the value of the
+// optional parameter `executionContextId` in the RunScript CDP command, see SetExecutionContextID.
+func RunScriptWithExecutionContextID(v int64) RunScriptOption {
+	return func(c *RunScript) {
+		c.SetExecutionContextID(v)
+	}
+}
+
 // SetObjectGroup adds or modifies the value of the optional
 // parameter `objectGroup` in the RunScript CDP command.
 //
@@ -1435,6 +1787,14 @@ func (t *RunScript) SetObjectGroup(v string) *RunScript {
 	return t
 }
 
+// RunScriptWithObjectGroup is a functional option which sets the value of the
+// optional parameter `objectGroup` in the RunScript CDP command, see SetObjectGroup.
+func RunScriptWithObjectGroup(v string) RunScriptOption {
+	return func(c *RunScript) {
+		c.SetObjectGroup(v)
+	}
+}
+
 // SetSilent adds or modifies the value of the optional
 // parameter `silent` in the RunScript CDP command.
 //
@@ -1445,6 +1805,14 @@ func (t *RunScript) SetSilent(v bool) *RunScript {
 	return t
 }
 
+// RunScriptWithSilent is a functional option which sets the value of the
+// optional parameter `silent` in the RunScript CDP command, see SetSilent.
+func RunScriptWithSilent(v bool) RunScriptOption {
+	return func(c *RunScript) {
+		c.SetSilent(v)
+	}
+}
+
 // SetIncludeCommandLineAPI adds or modifies the value of the optional
 // parameter `includeCommandLineAPI` in the RunScript CDP command.
 //
@@ -1454,6 +1822,14 @@ func (t *RunScript) SetIncludeCommandLineAPI(v bool) *RunScript {
 	return t
 }
 
+// RunScriptWithIncludeCommandLineAPI is a functional option which sets the value of the
+// optional parameter `includeCommandLineAPI` in the RunScript CDP command, see SetIncludeCommandLineAPI.
+func RunScriptWithIncludeCommandLineAPI(v bool) RunScriptOption {
+	return func(c *RunScript) {
+		c.SetIncludeCommandLineAPI(v)
+	}
+}
+
 // SetReturnByValue adds or modifies the value of the optional
 // parameter `returnByValue` in the RunScript CDP command.
 //
@@ -1463,6 +1839,14 @@ func (t *RunScript) SetReturnByValue(v bool) *RunScript {
 	return t
 }
 
+// RunScriptWithReturnByValue is a functional option which sets the value of the
+// optional parameter `returnByValue` in the RunScript CDP command, see SetReturnByValue.
+func RunScriptWithReturnByValue(v bool) RunScriptOption {
+	return func(c *RunScript) {
+		c.SetReturnByValue(v)
+	}
+}
+
 // SetGeneratePreview adds or modifies the value of the optional
 // parameter `generatePreview` in the RunScript CDP command.
 //
@@ -1472,6 +1856,14 @@ func (t *RunScript) SetGeneratePreview(v bool) *RunScript {
 	return t
 }
 
+// RunScriptWithGeneratePreview is a functional option which sets the value of the
+// optional parameter `generatePreview` in the RunScript CDP command, see SetGeneratePreview.
+func RunScriptWithGeneratePreview(v bool) RunScriptOption {
+	return func(c *RunScript) {
+		c.SetGeneratePreview(v)
+	}
+}
+
 // SetAwaitPromise adds or modifies the value of the optional
 // parameter `awaitPromise` in the RunScript CDP command.
 //
@@ -1482,6 +1874,14 @@ func (t *RunScript) SetAwaitPromise(v bool) *RunScript {
 	return t
 }
 
+// RunScriptWithAwaitPromise is a functional option which sets the value of the
+// optional parameter `awaitPromise` in the RunScript CDP command, see SetAwaitPromise.
+func RunScriptWithAwaitPromise(v bool) RunScriptOption {
+	return func(c *RunScript) {
+		c.SetAwaitPromise(v)
+	}
+}
+
 // RunScriptResult contains the browser's response
 // to calling the RunScript CDP command with Do().
 type RunScriptResult struct {
@@ -1732,17 +2132,27 @@ type AddBinding struct {
 
 // NewAddBinding constructs a new AddBinding struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `AddBindingOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Runtime/#method-addBinding
 //
 // This CDP method is experimental.
-func NewAddBinding(name string) *AddBinding {
-	return &AddBinding{
+func NewAddBinding(name string, opts ...AddBindingOption) *AddBinding {
+	c := &AddBinding{
 		Name: name,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// AddBindingOption is a functional option for the optional
+// parameters of the AddBinding CDP command, see the
+// NewAddBinding constructor. Options are applied in order.
+type AddBindingOption = func(*AddBinding)
+
 // SetExecutionContextID adds or modifies the value of the optional
 // parameter `executionContextId` in the AddBinding CDP command.
 //
@@ -1760,6 +2170,14 @@ func (t *AddBinding) SetExecutionContextID(v int64) *AddBinding {
 	return t
 }
 
+// AddBindingWithExecutionContextID is a functional option which sets the value of the
+// optional parameter `executionContextId` in the AddBinding CDP command, see SetExecutionContextID.
+func AddBindingWithExecutionContextID(v int64) AddBindingOption {
+	return func(c *AddBinding) {
+		c.SetExecutionContextID(v)
+	}
+}
+
 // SetExecutionContextName adds or modifies the value of the optional
 // parameter `executionContextName` in the AddBinding CDP command.
 //
@@ -1775,6 +2193,14 @@ func (t *AddBinding) SetExecutionContextName(v string) *AddBinding {
 	return t
 }
 
+// AddBindingWithExecutionContextName is a functional option which sets the value of the
+// optional parameter `executionContextName` in the AddBinding CDP command, see SetExecutionContextName.
+func AddBindingWithExecutionContextName(v string) AddBindingOption {
+	return func(c *AddBinding) {
+		c.SetExecutionContextName(v)
+	}
+}
+
 // Do sends the AddBinding CDP command to a browser,
 // and returns the browser's response.
 func (t *AddBinding) Do(ctx context.Context) error {
@@ -0,0 +1,47 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Error satisfies the Go error interface (https://golang.org/pkg/builtin/#error),
+// so JavaScript exceptions can be returned as Go errors.
+func (e *ExceptionDetails) Error() string {
+	msg := e.Text
+	if e.Exception != nil && e.Exception.Description != "" {
+		msg = fmt.Sprintf("%s %s", msg, e.Exception.Description)
+	}
+	if e.URL != "" {
+		return fmt.Sprintf("%s (%s:%d:%d)", msg, e.URL, e.LineNumber+1, e.ColumnNumber+1)
+	}
+	return msg
+}
+
+// Eval evaluates the given JavaScript expression in the main frame of the
+// page associated with the given context, waits for the result if it's a
+// promise, and parses its JSON value into v (unless v is nil).
+//
+// JavaScript exceptions are returned as `*runtime.ExceptionDetails` errors.
+// This is a convenience wrapper of the `Evaluate` CDP command, for
+// expressions which return JSON-serializable values.
+func Eval(ctx context.Context, expression string, v interface{}) error {
+	e := NewEvaluate(expression).SetReturnByValue(true).SetAwaitPromise(true)
+	r, err := e.Do(ctx)
+	if err != nil {
+		return err
+	}
+	return parseValue(&r.Result, r.ExceptionDetails, v)
+}
+
+// Parse the result of an `Evaluate` or `CallFunctionOn` CDP command.
+func parseValue(r *RemoteObject, d *ExceptionDetails, v interface{}) error {
+	if d != nil {
+		return d
+	}
+	if v == nil || r.Type == "undefined" || len(r.Value) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r.Value, v)
+}
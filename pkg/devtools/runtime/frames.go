@@ -0,0 +1,258 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// FrameResult is the result of evaluating an expression in a single frame,
+// see the `runtime.EvalInAllFrames` function.
+type FrameResult struct {
+	FrameID string
+	URL     string
+	// JSON value of the expression, if it was evaluated successfully.
+	Value json.RawMessage
+	// Err is the error of the evaluation in this frame, e.g. a JavaScript
+	// exception (`*runtime.ExceptionDetails`).
+	Err error
+}
+
+// Partial copies of page, DOM and target types, for the frame-related
+// CDP commands and events below (we don't use these sub-packages
+// to avoid circular dependencies).
+type (
+	frameTree struct {
+		Frame struct {
+			ID  string `json:"id"`
+			URL string `json:"url"`
+		} `json:"frame"`
+		ChildFrames []frameTree `json:"childFrames"`
+	}
+	getFrameTreeResult struct {
+		FrameTree frameTree `json:"frameTree"`
+	}
+	backendNode struct {
+		BackendNodeID   int64        `json:"backendNodeId"`
+		ContentDocument *backendNode `json:"contentDocument"`
+	}
+	describeNodeResult struct {
+		Node backendNode `json:"node"`
+	}
+	resolveNodeResult struct {
+		Object RemoteObject `json:"object"`
+	}
+	attachedToTarget struct {
+		SessionID  string `json:"sessionId"`
+		TargetInfo struct {
+			TargetID string `json:"targetId"`
+			Type     string `json:"type"`
+		} `json:"targetInfo"`
+	}
+)
+
+// EvalInAllFrames evaluates the given JavaScript expression in the main
+// world of every frame in the page associated with the given context,
+// including out-of-process iframes (OOPIFs), e.g. to inject instrumentation
+// across complex pages, and returns the results in frame tree order
+// (depth-first, starting with the main frame). It waits for results
+// which are promises. Failures in specific frames, e.g. exceptions and
+// frames which are detached during the evaluation, are reported in their
+// results, rather than failing the entire function.
+//
+// OOPIFs are found by attaching to them automatically (with the CDP command
+// `Target.setAutoAttach`), and the previous setting of this command is
+// restored before this function returns, so by default it detaches from them. The expression must be a single
+// expression, because it's wrapped in a function in iframes.
+func EvalInAllFrames(ctx context.Context, expression string) ([]FrameResult, error) {
+	tree := &getFrameTreeResult{}
+	if err := sendAndParse(ctx, "Page.getFrameTree", nil, tree); err != nil {
+		return nil, err
+	}
+	var results []FrameResult
+	var walk func(t *frameTree, main bool)
+	walk = func(t *frameTree, main bool) {
+		r := FrameResult{FrameID: t.Frame.ID, URL: t.Frame.URL}
+		if main {
+			r.Value, r.Err = evalInMainFrame(ctx, expression)
+		} else {
+			var local bool
+			local, r.Value, r.Err = evalInChildFrame(ctx, t.Frame.ID, expression)
+			if !local {
+				return // An OOPIF, and its descendants are in its own target.
+			}
+		}
+		results = append(results, r)
+		for i := range t.ChildFrames {
+			walk(&t.ChildFrames[i], false)
+		}
+	}
+	walk(&tree.FrameTree, true)
+
+	oopifs, detach, err := autoAttachIframes(ctx)
+	if err != nil {
+		return results, err
+	}
+	defer detach()
+	for _, a := range oopifs {
+		child, err := devtools.WithAttachedSession(ctx, a.TargetInfo.TargetID, a.SessionID)
+		if err != nil {
+			return results, err
+		}
+		rs, err := EvalInAllFrames(child, expression)
+		if err != nil {
+			results = append(results, FrameResult{FrameID: a.TargetInfo.TargetID, Err: err})
+			continue
+		}
+		results = append(results, rs...)
+	}
+	return results, nil
+}
+
+func evalInMainFrame(ctx context.Context, expression string) (json.RawMessage, error) {
+	r, err := NewEvaluate(expression).SetReturnByValue(true).SetAwaitPromise(true).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.ExceptionDetails != nil {
+		return nil, r.ExceptionDetails
+	}
+	return r.Result.Value, nil
+}
+
+// Evaluate the expression in the main world of a child frame, by calling a
+// function on its document. It reports false if the frame's content isn't
+// in the current target, i.e. it's an OOPIF.
+func evalInChildFrame(ctx context.Context, frameID, expression string) (bool, json.RawMessage, error) {
+	owner := &backendNode{}
+	params := map[string]interface{}{"frameId": frameID}
+	if err := sendAndParse(ctx, "DOM.getFrameOwner", params, owner); err != nil {
+		return true, nil, err
+	}
+	iframe := &describeNodeResult{}
+	params = map[string]interface{}{"backendNodeId": owner.BackendNodeID, "depth": 0}
+	if err := sendAndParse(ctx, "DOM.describeNode", params, iframe); err != nil {
+		return true, nil, err
+	}
+	doc := iframe.Node.ContentDocument
+	if doc == nil {
+		return false, nil, nil
+	}
+	// Without an execution context ID, nodes are resolved
+	// in the main world of their own frame.
+	obj := &resolveNodeResult{}
+	params = map[string]interface{}{"backendNodeId": doc.BackendNodeID}
+	if err := sendAndParse(ctx, "DOM.resolveNode", params, obj); err != nil {
+		return true, nil, err
+	}
+	defer NewReleaseObject(obj.Object.ObjectID).Do(ctx)
+
+	f := fmt.Sprintf("function() {\n\treturn (%s\n);\n}", expression)
+	r, err := NewCallFunctionOn(f).SetObjectID(obj.Object.ObjectID).
+		SetReturnByValue(true).SetAwaitPromise(true).Do(ctx)
+	if err != nil {
+		return true, nil, err
+	}
+	if r.ExceptionDetails != nil {
+		return true, nil, r.ExceptionDetails
+	}
+	return true, r.Result.Value, nil
+}
+
+// Attach automatically to the OOPIFs of the target which is associated with
+// the given context, and return their sessions, along with a function which
+// restores the previous auto-attachment setting (see `devtools.LastCommand`),
+// or disables it if it wasn't set, and thereby detaches from them.
+func autoAttachIframes(ctx context.Context) ([]attachedToTarget, func(), error) {
+	sub, err := devtools.Subscribe(ctx, "Target.attachedToTarget")
+	if err != nil {
+		return nil, nil, err
+	}
+	s, _ := devtools.FromContext(ctx)
+	sessionID := s.SessionID.Read()
+
+	// Collect attachment events in the background, because the events
+	// of existing iframes arrive before the response to the command.
+	// They're relayed before the response, so after it's received,
+	// the remaining ones are already buffered in the subscription.
+	var attached []attachedToTarget
+	collect := func(m *devtools.Message) {
+		e := attachedToTarget{}
+		if m.SessionID != sessionID || json.Unmarshal(m.Params, &e) != nil || e.TargetInfo.Type != "iframe" {
+			return
+		}
+		attached = append(attached, e)
+	}
+	responded := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case m, ok := <-sub.C:
+				if !ok {
+					return
+				}
+				collect(m)
+			case <-responded:
+				for {
+					select {
+					case m, ok := <-sub.C:
+						if !ok {
+							return
+						}
+						collect(m)
+					default:
+						return
+					}
+				}
+			}
+		}
+	}()
+
+	// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-setAutoAttach
+	prev := json.RawMessage(`{"autoAttach":false,"waitForDebuggerOnStart":false,"flatten":true}`)
+	if c := devtools.LastCommand(ctx, "Target.setAutoAttach"); c != nil {
+		prev = c.Params
+	}
+	params := map[string]interface{}{"autoAttach": true, "waitForDebuggerOnStart": false, "flatten": true}
+	err = sendAndParse(ctx, "Target.setAutoAttach", params, nil)
+	close(responded)
+	<-done
+	sub.Unsubscribe()
+	detach := func() {
+		sendAndParse(ctx, "Target.setAutoAttach", prev, nil)
+	}
+	if err != nil {
+		detach()
+		return nil, nil, err
+	}
+	return attached, detach, nil
+}
+
+// Marshal the given parameters (if not nil), send them in a CDP message to
+// the browser associated with the given context, wait for the response, and
+// parse its result into the given struct (if not nil).
+func sendAndParse(ctx context.Context, method string, params, result interface{}) error {
+	var b json.RawMessage
+	if params != nil {
+		var err error
+		if b, err = json.Marshal(params); err != nil {
+			return err
+		}
+	}
+	response, err := devtools.SendAndWait(ctx, method, b)
+	if err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return errors.New(response.Error.Error())
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(response.Result, result)
+}
@@ -0,0 +1,125 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAutoAttachIframes(t *testing.T) {
+	const (
+		enable  = `{"autoAttach":true,"flatten":true,"waitForDebuggerOnStart":false}`
+		disable = `{"autoAttach":false,"waitForDebuggerOnStart":false,"flatten":true}`
+		prev    = `{"autoAttach":true,"waitForDebuggerOnStart":true,"flatten":true}`
+	)
+	attached := func(sessionID, targetID, targetType string) map[string]interface{} {
+		return map[string]interface{}{"sessionId": sessionID,
+			"targetInfo": map[string]string{"targetId": targetID, "type": targetType}}
+	}
+
+	tests := []struct {
+		name     string
+		prev     string // Previous setting, if any.
+		iframes  int
+		wantIDs  []string
+		wantSent []string
+	}{
+		{
+			name:     "disable_afterwards",
+			iframes:  2,
+			wantIDs:  []string{"IFRAME0", "IFRAME1"},
+			wantSent: []string{enable, disable},
+		},
+		{
+			name:     "restore_previous_setting",
+			prev:     prev,
+			iframes:  1,
+			wantIDs:  []string{"IFRAME0"},
+			wantSent: []string{prev, enable, prev},
+		},
+		{
+			name:     "more_than_subscription_buffer",
+			iframes:  devtools.DefaultSubscriptionBuffer + 10,
+			wantSent: []string{enable, disable},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			ctx, b := devtoolstest.NewContext(ctx)
+			if tt.prev != "" {
+				if _, err := devtools.SendAndWait(ctx, "Target.setAutoAttach", json.RawMessage(tt.prev)); err != nil {
+					t.Fatalf("SendAndWait(); got error: %v", err)
+				}
+			}
+			b.Handle("Target.setAutoAttach", func(params json.RawMessage) (interface{}, error) {
+				if string(params) != enable {
+					return nil, nil
+				}
+				// Another session's iframe, and a worker.
+				if err := devtools.DispatchEvent(ctx, &devtools.Message{SessionID: "other",
+					Method: "Target.attachedToTarget", Params: json.RawMessage(
+						`{"sessionId":"S","targetInfo":{"targetId":"OTHER","type":"iframe"}}`)}); err != nil {
+					return nil, err
+				}
+				if err := b.Emit("Target.attachedToTarget", attached("W", "WORKER", "worker")); err != nil {
+					return nil, err
+				}
+				for i := 0; i < tt.iframes; i++ {
+					id := fmt.Sprintf("IFRAME%d", i)
+					if err := b.Emit("Target.attachedToTarget", attached("S_"+id, id, "iframe")); err != nil {
+						return nil, err
+					}
+				}
+				return nil, nil
+			})
+
+			got, detach, err := autoAttachIframes(ctx)
+			if err != nil {
+				t.Fatalf("autoAttachIframes(); got error: %v", err)
+			}
+			if len(got) != tt.iframes {
+				t.Errorf("autoAttachIframes() = %d iframes, want %d", len(got), tt.iframes)
+			}
+			if tt.wantIDs != nil {
+				var ids []string
+				for _, a := range got {
+					ids = append(ids, a.TargetInfo.TargetID)
+				}
+				if diff := cmp.Diff(tt.wantIDs, ids); diff != "" {
+					t.Errorf("autoAttachIframes() mismatch (-want +got):\n%s", diff)
+				}
+			}
+			detach()
+
+			var sent []string
+			for _, c := range b.Calls("Target.setAutoAttach") {
+				sent = append(sent, string(c.Params))
+			}
+			if diff := cmp.Diff(tt.wantSent, sent); diff != "" {
+				t.Errorf("Target.setAutoAttach params mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAutoAttachIframesError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx, b := devtoolstest.NewContext(ctx)
+	b.Fail("Target.setAutoAttach", "failed")
+
+	if _, _, err := autoAttachIframes(ctx); err == nil {
+		t.Error("autoAttachIframes() = nil error, want an error")
+	}
+	// The previous setting is restored anyway.
+	if got := b.Calls("Target.setAutoAttach"); len(got) != 2 {
+		t.Errorf("autoAttachIframes() sent %d Target.setAutoAttach commands, want 2", len(got))
+	}
+}
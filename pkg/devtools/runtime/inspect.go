@@ -0,0 +1,265 @@
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Default limits of the `runtime.Inspect` function.
+const (
+	DefaultMaxDepth      = 4
+	DefaultMaxProperties = 100
+)
+
+// InspectOption is used for customization in the `runtime.Inspect` function.
+type InspectOption = func(*inspector)
+
+// MaxDepth allows the caller of the `runtime.Inspect` function to customize
+// how many levels of nested objects are serialized (default:
+// `runtime.DefaultMaxDepth`). Deeper objects are replaced by their
+// descriptions, e.g. "[Object]" or "[Array(3)]".
+func MaxDepth(n int) InspectOption {
+	return func(i *inspector) {
+		i.maxDepth = n
+	}
+}
+
+// MaxProperties allows the caller of the `runtime.Inspect` function to
+// customize how many properties (or array elements) of each object are
+// serialized (default: `runtime.DefaultMaxProperties`).
+func MaxProperties(n int) InspectOption {
+	return func(i *inspector) {
+		i.maxProps = n
+	}
+}
+
+// Assign a unique number to `this` object in the WeakMap argument, or return
+// its existing number. Remote object IDs can't identify objects, because
+// each reference to the same object gets a new ID.
+const identifyObject = `function(ids) {
+	let id = ids.get(this);
+	if (id === undefined) {
+		ids.n = (ids.n || 0) + 1;
+		id = ids.n;
+		ids.set(this, id);
+	}
+	return id;
+}`
+
+type inspector struct {
+	maxDepth, maxProps int
+	// Return the own properties of an object.
+	properties func(ctx context.Context, objectID string) ([]PropertyDescriptor, error)
+	// Return a unique number which identifies an object.
+	identify func(ctx context.Context, objectID string) (int, error)
+	// Identifiers of the objects in the current path, to detect cycles.
+	path map[int]bool
+}
+
+// Inspect serializes the given remote object, e.g. the result of an
+// `Evaluate` CDP command which doesn't return by value, into a Go value,
+// by walking its own enumerable properties with `GetProperties` CDP
+// commands, for debugging page state which isn't JSON-serializable:
+//
+// • Primitive values become nil (null and undefined), bool, float64 or
+// string values. Numbers which aren't JSON-serializable (e.g. NaN and
+// -0) and bigints become their string representations.
+//
+// • Arrays become []interface{} values, and other objects become
+// map[string]interface{} values.
+//
+// • Functions, symbols and DOM nodes become their string descriptions,
+// e.g. "[function foo]" and "[div#main]", and so do nested objects beyond
+// the maximum depth (see the `runtime.MaxDepth` option), and circular
+// references ("[Circular]").
+//
+// See also the `runtime.InspectEval` and `runtime.Pretty` functions.
+func Inspect(ctx context.Context, obj *RemoteObject, opts ...InspectOption) (interface{}, error) {
+	i := &inspector{
+		maxDepth:   DefaultMaxDepth,
+		maxProps:   DefaultMaxProperties,
+		properties: getOwnProperties,
+		path:       make(map[int]bool),
+	}
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	ids, err := NewEvaluate("new WeakMap()").Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ids.ExceptionDetails != nil {
+		return nil, ids.ExceptionDetails
+	}
+	defer NewReleaseObject(ids.Result.ObjectID).Do(ctx)
+	i.identify = func(ctx context.Context, objectID string) (int, error) {
+		r, err := NewCallFunctionOn(identifyObject).SetObjectID(objectID).SetReturnByValue(true).
+			SetArguments([]CallArgument{{ObjectID: ids.Result.ObjectID}}).Do(ctx)
+		if err != nil {
+			return 0, err
+		}
+		n := 0
+		return n, parseValue(&r.Result, r.ExceptionDetails, &n)
+	}
+
+	return i.inspect(ctx, obj, 0)
+}
+
+// InspectEval evaluates the given JavaScript expression in the main frame of
+// the page associated with the given context, waits for the result if it's a
+// promise, and serializes it with the `runtime.Inspect` function.
+func InspectEval(ctx context.Context, expression string, opts ...InspectOption) (interface{}, error) {
+	r, err := NewEvaluate(expression).SetAwaitPromise(true).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.ExceptionDetails != nil {
+		return nil, r.ExceptionDetails
+	}
+	if r.Result.ObjectID != "" {
+		defer NewReleaseObject(r.Result.ObjectID).Do(ctx)
+	}
+	return Inspect(ctx, &r.Result, opts...)
+}
+
+// Pretty formats a value which was returned by the `runtime.Inspect`
+// function as indented JSON, with sorted object keys.
+func Pretty(v interface{}) string {
+	b := &bytes.Buffer{}
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func getOwnProperties(ctx context.Context, objectID string) ([]PropertyDescriptor, error) {
+	r, err := NewGetProperties(objectID).SetOwnProperties(true).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.ExceptionDetails != nil {
+		return nil, r.ExceptionDetails
+	}
+	return r.Result, nil
+}
+
+func (i *inspector) inspect(ctx context.Context, obj *RemoteObject, depth int) (interface{}, error) {
+	switch obj.Type {
+	case "undefined":
+		return nil, nil
+	case "function":
+		return fmt.Sprintf("[function %s]", functionName(obj.Description)), nil
+	case "symbol":
+		return fmt.Sprintf("[%s]", obj.Description), nil
+	case "object":
+		break
+	default: // "string", "number", "boolean" or "bigint".
+		if obj.UnserializableValue != "" {
+			return obj.UnserializableValue, nil
+		}
+		var v interface{}
+		if err := json.Unmarshal(obj.Value, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+
+	switch {
+	case obj.Subtype == "null":
+		return nil, nil
+	case obj.ObjectID == "":
+		// Returned by value, so it's JSON-serializable already.
+		var v interface{}
+		if err := json.Unmarshal(obj.Value, &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	case obj.Subtype == "node" || depth >= i.maxDepth:
+		return fmt.Sprintf("[%s]", obj.Description), nil
+	}
+
+	id, err := i.identify(ctx, obj.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if i.path[id] {
+		return "[Circular]", nil
+	}
+	i.path[id] = true
+	defer delete(i.path, id)
+
+	props, err := i.properties(ctx, obj.ObjectID)
+	if err != nil {
+		return nil, err
+	}
+	if obj.Subtype == "array" {
+		return i.inspectArray(ctx, props, depth)
+	}
+	m := make(map[string]interface{})
+	for _, p := range props {
+		if !p.Enumerable || p.Value == nil || p.Symbol != nil {
+			continue // Not an own enumerable data property with a string key.
+		}
+		if len(m) >= i.maxProps {
+			m["..."] = "[truncated]"
+			break
+		}
+		v, err := i.inspectProperty(ctx, p.Value, depth)
+		if err != nil {
+			return nil, err
+		}
+		m[p.Name] = v
+	}
+	return m, nil
+}
+
+func (i *inspector) inspectArray(ctx context.Context, props []PropertyDescriptor, depth int) (interface{}, error) {
+	length := 0
+	for _, p := range props {
+		if n, err := strconv.Atoi(p.Name); err == nil && n >= length {
+			length = n + 1
+		}
+	}
+	if length > i.maxProps {
+		length = i.maxProps
+	}
+	a := make([]interface{}, length) // Holes remain nil.
+	for _, p := range props {
+		n, err := strconv.Atoi(p.Name)
+		if err != nil || n >= length || p.Value == nil {
+			continue
+		}
+		if a[n], err = i.inspectProperty(ctx, p.Value, depth); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+func (i *inspector) inspectProperty(ctx context.Context, obj *RemoteObject, depth int) (interface{}, error) {
+	if obj.ObjectID != "" {
+		defer NewReleaseObject(obj.ObjectID).Do(ctx)
+	}
+	return i.inspect(ctx, obj, depth+1)
+}
+
+// Return the name of a function, from its source code description.
+func functionName(desc string) string {
+	desc = strings.TrimPrefix(strings.TrimPrefix(desc, "async "), "function")
+	desc = strings.TrimPrefix(desc, "*")
+	if i := strings.IndexAny(desc, "(=\n{"); i >= 0 {
+		desc = desc[:i]
+	}
+	if desc = strings.TrimSpace(desc); desc == "" {
+		return "(anonymous)"
+	}
+	return desc
+}
@@ -0,0 +1,97 @@
+package runtime
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInspect(t *testing.T) {
+	str := func(s string) *RemoteObject {
+		v, _ := json.Marshal(s)
+		return &RemoteObject{Type: "string", Value: v}
+	}
+	num := func(s string) *RemoteObject { return &RemoteObject{Type: "number", Value: json.RawMessage(s)} }
+	obj := func(id, desc string) *RemoteObject {
+		return &RemoteObject{Type: "object", ObjectID: id, Description: desc}
+	}
+	prop := func(name string, v *RemoteObject) PropertyDescriptor {
+		return PropertyDescriptor{Name: name, Value: v, Enumerable: true}
+	}
+	arr := obj("arr", "Array(3)")
+	arr.Subtype = "array"
+
+	// Each reference to the same object has a different remote object ID.
+	props := map[string][]PropertyDescriptor{
+		"root": {
+			prop("name", str("app")),
+			prop("nan", &RemoteObject{Type: "number", UnserializableValue: "NaN"}),
+			prop("nil", &RemoteObject{Type: "object", Subtype: "null"}),
+			prop("items", arr),
+			prop("self", obj("root-2", "Object")),
+			prop("el", &RemoteObject{Type: "object", Subtype: "node", ObjectID: "el", Description: "div#main"}),
+			prop("f", &RemoteObject{Type: "function", Description: "function onClick(e) { }"}),
+			{Name: "length", Value: num("3")}, // Not enumerable.
+		},
+		"arr": {
+			prop("0", num("1")),
+			prop("2", obj("deep", "Object")),
+			{Name: "length", Value: num("3")},
+		},
+		"deep": {prop("a", obj("deeper", "Object"))},
+	}
+	identities := map[string]int{"root": 1, "root-2": 1, "arr": 2, "deep": 3, "deeper": 4}
+
+	i := &inspector{
+		maxDepth: 3,
+		maxProps: DefaultMaxProperties,
+		properties: func(_ context.Context, id string) ([]PropertyDescriptor, error) {
+			return props[id], nil
+		},
+		identify: func(_ context.Context, id string) (int, error) {
+			return identities[id], nil
+		},
+		path: make(map[int]bool),
+	}
+	got, err := i.inspect(context.Background(), obj("root", "Object"), 0)
+	if err != nil {
+		t.Fatalf("inspect(); got error: %v", err)
+	}
+	want := map[string]interface{}{
+		"name":  "app",
+		"nan":   "NaN",
+		"nil":   nil,
+		"items": []interface{}{1.0, nil, map[string]interface{}{"a": "[Object]"}},
+		"self":  "[Circular]",
+		"el":    "[div#main]",
+		"f":     "[function onClick]",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("inspect() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFunctionName(t *testing.T) {
+	tests := map[string]string{
+		"function foo(a, b) {}":    "foo",
+		"async function* gen() {}": "gen",
+		"(a) => a + 1":             "(anonymous)",
+		"function () {}":           "(anonymous)",
+		"bar() { return 1; }":      "bar",
+	}
+	for desc, want := range tests {
+		if got := functionName(desc); got != want {
+			t.Errorf("functionName(%q) = %q, want %q", desc, got, want)
+		}
+	}
+}
+
+func TestPretty(t *testing.T) {
+	got := Pretty(map[string]interface{}{"b": []interface{}{1.0, "<a>"}, "a": nil})
+	want := "{\n  \"a\": null,\n  \"b\": [\n    1,\n    \"<a>\"\n  ]\n}"
+	if got != want {
+		t.Errorf("Pretty() = %q, want %q", got, want)
+	}
+}
@@ -1,5 +1,7 @@
 package security
 
+import "github.com/daabr/chrome-vision/pkg/devtools"
+
 // CertificateID data type. An internal certificate ID value.
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Security/#type-CertificateId
@@ -66,9 +68,9 @@ type CertificateSecurityState struct {
 	// Name of the issuing CA.
 	Issuer string `json:"issuer"`
 	// Certificate valid from date.
-	ValidFrom float64 `json:"validFrom"`
+	ValidFrom devtools.TimeSinceEpoch `json:"validFrom"`
 	// Certificate valid to (expiration) date
-	ValidTo float64 `json:"validTo"`
+	ValidTo devtools.TimeSinceEpoch `json:"validTo"`
 	// The highest priority network error code, if the certificate has an error.
 	CertificateNetworkError string `json:"certificateNetworkError,omitempty"`
 	// True if the certificate uses a weak signature aglorithm.
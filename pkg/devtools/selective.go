@@ -0,0 +1,174 @@
+package devtools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Domains whose events may be requested with the `devtools.SubscribeEvents`
+// function, because their "enable" commands have no side effects other than
+// sending events. For example, "Fetch.enable" pauses requests, and "DOM.enable"
+// requires the client to track DOM nodes, so they're not included.
+var selectiveDomains = map[string]bool{
+	"Log":     true,
+	"Network": true,
+	"Page":    true,
+	"Runtime": true,
+}
+
+// Key of the Page lifecycle events stream in the session's domain registry.
+// It's enabled when sessions start, see `devtools.NewContext`.
+const lifecycleEvents = "Page lifecycle events"
+
+// Events which the browser doesn't send even when their domain is
+// enabled, unless they're enabled separately with another command.
+var optionalEvents = map[string]struct {
+	key, method string
+	on, off     interface{}
+}{
+	"Page.lifecycleEvent": {lifecycleEvents, "Page.setLifecycleEventsEnabled",
+		map[string]bool{"enabled": true}, map[string]bool{"enabled": false}},
+	"Network.reportingApiReportAdded": {"Network Reporting API", "Network.enableReportingApi",
+		map[string]bool{"enable": true}, map[string]bool{"enable": false}},
+	"Network.reportingApiReportUpdated": {"Network Reporting API", "Network.enableReportingApi",
+		map[string]bool{"enable": true}, map[string]bool{"enable": false}},
+	"Network.reportingApiEndpointsChangedForOrigin": {"Network Reporting API", "Network.enableReportingApi",
+		map[string]bool{"enable": true}, map[string]bool{"enable": false}},
+}
+
+// Events which may be sent very frequently, or with very large parameters,
+// on busy pages. Each of them costs JSON parsing in this process even
+// when it's discarded, so they should be requested only when needed.
+var heavyEvents = map[string]string{
+	"Network.dataReceived":                   "sent for every chunk of every response body",
+	"Network.eventSourceMessageReceived":     "sent for every server-sent event, with its data",
+	"Network.requestWillBeSentExtraInfo":     "sent for every request, with all of its raw headers",
+	"Network.responseReceivedExtraInfo":      "sent for every response, with all of its raw headers",
+	"Network.webSocketFrameReceived":         "sent for every WebSocket frame, with its payload",
+	"Network.webSocketFrameSent":             "sent for every WebSocket frame, with its payload",
+	"Network.requestWillBeSent":              "sent for every request, with its POST data",
+	"Page.screencastFrame":                   "sent for every rendered frame, with a base64-encoded image",
+	"Page.lifecycleEvent":                    "sent for every lifecycle stage of every frame in every navigation",
+	"Runtime.consoleAPICalled":               "sent for every console call, with its serialized arguments",
+	"Debugger.scriptParsed":                  "sent for every script, including each evaluation",
+	"DOM.childNodeCountUpdated":              "sent for every DOM mutation in tracked nodes",
+	"DOM.attributeModified":                  "sent for every DOM mutation in tracked nodes",
+	"DOM.characterDataModified":              "sent for every DOM mutation in tracked nodes",
+	"DOM.childNodeInserted":                  "sent for every DOM mutation in tracked nodes",
+	"DOM.childNodeRemoved":                   "sent for every DOM mutation in tracked nodes",
+	"Tracing.dataCollected":                  "sent continuously while tracing, with the trace data",
+	"HeapProfiler.addHeapSnapshotChunk":      "sent for every chunk of a heap snapshot",
+	"PerformanceTimeline.timelineEventAdded": "sent for every layout shift and paint timing entry",
+}
+
+// HeavyEvent reports whether the CDP event with the given name (e.g.
+// "Network.dataReceived") is known to incur heavy traffic on busy pages,
+// and why. Subscribing to such events (or enabling their domains) should
+// be avoided unless they're needed, to reduce CPU usage in both the browser
+// and this process.
+func HeavyEvent(name string) (reason string, heavy bool) {
+	reason, heavy = heavyEvents[name]
+	return reason, heavy
+}
+
+// MaxPostDataSize allows the caller of the `devtools.SubscribeEvents` function
+// to limit the size of request bodies (in bytes) which the browser includes in
+// "Network.requestWillBeSent" events, with the parameter "maxPostDataSize" of
+// the "Network.enable" command (default: unlimited). It's applied by the
+// browser, but only if the Network domain isn't already enabled in the session.
+// It has no effect in the `devtools.Subscribe` function.
+func MaxPostDataSize(n int) SubscribeOption {
+	return func(sub *Subscription) {
+		sub.maxPostDataSize = n
+	}
+}
+
+// Partial copy of `network.Enable`.
+type networkEnableParams struct {
+	MaxPostDataSize int `json:"maxPostDataSize,omitempty"`
+}
+
+// SubscribeEvents is like the `devtools.Subscribe` function, but it delivers
+// multiple types of events from the Network, Page, Runtime and Log domains
+// (e.g. "Network.responseReceived" and "Page.loadEventFired") through a single
+// channel, in the order in which they were sent, and it asks the browser to
+// send only what's needed: it enables only the domains of the given events,
+// and event streams which CDP enables separately (e.g. "Page.lifecycleEvent"
+// and the Reporting API events) only if they're requested, and releases them
+// when the subscription ends (see `devtools.EnableDomain`). Server-side
+// filtering within a domain is supported only where CDP provides it, see
+// the `devtools.MaxPostDataSize` subscription option.
+//
+// CDP has no other way to filter the events of an enabled domain, so other
+// components which enable the same domains still cause the browser to send
+// all of their events. See also the `devtools.HeavyEvent` function. To receive
+// target events selectively, use the `devtools.WatchTargets` function.
+func SubscribeEvents(ctx context.Context, names []string, opts ...SubscribeOption) (*Subscription, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return nil, errNotInitialized
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no event names")
+	}
+	var domains []string
+	enabled := map[string]bool{}
+	for _, name := range names {
+		i := strings.Index(name, ".")
+		if i < 1 || !selectiveDomains[name[:i]] {
+			return nil, fmt.Errorf("unsupported event: %q", name)
+		}
+		if !enabled[name[:i]] {
+			enabled[name[:i]] = true
+			domains = append(domains, name[:i])
+		}
+	}
+
+	sub := newSubscription(opts)
+	s.subscribe(sub, names...)
+	var releases []func() error
+	release := func() {
+		// In reverse order, to disable event streams before their domains.
+		for i := len(releases) - 1; i >= 0; i-- {
+			if err := releases[i](); err != nil {
+				log.Printf("Failed to disable events: %v", err)
+			}
+		}
+	}
+
+	// Enable domains before their optional event streams,
+	// because some of them require the domain to be enabled.
+	for _, d := range domains {
+		var params interface{}
+		if d == "Network" && sub.maxPostDataSize > 0 {
+			params = &networkEnableParams{MaxPostDataSize: sub.maxPostDataSize}
+		}
+		r, err := enableDomain(ctx, d, params)
+		if err != nil {
+			sub.Unsubscribe()
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+	for _, name := range names {
+		e, ok := optionalEvents[name]
+		if !ok || enabled[e.key] {
+			continue
+		}
+		enabled[e.key] = true
+		r, err := acquire(ctx, e.key, e.method, e.on, e.method, e.off)
+		if err != nil {
+			sub.Unsubscribe()
+			release()
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+
+	go sub.endWith(ctx, release)
+	return sub, nil
+}
@@ -0,0 +1,120 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSubscribeEvents(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	flush := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		c := calls
+		calls = nil
+		return c
+	}
+	ctx := NewFakeContext(context.Background(), func(ctx context.Context, method string, params json.RawMessage) (*Message, error) {
+		mu.Lock()
+		calls = append(calls, method+" "+string(params))
+		mu.Unlock()
+		return &Message{Result: json.RawMessage(`{}`)}, nil
+	})
+
+	names := []string{"Network.responseReceived", "Page.lifecycleEvent", "Network.loadingFinished"}
+	sub, err := SubscribeEvents(ctx, names, MaxPostDataSize(1024))
+	if err != nil {
+		t.Fatalf("SubscribeEvents(); got error: %v", err)
+	}
+	other, err := SubscribeEvents(ctx, []string{"Network.loadingFailed"})
+	if err != nil {
+		t.Fatalf("SubscribeEvents(); got error: %v", err)
+	}
+	want := []string{
+		`Network.enable {"maxPostDataSize":1024}`,
+		"Page.enable ",
+		`Page.setLifecycleEventsEnabled {"enabled":true}`,
+	}
+	if diff := cmp.Diff(want, flush()); diff != "" {
+		t.Errorf("SubscribeEvents() commands mismatch (-want +got):\n%s", diff)
+	}
+
+	// Events of all the requested types are delivered in order.
+	for _, name := range []string{"Network.loadingFinished", "Network.dataReceived", "Page.lifecycleEvent"} {
+		if err := DispatchEvent(ctx, &Message{Method: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"Network.loadingFinished", "Page.lifecycleEvent"} {
+		if m := <-sub.C; m.Method != name {
+			t.Errorf("SubscribeEvents() event = %q, want %q", m.Method, name)
+		}
+	}
+
+	// The Network domain is still needed by the other subscription.
+	sub.Unsubscribe()
+	want = []string{
+		`Page.setLifecycleEventsEnabled {"enabled":false}`,
+		"Page.disable ",
+	}
+	var got []string
+	for deadline := time.Now().Add(5 * time.Second); len(got) < len(want) && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+		got = append(got, flush()...)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unsubscribe() commands mismatch (-want +got):\n%s", diff)
+	}
+	other.Unsubscribe()
+
+	if _, err := SubscribeEvents(ctx, []string{"Fetch.requestPaused"}); err == nil {
+		t.Error(`SubscribeEvents("Fetch.requestPaused"); got nil error`)
+	}
+}
+
+func TestSubscribeEventsContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	calls := make(chan string, 10)
+	ctx = NewFakeContext(ctx, func(ctx context.Context, method string, params json.RawMessage) (*Message, error) {
+		// Like the transport, with a default command timeout.
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("%q command not sent: %w", method, err)
+		}
+		calls <- method
+		return &Message{Result: json.RawMessage(`{}`)}, nil
+	})
+
+	subCtx, subCancel := context.WithCancel(ctx)
+	if _, err := SubscribeEvents(subCtx, []string{"Page.loadEventFired"}); err != nil {
+		t.Fatalf("SubscribeEvents(); got error: %v", err)
+	}
+	if got := <-calls; got != "Page.enable" {
+		t.Errorf("SubscribeEvents() sent %q, want %q", got, "Page.enable")
+	}
+	subCancel()
+	select {
+	case got := <-calls:
+		if got != "Page.disable" {
+			t.Errorf("subscription end sent %q, want %q", got, "Page.disable")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout: Page.disable not sent after the subscription's context was done")
+	}
+}
+
+func TestHeavyEvent(t *testing.T) {
+	if _, heavy := HeavyEvent("Network.dataReceived"); !heavy {
+		t.Error(`HeavyEvent("Network.dataReceived") = false, want true`)
+	}
+	if _, heavy := HeavyEvent("Page.loadEventFired"); heavy {
+		t.Error(`HeavyEvent("Page.loadEventFired") = true, want false`)
+	}
+}
@@ -12,7 +12,6 @@ import (
 	"log"
 	"os"
 	"path/filepath"
-	"runtime"
 	"sync"
 	"time"
 
@@ -55,13 +54,17 @@ func (t *SafeString) Write(s string) {
 // and retrievable with the `devtools.FromContext` function.
 type Session struct {
 	// For immediate canceling of the context returned by `devtools.NewContext`,
-	// and any descendant contexts, when the browser process ends.
+	// and any descendant contexts, when the browser process ends (or only the
+	// tab's context, in sessions which reuse an existing browser).
 	cancel context.CancelFunc
+	// Closed when that context is canceled, see `devtools.SessionDone`.
+	done <-chan struct{}
 
 	// Data directory per browser process. Created under Go's `os.TempDir()`,
 	// or the path specified in the environment variable "CDP_OUTPUT_ROOT",
 	// if set (see `devtools.OutputRootEnv`). Contains STDOUT and STDERR dumps,
 	// logs, user profile data, screenshots, etc. Not deleted automatically!
+	// See also the `devtools.CleanupStale` function.
 	OutputDir string
 	// User data directory per browser process, instead of the system's
 	// default location. Contains data such as history, bookmarks and cookies
@@ -75,9 +78,98 @@ type Session struct {
 	// the browser was already started by the first call to `devtools.NewContext`.
 	browserPath  *string
 	browserFlags map[string]interface{}
-	// TODO: environment variables.
+	strictFlags  bool
+	browserEnv   map[string]string
+	clearEnv     bool
+	browserDir   string
+	profileSeed  string
+	prefs        map[Pref]interface{}
+	localState   map[Pref]interface{}
+
+	// Optional headless detection mitigations, applied to every tab.
+	// See the `devtools.Stealth` session option.
+	stealth bool
+
+	// Optional web security relaxations, applied to the browser and every tab.
+	// See the `devtools.UnsafeRelaxSecurity` session option.
+	unsafeSecurity bool
+
+	// Optional network protocol presets, e.g. forcing QUIC (HTTP/3).
+	// See the `devtools.ForceQUIC` session option.
+	protocols *protocolConfig
+
+	// Optional compatibility mode for Firefox's subset of the CDP.
+	// See the `devtools.Firefox` session option.
+	firefox bool
+
+	// Optional connection to an existing browser, instead of starting one.
+	// See the `devtools.RemoteBrowser` session option.
+	remote *remoteBrowser
+
+	// Optional keep-alive pings of WebSocket connections, and the health
+	// state of the browser connection. See the `devtools.KeepAlive` session
+	// option and the `devtools.Healthy` function.
+	keepAlive time.Duration
+	health    *healthState
+
+	// Optional monitoring and limits of the resource usage of a locally-started
+	// browser's process tree. See the `devtools.Watchdog` session option.
+	watchdog *watchdog
+
+	// Mapping of the browser's monotonic timestamps to wall-clock times.
+	// See the `devtools.WallClock` function.
+	clock *Clock
+
+	// Optional WebSocket connection to a locally-started browser, and
+	// re-attachment to an existing session, instead of starting one.
+	// See the `devtools.Resumable` option and `devtools.Resume` function.
+	resumable  bool
+	resumeFrom *SessionDescriptor
+
+	// Optional browser context ID for new tabs, instead of the default one.
+	// See the `devtools.BrowserContextID` session option.
+	browserContextID string
+	// Optional details of new tabs. See the `devtools.TabURL`,
+	// `devtools.NewWindow` and `devtools.Background` session options.
+	tabURL                string
+	newWindow, background bool
+
+	// Crash reporting, and optional automatic reloading of crashed tabs.
+	// See the `devtools.Crashes` function and `devtools.ReloadOnCrash` option.
+	crashes       *crashWatcher
+	reloadOnCrash int
+	// Optional collection of minidumps of crashes.
+	// See the `devtools.CollectCrashDumps` session option.
+	crashDumps *crashDumps
+
+	// Optional monitoring of the browser's activity.
+	// See the `devtools.CollectMetrics` session option.
+	metrics *Metrics
+
+	// Optional consumers of the browser's STDOUT and STDERR lines.
+	// See the `devtools.BrowserOutput` and `devtools.LogBrowserOutput` options.
+	outputFuncs []func(OutputLine)
+
+	// Optional wrappers of all the commands in the session.
+	// See the `devtools.CommandMiddleware` session option.
+	middleware []Middleware
+
+	// Optional default timeouts of CDP commands, by command class.
+	// See the `devtools.DefaultTimeout` session option.
+	timeouts map[CommandClass]time.Duration
+
+	// Optional visual debugging of automated interactions.
+	// See the `devtools.ShowInteractions` session option.
+	showInteractions bool
 
 	browserDone chan struct{}
+	// The locally-started browser process, if there is one.
+	// See the `devtools.CloseWithTimeout` function.
+	process *os.Process
+
+	// Maximum size of incoming messages from the browser.
+	// See the `devtools.MaxMessageSize` session option.
+	maxMessageSize int
 
 	// Communication with the browser...
 	// ...On POSIX-compliant operating systems - via pipes.
@@ -86,14 +178,28 @@ type Session struct {
 	wsAddress, wsPath *SafeString
 	webSocket         *websocket.Conn
 
-	msgLog *log.Logger
-	msgID  int64
-	msgQ   chan asyncMessage // https://blog.golang.org/codelab-share
+	msgLog       *log.Logger
+	msgLogConfig msgLogConfig
+	msgID        int64
+	msgQ         chan asyncMessage // https://blog.golang.org/codelab-share
 
 	// Exactly one subscriber per response (created and used in devtools.Send).
 	responseSubscribers map[int64]chan *Message
+	responseMu          *sync.Mutex
 	// Zero or more subscribers per event type.
-	eventSubscribers map[string][]chan *Message
+	eventSubscribers map[string][]*Subscription
+	subscribersMu    *sync.Mutex
+	// Metadata for incoming event messages.
+	targets  *targetRegistry
+	eventSeq uint64
+	// Reference counts of enabled domains, see `devtools.EnableDomain`.
+	domains *domainRegistry
+	// Latest state-changing commands, see `devtools.LastCommand`.
+	commands *commandRegistry
+	// Browser-level automatic attachment, see `devtools.NewContextPaused`.
+	pausedTabs *pausedTabs
+	// Resumes the tab of a session created by `devtools.NewContextPaused`.
+	resume func() error
 
 	// IDs for the attached browser tab. Not shared with descendant contexts
 	// because they create their own tabs, targets and sessions IDs. See also:
@@ -103,6 +209,14 @@ type Session struct {
 
 type sessionKey struct{}
 
+// Partial copy of `target.CreateTarget`, for creating new tabs.
+type createTargetParams struct {
+	URL              string `json:"url"`
+	BrowserContextID string `json:"browserContextId,omitempty"`
+	NewWindow        bool   `json:"newWindow,omitempty"`
+	Background       bool   `json:"background,omitempty"`
+}
+
 // Copy of `target.CreateTargetResult`, for parsing the created target ID.
 type createTargetResult struct {
 	TargetID string `json:"targetId"`
@@ -130,6 +244,42 @@ func FromContext(ctx context.Context) (*Session, bool) {
 	return s, ok
 }
 
+// SessionDone returns a channel which is closed when the CDP session that is
+// associated with the given context ends, i.e. when the context which was
+// returned by the `devtools.NewContext` function is canceled, even if the
+// given context is a descendant with a shorter lifetime. It returns nil if
+// the given context wasn't initialized with the `devtools.NewContext`
+// function.
+func SessionDone(ctx context.Context) <-chan struct{} {
+	if s, ok := FromContext(ctx); ok {
+		return s.done
+	}
+	return nil
+}
+
+// WithAttachedSession returns a copy of the given context, which carries a
+// copy of its CDP session, for sending commands to another target in the
+// same browser connection, through a session which is already attached to
+// it, e.g. an out-of-process iframe (OOPIF) or a worker which was attached
+// automatically (see the CDP command `Target.setAutoAttach` and the
+// `Target.attachedToTarget` event). The session IDs are those in the event.
+//
+// The returned context shares the original session's browser connection.
+func WithAttachedSession(ctx context.Context, targetID, sessionID string) (context.Context, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return ctx, errNotInitialized
+	}
+	// Synchronize with `relayEvent`, which updates the event sequence number.
+	s.subscribersMu.Lock()
+	c := *s
+	s.subscribersMu.Unlock()
+	c.TargetID, c.SessionID = newSafeString(), newSafeString()
+	c.TargetID.Write(targetID)
+	c.SessionID.Write(sessionID)
+	return context.WithValue(ctx, sessionKey{}, &c), nil
+}
+
 // SessionOption is used for customization in the `devtools.NewContext` function.
 //
 // See https://commandcenter.blogspot.com/2014/01/self-referential-functions-and-design.html.
@@ -149,9 +299,16 @@ type SessionOption = func(*Session)
 // The default path for output directories is Go's `os.TempDir()`, but it can be
 // overridden with an optional environment variable (see `devtools.OutputRootEnv`).
 func NewContext(parent context.Context, opts ...SessionOption) (context.Context, error) {
+	return newContext(parent, nil, opts)
+}
+
+// Implementation of `devtools.NewContext` and `devtools.NewContextPaused`.
+// If pausedURL isn't nil, the parent context must carry a session, and the
+// new tab is created with this URL, paused until the debugger resumes it.
+func newContext(parent context.Context, pausedURL *string, opts []SessionOption) (context.Context, error) {
 	// Store the new session in a cancelable copy of the parent context.
 	ctx, cancel := context.WithCancel(parent)
-	session := &Session{cancel: cancel}
+	session := &Session{cancel: cancel, done: ctx.Done()}
 	ctx = context.WithValue(ctx, sessionKey{}, session)
 
 	// Report when this context will be canceled. No need to clean-up
@@ -164,13 +321,36 @@ func NewContext(parent context.Context, opts ...SessionOption) (context.Context,
 	}()
 
 	if ps, ok := FromContext(parent); ok {
-		// Reuse the existing session stored in the parent context.
-		session.cancel = ps.cancel
-
+		// Reuse the existing session stored in the parent context. The new
+		// tab's context keeps its own cancel function, so canceling it
+		// doesn't impact the browser or its other tabs.
 		session.OutputDir = ps.OutputDir
 		session.UserDataDir = ps.UserDataDir
+		session.stealth = ps.stealth
+		session.unsafeSecurity = ps.unsafeSecurity
+		session.reloadOnCrash = ps.reloadOnCrash
+		session.crashDumps = ps.crashDumps
+		session.showInteractions = ps.showInteractions
+		session.middleware = append([]Middleware(nil), ps.middleware...)
+		for class, d := range ps.timeouts {
+			DefaultTimeout(class, d)(session)
+		}
+
+		// Set optional session options specified by the caller, if any.
+		// Options related to the browser process are ignored at this point.
+		for _, o := range opts {
+			o(session)
+		}
 
 		session.browserDone = ps.browserDone
+		session.process = ps.process
+		session.maxMessageSize = ps.maxMessageSize
+		session.metrics = ps.metrics
+		session.firefox = ps.firefox
+		session.remote = ps.remote
+		session.health = ps.health
+		session.watchdog = ps.watchdog
+		session.clock = ps.clock
 		session.browserInputWriter = ps.browserInputWriter
 		session.browserOutputReader = ps.browserOutputReader
 		session.webSocket = ps.webSocket
@@ -180,70 +360,108 @@ func NewContext(parent context.Context, opts ...SessionOption) (context.Context,
 		session.msgQ = ps.msgQ
 
 		session.responseSubscribers = ps.responseSubscribers
+		session.responseMu = ps.responseMu
 		session.eventSubscribers = ps.eventSubscribers
+		session.subscribersMu = ps.subscribersMu
+		session.targets = ps.targets
+		session.domains = ps.domains
+		session.commands = ps.commands
+		session.pausedTabs = ps.pausedTabs
 
 		// Open a new tab.
 		session.TargetID, session.SessionID = newSafeString(), newSafeString()
-		targetID, err := createTarget(ctx)
-		if err != nil {
-			session.cancel()
-			return parent, fmt.Errorf(`"Target.createTarget" command error: %v`, err)
+		if pausedURL != nil {
+			targetID, err := newPausedTab(ctx, parent, *pausedURL)
+			if err != nil {
+				session.cancel()
+				return parent, err
+			}
+			session.TargetID.Write(targetID)
+		} else {
+			targetID, err := createTarget(ctx, session.tabURL)
+			if err != nil {
+				session.cancel()
+				return parent, fmt.Errorf(`"Target.createTarget" command error: %v`, err)
+			}
+			session.TargetID.Write(targetID)
 		}
-		session.TargetID.Write(targetID)
 	} else {
 		// Construct a new session.
+		session.maxMessageSize = DefaultMaxMessageSize
 
 		// Set optional session options specified by the caller, if any.
 		for _, o := range opts {
 			o(session)
 		}
-		// Initialize the session's output directory.
-		path, err := mkdirOutput()
-		if err != nil {
+		// Initialize the session's output directory,
+		// or reuse it if the session is resumed.
+		var path string
+		var err error
+		if session.resumeFrom != nil {
+			path = session.resumeFrom.OutputDir
+		} else if path, err = mkdirOutput(); err != nil {
 			err = fmt.Errorf("failed to create CDP output directory (%s): %v", path, err)
 			return parent, err
 		}
 		session.OutputDir = path
 		// Initialize a new log file for incoming/outgoing JSON messages.
-		f, err := os.Create(filepath.Join(path, "cdp_json.log"))
+		session.msgLog, err = newMsgLog(path, session.msgLogConfig)
 		if err != nil {
 			return parent, fmt.Errorf("failed to initialize CDP log file: %v", err)
 		}
-		session.msgLog = log.New(f, "", log.Ldate|log.Ltime|log.Lmicroseconds)
-		// Start a new browser.
-		if err := start(ctx, session); err != nil {
+		// Initialize the subscribers of incoming JSON messages from the browser.
+		session.responseSubscribers = make(map[int64]chan *Message)
+		session.responseMu = &sync.Mutex{}
+		session.eventSubscribers = make(map[string][]*Subscription)
+		session.subscribersMu = &sync.Mutex{}
+		session.targets = newTargetRegistry()
+		session.domains = newDomainRegistry()
+		session.commands = newCommandRegistry()
+		session.pausedTabs = &pausedTabs{}
+		session.health = &healthState{}
+		session.clock = &Clock{}
+		// Start a new browser, or connect to a remote one.
+		if session.remote == nil {
+			err = start(ctx, session)
+		} else {
+			err = connect(ctx, session)
+		}
+		if err != nil {
 			return parent, err
 		}
 		// Initialize channels to send JSON messages to and from the browser.
 		session.msgID = 1
 		session.msgQ = make(chan asyncMessage)
-		session.responseSubscribers = make(map[int64]chan *Message)
-		session.eventSubscribers = make(map[string][]chan *Message)
-		go func(s *Session) {
-			for {
-				asyncMsg, ok := <-s.msgQ
-				if !ok {
-					// s.msgQ is closed when the browser process ends (see the
-					// goroutine at the bottom of the start function in browser.go).
-					return
-				}
-				if runtime.GOOS != "windows" {
-					sendToPipe(s, asyncMsg)
-				} else {
-					sendToWebSocket(s, asyncMsg)
-				}
-				s.msgID++
-			}
-		}(session)
+		go sendMessages(session)
+		if session.webSocket != nil && session.keepAlive > 0 {
+			go keepAlive(ctx, session)
+		}
+		if session.process != nil && session.watchdog != nil {
+			go runWatchdog(ctx, session)
+		}
 
-		// Attach this session to the first tab.
+		// Attach this session to the first tab, or to
+		// a new tab if the browser is shared with others.
 		session.TargetID, session.SessionID = newSafeString(), newSafeString()
-		targetID, err := fetchTargetID(ctx)
-		if err != nil {
-			session.cancel()
-			return parent, fmt.Errorf(`"Target.getTargets" command error: %v`, err)
+		if session.remote == nil {
+			targetID, err := fetchTargetID(ctx)
+			if err != nil {
+				session.cancel()
+				return parent, fmt.Errorf(`"Target.getTargets" command error: %v`, err)
+			}
+			session.TargetID.Write(targetID)
+		} else if session.resumeFrom != nil {
+			go disconnect(ctx, session)
+			session.TargetID.Write(session.resumeFrom.TargetIDs[0])
+		} else {
+			go disconnect(ctx, session)
+			targetID, err := createTarget(ctx, session.tabURL)
+			if err != nil {
+				session.cancel()
+				return parent, fmt.Errorf(`"Target.createTarget" command error: %v`, err)
+			}
+			session.TargetID.Write(targetID)
 		}
-		session.TargetID.Write(targetID)
 	}
 
 	// Finish attaching this session to a browser tab.
@@ -255,6 +473,7 @@ func NewContext(parent context.Context, opts ...SessionOption) (context.Context,
 	log.Printf("Target ID: %s", session.TargetID.Read())
 	log.Printf("Session ID: %s", sessionID)
 	session.SessionID.Write(sessionID)
+	session.targets.set(sessionID, session.TargetID.Read())
 
 	// Enable receiving various asynchronous events from the browser.
 	if _, err := SendAndWait(ctx, "Page.enable", nil); err != nil {
@@ -266,18 +485,53 @@ func NewContext(parent context.Context, opts ...SessionOption) (context.Context,
 		session.cancel()
 		return parent, err
 	}
+	session.domains.retain(sessionID, "Page", lifecycleEvents)
+
+	if session.stealth {
+		if err := applyStealth(ctx); err != nil {
+			session.cancel()
+			return parent, err
+		}
+	}
+
+	if session.unsafeSecurity {
+		if err := applyUnsafeSecurity(ctx); err != nil {
+			session.cancel()
+			return parent, err
+		}
+	}
+
+	session.crashes = &crashWatcher{ctx: ctx, session: session}
+	if session.reloadOnCrash > 0 || session.crashDumps != nil {
+		if err := session.crashes.start(); err != nil {
+			session.cancel()
+			return parent, err
+		}
+	}
+
+	session.metrics.sessionOpened()
+	go func() {
+		<-ctx.Done()
+		session.metrics.sessionClosed()
+	}()
 
 	return ctx, nil
 }
 
+const outputDirPrefix = "chrome_vision_"
+
+// Return the parent directory of session output directories.
+func outputRoot() string {
+	if path, ok := os.LookupEnv(OutputRootEnv); ok {
+		return path
+	}
+	return os.TempDir()
+}
+
 // Create a uniquely-named session output directory.
 func mkdirOutput() (string, error) {
-	path, ok := os.LookupEnv(OutputRootEnv)
-	if !ok {
-		path = os.TempDir()
-	}
-	dir := time.Now().UTC().Format("chrome_vision_20060102_150405.000000000")
-	path = filepath.Join(path, dir)
+	dir := time.Now().UTC().Format(outputDirPrefix + "20060102_150405.000000000")
+	path := filepath.Join(outputRoot(), dir)
 	if err := os.MkdirAll(path, 0755); err != nil {
 		return "", nil
 	}
@@ -285,21 +539,24 @@ func mkdirOutput() (string, error) {
 	return path, nil
 }
 
-// Create a new browser tab and return its target ID.
-func createTarget(ctx context.Context) (string, error) {
+// Create a new browser tab with the given URL (or "about:blank"
+// if it's empty), and return its target ID.
+func createTarget(ctx context.Context, url string) (string, error) {
+	params := &createTargetParams{URL: url}
+	if s, ok := FromContext(ctx); ok {
+		params.BrowserContextID = s.browserContextID
+		params.NewWindow = s.newWindow
+		params.Background = s.background
+	}
 	// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-createTarget
 	// (we don't use the target sub-package to avoid circular dependencies).
-	response, err := SendAndWait(ctx, "Target.createTarget", []byte(`{"url":""}`))
-	if err != nil {
-		return "", err
-	}
-	if response.Error != nil {
-		return "", errors.New(response.Error.Error())
-	}
 	result := &createTargetResult{}
-	if err := json.Unmarshal(response.Result, result); err != nil {
+	if err := sendAndParse(ctx, "Target.createTarget", params, result); err != nil {
 		return "", err
 	}
+	if s, ok := FromContext(ctx); ok && s.remote != nil {
+		s.remote.addTarget(result.TargetID)
+	}
 	return result.TargetID, nil
 }
 
@@ -382,6 +639,51 @@ func UserDataDir(path string) SessionOption {
 	}
 }
 
+// BrowserContextID allows the caller of the `devtools.NewContext` function
+// to open a new tab in an existing browser, in a specific browser context
+// (similar to an incognito profile, created with the CDP command
+// `Target.createBrowserContext`), instead of the default one.
+//
+// This is ignored when the `devtools.NewContext` function starts a new browser.
+func BrowserContextID(id string) SessionOption {
+	return func(s *Session) {
+		s.browserContextID = id
+	}
+}
+
+// TabURL allows the caller of the `devtools.NewContext` function to open a
+// new tab in an existing browser with the given URL, instead of "about:blank".
+// The function returns when the tab is attached, and it may still be loading.
+//
+// This is ignored when the `devtools.NewContext` function starts a new browser.
+func TabURL(url string) SessionOption {
+	return func(s *Session) {
+		s.tabURL = url
+	}
+}
+
+// NewWindow allows the caller of the `devtools.NewContext` function to open
+// a new tab in an existing browser in a new window, instead of a new tab in
+// an existing window (only when the browser isn't headless).
+//
+// This is ignored when the `devtools.NewContext` function starts a new browser.
+func NewWindow() SessionOption {
+	return func(s *Session) {
+		s.newWindow = true
+	}
+}
+
+// Background allows the caller of the `devtools.NewContext` function to open
+// a new tab in an existing browser in the background, without activating it
+// (only when the browser isn't headless).
+//
+// This is ignored when the `devtools.NewContext` function starts a new browser.
+func Background() SessionOption {
+	return func(s *Session) {
+		s.background = true
+	}
+}
+
 // BrowserFlags allows the caller of the `devtools.NewContext` function to
 // override this Go package's default browser flags, retrieved with the
 // function `devtools.DefaultBrowserFlags`.
@@ -395,3 +697,18 @@ func BrowserFlags(flags map[string]interface{}) SessionOption {
 		}
 	}
 }
+
+// MaxMessageSize allows the caller of the `devtools.NewContext` function to
+// customize the maximum size (in bytes) of incoming messages from the browser
+// (default: `devtools.DefaultMaxMessageSize`, zero or less means no limit).
+// Larger responses are discarded, and reported as `devtools.ErrResponseTooLarge`
+// errors, instead of causing memory spikes, e.g. when calling
+// `dom.GetOuterHTML` on pathological pages. Larger events are dropped.
+//
+// This is ignored when the `devtools.NewContext` function opens a new tab
+// in an existing browser.
+func MaxMessageSize(n int) SessionOption {
+	return func(s *Session) {
+		s.maxMessageSize = n
+	}
+}
@@ -0,0 +1,102 @@
+package devtools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"syscall"
+	"time"
+)
+
+// TerminateTimeout is the maximum amount of time that the
+// `devtools.CloseWithTimeout` function waits for the browser
+// to end after each termination signal.
+const TerminateTimeout = 5 * time.Second
+
+// CloseResult describes how the `devtools.CloseWithTimeout`
+// function ended the browser.
+type CloseResult int
+
+// Ways to end the browser, in escalation order.
+const (
+	// ClosedGracefully means that the browser closed itself
+	// in response to the CDP command `Browser.close`.
+	ClosedGracefully CloseResult = iota
+	// Terminated means that the browser's process group
+	// ended in response to a SIGTERM signal.
+	Terminated
+	// Killed means that the browser's process group was sent a SIGKILL signal.
+	Killed
+)
+
+func (r CloseResult) String() string {
+	switch r {
+	case ClosedGracefully:
+		return "closed gracefully"
+	case Terminated:
+		return "terminated"
+	case Killed:
+		return "killed"
+	default:
+		return fmt.Sprintf("CloseResult(%d)", int(r))
+	}
+}
+
+// CloseWithTimeout is like the `devtools.Close` function, but it doesn't hang
+// if the browser ignores the CDP command `Browser.close`: if the browser doesn't
+// end within the given duration, this function sends a SIGTERM signal to the
+// browser's process group, and then a SIGKILL signal if the browser still
+// doesn't end within `devtools.TerminateTimeout`. It reports which of these
+// ended the browser, e.g. to detect unhealthy browsers in CI jobs.
+//
+// It returns an error if the browser doesn't end even after the SIGKILL
+// signal. With remote browsers (see the `devtools.RemoteBrowser` option),
+// it only waits for the session's tabs to close, like `devtools.Close`.
+func CloseWithTimeout(ctx context.Context, d time.Duration) (CloseResult, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return ClosedGracefully, errNotInitialized
+	}
+	if s.remote != nil || s.process == nil {
+		Close(ctx)
+		return ClosedGracefully, nil
+	}
+
+	// https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-close
+	// (we don't use the browser sub-package to avoid circular dependencies).
+	// The command may never get a response, so don't wait for it.
+	go SendAndWait(ctx, "Browser.close", nil)
+	if waitForBrowser(s, d) {
+		return ClosedGracefully, nil
+	}
+
+	log.Printf("Browser didn't close within %v, sending SIGTERM to PID %d", d, s.process.Pid)
+	if err := signalProcessGroup(s.process, syscall.SIGTERM); err != nil {
+		log.Printf("Failed to send SIGTERM: %v", err)
+	}
+	if waitForBrowser(s, TerminateTimeout) {
+		return Terminated, nil
+	}
+
+	log.Printf("Browser didn't terminate within %v, sending SIGKILL to PID %d", TerminateTimeout, s.process.Pid)
+	if err := signalProcessGroup(s.process, syscall.SIGKILL); err != nil {
+		log.Printf("Failed to send SIGKILL: %v", err)
+	}
+	if waitForBrowser(s, TerminateTimeout) {
+		return Killed, nil
+	}
+	return Killed, fmt.Errorf("browser process %d didn't end after SIGKILL", s.process.Pid)
+}
+
+// Wait up to the given duration for the session's browser to end,
+// and report whether it did.
+func waitForBrowser(s *Session, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-s.browserDone:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
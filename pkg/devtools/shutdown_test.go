@@ -0,0 +1,46 @@
+package devtools
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestCloseWithTimeoutTerminates(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no sleep command")
+	}
+	// A "browser" which ignores the CDP command Browser.close.
+	cmd := exec.Command("sleep", "60")
+	setProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("cmd.Start(); got error: %v", err)
+	}
+	s := &Session{
+		process:     cmd.Process,
+		browserDone: make(chan struct{}),
+		msgQ:        make(chan asyncMessage, 1),
+		SessionID:   newSafeString(),
+	}
+	go func() {
+		cmd.Wait()
+		close(s.browserDone)
+	}()
+
+	ctx := context.WithValue(context.Background(), sessionKey{}, s)
+	got, err := CloseWithTimeout(ctx, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("CloseWithTimeout(); got error: %v", err)
+	}
+	if got != Terminated {
+		t.Errorf("CloseWithTimeout() = %v, want %v", got, Terminated)
+	}
+}
+
+func TestCloseWithTimeoutNotInitialized(t *testing.T) {
+	if _, err := CloseWithTimeout(context.Background(), time.Second); err == nil {
+		t.Error("CloseWithTimeout(); got nil error")
+	}
+}
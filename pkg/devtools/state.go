@@ -0,0 +1,93 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// Names of the CDP commands which the command registries record,
+// see the `devtools.TrackCommands` function.
+var (
+	trackedMu       sync.RWMutex
+	trackedCommands = make(map[string]bool)
+)
+
+// TrackCommands makes all sessions record the latest successful call of each
+// of the given state-changing CDP commands (e.g.
+// "Emulation.setDeviceMetricsOverride"), from now on, so they're reported
+// by the `devtools.LastCommand` function. Sub-packages call it for the
+// commands that they need, e.g. the emulation overrides of `emulation.Push`.
+func TrackCommands(methods ...string) {
+	trackedMu.Lock()
+	defer trackedMu.Unlock()
+	for _, m := range methods {
+		trackedCommands[m] = true
+	}
+}
+
+func isTracked(method string) bool {
+	trackedMu.RLock()
+	defer trackedMu.RUnlock()
+	return trackedCommands[method]
+}
+
+// Latest parameters of state-changing CDP commands, for all the sessions in
+// a browser connection, because the browser doesn't report some of its
+// state (e.g. emulation overrides), see the `devtools.LastCommand` function.
+type commandRegistry struct {
+	mu       sync.Mutex
+	seq      uint64
+	commands map[string]*CommandRecord // By "<session ID> <method>".
+}
+
+// CommandRecord is a successful CDP command which changed the state
+// of a session, see the `devtools.LastCommand` function.
+type CommandRecord struct {
+	Params json.RawMessage
+	// Sequence number of the command, for ordering related commands (e.g.
+	// "Emulation.setGeolocationOverride" and its "clear" counterpart).
+	// It increases monotonically across all the sessions of a browser.
+	Seq uint64
+}
+
+func newCommandRegistry() *commandRegistry {
+	return &commandRegistry{commands: make(map[string]*CommandRecord)}
+}
+
+// Record the given command, if it succeeded and it was
+// registered with the `devtools.TrackCommands` function.
+func (r *commandRegistry) record(sessionID, method string, params json.RawMessage, m *Message) {
+	if m == nil || m.Error != nil || !isTracked(method) {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	r.commands[sessionID+" "+method] = &CommandRecord{Params: params, Seq: r.seq}
+}
+
+// LastCommand returns the latest successful CDP command with the given name
+// (e.g. "Emulation.setDeviceMetricsOverride") which was sent in the session
+// that is associated with the given context, or nil if there wasn't any.
+// Only commands which were registered with the `devtools.TrackCommands`
+// function are recorded, and only if they were sent with the `Do` methods
+// of the domain sub-packages, or with the `devtools.SendAndWait` function.
+//
+// This allows snapshotting state which the browser doesn't report,
+// see for example `emulation.Push`.
+func LastCommand(ctx context.Context, method string) *CommandRecord {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	r := s.commands
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.commands[s.SessionID.Read()+" "+method]
+	if !ok {
+		return nil
+	}
+	result := *c
+	return &result
+}
@@ -0,0 +1,46 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestLastCommand(t *testing.T) {
+	h := func(_ context.Context, method string, _ json.RawMessage) (*Message, error) {
+		if method == "Emulation.setTimezoneOverride" {
+			return &Message{Error: &Error{Code: -32000, Message: "invalid timezone"}}, nil
+		}
+		return &Message{}, nil
+	}
+	ctx := NewFakeContext(context.Background(), h)
+	TrackCommands("Emulation.setGeolocationOverride", "Emulation.clearGeolocationOverride", "Emulation.setTimezoneOverride")
+	if c := LastCommand(ctx, "Emulation.setGeolocationOverride"); c != nil {
+		t.Errorf("LastCommand() before sending = %v, want nil", c)
+	}
+
+	SendAndWait(ctx, "Emulation.setGeolocationOverride", json.RawMessage(`{"latitude":1}`))
+	SendAndWait(ctx, "Emulation.setGeolocationOverride", json.RawMessage(`{"latitude":2}`))
+	SendAndWait(ctx, "Emulation.clearGeolocationOverride", nil)
+	SendAndWait(ctx, "Emulation.setTimezoneOverride", json.RawMessage(`{"timezoneId":"x"}`))
+	SendAndWait(ctx, "Page.navigate", json.RawMessage(`{"url":"about:blank"}`))
+	SendAndWait(ctx, "Page.setBypassCSP", json.RawMessage(`{"enabled":true}`))
+
+	set := LastCommand(ctx, "Emulation.setGeolocationOverride")
+	if set == nil || string(set.Params) != `{"latitude":2}` {
+		t.Fatalf("LastCommand(set) = %v, want latitude 2", set)
+	}
+	clear := LastCommand(ctx, "Emulation.clearGeolocationOverride")
+	if clear == nil || clear.Seq <= set.Seq {
+		t.Errorf("LastCommand(clear) = %v, want a sequence number after %d", clear, set.Seq)
+	}
+	if c := LastCommand(ctx, "Emulation.setTimezoneOverride"); c != nil {
+		t.Errorf("LastCommand(failed command) = %v, want nil", c)
+	}
+	if c := LastCommand(ctx, "Page.navigate"); c != nil {
+		t.Errorf("LastCommand(stateless command) = %v, want nil", c)
+	}
+	if c := LastCommand(ctx, "Page.setBypassCSP"); c != nil {
+		t.Errorf("LastCommand(unregistered command) = %v, want nil", c)
+	}
+}
@@ -0,0 +1,246 @@
+package devtools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// stealthPatch is a named JavaScript snippet which is evaluated in every
+// frame, before any of the frame's own scripts, to hide a specific trait
+// of automated and/or headless browsers.
+type stealthPatch struct {
+	name, script string
+}
+
+// stealthPatches is the data-driven list of headless detection mitigations
+// applied by the `devtools.Stealth` session option. It is based on the most
+// common checks in the wild - see for example:
+//
+// • https://github.com/berstend/puppeteer-extra/tree/master/packages/puppeteer-extra-plugin-stealth/evasions
+//
+// • https://intoli.com/blog/not-possible-to-block-chrome-headless/
+var stealthPatches = []stealthPatch{
+	{
+		name: "navigator.webdriver",
+		script: `Object.defineProperty(Navigator.prototype, "webdriver", {
+	get: () => undefined,
+});`,
+	},
+	{
+		name: "navigator.languages",
+		script: `Object.defineProperty(Navigator.prototype, "languages", {
+	get: () => ["en-US", "en"],
+});`,
+	},
+	{
+		name: "navigator.plugins and navigator.mimeTypes",
+		script: `(() => {
+	const mimeTypes = [
+		{type: "application/pdf", suffixes: "pdf", description: "Portable Document Format"},
+		{type: "text/pdf", suffixes: "pdf", description: "Portable Document Format"},
+	];
+	const pluginNames = ["PDF Viewer", "Chrome PDF Viewer", "Chromium PDF Viewer",
+		"Microsoft Edge PDF Viewer", "WebKit built-in PDF"];
+	const plugins = pluginNames.map((name) => {
+		const p = Object.create(Plugin.prototype);
+		Object.defineProperties(p, {
+			name: {value: name},
+			filename: {value: "internal-pdf-viewer"},
+			description: {value: "Portable Document Format"},
+			length: {value: mimeTypes.length},
+		});
+		return p;
+	});
+	const toArray = (items, proto) => {
+		const a = Object.create(proto);
+		items.forEach((item, i) => Object.defineProperty(a, i, {value: item}));
+		Object.defineProperties(a, {
+			length: {value: items.length},
+			item: {value: (i) => items[i] || null},
+			namedItem: {value: (n) => items.find((x) => x.name === n || x.type === n) || null},
+		});
+		return a;
+	};
+	const pluginArray = toArray(plugins, PluginArray.prototype);
+	const mimeTypeArray = toArray(mimeTypes.map((m) => {
+		const mt = Object.create(MimeType.prototype);
+		Object.defineProperties(mt, {
+			type: {value: m.type},
+			suffixes: {value: m.suffixes},
+			description: {value: m.description},
+			enabledPlugin: {value: plugins[0]},
+		});
+		return mt;
+	}), MimeTypeArray.prototype);
+	Object.defineProperty(Navigator.prototype, "plugins", {get: () => pluginArray});
+	Object.defineProperty(Navigator.prototype, "mimeTypes", {get: () => mimeTypeArray});
+})();`,
+	},
+	{
+		name: "window.chrome",
+		script: `if (!window.chrome) {
+	Object.defineProperty(window, "chrome", {
+		value: {runtime: {}, loadTimes: () => ({}), csi: () => ({})},
+		writable: true,
+		enumerable: true,
+		configurable: false,
+	});
+}`,
+	},
+	{
+		name: "navigator.permissions",
+		script: `(() => {
+	const query = Permissions.prototype.query;
+	Permissions.prototype.query = function(p) {
+		if (p && p.name === "notifications") {
+			return Promise.resolve({state: Notification.permission, onchange: null});
+		}
+		return query.call(this, p);
+	};
+})();`,
+	},
+	{
+		name: "WebGL vendor and renderer",
+		script: `(() => {
+	const patch = (proto) => {
+		const getParameter = proto.getParameter;
+		proto.getParameter = function(p) {
+			if (p === 37445) { // UNMASKED_VENDOR_WEBGL
+				return "Intel Inc.";
+			}
+			if (p === 37446) { // UNMASKED_RENDERER_WEBGL
+				return "Intel Iris OpenGL Engine";
+			}
+			return getParameter.call(this, p);
+		};
+	};
+	patch(WebGLRenderingContext.prototype);
+	if (window.WebGL2RenderingContext) {
+		patch(WebGL2RenderingContext.prototype);
+	}
+})();`,
+	},
+}
+
+// Stealth allows the caller of the `devtools.NewContext` function to apply
+// an opt-in bundle of common mitigations against headless browser detection,
+// in the browser and in all of its tabs:
+//
+// • Browser flags which disable automation-controlled Blink features
+//
+// • A consistent user agent string and client hints (without "Headless")
+//
+// • JavaScript patches for navigator.webdriver, navigator.plugins,
+// navigator.mimeTypes, window.chrome, WebGL vendor details, etc.
+//
+// This is a best-effort feature: it makes detection harder, not impossible.
+func Stealth() SessionOption {
+	return func(s *Session) {
+		s.stealth = true
+	}
+}
+
+// Adjust the browser flags before starting the browser, if the caller
+// specified the `devtools.Stealth` session option.
+func adjustStealthFlags(s *Session) {
+	delete(s.browserFlags, "enable-automation")
+	const feature = "AutomationControlled"
+	if v, ok := s.browserFlags["disable-blink-features"]; ok && fmt.Sprint(v) != "" {
+		if !strings.Contains(fmt.Sprint(v), feature) {
+			s.browserFlags["disable-blink-features"] = fmt.Sprintf("%v,%s", v, feature)
+		}
+	} else {
+		s.browserFlags["disable-blink-features"] = feature
+	}
+}
+
+// Copy of `emulation.UserAgentBrandVersion`.
+type userAgentBrandVersion struct {
+	Brand   string `json:"brand"`
+	Version string `json:"version"`
+}
+
+// Partial copy of `emulation.UserAgentMetadata`.
+type userAgentMetadata struct {
+	Brands          []userAgentBrandVersion `json:"brands"`
+	FullVersion     string                  `json:"fullVersion"`
+	Platform        string                  `json:"platform"`
+	PlatformVersion string                  `json:"platformVersion"`
+	Architecture    string                  `json:"architecture"`
+	Model           string                  `json:"model"`
+	Mobile          bool                    `json:"mobile"`
+}
+
+// Partial copy of `emulation.SetUserAgentOverride`.
+type setUserAgentOverride struct {
+	UserAgent         string             `json:"userAgent"`
+	AcceptLanguage    string             `json:"acceptLanguage,omitempty"`
+	Platform          string             `json:"platform,omitempty"`
+	UserAgentMetadata *userAgentMetadata `json:"userAgentMetadata,omitempty"`
+}
+
+// Partial copy of `browser.GetVersionResult`.
+type getVersionResult struct {
+	Product   string `json:"product"`
+	UserAgent string `json:"userAgent"`
+}
+
+// Apply the `devtools.Stealth` session option to the tab
+// which is associated with the given context.
+func applyStealth(ctx context.Context) error {
+	// https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-addScriptToEvaluateOnNewDocument
+	// (we don't use the page sub-package to avoid circular dependencies).
+	for _, p := range stealthPatches {
+		params := struct {
+			Source string `json:"source"`
+		}{p.script}
+		if err := sendAndParse(ctx, "Page.addScriptToEvaluateOnNewDocument", params, nil); err != nil {
+			return fmt.Errorf("stealth patch %q error: %v", p.name, err)
+		}
+	}
+
+	// https://chromedevtools.github.io/devtools-protocol/tot/Browser/#method-getVersion
+	// (we don't use the browser sub-package to avoid circular dependencies).
+	v := &getVersionResult{}
+	if err := sendAndParse(ctx, "Browser.getVersion", nil, v); err != nil {
+		return fmt.Errorf(`"Browser.getVersion" command error: %v`, err)
+	}
+	ua := strings.ReplaceAll(v.UserAgent, "HeadlessChrome", "Chrome")
+	fullVersion := regexp.MustCompile(`[\d.]+$`).FindString(v.Product)
+	major := strings.SplitN(fullVersion, ".", 2)[0]
+
+	platform, metadataPlatform := "Linux x86_64", "Linux"
+	switch runtime.GOOS {
+	case "darwin":
+		platform, metadataPlatform = "MacIntel", "macOS"
+	case "windows":
+		platform, metadataPlatform = "Win32", "Windows"
+	}
+
+	// https://chromedevtools.github.io/devtools-protocol/tot/Emulation/#method-setUserAgentOverride
+	// (we don't use the emulation sub-package to avoid circular dependencies).
+	params := &setUserAgentOverride{
+		UserAgent:      ua,
+		AcceptLanguage: "en-US,en",
+		Platform:       platform,
+		UserAgentMetadata: &userAgentMetadata{
+			Brands: []userAgentBrandVersion{
+				{Brand: " Not A;Brand", Version: "99"},
+				{Brand: "Chromium", Version: major},
+				{Brand: "Google Chrome", Version: major},
+			},
+			FullVersion: fullVersion,
+			Platform:    metadataPlatform,
+		},
+	}
+	if err := sendAndParse(ctx, "Emulation.setUserAgentOverride", params, nil); err != nil {
+		return fmt.Errorf(`"Emulation.setUserAgentOverride" command error: %v`, err)
+	}
+
+	log.Printf("Applied %d stealth patches, user agent: %s", len(stealthPatches), ua)
+	return nil
+}
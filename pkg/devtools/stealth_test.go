@@ -0,0 +1,131 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAdjustStealthFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags map[string]interface{}
+		want  map[string]interface{}
+	}{
+		{
+			name:  "default_flags",
+			flags: map[string]interface{}{"enable-automation": true, "headless": true},
+			want:  map[string]interface{}{"disable-blink-features": "AutomationControlled", "headless": true},
+		},
+		{
+			name:  "other_disabled_features",
+			flags: map[string]interface{}{"disable-blink-features": "Foo"},
+			want:  map[string]interface{}{"disable-blink-features": "Foo,AutomationControlled"},
+		},
+		{
+			name:  "already_disabled",
+			flags: map[string]interface{}{"disable-blink-features": "AutomationControlled,Foo"},
+			want:  map[string]interface{}{"disable-blink-features": "AutomationControlled,Foo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Session{browserFlags: tt.flags}
+			adjustStealthFlags(s)
+			if diff := cmp.Diff(tt.want, s.browserFlags); diff != "" {
+				t.Errorf("adjustStealthFlags() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyStealth(t *testing.T) {
+	tests := []struct {
+		name    string
+		failOn  string
+		want    []string // Methods of the sent commands.
+		wantErr bool
+	}{
+		{
+			name: "success",
+			want: append(repeat("Page.addScriptToEvaluateOnNewDocument", len(stealthPatches)),
+				"Browser.getVersion", "Emulation.setUserAgentOverride"),
+		},
+		{
+			name:    "script_error",
+			failOn:  "Page.addScriptToEvaluateOnNewDocument",
+			want:    []string{"Page.addScriptToEvaluateOnNewDocument"},
+			wantErr: true,
+		},
+		{
+			name:    "version_error",
+			failOn:  "Browser.getVersion",
+			want:    append(repeat("Page.addScriptToEvaluateOnNewDocument", len(stealthPatches)), "Browser.getVersion"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var methods []string
+			var scripts []string
+			override := &setUserAgentOverride{}
+			ctx := NewFakeContext(context.Background(), func(_ context.Context, method string, params json.RawMessage) (*Message, error) {
+				methods = append(methods, method)
+				if method == tt.failOn {
+					return nil, errors.New("fake error")
+				}
+				switch method {
+				case "Page.addScriptToEvaluateOnNewDocument":
+					p := &struct{ Source string }{}
+					if err := json.Unmarshal(params, p); err != nil {
+						return nil, err
+					}
+					scripts = append(scripts, p.Source)
+				case "Browser.getVersion":
+					return &Message{Result: json.RawMessage(`{"product":"HeadlessChrome/96.0.4664.45",` +
+						`"userAgent":"Mozilla/5.0 (X11; Linux x86_64) HeadlessChrome/96.0.4664.45 Safari/537.36"}`)}, nil
+				case "Emulation.setUserAgentOverride":
+					if err := json.Unmarshal(params, override); err != nil {
+						return nil, err
+					}
+				}
+				return &Message{Result: json.RawMessage(`{}`)}, nil
+			})
+
+			err := applyStealth(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyStealth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, methods); diff != "" {
+				t.Errorf("applyStealth() commands mismatch (-want +got):\n%s", diff)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			for i, p := range stealthPatches {
+				if scripts[i] != p.script {
+					t.Errorf("applyStealth() script %d = %q, want the %q patch", i, scripts[i], p.name)
+				}
+			}
+			if strings.Contains(override.UserAgent, "Headless") {
+				t.Errorf("Emulation.setUserAgentOverride user agent = %q, want without \"Headless\"", override.UserAgent)
+			}
+			if m := override.UserAgentMetadata; m == nil || m.FullVersion != "96.0.4664.45" || len(m.Brands) != 3 || m.Brands[1].Version != "96" {
+				t.Errorf("Emulation.setUserAgentOverride metadata = %+v", m)
+			}
+		})
+	}
+}
+
+func repeat(s string, n int) []string {
+	ss := make([]string, n)
+	for i := range ss {
+		ss[i] = s
+	}
+	return ss
+}
@@ -82,13 +82,23 @@ type GetCookies struct {
 
 // NewGetCookies constructs a new GetCookies struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetCookiesOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-getCookies
-func NewGetCookies() *GetCookies {
-	return &GetCookies{}
+func NewGetCookies(opts ...GetCookiesOption) *GetCookies {
+	c := &GetCookies{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetCookiesOption is a functional option for the optional
+// parameters of the GetCookies CDP command, see the
+// NewGetCookies constructor. Options are applied in order.
+type GetCookiesOption = func(*GetCookies)
+
 // SetBrowserContextID adds or modifies the value of the optional
 // parameter `browserContextId` in the GetCookies CDP command.
 //
@@ -98,6 +108,14 @@ func (t *GetCookies) SetBrowserContextID(v string) *GetCookies {
 	return t
 }
 
+// GetCookiesWithBrowserContextID is a functional option which sets the value of the
+// optional parameter `browserContextId` in the GetCookies CDP command, see SetBrowserContextID.
+func GetCookiesWithBrowserContextID(v string) GetCookiesOption {
+	return func(c *GetCookies) {
+		c.SetBrowserContextID(v)
+	}
+}
+
 // GetCookiesResult contains the browser's response
 // to calling the GetCookies CDP command with Do().
 type GetCookiesResult struct {
@@ -159,15 +177,25 @@ type SetCookies struct {
 
 // NewSetCookies constructs a new SetCookies struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetCookiesOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-setCookies
-func NewSetCookies(cookies []network.CookieParam) *SetCookies {
-	return &SetCookies{
+func NewSetCookies(cookies []network.CookieParam, opts ...SetCookiesOption) *SetCookies {
+	c := &SetCookies{
 		Cookies: cookies,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetCookiesOption is a functional option for the optional
+// parameters of the SetCookies CDP command, see the
+// NewSetCookies constructor. Options are applied in order.
+type SetCookiesOption = func(*SetCookies)
+
 // SetBrowserContextID adds or modifies the value of the optional
 // parameter `browserContextId` in the SetCookies CDP command.
 //
@@ -177,6 +205,14 @@ func (t *SetCookies) SetBrowserContextID(v string) *SetCookies {
 	return t
 }
 
+// SetCookiesWithBrowserContextID is a functional option which sets the value of the
+// optional parameter `browserContextId` in the SetCookies CDP command, see SetBrowserContextID.
+func SetCookiesWithBrowserContextID(v string) SetCookiesOption {
+	return func(c *SetCookies) {
+		c.SetBrowserContextID(v)
+	}
+}
+
 // Do sends the SetCookies CDP command to a browser,
 // and returns the browser's response.
 func (t *SetCookies) Do(ctx context.Context) error {
@@ -225,13 +261,23 @@ type ClearCookies struct {
 
 // NewClearCookies constructs a new ClearCookies struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ClearCookiesOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-clearCookies
-func NewClearCookies() *ClearCookies {
-	return &ClearCookies{}
+func NewClearCookies(opts ...ClearCookiesOption) *ClearCookies {
+	c := &ClearCookies{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ClearCookiesOption is a functional option for the optional
+// parameters of the ClearCookies CDP command, see the
+// NewClearCookies constructor. Options are applied in order.
+type ClearCookiesOption = func(*ClearCookies)
+
 // SetBrowserContextID adds or modifies the value of the optional
 // parameter `browserContextId` in the ClearCookies CDP command.
 //
@@ -241,6 +287,14 @@ func (t *ClearCookies) SetBrowserContextID(v string) *ClearCookies {
 	return t
 }
 
+// ClearCookiesWithBrowserContextID is a functional option which sets the value of the
+// optional parameter `browserContextId` in the ClearCookies CDP command, see SetBrowserContextID.
+func ClearCookiesWithBrowserContextID(v string) ClearCookiesOption {
+	return func(c *ClearCookies) {
+		c.SetBrowserContextID(v)
+	}
+}
+
 // Do sends the ClearCookies CDP command to a browser,
 // and returns the browser's response.
 func (t *ClearCookies) Do(ctx context.Context) error {
@@ -373,17 +427,27 @@ type OverrideQuotaForOrigin struct {
 
 // NewOverrideQuotaForOrigin constructs a new OverrideQuotaForOrigin struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `OverrideQuotaForOriginOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Storage/#method-overrideQuotaForOrigin
 //
 // This CDP method is experimental.
-func NewOverrideQuotaForOrigin(origin string) *OverrideQuotaForOrigin {
-	return &OverrideQuotaForOrigin{
+func NewOverrideQuotaForOrigin(origin string, opts ...OverrideQuotaForOriginOption) *OverrideQuotaForOrigin {
+	c := &OverrideQuotaForOrigin{
 		Origin: origin,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// OverrideQuotaForOriginOption is a functional option for the optional
+// parameters of the OverrideQuotaForOrigin CDP command, see the
+// NewOverrideQuotaForOrigin constructor. Options are applied in order.
+type OverrideQuotaForOriginOption = func(*OverrideQuotaForOrigin)
+
 // SetQuotaSize adds or modifies the value of the optional
 // parameter `quotaSize` in the OverrideQuotaForOrigin CDP command.
 //
@@ -399,6 +463,14 @@ func (t *OverrideQuotaForOrigin) SetQuotaSize(v float64) *OverrideQuotaForOrigin
 	return t
 }
 
+// OverrideQuotaForOriginWithQuotaSize is a functional option which sets the value of the
+// optional parameter `quotaSize` in the OverrideQuotaForOrigin CDP command, see SetQuotaSize.
+func OverrideQuotaForOriginWithQuotaSize(v float64) OverrideQuotaForOriginOption {
+	return func(c *OverrideQuotaForOrigin) {
+		c.SetQuotaSize(v)
+	}
+}
+
 // Do sends the OverrideQuotaForOrigin CDP command to a browser,
 // and returns the browser's response.
 func (t *OverrideQuotaForOrigin) Do(ctx context.Context) error {
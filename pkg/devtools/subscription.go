@@ -0,0 +1,268 @@
+package devtools
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultSubscriptionBuffer is the default buffer size of the channels
+// of subscriptions which are returned by the `devtools.Subscribe` function.
+const DefaultSubscriptionBuffer = 64
+
+// ErrSubscriptionOverflow is returned by the `Err` method of subscriptions
+// which ended because a subscriber didn't keep up with their events, when
+// the `devtools.OverflowError` policy is used.
+var ErrSubscriptionOverflow = errors.New("event subscription overflow")
+
+// OverflowPolicy determines what happens when an event is relayed to a
+// subscription whose channel buffer is full, see the `devtools.Overflow`
+// subscription option.
+type OverflowPolicy int
+
+// OverflowPolicy valid values.
+const (
+	// OverflowBlock waits until the subscriber receives the event. This
+	// delays the delivery of all the subsequent messages from the browser
+	// (in all the tabs), but doesn't lose any events. This is the default.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest buffered event to make room
+	// for the new one, so slow subscribers never block the browser's messages.
+	OverflowDropOldest
+	// OverflowError discards the new event, and ends the subscription: its
+	// channel is closed, and its `Err` method returns `devtools.ErrSubscriptionOverflow`.
+	OverflowError
+)
+
+// Subscription delivers event messages of a specific type from the browser,
+// see the `devtools.Subscribe` function. Multiple goroutines may call its
+// methods simultaneously.
+type Subscription struct {
+	// C delivers the subscribed events. It's owned by the subscription:
+	// it's closed when the subscription ends, and callers must not close it.
+	C <-chan *Message
+
+	names   []string
+	ch      chan *Message
+	size    int
+	policy  OverflowPolicy
+	session *Session
+	done    chan struct{}
+	dropped uint64 // Accessed atomically.
+
+	// See the `devtools.MaxPostDataSize` subscription option.
+	maxPostDataSize int
+
+	// Held for reading while an event is sent to the channel, and for
+	// writing while the channel is closed. It isn't the session's
+	// subscribers mutex, so a blocked delivery doesn't block the
+	// other subscribers' methods.
+	mu    sync.RWMutex
+	ended bool
+	err   error
+}
+
+// SubscribeOption is used for customization in the `devtools.Subscribe` function.
+//
+// See https://commandcenter.blogspot.com/2014/01/self-referential-functions-and-design.html.
+type SubscribeOption = func(*Subscription)
+
+// BufferSize allows the caller of the `devtools.Subscribe` function to customize
+// the buffer size of the subscription's channel (default: `devtools.DefaultSubscriptionBuffer`).
+// The minimum size with policies other than `devtools.OverflowBlock` is 1.
+func BufferSize(n int) SubscribeOption {
+	return func(sub *Subscription) {
+		sub.size = n
+	}
+}
+
+// Overflow allows the caller of the `devtools.Subscribe` function to choose what
+// happens when the subscription's channel buffer is full (default: `devtools.OverflowBlock`).
+func Overflow(p OverflowPolicy) SubscribeOption {
+	return func(sub *Subscription) {
+		sub.policy = p
+	}
+}
+
+// Subscribe returns a subscription to receive event messages of the given
+// type from the browser associated with the given context, through its
+// buffered channel, until its `Unsubscribe` method is called, or until
+// the context is done. Dropped events are counted by the subscription's
+// `Dropped` method, and by the session's metrics, if there are any (see
+// the `devtools.CollectMetrics` session option).
+//
+// Unlike the `devtools.SubscribeEvent` function, slow subscribers may avoid
+// blocking the delivery of all the subsequent messages from the browser,
+// with the `devtools.Overflow` subscription option.
+func Subscribe(ctx context.Context, name string, opts ...SubscribeOption) (*Subscription, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return nil, errNotInitialized
+	}
+	sub := newSubscription(opts)
+	s.subscribe(sub, name)
+	go sub.endWith(ctx, nil)
+	return sub, nil
+}
+
+func newSubscription(opts []SubscribeOption) *Subscription {
+	sub := &Subscription{size: DefaultSubscriptionBuffer}
+	for _, opt := range opts {
+		opt(sub)
+	}
+	if sub.size < 1 && sub.policy != OverflowBlock {
+		sub.size = 1
+	}
+	return sub
+}
+
+// Unsubscribe when the given context is done, and then call the given
+// function (if it isn't nil), or just call it if the subscription ends first.
+func (sub *Subscription) endWith(ctx context.Context, release func()) {
+	select {
+	case <-ctx.Done():
+		sub.Unsubscribe()
+	case <-sub.done:
+	}
+	if release != nil {
+		release()
+	}
+}
+
+// Register a new subscription to the given event types,
+// with a channel of the subscription's size.
+func (s *Session) subscribe(sub *Subscription, names ...string) {
+	sub.names = names
+	sub.ch = make(chan *Message, sub.size)
+	sub.C = sub.ch
+	sub.session = s
+	sub.done = make(chan struct{})
+	s.subscribersMu.Lock()
+	defer s.subscribersMu.Unlock()
+	for _, name := range names {
+		s.eventSubscribers[name] = append(s.eventSubscribers[name], sub)
+	}
+}
+
+// Unsubscribe ends the subscription, and closes its channel. Events which
+// are still buffered in it are discarded. Calling it more than once,
+// or after the subscription ended for another reason, has no effect.
+func (sub *Subscription) Unsubscribe() {
+	stop := drain(sub.ch)
+	sub.end(nil)
+	stop()
+}
+
+// Drain the given channel in the background, until it's closed or the
+// returned function is called, in case an event is being relayed to it
+// right now. Call this before ending a subscription.
+func drain(ch chan *Message) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case _, ok := <-ch:
+				if !ok {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// Err returns `devtools.ErrSubscriptionOverflow` if the subscription ended
+// because of the `devtools.OverflowError` policy, or nil otherwise.
+func (sub *Subscription) Err() error {
+	sub.mu.RLock()
+	defer sub.mu.RUnlock()
+	return sub.err
+}
+
+// Dropped returns the number of events which were discarded
+// because the subscription's channel buffer was full.
+func (sub *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&sub.dropped)
+}
+
+// Relay an event message to the subscriber, according to the overflow
+// policy, unless the subscription already ended. Don't call this while
+// holding the session's subscribers mutex, because it may block.
+func (sub *Subscription) deliver(m *Message) {
+	if sub.send(m) {
+		return
+	}
+	sub.drop(m)
+	sub.end(ErrSubscriptionOverflow)
+}
+
+// Send an event message to the subscriber's channel, and report false
+// if it overflowed according to the `devtools.OverflowError` policy.
+func (sub *Subscription) send(m *Message) bool {
+	sub.mu.RLock()
+	defer sub.mu.RUnlock()
+	if sub.ended {
+		return true
+	}
+	if sub.policy == OverflowBlock {
+		sub.ch <- m
+		return true
+	}
+	for {
+		select {
+		case sub.ch <- m:
+			return true
+		default:
+		}
+		if sub.policy == OverflowError {
+			return false
+		}
+		// The subscriber may receive the oldest event concurrently,
+		// in which case there's room for the new one in the next try.
+		select {
+		case old := <-sub.ch:
+			sub.drop(old)
+		default:
+		}
+	}
+}
+
+func (sub *Subscription) drop(m *Message) {
+	atomic.AddUint64(&sub.dropped, 1)
+	sub.session.metrics.eventDropped(m.Method)
+}
+
+// Close the subscription's channel, and remove the subscription from the
+// session's subscribers. Events which are being sent to the channel right
+// now must be drained first (see the `drain` function).
+func (sub *Subscription) end(err error) {
+	sub.mu.Lock()
+	if sub.ended {
+		sub.mu.Unlock()
+		return
+	}
+	sub.ended = true
+	sub.err = err
+	close(sub.ch)
+	close(sub.done)
+	sub.mu.Unlock()
+
+	s := sub.session
+	s.subscribersMu.Lock()
+	defer s.subscribersMu.Unlock()
+	for _, name := range sub.names {
+		subscribers := s.eventSubscribers[name]
+		for i, c := range subscribers {
+			if c == sub {
+				s.eventSubscribers[name] = append(subscribers[:i:i], subscribers[i+1:]...)
+				break
+			}
+		}
+		if len(s.eventSubscribers[name]) == 0 {
+			delete(s.eventSubscribers, name)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fakeEvents(t *testing.T, ctx context.Context, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		m := &Message{Method: "Test.event", Params: json.RawMessage(`{}`)}
+		if err := DispatchEvent(ctx, m); err != nil {
+			t.Fatalf("DispatchEvent(); got error: %v", err)
+		}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []SubscribeOption
+		wantSeqs    []uint64
+		wantDropped uint64
+		wantErr     error
+	}{
+		{
+			name:     "block",
+			opts:     []SubscribeOption{BufferSize(5)},
+			wantSeqs: []uint64{1, 2, 3, 4, 5},
+		},
+		{
+			name:        "drop_oldest",
+			opts:        []SubscribeOption{BufferSize(3), Overflow(OverflowDropOldest)},
+			wantSeqs:    []uint64{3, 4, 5},
+			wantDropped: 2,
+		},
+		{
+			name:        "error",
+			opts:        []SubscribeOption{BufferSize(3), Overflow(OverflowError)},
+			wantSeqs:    []uint64{1, 2, 3},
+			wantDropped: 1,
+			wantErr:     ErrSubscriptionOverflow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMetrics()
+			ctx := NewFakeContext(context.Background(), nil, CollectMetrics(m))
+			sub, err := Subscribe(ctx, "Test.event", tt.opts...)
+			if err != nil {
+				t.Fatalf("Subscribe(); got error: %v", err)
+			}
+			fakeEvents(t, ctx, 5)
+
+			var got []uint64
+			for range tt.wantSeqs {
+				got = append(got, (<-sub.C).Seq)
+			}
+			for i := range got {
+				if got[i] != tt.wantSeqs[i] {
+					t.Errorf("received events %v, want %v", got, tt.wantSeqs)
+					break
+				}
+			}
+			sub.Unsubscribe()
+			sub.Unsubscribe() // No effect.
+			if _, ok := <-sub.C; ok {
+				t.Error("received an unexpected event")
+			}
+			if got := sub.Dropped(); got != tt.wantDropped {
+				t.Errorf("Dropped() = %d, want %d", got, tt.wantDropped)
+			}
+			if err := sub.Err(); err != tt.wantErr {
+				t.Errorf("Err() = %v, want %v", err, tt.wantErr)
+			}
+			b := &strings.Builder{}
+			m.WriteTo(b)
+			if tt.wantDropped > 0 && !strings.Contains(b.String(), `chrome_vision_events_dropped_total{method="Test.event"}`) {
+				t.Errorf("metrics don't contain dropped events:\n%s", b)
+			}
+
+			// Events aren't delivered to ended subscriptions.
+			fakeEvents(t, ctx, 1)
+		})
+	}
+}
+
+func TestSubscribeDrainedContinuously(t *testing.T) {
+	ctx := NewFakeContext(context.Background(), nil)
+	sub, err := Subscribe(ctx, "Test.event", BufferSize(1), Overflow(OverflowDropOldest))
+	if err != nil {
+		t.Fatalf("Subscribe(); got error: %v", err)
+	}
+	fakeEvents(t, ctx, 1)
+	if e := <-sub.C; e.Seq != 1 {
+		t.Errorf("received event %d, want 1", e.Seq)
+	}
+	fakeEvents(t, ctx, 1)
+	if e := <-sub.C; e.Seq != 2 {
+		t.Errorf("received event %d, want 2", e.Seq)
+	}
+	if got := sub.Dropped(); got != 0 {
+		t.Errorf("Dropped() = %d, want 0", got)
+	}
+}
+
+func TestSubscribeContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = NewFakeContext(ctx, nil)
+	sub, err := Subscribe(ctx, "Test.event", BufferSize(0))
+	if err != nil {
+		t.Fatalf("Subscribe(); got error: %v", err)
+	}
+	cancel()
+	select {
+	case _, ok := <-sub.C:
+		if ok {
+			t.Error("received an unexpected event")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout: channel not closed after the context is done")
+	}
+	if err := sub.Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+}
+
+func TestUnsubscribeEventWhileRelaying(t *testing.T) {
+	ctx := NewFakeContext(context.Background(), nil)
+	ch, err := SubscribeEvent(ctx, "Test.event")
+	if err != nil {
+		t.Fatalf("SubscribeEvent(); got error: %v", err)
+	}
+	// The unbuffered channel isn't drained, so this blocks.
+	go DispatchEvent(ctx, &Message{Method: "Test.event", Params: json.RawMessage(`{}`)})
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		UnsubscribeEvent(ctx, "Test.event", ch)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout: UnsubscribeEvent() blocked by an undelivered event")
+	}
+}
+
+func TestSubscribeWhileRelaying(t *testing.T) {
+	ctx := NewFakeContext(context.Background(), nil)
+	sub, err := Subscribe(ctx, "Test.event", BufferSize(1))
+	if err != nil {
+		t.Fatalf("Subscribe(); got error: %v", err)
+	}
+	// The buffer is full, so the second event blocks the relaying.
+	go func() {
+		for i := 0; i < 2; i++ {
+			DispatchEvent(ctx, &Message{Method: "Test.event", Params: json.RawMessage(`{}`)})
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	// The subscriber calls functions which use the session's
+	// subscribers mutex before it receives the events.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		other, err := Subscribe(ctx, "Other.event")
+		if err != nil {
+			t.Errorf("Subscribe(); got error: %v", err)
+			return
+		}
+		other.Unsubscribe()
+		ch, err := SubscribeEvent(ctx, "Other.event")
+		if err != nil {
+			t.Errorf("SubscribeEvent(); got error: %v", err)
+			return
+		}
+		UnsubscribeEvent(ctx, "Other.event", ch)
+		sub.Err()
+		if _, err := WithAttachedSession(ctx, "target", "session"); err != nil {
+			t.Errorf("WithAttachedSession(); got error: %v", err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout: deadlock with a blocked subscription")
+	}
+	for i := uint64(1); i <= 2; i++ {
+		if e := <-sub.C; e.Seq != i {
+			t.Errorf("received event %d, want %d", e.Seq, i)
+		}
+	}
+	sub.Unsubscribe()
+}
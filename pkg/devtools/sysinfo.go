@@ -0,0 +1,184 @@
+package devtools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SystemReport describes the machine and the GPU capabilities of a browser,
+// as reported by the `devtools.SystemInfo` function, e.g. to diagnose
+// rendering differences between screenshots from different CI machines.
+type SystemReport struct {
+	// Graphics devices, the primary one first.
+	GPUs []GPU
+	// Status of graphics features, e.g. "gpu_compositing": "enabled" or
+	// "rasterization": "disabled_software", as shown in chrome://gpu.
+	FeatureStatus map[string]string
+	// GPU driver bug workarounds which the browser applies, e.g.
+	// "disable_accelerated_vp9_decode", as shown in chrome://gpu.
+	DriverBugWorkarounds []string
+	// Platform-dependent model name and version of the machine (e.g.
+	// "MacBookPro" and "10.1"), empty if not supported.
+	ModelName, ModelVersion string
+	// Command line which was used to launch the browser, empty if not supported.
+	CommandLine string
+	// Browser processes, sorted by type and ID.
+	Processes []ProcessReport
+	// JavaScript heap of the tab, in bytes: used, and allocated.
+	HeapUsed, HeapTotal int64
+}
+
+// GPU describes a graphics device, see `devtools.SystemReport`.
+type GPU struct {
+	// PCI IDs of the vendor and the device, if available, 0 otherwise.
+	VendorID, DeviceID int64
+	// Descriptions of the vendor and the device, if
+	// the PCI IDs aren't available (e.g. "Google" and "SwiftShader").
+	Vendor, Device string
+	// Vendor and version of the driver.
+	DriverVendor, DriverVersion string
+}
+
+// ProcessReport describes a browser process, see `devtools.SystemReport`.
+type ProcessReport struct {
+	// Process type, e.g. "browser", "renderer" or "GPU".
+	Type string
+	// Process ID.
+	ID int64
+	// Cumulative CPU usage across all threads since the process started.
+	CPUTime time.Duration
+}
+
+// Partial copies of the results of the CDP commands `SystemInfo.getInfo`,
+// `SystemInfo.getProcessInfo` and `Runtime.getHeapUsage`.
+type systemInfoResult struct {
+	GPU struct {
+		Devices []struct {
+			VendorID      float64 `json:"vendorId"`
+			DeviceID      float64 `json:"deviceId"`
+			VendorString  string  `json:"vendorString"`
+			DeviceString  string  `json:"deviceString"`
+			DriverVendor  string  `json:"driverVendor"`
+			DriverVersion string  `json:"driverVersion"`
+		} `json:"devices"`
+		FeatureStatus        map[string]string `json:"featureStatus"`
+		DriverBugWorkarounds []string          `json:"driverBugWorkarounds"`
+	} `json:"gpu"`
+	ModelName    string `json:"modelName"`
+	ModelVersion string `json:"modelVersion"`
+	CommandLine  string `json:"commandLine"`
+}
+
+type processInfoResult struct {
+	ProcessInfo []struct {
+		Type    string  `json:"type"`
+		ID      int64   `json:"id"`
+		CPUTime float64 `json:"cpuTime"`
+	} `json:"processInfo"`
+}
+
+type heapUsageResult struct {
+	UsedSize  float64 `json:"usedSize"`
+	TotalSize float64 `json:"totalSize"`
+}
+
+// SystemInfo returns a report of the machine, the GPU capabilities and the
+// processes of the browser which is associated with the given context, and
+// the JavaScript heap of its tab, based on the CDP commands
+// `SystemInfo.getInfo`, `SystemInfo.getProcessInfo` and
+// `Runtime.getHeapUsage` (we don't use the systeminfo and runtime
+// sub-packages to avoid circular dependencies).
+func SystemInfo(ctx context.Context) (*SystemReport, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return nil, errNotInitialized
+	}
+	info := &systemInfoResult{}
+	if err := sendAndParse(ctx, "SystemInfo.getInfo", nil, info); err != nil {
+		return nil, err
+	}
+	r := &SystemReport{
+		FeatureStatus:        info.GPU.FeatureStatus,
+		DriverBugWorkarounds: info.GPU.DriverBugWorkarounds,
+		ModelName:            info.ModelName,
+		ModelVersion:         info.ModelVersion,
+		CommandLine:          info.CommandLine,
+	}
+	for _, d := range info.GPU.Devices {
+		r.GPUs = append(r.GPUs, GPU{
+			VendorID:      int64(d.VendorID),
+			DeviceID:      int64(d.DeviceID),
+			Vendor:        d.VendorString,
+			Device:        d.DeviceString,
+			DriverVendor:  d.DriverVendor,
+			DriverVersion: d.DriverVersion,
+		})
+	}
+
+	// Process information is available only in the browser target,
+	// not in tabs, so this command is sent without a session ID.
+	procs := &processInfoResult{}
+	if err := sendToBrowser(ctx, s, "SystemInfo.getProcessInfo", nil, procs); err != nil {
+		return nil, err
+	}
+	for _, p := range procs.ProcessInfo {
+		r.Processes = append(r.Processes, ProcessReport{
+			Type:    p.Type,
+			ID:      p.ID,
+			CPUTime: time.Duration(p.CPUTime * float64(time.Second)),
+		})
+	}
+	sort.Slice(r.Processes, func(i, j int) bool {
+		if r.Processes[i].Type != r.Processes[j].Type {
+			return r.Processes[i].Type < r.Processes[j].Type
+		}
+		return r.Processes[i].ID < r.Processes[j].ID
+	})
+
+	heap := &heapUsageResult{}
+	if err := sendAndParse(ctx, "Runtime.getHeapUsage", nil, heap); err != nil {
+		return nil, err
+	}
+	r.HeapUsed, r.HeapTotal = int64(heap.UsedSize), int64(heap.TotalSize)
+	return r, nil
+}
+
+// SoftwareRendering reports whether the browser renders pages without GPU
+// acceleration, which is a common cause of pixel differences between
+// screenshots from different machines: when GPU compositing is disabled, or
+// when the primary GPU is a software renderer (e.g. SwiftShader).
+func (r *SystemReport) SoftwareRendering() bool {
+	if status, ok := r.FeatureStatus["gpu_compositing"]; ok && !strings.HasPrefix(status, "enabled") {
+		return true
+	}
+	if len(r.GPUs) == 0 {
+		return true
+	}
+	d := strings.ToLower(r.GPUs[0].Vendor + " " + r.GPUs[0].Device)
+	return strings.Contains(d, "swiftshader") || strings.Contains(d, "llvmpipe")
+}
+
+// String returns a one-line summary of the report's rendering details,
+// for logging alongside test results.
+func (r *SystemReport) String() string {
+	gpu := "no GPU"
+	if len(r.GPUs) > 0 {
+		g := r.GPUs[0]
+		gpu = fmt.Sprintf("GPU %04x:%04x", g.VendorID, g.DeviceID)
+		if g.Vendor != "" || g.Device != "" {
+			gpu = strings.TrimSpace(fmt.Sprintf("GPU %s %s", g.Vendor, g.Device))
+		}
+		if g.DriverVersion != "" {
+			gpu += fmt.Sprintf(" (driver %s)", strings.TrimSpace(g.DriverVendor+" "+g.DriverVersion))
+		}
+	}
+	rendering := "hardware"
+	if r.SoftwareRendering() {
+		rendering = "software"
+	}
+	return fmt.Sprintf("%s, %s rendering, %d driver bug workarounds, %d processes",
+		gpu, rendering, len(r.DriverBugWorkarounds), len(r.Processes))
+}
@@ -0,0 +1,76 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSystemInfo(t *testing.T) {
+	results := map[string]string{
+		"SystemInfo.getInfo": `{"gpu":{"devices":[{"vendorId":0,"deviceId":0,"vendorString":"Google Inc.","deviceString":"ANGLE (Google, Vulkan 1.3.0 (SwiftShader Device))","driverVendor":"","driverVersion":"5.0.0"}],
+			"featureStatus":{"gpu_compositing":"disabled_software","rasterization":"disabled_software"},
+			"driverBugWorkarounds":["disable_d3d11_video_decoder"]},"modelName":"","modelVersion":"","commandLine":"chrome --headless"}`,
+		"SystemInfo.getProcessInfo": `{"processInfo":[{"type":"renderer","id":12,"cpuTime":0.5},{"type":"browser","id":10,"cpuTime":1.25}]}`,
+		"Runtime.getHeapUsage":      `{"usedSize":1000,"totalSize":4096}`,
+	}
+	ctx := NewFakeContext(context.Background(), func(_ context.Context, method string, _ json.RawMessage) (*Message, error) {
+		return &Message{Result: json.RawMessage(results[method])}, nil
+	})
+	got, err := SystemInfo(ctx)
+	if err != nil {
+		t.Fatalf("SystemInfo(); got error: %v", err)
+	}
+	want := &SystemReport{
+		GPUs: []GPU{{
+			Vendor:        "Google Inc.",
+			Device:        "ANGLE (Google, Vulkan 1.3.0 (SwiftShader Device))",
+			DriverVersion: "5.0.0",
+		}},
+		FeatureStatus:        map[string]string{"gpu_compositing": "disabled_software", "rasterization": "disabled_software"},
+		DriverBugWorkarounds: []string{"disable_d3d11_video_decoder"},
+		CommandLine:          "chrome --headless",
+		Processes: []ProcessReport{
+			{Type: "browser", ID: 10, CPUTime: 1250 * time.Millisecond},
+			{Type: "renderer", ID: 12, CPUTime: 500 * time.Millisecond},
+		},
+		HeapUsed:  1000,
+		HeapTotal: 4096,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SystemInfo() mismatch (-want +got):\n%s", diff)
+	}
+	wantString := "GPU Google Inc. ANGLE (Google, Vulkan 1.3.0 (SwiftShader Device)) (driver 5.0.0), software rendering, 1 driver bug workarounds, 2 processes"
+	if got.String() != wantString {
+		t.Errorf("String() = %q, want %q", got.String(), wantString)
+	}
+}
+
+func TestSoftwareRendering(t *testing.T) {
+	tests := []struct {
+		name string
+		r    *SystemReport
+		want bool
+	}{
+		{"no_gpu", &SystemReport{}, true},
+		{"compositing_disabled", &SystemReport{
+			GPUs:          []GPU{{VendorID: 0x10de, DeviceID: 0x1c82}},
+			FeatureStatus: map[string]string{"gpu_compositing": "disabled_off"},
+		}, true},
+		{"llvmpipe", &SystemReport{GPUs: []GPU{{Vendor: "Mesa", Device: "llvmpipe (LLVM 12.0.0, 256 bits)"}}}, true},
+		{"hardware", &SystemReport{
+			GPUs:          []GPU{{VendorID: 0x10de, DeviceID: 0x1c82}},
+			FeatureStatus: map[string]string{"gpu_compositing": "enabled"},
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.SoftwareRendering(); got != tt.want {
+				t.Errorf("SoftwareRendering() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -80,15 +80,25 @@ type AttachToTarget struct {
 
 // NewAttachToTarget constructs a new AttachToTarget struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `AttachToTargetOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-attachToTarget
-func NewAttachToTarget(targetID string) *AttachToTarget {
-	return &AttachToTarget{
+func NewAttachToTarget(targetID string, opts ...AttachToTargetOption) *AttachToTarget {
+	c := &AttachToTarget{
 		TargetID: targetID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// AttachToTargetOption is a functional option for the optional
+// parameters of the AttachToTarget CDP command, see the
+// NewAttachToTarget constructor. Options are applied in order.
+type AttachToTargetOption = func(*AttachToTarget)
+
 // SetFlatten adds or modifies the value of the optional
 // parameter `flatten` in the AttachToTarget CDP command.
 //
@@ -100,6 +110,14 @@ func (t *AttachToTarget) SetFlatten(v bool) *AttachToTarget {
 	return t
 }
 
+// AttachToTargetWithFlatten is a functional option which sets the value of the
+// optional parameter `flatten` in the AttachToTarget CDP command, see SetFlatten.
+func AttachToTargetWithFlatten(v bool) AttachToTargetOption {
+	return func(c *AttachToTarget) {
+		c.SetFlatten(v)
+	}
+}
+
 // AttachToTargetResult contains the browser's response
 // to calling the AttachToTarget CDP command with Do().
 type AttachToTargetResult struct {
@@ -297,17 +315,27 @@ type ExposeDevToolsProtocol struct {
 
 // NewExposeDevToolsProtocol constructs a new ExposeDevToolsProtocol struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `ExposeDevToolsProtocolOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-exposeDevToolsProtocol
 //
 // This CDP method is experimental.
-func NewExposeDevToolsProtocol(targetID string) *ExposeDevToolsProtocol {
-	return &ExposeDevToolsProtocol{
+func NewExposeDevToolsProtocol(targetID string, opts ...ExposeDevToolsProtocolOption) *ExposeDevToolsProtocol {
+	c := &ExposeDevToolsProtocol{
 		TargetID: targetID,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// ExposeDevToolsProtocolOption is a functional option for the optional
+// parameters of the ExposeDevToolsProtocol CDP command, see the
+// NewExposeDevToolsProtocol constructor. Options are applied in order.
+type ExposeDevToolsProtocolOption = func(*ExposeDevToolsProtocol)
+
 // SetBindingName adds or modifies the value of the optional
 // parameter `bindingName` in the ExposeDevToolsProtocol CDP command.
 //
@@ -317,6 +345,14 @@ func (t *ExposeDevToolsProtocol) SetBindingName(v string) *ExposeDevToolsProtoco
 	return t
 }
 
+// ExposeDevToolsProtocolWithBindingName is a functional option which sets the value of the
+// optional parameter `bindingName` in the ExposeDevToolsProtocol CDP command, see SetBindingName.
+func ExposeDevToolsProtocolWithBindingName(v string) ExposeDevToolsProtocolOption {
+	return func(c *ExposeDevToolsProtocol) {
+		c.SetBindingName(v)
+	}
+}
+
 // Do sends the ExposeDevToolsProtocol CDP command to a browser,
 // and returns the browser's response.
 func (t *ExposeDevToolsProtocol) Do(ctx context.Context) error {
@@ -375,15 +411,25 @@ type CreateBrowserContext struct {
 
 // NewCreateBrowserContext constructs a new CreateBrowserContext struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `CreateBrowserContextOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-createBrowserContext
 //
 // This CDP method is experimental.
-func NewCreateBrowserContext() *CreateBrowserContext {
-	return &CreateBrowserContext{}
+func NewCreateBrowserContext(opts ...CreateBrowserContextOption) *CreateBrowserContext {
+	c := &CreateBrowserContext{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// CreateBrowserContextOption is a functional option for the optional
+// parameters of the CreateBrowserContext CDP command, see the
+// NewCreateBrowserContext constructor. Options are applied in order.
+type CreateBrowserContextOption = func(*CreateBrowserContext)
+
 // SetDisposeOnDetach adds or modifies the value of the optional
 // parameter `disposeOnDetach` in the CreateBrowserContext CDP command.
 //
@@ -393,6 +439,14 @@ func (t *CreateBrowserContext) SetDisposeOnDetach(v bool) *CreateBrowserContext
 	return t
 }
 
+// CreateBrowserContextWithDisposeOnDetach is a functional option which sets the value of the
+// optional parameter `disposeOnDetach` in the CreateBrowserContext CDP command, see SetDisposeOnDetach.
+func CreateBrowserContextWithDisposeOnDetach(v bool) CreateBrowserContextOption {
+	return func(c *CreateBrowserContext) {
+		c.SetDisposeOnDetach(v)
+	}
+}
+
 // SetProxyServer adds or modifies the value of the optional
 // parameter `proxyServer` in the CreateBrowserContext CDP command.
 //
@@ -402,6 +456,14 @@ func (t *CreateBrowserContext) SetProxyServer(v string) *CreateBrowserContext {
 	return t
 }
 
+// CreateBrowserContextWithProxyServer is a functional option which sets the value of the
+// optional parameter `proxyServer` in the CreateBrowserContext CDP command, see SetProxyServer.
+func CreateBrowserContextWithProxyServer(v string) CreateBrowserContextOption {
+	return func(c *CreateBrowserContext) {
+		c.SetProxyServer(v)
+	}
+}
+
 // SetProxyBypassList adds or modifies the value of the optional
 // parameter `proxyBypassList` in the CreateBrowserContext CDP command.
 //
@@ -411,6 +473,14 @@ func (t *CreateBrowserContext) SetProxyBypassList(v string) *CreateBrowserContex
 	return t
 }
 
+// CreateBrowserContextWithProxyBypassList is a functional option which sets the value of the
+// optional parameter `proxyBypassList` in the CreateBrowserContext CDP command, see SetProxyBypassList.
+func CreateBrowserContextWithProxyBypassList(v string) CreateBrowserContextOption {
+	return func(c *CreateBrowserContext) {
+		c.SetProxyBypassList(v)
+	}
+}
+
 // SetOriginsWithUniversalNetworkAccess adds or modifies the value of the optional
 // parameter `originsWithUniversalNetworkAccess` in the CreateBrowserContext CDP command.
 //
@@ -421,6 +491,14 @@ func (t *CreateBrowserContext) SetOriginsWithUniversalNetworkAccess(v []string)
 	return t
 }
 
+// CreateBrowserContextWithOriginsWithUniversalNetworkAccess is a functional option which sets the value of the
+// optional parameter `originsWithUniversalNetworkAccess` in the CreateBrowserContext CDP command, see SetOriginsWithUniversalNetworkAccess.
+func CreateBrowserContextWithOriginsWithUniversalNetworkAccess(v []string) CreateBrowserContextOption {
+	return func(c *CreateBrowserContext) {
+		c.SetOriginsWithUniversalNetworkAccess(v)
+	}
+}
+
 // CreateBrowserContextResult contains the browser's response
 // to calling the CreateBrowserContext CDP command with Do().
 type CreateBrowserContextResult struct {
@@ -557,15 +635,25 @@ type CreateTarget struct {
 
 // NewCreateTarget constructs a new CreateTarget struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `CreateTargetOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-createTarget
-func NewCreateTarget(url string) *CreateTarget {
-	return &CreateTarget{
+func NewCreateTarget(url string, opts ...CreateTargetOption) *CreateTarget {
+	c := &CreateTarget{
 		URL: url,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// CreateTargetOption is a functional option for the optional
+// parameters of the CreateTarget CDP command, see the
+// NewCreateTarget constructor. Options are applied in order.
+type CreateTargetOption = func(*CreateTarget)
+
 // SetWidth adds or modifies the value of the optional
 // parameter `width` in the CreateTarget CDP command.
 //
@@ -575,6 +663,14 @@ func (t *CreateTarget) SetWidth(v int64) *CreateTarget {
 	return t
 }
 
+// CreateTargetWithWidth is a functional option which sets the value of the
+// optional parameter `width` in the CreateTarget CDP command, see SetWidth.
+func CreateTargetWithWidth(v int64) CreateTargetOption {
+	return func(c *CreateTarget) {
+		c.SetWidth(v)
+	}
+}
+
 // SetHeight adds or modifies the value of the optional
 // parameter `height` in the CreateTarget CDP command.
 //
@@ -584,6 +680,14 @@ func (t *CreateTarget) SetHeight(v int64) *CreateTarget {
 	return t
 }
 
+// CreateTargetWithHeight is a functional option which sets the value of the
+// optional parameter `height` in the CreateTarget CDP command, see SetHeight.
+func CreateTargetWithHeight(v int64) CreateTargetOption {
+	return func(c *CreateTarget) {
+		c.SetHeight(v)
+	}
+}
+
 // SetBrowserContextID adds or modifies the value of the optional
 // parameter `browserContextId` in the CreateTarget CDP command.
 //
@@ -595,6 +699,14 @@ func (t *CreateTarget) SetBrowserContextID(v string) *CreateTarget {
 	return t
 }
 
+// CreateTargetWithBrowserContextID is a functional option which sets the value of the
+// optional parameter `browserContextId` in the CreateTarget CDP command, see SetBrowserContextID.
+func CreateTargetWithBrowserContextID(v string) CreateTargetOption {
+	return func(c *CreateTarget) {
+		c.SetBrowserContextID(v)
+	}
+}
+
 // SetEnableBeginFrameControl adds or modifies the value of the optional
 // parameter `enableBeginFrameControl` in the CreateTarget CDP command.
 //
@@ -607,6 +719,14 @@ func (t *CreateTarget) SetEnableBeginFrameControl(v bool) *CreateTarget {
 	return t
 }
 
+// CreateTargetWithEnableBeginFrameControl is a functional option which sets the value of the
+// optional parameter `enableBeginFrameControl` in the CreateTarget CDP command, see SetEnableBeginFrameControl.
+func CreateTargetWithEnableBeginFrameControl(v bool) CreateTargetOption {
+	return func(c *CreateTarget) {
+		c.SetEnableBeginFrameControl(v)
+	}
+}
+
 // SetNewWindow adds or modifies the value of the optional
 // parameter `newWindow` in the CreateTarget CDP command.
 //
@@ -616,6 +736,14 @@ func (t *CreateTarget) SetNewWindow(v bool) *CreateTarget {
 	return t
 }
 
+// CreateTargetWithNewWindow is a functional option which sets the value of the
+// optional parameter `newWindow` in the CreateTarget CDP command, see SetNewWindow.
+func CreateTargetWithNewWindow(v bool) CreateTargetOption {
+	return func(c *CreateTarget) {
+		c.SetNewWindow(v)
+	}
+}
+
 // SetBackground adds or modifies the value of the optional
 // parameter `background` in the CreateTarget CDP command.
 //
@@ -626,6 +754,14 @@ func (t *CreateTarget) SetBackground(v bool) *CreateTarget {
 	return t
 }
 
+// CreateTargetWithBackground is a functional option which sets the value of the
+// optional parameter `background` in the CreateTarget CDP command, see SetBackground.
+func CreateTargetWithBackground(v bool) CreateTargetOption {
+	return func(c *CreateTarget) {
+		c.SetBackground(v)
+	}
+}
+
 // CreateTargetResult contains the browser's response
 // to calling the CreateTarget CDP command with Do().
 type CreateTargetResult struct {
@@ -689,13 +825,23 @@ type DetachFromTarget struct {
 
 // NewDetachFromTarget constructs a new DetachFromTarget struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `DetachFromTargetOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-detachFromTarget
-func NewDetachFromTarget() *DetachFromTarget {
-	return &DetachFromTarget{}
+func NewDetachFromTarget(opts ...DetachFromTargetOption) *DetachFromTarget {
+	c := &DetachFromTarget{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// DetachFromTargetOption is a functional option for the optional
+// parameters of the DetachFromTarget CDP command, see the
+// NewDetachFromTarget constructor. Options are applied in order.
+type DetachFromTargetOption = func(*DetachFromTarget)
+
 // SetSessionID adds or modifies the value of the optional
 // parameter `sessionId` in the DetachFromTarget CDP command.
 //
@@ -705,6 +851,14 @@ func (t *DetachFromTarget) SetSessionID(v string) *DetachFromTarget {
 	return t
 }
 
+// DetachFromTargetWithSessionID is a functional option which sets the value of the
+// optional parameter `sessionId` in the DetachFromTarget CDP command, see SetSessionID.
+func DetachFromTargetWithSessionID(v string) DetachFromTargetOption {
+	return func(c *DetachFromTarget) {
+		c.SetSessionID(v)
+	}
+}
+
 // SetTargetID adds or modifies the value of the optional
 // parameter `targetId` in the DetachFromTarget CDP command.
 //
@@ -716,6 +870,14 @@ func (t *DetachFromTarget) SetTargetID(v string) *DetachFromTarget {
 	return t
 }
 
+// DetachFromTargetWithTargetID is a functional option which sets the value of the
+// optional parameter `targetId` in the DetachFromTarget CDP command, see SetTargetID.
+func DetachFromTargetWithTargetID(v string) DetachFromTargetOption {
+	return func(c *DetachFromTarget) {
+		c.SetTargetID(v)
+	}
+}
+
 // Do sends the DetachFromTarget CDP command to a browser,
 // and returns the browser's response.
 func (t *DetachFromTarget) Do(ctx context.Context) error {
@@ -826,15 +988,25 @@ type GetTargetInfo struct {
 
 // NewGetTargetInfo constructs a new GetTargetInfo struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `GetTargetInfoOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-getTargetInfo
 //
 // This CDP method is experimental.
-func NewGetTargetInfo() *GetTargetInfo {
-	return &GetTargetInfo{}
+func NewGetTargetInfo(opts ...GetTargetInfoOption) *GetTargetInfo {
+	c := &GetTargetInfo{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// GetTargetInfoOption is a functional option for the optional
+// parameters of the GetTargetInfo CDP command, see the
+// NewGetTargetInfo constructor. Options are applied in order.
+type GetTargetInfoOption = func(*GetTargetInfo)
+
 // SetTargetID adds or modifies the value of the optional
 // parameter `targetId` in the GetTargetInfo CDP command.
 func (t *GetTargetInfo) SetTargetID(v string) *GetTargetInfo {
@@ -842,6 +1014,14 @@ func (t *GetTargetInfo) SetTargetID(v string) *GetTargetInfo {
 	return t
 }
 
+// GetTargetInfoWithTargetID is a functional option which sets the value of the
+// optional parameter `targetId` in the GetTargetInfo CDP command, see SetTargetID.
+func GetTargetInfoWithTargetID(v string) GetTargetInfoOption {
+	return func(c *GetTargetInfo) {
+		c.SetTargetID(v)
+	}
+}
+
 // GetTargetInfoResult contains the browser's response
 // to calling the GetTargetInfo CDP command with Do().
 type GetTargetInfoResult struct {
@@ -964,17 +1144,27 @@ type SendMessageToTarget struct {
 
 // NewSendMessageToTarget constructs a new SendMessageToTarget struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SendMessageToTargetOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-sendMessageToTarget
 //
 // This CDP method is deprecated.
-func NewSendMessageToTarget(message string) *SendMessageToTarget {
-	return &SendMessageToTarget{
+func NewSendMessageToTarget(message string, opts ...SendMessageToTargetOption) *SendMessageToTarget {
+	c := &SendMessageToTarget{
 		Message: message,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SendMessageToTargetOption is a functional option for the optional
+// parameters of the SendMessageToTarget CDP command, see the
+// NewSendMessageToTarget constructor. Options are applied in order.
+type SendMessageToTargetOption = func(*SendMessageToTarget)
+
 // SetSessionID adds or modifies the value of the optional
 // parameter `sessionId` in the SendMessageToTarget CDP command.
 //
@@ -984,6 +1174,14 @@ func (t *SendMessageToTarget) SetSessionID(v string) *SendMessageToTarget {
 	return t
 }
 
+// SendMessageToTargetWithSessionID is a functional option which sets the value of the
+// optional parameter `sessionId` in the SendMessageToTarget CDP command, see SetSessionID.
+func SendMessageToTargetWithSessionID(v string) SendMessageToTargetOption {
+	return func(c *SendMessageToTarget) {
+		c.SetSessionID(v)
+	}
+}
+
 // SetTargetID adds or modifies the value of the optional
 // parameter `targetId` in the SendMessageToTarget CDP command.
 //
@@ -995,6 +1193,14 @@ func (t *SendMessageToTarget) SetTargetID(v string) *SendMessageToTarget {
 	return t
 }
 
+// SendMessageToTargetWithTargetID is a functional option which sets the value of the
+// optional parameter `targetId` in the SendMessageToTarget CDP command, see SetTargetID.
+func SendMessageToTargetWithTargetID(v string) SendMessageToTargetOption {
+	return func(c *SendMessageToTarget) {
+		c.SetTargetID(v)
+	}
+}
+
 // Do sends the SendMessageToTarget CDP command to a browser,
 // and returns the browser's response.
 func (t *SendMessageToTarget) Do(ctx context.Context) error {
@@ -1056,18 +1262,28 @@ type SetAutoAttach struct {
 
 // NewSetAutoAttach constructs a new SetAutoAttach struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `SetAutoAttachOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-setAutoAttach
 //
 // This CDP method is experimental.
-func NewSetAutoAttach(autoAttach bool, waitForDebuggerOnStart bool) *SetAutoAttach {
-	return &SetAutoAttach{
+func NewSetAutoAttach(autoAttach bool, waitForDebuggerOnStart bool, opts ...SetAutoAttachOption) *SetAutoAttach {
+	c := &SetAutoAttach{
 		AutoAttach:             autoAttach,
 		WaitForDebuggerOnStart: waitForDebuggerOnStart,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// SetAutoAttachOption is a functional option for the optional
+// parameters of the SetAutoAttach CDP command, see the
+// NewSetAutoAttach constructor. Options are applied in order.
+type SetAutoAttachOption = func(*SetAutoAttach)
+
 // SetFlatten adds or modifies the value of the optional
 // parameter `flatten` in the SetAutoAttach CDP command.
 //
@@ -1079,6 +1295,14 @@ func (t *SetAutoAttach) SetFlatten(v bool) *SetAutoAttach {
 	return t
 }
 
+// SetAutoAttachWithFlatten is a functional option which sets the value of the
+// optional parameter `flatten` in the SetAutoAttach CDP command, see SetFlatten.
+func SetAutoAttachWithFlatten(v bool) SetAutoAttachOption {
+	return func(c *SetAutoAttach) {
+		c.SetFlatten(v)
+	}
+}
+
 // Do sends the SetAutoAttach CDP command to a browser,
 // and returns the browser's response.
 func (t *SetAutoAttach) Do(ctx context.Context) error {
@@ -0,0 +1,50 @@
+package target
+
+import (
+	"context"
+	"errors"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// OpenOption is used for customization in the `target.Open` function.
+type OpenOption = devtools.SessionOption
+
+// InNewWindow allows the caller of the `target.Open` function to open the
+// tab in a new window (only when the browser isn't headless).
+func InNewWindow() OpenOption {
+	return devtools.NewWindow()
+}
+
+// InBackground allows the caller of the `target.Open` function to open the
+// tab without activating it (only when the browser isn't headless).
+func InBackground() OpenOption {
+	return devtools.Background()
+}
+
+// InContext allows the caller of the `target.Open` function to open the tab
+// in the browser context (similar to an incognito profile) with the given
+// ID, e.g. from `target.NewCreateBrowserContext`, instead of the default one.
+func InContext(browserContextID string) OpenOption {
+	return devtools.BrowserContextID(browserContextID)
+}
+
+// Open opens a new tab with the given URL (or "about:blank", if it's empty)
+// in the browser associated with the given context, attaches a new CDP session
+// to it, and returns a copy of the context which carries this session, ready
+// for sending commands to the tab. It combines the CDP commands
+// `Target.createTarget` and `Target.attachToTarget`, like the
+// `devtools.NewContext` function, which it calls with the given options
+// (other session options may be used too, e.g. `devtools.Stealth`).
+//
+// The tab may still be loading the URL when this function returns,
+// see `page.WaitForLoad`.
+func Open(ctx context.Context, url string, opts ...OpenOption) (context.Context, error) {
+	if _, ok := devtools.FromContext(ctx); !ok {
+		return ctx, errors.New("context not initialized with devtools.NewContext")
+	}
+	if url != "" {
+		opts = append([]OpenOption{devtools.TabURL(url)}, opts...)
+	}
+	return devtools.NewContext(ctx, opts...)
+}
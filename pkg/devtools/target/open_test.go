@@ -0,0 +1,12 @@
+package target
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOpenWithoutBrowser(t *testing.T) {
+	if _, err := Open(context.Background(), "https://example.com", InNewWindow(), InBackground()); err == nil {
+		t.Error("Open(); got nil error")
+	}
+}
@@ -0,0 +1,242 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+)
+
+// TargetInfo describes a browser target, e.g. a tab, a worker, a service
+// worker, or an extension's background page. It's a partial copy of
+// `target.TargetInfo` (we don't use the target sub-package to avoid
+// circular dependencies).
+type TargetInfo struct {
+	TargetID string `json:"targetId"`
+	// Type is e.g. "page", "iframe", "worker", "shared_worker",
+	// "service_worker", "background_page" or "browser".
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	URL      string `json:"url"`
+	Attached bool   `json:"attached"`
+	// The ID of the target which opened this one, if any.
+	OpenerID         string `json:"openerId,omitempty"`
+	BrowserContextID string `json:"browserContextId,omitempty"`
+}
+
+// TargetEventKind is the kind of change which is reported by a `devtools.TargetEvent`.
+type TargetEventKind string
+
+// TargetEventKind valid values.
+const (
+	TargetCreated   TargetEventKind = "created"
+	TargetChanged   TargetEventKind = "changed"
+	TargetDestroyed TargetEventKind = "destroyed"
+)
+
+// TargetEvent reports a change in the set of targets of the browser,
+// see the `devtools.WatchTargets` function.
+type TargetEvent struct {
+	Kind TargetEventKind
+	// The target's latest state. For destroyed targets, this is the last
+	// known state, or only the target ID if it wasn't known.
+	Target TargetInfo
+}
+
+// TargetWatchOption is the type of optional arguments of the `devtools.WatchTargets`
+// function. See https://commandcenter.blogspot.com/2014/01/self-referential-functions-and-design.html
+// for more details about this design pattern.
+type TargetWatchOption = func(*targetWatcher)
+
+// TargetTypes allows the caller of the `devtools.WatchTargets` function to
+// receive only events about targets of the given types (e.g. "page" and
+// "service_worker"). By default, events about all types are received.
+func TargetTypes(types ...string) TargetWatchOption {
+	return func(w *targetWatcher) {
+		if w.types == nil {
+			w.types = make(map[string]bool)
+		}
+		for _, t := range types {
+			w.types[t] = true
+		}
+	}
+}
+
+// TargetFilter allows the caller of the `devtools.WatchTargets` function to
+// receive only events about targets for which the given function returns
+// true, e.g. targets in a specific browser context, or with specific URLs.
+// It's called in the order of the events, not concurrently.
+func TargetFilter(f func(*TargetInfo) bool) TargetWatchOption {
+	return func(w *targetWatcher) {
+		w.filters = append(w.filters, f)
+	}
+}
+
+type targetWatcher struct {
+	ctx     context.Context
+	types   map[string]bool
+	filters []func(*TargetInfo) bool
+	ch      chan *TargetEvent
+	known   map[string]*TargetInfo // Only accessed by the watch goroutine.
+}
+
+// Partial copies of `target.TargetCreated`, `target.TargetInfoChanged`,
+// `target.TargetDestroyed` and `target.GetTargetsResult`.
+type targetInfoEvent struct {
+	TargetInfo TargetInfo `json:"targetInfo"`
+}
+
+type targetDestroyed struct {
+	TargetID string `json:"targetId"`
+}
+
+type targetInfos struct {
+	TargetInfos []TargetInfo `json:"targetInfos"`
+}
+
+var targetEvents = []string{"Target.targetCreated", "Target.targetInfoChanged", "Target.targetDestroyed"}
+
+// WatchTargets returns a channel to receive events about all the targets
+// of the browser which is associated with the given context, when they're
+// created, changed (e.g. navigated to a different URL, or attached), and
+// destroyed, so supervisors can monitor what the browser is doing globally.
+// Targets which already exist are reported first, as created targets.
+//
+// The channel is closed when the context is done. Events are dropped if
+// the channel's buffer is full, so it should be drained continuously.
+// This enables target discovery (the CDP command `Target.setDiscoverTargets`),
+// and leaves it enabled.
+func WatchTargets(ctx context.Context, opts ...TargetWatchOption) (<-chan *TargetEvent, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return nil, errNotInitialized
+	}
+	w := &targetWatcher{
+		ctx:   ctx,
+		ch:    make(chan *TargetEvent, 64),
+		known: make(map[string]*TargetInfo),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	// A single subscription keeps the events of each target in order.
+	sub := newSubscription(nil)
+	s.subscribe(sub, targetEvents...)
+	initial := make(chan []TargetInfo, 1)
+	go w.watch(sub, initial)
+
+	// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-setDiscoverTargets
+	// https://chromedevtools.github.io/devtools-protocol/tot/Target/#method-getTargets
+	// Discovery may already be enabled (e.g. by `devtools.Crashes`), in which
+	// case existing targets aren't reported again as events.
+	if err := sendAndParse(ctx, "Target.setDiscoverTargets", json.RawMessage(`{"discover":true}`), nil); err != nil {
+		close(initial)
+		return nil, fmt.Errorf(`"Target.setDiscoverTargets" command error: %v`, err)
+	}
+	result := &targetInfos{}
+	if err := sendAndParse(ctx, "Target.getTargets", nil, result); err != nil {
+		close(initial)
+		return nil, fmt.Errorf(`"Target.getTargets" command error: %v`, err)
+	}
+	initial <- result.TargetInfos
+	return w.ch, nil
+}
+
+// Receive target events until the context is done, or the initial
+// targets channel is closed without a value (if starting failed).
+func (w *targetWatcher) watch(sub *Subscription, initial chan []TargetInfo) {
+	defer func() {
+		sub.Unsubscribe()
+		close(w.ch)
+	}()
+
+	// Targets which are destroyed before the initial targets are received.
+	destroyed := make(map[string]bool)
+	for {
+		select {
+		case infos, ok := <-initial:
+			if !ok {
+				return
+			}
+			initial, destroyed = nil, nil
+			for i := range infos {
+				if _, ok := w.known[infos[i].TargetID]; !ok && !destroyed[infos[i].TargetID] {
+					w.update(TargetCreated, &infos[i])
+				}
+			}
+		case m, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			switch m.Method {
+			case "Target.targetCreated":
+				w.handle(TargetCreated, m)
+			case "Target.targetInfoChanged":
+				w.handle(TargetChanged, m)
+			case "Target.targetDestroyed":
+				w.destroy(m, destroyed)
+			}
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+// Report a destroyed target, and remember it if the initial
+// targets weren't received yet (i.e. destroyed isn't nil).
+func (w *targetWatcher) destroy(m *Message, destroyed map[string]bool) {
+	e := &targetDestroyed{}
+	if err := json.Unmarshal(m.Params, e); err != nil {
+		log.Printf("JSON error: %v", err)
+		return
+	}
+	info, ok := w.known[e.TargetID]
+	if !ok {
+		info = &TargetInfo{TargetID: e.TargetID}
+	}
+	delete(w.known, e.TargetID)
+	if destroyed != nil {
+		destroyed[e.TargetID] = true
+	}
+	w.send(TargetDestroyed, info)
+}
+
+func (w *targetWatcher) handle(kind TargetEventKind, m *Message) {
+	e := &targetInfoEvent{}
+	if err := json.Unmarshal(m.Params, e); err != nil {
+		log.Printf("JSON error: %v", err)
+		return
+	}
+	// Targets which were reported by "Target.getTargets" before
+	// their creation event was received aren't reported twice.
+	if prev, ok := w.known[e.TargetInfo.TargetID]; ok {
+		if *prev == e.TargetInfo {
+			return
+		}
+		kind = TargetChanged
+	}
+	w.update(kind, &e.TargetInfo)
+}
+
+func (w *targetWatcher) update(kind TargetEventKind, info *TargetInfo) {
+	w.known[info.TargetID] = info
+	w.send(kind, info)
+}
+
+// Send an event to the caller, if the target matches the filters.
+func (w *targetWatcher) send(kind TargetEventKind, info *TargetInfo) {
+	if w.types != nil && !w.types[info.Type] {
+		return
+	}
+	for _, f := range w.filters {
+		if !f(info) {
+			return
+		}
+	}
+	select {
+	case w.ch <- &TargetEvent{Kind: kind, Target: *info}:
+	default:
+		log.Printf("Dropped %s event of target %s: channel is full", kind, info.TargetID)
+	}
+}
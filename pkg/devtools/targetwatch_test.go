@@ -0,0 +1,69 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWatchTargets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = NewFakeContext(ctx, func(_ context.Context, method string, _ json.RawMessage) (*Message, error) {
+		if method == "Target.getTargets" {
+			return &Message{Result: json.RawMessage(`{"targetInfos":[
+				{"targetId":"1","type":"page","url":"about:blank"},
+				{"targetId":"2","type":"browser"}]}`)}, nil
+		}
+		return &Message{Result: json.RawMessage(`{}`)}, nil
+	})
+
+	ch, err := WatchTargets(ctx, TargetTypes("page", "service_worker"), TargetFilter(func(t *TargetInfo) bool {
+		return t.URL != "chrome://newtab/"
+	}))
+	if err != nil {
+		t.Fatalf("WatchTargets(); got error: %v", err)
+	}
+	events := []struct{ method, params string }{
+		// Already reported by "Target.getTargets", and unchanged.
+		{"Target.targetCreated", `{"targetInfo":{"targetId":"1","type":"page","url":"about:blank"}}`},
+		{"Target.targetInfoChanged", `{"targetInfo":{"targetId":"1","type":"page","url":"https://example.com/"}}`},
+		// Filtered out.
+		{"Target.targetCreated", `{"targetInfo":{"targetId":"3","type":"page","url":"chrome://newtab/"}}`},
+		{"Target.targetCreated", `{"targetInfo":{"targetId":"4","type":"worker"}}`},
+		{"Target.targetCreated", `{"targetInfo":{"targetId":"5","type":"service_worker","url":"https://example.com/sw.js"}}`},
+		{"Target.targetDestroyed", `{"targetId":"1"}`},
+	}
+	for _, e := range events {
+		if err := DispatchEvent(ctx, &Message{Method: e.method, Params: json.RawMessage(e.params)}); err != nil {
+			t.Fatalf("DispatchEvent(%s); got error: %v", e.method, err)
+		}
+	}
+
+	want := []*TargetEvent{
+		{Kind: TargetCreated, Target: TargetInfo{TargetID: "1", Type: "page", URL: "about:blank"}},
+		{Kind: TargetChanged, Target: TargetInfo{TargetID: "1", Type: "page", URL: "https://example.com/"}},
+		{Kind: TargetCreated, Target: TargetInfo{TargetID: "5", Type: "service_worker", URL: "https://example.com/sw.js"}},
+		{Kind: TargetDestroyed, Target: TargetInfo{TargetID: "1", Type: "page", URL: "https://example.com/"}},
+	}
+	var got []*TargetEvent
+	for len(got) < len(want) {
+		select {
+		case e := <-ch:
+			got = append(got, e)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout: received %d events, want %d", len(got), len(want))
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("WatchTargets() events mismatch (-want +got):\n%s", diff)
+	}
+
+	cancel()
+	for range ch {
+		// Wait for the channel to be closed.
+	}
+}
@@ -0,0 +1,122 @@
+package devtools
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CommandClass categorizes CDP commands by their expected duration,
+// for default timeouts, see the `devtools.DefaultTimeout` session option.
+type CommandClass int
+
+// Command classes, see the `devtools.ClassifyCommand` function.
+const (
+	// FastCommand is the class of most CDP commands, which the browser
+	// handles immediately (e.g. DOM queries and input events).
+	FastCommand CommandClass = iota
+	// SlowCommand is the class of CDP commands which may take several
+	// seconds in large pages, e.g. "Page.printToPDF",
+	// "DOMSnapshot.captureSnapshot" and "Runtime.evaluate" (which may
+	// await promises).
+	SlowCommand
+	// NavigationCommand is the class of CDP commands which navigate
+	// the page, and respond only after the server responds,
+	// e.g. "Page.navigate".
+	NavigationCommand
+)
+
+func (c CommandClass) String() string {
+	switch c {
+	case FastCommand:
+		return "fast"
+	case SlowCommand:
+		return "slow"
+	case NavigationCommand:
+		return "navigation"
+	default:
+		return fmt.Sprintf("CommandClass(%d)", int(c))
+	}
+}
+
+// Commands which aren't fast, see the `devtools.ClassifyCommand` function.
+var commandClasses = map[string]CommandClass{
+	"Page.navigate":                 NavigationCommand,
+	"Page.navigateToHistoryEntry":   NavigationCommand,
+	"Page.reload":                   NavigationCommand,
+	"Page.captureScreenshot":        SlowCommand,
+	"Page.captureSnapshot":          SlowCommand,
+	"Page.printToPDF":               SlowCommand,
+	"DOMSnapshot.captureSnapshot":   SlowCommand,
+	"DOMSnapshot.getSnapshot":       SlowCommand,
+	"HeapProfiler.takeHeapSnapshot": SlowCommand,
+	"Network.getResponseBody":       SlowCommand,
+	"Network.loadNetworkResource":   SlowCommand,
+	"Fetch.getResponseBody":         SlowCommand,
+	"Runtime.awaitPromise":          SlowCommand,
+	"Runtime.callFunctionOn":        SlowCommand,
+	"Runtime.evaluate":              SlowCommand,
+	"Storage.clearDataForOrigin":    SlowCommand,
+	"Target.createTarget":           SlowCommand,
+	"Accessibility.getFullAXTree":   SlowCommand,
+	"ServiceWorker.stopAllWorkers":  SlowCommand,
+	"Tracing.requestMemoryDump":     SlowCommand,
+}
+
+// ClassifyCommand returns the class of the given CDP command (e.g.
+// "Page.printToPDF"), which determines its default timeout (see the
+// `devtools.DefaultTimeout` session option). Commands which aren't
+// known to be slow are fast.
+func ClassifyCommand(method string) CommandClass {
+	return commandClasses[method]
+}
+
+// DefaultTimeout allows the caller of the `devtools.NewContext` function to
+// limit the amount of time to wait for the browser's responses to CDP
+// commands of the given class (see the `devtools.ClassifyCommand` function),
+// e.g. 5 seconds for fast commands, and 2 minutes for slow ones, to prevent
+// both premature timeouts and indefinite hangs. By default, there's no limit.
+//
+// The timeout applies to commands which are sent with the `Do` methods of
+// the domain sub-packages, or with the `devtools.SendAndWait` function, only
+// when the command's context has no sooner deadline. Commands which time
+// out return an error which wraps `context.DeadlineExceeded`. New tabs
+// which are opened in an existing browser inherit the timeouts of their
+// parent session.
+//
+// Sessions with any default timeout also stop waiting for the responses of
+// commands whose context is done for other reasons (e.g. when it's canceled),
+// and don't send commands whose context is done while they're queued.
+// Sessions without default timeouts wait for every response, regardless of
+// the command's context.
+func DefaultTimeout(class CommandClass, d time.Duration) SessionOption {
+	return func(s *Session) {
+		if s.timeouts == nil {
+			s.timeouts = make(map[CommandClass]time.Duration)
+		}
+		s.timeouts[class] = d
+	}
+}
+
+// Return a channel which is closed when the given command context is done,
+// if the session has default timeouts, or nil otherwise: sessions without
+// them wait for every response, regardless of the command's context.
+func (s *Session) commandDone(ctx context.Context) <-chan struct{} {
+	if len(s.timeouts) == 0 {
+		return nil
+	}
+	return ctx.Done()
+}
+
+// Return the given context with the session's default timeout for the
+// given command, if it has one which is sooner than the context's deadline.
+func (s *Session) withCommandTimeout(ctx context.Context, method string) (context.Context, context.CancelFunc) {
+	d := s.timeouts[ClassifyCommand(method)]
+	if d <= 0 {
+		return ctx, func() {}
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= d {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d)
+}
@@ -0,0 +1,113 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDefaultTimeout(t *testing.T) {
+	deadlines := make(map[string]time.Duration)
+	h := func(ctx context.Context, method string, _ json.RawMessage) (*Message, error) {
+		if d, ok := ctx.Deadline(); ok {
+			deadlines[method] = time.Until(d).Round(time.Minute)
+		}
+		if method == "DOM.hang" {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return &Message{}, nil
+	}
+	ctx := NewFakeContext(context.Background(), h,
+		DefaultTimeout(FastCommand, time.Millisecond),
+		DefaultTimeout(SlowCommand, 10*time.Minute),
+		DefaultTimeout(NavigationCommand, 30*time.Minute))
+
+	if _, err := SendAndWait(ctx, "DOM.hang", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SendAndWait(fast command) error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	SendAndWait(ctx, "Page.printToPDF", nil)
+	SendAndWait(ctx, "Page.navigate", nil)
+	sooner, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	SendAndWait(sooner, "Runtime.evaluate", nil)
+
+	want := map[string]time.Duration{
+		"DOM.hang":         0,
+		"Page.printToPDF":  10 * time.Minute,
+		"Page.navigate":    30 * time.Minute,
+		"Runtime.evaluate": 2 * time.Minute,
+	}
+	for method, d := range want {
+		if deadlines[method] != d {
+			t.Errorf("deadline of %q = %v, want %v", method, deadlines[method], d)
+		}
+	}
+}
+
+func TestClassifyCommand(t *testing.T) {
+	tests := map[string]CommandClass{
+		"DOM.querySelector":           FastCommand,
+		"DOMSnapshot.captureSnapshot": SlowCommand,
+		"Page.navigate":               NavigationCommand,
+	}
+	for method, want := range tests {
+		if got := ClassifyCommand(method); got != want {
+			t.Errorf("ClassifyCommand(%q) = %v, want %v", method, got, want)
+		}
+	}
+}
+
+func TestDefaultTimeoutUnblocksQueue(t *testing.T) {
+	ctx := NewFakeContext(context.Background(), nil, DefaultTimeout(FastCommand, time.Hour))
+	s, _ := FromContext(ctx)
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	defer w.Close()
+	go io.Copy(ioutil.Discard, r)
+	s.browserInputWriter = w
+	s.msgLog = log.New(ioutil.Discard, "", 0)
+	s.msgID = 1
+	s.msgQ = make(chan asyncMessage)
+	defer close(s.msgQ)
+	go sendMessages(s)
+
+	// The browser never responds to the first two commands.
+	for i := 0; i < 2; i++ {
+		cmdCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		_, err := sendAndReceive(cmdCtx, "DOM.hang", nil)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("sendAndReceive(%d) error = %v, want %v", i, err, context.DeadlineExceeded)
+		}
+	}
+	go func() {
+		for {
+			s.responseMu.Lock()
+			_, ok := s.responseSubscribers[3]
+			s.responseMu.Unlock()
+			if ok {
+				parseAndRelay(s, []byte(`{"id":3,"result":{}}`))
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	if _, err := sendAndReceive(ctx, "DOM.ok", nil); err != nil {
+		t.Fatalf("sendAndReceive() after timeouts; got error: %v", err)
+	}
+	s.responseMu.Lock()
+	defer s.responseMu.Unlock()
+	if n := len(s.responseSubscribers); n != 0 {
+		t.Errorf("%d response subscribers left, want 0", n)
+	}
+}
@@ -0,0 +1,41 @@
+package devtools
+
+import (
+	"math"
+	"time"
+)
+
+// TimeSinceEpoch is a CDP timestamp: UTC time in seconds, counted from
+// January 1, 1970, with a fractional part (e.g. "Network.TimeSinceEpoch"
+// and "Input.TimeSinceEpoch"). It's defined here, and aliased in the
+// generated CDP domain packages, to avoid circular dependencies between
+// them (https://crbug.com/1193242).
+type TimeSinceEpoch float64
+
+// NewTimeSinceEpoch converts a Go time into a CDP timestamp,
+// e.g. for the expiration time of cookies.
+func NewTimeSinceEpoch(t time.Time) TimeSinceEpoch {
+	return TimeSinceEpoch(float64(t.Unix()) + float64(t.Nanosecond())/1e9)
+}
+
+// Time converts the CDP timestamp into a Go time, in UTC. Timestamps
+// of Chrome have microsecond precision at most, so the result is
+// rounded to the nearest microsecond, to discard floating-point noise.
+func (t TimeSinceEpoch) Time() time.Time {
+	sec, frac := math.Modf(float64(t))
+	return time.Unix(int64(sec), int64(math.Round(frac*1e6))*1e3).UTC()
+}
+
+// MonotonicTime is a CDP timestamp: monotonically increasing time in
+// seconds since an arbitrary point in the past, with a fractional part
+// (e.g. "Network.MonotonicTime"). It's useful only for measuring intervals
+// between timestamps of the same browser, see also the `devtools.TimeSinceEpoch`
+// type. It's defined here for the same reason.
+type MonotonicTime float64
+
+// Duration returns the time since the arbitrary point in the past, rounded
+// to the nearest microsecond. The difference between two monotonic
+// timestamps is `t2.Duration() - t1.Duration()`.
+func (t MonotonicTime) Duration() time.Duration {
+	return time.Duration(math.Round(float64(t)*1e6)) * time.Microsecond
+}
@@ -0,0 +1,40 @@
+package devtools
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeSinceEpoch(t *testing.T) {
+	var v struct {
+		WallTime TimeSinceEpoch `json:"wallTime"`
+	}
+	if err := json.Unmarshal([]byte(`{"wallTime":1700000000.123456}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2023, 11, 14, 22, 13, 20, 123456000, time.UTC)
+	if got := v.WallTime.Time(); !got.Equal(want) {
+		t.Errorf("TimeSinceEpoch.Time() = %v, want %v", got, want)
+	}
+	if got := NewTimeSinceEpoch(want).Time(); !got.Equal(want) {
+		t.Errorf("NewTimeSinceEpoch(%v).Time() = %v", want, got)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); got != `{"wallTime":1700000000.123456}` {
+		t.Errorf("json.Marshal() = %s", got)
+	}
+}
+
+func TestMonotonicTime(t *testing.T) {
+	start, end := MonotonicTime(12345.678901), MonotonicTime(12346.1789)
+	if got, want := start.Duration(), 12345678901*time.Microsecond; got != want {
+		t.Errorf("MonotonicTime.Duration() = %v, want %v", got, want)
+	}
+	if got, want := end.Duration()-start.Duration(), 499999*time.Microsecond; got != want {
+		t.Errorf("interval = %v, want %v", got, want)
+	}
+}
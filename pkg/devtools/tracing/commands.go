@@ -179,13 +179,23 @@ type RequestMemoryDump struct {
 
 // NewRequestMemoryDump constructs a new RequestMemoryDump struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `RequestMemoryDumpOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#method-requestMemoryDump
-func NewRequestMemoryDump() *RequestMemoryDump {
-	return &RequestMemoryDump{}
+func NewRequestMemoryDump(opts ...RequestMemoryDumpOption) *RequestMemoryDump {
+	c := &RequestMemoryDump{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// RequestMemoryDumpOption is a functional option for the optional
+// parameters of the RequestMemoryDump CDP command, see the
+// NewRequestMemoryDump constructor. Options are applied in order.
+type RequestMemoryDumpOption = func(*RequestMemoryDump)
+
 // SetDeterministic adds or modifies the value of the optional
 // parameter `deterministic` in the RequestMemoryDump CDP command.
 //
@@ -195,6 +205,14 @@ func (t *RequestMemoryDump) SetDeterministic(v bool) *RequestMemoryDump {
 	return t
 }
 
+// RequestMemoryDumpWithDeterministic is a functional option which sets the value of the
+// optional parameter `deterministic` in the RequestMemoryDump CDP command, see SetDeterministic.
+func RequestMemoryDumpWithDeterministic(v bool) RequestMemoryDumpOption {
+	return func(c *RequestMemoryDump) {
+		c.SetDeterministic(v)
+	}
+}
+
 // SetLevelOfDetail adds or modifies the value of the optional
 // parameter `levelOfDetail` in the RequestMemoryDump CDP command.
 //
@@ -204,6 +222,14 @@ func (t *RequestMemoryDump) SetLevelOfDetail(v MemoryDumpLevelOfDetail) *Request
 	return t
 }
 
+// RequestMemoryDumpWithLevelOfDetail is a functional option which sets the value of the
+// optional parameter `levelOfDetail` in the RequestMemoryDump CDP command, see SetLevelOfDetail.
+func RequestMemoryDumpWithLevelOfDetail(v MemoryDumpLevelOfDetail) RequestMemoryDumpOption {
+	return func(c *RequestMemoryDump) {
+		c.SetLevelOfDetail(v)
+	}
+}
+
 // RequestMemoryDumpResult contains the browser's response
 // to calling the RequestMemoryDump CDP command with Do().
 type RequestMemoryDumpResult struct {
@@ -289,13 +315,23 @@ type Start struct {
 
 // NewStart constructs a new Start struct instance, with
 // all (but only) the required parameters. Optional parameters
-// may be added using the builder-like methods below.
+// may be added using the builder-like methods below, or with
+// functional options (see `StartOption`).
 //
 // https://chromedevtools.github.io/devtools-protocol/tot/Tracing/#method-start
-func NewStart() *Start {
-	return &Start{}
+func NewStart(opts ...StartOption) *Start {
+	c := &Start{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
+// StartOption is a functional option for the optional
+// parameters of the Start CDP command, see the
+// NewStart constructor. Options are applied in order.
+type StartOption = func(*Start)
+
 // SetCategories adds or modifies the value of the optional
 // parameter `categories` in the Start CDP command.
 //
@@ -307,6 +343,14 @@ func (t *Start) SetCategories(v string) *Start {
 	return t
 }
 
+// StartWithCategories is a functional option which sets the value of the
+// optional parameter `categories` in the Start CDP command, see SetCategories.
+func StartWithCategories(v string) StartOption {
+	return func(c *Start) {
+		c.SetCategories(v)
+	}
+}
+
 // SetOptions adds or modifies the value of the optional
 // parameter `options` in the Start CDP command.
 //
@@ -318,6 +362,14 @@ func (t *Start) SetOptions(v string) *Start {
 	return t
 }
 
+// StartWithOptions is a functional option which sets the value of the
+// optional parameter `options` in the Start CDP command, see SetOptions.
+func StartWithOptions(v string) StartOption {
+	return func(c *Start) {
+		c.SetOptions(v)
+	}
+}
+
 // SetBufferUsageReportingInterval adds or modifies the value of the optional
 // parameter `bufferUsageReportingInterval` in the Start CDP command.
 //
@@ -327,6 +379,14 @@ func (t *Start) SetBufferUsageReportingInterval(v float64) *Start {
 	return t
 }
 
+// StartWithBufferUsageReportingInterval is a functional option which sets the value of the
+// optional parameter `bufferUsageReportingInterval` in the Start CDP command, see SetBufferUsageReportingInterval.
+func StartWithBufferUsageReportingInterval(v float64) StartOption {
+	return func(c *Start) {
+		c.SetBufferUsageReportingInterval(v)
+	}
+}
+
 // SetTransferMode adds or modifies the value of the optional
 // parameter `transferMode` in the Start CDP command.
 //
@@ -337,6 +397,14 @@ func (t *Start) SetTransferMode(v string) *Start {
 	return t
 }
 
+// StartWithTransferMode is a functional option which sets the value of the
+// optional parameter `transferMode` in the Start CDP command, see SetTransferMode.
+func StartWithTransferMode(v string) StartOption {
+	return func(c *Start) {
+		c.SetTransferMode(v)
+	}
+}
+
 // SetStreamFormat adds or modifies the value of the optional
 // parameter `streamFormat` in the Start CDP command.
 //
@@ -347,6 +415,14 @@ func (t *Start) SetStreamFormat(v StreamFormat) *Start {
 	return t
 }
 
+// StartWithStreamFormat is a functional option which sets the value of the
+// optional parameter `streamFormat` in the Start CDP command, see SetStreamFormat.
+func StartWithStreamFormat(v StreamFormat) StartOption {
+	return func(c *Start) {
+		c.SetStreamFormat(v)
+	}
+}
+
 // SetStreamCompression adds or modifies the value of the optional
 // parameter `streamCompression` in the Start CDP command.
 //
@@ -357,6 +433,14 @@ func (t *Start) SetStreamCompression(v StreamCompression) *Start {
 	return t
 }
 
+// StartWithStreamCompression is a functional option which sets the value of the
+// optional parameter `streamCompression` in the Start CDP command, see SetStreamCompression.
+func StartWithStreamCompression(v StreamCompression) StartOption {
+	return func(c *Start) {
+		c.SetStreamCompression(v)
+	}
+}
+
 // SetTraceConfig adds or modifies the value of the optional
 // parameter `traceConfig` in the Start CDP command.
 func (t *Start) SetTraceConfig(v TraceConfig) *Start {
@@ -364,6 +448,14 @@ func (t *Start) SetTraceConfig(v TraceConfig) *Start {
 	return t
 }
 
+// StartWithTraceConfig is a functional option which sets the value of the
+// optional parameter `traceConfig` in the Start CDP command, see SetTraceConfig.
+func StartWithTraceConfig(v TraceConfig) StartOption {
+	return func(c *Start) {
+		c.SetTraceConfig(v)
+	}
+}
+
 // SetPerfettoConfig adds or modifies the value of the optional
 // parameter `perfettoConfig` in the Start CDP command.
 //
@@ -375,6 +467,14 @@ func (t *Start) SetPerfettoConfig(v string) *Start {
 	return t
 }
 
+// StartWithPerfettoConfig is a functional option which sets the value of the
+// optional parameter `perfettoConfig` in the Start CDP command, see SetPerfettoConfig.
+func StartWithPerfettoConfig(v string) StartOption {
+	return func(c *Start) {
+		c.SetPerfettoConfig(v)
+	}
+}
+
 // SetTracingBackend adds or modifies the value of the optional
 // parameter `tracingBackend` in the Start CDP command.
 //
@@ -384,6 +484,14 @@ func (t *Start) SetTracingBackend(v Backend) *Start {
 	return t
 }
 
+// StartWithTracingBackend is a functional option which sets the value of the
+// optional parameter `tracingBackend` in the Start CDP command, see SetTracingBackend.
+func StartWithTracingBackend(v Backend) StartOption {
+	return func(c *Start) {
+		c.SetTracingBackend(v)
+	}
+}
+
 // Do sends the Start CDP command to a browser,
 // and returns the browser's response.
 func (t *Start) Do(ctx context.Context) error {
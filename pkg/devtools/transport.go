@@ -2,15 +2,31 @@ package devtools
 
 import (
 	"bufio"
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"regexp"
+
+	"github.com/daabr/chrome-vision/pkg/websocket"
 )
 
+var errNotInitialized = errors.New("context not initialized with devtools.NewContext")
+
+// ErrResponseTooLarge is returned by the `devtools.SendAndWait` function
+// (and by the CDP commands in the domain sub-packages) when the browser's
+// response exceeds the maximum message size (see the `devtools.MaxMessageSize`
+// session option). The response is discarded while it's being received, so
+// it doesn't consume memory. Callers may fall back to CDP commands which
+// return large data as streams, e.g. `fetch.TakeResponseBodyAsStream`.
+var ErrResponseTooLarge = errors.New("CDP response exceeds the maximum message size")
+
+// DefaultMaxMessageSize is the default maximum size of incoming CDP messages,
+// unless the `devtools.MaxMessageSize` session option overrides it.
+const DefaultMaxMessageSize = 256 * 1024 * 1024
+
 // Error details passed within a CDP response message.
 type Error struct {
 	Code    int64  `json:"code"`
@@ -33,11 +49,25 @@ type Message struct {
 	Params    json.RawMessage `json:"params,omitempty"`
 	Result    json.RawMessage `json:"result,omitempty"`
 	Error     *Error          `json:"error,omitempty"`
+
+	// Metadata of incoming event messages, which is not a part of the CDP
+	// message itself: the ID of the target that sent the event (based on the
+	// message's session ID), and a sequence number of the event within the
+	// browser's connection, to restore their order if they're received
+	// from multiple channels.
+	TargetID string `json:"-"`
+	Seq      uint64 `json:"-"`
+
+	// Local error which replaces the response, e.g. `devtools.ErrResponseTooLarge`.
+	err error
 }
 
 type asyncMessage struct {
 	requestMsg   Message
 	responseChan chan<- *Message
+	// Closed when the caller stops waiting for the response (nil if it never
+	// does), so the sender can move on to subsequent messages.
+	done <-chan struct{}
 }
 
 // Parse and relay incoming CDP messages.
@@ -56,74 +86,182 @@ func parseAndRelay(s *Session, b []byte) {
 	if len(m.Method) == 0 {
 		// Solicited response: relay to the request caller.
 		log.Printf("Received response: ID %d (%d bytes)", m.ID, len(b))
-		if ch, ok := s.responseSubscribers[m.ID]; ok {
-			ch <- m
+		if m.Error != nil {
+			s.metrics.commandFailed(m.Error.Code)
 		}
+		s.relayResponse(m)
 	} else {
 		// Unsolicited event: relay to any subscribers.
 		log.Printf("Received event: %q (%d bytes)", m.Method, len(b))
-		if subscribers, ok := s.eventSubscribers[m.Method]; ok {
-			for _, ch := range subscribers {
-				ch <- m
-			}
-			switch len(subscribers) {
-			case 1:
-				log.Printf("Relayed to 1 subscriber")
-			default:
-				log.Printf("Relayed to %d subscribers", len(subscribers))
-			}
+		relayEvent(s, m)
+	}
+}
+
+// Relay an incoming event message to its subscribers.
+func relayEvent(s *Session, m *Message) {
+	s.metrics.eventReceived(m.Method)
+	s.subscribersMu.Lock()
+	s.eventSeq++
+	m.Seq = s.eventSeq
+	s.targets.track(m)
+	m.TargetID = s.targets.lookup(m.SessionID)
+	subscribers := append([]*Subscription(nil), s.eventSubscribers[m.Method]...)
+	s.subscribersMu.Unlock()
+
+	// Deliver without holding the lock, because delivery may block until a
+	// subscriber receives the event, and it may call functions which take
+	// the lock (e.g. `devtools.Subscribe`) before doing so.
+	if len(subscribers) > 0 {
+		for _, sub := range subscribers {
+			sub.deliver(m)
+		}
+		switch len(subscribers) {
+		case 1:
+			log.Printf("Relayed to 1 subscriber")
+		default:
+			log.Printf("Relayed to %d subscribers", len(subscribers))
 		}
 	}
 }
 
-const (
-	// Source: `startBufSize` in https://golang.org/src/bufio/scan.go.
-	startScannerSize = 4096
-	// Replaces `Scanner.MaxScanTokenSize` (65536 = 16 KiB) with 1 MiB.
-	maxScannerSize = 1024 * 1024
-)
+// Number of bytes to keep from the beginning of oversized messages,
+// in order to identify them.
+const tooLargePrefixSize = 256
 
 // Asynchronously receive incoming CDP messages from the browser through a
 // POSIX pipe on non-Windows operating systems, as long as the pipe is open.
 // Called as a goroutine in the `start` function in `browser.go`.
 func receiveFromPipe(s *Session) {
-	// This scanner wraps the browser's POSIX pipe, which is closed when the
-	// browser process ends (see the goroutine at the bottom of the `start`
-	// function in `browser.go`).
-	scanner := bufio.NewScanner(s.browserOutputReader)
-	// A capacity of `Scanner.MaxScanTokenSize` (16 KiB) is too small.
-	scanner.Buffer(make([]byte, startScannerSize, maxScannerSize), maxScannerSize)
-	scanner.Split(scanMessages)
-	for scanner.Scan() {
-		b := scanner.Bytes()
+	// The browser's POSIX pipe is closed when the browser process ends (see
+	// the goroutine at the bottom of the `start` function in `browser.go`).
+	err := readMessages(s.browserOutputReader, s.maxMessageSize, func(b []byte) {
 		parseAndRelay(s, b)
+	}, func(prefix []byte, size int) {
+		relayTooLarge(s, prefix, size)
+	})
+	if err != nil && err != io.EOF {
+		log.Printf("WARNING: failed to read incoming CDP message: %v", err)
 	}
 }
 
-// Helper function based on `bufio.ScanLines`, using \0 instead of \n as a
-// separator - see https://golang.org/pkg/bufio/#example_Scanner_custom.
-func scanMessages(data []byte, atEOF bool) (int, []byte, error) {
-	if atEOF && len(data) == 0 {
-		return 0, nil, nil
+// Read \0-terminated messages from the given reader until it fails, and pass
+// them to the relay function. Messages which exceed the given maximum size
+// (if positive) are discarded while they're being read, and only their
+// beginning is passed to the tooLarge function, along with their size.
+// Both functions must not retain the message after they return, because
+// its buffer is reused.
+func readMessages(r io.Reader, max int, relay func([]byte), tooLarge func([]byte, int)) error {
+	br := bufio.NewReader(r)
+	bp := getBuffer()
+	msg := *bp
+	defer func() {
+		putBuffer(bp, msg)
+	}()
+	size := 0
+	for {
+		b, err := br.ReadSlice('\000')
+		if err != nil && err != bufio.ErrBufferFull {
+			// A final, non-terminated message.
+			if size+len(b) > 0 {
+				msg, size = appendMessage(msg, b, size, max)
+				deliverMessage(msg, size, max, relay, tooLarge)
+			}
+			return err
+		}
+		if err == nil {
+			b = b[:len(b)-1] // Remove the \0 separator.
+		}
+		msg, size = appendMessage(msg, b, size, max)
+		if err == nil {
+			deliverMessage(msg, size, max, relay, tooLarge)
+			// Don't hold onto the memory of large messages.
+			if cap(msg) > maxPooledBuffer {
+				msg = nil
+			}
+			msg, size = msg[:0], 0
+		}
 	}
-	if i := bytes.IndexByte(data, '\000'); i >= 0 {
-		// We have a full \0-terminated message.
-		return i + 1, data[0:i], nil
+}
+
+// Append a chunk to a message, unless the message has already exceeded the
+// maximum size, in which case only its beginning is kept.
+func appendMessage(msg, chunk []byte, size, max int) ([]byte, int) {
+	size += len(chunk)
+	if max > 0 && size > max {
+		if len(msg) > tooLargePrefixSize {
+			msg = append([]byte(nil), msg[:tooLargePrefixSize]...)
+		}
+		if n := tooLargePrefixSize - len(msg); n > 0 {
+			if n > len(chunk) {
+				n = len(chunk)
+			}
+			msg = append(msg, chunk[:n]...)
+		}
+		return msg, size
 	}
-	// If we're at EOF, we have a final, non-terminated message. Return it.
-	if atEOF {
-		return len(data), data, nil
+	return append(msg, chunk...), size
+}
+
+func deliverMessage(msg []byte, size, max int, relay func([]byte), tooLarge func([]byte, int)) {
+	if max > 0 && size > max {
+		tooLarge(msg, size)
+		return
+	}
+	relay(msg)
+}
+
+// The ID of solicited response messages, which is the first field in them.
+var responseIDPattern = regexp.MustCompile(`^\s*\{\s*"id"\s*:\s*(\d+)`)
+
+// Report an oversized incoming CDP message: if it's a response, relay an
+// error to the request caller instead; if it's an event, drop it.
+func relayTooLarge(s *Session, prefix []byte, size int) {
+	s.msgLog.Printf("<- %s... (%d bytes, discarded)\n", prefix, size)
+	match := responseIDPattern.FindSubmatch(prefix)
+	if match == nil {
+		log.Printf("WARNING: dropped oversized CDP event message (%d bytes)", size)
+		return
+	}
+	m := &Message{err: fmt.Errorf("%w (%d bytes)", ErrResponseTooLarge, size)}
+	fmt.Sscan(string(match[1]), &m.ID)
+	m.Error = &Error{Message: m.err.Error()}
+	log.Printf("WARNING: discarded oversized response: ID %d (%d bytes)", m.ID, size)
+	s.relayResponse(m)
+}
+
+// Relay a response message to its subscriber, if it's still waiting for it.
+// Subscriber channels are buffered, so this never blocks.
+func (s *Session) relayResponse(m *Message) {
+	s.responseMu.Lock()
+	defer s.responseMu.Unlock()
+	if ch, ok := s.responseSubscribers[m.ID]; ok {
+		select {
+		case ch <- m:
+		default:
+			log.Printf("WARNING: discarded duplicate response: ID %d", m.ID)
+		}
 	}
-	// Request more data.
-	return 0, nil, nil
 }
 
 // Asynchronously receive incoming CDP messages from the browser through a
 // WebSocket on Windows operating systems, as long as the connection is open.
 // Called as a goroutine in the `start` function in `browser.go`.
 func receiveFromWebSocket(s *Session) {
+	bp := getBuffer()
+	b := *bp
+	defer func() {
+		putBuffer(bp, b)
+	}()
 	for {
-		b, err := s.webSocket.Read()
+		var err error
+		if cap(b) > maxPooledBuffer {
+			b = nil // Don't hold onto the memory of large messages.
+		}
+		b, err = s.webSocket.ReadAppend(b[:0])
+		if err == websocket.ErrMessageTooLarge {
+			relayTooLarge(s, b, s.maxMessageSize+1)
+			continue
+		}
 		if err != nil {
 			if err == io.EOF {
 				continue
@@ -155,7 +293,9 @@ func preSend(s *Session, async *asyncMessage) ([]byte, error) {
 		return nil, errors.New(m.Error.Message)
 	}
 
-	s.responseSubscribers[s.msgID] = make(chan *Message)
+	s.responseMu.Lock()
+	s.responseSubscribers[s.msgID] = make(chan *Message, 1)
+	s.responseMu.Unlock()
 	log.Printf("Sending: %s", b)
 	return b, nil
 }
@@ -163,14 +303,42 @@ func preSend(s *Session, async *asyncMessage) ([]byte, error) {
 func postSend(s *Session, async asyncMessage, b []byte) {
 	// Wait for the response, clean-up, and relay back to the caller of devtools.Send.
 	s.msgLog.Printf("-> %s\n", b)
-	m := <-s.responseSubscribers[s.msgID]
+	s.responseMu.Lock()
+	ch := s.responseSubscribers[s.msgID]
+	s.responseMu.Unlock()
 
-	close(s.responseSubscribers[s.msgID])
-	delete(s.responseSubscribers, m.ID)
+	var m *Message
+	select {
+	case m = <-ch:
+	case <-async.done:
+		// The caller stopped waiting (e.g. due to a default timeout, see
+		// `devtools.DefaultTimeout`): don't block subsequent messages.
+		// If the response arrives later, it's discarded.
+		err := fmt.Errorf("no response to %q command", async.requestMsg.Method)
+		m = &Message{ID: s.msgID, Error: &Error{Message: err.Error()}, err: err}
+	}
+
+	s.responseMu.Lock()
+	delete(s.responseSubscribers, s.msgID)
+	s.responseMu.Unlock()
 
 	async.responseChan <- m
 }
 
+// Send queued CDP messages to the browser, one at a time, until the queue
+// is closed when the browser process ends (see the goroutine at the bottom
+// of the start function in browser.go). Called as a goroutine in `session.go`.
+func sendMessages(s *Session) {
+	for asyncMsg := range s.msgQ {
+		if s.webSocket == nil {
+			sendToPipe(s, asyncMsg)
+		} else {
+			sendToWebSocket(s, asyncMsg)
+		}
+		s.msgID++
+	}
+}
+
 // Construct and send CDP messages to the browser through a POSIX pipe on non-Windows
 // operating systems, in a thread-safe manner (https://blog.golang.org/codelab-share).
 // Called in a goroutine in `session.go` as long as the browser is running.
@@ -237,38 +405,137 @@ func sendToWebSocket(s *Session, async asyncMessage) {
 func Send(ctx context.Context, method string, params json.RawMessage) (chan *Message, error) {
 	s, ok := FromContext(ctx)
 	if !ok {
-		return nil, errors.New("context not initialized with devtools.NewContext")
+		return nil, errNotInitialized
+	}
+	if len(s.middleware) == 0 {
+		return send(ctx, method, params)
 	}
+	h := s.handler()
+	ch := make(chan *Message)
+	go func() {
+		m, err := h(ctx, method, params)
+		if err != nil {
+			m = &Message{err: err}
+		}
+		ch <- m
+	}()
+	return ch, nil
+}
+
+// Implementation of `devtools.Send`, without middleware.
+func send(ctx context.Context, method string, params json.RawMessage) (chan *Message, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return nil, errNotInitialized
+	}
+	if s.firefox && !firefoxCommands[method] {
+		return nil, fmt.Errorf("%w: %s", ErrNotSupported, method)
+	}
+	if s.showInteractions {
+		showInteraction(ctx, method, params)
+	}
+	s.metrics.commandSent(method)
 	// https://github.com/aslushnikov/getting-started-with-cdp#targets--sessions
 	m := &Message{Method: method, SessionID: s.SessionID.Read(), Params: params}
 	ch := make(chan *Message)
+	done := s.commandDone(ctx)
 	// https://blog.golang.org/codelab-share
-	s.msgQ <- asyncMessage{requestMsg: *m, responseChan: ch}
+	select {
+	case s.msgQ <- asyncMessage{requestMsg: *m, responseChan: ch, done: done}:
+	case <-done:
+		return nil, fmt.Errorf("%q command not sent: %w", method, ctx.Err())
+	}
 	return ch, nil
 }
 
 // SendAndWait constructs and sends a CDP message to the browser associated
 // with the given context, and returns the browser's response message when
 // it arrives. Multiple goroutines may call this function simultaneously.
+//
+// If the response exceeds the maximum message size, this function returns
+// an error which wraps `devtools.ErrResponseTooLarge` instead. In sessions
+// with default timeouts (see the `devtools.DefaultTimeout` option), if the
+// context is done before the response arrives, it returns an error which
+// wraps the context's error.
 func SendAndWait(ctx context.Context, method string, params json.RawMessage) (*Message, error) {
-	ch, err := Send(ctx, method, params)
+	s, ok := FromContext(ctx)
+	if !ok {
+		return nil, errNotInitialized
+	}
+	ctx, cancel := s.withCommandTimeout(ctx, method)
+	defer cancel()
+	m, err := s.handler()(ctx, method, params)
+	if err == nil {
+		s.commands.record(s.SessionID.Read(), method, params, m)
+	}
+	return m, err
+}
+
+// Marshal the given parameters (if not nil), send them in a CDP message to
+// the browser associated with the given context, wait for the response, and
+// parse its result into the given struct (if not nil). This is used by
+// features in this package which can't use the CDP domain sub-packages,
+// in order to avoid circular dependencies.
+func sendAndParse(ctx context.Context, method string, params, result interface{}) error {
+	var b json.RawMessage
+	if params != nil {
+		var err error
+		if b, err = json.Marshal(params); err != nil {
+			return err
+		}
+	}
+	response, err := SendAndWait(ctx, method, b)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if response.Error != nil {
+		return errors.New(response.Error.Error())
+	}
+	if result == nil {
+		return nil
 	}
-	defer close(ch)
-	return <-ch, nil
+	return json.Unmarshal(response.Result, result)
 }
 
 // SubscribeEvent returns a channel to receive event messages of
 // the given type from the browser associated with the given context.
+//
+// The channel must be drained continuously, until it's passed to the
+// `devtools.UnsubscribeEvent` function, because undelivered events
+// block the delivery of all subsequent messages from the browser.
+// See also the `devtools.Subscribe` function, which supports buffering
+// and other overflow policies.
 func SubscribeEvent(ctx context.Context, name string) (chan *Message, error) {
 	s, ok := FromContext(ctx)
 	if !ok {
-		return nil, errors.New("context not initialized with devtools.NewContext")
+		return nil, errNotInitialized
 	}
-	ch := make(chan *Message)
-	s.eventSubscribers[name] = append(s.eventSubscribers[name], ch)
-	return ch, nil
+	sub := &Subscription{policy: OverflowBlock}
+	s.subscribe(sub, name)
+	return sub.ch, nil
 }
 
-// TODO: unsubscribe.
+// UnsubscribeEvent stops the delivery of event messages of the given type
+// to the given channel, which was returned by the `devtools.SubscribeEvent`
+// function, and closes it. Callers must not close the channel on their own.
+func UnsubscribeEvent(ctx context.Context, name string, ch chan *Message) error {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return errNotInitialized
+	}
+	stop := drain(ch)
+	defer stop()
+	var found *Subscription
+	s.subscribersMu.Lock()
+	for _, sub := range s.eventSubscribers[name] {
+		if sub.ch == ch {
+			found = sub
+			break
+		}
+	}
+	s.subscribersMu.Unlock()
+	if found != nil {
+		found.end(nil)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package devtools
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadMessages(t *testing.T) {
+	big := `{"id":7,"result":{"outerHTML":"` + strings.Repeat("x", 2*bufio.MaxScanTokenSize) + `"}}`
+	r := strings.NewReader(`{"id":1}` + "\000" + big + "\000" + `{"id":2}` + "\000" + `{"id":3}`)
+	var got []string
+	var tooLarge []int
+	err := readMessages(r, bufio.MaxScanTokenSize, func(b []byte) {
+		got = append(got, string(b))
+	}, func(prefix []byte, size int) {
+		if len(prefix) != tooLargePrefixSize || !responseIDPattern.Match(prefix) {
+			t.Errorf("readMessages() oversized message prefix = %q", prefix)
+		}
+		tooLarge = append(tooLarge, size)
+	})
+	if err != io.EOF {
+		t.Errorf("readMessages() = %v, want %v", err, io.EOF)
+	}
+	want := []string{`{"id":1}`, `{"id":2}`, `{"id":3}`}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("readMessages() mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]int{len(big)}, tooLarge); diff != "" {
+		t.Errorf("readMessages() oversized messages mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func BenchmarkReadMessages(b *testing.B) {
+	msg := `{"method":"Network.dataReceived","params":{"requestId":"1000.1","timestamp":1.5,"dataLength":` +
+		strings.Repeat("1", 512) + `,"encodedDataLength":0}}` + "\000"
+	data := strings.Repeat(msg, 100)
+	r := strings.NewReader(data)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		r.Reset(data)
+		n := 0
+		err := readMessages(r, bufio.MaxScanTokenSize, func(m []byte) {
+			n++
+		}, func([]byte, int) {})
+		if err != io.EOF || n != 100 {
+			b.Fatalf("readMessages() = %v, relayed %d messages", err, n)
+		}
+	}
+}
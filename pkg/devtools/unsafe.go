@@ -0,0 +1,78 @@
+package devtools
+
+import (
+	"context"
+	"fmt"
+	"log"
+)
+
+// Browser flags which disable web security features, applied by the
+// `devtools.UnsafeRelaxSecurity` session option.
+var unsafeSecurityFlags = map[string]interface{}{
+	// Same-origin policy and CORS checks.
+	"disable-web-security": true,
+	// TLS certificate errors, e.g. self-signed or expired certificates.
+	"ignore-certificate-errors": true,
+	// Active mixed content, i.e. scripts and iframes with "http" URLs
+	// in "https" pages.
+	"allow-running-insecure-content": true,
+}
+
+// UnsafeRelaxSecurity allows the caller of the `devtools.NewContext` function
+// to disable web security features in the browser and in all of its tabs, to
+// test web apps in internal sandboxes (e.g. with self-signed certificates,
+// or backends on different origins without CORS headers):
+//
+// • Content Security Policy (CSP) checks are bypassed
+//
+// • TLS certificate errors are ignored
+//
+// • The same-origin policy is disabled
+//
+// • Mixed content (e.g. "http" scripts in "https" pages) is allowed
+//
+// NEVER use this option to browse untrusted content, or with a browser
+// profile which has real credentials. The browser flags are ignored when
+// connecting to a remote browser (see `devtools.RemoteBrowser`).
+func UnsafeRelaxSecurity() SessionOption {
+	return func(s *Session) {
+		s.unsafeSecurity = true
+	}
+}
+
+// Adjust the browser flags before starting the browser, if the caller
+// specified the `devtools.UnsafeRelaxSecurity` session option.
+func adjustUnsafeSecurityFlags(s *Session) {
+	for k, v := range unsafeSecurityFlags {
+		s.browserFlags[k] = v
+	}
+	log.Print("WARNING: UNSAFE browser flags which disable web security: " +
+		"--allow-running-insecure-content --disable-web-security --ignore-certificate-errors")
+}
+
+// Apply the `devtools.UnsafeRelaxSecurity` session option to the tab
+// which is associated with the given context.
+func applyUnsafeSecurity(ctx context.Context) error {
+	// https://chromedevtools.github.io/devtools-protocol/tot/Page/#method-setBypassCSP
+	// (we don't use the page sub-package to avoid circular dependencies).
+	params := struct {
+		Enabled bool `json:"enabled"`
+	}{true}
+	if err := sendAndParse(ctx, "Page.setBypassCSP", params, nil); err != nil {
+		return fmt.Errorf(`"Page.setBypassCSP" command error: %v`, err)
+	}
+
+	// https://chromedevtools.github.io/devtools-protocol/tot/Security/#method-setIgnoreCertificateErrors
+	// (we don't use the security sub-package to avoid circular dependencies).
+	ignore := struct {
+		Ignore bool `json:"ignore"`
+	}{true}
+	if err := sendAndParse(ctx, "Security.setIgnoreCertificateErrors", ignore, nil); err != nil {
+		return fmt.Errorf(`"Security.setIgnoreCertificateErrors" command error: %v`, err)
+	}
+
+	s, _ := FromContext(ctx)
+	log.Printf("WARNING: UNSAFE security relaxations in target %s: CSP bypassed, certificate errors ignored",
+		s.TargetID.Read())
+	return nil
+}
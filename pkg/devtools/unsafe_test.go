@@ -0,0 +1,42 @@
+package devtools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAdjustUnsafeSecurityFlags(t *testing.T) {
+	s := &Session{browserFlags: map[string]interface{}{"headless": true}}
+	UnsafeRelaxSecurity()(s)
+	adjustUnsafeSecurityFlags(s)
+	want := map[string]interface{}{
+		"headless":                       true,
+		"allow-running-insecure-content": true,
+		"disable-web-security":           true,
+		"ignore-certificate-errors":      true,
+	}
+	if diff := cmp.Diff(want, s.browserFlags); diff != "" {
+		t.Errorf("adjustUnsafeSecurityFlags() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestApplyUnsafeSecurity(t *testing.T) {
+	got := map[string]string{}
+	ctx := NewFakeContext(context.Background(), func(_ context.Context, method string, params json.RawMessage) (*Message, error) {
+		got[method] = string(params)
+		return &Message{}, nil
+	})
+	if err := applyUnsafeSecurity(ctx); err != nil {
+		t.Fatalf("applyUnsafeSecurity(); got error: %v", err)
+	}
+	want := map[string]string{
+		"Page.setBypassCSP":                   `{"enabled":true}`,
+		"Security.setIgnoreCertificateErrors": `{"ignore":true}`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("applyUnsafeSecurity() mismatch (-want +got):\n%s", diff)
+	}
+}
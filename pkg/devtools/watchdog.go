@@ -0,0 +1,329 @@
+package devtools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ErrResourceLimit is wrapped by the errors of the `devtools.Healthy` and
+// `devtools.ResourceUsage` functions after the browser's process tree
+// exceeded the limits of its watchdog (see the `devtools.Watchdog` option).
+var ErrResourceLimit = errors.New("browser exceeded its resource limits")
+
+// ResourceReading is a measurement of the resource usage of a locally-started
+// browser's process tree, see the `devtools.ResourceUsage` function.
+type ResourceReading struct {
+	Time time.Time
+	// Number of processes: the browser, renderers, GPU, utilities, etc.
+	Processes int
+	// Cumulative CPU time of all the processes, since they started.
+	CPUTime time.Duration
+	// Average CPU usage since the previous reading, in cores (e.g.
+	// 1.5 means one and a half cores were busy), or 0 in the first reading.
+	CPU float64
+	// Total resident set size (physical memory) of all the processes, in
+	// bytes. Memory which is shared between them is counted more than once.
+	RSS int64
+	// Largest resident set size of a single process, and its ID,
+	// e.g. to identify a runaway renderer.
+	MaxProcessRSS int64
+	MaxProcessID  int
+}
+
+// Resource usage of a single process, see `listProcesses`.
+type processStat struct {
+	pid, ppid, pgid int
+	cpuTime         time.Duration
+	rss             int64
+}
+
+type watchdog struct {
+	interval   time.Duration
+	maxCPU     float64
+	cpuGrace   time.Duration
+	maxRSS     int64
+	reportOnly bool
+	onExceeded func(error)
+
+	mu       sync.Mutex
+	last     *ResourceReading
+	cpuSince time.Time // When the CPU usage started exceeding its limit.
+	err      error
+}
+
+// WatchdogOption is used for customization in the `devtools.Watchdog`
+// session option.
+type WatchdogOption = func(*watchdog)
+
+// MaxCPU allows the caller of the `devtools.Watchdog` session option to limit
+// the CPU usage of the browser's process tree to the given number of cores
+// (e.g. 2), when it's exceeded continuously for at least the given duration,
+// so short bursts (e.g. page loads) are allowed.
+func MaxCPU(cores float64, d time.Duration) WatchdogOption {
+	return func(w *watchdog) {
+		w.maxCPU, w.cpuGrace = cores, d
+	}
+}
+
+// MaxRSS allows the caller of the `devtools.Watchdog` session option to limit
+// the total resident set size (physical memory) of the browser's process
+// tree to the given number of bytes.
+func MaxRSS(bytes int64) WatchdogOption {
+	return func(w *watchdog) {
+		w.maxRSS = bytes
+	}
+}
+
+// ReportOnly allows the caller of the `devtools.Watchdog` session option to
+// only log and report the first limit violation (see `devtools.Healthy`),
+// and stop monitoring, instead of killing the browser.
+func ReportOnly() WatchdogOption {
+	return func(w *watchdog) {
+		w.reportOnly = true
+	}
+}
+
+// OnLimitExceeded allows the caller of the `devtools.Watchdog` session option
+// to be notified when the browser exceeds its limits, before it's killed
+// (unless the `devtools.ReportOnly` option is specified), e.g. to start a
+// replacement session. The error wraps `devtools.ErrResourceLimit`. The
+// function is called at most once, in the watchdog's goroutine.
+func OnLimitExceeded(f func(error)) WatchdogOption {
+	return func(w *watchdog) {
+		w.onExceeded = f
+	}
+}
+
+// Watchdog allows the caller of the `devtools.NewContext` function to monitor
+// the CPU and memory usage of a locally-started browser's process tree at the
+// given interval (see `devtools.ResourceUsage`), and to kill the browser's
+// process group when it exceeds the limits in the given options (see
+// `devtools.MaxCPU` and `devtools.MaxRSS`), which protects shared CI hosts
+// from runaway renderers. Killing the browser ends the session, like a
+// browser crash, and the `devtools.Healthy` function reports why.
+//
+// Process trees are read from procfs on Linux, and with the "ps" command on
+// other POSIX-compliant operating systems. This is ignored on Windows, and
+// with remote browsers (see the `devtools.RemoteBrowser` option), and when
+// the `devtools.NewContext` function opens a new tab in an existing browser.
+func Watchdog(interval time.Duration, opts ...WatchdogOption) SessionOption {
+	return func(s *Session) {
+		w := &watchdog{interval: interval}
+		for _, opt := range opts {
+			opt(w)
+		}
+		s.watchdog = w
+	}
+}
+
+// ResourceUsage returns the latest reading of the watchdog of the browser
+// which is associated with the given context (see the `devtools.Watchdog`
+// option), or an error if it doesn't have a watchdog or readings yet. After
+// the browser exceeded its limits, it returns the last reading, and an
+// error which wraps `devtools.ErrResourceLimit`.
+func ResourceUsage(ctx context.Context) (*ResourceReading, error) {
+	s, ok := FromContext(ctx)
+	if !ok {
+		return nil, errNotInitialized
+	}
+	if s.watchdog == nil {
+		return nil, errors.New("the browser doesn't have a watchdog")
+	}
+	w := s.watchdog
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.last == nil {
+		return nil, errors.New("no resource usage readings yet")
+	}
+	r := *w.last
+	return &r, w.err
+}
+
+// Read the resource usage of the browser's process tree at the
+// watchdog's interval, until the given context is done, or until
+// the browser exceeds its limits.
+func runWatchdog(ctx context.Context, s *Session) {
+	w := s.watchdog
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		procs, err := listProcesses()
+		if err != nil {
+			log.Printf("Watchdog stopped: failed to list processes: %v", err)
+			return
+		}
+		err = w.record(treeUsage(s.process.Pid, procs, time.Now()))
+		if err == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Watchdog: %v", err)
+		s.health.fail(err)
+		if w.onExceeded != nil {
+			w.onExceeded(err)
+		}
+		if !w.reportOnly {
+			log.Printf("Watchdog: sending SIGKILL to browser PID %d", s.process.Pid)
+			if err := signalProcessGroup(s.process, syscall.SIGKILL); err != nil {
+				log.Printf("Failed to send SIGKILL: %v", err)
+			}
+		}
+		return
+	}
+}
+
+// Store the given reading, with the average CPU usage since the previous
+// one, and return an error if it exceeds the watchdog's limits.
+func (w *watchdog) record(r *ResourceReading) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev := w.last
+	if prev != nil {
+		if elapsed := r.Time.Sub(prev.Time); elapsed > 0 && r.CPUTime >= prev.CPUTime {
+			r.CPU = float64(r.CPUTime-prev.CPUTime) / float64(elapsed)
+		}
+	}
+	w.last = r
+
+	switch {
+	case w.maxRSS > 0 && r.RSS > w.maxRSS:
+		w.err = fmt.Errorf("%w: RSS of %d processes is %d bytes (limit: %d), largest: PID %d with %d bytes",
+			ErrResourceLimit, r.Processes, r.RSS, w.maxRSS, r.MaxProcessID, r.MaxProcessRSS)
+	case w.maxCPU > 0 && r.CPU > w.maxCPU:
+		if w.cpuSince.IsZero() {
+			w.cpuSince = prev.Time // r.CPU is the average since then.
+		}
+		if d := r.Time.Sub(w.cpuSince); d >= w.cpuGrace {
+			w.err = fmt.Errorf("%w: CPU usage of %d processes is %.2f cores for %v (limit: %.2f cores for %v)",
+				ErrResourceLimit, r.Processes, r.CPU, d.Round(time.Second), w.maxCPU, w.cpuGrace)
+		}
+	default:
+		w.cpuSince = time.Time{}
+	}
+	return w.err
+}
+
+// Sum the resource usage of the given root process, its descendants,
+// and other processes in its process group.
+func treeUsage(root int, procs []processStat, now time.Time) *ResourceReading {
+	children := make(map[int][]int)
+	for _, p := range procs {
+		children[p.ppid] = append(children[p.ppid], p.pid)
+	}
+	inTree := map[int]bool{root: true}
+	queue := []int{root}
+	for len(queue) > 0 {
+		pid := queue[0]
+		queue = queue[1:]
+		for _, c := range children[pid] {
+			if !inTree[c] {
+				inTree[c] = true
+				queue = append(queue, c)
+			}
+		}
+	}
+
+	r := &ResourceReading{Time: now}
+	for _, p := range procs {
+		if !inTree[p.pid] && p.pgid != root {
+			continue
+		}
+		r.Processes++
+		r.CPUTime += p.cpuTime
+		r.RSS += p.rss
+		if p.rss > r.MaxProcessRSS {
+			r.MaxProcessRSS, r.MaxProcessID = p.rss, p.pid
+		}
+	}
+	return r
+}
+
+// Linux reports CPU times in clock ticks, whose frequency (USER_HZ)
+// is 100 per second on all the architectures which Chrome supports.
+const clockTicks = 100
+
+// Parse the content of a "/proc/<pid>/stat" file, see
+// https://man7.org/linux/man-pages/man5/proc.5.html.
+func parseProcStat(b string) (processStat, error) {
+	// The command name (2nd field) may contain spaces and parentheses.
+	i := strings.LastIndexByte(b, ')')
+	if i < 0 {
+		return processStat{}, errors.New("missing command name")
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(b[:strings.IndexByte(b, '(')]))
+	if err != nil {
+		return processStat{}, err
+	}
+	// Fields from the 3rd one (state).
+	f := strings.Fields(b[i+1:])
+	if len(f) < 22 {
+		return processStat{}, fmt.Errorf("only %d fields", len(f)+2)
+	}
+	p := processStat{pid: pid}
+	ints := make([]int64, 0, 5)
+	for _, j := range []int{1, 2, 11, 12, 21} { // ppid, pgrp, utime, stime, rss.
+		n, err := strconv.ParseInt(f[j], 10, 64)
+		if err != nil {
+			return processStat{}, err
+		}
+		ints = append(ints, n)
+	}
+	p.ppid, p.pgid = int(ints[0]), int(ints[1])
+	p.cpuTime = time.Duration(ints[2]+ints[3]) * time.Second / clockTicks
+	p.rss = ints[4] * int64(os.Getpagesize())
+	return p, nil
+}
+
+// Parse a line of the output of "ps -A -o pid=,ppid=,pgid=,rss=,time=",
+// where the RSS is in KiB, and the CPU time is formatted
+// as "[[dd-]hh:]mm:ss[.cc]".
+func parsePSLine(line string) (processStat, error) {
+	f := strings.Fields(line)
+	if len(f) != 5 {
+		return processStat{}, fmt.Errorf("%d fields instead of 5", len(f))
+	}
+	var ints [4]int64
+	for i := range ints {
+		n, err := strconv.ParseInt(f[i], 10, 64)
+		if err != nil {
+			return processStat{}, err
+		}
+		ints[i] = n
+	}
+	p := processStat{pid: int(ints[0]), ppid: int(ints[1]), pgid: int(ints[2]), rss: ints[3] * 1024}
+
+	t := f[4]
+	days := 0.0
+	if i := strings.IndexByte(t, '-'); i >= 0 {
+		d, err := strconv.ParseFloat(t[:i], 64)
+		if err != nil {
+			return processStat{}, err
+		}
+		days, t = d, t[i+1:]
+	}
+	secs := 0.0
+	for _, part := range strings.Split(t, ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return processStat{}, err
+		}
+		secs = secs*60 + n
+	}
+	p.cpuTime = time.Duration((days*86400 + secs) * float64(time.Second))
+	return p, nil
+}
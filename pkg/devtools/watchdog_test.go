@@ -0,0 +1,101 @@
+package devtools
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseProcStat(t *testing.T) {
+	stat := "4242 (chrome (renderer)) S 4200 4100 4100 0 -1 4194560 100 0 0 0 250 50 0 0 20 0 8 0 1000 1234567 300 18446744073709551615"
+	got, err := parseProcStat(stat)
+	if err != nil {
+		t.Fatalf("parseProcStat(); got error: %v", err)
+	}
+	want := processStat{pid: 4242, ppid: 4200, pgid: 4100, cpuTime: 3 * time.Second, rss: 300 * int64(os.Getpagesize())}
+	if got != want {
+		t.Errorf("parseProcStat() = %+v, want %+v", got, want)
+	}
+	if _, err := parseProcStat("4242 (chrome) S 1 2"); err == nil {
+		t.Error("parseProcStat(truncated); got nil error")
+	}
+}
+
+func TestParsePSLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want processStat
+	}{
+		{"  4242  4200  4100  2048   0:03.50", processStat{4242, 4200, 4100, 3500 * time.Millisecond, 2 << 20}},
+		{"1 0 1 10 01:02:03", processStat{1, 0, 1, time.Hour + 2*time.Minute + 3*time.Second, 10240}},
+		{"1 0 1 10 2-00:00:01", processStat{1, 0, 1, 48*time.Hour + time.Second, 10240}},
+	}
+	for _, tt := range tests {
+		got, err := parsePSLine(tt.line)
+		if err != nil {
+			t.Errorf("parsePSLine(%q); got error: %v", tt.line, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parsePSLine(%q) = %+v, want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestTreeUsage(t *testing.T) {
+	procs := []processStat{
+		{pid: 1, ppid: 0, pgid: 1, cpuTime: time.Hour, rss: 1 << 30},
+		{pid: 100, ppid: 1, pgid: 100, cpuTime: time.Second, rss: 100},
+		{pid: 101, ppid: 100, pgid: 100, cpuTime: 2 * time.Second, rss: 500},
+		{pid: 102, ppid: 101, pgid: 100, cpuTime: 3 * time.Second, rss: 200},
+		// Reparented to init, but still in the browser's process group.
+		{pid: 103, ppid: 1, pgid: 100, cpuTime: 4 * time.Second, rss: 50},
+		{pid: 200, ppid: 1, pgid: 200, cpuTime: time.Minute, rss: 1 << 20},
+	}
+	now := time.Now()
+	got := treeUsage(100, procs, now)
+	want := &ResourceReading{Time: now, Processes: 4, CPUTime: 10 * time.Second, RSS: 850, MaxProcessRSS: 500, MaxProcessID: 101}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("treeUsage() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWatchdogRecord(t *testing.T) {
+	t0 := time.Now()
+	reading := func(secs int, cpu time.Duration, rss int64) *ResourceReading {
+		return &ResourceReading{Time: t0.Add(time.Duration(secs) * time.Second), Processes: 3, CPUTime: cpu, RSS: rss}
+	}
+
+	w := &watchdog{}
+	MaxCPU(1.5, 2*time.Second)(w)
+	MaxRSS(1000)(w)
+	steps := []struct {
+		r       *ResourceReading
+		wantCPU float64
+		wantErr bool
+	}{
+		{reading(0, 0, 100), 0, false},
+		{reading(1, 2*time.Second, 100), 2, false}, // Over the CPU limit for 1s.
+		{reading(2, 3*time.Second, 100), 1, false}, // Back under it.
+		{reading(3, 5*time.Second, 100), 2, false}, // Over it again, for 1s.
+		{reading(4, 7*time.Second, 100), 2, true},  // For 2s.
+	}
+	for i, s := range steps {
+		err := w.record(s.r)
+		if s.r.CPU != s.wantCPU {
+			t.Errorf("record(step %d) CPU = %v, want %v", i, s.r.CPU, s.wantCPU)
+		}
+		if (err != nil) != s.wantErr || (err != nil && !errors.Is(err, ErrResourceLimit)) {
+			t.Errorf("record(step %d) = %v, want error: %v", i, err, s.wantErr)
+		}
+	}
+
+	w = &watchdog{}
+	MaxRSS(1000)(w)
+	if err := w.record(reading(0, 0, 1001)); !errors.Is(err, ErrResourceLimit) {
+		t.Errorf("record(RSS 1001) = %v, want %v", err, ErrResourceLimit)
+	}
+}
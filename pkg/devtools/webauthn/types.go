@@ -82,7 +82,7 @@ type VirtualAuthenticatorOptions struct {
 	HasMinPinLength bool `json:"hasMinPinLength,omitempty"`
 	// If set to true, tests of user presence will succeed immediately.
 	// Otherwise, they will not be resolved. Defaults to true.
-	AutomaticPresenceSimulation bool `json:"automaticPresenceSimulation,omitempty"`
+	AutomaticPresenceSimulation *bool `json:"automaticPresenceSimulation,omitempty"`
 	// Sets whether User Verification succeeds or fails for an authenticator.
 	// Defaults to false.
 	IsUserVerified bool `json:"isUserVerified,omitempty"`
@@ -0,0 +1,260 @@
+// Package expect provides polling assertions for tests which automate web
+// pages with the devtools package. Each assertion retries until its condition
+// is met or a timeout expires, instead of relying on sleeps, which are
+// either too short (flaky) or too long (slow):
+//
+//	func TestLogin(t *testing.T) {
+//		...
+//		e := expect.With(t)
+//		e.Text(ctx, "h1").Contains("Welcome")
+//		e.URL(ctx).Matches(regexp.MustCompile(`/home$`))
+//		e.Count(ctx, "li.notification").Equals(3)
+//	}
+//
+// Assertions which are created with `expect.With` fail the test with
+// `t.Fatalf`. The package-level functions only return errors.
+package expect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/dom"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// Default values for the polling of assertions.
+const (
+	DefaultTimeout  = 5 * time.Second
+	DefaultInterval = 100 * time.Millisecond
+)
+
+// Expect creates assertions with common settings, see `expect.With`.
+type Expect struct {
+	t        testing.TB
+	timeout  time.Duration
+	interval time.Duration
+}
+
+var std = &Expect{timeout: DefaultTimeout, interval: DefaultInterval}
+
+// With returns an `Expect` whose assertions fail the given test (or
+// benchmark) with `t.Fatalf` when they time out, in addition to
+// returning an error.
+func With(t testing.TB) *Expect {
+	return &Expect{t: t, timeout: DefaultTimeout, interval: DefaultInterval}
+}
+
+// Within returns a copy of e whose assertions time out after the
+// given duration (default: `expect.DefaultTimeout`), or when their
+// context is done, whichever comes first.
+func (e *Expect) Within(d time.Duration) *Expect {
+	c := *e
+	c.timeout = d
+	return &c
+}
+
+// Every returns a copy of e whose assertions retry with the given
+// interval (default: `expect.DefaultInterval`).
+func (e *Expect) Every(d time.Duration) *Expect {
+	c := *e
+	c.interval = d
+	return &c
+}
+
+// Text returns an assertion on the rendered text (`innerText`) of the first
+// element which matches the given selector (see `dom.Query`), in the main
+// frame of the page associated with the given context.
+func (e *Expect) Text(ctx context.Context, selector string) *StringAssertion {
+	return &StringAssertion{e: e, ctx: ctx, subject: fmt.Sprintf("text of %q", selector),
+		get: func(ctx context.Context) (string, error) { return innerText(ctx, selector) }}
+}
+
+// URL returns an assertion on the URL of the page
+// associated with the given context.
+func (e *Expect) URL(ctx context.Context) *StringAssertion {
+	return &StringAssertion{e: e, ctx: ctx, subject: "URL", get: func(ctx context.Context) (string, error) {
+		var u string
+		err := runtime.Eval(ctx, "location.href", &u)
+		return u, err
+	}}
+}
+
+// Count returns an assertion on the number of elements which match the
+// given selector (see `dom.QueryAll`), in the main frame of the page
+// associated with the given context.
+func (e *Expect) Count(ctx context.Context, selector string) *IntAssertion {
+	return &IntAssertion{e: e, ctx: ctx, subject: fmt.Sprintf("count of %q", selector),
+		get: func(ctx context.Context) (int, error) {
+			hs, err := dom.QueryAll(ctx, selector)
+			return len(hs), err
+		}}
+}
+
+// Text is like `Expect.Text`, for assertions which only return errors.
+func Text(ctx context.Context, selector string) *StringAssertion {
+	return std.Text(ctx, selector)
+}
+
+// URL is like `Expect.URL`, for assertions which only return errors.
+func URL(ctx context.Context) *StringAssertion {
+	return std.URL(ctx)
+}
+
+// Count is like `Expect.Count`, for assertions which only return errors.
+func Count(ctx context.Context, selector string) *IntAssertion {
+	return std.Count(ctx, selector)
+}
+
+// StringAssertion is a pending assertion on a string value in a page.
+type StringAssertion struct {
+	e       *Expect
+	ctx     context.Context
+	subject string
+	get     func(context.Context) (string, error)
+}
+
+// Equals waits until the value is equal to the given string.
+func (a *StringAssertion) Equals(s string) error {
+	a.helper()
+	return a.poll(fmt.Sprintf("to equal %q", s), func(v string) bool { return v == s })
+}
+
+// Contains waits until the value contains the given substring.
+func (a *StringAssertion) Contains(s string) error {
+	a.helper()
+	return a.poll(fmt.Sprintf("to contain %q", s), func(v string) bool { return strings.Contains(v, s) })
+}
+
+// Matches waits until the value matches the given regular expression.
+func (a *StringAssertion) Matches(re *regexp.Regexp) error {
+	a.helper()
+	return a.poll(fmt.Sprintf("to match %q", re), re.MatchString)
+}
+
+func (a *StringAssertion) helper() {
+	if a.e.t != nil {
+		a.e.t.Helper()
+	}
+}
+
+func (a *StringAssertion) poll(want string, ok func(string) bool) error {
+	a.helper()
+	return a.e.poll(a.ctx, a.subject, want, func(ctx context.Context) (bool, string, error) {
+		v, err := a.get(ctx)
+		return err == nil && ok(v), fmt.Sprintf("%q", v), err
+	})
+}
+
+// IntAssertion is a pending assertion on an integer value in a page.
+type IntAssertion struct {
+	e       *Expect
+	ctx     context.Context
+	subject string
+	get     func(context.Context) (int, error)
+}
+
+// Equals waits until the value is equal to the given number.
+func (a *IntAssertion) Equals(n int) error {
+	a.helper()
+	return a.poll(fmt.Sprintf("to equal %d", n), func(v int) bool { return v == n })
+}
+
+// AtLeast waits until the value is greater than or equal to the given number.
+func (a *IntAssertion) AtLeast(n int) error {
+	a.helper()
+	return a.poll(fmt.Sprintf("to be at least %d", n), func(v int) bool { return v >= n })
+}
+
+// AtMost waits until the value is less than or equal to the given number.
+func (a *IntAssertion) AtMost(n int) error {
+	a.helper()
+	return a.poll(fmt.Sprintf("to be at most %d", n), func(v int) bool { return v <= n })
+}
+
+func (a *IntAssertion) helper() {
+	if a.e.t != nil {
+		a.e.t.Helper()
+	}
+}
+
+func (a *IntAssertion) poll(want string, ok func(int) bool) error {
+	a.helper()
+	return a.e.poll(a.ctx, a.subject, want, func(ctx context.Context) (bool, string, error) {
+		v, err := a.get(ctx)
+		return err == nil && ok(v), fmt.Sprintf("%d", v), err
+	})
+}
+
+// Call the given check function repeatedly, until it succeeds, or the timeout
+// expires, or the context is done. Errors of the check function are retried
+// too, e.g. when the element doesn't exist yet.
+func (e *Expect) poll(ctx context.Context, subject, want string, check func(context.Context) (bool, string, error)) error {
+	if e.t != nil {
+		e.t.Helper()
+	}
+	ctx, cancel := context.WithTimeout(ctx, e.timeout)
+	defer cancel()
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	start := time.Now()
+	var last string
+	var lastErr error
+	for {
+		ok, v, err := check(ctx)
+		if ok {
+			return nil
+		}
+		if err != nil {
+			lastErr = err
+		} else {
+			last, lastErr = v, nil
+		}
+		select {
+		case <-ticker.C:
+			continue
+		case <-ctx.Done():
+		}
+		d := time.Since(start).Round(time.Millisecond)
+		if lastErr != nil {
+			err = fmt.Errorf("expected %s %s, timed out after %v: %w", subject, want, d, lastErr)
+		} else {
+			err = fmt.Errorf("expected %s %s, timed out after %v: got %s", subject, want, d, last)
+		}
+		if e.t != nil {
+			e.t.Fatalf("%v", err)
+		}
+		return err
+	}
+}
+
+// Return the rendered text of the first element which matches the selector.
+func innerText(ctx context.Context, selector string) (string, error) {
+	h, err := dom.Query(ctx, selector, dom.NoRequery())
+	if err != nil {
+		return "", err
+	}
+	obj, err := h.ObjectID(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer runtime.NewReleaseObject(string(obj)).Do(ctx)
+	r, err := runtime.NewCallFunctionOn("function() { return this.innerText; }").
+		SetObjectID(string(obj)).SetReturnByValue(true).Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	if r.ExceptionDetails != nil {
+		return "", r.ExceptionDetails
+	}
+	var s string
+	if err := json.Unmarshal(r.Result.Value, &s); err != nil {
+		return "", err
+	}
+	return s, nil
+}
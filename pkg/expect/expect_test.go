@@ -0,0 +1,85 @@
+package expect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeT records fatal failures, instead of stopping the test.
+type fakeT struct {
+	testing.TB
+	failures []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Fatalf(format string, args ...interface{}) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func TestStringAssertion(t *testing.T) {
+	// The value becomes "Welcome back" on the third poll.
+	polls := 0
+	get := func(context.Context) (string, error) {
+		polls++
+		switch {
+		case polls == 1:
+			return "", errors.New("no element matches the selector")
+		case polls < 3:
+			return "Loading...", nil
+		default:
+			return "Welcome back", nil
+		}
+	}
+	ft := &fakeT{}
+	e := With(ft).Every(time.Millisecond)
+	a := &StringAssertion{e: e, ctx: context.Background(), subject: "text", get: get}
+
+	if err := a.Contains("Welcome"); err != nil {
+		t.Errorf("Contains(); got error: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("Contains() polled %d times, want 3", polls)
+	}
+	if err := a.Matches(regexp.MustCompile(`^Welcome \w+$`)); err != nil {
+		t.Errorf("Matches(); got error: %v", err)
+	}
+	if len(ft.failures) > 0 {
+		t.Errorf("fatal failures: %q", ft.failures)
+	}
+}
+
+func TestAssertionTimeout(t *testing.T) {
+	ft := &fakeT{}
+	e := With(ft).Within(20 * time.Millisecond).Every(time.Millisecond)
+	a := &IntAssertion{e: e, ctx: context.Background(), subject: `count of "li"`,
+		get: func(context.Context) (int, error) { return 2, nil }}
+
+	err := a.Equals(3)
+	if err == nil || !strings.Contains(err.Error(), `expected count of "li" to equal 3, timed out after`) ||
+		!strings.HasSuffix(err.Error(), "got 2") {
+		t.Errorf("Equals() error = %v", err)
+	}
+	if len(ft.failures) != 1 {
+		t.Errorf("Equals() fatal failures = %q, want 1", ft.failures)
+	}
+	if err := a.AtMost(2); err != nil {
+		t.Errorf("AtMost(); got error: %v", err)
+	}
+}
+
+func TestAssertionError(t *testing.T) {
+	e := std.Within(10 * time.Millisecond).Every(time.Millisecond)
+	wantErr := errors.New("no element matches the selector")
+	a := &StringAssertion{e: e, ctx: context.Background(), subject: "text",
+		get: func(context.Context) (string, error) { return "", wantErr }}
+
+	if err := a.Equals("x"); !errors.Is(err, wantErr) {
+		t.Errorf("Equals() error = %v, want wrapped %v", err, wantErr)
+	}
+}
@@ -0,0 +1,124 @@
+// Package facade provides an optional object-oriented facade on top of the
+// Chrome DevTools Protocol (CDP) bindings in the devtools package, for users
+// who prefer method-style APIs (e.g. when migrating from chromedp or rod)
+// over command structs:
+//
+//	ctx, err := devtools.NewContext(context.Background())
+//	...
+//	p := facade.Page(ctx)
+//	if _, err := p.Navigate("https://example.com"); err != nil {
+//		...
+//	}
+//	title := ""
+//	err = p.Eval("document.title", &title)
+//	png, err := p.Screenshot()
+//
+// The methods are thin wrappers of existing functions and generated commands
+// in the devtools sub-packages, and they can be mixed freely with them,
+// using the same context (see the `Tab.Context` method).
+//
+// The devtools package itself doesn't provide this facade, because it can't
+// depend on its sub-packages without circular dependencies.
+package facade
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/dom"
+	"github.com/daabr/chrome-vision/pkg/devtools/emulation"
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// Tab is a method-style facade of the page which is associated with
+// a context, see the `facade.Page` function. It's a lightweight value
+// without state of its own, so multiple goroutines may use it
+// simultaneously, to the extent that the page allows it.
+type Tab struct {
+	ctx context.Context
+}
+
+// Page returns a facade of the page associated with the given context,
+// which was initialized with the `devtools.NewContext` function. Methods
+// fail with the same errors as the functions they wrap if it wasn't.
+func Page(ctx context.Context) *Tab {
+	return &Tab{ctx: ctx}
+}
+
+// Context returns the context of the page, to use it with functions and
+// commands in the devtools sub-packages which the facade doesn't wrap.
+func (t *Tab) Context() context.Context {
+	return t.ctx
+}
+
+// Navigate navigates the page to the given URL, and returns the details of
+// the main resource's response. It returns as soon as the response is
+// received (see the `Tab.WaitForLoad` method). HTTP error statuses are
+// returned as errors, see the `page.NavigateChecked` function.
+func (t *Tab) Navigate(url string) (*page.Navigation, error) {
+	return page.NavigateChecked(t.ctx, url)
+}
+
+// WaitForLoad waits until the main frame of the page fires its "load"
+// event, see the `page.WaitForLoad` function.
+func (t *Tab) WaitForLoad() error {
+	return page.WaitForLoad(t.ctx)
+}
+
+// Screenshot captures a screenshot of the page, as a PNG image by default,
+// see the `emulation.CaptureScreenshot` function and its options.
+func (t *Tab) Screenshot(opts ...emulation.ScreenshotOption) ([]byte, error) {
+	return emulation.CaptureScreenshot(t.ctx, opts...)
+}
+
+// PDF prints the page as a PDF document, with the `page.PrintToPDF` CDP
+// command and its options (e.g. `page.PrintToPDFWithLandscape`), and
+// returns its content. This works only in headless mode.
+func (t *Tab) PDF(opts ...page.PrintToPDFOption) ([]byte, error) {
+	r, err := page.NewPrintToPDF(opts...).Do(t.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(r.Data)
+}
+
+// Query returns a handle to the first element which matches the given
+// selector in the main frame of the page, see the `dom.Query` function.
+func (t *Tab) Query(selector string, opts ...dom.QueryOption) (*dom.ElementHandle, error) {
+	return dom.Query(t.ctx, selector, opts...)
+}
+
+// Click clicks the first element which matches the given selector in the
+// main frame of the page, see the `ElementHandle.Click` method in the
+// dom package.
+func (t *Tab) Click(selector string) error {
+	h, err := dom.Query(t.ctx, selector)
+	if err != nil {
+		return err
+	}
+	return h.Click(t.ctx)
+}
+
+// Eval evaluates the given JavaScript expression in the main frame of the
+// page, and parses its JSON value into v (unless v is nil), see the
+// `runtime.Eval` function.
+func (t *Tab) Eval(expression string, v interface{}) error {
+	return runtime.Eval(t.ctx, expression, v)
+}
+
+// Cookies returns the browser cookies for the given URLs, or for the URLs
+// of the page and all of its subframes if none are specified, with the
+// `network.GetCookies` CDP command.
+func (t *Tab) Cookies(urls ...string) ([]network.Cookie, error) {
+	c := network.NewGetCookies()
+	if len(urls) > 0 {
+		c.SetURLs(urls)
+	}
+	r, err := c.Do(t.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.Cookies, nil
+}
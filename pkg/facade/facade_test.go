@@ -0,0 +1,43 @@
+package facade
+
+import (
+	"context"
+	"testing"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTab(t *testing.T) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	b.Respond("Runtime.evaluate", map[string]interface{}{"result": map[string]interface{}{"type": "string", "value": "Title"}})
+	b.Respond("Page.printToPDF", map[string]string{"data": "JVBERi0="})
+	b.Respond("Network.getCookies", map[string]interface{}{"cookies": []map[string]interface{}{{"name": "a", "value": "1"}}})
+	p := Page(ctx)
+
+	title := ""
+	if err := p.Eval("document.title", &title); err != nil || title != "Title" {
+		t.Errorf("Eval() = %q, %v, want %q", title, err, "Title")
+	}
+	pdf, err := p.PDF(page.PrintToPDFWithLandscape(true))
+	if err != nil || string(pdf) != "%PDF-" {
+		t.Errorf("PDF() = %q, %v, want %q", pdf, err, "%PDF-")
+	}
+	cookies, err := p.Cookies("https://a.com/")
+	if err != nil || len(cookies) != 1 || cookies[0].Name != "a" {
+		t.Errorf("Cookies() = %+v, %v", cookies, err)
+	}
+
+	var params []string
+	for _, c := range b.Calls("Page.printToPDF", "Network.getCookies") {
+		params = append(params, string(c.Params))
+	}
+	want := []string{`{"landscape":true}`, `{"urls":["https://a.com/"]}`}
+	if diff := cmp.Diff(want, params); diff != "" {
+		t.Errorf("parameters mismatch (-want +got):\n%s", diff)
+	}
+	if p.Context() != ctx {
+		t.Errorf("Context() isn't the page's context")
+	}
+}
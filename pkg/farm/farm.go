@@ -0,0 +1,234 @@
+// Package farm routes Chrome DevTools Protocol (CDP) sessions across a pool
+// of remote browsers, for horizontal scale-out without an external grid
+// component: it load-balances new sessions between healthy browsers,
+// checks their health periodically, and fails over to other browsers
+// when connecting to one of them fails.
+package farm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+)
+
+// ErrNoHealthyBrowsers is returned by `Farm.NewContext` when none of the
+// remote browsers is healthy and below its session limit.
+var ErrNoHealthyBrowsers = errors.New("no healthy remote browsers available")
+
+// Farm is a client-side scheduler for a pool of remote browsers.
+// Multiple goroutines may use the same farm simultaneously.
+type Farm struct {
+	healthInterval time.Duration
+	maxSessions    int
+
+	mu        sync.Mutex
+	endpoints []*endpoint
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+type endpoint struct {
+	addr     string
+	healthy  bool
+	sessions int
+	lastErr  error
+}
+
+// Status describes the state of a single remote browser in a farm.
+type Status struct {
+	Addr     string
+	Healthy  bool
+	Sessions int
+	// The error of the last failed health check or connection, if any.
+	LastErr error
+}
+
+// Option is used for customization in the `farm.New` function.
+//
+// See https://commandcenter.blogspot.com/2014/01/self-referential-functions-and-design.html.
+type Option = func(*Farm)
+
+// HealthInterval allows the caller of the `farm.New` function to customize
+// the interval between health checks of the remote browsers (default:
+// 10 seconds).
+func HealthInterval(d time.Duration) Option {
+	return func(f *Farm) {
+		f.healthInterval = d
+	}
+}
+
+// MaxSessionsPerBrowser allows the caller of the `farm.New` function to
+// limit the number of concurrent sessions (i.e. tabs) in each remote
+// browser (default: 0, which means no limit).
+func MaxSessionsPerBrowser(n int) Option {
+	return func(f *Farm) {
+		f.maxSessions = n
+	}
+}
+
+// New constructs a new farm of the remote browsers which listen for DevTools
+// connections on the given addresses ("host:port"), and starts checking their
+// health in the background, until the `Close` method is called. All the
+// browsers are considered healthy until their first health check.
+func New(addrs []string, opts ...Option) *Farm {
+	f := &Farm{
+		healthInterval: 10 * time.Second,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+	}
+	for _, a := range addrs {
+		f.endpoints = append(f.endpoints, &endpoint{addr: a, healthy: true})
+	}
+	for _, o := range opts {
+		o(f)
+	}
+	go f.monitor()
+	return f
+}
+
+// Close stops the background health checks. It doesn't close
+// any sessions, which end when their contexts are done.
+func (f *Farm) Close() {
+	close(f.stop)
+	<-f.done
+}
+
+// Check the health of all the browsers periodically, until the farm is closed.
+func (f *Farm) monitor() {
+	defer close(f.done)
+	t := time.NewTicker(f.healthInterval)
+	defer t.Stop()
+	f.CheckHealth()
+	for {
+		select {
+		case <-t.C:
+			f.CheckHealth()
+		case <-f.stop:
+			return
+		}
+	}
+}
+
+// CheckHealth checks the health of all the remote browsers in the farm
+// immediately and concurrently, instead of waiting for the next periodic
+// check.
+func (f *Farm) CheckHealth() {
+	f.mu.Lock()
+	eps := append([]*endpoint(nil), f.endpoints...)
+	f.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, ep := range eps {
+		wg.Add(1)
+		go func(ep *endpoint) {
+			defer wg.Done()
+			_, err := devtools.RemoteVersion(context.Background(), ep.addr)
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if err != nil && ep.healthy {
+				log.Printf("Remote browser %s is unhealthy: %v", ep.addr, err)
+			}
+			if err == nil && !ep.healthy {
+				log.Printf("Remote browser %s is healthy again", ep.addr)
+			}
+			ep.healthy = err == nil
+			if err != nil {
+				ep.lastErr = err
+			}
+		}(ep)
+	}
+	wg.Wait()
+}
+
+// Status returns a snapshot of the state of all the remote browsers in the farm.
+func (f *Farm) Status() []Status {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s := make([]Status, len(f.endpoints))
+	for i, ep := range f.endpoints {
+		s[i] = Status{Addr: ep.addr, Healthy: ep.healthy, Sessions: ep.sessions, LastErr: ep.lastErr}
+	}
+	return s
+}
+
+// Reserve a slot in the healthy browser with the fewest sessions, excluding
+// the given ones which have already failed, and return its endpoint.
+func (f *Farm) reserve(exclude map[*endpoint]bool) *endpoint {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var best *endpoint
+	for _, ep := range f.endpoints {
+		if !ep.healthy || exclude[ep] || (f.maxSessions > 0 && ep.sessions >= f.maxSessions) {
+			continue
+		}
+		if best == nil || ep.sessions < best.sessions {
+			best = ep
+		}
+	}
+	if best != nil {
+		best.sessions++
+	}
+	return best
+}
+
+func (f *Farm) release(ep *endpoint, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ep.sessions--
+	if err != nil {
+		// Don't route more sessions to this browser until it passes a health check.
+		ep.healthy = false
+		ep.lastErr = err
+	}
+}
+
+// NewContext constructs a new `devtools.Session` in a new tab of the healthy
+// remote browser with the fewest sessions, like the `devtools.NewContext`
+// function with the `devtools.RemoteBrowser` session option. If connecting
+// to that browser fails, it's marked as unhealthy, and this method fails over
+// to the next one. The session's slot in the browser is released when the
+// returned context is done.
+//
+// The parent context must not carry a CDP session already. To open more
+// tabs in the same browser, call `devtools.NewContext` with the returned
+// context instead.
+func (f *Farm) NewContext(parent context.Context, opts ...devtools.SessionOption) (context.Context, error) {
+	if _, ok := devtools.FromContext(parent); ok {
+		return parent, errors.New("parent context already carries a CDP session")
+	}
+	failed := make(map[*endpoint]bool)
+	var errs []string
+	for {
+		ep := f.reserve(failed)
+		if ep == nil {
+			if len(errs) > 0 {
+				return parent, fmt.Errorf("%w: %s", ErrNoHealthyBrowsers, strings.Join(errs, "; "))
+			}
+			return parent, ErrNoHealthyBrowsers
+		}
+		o := append(append([]devtools.SessionOption(nil), opts...), devtools.RemoteBrowser(ep.addr))
+		ctx, err := devtools.NewContext(parent, o...)
+		if err != nil {
+			log.Printf("Failed to connect to remote browser %s: %v", ep.addr, err)
+			f.release(ep, err)
+			failed[ep] = true
+			errs = append(errs, fmt.Sprintf("%s: %v", ep.addr, err))
+			if parent.Err() != nil {
+				return parent, parent.Err()
+			}
+			continue
+		}
+		go func() {
+			<-ctx.Done()
+			f.release(ep, nil)
+		}()
+		return ctx, nil
+	}
+}
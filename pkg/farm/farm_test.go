@@ -0,0 +1,60 @@
+package farm_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/farm"
+)
+
+// A fake remote browser which responds to health checks,
+// but doesn't accept WebSocket connections.
+func newFakeBrowser(t *testing.T, healthy bool) string {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy || r.URL.Path != "/json/version" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"Browser":"HeadlessChrome/99.0","webSocketDebuggerUrl":"ws://127.0.0.1:1/devtools/browser/x"}`)
+	}))
+	t.Cleanup(s.Close)
+	return strings.TrimPrefix(s.URL, "http://")
+}
+
+func TestCheckHealth(t *testing.T) {
+	// Set up.
+	good, bad := newFakeBrowser(t, true), newFakeBrowser(t, false)
+	f := farm.New([]string{good, bad}, farm.HealthInterval(time.Hour))
+	defer f.Close()
+	// Test.
+	f.CheckHealth()
+	got := f.Status()
+	if len(got) != 2 || !got[0].Healthy || got[1].Healthy || got[1].LastErr == nil {
+		t.Errorf("Status() = %+v, want only the first browser to be healthy", got)
+	}
+}
+
+func TestNewContextFailover(t *testing.T) {
+	// Set up.
+	addrs := []string{newFakeBrowser(t, true), newFakeBrowser(t, true)}
+	f := farm.New(addrs, farm.HealthInterval(time.Hour))
+	defer f.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// Test.
+	_, err := f.NewContext(ctx)
+	if !errors.Is(err, farm.ErrNoHealthyBrowsers) {
+		t.Fatalf("NewContext() error = %v, want %v", err, farm.ErrNoHealthyBrowsers)
+	}
+	for _, s := range f.Status() {
+		if s.Healthy || s.Sessions != 0 {
+			t.Errorf("Status() = %+v, want unhealthy browser without sessions", s)
+		}
+	}
+}
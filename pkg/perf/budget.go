@@ -0,0 +1,233 @@
+// Package perf provides performance gates for continuous integration on top
+// of the Chrome DevTools Protocol (CDP) bindings in the devtools package:
+// page loads under emulated CPU and network throttling, which are measured
+// with Core Web Vitals and compared against a performance budget.
+package perf
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/emulation"
+	"github.com/daabr/chrome-vision/pkg/devtools/network"
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// CPU slowdown factors, for the `Budget.CPUSlowdown` field.
+const (
+	NoCPUThrottling = 1.0
+	CPUx4           = 4.0 // Mid-tier mobile device, like in Lighthouse.
+	CPUx6           = 6.0 // Low-end mobile device.
+)
+
+// NetworkConditions describe an emulated network connection.
+type NetworkConditions struct {
+	// Minimum latency from request sent to response headers received.
+	Latency time.Duration
+	// Maximal aggregated throughputs, in bytes per second.
+	DownloadThroughput, UploadThroughput float64
+	// Optional, e.g. `network.ConnectionTypeCellular3g`.
+	ConnectionType network.ConnectionType
+}
+
+// Network presets, for the `Budget.Network` field. They're identical to
+// the throttling presets in Chrome DevTools.
+var (
+	Network3G = &NetworkConditions{
+		Latency:            562500 * time.Microsecond,
+		DownloadThroughput: 1.6 * 1000 * 1000 / 8 * 0.9,
+		UploadThroughput:   750 * 1000 / 8 * 0.9,
+		ConnectionType:     network.ConnectionTypeCellular3g,
+	}
+	NetworkSlow3G = &NetworkConditions{
+		Latency:            2 * time.Second,
+		DownloadThroughput: 500 * 1000 / 8 * 0.8,
+		UploadThroughput:   500 * 1000 / 8 * 0.8,
+		ConnectionType:     network.ConnectionTypeCellular3g,
+	}
+)
+
+// Budget specifies the conditions of a page load, and the maximum values
+// of its metrics. Zero values mean no throttling and no limit. Example:
+//
+//	b := perf.Budget{CPUSlowdown: perf.CPUx4, Network: perf.Network3G, MaxLCP: 2500 * time.Millisecond}
+type Budget struct {
+	CPUSlowdown float64
+	Network     *NetworkConditions
+
+	MaxTTFB time.Duration
+	MaxFCP  time.Duration
+	MaxLCP  time.Duration
+	MaxCLS  float64
+}
+
+// Vitals are the measured metrics of a page load. Timings are relative
+// to the start of the navigation, and zero if they weren't reported.
+type Vitals struct {
+	TTFB time.Duration // Time to First Byte.
+	FCP  time.Duration // First Contentful Paint.
+	LCP  time.Duration // Largest Contentful Paint.
+	CLS  float64       // Cumulative Layout Shift.
+}
+
+// Result is the outcome of the `perf.WithBudget` function.
+type Result struct {
+	Vitals
+	// Human-readable descriptions of metrics which exceeded the budget.
+	Violations []string
+}
+
+// Passed reports whether all the metrics are within the budget.
+func (r *Result) Passed() bool {
+	return len(r.Violations) == 0
+}
+
+// Collect the timings and layout shifts of the current document. Buffered
+// entries are added to a new performance observer synchronously, so they
+// can be taken immediately, without waiting for its callback.
+const collectVitals = `(() => {
+	const records = (type) => {
+		const o = new PerformanceObserver(() => {});
+		o.observe({type, buffered: true});
+		const entries = o.takeRecords();
+		o.disconnect();
+		return entries;
+	};
+	const nav = performance.getEntriesByType("navigation")[0];
+	const fcp = performance.getEntriesByName("first-contentful-paint")[0];
+	const lcp = records("largest-contentful-paint").pop();
+	return {
+		ttfb: nav ? nav.responseStart : 0,
+		fcp: fcp ? fcp.startTime : 0,
+		lcp: lcp ? lcp.startTime : 0,
+		shifts: records("layout-shift").filter((e) => !e.hadRecentInput)
+			.map((e) => ({time: e.startTime, value: e.value})),
+	};
+})()`
+
+type layoutShift struct {
+	Time  float64 `json:"time"`
+	Value float64 `json:"value"`
+}
+
+type rawVitals struct {
+	TTFB   float64       `json:"ttfb"`
+	FCP    float64       `json:"fcp"`
+	LCP    float64       `json:"lcp"`
+	Shifts []layoutShift `json:"shifts"`
+}
+
+// WithBudget applies the CPU and network throttling of the given budget
+// to the page associated with the given context, navigates to the given
+// URL, waits for the page to load and settle, measures its vitals and
+// compares them against the budget. The throttling is removed afterwards.
+//
+// The error is non-nil only if the measurement itself failed; budget
+// violations are reported in the result (see the `Result.Passed` method).
+func WithBudget(ctx context.Context, url string, b Budget) (*Result, error) {
+	if b.CPUSlowdown > 1 {
+		if err := emulation.NewSetCPUThrottlingRate(b.CPUSlowdown).Do(ctx); err != nil {
+			return nil, err
+		}
+		defer emulation.NewSetCPUThrottlingRate(NoCPUThrottling).Do(ctx)
+	}
+	if n := b.Network; n != nil {
+		release, err := devtools.EnableDomain(ctx, "Network")
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		ms := float64(n.Latency) / float64(time.Millisecond)
+		e := network.NewEmulateNetworkConditions(false, ms, n.DownloadThroughput, n.UploadThroughput)
+		if n.ConnectionType != "" {
+			e.SetConnectionType(n.ConnectionType)
+		}
+		if err := e.Do(ctx); err != nil {
+			return nil, err
+		}
+		// -1 disables throughput throttling.
+		defer network.NewEmulateNetworkConditions(false, 0, -1, -1).Do(ctx)
+	}
+
+	nav, err := page.NewNavigate(url).Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if nav.ErrorText != "" {
+		return nil, fmt.Errorf("navigation error: %s", nav.ErrorText)
+	}
+	if err := page.WaitForLoad(ctx); err != nil {
+		return nil, err
+	}
+	// Late resources (e.g. lazy images) may still affect LCP and CLS.
+	if err := page.WaitForNetworkIdle(ctx); err != nil {
+		return nil, err
+	}
+
+	var raw rawVitals
+	if err := runtime.Eval(ctx, collectVitals, &raw); err != nil {
+		return nil, err
+	}
+	r := &Result{Vitals: Vitals{
+		TTFB: milliseconds(raw.TTFB),
+		FCP:  milliseconds(raw.FCP),
+		LCP:  milliseconds(raw.LCP),
+		CLS:  cumulativeLayoutShift(raw.Shifts),
+	}}
+	r.Violations = b.check(r.Vitals)
+	return r, nil
+}
+
+func milliseconds(f float64) time.Duration {
+	return time.Duration(f * float64(time.Millisecond))
+}
+
+// CLS is the largest sum of layout shifts in a session window: shifts
+// less than 1 second apart, with a maximum window duration of 5 seconds.
+// See https://web.dev/cls/.
+func cumulativeLayoutShift(shifts []layoutShift) float64 {
+	cls, window := 0.0, 0.0
+	var first, last float64
+	for i, s := range shifts {
+		if i > 0 && s.Time-last < 1000 && s.Time-first < 5000 {
+			window += s.Value
+		} else {
+			window, first = s.Value, s.Time
+		}
+		last = s.Time
+		if window > cls {
+			cls = window
+		}
+	}
+	return cls
+}
+
+// Compare the given vitals against the budget. Timings which weren't
+// reported are violations too, if the budget limits them.
+func (b Budget) check(v Vitals) []string {
+	var violations []string
+	timings := []struct {
+		name          string
+		limit, actual time.Duration
+	}{
+		{"TTFB", b.MaxTTFB, v.TTFB},
+		{"FCP", b.MaxFCP, v.FCP},
+		{"LCP", b.MaxLCP, v.LCP},
+	}
+	for _, t := range timings {
+		switch {
+		case t.limit == 0:
+		case t.actual == 0:
+			violations = append(violations, fmt.Sprintf("%s not reported (budget %v)", t.name, t.limit))
+		case t.actual > t.limit:
+			violations = append(violations, fmt.Sprintf("%s %v > %v", t.name, t.actual, t.limit))
+		}
+	}
+	if b.MaxCLS > 0 && v.CLS > b.MaxCLS {
+		violations = append(violations, fmt.Sprintf("CLS %.3f > %.3f", v.CLS, b.MaxCLS))
+	}
+	return violations
+}
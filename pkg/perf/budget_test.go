@@ -0,0 +1,61 @@
+package perf
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCumulativeLayoutShift(t *testing.T) {
+	tests := []struct {
+		name   string
+		shifts []layoutShift
+		want   float64
+	}{
+		{"none", nil, 0},
+		{"single", []layoutShift{{100, 0.1}}, 0.1},
+		{"one_window", []layoutShift{{100, 0.1}, {900, 0.05}, {1800, 0.05}}, 0.2},
+		{"gap_starts_new_window", []layoutShift{{100, 0.1}, {1200, 0.15}, {1300, 0.1}}, 0.25},
+		{"window_max_5s", []layoutShift{{0, 0.1}, {900, 0.1}, {1800, 0.1}, {2700, 0.1}, {3600, 0.1}, {4500, 0.1}, {5400, 0.1}}, 0.6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cumulativeLayoutShift(tt.shifts); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("cumulativeLayoutShift() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBudgetCheck(t *testing.T) {
+	b := Budget{MaxLCP: 2500 * time.Millisecond, MaxFCP: time.Second, MaxCLS: 0.1}
+	tests := []struct {
+		name string
+		v    Vitals
+		want []string
+	}{
+		{
+			name: "pass",
+			v:    Vitals{TTFB: 5 * time.Second, FCP: time.Second, LCP: 2 * time.Second, CLS: 0.1},
+		},
+		{
+			name: "fail",
+			v:    Vitals{FCP: 1500 * time.Millisecond, LCP: 3 * time.Second, CLS: 0.25},
+			want: []string{"FCP 1.5s > 1s", "LCP 3s > 2.5s", "CLS 0.250 > 0.100"},
+		},
+		{
+			name: "not_reported",
+			v:    Vitals{FCP: time.Second},
+			want: []string{"LCP not reported (budget 2.5s)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, b.check(tt.v)); diff != "" {
+				t.Errorf("check() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
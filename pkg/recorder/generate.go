@@ -0,0 +1,148 @@
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strings"
+)
+
+const mainFunc = `
+func main() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	flags := devtools.DefaultBrowserFlags()
+	delete(flags, "headless")
+
+	ctx, err := devtools.NewContext(ctx, devtools.BrowserFlags(flags))
+	if err != nil {
+		log.Fatalf("initialization error: %v", err)
+	}
+	defer devtools.Close(ctx)
+
+	if err := run(ctx); err != nil {
+		log.Fatal(err)
+	}
+}
+`
+
+const clickFunc = `
+// Click the first element which matches the given selector.
+func click(ctx context.Context, selector string) error {
+	h, err := dom.Query(ctx, selector)
+	if err != nil {
+		return err
+	}
+	return h.Click(ctx)
+}
+`
+
+const fillFunc = `
+// Replace the value of the first text field
+// which matches the given selector.
+func fill(ctx context.Context, selector, text string) error {
+	h, err := dom.Query(ctx, selector)
+	if err != nil {
+		return err
+	}
+	if err := h.Click(ctx); err != nil {
+		return err
+	}
+	if err := input.Shortcut(ctx, "Mod+A"); err != nil {
+		return err
+	}
+	if err := input.Shortcut(ctx, "Backspace"); err != nil {
+		return err
+	}
+	return input.TypeText(ctx, text)
+}
+`
+
+// Generate writes to w the source code of a Go program which replays the
+// given actions (see `Recorder.Actions`) in a new visible browser, using
+// the devtools package. The program is meant to be a skeleton: selectors
+// may need to be made more robust, and assertions added.
+func Generate(w io.Writer, actions []Action) error {
+	imports := map[string]bool{
+		"context": true,
+		"log":     true,
+		"time":    true,
+		"github.com/daabr/chrome-vision/pkg/devtools": true,
+	}
+	body := &bytes.Buffer{}
+	var helpers []string
+	used := map[ActionKind]bool{}
+	for _, a := range actions {
+		switch a.Kind {
+		case Navigate:
+			imports["github.com/daabr/chrome-vision/pkg/devtools/page"] = true
+			if body.Len() > 0 {
+				body.WriteString("\n")
+			}
+			fmt.Fprintf(body, "// Navigate to %s\n", a.URL)
+			fmt.Fprintf(body, "if _, err := page.NewNavigate(%q).Do(ctx); err != nil {\nreturn err\n}\n", a.URL)
+			fmt.Fprintf(body, "if err := page.WaitForLoad(ctx); err != nil {\nreturn err\n}\n")
+		case WaitForNavigation:
+			imports["github.com/daabr/chrome-vision/pkg/devtools/page"] = true
+			fmt.Fprintf(body, "// Wait for the navigation to %s\n", a.URL)
+			fmt.Fprintf(body, "if err := page.WaitForStable(ctx); err != nil {\nreturn err\n}\n")
+		case Click:
+			imports["github.com/daabr/chrome-vision/pkg/devtools/dom"] = true
+			if !used[Click] {
+				helpers = append(helpers, clickFunc)
+			}
+			fmt.Fprintf(body, "if err := click(ctx, %q); err != nil {\nreturn err\n}\n", a.Selector)
+		case Fill:
+			imports["github.com/daabr/chrome-vision/pkg/devtools/dom"] = true
+			imports["github.com/daabr/chrome-vision/pkg/devtools/input"] = true
+			if !used[Fill] {
+				helpers = append(helpers, fillFunc)
+			}
+			fmt.Fprintf(body, "if err := fill(ctx, %q, %q); err != nil {\nreturn err\n}\n", a.Selector, a.Text)
+		case Press:
+			imports["github.com/daabr/chrome-vision/pkg/devtools/input"] = true
+			fmt.Fprintf(body, "if err := input.Shortcut(ctx, %q); err != nil {\nreturn err\n}\n", a.Text)
+		default:
+			return fmt.Errorf("unsupported action kind %q", a.Kind)
+		}
+		used[a.Kind] = true
+	}
+
+	src := &bytes.Buffer{}
+	src.WriteString("// This program was generated by the Chrome Vision recorder, edit it as needed.\n\n")
+	src.WriteString("package main\n\nimport (\n")
+	paths := make([]string, 0, len(imports))
+	for p := range imports {
+		paths = append(paths, p)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		si, sj := strings.Contains(paths[i], "."), strings.Contains(paths[j], ".")
+		if si != sj {
+			return sj
+		}
+		return paths[i] < paths[j]
+	})
+	for i, p := range paths {
+		// Separate the standard library's packages from the others.
+		if i > 0 && !strings.Contains(paths[i-1], ".") && strings.Contains(p, ".") {
+			src.WriteString("\n")
+		}
+		fmt.Fprintf(src, "%q\n", p)
+	}
+	src.WriteString(")\n")
+	src.WriteString(mainFunc)
+	fmt.Fprintf(src, "\nfunc run(ctx context.Context) error {\n%sreturn nil\n}\n", body)
+	for _, h := range helpers {
+		src.WriteString(h)
+	}
+
+	b, err := format.Source(src.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format the generated code: %v", err)
+	}
+	_, err = w.Write(b)
+	return err
+}
@@ -0,0 +1,336 @@
+// Package recorder captures the actions of a human who drives a visible
+// (non-headless) browser: page navigations, clicks, filled text fields and
+// a few special key presses, and generates a skeleton Go program which
+// replays them with the devtools package, as a starting point for
+// automation scripts and tests:
+//
+//	flags := devtools.DefaultBrowserFlags()
+//	delete(flags, "headless")
+//	ctx, err := devtools.NewContext(ctx, devtools.BrowserFlags(flags))
+//	...
+//	r, err := recorder.Start(ctx)
+//	...
+//	// Wait until the user is done, e.g. until Enter is pressed in the terminal.
+//	err = recorder.Generate(os.Stdout, r.Stop())
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+	"github.com/daabr/chrome-vision/pkg/devtools/runtime"
+)
+
+// ActionKind is the type of a recorded `recorder.Action`.
+type ActionKind string
+
+// Kinds of recorded actions.
+const (
+	// Navigate to `Action.URL` (e.g. typed in the address bar).
+	Navigate ActionKind = "navigate"
+	// WaitForNavigation waits for a navigation to `Action.URL`,
+	// which was caused by the previous click or key press.
+	WaitForNavigation ActionKind = "wait"
+	// Click the element which matches `Action.Selector`.
+	Click ActionKind = "click"
+	// Fill replaces the value of the text field which matches
+	// `Action.Selector` with `Action.Text`.
+	Fill ActionKind = "fill"
+	// Press the key `Action.Text` (e.g. "Enter") in the focused element.
+	Press ActionKind = "press"
+)
+
+// Action is a single user action in a recording.
+type Action struct {
+	Kind     ActionKind
+	URL      string
+	Selector string
+	Text     string
+	Time     time.Time
+}
+
+// Navigations which start within this duration after a click or a key press
+// are considered to be caused by them, rather than by the user directly.
+const navigationDelay = 5 * time.Second
+
+// Name of the JavaScript binding which reports user actions.
+const binding = "__chromeVisionRecord"
+
+// Report trusted (i.e. user-generated) clicks, text changes and Enter/Escape
+// key presses through the binding, along with unique CSS selectors of the
+// elements they target.
+const script = `(() => {
+	if (window.__chromeVisionRecorder) {
+		return;
+	}
+	window.__chromeVisionRecorder = true;
+	const record = (action) => {
+		action.time = Date.now();
+		window.` + binding + `(JSON.stringify(action));
+	};
+	const unique = (s) => {
+		try {
+			return document.querySelectorAll(s).length === 1;
+		} catch (e) {
+			return false;
+		}
+	};
+	const selectorOf = (e) => {
+		if (e.id && unique("#" + CSS.escape(e.id))) {
+			return "#" + CSS.escape(e.id);
+		}
+		for (const a of ["data-testid", "data-test-id", "data-test", "data-qa", "name", "aria-label"]) {
+			const v = e.getAttribute(a);
+			const s = e.localName + "[" + a + '="' + CSS.escape(v || "") + '"]';
+			if (v && unique(s)) {
+				return s;
+			}
+		}
+		const parts = [];
+		for (let n = e; n && n.nodeType === Node.ELEMENT_NODE; n = n.parentElement) {
+			if (n !== e && n.id && unique("#" + CSS.escape(n.id))) {
+				parts.unshift("#" + CSS.escape(n.id));
+				break;
+			}
+			let s = n.localName;
+			const p = n.parentElement;
+			if (p) {
+				const same = Array.from(p.children).filter((c) => c.localName === n.localName);
+				if (same.length > 1) {
+					s += ":nth-of-type(" + (same.indexOf(n) + 1) + ")";
+				}
+			}
+			parts.unshift(s);
+		}
+		return parts.join(" > ");
+	};
+	const nonText = ["button", "checkbox", "color", "file", "hidden", "image", "radio", "range", "reset", "submit"];
+	const editable = (e) => e instanceof HTMLTextAreaElement ||
+		(e instanceof HTMLInputElement && !nonText.includes(e.type));
+
+	// Text changes are reported once per field, when the user is done with it.
+	let pending = null;
+	const flush = () => {
+		if (pending) {
+			record({kind: "fill", selector: selectorOf(pending), text: pending.value});
+			pending = null;
+		}
+	};
+	addEventListener("input", (e) => {
+		if (!e.isTrusted || !editable(e.target)) {
+			return;
+		}
+		if (pending !== e.target) {
+			flush();
+		}
+		pending = e.target;
+	}, true);
+	addEventListener("change", (e) => {
+		if (pending === e.target) {
+			flush();
+		}
+	}, true);
+	addEventListener("keydown", (e) => {
+		if (e.isTrusted && (e.key === "Enter" || e.key === "Escape")) {
+			flush();
+			record({kind: "press", text: e.key});
+		}
+	}, true);
+	addEventListener("click", (e) => {
+		if (!e.isTrusted || !(e.target instanceof Element)) {
+			return;
+		}
+		flush();
+		const t = e.target.closest("a, button, input, select, textarea, label, summary, [role], [onclick]") || e.target;
+		record({kind: "click", selector: selectorOf(t)});
+	}, true);
+	addEventListener("pagehide", flush, true);
+})();`
+
+// Payload of the binding calls.
+type recordedAction struct {
+	Kind     ActionKind `json:"kind"`
+	Selector string     `json:"selector"`
+	Text     string     `json:"text"`
+	Time     float64    `json:"time"` // Milliseconds since the Unix epoch.
+}
+
+// Recorder records user actions in the background, see `recorder.Start`.
+type Recorder struct {
+	ctx      context.Context
+	calls    *devtools.Subscription
+	navs     *devtools.Subscription
+	done     chan struct{}
+	scriptID string
+	// Release the Page and Runtime domains, see `devtools.EnableDomain`.
+	releases []func() error
+
+	mu      sync.Mutex
+	actions []Action
+}
+
+// Start starts recording the user's actions in the page associated with the
+// given context, until the `Stop` method of the returned recorder is called.
+// The current URL of the page, unless it's "about:blank", is recorded as
+// the first navigation. This function enables the Page and Runtime domains
+// until the recorder is stopped (see `devtools.EnableDomain`).
+//
+// Clicks and text fields are identified by unique CSS selectors (preferring
+// IDs, test IDs and names). Other interactions, e.g. choosing an option
+// in a <select> element, or typing in a "contenteditable" element,
+// aren't recorded yet.
+func Start(ctx context.Context) (*Recorder, error) {
+	calls, err := devtools.Subscribe(ctx, "Runtime.bindingCalled")
+	if err != nil {
+		return nil, err
+	}
+	navs, err := devtools.Subscribe(ctx, "Page.frameNavigated")
+	if err != nil {
+		calls.Unsubscribe()
+		return nil, err
+	}
+	r := &Recorder{ctx: ctx, calls: calls, navs: navs, done: make(chan struct{})}
+	go r.collect(devtools.EventsOfSession(ctx))
+
+	if err := r.inject(); err != nil {
+		r.Stop()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Enable the domains, and inject the recording script into
+// the current document and all the subsequent ones.
+func (r *Recorder) inject() error {
+	for _, domain := range []string{"Page", "Runtime"} {
+		release, err := devtools.EnableDomain(r.ctx, domain)
+		if err != nil {
+			return err
+		}
+		r.releases = append(r.releases, release)
+	}
+	tree, err := page.NewGetFrameTree().Do(r.ctx)
+	if err != nil {
+		return err
+	}
+	if u := frameURL(&tree.FrameTree.Frame); u != "about:blank" {
+		r.add(Action{Kind: Navigate, URL: u, Time: time.Now()})
+	}
+
+	if err := runtime.NewAddBinding(binding).Do(r.ctx); err != nil {
+		return err
+	}
+	s, err := page.NewAddScriptToEvaluateOnNewDocument(script).Do(r.ctx)
+	if err != nil {
+		return err
+	}
+	r.scriptID = s.Identifier
+	return runtime.Eval(r.ctx, script, nil)
+}
+
+// Receive events until both subscriptions are closed.
+func (r *Recorder) collect(accept devtools.EventFilter) {
+	defer close(r.done)
+	calls, navs := r.calls.C, r.navs.C
+	for calls != nil || navs != nil {
+		select {
+		case m, ok := <-calls:
+			if !ok {
+				calls = nil
+				continue
+			}
+			e := &runtime.BindingCalled{}
+			if !accept(m) || json.Unmarshal(m.Params, e) != nil || e.Name != binding {
+				continue
+			}
+			a := &recordedAction{}
+			if json.Unmarshal([]byte(e.Payload), a) != nil {
+				continue
+			}
+			r.add(Action{
+				Kind:     a.Kind,
+				Selector: a.Selector,
+				Text:     a.Text,
+				Time:     time.Unix(0, int64(a.Time*float64(time.Millisecond))),
+			})
+		case m, ok := <-navs:
+			if !ok {
+				navs = nil
+				continue
+			}
+			e := &page.FrameNavigated{}
+			if !accept(m) || json.Unmarshal(m.Params, e) != nil || e.Frame.ParentID != "" {
+				continue
+			}
+			r.add(Action{Kind: Navigate, URL: frameURL(&e.Frame), Time: time.Now()})
+		}
+	}
+}
+
+func (r *Recorder) add(a Action) {
+	r.mu.Lock()
+	r.actions = append(r.actions, a)
+	r.mu.Unlock()
+}
+
+// Actions returns the actions which were recorded so far, in chronological
+// order, after simplifying them: consecutive changes of the same text field
+// are merged, clicks which only focus a text field before it's filled are
+// dropped, and navigations which were caused by a click or a key press
+// are converted to `recorder.WaitForNavigation` actions.
+func (r *Recorder) Actions() []Action {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return simplify(r.actions)
+}
+
+// Stop stops recording, and returns the final list of actions (see the
+// `Recorder.Actions` method). The recording script is removed from
+// subsequent documents, but not from the current one.
+func (r *Recorder) Stop() []Action {
+	if r.scriptID != "" {
+		page.NewRemoveScriptToEvaluateOnNewDocument(r.scriptID).Do(r.ctx)
+	}
+	runtime.NewRemoveBinding(binding).Do(r.ctx)
+	r.calls.Unsubscribe()
+	r.navs.Unsubscribe()
+	for _, release := range r.releases {
+		release()
+	}
+	<-r.done
+	return r.Actions()
+}
+
+func frameURL(f *page.Frame) string {
+	return f.URL + f.URLFragment
+}
+
+// Return a simplified copy of the given actions, see `Recorder.Actions`.
+func simplify(actions []Action) []Action {
+	sorted := append([]Action(nil), actions...)
+	// Events arrive through separate subscriptions, so they may be out of order.
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time.Before(sorted[j].Time) })
+
+	var result []Action
+	for _, a := range sorted {
+		var prev *Action
+		if n := len(result); n > 0 {
+			prev = &result[n-1]
+		}
+		switch {
+		case a.Kind == Fill && prev != nil && (prev.Kind == Fill || prev.Kind == Click) && prev.Selector == a.Selector:
+			*prev = a
+			continue
+		case a.Kind == Navigate && prev != nil && (prev.Kind == Click || prev.Kind == Press) &&
+			a.Time.Sub(prev.Time) < navigationDelay:
+			a.Kind = WaitForNavigation
+		}
+		result = append(result, a)
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package recorder
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSimplify(t *testing.T) {
+	t0 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(s int) time.Time { return t0.Add(time.Duration(s) * time.Second) }
+	actions := []Action{
+		{Kind: Navigate, URL: "https://example.com/", Time: at(0)},
+		{Kind: Click, Selector: "#user", Time: at(1)},
+		{Kind: Fill, Selector: "#user", Text: "al", Time: at(2)},
+		{Kind: Fill, Selector: "#user", Text: "alice", Time: at(3)},
+		{Kind: Press, Text: "Enter", Time: at(5)},
+		// Received before the key press, but happened after it.
+		{Kind: Navigate, URL: "https://example.com/home", Time: at(6)},
+		{Kind: Click, Selector: "a.logout", Time: at(30)},
+		{Kind: Navigate, URL: "https://example.org/", Time: at(60)},
+	}
+	actions[4], actions[5] = actions[5], actions[4]
+
+	want := []Action{
+		{Kind: Navigate, URL: "https://example.com/", Time: at(0)},
+		{Kind: Fill, Selector: "#user", Text: "alice", Time: at(3)},
+		{Kind: Press, Text: "Enter", Time: at(5)},
+		{Kind: WaitForNavigation, URL: "https://example.com/home", Time: at(6)},
+		{Kind: Click, Selector: "a.logout", Time: at(30)},
+		{Kind: Navigate, URL: "https://example.org/", Time: at(60)},
+	}
+	if diff := cmp.Diff(want, simplify(actions)); diff != "" {
+		t.Errorf("simplify() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	actions := []Action{
+		{Kind: Navigate, URL: "https://example.com/"},
+		{Kind: Fill, Selector: `input[name="q"]`, Text: "kittens"},
+		{Kind: Press, Text: "Enter"},
+		{Kind: WaitForNavigation, URL: "https://example.com/search?q=kittens"},
+		{Kind: Click, Selector: "#results > li:nth-of-type(2) > a"},
+	}
+	b := &bytes.Buffer{}
+	if err := Generate(b, actions); err != nil {
+		t.Fatalf("Generate(); got error: %v", err)
+	}
+	src := b.String()
+	if _, err := parser.ParseFile(token.NewFileSet(), "main.go", src, 0); err != nil {
+		t.Errorf("Generate() output isn't valid Go code: %v\n%s", err, src)
+	}
+	for _, s := range []string{
+		`page.NewNavigate("https://example.com/")`,
+		`fill(ctx, "input[name=\"q\"]", "kittens")`,
+		`input.Shortcut(ctx, "Enter")`,
+		`page.WaitForStable(ctx)`,
+		`click(ctx, "#results > li:nth-of-type(2) > a")`,
+		`"github.com/daabr/chrome-vision/pkg/devtools/dom"`,
+	} {
+		if !strings.Contains(src, s) {
+			t.Errorf("Generate() output doesn't contain %s:\n%s", s, src)
+		}
+	}
+
+	if err := Generate(b, []Action{{Kind: "hover"}}); err == nil {
+		t.Error("Generate() with unsupported action; got nil error")
+	}
+}
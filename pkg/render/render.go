@@ -0,0 +1,429 @@
+// Package render provides building blocks for rendering services, which
+// convert HTML documents or URLs to PDF documents or PNG images with a
+// shared browser:
+//
+//	ctx, err := devtools.NewContext(context.Background())
+//	...
+//	r, err := render.New(ctx, render.MaxConcurrency(8))
+//	...
+//	defer r.Close()
+//	pdf, err := r.Render(requestCtx, &render.Request{HTML: html, Format: render.PDF})
+//
+// Each request is rendered in a new tab, in its own browser context (similar
+// to an incognito profile), so requests don't share cookies, cache or storage.
+// Tabs are opened ahead of time, to reduce latency, and closed after a single
+// use. Pages may not load "file" URLs, or URLs in private networks (e.g.
+// "localhost", or intranet hosts), unless they're allowed explicitly. This
+// doesn't cover cross-site iframes, workers and WebSockets (see the
+// `fetch.EnforceURLPolicy` function), so services which render untrusted
+// content should block private networks at the network level too.
+package render
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools"
+	"github.com/daabr/chrome-vision/pkg/devtools/emulation"
+	"github.com/daabr/chrome-vision/pkg/devtools/fetch"
+	"github.com/daabr/chrome-vision/pkg/devtools/page"
+	"github.com/daabr/chrome-vision/pkg/devtools/target"
+)
+
+// Format is the output format of a render request.
+type Format string
+
+// Output formats.
+const (
+	PDF Format = "pdf"
+	PNG Format = "png"
+)
+
+// Default values for the `render.New` function and `render.Request` struct.
+const (
+	DefaultTimeout        = 30 * time.Second
+	DefaultViewportWidth  = 1280
+	DefaultViewportHeight = 800
+)
+
+// Request describes a single rendering, see the `Renderer.Render` method.
+type Request struct {
+	// The URL of the page to render, or the page's HTML (exactly one of them).
+	// Relative URLs in the HTML are resolved against "about:blank", so it
+	// should contain absolute URLs, or a <base> element.
+	URL, HTML string
+	// Output format (default: `render.PDF`).
+	Format Format
+	// Viewport size in CSS pixels (default: `render.DefaultViewportWidth`
+	// and `render.DefaultViewportHeight`).
+	Width, Height int64
+
+	// PNG only: capture the entire scrollable page, instead of the viewport.
+	FullPage bool
+	// PNG only: device pixel ratio (default: 1).
+	Scale float64
+
+	// PDF only: paper orientation, whether to print background graphics,
+	// and paper size in inches (default: 8.5 x 11, i.e. US Letter).
+	Landscape               bool
+	PrintBackground         bool
+	PaperWidth, PaperHeight float64
+}
+
+// Renderer renders requests with a pool of isolated tabs in a single
+// browser, see the `render.New` function. Multiple goroutines may use
+// it simultaneously.
+type Renderer struct {
+	browser     context.Context
+	concurrency int
+	poolSize    int
+	timeout     time.Duration
+	quiet       time.Duration
+	policy      *fetch.URLPolicy
+
+	// Opens a new isolated tab, with the URL policy, and returns its context
+	// along with a function to close it. Replaceable in tests.
+	open func(ctx context.Context) (context.Context, func(), error)
+
+	slots   chan struct{}
+	idle    chan *session
+	warming sync.WaitGroup
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// A single-use tab.
+type session struct {
+	ctx   context.Context
+	close func()
+}
+
+// Option is used for customization in the `render.New` function.
+type Option = func(*Renderer)
+
+// MaxConcurrency allows the caller of the `render.New` function to limit the
+// number of requests which are rendered at the same time (default: the
+// number of CPUs). Other requests wait for a free slot.
+func MaxConcurrency(n int) Option {
+	return func(r *Renderer) {
+		r.concurrency = n
+	}
+}
+
+// PoolSize allows the caller of the `render.New` function to customize the
+// number of tabs which are opened ahead of time (default: the maximum
+// concurrency, see the `render.MaxConcurrency` option). 0 disables pooling,
+// so each request opens its tab.
+func PoolSize(n int) Option {
+	return func(r *Renderer) {
+		r.poolSize = n
+	}
+}
+
+// Timeout allows the caller of the `render.New` function to limit the
+// duration of each rendering, including waiting for a free slot (default:
+// `render.DefaultTimeout`). The request's context may have a sooner deadline.
+func Timeout(d time.Duration) Option {
+	return func(r *Renderer) {
+		r.timeout = d
+	}
+}
+
+// QuietPeriod allows the caller of the `render.New` function to customize how
+// long pages must be idle before they're rendered (default:
+// `page.DefaultQuietPeriod`), see the `page.WaitForNetworkIdle` function.
+func QuietPeriod(d time.Duration) Option {
+	return func(r *Renderer) {
+		r.quiet = d
+	}
+}
+
+// ErrBlockedURL is wrapped by the errors of the `Renderer.Render` method
+// when the requested URL is blocked by the renderer's URL policy.
+var ErrBlockedURL = fetch.ErrBlockedURL
+
+// Policy allows the caller of the `render.New` function to replace the
+// renderer's URL policy (default: the zero value of `fetch.URLPolicy`,
+// which blocks "file" URLs and private networks), e.g. to allow only
+// specific hosts. Options which adjust the policy (e.g.
+// `render.AllowHosts`) should follow this one.
+func Policy(p fetch.URLPolicy) Option {
+	return func(r *Renderer) {
+		r.policy = &p
+	}
+}
+
+// AllowFileURLs allows the caller of the `render.New` function to let pages
+// load "file" URLs, i.e. local files of the browser's machine. This is
+// unsafe if requests are untrusted.
+func AllowFileURLs() Option {
+	return func(r *Renderer) {
+		r.policy.FileURLs = true
+	}
+}
+
+// AllowPrivateNetworks allows the caller of the `render.New` function to let
+// pages load URLs whose hosts are in private networks, e.g. "localhost",
+// "10.0.0.1", or intranet hosts which resolve to private IP addresses. This
+// is unsafe if requests are untrusted, see also the `render.AllowHosts` option.
+func AllowPrivateNetworks() Option {
+	return func(r *Renderer) {
+		r.policy.PrivateNetworks = true
+	}
+}
+
+// AllowHosts allows the caller of the `render.New` function to let pages
+// load URLs of the given hosts (e.g. "assets.intranet"), even if they're in
+// private networks.
+func AllowHosts(hosts ...string) Option {
+	return func(r *Renderer) {
+		r.policy.Intranet = append(r.policy.Intranet, hosts...)
+	}
+}
+
+// New constructs a renderer which renders requests in the browser that is
+// associated with the given context (which must be initialized with the
+// `devtools.NewContext` function), and starts opening its pool of tabs in
+// the background. Call the `Close` method to close them.
+func New(ctx context.Context, opts ...Option) (*Renderer, error) {
+	if _, ok := devtools.FromContext(ctx); !ok {
+		return nil, errors.New("context not initialized with devtools.NewContext")
+	}
+	r := &Renderer{
+		browser:     ctx,
+		concurrency: runtime.NumCPU(),
+		poolSize:    -1,
+		timeout:     DefaultTimeout,
+		quiet:       page.DefaultQuietPeriod,
+		policy:      &fetch.URLPolicy{},
+	}
+	r.open = r.openSession
+	for _, o := range opts {
+		o(r)
+	}
+	if r.concurrency < 1 {
+		r.concurrency = 1
+	}
+	if r.poolSize < 0 {
+		r.poolSize = r.concurrency
+	}
+	r.slots = make(chan struct{}, r.concurrency)
+	r.idle = make(chan *session, r.poolSize)
+	for i := 0; i < r.poolSize; i++ {
+		r.warm()
+	}
+	return r, nil
+}
+
+// Close closes the renderer's idle tabs, and makes subsequent calls to
+// the `Render` method fail. Renderings which are in progress continue.
+func (r *Renderer) Close() {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.closed = true
+	r.mu.Unlock()
+	r.warming.Wait()
+	close(r.idle)
+	for s := range r.idle {
+		s.close()
+	}
+}
+
+// Open a tab in the background, and add it to the pool.
+func (r *Renderer) warm() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed || r.poolSize == 0 {
+		return
+	}
+	r.warming.Add(1)
+	go func() {
+		defer r.warming.Done()
+		ctx, closeTab, err := r.open(r.browser)
+		if err != nil {
+			log.Printf("Failed to open a rendering tab: %v", err)
+			return
+		}
+		select {
+		case r.idle <- &session{ctx: ctx, close: closeTab}:
+		default:
+			closeTab() // The pool is full.
+		}
+	}()
+}
+
+// Return a tab from the pool, or open a new one if the pool is empty.
+func (r *Renderer) acquire() (*session, error) {
+	select {
+	case s, ok := <-r.idle:
+		if ok {
+			r.warm()
+			return s, nil
+		}
+	default:
+	}
+	ctx, closeTab, err := r.open(r.browser)
+	if err != nil {
+		return nil, err
+	}
+	return &session{ctx: ctx, close: closeTab}, nil
+}
+
+// Open a new tab in a new browser context, and apply the URL policy to it.
+func (r *Renderer) openSession(ctx context.Context) (context.Context, func(), error) {
+	bc, err := target.NewCreateBrowserContext().Do(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	dispose := func() {
+		target.NewDisposeBrowserContext(bc.BrowserContextID).Do(ctx)
+	}
+	tabCtx, err := target.Open(ctx, "", target.InContext(bc.BrowserContextID))
+	if err != nil {
+		dispose()
+		return nil, nil, err
+	}
+	var interceptor *fetch.Interceptor
+	closeTab := func() {
+		if interceptor != nil {
+			interceptor.Stop()
+		}
+		if s, ok := devtools.FromContext(tabCtx); ok {
+			target.NewCloseTarget(s.TargetID.Read()).Do(ctx)
+		}
+		devtools.Cancel(tabCtx)
+		dispose()
+	}
+	if interceptor, err = fetch.EnforceURLPolicy(tabCtx, r.policy); err != nil {
+		closeTab()
+		return nil, nil, err
+	}
+	return tabCtx, closeTab, nil
+}
+
+// Render renders the given request, and returns the PDF document or
+// PNG image. It fails if the request's URL is blocked by the renderer's
+// URL policy (with an error which wraps `render.ErrBlockedURL`), if the
+// page fails to load, or if the rendering exceeds the renderer's timeout
+// (see the `render.Timeout` option) or the given context's deadline.
+// Subresources which are blocked by the URL policy fail to load, without
+// failing the rendering.
+func (r *Renderer) Render(ctx context.Context, req *Request) ([]byte, error) {
+	if (req.URL == "") == (req.HTML == "") {
+		return nil, errors.New("render request must have either a URL or HTML")
+	}
+	if req.Format != "" && req.Format != PDF && req.Format != PNG {
+		return nil, fmt.Errorf("unsupported render format: %q", req.Format)
+	}
+	if req.URL != "" {
+		if err := r.policy.Check(ctx, req.URL); err != nil {
+			return nil, err
+		}
+	}
+	r.mu.Lock()
+	closed := r.closed
+	r.mu.Unlock()
+	if closed {
+		return nil, errors.New("renderer is closed")
+	}
+
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	select {
+	case r.slots <- struct{}{}:
+		defer func() { <-r.slots }()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	s, err := r.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer s.close()
+
+	// Commands are sent in the tab's session, but they're bounded by the
+	// request's context.
+	tabCtx, cancelTab := context.WithCancel(s.ctx)
+	defer cancelTab()
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancelTab()
+		case <-tabCtx.Done():
+		}
+	}()
+	b, err := render(tabCtx, req, r.quiet)
+	if err != nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("rendering timed out: %w", ctx.Err())
+	}
+	return b, err
+}
+
+func render(ctx context.Context, req *Request, quiet time.Duration) ([]byte, error) {
+	width, height := req.Width, req.Height
+	if width <= 0 {
+		width = DefaultViewportWidth
+	}
+	if height <= 0 {
+		height = DefaultViewportHeight
+	}
+	if err := emulation.NewSetDeviceMetricsOverride(width, height, 1, false).Do(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.HTML != "" {
+		if err := page.SetContentAndWait(ctx, req.HTML, page.QuietPeriod(quiet)); err != nil {
+			return nil, err
+		}
+	} else {
+		if _, err := page.NavigateChecked(ctx, req.URL); err != nil {
+			return nil, err
+		}
+		if err := page.WaitForLoad(ctx); err != nil {
+			return nil, err
+		}
+		if err := page.WaitForNetworkIdle(ctx, page.QuietPeriod(quiet)); err != nil {
+			return nil, err
+		}
+		if err := page.WaitForFonts(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Format == PNG {
+		scale := req.Scale
+		if scale <= 0 {
+			scale = 1
+		}
+		opts := []emulation.ScreenshotOption{emulation.ScreenshotScale(scale)}
+		if req.FullPage {
+			opts = append(opts, emulation.FullPageScreenshot())
+		}
+		return emulation.CaptureScreenshot(ctx, opts...)
+	}
+	p := page.NewPrintToPDF().SetLandscape(req.Landscape).SetPrintBackground(req.PrintBackground)
+	if req.PaperWidth > 0 {
+		p.SetPaperWidth(req.PaperWidth)
+	}
+	if req.PaperHeight > 0 {
+		p.SetPaperHeight(req.PaperHeight)
+	}
+	result, err := p.Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(result.Data)
+}
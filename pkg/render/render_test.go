@@ -0,0 +1,104 @@
+package render
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/daabr/chrome-vision/pkg/devtools/devtoolstest"
+)
+
+// Render in the given fake browser's tab, instead of opening real ones,
+// and count the number of closed tabs.
+func fakeTabs(ctx context.Context, closed *int32) Option {
+	return func(r *Renderer) {
+		r.open = func(context.Context) (context.Context, func(), error) {
+			return ctx, func() { atomic.AddInt32(closed, 1) }, nil
+		}
+	}
+}
+
+func newFakeBrowser() (context.Context, *devtoolstest.Browser) {
+	ctx, b := devtoolstest.NewContext(context.Background())
+	b.Respond("Page.getFrameTree", map[string]interface{}{"frameTree": map[string]interface{}{"frame": map[string]string{"id": "main"}}})
+	b.Respond("Runtime.evaluate", map[string]interface{}{"result": map[string]string{"type": "string", "value": "complete"}})
+	b.Respond("Page.printToPDF", map[string]string{"data": base64.StdEncoding.EncodeToString([]byte("%PDF-1.4"))})
+	return ctx, b
+}
+
+func TestRender(t *testing.T) {
+	ctx, b := newFakeBrowser()
+	closed := int32(0)
+	r, err := New(ctx, PoolSize(1), QuietPeriod(time.Millisecond), fakeTabs(ctx, &closed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.Render(ctx, &Request{HTML: "<p>Hello</p>", Landscape: true})
+	if err != nil {
+		t.Fatalf("Render(); got error: %v", err)
+	}
+	if string(got) != "%PDF-1.4" {
+		t.Errorf("Render() = %q, want %q", got, "%PDF-1.4")
+	}
+	if len(b.Calls("Page.setDocumentContent")) != 1 || len(b.Calls("Emulation.setDeviceMetricsOverride")) != 1 {
+		t.Errorf("Render() didn't set the viewport and content: %v", b.Calls())
+	}
+
+	r.Close()
+	if n := atomic.LoadInt32(&closed); n != 2 {
+		t.Errorf("closed tabs = %d, want 2 (the used one and the pooled replacement)", n)
+	}
+	if _, err := r.Render(ctx, &Request{HTML: "<p>Hello</p>"}); err == nil {
+		t.Error("Render() after Close(); got nil error")
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	ctx, b := newFakeBrowser()
+	closed := int32(0)
+	r, err := New(ctx, PoolSize(0), QuietPeriod(time.Millisecond), Timeout(50*time.Millisecond), fakeTabs(ctx, &closed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if _, err := r.Render(ctx, &Request{URL: "http://127.0.0.1/"}); !errors.Is(err, ErrBlockedURL) {
+		t.Errorf("Render(private URL) = %v, want %v", err, ErrBlockedURL)
+	}
+	if _, err := r.Render(ctx, &Request{}); err == nil {
+		t.Error("Render(empty request); got nil error")
+	}
+	if _, err := r.Render(ctx, &Request{HTML: "<p>Hello</p>", Format: "gif"}); err == nil {
+		t.Error("Render(unsupported format); got nil error")
+	}
+
+	// A page which never finishes loading.
+	b.Respond("Runtime.evaluate", map[string]interface{}{"result": map[string]string{"type": "string", "value": "loading"}})
+	if _, err := r.Render(ctx, &Request{HTML: "<p>Hello</p>"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Render(slow page) = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func BenchmarkRender(b *testing.B) {
+	ctx, _ := newFakeBrowser()
+	closed := int32(0)
+	r, err := New(ctx, MaxConcurrency(8), QuietPeriod(time.Millisecond), fakeTabs(ctx, &closed))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer r.Close()
+	req := &Request{HTML: "<p>Hello</p>"}
+	b.ResetTimer()
+	start := time.Now()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := r.Render(ctx, req); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+	b.ReportMetric(float64(b.N)/time.Since(start).Seconds(), "renders/s")
+}
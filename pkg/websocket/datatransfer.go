@@ -22,15 +22,23 @@
 package websocket
 
 import (
-	"bytes"
 	"crypto/rand"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"sync"
 )
 
+// ErrMessageTooLarge is returned by `Conn.Read` when an incoming message
+// exceeds the limit which was set with `Conn.SetReadLimit`.
+var ErrMessageTooLarge = errors.New("message exceeds the read limit")
+
+// Number of bytes which `Conn.Read` returns from the beginning of messages
+// which exceed the read limit, e.g. for identifying them.
+const tooLargePrefixSize = 256
+
 // Based on https://datatracker.ietf.org/doc/html/rfc6455#section-11.8.
 type opcode byte
 
@@ -50,6 +58,16 @@ const (
 	// Opcodes 11-15 are reserved for further control frames.
 )
 
+// Pool of buffers for masking the payload of outgoing frames, to reduce
+// GC pressure. Buffers which grew larger than `maxPooledBuffer` are not
+// returned to the pool, so they don't hold onto memory.
+var bufferPool = sync.Pool{New: func() interface{} {
+	b := make([]byte, 0, 4096)
+	return &b
+}}
+
+const maxPooledBuffer = 1 << 20
+
 // Based on https://datatracker.ietf.org/doc/html/rfc6455#section-5.2.
 type frame struct {
 	// Bit 0: Indicates that this is the final fragment in a message.
@@ -88,7 +106,11 @@ type frame struct {
 }
 
 // Based on https://datatracker.ietf.org/doc/html/rfc6455#section-5.2.
-func (c *Conn) readFrame() (f frame, closeConnection bool, err error) {
+// The payload of data frames is appended to the given buffer, and the
+// frame's payload data is the extended buffer. Data frames whose payload
+// exceeds the given limit (if positive) are truncated, and their payload
+// length remains the original one.
+func (c *Conn) readFrame(limit int64, dst []byte) (f frame, closeConnection bool, err error) {
 	// First header byte.
 	b, err := c.rw.ReadByte()
 	if err != nil {
@@ -134,21 +156,33 @@ func (c *Conn) readFrame() (f frame, closeConnection bool, err error) {
 	case b <= 125: // Up to 125 bytes.
 		f.payloadLength = uint64(b)
 	case b == 126: // Up to 64 KiB.
-		extendedLength := make([]byte, 2)
-		_, err = io.ReadFull(c.rw, extendedLength)
-		f.payloadLength = uint64(binary.BigEndian.Uint16(extendedLength))
+		_, err = io.ReadFull(c.rw, c.extendedLength[:2])
+		f.payloadLength = uint64(binary.BigEndian.Uint16(c.extendedLength[:2]))
 	default: // Up to 16 EiB.
-		extendedLength := make([]byte, 8)
-		_, err = io.ReadFull(c.rw, extendedLength)
-		f.payloadLength = binary.BigEndian.Uint64(extendedLength)
+		_, err = io.ReadFull(c.rw, c.extendedLength[:])
+		f.payloadLength = binary.BigEndian.Uint64(c.extendedLength[:])
 	}
 	if err != nil {
 		return f, false, fmt.Errorf("failed to read extended payload length: %v", err)
 	}
+	isControl := f.opcode >= connectionCloseFrame
+	discard := !isControl && limit > 0 && f.payloadLength > uint64(limit)
 
-	// Unmasked payload data (variable length).
-	f.payloadData = make([]byte, f.payloadLength)
-	_, err = io.ReadFull(c.rw, f.payloadData)
+	// Unmasked payload data (variable length). Control frames are
+	// handled immediately, so they don't use the given buffer.
+	if isControl {
+		dst = nil
+	}
+	n := f.payloadLength
+	if discard && n > tooLargePrefixSize {
+		// Keep only the beginning of data frames in oversized messages.
+		n = tooLargePrefixSize
+	}
+	start := len(dst)
+	f.payloadData = grow(dst, int(n))
+	if _, err = io.ReadFull(c.rw, f.payloadData[start:]); err == nil && discard {
+		_, err = io.CopyN(io.Discard, c.rw, int64(f.payloadLength-n))
+	}
 	if err != nil {
 		return f, false, fmt.Errorf("failed to read the payload: %v", err)
 	}
@@ -159,10 +193,19 @@ func (c *Conn) readFrame() (f frame, closeConnection bool, err error) {
 // https://datatracker.ietf.org/doc/html/rfc6455#section-5.4,
 // https://datatracker.ietf.org/doc/html/rfc6455#section-5.5,
 // and https://datatracker.ietf.org/doc/html/rfc6455#section-7.
-func (c *Conn) readMessage() ([]byte, error) {
-	msg := bytes.NewBuffer([]byte{})
+func (c *Conn) readMessage(dst []byte) ([]byte, error) {
+	base := len(dst)
+	msg := dst
+	size, tooLarge := int64(0), false
 	for {
-		f, close, err := c.readFrame()
+		limit := int64(0)
+		if c.readLimit > 0 {
+			limit = c.readLimit - size
+			if tooLarge || limit < 1 {
+				limit = 1 // Truncate all the remaining data frames.
+			}
+		}
+		f, close, err := c.readFrame(limit, msg)
 		if close {
 			log.Printf("Closing WebSocket connection due to protocol error: %v", err)
 			c.Close(1002, []byte{})
@@ -197,29 +240,55 @@ func (c *Conn) readMessage() ([]byte, error) {
 		// Handle data frames. The fragments of one message MUST NOT be interleaved
 		// between the fragments of another message unless an extension has been
 		// negotiated that can interpret the interleaving.
-		if f.fin {
-			// An unfragmented message consists of a single frame with the FIN
-			// bit set (Section 5.2) and an opcode other than 0.
-			if f.opcode != continuationFrame {
-				return f.payloadData, nil
+		prev := len(msg)
+		if f.opcode != continuationFrame {
+			// A single frame with an opcode other than 0 starts a new message
+			// (Section 5.4), so discard any fragments of a previous one.
+			size, tooLarge = 0, false
+			n := copy(f.payloadData[base:], f.payloadData[prev:])
+			f.payloadData, prev = f.payloadData[:base+n], base
+		}
+		msg = f.payloadData
+		size += int64(f.payloadLength)
+		if c.readLimit > 0 && size > c.readLimit {
+			tooLarge = true
+		}
+		if tooLarge {
+			// Keep only the beginning of oversized messages.
+			n := tooLargePrefixSize - (prev - base)
+			if n < 0 {
+				n = 0
+			}
+			if prev+n < len(msg) {
+				msg = msg[:prev+n]
 			}
-			// A fragmented message [...] terminated by a single frame with
-			// the FIN bit set and an opcode of 0.
-			msg.Write(f.payloadData)
-			return msg.Bytes(), nil
+			if f.fin {
+				return msg, ErrMessageTooLarge
+			}
+			continue
 		}
-		if f.opcode != continuationFrame {
-			// A fragmented message consists of a single frame with the
-			// FIN bit clear and an opcode other than 0...
-			msg = bytes.NewBuffer(f.payloadData)
-		} else {
-			// ...Followed by zero or more frames with the FIN bit clear
-			// and the opcode set to 0.
-			msg.Write(f.payloadData)
+		// An unfragmented message consists of a single frame with the FIN
+		// bit set (Section 5.2) and an opcode other than 0. A fragmented
+		// message consists of a single frame with the FIN bit clear and an
+		// opcode other than 0, followed by zero or more frames with the FIN
+		// bit clear and the opcode set to 0, and terminated by a single frame
+		// with the FIN bit set and an opcode of 0.
+		if f.fin {
+			return msg, nil
 		}
 	}
 }
 
+// Return the given buffer, extended by n bytes, reallocating it if needed.
+func grow(b []byte, n int) []byte {
+	if cap(b)-len(b) >= n {
+		return b[:len(b)+n]
+	}
+	g := make([]byte, len(b)+n, 2*cap(b)+n)
+	copy(g, b)
+	return g
+}
+
 // Based on https://datatracker.ietf.org/doc/html/rfc6455#section-5.2
 // and https://datatracker.ietf.org/doc/html/rfc6455#section-6.1.
 func (c *Conn) writeFrame(f frame) error {
@@ -297,11 +366,19 @@ func (c *Conn) writeMessage(o opcode, msg []byte) error {
 	f := frame{fin: true, opcode: o, mask: true, payloadLength: uint64(len(msg))}
 
 	// https://datatracker.ietf.org/doc/html/rfc6455#section-5.3: masking.
-	f.maskingKey = make([]byte, 4)
+	bp := bufferPool.Get().(*[]byte)
+	b := grow((*bp)[:0], 4+len(msg))
+	defer func() {
+		if cap(b) <= maxPooledBuffer {
+			*bp = b
+			bufferPool.Put(bp)
+		}
+	}()
+	f.maskingKey = b[:4]
 	if _, err := io.ReadFull(rand.Reader, f.maskingKey); err != nil {
 		return fmt.Errorf("failed to generate frame masking key: %v", err)
 	}
-	f.payloadData = make([]byte, f.payloadLength)
+	f.payloadData = b[4:]
 	for i := range msg {
 		f.payloadData[i] = msg[i] ^ f.maskingKey[i%4]
 	}
@@ -312,14 +389,37 @@ func (c *Conn) writeMessage(o opcode, msg []byte) error {
 // Read receives a full message from a WebSocket server. It handles all
 // the implementation details internally, such as frame de/fragmentation,
 // masking, and handling control frames.
+//
+// If the message exceeds the limit which was set with `Conn.SetReadLimit`,
+// its payload is discarded while it's being read, and this method returns
+// the beginning of the message with the error `websocket.ErrMessageTooLarge`.
+// The connection remains usable in this case.
 func (c *Conn) Read() ([]byte, error) {
-	b, err := c.readMessage()
+	return c.ReadAppend(nil)
+}
+
+// ReadAppend is like `Conn.Read`, but it appends the message to the given
+// buffer, and returns the extended buffer. This allows callers to reuse
+// buffers (e.g. with a `sync.Pool`), to reduce GC pressure when they
+// receive many messages.
+func (c *Conn) ReadAppend(dst []byte) ([]byte, error) {
+	b, err := c.readMessage(dst)
+	if err == ErrMessageTooLarge {
+		return b, err
+	}
 	if err != nil {
 		err = fmt.Errorf("failed to read message from WebSocket: %v", err)
 	}
 	return b, err
 }
 
+// SetReadLimit sets the maximum size (in bytes) of incoming messages. Larger
+// messages are not stored in memory, see `Conn.Read`. Zero (the default)
+// means that there is no limit.
+func (c *Conn) SetReadLimit(n int64) {
+	c.readLimit = n
+}
+
 // WriteText sends a full UTF-8 text message to a WebSocket server. It handles
 // all the implementation details internally, such as frame de/fragmentation,
 // masking, and handling control frames.
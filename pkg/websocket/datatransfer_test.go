@@ -380,3 +380,118 @@ func TestClose(t *testing.T) {
 		t.Errorf("server.Read(b); b[1] = %b, want %b", b[1], 0x88)
 	}
 }
+
+func TestReadLimit(t *testing.T) {
+	server, client := net.Pipe()
+	conn := websocket.NewConn(client)
+	conn.SetReadLimit(4)
+	defer server.Close()
+	defer client.Close()
+
+	go func() {
+		b := []byte{0x01, 0x01, 0xaa, 0x00, 0x02, 0xbb, 0xcc, 0x80, 0x03, 0xdd, 0xee, 0xff, 0x81, 0x01, 0x11}
+		server.Write(b)
+	}()
+
+	got, err := conn.Read()
+	if err != websocket.ErrMessageTooLarge {
+		t.Fatalf("Conn.Read() error = %v, want %v", err, websocket.ErrMessageTooLarge)
+	}
+	want := []byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Conn.Read() = %#v, want %#v", got, want)
+	}
+
+	got, err = conn.Read()
+	want = []byte{0x11}
+	if err != nil {
+		t.Fatalf("Conn.Read(); got unexpected error: %v", err)
+	}
+	if !cmp.Equal(got, want) {
+		t.Errorf("Conn.Read() = %#v, want %#v", got, want)
+	}
+}
+
+func TestReadAppend(t *testing.T) {
+	server, client := net.Pipe()
+	conn := websocket.NewConn(client)
+	defer server.Close()
+	defer client.Close()
+
+	go func() {
+		// Fragmented message, with a ping control frame in the middle.
+		server.Write([]byte{0x01, 0x02, 'h', 'e', 0x89, 0x00, 0x80, 0x03, 'l', 'l', 'o'})
+		server.Read(make([]byte, 8)) // Pong.
+	}()
+
+	dst := append(make([]byte, 0, 64), "say "...)
+	got, err := conn.ReadAppend(dst)
+	if err != nil {
+		t.Fatalf("Conn.ReadAppend(); got unexpected error: %v", err)
+	}
+	if string(got) != "say hello" {
+		t.Errorf("Conn.ReadAppend() = %q, want %q", got, "say hello")
+	}
+	if &got[0] != &dst[0] {
+		t.Error("Conn.ReadAppend() reallocated a buffer with enough capacity")
+	}
+}
+
+// A connection which serves the same bytes endlessly, and discards writes.
+type loopConn struct {
+	net.Conn
+	b   []byte
+	off int
+}
+
+func (c *loopConn) Read(b []byte) (int, error) {
+	n := copy(b, c.b[c.off:])
+	c.off = (c.off + n) % len(c.b)
+	return n, nil
+}
+
+func (c *loopConn) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+// A 1 KB text frame.
+func textFrame() []byte {
+	b := []byte{0x81, 0x7e, 0x04, 0x00}
+	return append(b, make([]byte, 1024)...)
+}
+
+func BenchmarkRead(b *testing.B) {
+	conn := websocket.NewConn(&loopConn{b: textFrame()})
+	b.ReportAllocs()
+	b.SetBytes(1024)
+	for i := 0; i < b.N; i++ {
+		if _, err := conn.Read(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadAppend(b *testing.B) {
+	conn := websocket.NewConn(&loopConn{b: textFrame()})
+	b.ReportAllocs()
+	b.SetBytes(1024)
+	var msg []byte
+	for i := 0; i < b.N; i++ {
+		var err error
+		if msg, err = conn.ReadAppend(msg[:0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWriteText(b *testing.B) {
+	conn := websocket.NewConn(&loopConn{b: []byte{0}})
+	msg := make([]byte, 1024)
+	b.ReportAllocs()
+	b.SetBytes(1024)
+	for i := 0; i < b.N; i++ {
+		if err := conn.WriteText(msg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -24,6 +24,11 @@ const (
 type Conn struct {
 	nc net.Conn
 	rw *bufio.ReadWriter
+
+	// Maximum size of incoming messages, see `Conn.SetReadLimit`.
+	readLimit int64
+	// Scratch space for reading the extended payload length of frames.
+	extendedLength [8]byte
 }
 
 // NewConn initializes a WebSocket connection based on an open TCP connection.